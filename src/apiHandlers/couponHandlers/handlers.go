@@ -0,0 +1,348 @@
+package couponHandlers
+
+import (
+	"ExamSphere/src/apiHandlers"
+	"ExamSphere/src/core/utils/logging"
+	"ExamSphere/src/core/utils/paymentUtils"
+	"ExamSphere/src/database"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// CreateCouponV1 godoc
+// @Summary Create a discount coupon
+// @Description Allows the user to create a percentage or fixed discount coupon for an exam, a course or a topic. Teachers can only create coupons for their own exams and courses.
+// @ID createCouponV1
+// @Tags Coupon
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param data body CreateCouponData true "Data needed to create a coupon"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=CouponInfo}
+// @Router /api/v1/coupon/create [post]
+func CreateCouponV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	} else if !userInfo.CanTryToCreateExamCoupon() {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	data := &CreateCouponData{}
+	if err := c.BodyParser(data); err != nil {
+		return apiHandlers.SendErrInvalidBodyData(c)
+	}
+
+	data.CouponCode = strings.ToUpper(strings.TrimSpace(data.CouponCode))
+	if data.CouponCode == "" {
+		return apiHandlers.SendErrParameterRequired(c, "coupon_code")
+	} else if data.ScopeId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "scope_id")
+	} else if !isCouponCodeValid(data.CouponCode) {
+		return apiHandlers.SendErrInvalidCouponCode(c, data.CouponCode)
+	} else if (data.MaxUses != nil && *data.MaxUses <= 0) ||
+		(data.MaxUsesPerUser != nil && *data.MaxUsesPerUser <= 0) {
+		return apiHandlers.SendErrInvalidBodyData(c)
+	}
+
+	var discountValue int64
+	switch data.DiscountType {
+	case database.DiscountTypePercentage:
+		if data.Percentage <= 0 || data.Percentage > 100 {
+			return apiHandlers.SendErrInvalidDiscount(c, "percentage has to be between 1 and 100")
+		}
+		discountValue = int64(data.Percentage)
+	case database.DiscountTypeFixed:
+		amount, currency, err := paymentUtils.ParsePrice(data.Amount)
+		if err != nil || amount <= 0 || currency != paymentUtils.DefaultCurrency {
+			return apiHandlers.SendErrInvalidDiscount(c, "invalid amount")
+		}
+		discountValue = amount
+	default:
+		return apiHandlers.SendErrInvalidDiscount(c, "discount_type has to be either percentage or fixed")
+	}
+
+	if data.ScopeType != database.CouponScopeExam &&
+		data.ScopeType != database.CouponScopeCourse &&
+		data.ScopeType != database.CouponScopeTopic {
+		return apiHandlers.SendErrInvalidBodyData(c)
+	}
+
+	scopeOwner, found, err := getScopeOwner(data.ScopeType, data.ScopeId)
+	if err != nil {
+		logging.UnexpectedError("CreateCoupon: Failed to get the target of the scope:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	} else if !found {
+		return sendErrScopeNotFound(c, data.ScopeType)
+	}
+
+	if !userInfo.CanCreateExamCoupon(data.ScopeType, scopeOwner) {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	var expiresAt *time.Time
+	if data.ExpiresAt != nil {
+		expiryTime := time.Unix(*data.ExpiresAt, 0)
+		if !expiryTime.After(time.Now()) {
+			return apiHandlers.SendErrInvalidBodyData(c)
+		}
+		expiresAt = &expiryTime
+	}
+
+	_, err = database.GetExamCouponByCode(data.CouponCode)
+	if err == nil {
+		return apiHandlers.SendErrCouponCodeExists(c)
+	} else if err != database.ErrExamCouponNotFound {
+		logging.UnexpectedError("CreateCoupon: Failed to get exam coupon:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	coupon, err := database.CreateExamCoupon(&database.NewExamCouponData{
+		CouponCode:     data.CouponCode,
+		DiscountType:   data.DiscountType,
+		DiscountValue:  discountValue,
+		ScopeType:      data.ScopeType,
+		ScopeId:        data.ScopeId,
+		MaxUses:        data.MaxUses,
+		MaxUsesPerUser: data.MaxUsesPerUser,
+		ExpiresAt:      expiresAt,
+		CreatedBy:      userInfo.UserId,
+	})
+	if err != nil {
+		logging.UnexpectedError("CreateCoupon: Failed to create exam coupon:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	return apiHandlers.SendResult(c, toCouponInfo(coupon))
+}
+
+// GetCouponInfoV1 godoc
+// @Summary Get the details of a coupon
+// @Description Allows the creator of a coupon (and admins) to get its details, including how many times it has been used.
+// @ID getCouponInfoV1
+// @Tags Coupon
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param code query string true "Coupon code"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=CouponInfo}
+// @Router /api/v1/coupon/info [get]
+func GetCouponInfoV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	}
+
+	couponCode := c.Query("code")
+	if couponCode == "" {
+		return apiHandlers.SendErrParameterRequired(c, "code")
+	}
+
+	coupon, err := database.GetExamCouponByCode(couponCode)
+	if err == database.ErrExamCouponNotFound {
+		return apiHandlers.SendErrExamCouponNotFound(c)
+	} else if err != nil {
+		logging.UnexpectedError("GetCouponInfo: Failed to get exam coupon:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	if !userInfo.CanEditExamCoupon(coupon) {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	return apiHandlers.SendResult(c, toCouponInfo(coupon))
+}
+
+// GetCouponsV1 godoc
+// @Summary Get the coupons
+// @Description Allows the user to get the coupons they have created (admins get all of the coupons), most recent ones first.
+// @ID getCouponsV1
+// @Tags Coupon
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param offset query int false "Offset"
+// @Param limit query int false "Limit"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=GetCouponsResult}
+// @Router /api/v1/coupon/list [get]
+func GetCouponsV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	} else if !userInfo.CanTryToCreateExamCoupon() {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	offset := c.QueryInt("offset")
+	limit := c.QueryInt("limit", database.DefaultPaginationLimit)
+	if offset < 0 || limit < 0 {
+		return apiHandlers.SendErrInvalidPagination(c)
+	}
+
+	createdBy := userInfo.UserId
+	if userInfo.IsAdminOrOwner() {
+		createdBy = ""
+	}
+
+	coupons, err := database.GetExamCoupons(&database.GetExamCouponsData{
+		CreatedBy: createdBy,
+		Offset:    offset,
+		Limit:     limit,
+	})
+	if err != nil {
+		logging.UnexpectedError("GetCoupons: Failed to get exam coupons:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	couponsInfo := make([]*CouponInfo, 0, len(coupons))
+	for _, coupon := range coupons {
+		couponsInfo = append(couponsInfo, toCouponInfo(coupon))
+	}
+
+	return apiHandlers.SendResult(c, &GetCouponsResult{
+		Coupons: couponsInfo,
+	})
+}
+
+// DeactivateCouponV1 godoc
+// @Summary Deactivate a coupon
+// @Description Allows the creator of a coupon (and admins) to make it unusable from now on.
+// @ID deactivateCouponV1
+// @Tags Coupon
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param data body DeactivateCouponData true "Data needed to deactivate a coupon"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=CouponInfo}
+// @Router /api/v1/coupon/deactivate [post]
+func DeactivateCouponV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	}
+
+	data := &DeactivateCouponData{}
+	if err := c.BodyParser(data); err != nil {
+		return apiHandlers.SendErrInvalidBodyData(c)
+	}
+
+	if data.CouponCode == "" {
+		return apiHandlers.SendErrParameterRequired(c, "coupon_code")
+	}
+
+	coupon, err := database.GetExamCouponByCode(data.CouponCode)
+	if err == database.ErrExamCouponNotFound {
+		return apiHandlers.SendErrExamCouponNotFound(c)
+	} else if err != nil {
+		logging.UnexpectedError("DeactivateCoupon: Failed to get exam coupon:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	if !userInfo.CanEditExamCoupon(coupon) {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	err = database.DeactivateExamCoupon(coupon.CouponId)
+	if err != nil {
+		logging.UnexpectedError("DeactivateCoupon: Failed to deactivate exam coupon:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	coupon.IsActive = false
+	return apiHandlers.SendResult(c, toCouponInfo(coupon))
+}
+
+// CheckCouponV1 godoc
+// @Summary Check a coupon for an exam
+// @Description Allows the user to check whether they can use a coupon for an exam, and the price they would pay with it.
+// @ID checkCouponV1
+// @Tags Coupon
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param data body CheckCouponData true "Data needed to check a coupon"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=CheckCouponResult}
+// @Router /api/v1/coupon/check [post]
+func CheckCouponV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	}
+
+	data := &CheckCouponData{}
+	if err := c.BodyParser(data); err != nil {
+		return apiHandlers.SendErrInvalidBodyData(c)
+	}
+
+	if data.ExamId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "exam_id")
+	} else if data.CouponCode == "" {
+		return apiHandlers.SendErrParameterRequired(c, "coupon_code")
+	}
+
+	examInfo := database.GetExamInfoOrNil(data.ExamId)
+	if examInfo == nil {
+		return apiHandlers.SendErrExamNotFound(c)
+	}
+
+	coupon, err := database.GetUsableExamCoupon(data.CouponCode, userInfo.UserId, data.ExamId)
+	if err == database.ErrExamCouponNotFound {
+		return apiHandlers.SendErrExamCouponNotFound(c)
+	} else if err == database.ErrExamCouponNotUsable {
+		return apiHandlers.SendErrExamCouponNotUsable(c)
+	} else if err != nil {
+		logging.UnexpectedError("CheckCoupon: Failed to get exam coupon:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	price, discount, err := examInfo.GetPriceWithCoupon(coupon)
+	if err != nil {
+		logging.UnexpectedError("CheckCoupon: Failed to apply exam coupon:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	return apiHandlers.SendResult(c, &CheckCouponResult{
+		ExamId:        examInfo.ExamId,
+		CouponCode:    coupon.CouponCode,
+		OriginalPrice: examInfo.Price,
+		Discount:      paymentUtils.FormatPrice(discount, paymentUtils.DefaultCurrency),
+		Price:         price,
+	})
+}
@@ -0,0 +1,97 @@
+package couponHandlers
+
+import (
+	"ExamSphere/src/apiHandlers"
+	"ExamSphere/src/core/utils/paymentUtils"
+	"ExamSphere/src/database"
+	"strconv"
+
+	"github.com/ALiwoto/ssg/ssg"
+	"github.com/gofiber/fiber/v2"
+)
+
+func toCouponInfo(coupon *database.ExamCoupon) *CouponInfo {
+	discount := paymentUtils.FormatPrice(coupon.DiscountValue, paymentUtils.DefaultCurrency)
+	if coupon.DiscountType == database.DiscountTypePercentage {
+		discount = strconv.FormatInt(coupon.DiscountValue, 10) + "%"
+	}
+
+	return &CouponInfo{
+		CouponId:       coupon.CouponId,
+		CouponCode:     coupon.CouponCode,
+		DiscountType:   coupon.DiscountType,
+		Discount:       discount,
+		ScopeType:      coupon.ScopeType,
+		ScopeId:        coupon.ScopeId,
+		MaxUses:        ssg.Clone(coupon.MaxUses),
+		MaxUsesPerUser: ssg.Clone(coupon.MaxUsesPerUser),
+		UsesCount:      coupon.UsesCount,
+		ExpiresAt:      ssg.Clone(coupon.ExpiresAt),
+		IsActive:       coupon.IsActive,
+		CreatedBy:      coupon.CreatedBy,
+		CreatedAt:      coupon.CreatedAt,
+	}
+}
+
+// isCouponCodeValid returns true if the code only contains letters, digits,
+// '_' and '-', and its length is within the limits.
+func isCouponCodeValid(code string) bool {
+	if len(code) < database.MinCouponCodeLength ||
+		len(code) > database.MaxCouponCodeLength {
+		return false
+	}
+
+	for _, r := range code {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') &&
+			(r < '0' || r > '9') && r != '_' && r != '-' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// getScopeOwner returns the creator of the exam or course the coupon is
+// going to be scoped to; topics don't have an owner. The returned bool is
+// false if the target of the scope doesn't exist.
+func getScopeOwner(scopeType string, scopeId int) (string, bool, error) {
+	switch scopeType {
+	case database.CouponScopeExam:
+		examInfo := database.GetExamInfoOrNil(scopeId)
+		if examInfo == nil {
+			return "", false, nil
+		}
+		return examInfo.CreatedBy, true, nil
+	case database.CouponScopeCourse:
+		courseInfo, err := database.GetCourseInfo(scopeId)
+		if err == database.ErrCourseNotFound {
+			return "", false, nil
+		} else if err != nil {
+			return "", false, err
+		}
+		return courseInfo.AddedBy, courseInfo != nil, nil
+	case database.CouponScopeTopic:
+		topicInfo, err := database.GetTopicInfo(scopeId)
+		if err == database.ErrTopicNotFound {
+			return "", false, nil
+		} else if err != nil {
+			return "", false, err
+		}
+		return "", topicInfo != nil, nil
+	}
+
+	return "", false, nil
+}
+
+// sendErrScopeNotFound sends the not found error of the target of
+// the scope.
+func sendErrScopeNotFound(c *fiber.Ctx, scopeType string) error {
+	switch scopeType {
+	case database.CouponScopeCourse:
+		return apiHandlers.SendErrCourseNotFound(c)
+	case database.CouponScopeTopic:
+		return apiHandlers.SendErrTopicNotFound(c)
+	}
+
+	return apiHandlers.SendErrExamNotFound(c)
+}
@@ -0,0 +1,76 @@
+package couponHandlers
+
+import "time"
+
+type CreateCouponData struct {
+	// CouponCode is the code entered by the users; it's case-insensitive
+	// and may only contain letters, digits, '_' and '-'.
+	CouponCode string `json:"coupon_code"`
+
+	// DiscountType is either "percentage" or "fixed".
+	DiscountType string `json:"discount_type"`
+
+	// Percentage is the discount percentage (1-100), only used for the
+	// percentage coupons.
+	Percentage int `json:"percentage"`
+
+	// Amount is the fixed discount (e.g. "20T"), only used for the
+	// fixed coupons.
+	Amount string `json:"amount"`
+
+	// ScopeType is one of "exam", "course" or "topic"; ScopeId is the id
+	// of the exam, course or topic the coupon can be used for.
+	ScopeType string `json:"scope_type"`
+	ScopeId   int    `json:"scope_id"`
+
+	// MaxUses and MaxUsesPerUser are the usage limits of the coupon;
+	// null means unlimited.
+	MaxUses        *int `json:"max_uses"`
+	MaxUsesPerUser *int `json:"max_uses_per_user"`
+
+	// ExpiresAt is the unix timestamp after which the coupon cannot be
+	// used anymore.
+	ExpiresAt *int64 `json:"expires_at"`
+} // @name CreateCouponData
+
+type CouponInfo struct {
+	CouponId     int    `json:"coupon_id"`
+	CouponCode   string `json:"coupon_code"`
+	DiscountType string `json:"discount_type"`
+
+	// Discount is either a percentage (e.g. "20%") or an amount
+	// (e.g. "20T").
+	Discount       string     `json:"discount"`
+	ScopeType      string     `json:"scope_type"`
+	ScopeId        int        `json:"scope_id"`
+	MaxUses        *int       `json:"max_uses"`
+	MaxUsesPerUser *int       `json:"max_uses_per_user"`
+	UsesCount      int        `json:"uses_count"`
+	ExpiresAt      *time.Time `json:"expires_at"`
+	IsActive       bool       `json:"is_active"`
+	CreatedBy      string     `json:"created_by"`
+	CreatedAt      time.Time  `json:"created_at"`
+} // @name CouponInfo
+
+type GetCouponsResult struct {
+	Coupons []*CouponInfo `json:"coupons"`
+} // @name GetCouponsResult
+
+type DeactivateCouponData struct {
+	CouponCode string `json:"coupon_code"`
+} // @name DeactivateCouponData
+
+type CheckCouponData struct {
+	ExamId     int    `json:"exam_id"`
+	CouponCode string `json:"coupon_code"`
+} // @name CheckCouponData
+
+type CheckCouponResult struct {
+	ExamId        int    `json:"exam_id"`
+	CouponCode    string `json:"coupon_code"`
+	OriginalPrice string `json:"original_price"`
+	Discount      string `json:"discount"`
+
+	// Price is the price the user has to pay when using the coupon.
+	Price string `json:"price"`
+} // @name CheckCouponResult
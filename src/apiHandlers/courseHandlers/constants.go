@@ -1 +1,15 @@
 package courseHandlers
+
+const (
+	// the keys of the columns of the gradebook exports
+	gradebookColumnUserId         = "user_id"
+	gradebookColumnFullName       = "full_name"
+	gradebookColumnExamScores     = "exam_scores"
+	gradebookColumnCategoryGrades = "category_grades"
+	gradebookColumnCourseGrade    = "course_grade"
+
+	// the prefixes of the keys of the expanded columns of the gradebook
+	// exports, followed by the id of the exam or the category
+	gradebookColumnExamPrefix     = "exam_"
+	gradebookColumnCategoryPrefix = "category_"
+)
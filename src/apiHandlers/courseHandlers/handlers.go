@@ -2,8 +2,12 @@ package courseHandlers
 
 import (
 	"ExamSphere/src/apiHandlers"
+	"ExamSphere/src/core/utils/exportUtils"
 	"ExamSphere/src/core/utils/logging"
 	"ExamSphere/src/database"
+	"bufio"
+	"strconv"
+	"strings"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -394,3 +398,572 @@ func GetCourseParticipantsV1(c *fiber.Ctx) error {
 		Participants: participantsInfo,
 	})
 }
+
+// CreateGradeCategoryV1 godoc
+// @Summary Create a grade category in a course
+// @Description Allows a user to add a weighted grade category (e.g. quizzes 20%) to the gradebook of a course, optionally dropping the lowest scores of its exams.
+// @ID createGradeCategoryV1
+// @Tags Course
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param data body CreateGradeCategoryData true "Data needed to create a grade category"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=GradeCategoryInfo}
+// @Router /api/v1/course/createGradeCategory [post]
+func CreateGradeCategoryV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	}
+
+	data := &CreateGradeCategoryData{}
+	if err := c.BodyParser(data); err != nil {
+		return apiHandlers.SendErrInvalidBodyData(c)
+	}
+
+	data.CategoryName = strings.TrimSpace(data.CategoryName)
+	if data.CourseId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "course_id")
+	} else if data.CategoryName == "" {
+		return apiHandlers.SendErrParameterRequired(c, "category_name")
+	} else if len(data.CategoryName) > database.MaxGradeCategoryNameLength {
+		return apiHandlers.SendErrGradeCategoryNameTooLong(c, database.MaxGradeCategoryNameLength)
+	} else if data.Weight < 0 || data.Weight > database.MaxGradeCategoryWeight {
+		return apiHandlers.SendErrInvalidGradeCategoryWeight(c)
+	} else if data.DropLowest < 0 {
+		return apiHandlers.SendErrInvalidDropLowestCount(c)
+	}
+
+	courseInfo, err := database.GetCourseInfo(data.CourseId)
+	if err != nil {
+		if err == database.ErrCourseNotFound {
+			return apiHandlers.SendErrCourseNotFound(c)
+		}
+
+		logging.UnexpectedError("CreateGradeCategoryV1: failed to query database.GetCourseInfo: ", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	} else if !userInfo.CanManageCourseGradebook(courseInfo) {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	categories, err := database.GetCourseGradeCategories(data.CourseId)
+	if err != nil {
+		logging.UnexpectedError("CreateGradeCategoryV1: failed to query database.GetCourseGradeCategories: ", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	} else if len(categories) >= database.MaxCourseGradeCategoriesCount {
+		return apiHandlers.SendErrTooManyGradeCategories(c)
+	}
+
+	for _, category := range categories {
+		if strings.EqualFold(category.CategoryName, data.CategoryName) {
+			return apiHandlers.SendErrGradeCategoryAlreadyExists(c)
+		}
+	}
+
+	category, err := database.CreateCourseGradeCategory(&database.NewCourseGradeCategoryData{
+		CourseId:     data.CourseId,
+		CategoryName: data.CategoryName,
+		Weight:       data.Weight,
+		DropLowest:   data.DropLowest,
+		AddedBy:      userInfo.UserId,
+	})
+	if err != nil {
+		logging.UnexpectedError("CreateGradeCategoryV1: failed to query database.CreateCourseGradeCategory: ", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	return apiHandlers.SendResult(c, toGradeCategoryInfo(category))
+}
+
+// EditGradeCategoryV1 godoc
+// @Summary Edit a grade category of a course
+// @Description Allows a user to edit the name, the weight and the number of the dropped scores of a grade category.
+// @ID editGradeCategoryV1
+// @Tags Course
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param data body EditGradeCategoryData true "Data needed to edit a grade category"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=GradeCategoryInfo}
+// @Router /api/v1/course/editGradeCategory [post]
+func EditGradeCategoryV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	}
+
+	data := &EditGradeCategoryData{}
+	if err := c.BodyParser(data); err != nil {
+		return apiHandlers.SendErrInvalidBodyData(c)
+	}
+
+	data.CategoryName = strings.TrimSpace(data.CategoryName)
+	if data.CategoryId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "category_id")
+	} else if data.CategoryName == "" {
+		return apiHandlers.SendErrParameterRequired(c, "category_name")
+	} else if len(data.CategoryName) > database.MaxGradeCategoryNameLength {
+		return apiHandlers.SendErrGradeCategoryNameTooLong(c, database.MaxGradeCategoryNameLength)
+	} else if data.Weight < 0 || data.Weight > database.MaxGradeCategoryWeight {
+		return apiHandlers.SendErrInvalidGradeCategoryWeight(c)
+	} else if data.DropLowest < 0 {
+		return apiHandlers.SendErrInvalidDropLowestCount(c)
+	}
+
+	category, err := database.GetCourseGradeCategory(data.CategoryId)
+	if err != nil {
+		if err == database.ErrGradeCategoryNotFound {
+			return apiHandlers.SendErrGradeCategoryNotFound(c)
+		}
+
+		logging.UnexpectedError("EditGradeCategoryV1: failed to query database.GetCourseGradeCategory: ", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	courseInfo, err := database.GetCourseInfo(category.CourseId)
+	if err != nil {
+		logging.UnexpectedError("EditGradeCategoryV1: failed to query database.GetCourseInfo: ", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	} else if !userInfo.CanManageCourseGradebook(courseInfo) {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	categories, err := database.GetCourseGradeCategories(category.CourseId)
+	if err != nil {
+		logging.UnexpectedError("EditGradeCategoryV1: failed to query database.GetCourseGradeCategories: ", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	for _, current := range categories {
+		if current.CategoryId != category.CategoryId &&
+			strings.EqualFold(current.CategoryName, data.CategoryName) {
+			return apiHandlers.SendErrGradeCategoryAlreadyExists(c)
+		}
+	}
+
+	category, err = database.EditCourseGradeCategory(&database.EditCourseGradeCategoryData{
+		CategoryId:   data.CategoryId,
+		CategoryName: data.CategoryName,
+		Weight:       data.Weight,
+		DropLowest:   data.DropLowest,
+	})
+	if err != nil {
+		logging.UnexpectedError("EditGradeCategoryV1: failed to query database.EditCourseGradeCategory: ", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	return apiHandlers.SendResult(c, toGradeCategoryInfo(category))
+}
+
+// DeleteGradeCategoryV1 godoc
+// @Summary Delete a grade category of a course
+// @Description Allows a user to delete a grade category of a course; its exams are not counted in the course grade anymore.
+// @ID deleteGradeCategoryV1
+// @Tags Course
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param data body DeleteGradeCategoryData true "Data needed to delete a grade category"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=DeleteGradeCategoryResult}
+// @Router /api/v1/course/deleteGradeCategory [post]
+func DeleteGradeCategoryV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	}
+
+	data := &DeleteGradeCategoryData{}
+	if err := c.BodyParser(data); err != nil {
+		return apiHandlers.SendErrInvalidBodyData(c)
+	}
+
+	if data.CategoryId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "category_id")
+	}
+
+	category, err := database.GetCourseGradeCategory(data.CategoryId)
+	if err != nil {
+		if err == database.ErrGradeCategoryNotFound {
+			return apiHandlers.SendErrGradeCategoryNotFound(c)
+		}
+
+		logging.UnexpectedError("DeleteGradeCategoryV1: failed to query database.GetCourseGradeCategory: ", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	courseInfo, err := database.GetCourseInfo(category.CourseId)
+	if err != nil {
+		logging.UnexpectedError("DeleteGradeCategoryV1: failed to query database.GetCourseInfo: ", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	} else if !userInfo.CanManageCourseGradebook(courseInfo) {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	err = database.DeleteCourseGradeCategory(category.CategoryId)
+	if err != nil {
+		logging.UnexpectedError("DeleteGradeCategoryV1: failed to query database.DeleteCourseGradeCategory: ", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	return apiHandlers.SendResult(c, &DeleteGradeCategoryResult{
+		CategoryId: category.CategoryId,
+		CourseId:   category.CourseId,
+	})
+}
+
+// GetGradeCategoriesV1 godoc
+// @Summary Get grade categories of a course
+// @Description Allows a user to get the weighted grade categories of a course.
+// @ID getGradeCategoriesV1
+// @Tags Course
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param id query int true "Course ID"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=GetGradeCategoriesResult}
+// @Router /api/v1/course/gradeCategories [get]
+func GetGradeCategoriesV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	}
+
+	courseId := c.QueryInt("id")
+	if courseId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "id")
+	}
+
+	courseInfo, err := database.GetCourseInfo(courseId)
+	if err != nil {
+		if err == database.ErrCourseNotFound {
+			return apiHandlers.SendErrCourseNotFound(c)
+		}
+
+		logging.UnexpectedError("GetGradeCategoriesV1: failed to query database.GetCourseInfo: ", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	categories, err := database.GetCourseGradeCategories(courseInfo.CourseId)
+	if err != nil {
+		logging.UnexpectedError("GetGradeCategoriesV1: failed to query database.GetCourseGradeCategories: ", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	result := &GetGradeCategoriesResult{
+		CourseId:   courseInfo.CourseId,
+		Categories: make([]*GradeCategoryInfo, 0, len(categories)),
+	}
+	for _, category := range categories {
+		result.TotalWeight += category.Weight
+		result.Categories = append(result.Categories, toGradeCategoryInfo(category))
+	}
+
+	return apiHandlers.SendResult(c, result)
+}
+
+// GetCourseGradebookV1 godoc
+// @Summary Get the gradebook of a course
+// @Description Allows a user to get the gradebook of a course: the scores of every participant in every graded exam of the course, their grades in the categories and their computed course grade.
+// @ID getCourseGradebookV1
+// @Tags Course
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param id query int true "Course ID"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=GetCourseGradebookResult}
+// @Router /api/v1/course/gradebook [get]
+func GetCourseGradebookV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	}
+
+	courseId := c.QueryInt("id")
+	if courseId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "id")
+	}
+
+	courseInfo, err := database.GetCourseInfo(courseId)
+	if err != nil {
+		if err == database.ErrCourseNotFound {
+			return apiHandlers.SendErrCourseNotFound(c)
+		}
+
+		logging.UnexpectedError("GetCourseGradebookV1: failed to query database.GetCourseInfo: ", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	exams, err := database.GetCourseGradedExams(courseInfo.CourseId)
+	if err != nil {
+		logging.UnexpectedError("GetCourseGradebookV1: failed to query database.GetCourseGradedExams: ", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	} else if !userInfo.CanViewCourseGradebook(courseInfo, exams) {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	categories, err := database.GetCourseGradeCategories(courseInfo.CourseId)
+	if err != nil {
+		logging.UnexpectedError("GetCourseGradebookV1: failed to query database.GetCourseGradeCategories: ", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	participants, err := database.GetAllParticipantsOfCourse(courseInfo.CourseId)
+	if err != nil {
+		logging.UnexpectedError("GetCourseGradebookV1: failed to query database.GetAllParticipantsOfCourse: ", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	scores, err := database.GetCourseGradebookScores(courseInfo.CourseId, "")
+	if err != nil {
+		logging.UnexpectedError("GetCourseGradebookV1: failed to query database.GetCourseGradebookScores: ", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	userScores := make(map[string]map[int]*database.CourseGradebookScore)
+	for _, score := range scores {
+		if userScores[score.UserId] == nil {
+			userScores[score.UserId] = make(map[int]*database.CourseGradebookScore)
+		}
+		userScores[score.UserId][score.ExamId] = score
+	}
+
+	result := &GetCourseGradebookResult{
+		CourseId:   courseInfo.CourseId,
+		CourseName: courseInfo.CourseName,
+		Categories: make([]*GradeCategoryInfo, 0, len(categories)),
+		Exams:      make([]*GradebookExamInfo, 0, len(exams)),
+		Rows:       make([]*GradebookRowInfo, 0, len(participants)),
+	}
+	for _, category := range categories {
+		result.Categories = append(result.Categories, toGradeCategoryInfo(category))
+	}
+	for _, examInfo := range exams {
+		result.Exams = append(result.Exams, toGradebookExamInfo(examInfo))
+	}
+	for _, participant := range participants {
+		result.Rows = append(result.Rows, computeGradebookRow(
+			participant.UserId,
+			participant.FullName,
+			categories,
+			exams,
+			userScores[participant.UserId],
+		))
+	}
+
+	return apiHandlers.SendResult(c, result)
+}
+
+// GetMyCourseGradesV1 godoc
+// @Summary Get own grades in a course
+// @Description Allows a user to get their own row of the gradebook of a course: their scores in the graded exams of the course, their grades in the categories and their computed course grade.
+// @ID getMyCourseGradesV1
+// @Tags Course
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param id query int true "Course ID"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=GetMyCourseGradesResult}
+// @Router /api/v1/course/myGrades [get]
+func GetMyCourseGradesV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	}
+
+	courseId := c.QueryInt("id")
+	if courseId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "id")
+	}
+
+	courseInfo, err := database.GetCourseInfo(courseId)
+	if err != nil {
+		if err == database.ErrCourseNotFound {
+			return apiHandlers.SendErrCourseNotFound(c)
+		}
+
+		logging.UnexpectedError("GetMyCourseGradesV1: failed to query database.GetCourseInfo: ", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	exams, err := database.GetCourseGradedExams(courseInfo.CourseId)
+	if err != nil {
+		logging.UnexpectedError("GetMyCourseGradesV1: failed to query database.GetCourseGradedExams: ", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	categories, err := database.GetCourseGradeCategories(courseInfo.CourseId)
+	if err != nil {
+		logging.UnexpectedError("GetMyCourseGradesV1: failed to query database.GetCourseGradeCategories: ", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	scores, err := database.GetCourseGradebookScores(courseInfo.CourseId, userInfo.UserId)
+	if err != nil {
+		logging.UnexpectedError("GetMyCourseGradesV1: failed to query database.GetCourseGradebookScores: ", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	scoresMap := make(map[int]*database.CourseGradebookScore, len(scores))
+	for _, score := range scores {
+		scoresMap[score.ExamId] = score
+	}
+
+	result := &GetMyCourseGradesResult{
+		CourseId:   courseInfo.CourseId,
+		CourseName: courseInfo.CourseName,
+		Categories: make([]*GradeCategoryInfo, 0, len(categories)),
+		Exams:      make([]*GradebookExamInfo, 0, len(exams)),
+		Row: computeGradebookRow(
+			userInfo.UserId,
+			userInfo.FullName,
+			categories,
+			exams,
+			scoresMap,
+		),
+	}
+	for _, category := range categories {
+		result.Categories = append(result.Categories, toGradeCategoryInfo(category))
+	}
+	for _, examInfo := range exams {
+		result.Exams = append(result.Exams, toGradebookExamInfo(examInfo))
+	}
+
+	return apiHandlers.SendResult(c, result)
+}
+
+// ExportCourseGradebookV1 godoc
+// @Summary Export the gradebook of a course
+// @Description Allows the users who can see the gradebook of a course to download it as a csv or xlsx file. Teachers who do not manage the gradebook only get the scores of the exams they have created or grade as collaborators, without the category and course grades. The file is streamed, so courses of any size can be exported.
+// @ID exportCourseGradebookV1
+// @Tags Course
+// @Produce octet-stream
+// @Param Authorization header string true "Authorization token"
+// @Param id query int true "Course ID"
+// @Param format query string false "Format of the file (csv or xlsx); defaults to csv"
+// @Param columns query string false "Comma separated keys of the columns to export, in order (user_id, full_name, exam_scores, category_grades, course_grade); defaults to all of them"
+// @Success 200 {file} file
+// @Router /api/v1/course/exportGradebook [get]
+func ExportCourseGradebookV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	}
+
+	courseId := c.QueryInt("id")
+	if courseId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "id")
+	}
+
+	format := strings.ToLower(c.Query("format", exportUtils.FormatCsv))
+	if !exportUtils.IsFormatValid(format) {
+		return apiHandlers.SendErrInvalidExportFormat(c, format)
+	}
+
+	selectedColumns, unknownColumn := exportUtils.SelectColumns(gradebookExportColumns, c.Query("columns"))
+	if unknownColumn != "" {
+		return apiHandlers.SendErrUnknownExportColumn(c, unknownColumn)
+	}
+
+	courseInfo, err := database.GetCourseInfo(courseId)
+	if err != nil {
+		if err == database.ErrCourseNotFound {
+			return apiHandlers.SendErrCourseNotFound(c)
+		}
+
+		logging.UnexpectedError("ExportCourseGradebookV1: failed to query database.GetCourseInfo: ", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	exams, err := database.GetCourseGradedExams(courseInfo.CourseId)
+	if err != nil {
+		logging.UnexpectedError("ExportCourseGradebookV1: failed to query database.GetCourseGradedExams: ", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	} else if !userInfo.CanViewCourseGradebook(courseInfo, exams) {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	canManage := userInfo.CanManageCourseGradebook(courseInfo)
+	if !canManage {
+		// teachers only get the exams they have created or grade themselves
+		var gradableExams []*database.ExamInfo
+		for _, examInfo := range exams {
+			if userInfo.CanExportExamResults(examInfo) {
+				gradableExams = append(gradableExams, examInfo)
+			}
+		}
+		exams = gradableExams
+	}
+
+	categories, err := database.GetCourseGradeCategories(courseInfo.CourseId)
+	if err != nil {
+		logging.UnexpectedError("ExportCourseGradebookV1: failed to query database.GetCourseGradeCategories: ", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	columns := expandGradebookColumns(selectedColumns, categories, exams, canManage)
+	if len(columns) == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "columns")
+	}
+
+	fileName := exportUtils.GetFileName("course_"+strconv.Itoa(courseInfo.CourseId)+"_gradebook", format)
+	c.Set(fiber.HeaderContentType, exportUtils.GetContentType(format))
+	c.Set(fiber.HeaderContentDisposition, `attachment; filename="`+fileName+`"`)
+	c.Set(fiber.HeaderXContentTypeOptions, "nosniff")
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		err := writeGradebookExport(w, format, courseInfo, categories, exams, columns, canManage)
+		if err != nil {
+			logging.UnexpectedError("ExportCourseGradebookV1: failed to write the export: ", err)
+		}
+	})
+
+	return nil
+}
@@ -1 +1,256 @@
 package courseHandlers
+
+import (
+	"ExamSphere/src/core/utils/exportUtils"
+	"ExamSphere/src/core/utils/gradebookUtils"
+	"ExamSphere/src/database"
+	"bufio"
+	"strconv"
+
+	"github.com/ALiwoto/ssg/ssg"
+)
+
+func toGradeCategoryInfo(category *database.CourseGradeCategory) *GradeCategoryInfo {
+	return &GradeCategoryInfo{
+		CategoryId:   category.CategoryId,
+		CourseId:     category.CourseId,
+		CategoryName: category.CategoryName,
+		Weight:       category.Weight,
+		DropLowest:   category.DropLowest,
+		AddedBy:      ssg.Clone(category.AddedBy),
+		CreatedAt:    category.CreatedAt,
+	}
+}
+
+func toGradebookExamInfo(examInfo *database.ExamInfo) *GradebookExamInfo {
+	return &GradebookExamInfo{
+		ExamId:          examInfo.ExamId,
+		ExamTitle:       examInfo.ExamTitle,
+		ExamDate:        examInfo.ExamDate,
+		GradeCategoryId: ssg.Clone(examInfo.GradeCategoryId),
+	}
+}
+
+// computeGradebookRow computes the row of a participant in the gradebook:
+// their score in each exam, their grade in each category and their course
+// grade. scoresMap holds the scores of the participant by the exam ids.
+func computeGradebookRow(
+	userId, fullName string,
+	categories []*database.CourseGradeCategory,
+	exams []*database.ExamInfo,
+	scoresMap map[int]*database.CourseGradebookScore,
+) *GradebookRowInfo {
+	row := &GradebookRowInfo{
+		UserId:     userId,
+		FullName:   fullName,
+		Scores:     make([]*GradebookScoreInfo, 0, len(exams)),
+		Categories: make([]*GradebookCategoryGrade, 0, len(categories)),
+	}
+
+	categoryIndexes := make(map[int]int, len(categories))
+	categoryScores := make([]*gradebookUtils.CategoryScores, 0, len(categories))
+	categoryExams := make([][]*GradebookScoreInfo, len(categories))
+	for i, category := range categories {
+		categoryIndexes[category.CategoryId] = i
+		categoryScores = append(categoryScores, &gradebookUtils.CategoryScores{
+			Weight:     category.Weight,
+			DropLowest: category.DropLowest,
+		})
+	}
+
+	for _, examInfo := range exams {
+		scoreInfo := &GradebookScoreInfo{ExamId: examInfo.ExamId}
+		if score := scoresMap[examInfo.ExamId]; score != nil {
+			scoreInfo.FinalScore = ssg.Clone(score.FinalScore)
+			scoreInfo.ScorePercentage = ssg.Clone(score.ScorePercentage)
+		}
+		row.Scores = append(row.Scores, scoreInfo)
+
+		if examInfo.GradeCategoryId == nil {
+			continue
+		}
+
+		i, found := categoryIndexes[*examInfo.GradeCategoryId]
+		if !found {
+			continue
+		}
+
+		categoryScores[i].Scores = append(categoryScores[i].Scores, scoreInfo.ScorePercentage)
+		categoryExams[i] = append(categoryExams[i], scoreInfo)
+	}
+
+	courseGrade := gradebookUtils.ComputeCourseGrade(categoryScores)
+	for i, grade := range courseGrade.Categories {
+		for _, dropped := range grade.Dropped {
+			categoryExams[i][dropped].IsDropped = true
+		}
+
+		row.Categories = append(row.Categories, &GradebookCategoryGrade{
+			CategoryId: categories[i].CategoryId,
+			Average:    grade.Average,
+		})
+	}
+	row.CourseGrade = courseGrade.Grade
+
+	return row
+}
+
+// expandGradebookColumns expands the selected columns of a gradebook
+// export to a column per graded exam and per grade category; the category
+// and course grades are only exported for those who manage the gradebook.
+func expandGradebookColumns(
+	selected []exportUtils.Column,
+	categories []*database.CourseGradeCategory,
+	exams []*database.ExamInfo,
+	canManage bool,
+) []exportUtils.Column {
+	var columns []exportUtils.Column
+	for _, column := range selected {
+		switch column.Key {
+		case gradebookColumnExamScores:
+			for _, examInfo := range exams {
+				columns = append(columns, exportUtils.Column{
+					Key:   gradebookColumnExamPrefix + strconv.Itoa(examInfo.ExamId),
+					Title: examInfo.ExamTitle,
+				})
+			}
+		case gradebookColumnCategoryGrades:
+			if !canManage {
+				continue
+			}
+
+			for _, category := range categories {
+				columns = append(columns, exportUtils.Column{
+					Key:   gradebookColumnCategoryPrefix + strconv.Itoa(category.CategoryId),
+					Title: category.CategoryName,
+				})
+			}
+		case gradebookColumnCourseGrade:
+			if canManage {
+				columns = append(columns, column)
+			}
+		default:
+			columns = append(columns, column)
+		}
+	}
+
+	return columns
+}
+
+// writeGradebookExport writes the rows of the gradebook of the course to w
+// in the specified format, batch by batch of the participants; each batch
+// is flushed as soon as it's written, so the export is streamed to the
+// client. If canManage is false, only the participants of the specified
+// exams are exported, without their category and course grades.
+func writeGradebookExport(
+	w *bufio.Writer,
+	format string,
+	courseInfo *database.CourseInfo,
+	categories []*database.CourseGradeCategory,
+	exams []*database.ExamInfo,
+	columns []exportUtils.Column,
+	canManage bool,
+) error {
+	writer, err := exportUtils.NewWriter(format, w, courseInfo.CourseName)
+	if err != nil {
+		return err
+	}
+
+	if err = writer.WriteRow(exportUtils.GetHeaderRow(columns)); err != nil {
+		return err
+	}
+
+	var examIds []int
+	if !canManage {
+		examIds = make([]int, 0, len(exams))
+		for _, examInfo := range exams {
+			examIds = append(examIds, examInfo.ExamId)
+		}
+		categories = nil
+	}
+
+	afterUserId := ""
+	for {
+		participants, err := database.GetCourseParticipantsExportBatch(
+			courseInfo.CourseId, examIds, afterUserId, database.ExportBatchSize,
+		)
+		if err != nil {
+			return err
+		}
+
+		userIds := make([]string, 0, len(participants))
+		for _, participant := range participants {
+			userIds = append(userIds, participant.UserId)
+		}
+
+		scores, err := database.GetCourseUsersGradebookScores(courseInfo.CourseId, userIds)
+		if err != nil {
+			return err
+		}
+
+		userScores := make(map[string]map[int]*database.CourseGradebookScore)
+		for _, score := range scores {
+			if userScores[score.UserId] == nil {
+				userScores[score.UserId] = make(map[int]*database.CourseGradebookScore)
+			}
+			userScores[score.UserId][score.ExamId] = score
+		}
+
+		for _, participant := range participants {
+			row := computeGradebookRow(
+				participant.UserId,
+				participant.FullName,
+				categories,
+				exams,
+				userScores[participant.UserId],
+			)
+
+			err = writer.WriteRow(exportUtils.GetRow(columns, toGradebookExportValues(row)))
+			if err != nil {
+				return err
+			}
+		}
+
+		if err = writer.Flush(); err != nil {
+			return err
+		} else if err = w.Flush(); err != nil {
+			return err
+		}
+
+		if len(participants) < database.ExportBatchSize {
+			break
+		}
+		afterUserId = participants[len(participants)-1].UserId
+	}
+
+	if err = writer.Close(); err != nil {
+		return err
+	}
+
+	return w.Flush()
+}
+
+func toGradebookExportValues(row *GradebookRowInfo) map[string]exportUtils.Cell {
+	values := map[string]exportUtils.Cell{
+		gradebookColumnUserId:      exportUtils.TextCell(row.UserId),
+		gradebookColumnFullName:    exportUtils.TextCell(row.FullName),
+		gradebookColumnCourseGrade: exportUtils.OptionalNumberCell(row.CourseGrade),
+	}
+
+	for _, score := range row.Scores {
+		// the scores which cannot be converted to a percentage are
+		// exported the way they have been given
+		cell := exportUtils.OptionalNumberCell(score.ScorePercentage)
+		if score.ScorePercentage == nil {
+			cell = exportUtils.OptionalTextCell(score.FinalScore)
+		}
+		values[gradebookColumnExamPrefix+strconv.Itoa(score.ExamId)] = cell
+	}
+
+	for _, grade := range row.Categories {
+		values[gradebookColumnCategoryPrefix+strconv.Itoa(grade.CategoryId)] =
+			exportUtils.OptionalNumberCell(grade.Average)
+	}
+
+	return values
+}
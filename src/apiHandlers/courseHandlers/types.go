@@ -93,3 +93,106 @@ type CourseParticipantInfo struct {
 	UserId   string `json:"user_id"`
 	FullName string `json:"full_name"`
 } // @name CourseParticipantInfo
+
+type CreateGradeCategoryData struct {
+	CourseId     int    `json:"course_id"`
+	CategoryName string `json:"category_name"`
+
+	// Weight is the share of the category in the course grade (between
+	// 0 and 100); the weights are normalised, so they don't have to add
+	// up to 100.
+	Weight float64 `json:"weight"`
+
+	// DropLowest is the number of the lowest scores of the category which
+	// are not counted.
+	DropLowest int `json:"drop_lowest"`
+} // @name CreateGradeCategoryData
+
+type GradeCategoryInfo struct {
+	CategoryId   int       `json:"category_id"`
+	CourseId     int       `json:"course_id"`
+	CategoryName string    `json:"category_name"`
+	Weight       float64   `json:"weight"`
+	DropLowest   int       `json:"drop_lowest"`
+	AddedBy      *string   `json:"added_by"`
+	CreatedAt    time.Time `json:"created_at"`
+} // @name GradeCategoryInfo
+
+type EditGradeCategoryData struct {
+	CategoryId   int     `json:"category_id"`
+	CategoryName string  `json:"category_name"`
+	Weight       float64 `json:"weight"`
+	DropLowest   int     `json:"drop_lowest"`
+} // @name EditGradeCategoryData
+
+type DeleteGradeCategoryData struct {
+	CategoryId int `json:"category_id"`
+} // @name DeleteGradeCategoryData
+
+type DeleteGradeCategoryResult struct {
+	CategoryId int `json:"category_id"`
+	CourseId   int `json:"course_id"`
+} // @name DeleteGradeCategoryResult
+
+type GetGradeCategoriesResult struct {
+	CourseId    int                  `json:"course_id"`
+	TotalWeight float64              `json:"total_weight"`
+	Categories  []*GradeCategoryInfo `json:"categories"`
+} // @name GetGradeCategoriesResult
+
+type GradebookExamInfo struct {
+	ExamId    int       `json:"exam_id"`
+	ExamTitle string    `json:"exam_title"`
+	ExamDate  time.Time `json:"exam_date"`
+
+	// GradeCategoryId is nil if the exam is not counted in the course grade.
+	GradeCategoryId *int `json:"grade_category_id"`
+} // @name GradebookExamInfo
+
+type GradebookScoreInfo struct {
+	ExamId     int     `json:"exam_id"`
+	FinalScore *string `json:"final_score"`
+
+	// ScorePercentage is nil if the exam has not been scored yet.
+	ScorePercentage *float64 `json:"score_percentage"`
+
+	// IsDropped is true if the score is one of the lowest scores of its
+	// category, which are not counted.
+	IsDropped bool `json:"is_dropped"`
+} // @name GradebookScoreInfo
+
+type GradebookCategoryGrade struct {
+	CategoryId int `json:"category_id"`
+
+	// Average is nil if none of the exams of the category are scored yet.
+	Average *float64 `json:"average"`
+} // @name GradebookCategoryGrade
+
+type GradebookRowInfo struct {
+	UserId   string `json:"user_id"`
+	FullName string `json:"full_name"`
+
+	// Scores are in the same order as the exams of the gradebook.
+	Scores     []*GradebookScoreInfo     `json:"scores"`
+	Categories []*GradebookCategoryGrade `json:"categories"`
+
+	// CourseGrade is the weighted average of the grades of the categories
+	// scored so far; nil if there are none.
+	CourseGrade *float64 `json:"course_grade"`
+} // @name GradebookRowInfo
+
+type GetCourseGradebookResult struct {
+	CourseId   int                  `json:"course_id"`
+	CourseName string               `json:"course_name"`
+	Categories []*GradeCategoryInfo `json:"categories"`
+	Exams      []*GradebookExamInfo `json:"exams"`
+	Rows       []*GradebookRowInfo  `json:"rows"`
+} // @name GetCourseGradebookResult
+
+type GetMyCourseGradesResult struct {
+	CourseId   int                  `json:"course_id"`
+	CourseName string               `json:"course_name"`
+	Categories []*GradeCategoryInfo `json:"categories"`
+	Exams      []*GradebookExamInfo `json:"exams"`
+	Row        *GradebookRowInfo    `json:"row"`
+} // @name GetMyCourseGradesResult
@@ -1 +1,16 @@
 package courseHandlers
+
+import "ExamSphere/src/core/utils/exportUtils"
+
+var (
+	// gradebookExportColumns are the columns which can be selected while
+	// exporting the gradebook of a course; exam_scores and category_grades
+	// are expanded to a column per graded exam and per grade category.
+	gradebookExportColumns = []exportUtils.Column{
+		{Key: gradebookColumnUserId, Title: "User ID"},
+		{Key: gradebookColumnFullName, Title: "Full name"},
+		{Key: gradebookColumnExamScores, Title: "Exam scores"},
+		{Key: gradebookColumnCategoryGrades, Title: "Category grades"},
+		{Key: gradebookColumnCourseGrade, Title: "Course grade"},
+	}
+)
@@ -59,6 +59,89 @@ const (
 	ErrTopicNameExists               = "A topic with this name already exists"
 	ErrTopicNotFound                 = "Topic not found"
 	ErrBodyTooLong                   = "The provided body is too long"
+	ErrInvalidDeviceId               = "Invalid client device id provided: %s"
+	ErrForeignAttemptClient          = "This exam attempt is bound to another client"
+	ErrAttemptBindingNotFound        = "Exam attempt binding not found"
+	ErrInvalidAccessCode             = "Invalid access code provided"
+	ErrTooManyAccessCodeAttempts     = "Too many wrong access codes. Please try again later"
+	ErrAttemptNotStarted             = "Exam attempt has not been started yet"
+	ErrInvalidAccessCodeType         = "Invalid access code type provided: %s"
+	ErrInvalidPrerequisiteType       = "Invalid prerequisite type provided: %s"
+	ErrPrerequisiteNotFound          = "Exam prerequisite not found"
+	ErrInvalidPrerequisite           = "Invalid prerequisite: %s"
+	ErrPrerequisitesNotMet           = "You have not met the prerequisites of this exam"
+	ErrAttemptFinished               = "This exam attempt has already been finished"
+	ErrNoAttemptsLeft                = "You have no attempts left in this exam"
+	ErrRetakeCooldown                = "You have to wait %d seconds before retaking this exam"
+	ErrAttemptNotFound               = "Exam attempt not found"
+	ErrInvalidGradingPolicy          = "Invalid grading policy provided: %s"
+	ErrAccommodationNotFound         = "Accommodation not found"
+	ErrInvalidAccommodation          = "Invalid accommodation: %s"
+	ErrLateStartNotAllowed           = "The exam cannot be started anymore"
+	ErrInvalidAvailability           = "Invalid availability: %s"
+	ErrInvalidDeadlinePolicy         = "Invalid deadline policy: %s"
+	ErrExamSeriesNotFound            = "Exam series not found"
+	ErrInvalidRecurrenceRule         = "Invalid recurrence rule: %s"
+	ErrInvalidSeriesOccurrence       = "The specified time is not an occurrence of the series"
+	ErrOccurrenceHasParticipants     = "The exam of this occurrence already has participants"
+	ErrInvalidPrice                  = "Invalid price: %s"
+	ErrInsufficientBalance           = "Insufficient wallet balance"
+	ErrWalletTransactionNotFound     = "Wallet transaction not found"
+	ErrRefundExceedsAmount           = "Refund amount exceeds the refundable amount of the transaction"
+	ErrPaymentFailed                 = "Payment failed: %s"
+	ErrInvalidAmount                 = "Invalid amount: %s"
+	ErrTransactionNotRefundable      = "This transaction cannot be refunded"
+	ErrExamCouponNotFound            = "Coupon not found"
+	ErrExamCouponNotUsable           = "This coupon cannot be used for this exam"
+	ErrInvalidCouponCode             = "Invalid coupon code: %s"
+	ErrInvalidDiscount               = "Invalid discount: %s"
+	ErrCouponCodeExists              = "A coupon with this code already exists"
+	ErrExamInvitationNotFound        = "Exam invitation not found"
+	ErrInvalidInvitationToken        = "Invalid invitation token"
+	ErrInvitationNotPending          = "This invitation is %s"
+	ErrExamAlreadyStarted            = "Exam has already started"
+	ErrExamSectionNotFound           = "Exam section not found"
+	ErrExamSectionClosed             = "The section of this question is not open"
+	ErrNoOngoingSection              = "There is no ongoing section in this exam attempt"
+	ErrExamNotAdaptive               = "This exam is not adaptive"
+	ErrAdaptiveExamQuestions         = "The questions of an adaptive exam are given one at a time"
+	ErrQuestionNotServed             = "This question is not waiting for an answer"
+	ErrNoAdaptiveQuestions           = "This exam has no questions with an answer key"
+	ErrAttachmentNotFound            = "Attachment not found"
+	ErrUnsupportedFileType           = "Unsupported file type"
+	ErrFileTooLarge                  = "The file is too large"
+	ErrStorageNotAvailable           = "File storage is not available"
+	ErrTooManyAttachments            = "Too many files attached to this answer"
+	ErrInvalidContentFormat          = "Invalid content format provided: %s"
+	ErrInvalidContent                = "Invalid content provided: %s"
+	ErrSimilarityCheckNotFound       = "Similarity check not found"
+	ErrSimilarityCheckRunning        = "Another similarity check of this question is running"
+	ErrInvalidSimilarityOptions      = "Invalid similarity check options: %s"
+	ErrInvalidQuestionMetadata       = "Invalid question metadata: %s"
+	ErrExamNotPublished              = "Exam has not been published yet"
+	ErrInvalidReviewStatus           = "Exam cannot be changed while its review status is %s"
+	ErrReviewCommentNotFound         = "Review comment not found"
+	ErrExamCollaboratorNotFound      = "Exam collaborator not found"
+	ErrInvalidCollaboratorRole       = "Invalid collaborator role: %s"
+	ErrTooManyCollaborators          = "Too many collaborators for this exam"
+	ErrPreviewAttemptNotFound        = "Preview attempt not found"
+	ErrPreviewAttemptFinished        = "Preview attempt has already been finished"
+	ErrTooManyPreviewAttempts        = "Too many unfinished preview attempts in this exam"
+	ErrPreviewTimeUp                 = "Time is up for this preview attempt"
+	ErrPracticeExamNotGraded         = "Practice exams are not graded"
+	ErrAttemptReviewNotAvailable     = "Reviewing this attempt is not available"
+	ErrInvalidAttemptReviewPolicy    = "Invalid attempt review policy: %s"
+	ErrInvalidPoints                 = "Invalid number of points"
+	ErrGivenAnswerNotFound           = "Given answer not found"
+	ErrGradeCategoryNotFound         = "Grade category not found"
+	ErrGradeCategoryAlreadyExists    = "A grade category with this name already exists in the course"
+	ErrTooManyGradeCategories        = "The course has too many grade categories"
+	ErrInvalidGradeCategoryWeight    = "Weight of a grade category has to be between 0 and 100"
+	ErrInvalidDropLowestCount        = "Number of the dropped scores cannot be negative"
+	ErrGradeCategoryNameTooLong      = "Grade category name is too long; max length is %d characters"
+	ErrInvalidExportFormat           = "Invalid export format: %s; it must be either csv or xlsx"
+	ErrUnknownExportColumn           = "Unknown export column: %s"
+	ErrCurrencyMismatch              = "The price of the exam is not in the currency of the wallet"
 )
 
 // error codes
@@ -120,4 +203,87 @@ const (
 	ErrCodeTopicNameExists
 	ErrCodeTopicNotFound
 	ErrCodeBodyTooLong
+	ErrCodeInvalidDeviceId
+	ErrCodeForeignAttemptClient
+	ErrCodeAttemptBindingNotFound
+	ErrCodeInvalidAccessCode
+	ErrCodeTooManyAccessCodeAttempts
+	ErrCodeAttemptNotStarted
+	ErrCodeInvalidAccessCodeType
+	ErrCodeInvalidPrerequisiteType
+	ErrCodePrerequisiteNotFound
+	ErrCodeInvalidPrerequisite
+	ErrCodePrerequisitesNotMet
+	ErrCodeAttemptFinished
+	ErrCodeNoAttemptsLeft
+	ErrCodeRetakeCooldown
+	ErrCodeAttemptNotFound
+	ErrCodeInvalidGradingPolicy
+	ErrCodeAccommodationNotFound
+	ErrCodeInvalidAccommodation
+	ErrCodeLateStartNotAllowed
+	ErrCodeInvalidAvailability
+	ErrCodeInvalidDeadlinePolicy
+	ErrCodeExamSeriesNotFound
+	ErrCodeInvalidRecurrenceRule
+	ErrCodeInvalidSeriesOccurrence
+	ErrCodeOccurrenceHasParticipants
+	ErrCodeInvalidPrice
+	ErrCodeInsufficientBalance
+	ErrCodeWalletTransactionNotFound
+	ErrCodeRefundExceedsAmount
+	ErrCodePaymentFailed
+	ErrCodeInvalidAmount
+	ErrCodeTransactionNotRefundable
+	ErrCodeExamCouponNotFound
+	ErrCodeExamCouponNotUsable
+	ErrCodeInvalidCouponCode
+	ErrCodeInvalidDiscount
+	ErrCodeCouponCodeExists
+	ErrCodeExamInvitationNotFound
+	ErrCodeInvalidInvitationToken
+	ErrCodeInvitationNotPending
+	ErrCodeExamAlreadyStarted
+	ErrCodeExamSectionNotFound
+	ErrCodeExamSectionClosed
+	ErrCodeNoOngoingSection
+	ErrCodeExamNotAdaptive
+	ErrCodeAdaptiveExamQuestions
+	ErrCodeQuestionNotServed
+	ErrCodeNoAdaptiveQuestions
+	ErrCodeAttachmentNotFound
+	ErrCodeUnsupportedFileType
+	ErrCodeFileTooLarge
+	ErrCodeStorageNotAvailable
+	ErrCodeTooManyAttachments
+	ErrCodeInvalidContentFormat
+	ErrCodeInvalidContent
+	ErrCodeSimilarityCheckNotFound
+	ErrCodeSimilarityCheckRunning
+	ErrCodeInvalidSimilarityOptions
+	ErrCodeInvalidQuestionMetadata
+	ErrCodeExamNotPublished
+	ErrCodeInvalidReviewStatus
+	ErrCodeReviewCommentNotFound
+	ErrCodeExamCollaboratorNotFound
+	ErrCodeInvalidCollaboratorRole
+	ErrCodeTooManyCollaborators
+	ErrCodePreviewAttemptNotFound
+	ErrCodePreviewAttemptFinished
+	ErrCodeTooManyPreviewAttempts
+	ErrCodePreviewTimeUp
+	ErrCodePracticeExamNotGraded
+	ErrCodeAttemptReviewNotAvailable
+	ErrCodeInvalidAttemptReviewPolicy
+	ErrCodeInvalidPoints
+	ErrCodeGivenAnswerNotFound
+	ErrCodeGradeCategoryNotFound
+	ErrCodeGradeCategoryAlreadyExists
+	ErrCodeTooManyGradeCategories
+	ErrCodeInvalidGradeCategoryWeight
+	ErrCodeInvalidDropLowestCount
+	ErrCodeGradeCategoryNameTooLong
+	ErrCodeInvalidExportFormat
+	ErrCodeUnknownExportColumn
+	ErrCodeCurrencyMismatch
 )
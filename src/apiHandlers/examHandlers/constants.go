@@ -1 +1,70 @@
 package examHandlers
+
+const (
+	// DeviceIdHeader is the header that clients should use to send their
+	// device id while they are taking an exam.
+	DeviceIdHeader = "Client-Device-ID"
+
+	// MaxDeviceIdLength is the maximum length of a device id.
+	MaxDeviceIdLength = 127
+)
+
+const (
+	// RotatingAccessCodeSecretLength is the length of the secret generated
+	// for exams with rotating access codes.
+	RotatingAccessCodeSecretLength = 32
+)
+
+const (
+	attemptClientAllowed attemptClientStatus = iota
+	attemptClientForeign
+	attemptClientNotStarted
+	attemptClientFinished
+	attemptClientLateStart
+)
+
+const (
+	questionAnswerable questionAnswerStatus = iota
+	questionNotServed
+	questionSectionClosed
+)
+
+const (
+	// attachmentKeyRandomLength is the length of the random part of the
+	// keys the uploaded files are stored under.
+	attachmentKeyRandomLength = 32
+)
+
+const (
+	// the reasons of skipping an invitee of an exam
+	inviteeReasonUserNotFound        = "user not found"
+	inviteeReasonInvalidEmail        = "invalid email address"
+	inviteeReasonCannotParticipate   = "user cannot participate in exams"
+	inviteeReasonAlreadyParticipated = "user is already participating in the exam"
+	inviteeReasonDuplicate           = "duplicate invitee"
+)
+
+const (
+	// the statuses of the questions in the score breakdown of a preview
+	previewQuestionCorrect      = "correct"
+	previewQuestionIncorrect    = "incorrect"
+	previewQuestionUnanswered   = "unanswered"
+	previewQuestionNeedsGrading = "needs_grading"
+)
+
+const (
+	// the widths of the columns of the answers table of the result
+	// reports, other than the question column which takes the rest
+	reportNumberColumnWidth = 24
+	reportAnswerColumnWidth = 115
+	reportPointsColumnWidth = 76
+
+	// reportChartHeight is the height of the trend chart of the result
+	// reports.
+	reportChartHeight = 140
+
+	// reportUnknownCharsNote is put at the end of the result reports which
+	// have characters their fonts cannot show.
+	reportUnknownCharsNote = "Some characters (e.g. of non-Latin texts) cannot be shown " +
+		"in this report and have been replaced with question marks."
+)
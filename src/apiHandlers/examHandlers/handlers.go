@@ -2,12 +2,28 @@ package examHandlers
 
 import (
 	"ExamSphere/src/apiHandlers"
+	"ExamSphere/src/apiHandlers/userHandlers"
+	"ExamSphere/src/core/appConfig"
+	"ExamSphere/src/core/appValues"
+	"ExamSphere/src/core/utils/contentUtils"
+	"ExamSphere/src/core/utils/emailUtils"
+	"ExamSphere/src/core/utils/exportUtils"
+	"ExamSphere/src/core/utils/hashing"
+	"ExamSphere/src/core/utils/irtUtils"
 	"ExamSphere/src/core/utils/logging"
+	"ExamSphere/src/core/utils/recurrenceUtils"
+	"ExamSphere/src/core/utils/similarityUtils"
+	"ExamSphere/src/core/utils/storageUtils"
 	"ExamSphere/src/database"
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/ALiwoto/ssg/ssg"
 	"github.com/gofiber/fiber/v2"
+	fUtils "github.com/gofiber/fiber/v2/utils"
 	"github.com/jackc/pgx/v5"
 )
 
@@ -47,6 +63,10 @@ func CreateExamV1(c *fiber.Ctx) error {
 		return apiHandlers.SendErrInvalidBodyData(c)
 	}
 
+	if !isPriceValid(data.Price) {
+		return apiHandlers.SendErrInvalidPrice(c, data.Price)
+	}
+
 	examInfo, err := database.CreateNewExam(&database.NewExamData{
 		CourseId:        data.CourseId,
 		ExamTitle:       data.ExamTitle,
@@ -115,6 +135,32 @@ func GetExamInfoV1(c *fiber.Ctx) error {
 		return apiHandlers.SendErrInternalServerError(c)
 	}
 
+	hasParticipated := database.HasParticipatedInExam(userInfo.UserId, examId)
+	if !examInfo.IsPublished() && !hasParticipated && !userInfo.CanSeeUnpublishedExam(examInfo) {
+		// the exams are hidden from the students until they're approved
+		return apiHandlers.SendErrExamNotFound(c)
+	}
+
+	var unmetPrerequisites []*ExamPrerequisiteInfo
+	var latestAttempt *database.ExamAttempt
+	waitlistPosition := 0
+	if !hasParticipated {
+		unmetPrerequisites = getUnmetPrerequisitesInfo(userInfo.UserId, examId)
+		waitlistEntry, _ := database.GetExamWaitlistEntry(userInfo.UserId, examId)
+		if waitlistEntry != nil {
+			waitlistPosition = waitlistEntry.Position
+		}
+	} else {
+		latestAttempt = database.GetLatestExamAttemptOrNil(userInfo.UserId, examId)
+	}
+
+	accommodation := database.GetExamAccommodationOrNil(userInfo.UserId, examId)
+	err = submitExpiredAttempt(examInfo, accommodation, latestAttempt)
+	if err != nil {
+		logging.UnexpectedError("GetExamInfo: Failed to submit expired attempt:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
 	return apiHandlers.SendResult(c, &GetExamInfoResult{
 		ExamId:             examInfo.ExamId,
 		CourseId:           examInfo.CourseId,
@@ -126,15 +172,42 @@ func GetExamInfoV1(c *fiber.Ctx) error {
 		Duration:           examInfo.Duration,
 		CreatedBy:          examInfo.CreatedBy,
 		IsPublic:           examInfo.IsPublic,
-		HasParticipated:    database.HasParticipatedInExam(userInfo.UserId, examId),
-		HasStarted:         examInfo.HasExamStarted(),
+		HasParticipated:    hasParticipated,
+		HasStarted:         examInfo.HasExamStartedFor(accommodation),
 		CanParticipate:     database.CanParticipateInExamOrFalse(userInfo.UserId, examId),
 		CanEditQuestion:    userInfo.CanEditExamQuestion(examInfo),
 		CanAddOthersToExam: userInfo.CanAddOthersToExam(examInfo),
-		HasFinished:        examInfo.HasExamFinished(),
-		StartsIn:           examInfo.ExamStartsIn(),
-		FinishesIn:         examInfo.ExamFinishesIn(),
+		HasFinished:        examInfo.HasExamFinishedFor(accommodation),
+		StartsIn:           examInfo.ExamStartsInFor(accommodation),
+		FinishesIn:         examInfo.DeadlineInFor(accommodation, latestAttempt),
 		QuestionCount:      database.GetExamQuestionsCount(examId),
+		RequiresAccessCode: examInfo.RequiresAccessCode(),
+		AccessCodeType:     examInfo.AccessCodeType,
+		MaxAttempts:        examInfo.MaxAttempts,
+		AttemptCooldown:    examInfo.AttemptCooldown,
+		GradingPolicy:      examInfo.GradingPolicy,
+		AttemptsLeft:       examInfo.GetAttemptsLeft(latestAttempt),
+		RetakeAvailableIn:  examInfo.RetakeAvailableIn(latestAttempt),
+		OpensAt:            examInfo.GetStartTimeFor(accommodation),
+		ClosesAt:           examInfo.GetEndTimeFor(accommodation),
+		IsWindowed:         examInfo.IsWindowed(),
+		LatestStartAt:      examInfo.GetLatestStartTimeFor(accommodation),
+		Deadline:           examInfo.GetDeadlineFor(accommodation, latestAttempt),
+		DeadlinePolicy:     examInfo.DeadlinePolicy,
+		GracePeriod:        examInfo.GracePeriod,
+		UnmetPrerequisites: unmetPrerequisites,
+		Capacity:           ssg.Clone(examInfo.Capacity),
+		ParticipantsCount:  database.GetExamParticipantsCount(examId),
+		WaitlistPosition:   waitlistPosition,
+		IsAdaptive:         examInfo.IsAdaptive,
+		ReviewStatus:       examInfo.ReviewStatus,
+		CanReview:          userInfo.CanReviewExam(examInfo),
+		IsPractice:         examInfo.IsPractice,
+
+		AttemptReviewPolicy: examInfo.AttemptReviewPolicy,
+		ResultsReleasedAt:   ssg.Clone(examInfo.ResultsReleasedAt),
+		CanReviewAttempt:    examInfo.CanReviewAttemptFor(accommodation),
+		GradeCategoryId:     ssg.Clone(examInfo.GradeCategoryId),
 	})
 }
 
@@ -178,6 +251,9 @@ func SearchExamV1(c *fiber.Ctx) error {
 		Offset:      data.Offset,
 		Limit:       data.Limit,
 		PublicOnly:  !userInfo.CanGetAllExams(),
+
+		IncludeUnpublished: userInfo.CanReviewExams(),
+		UserId:             userInfo.UserId,
 	})
 	if err != nil {
 		logging.UnexpectedError("SearchExam: Failed to search exams:", err)
@@ -241,6 +317,10 @@ func EditExamV1(c *fiber.Ctx) error {
 		return apiHandlers.SendErrInvalidBodyData(c)
 	}
 
+	if !isPriceValid(data.Price) {
+		return apiHandlers.SendErrInvalidPrice(c, data.Price)
+	}
+
 	examInfo := database.GetExamInfoOrNil(data.ExamId)
 	if examInfo == nil {
 		return apiHandlers.SendErrExamNotFound(c)
@@ -323,6 +403,10 @@ func ParticipateExamV1(c *fiber.Ctx) error {
 		return apiHandlers.SendErrPermissionDenied(c)
 	}
 
+	if !examInfo.IsPublished() {
+		return apiHandlers.SendErrExamNotPublished(c)
+	}
+
 	targetUser, err := database.GetUserByUserId(data.UserId)
 	if err != nil {
 		if err == database.ErrUserNotFound {
@@ -336,24 +420,93 @@ func ParticipateExamV1(c *fiber.Ctx) error {
 		return apiHandlers.SendErrPermissionDenied(c)
 	}
 
-	if !database.CanParticipateInExamOrFalse(userInfo.UserId, data.ExamId) {
+	// users can only join the public exams themselves, but the rules are
+	// of the student, whoever is adding them
+	var canParticipate bool
+	if data.UserId == userInfo.UserId {
+		canParticipate = database.CanParticipateInExamOrFalse(data.UserId, data.ExamId)
+	} else {
+		canParticipate = database.CanAddUserToExamOrFalse(data.UserId, data.ExamId, userInfo.UserId)
+	}
+
+	if !canParticipate {
+		unmetPrerequisites, err := database.GetUnmetExamPrerequisites(data.UserId, data.ExamId)
+		if err != nil {
+			logging.UnexpectedError("ParticipateExam: Failed to get unmet prerequisites:", err)
+			return apiHandlers.SendErrInternalServerError(c)
+		} else if len(unmetPrerequisites) > 0 {
+			return apiHandlers.SendErrPrerequisitesNotMet(c)
+		}
+
 		return apiHandlers.SendErrPermissionDenied(c)
-	} else if examInfo.HasExamFinished() {
+	}
+
+	accommodation := database.GetExamAccommodationOrNil(data.UserId, data.ExamId)
+	if examInfo.HasExamFinishedFor(accommodation) {
 		return apiHandlers.SendErrExamFinished(c)
 	}
 
+	if data.UserId == userInfo.UserId && examInfo.RequiresAccessCode() {
+		if isAccessCodeRateLimited(userInfo.UserId, data.ExamId) {
+			return apiHandlers.SendErrTooManyAccessCodeAttempts(c)
+		} else if !verifyAccessCode(userInfo.UserId, examInfo, data.AccessCode) {
+			return apiHandlers.SendErrInvalidAccessCode(c)
+		}
+	}
+
+	var coupon *database.ExamCoupon
+	if data.CouponCode != "" {
+		coupon, err = database.GetUsableExamCoupon(data.CouponCode, data.UserId, data.ExamId)
+		if err == database.ErrExamCouponNotFound {
+			return apiHandlers.SendErrExamCouponNotFound(c)
+		} else if err == database.ErrExamCouponNotUsable {
+			return apiHandlers.SendErrExamCouponNotUsable(c)
+		} else if err != nil {
+			logging.UnexpectedError("ParticipateExam: Failed to get exam coupon:", err)
+			return apiHandlers.SendErrInternalServerError(c)
+		}
+	}
+
+	price, discount, err := examInfo.GetPriceWithCoupon(coupon)
+	if err != nil {
+		logging.UnexpectedError("ParticipateExam: Failed to apply exam coupon:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
 	var addedBy *string
 	if userInfo.UserId != data.UserId {
 		addedBy = ssg.Clone(&userInfo.UserId)
 	}
 
 	givenExam, err := database.AddUserInExam(&database.NewGivenExamData{
-		UserId:  data.UserId,
-		ExamId:  data.ExamId,
-		Price:   examInfo.Price,
-		AddedBy: addedBy,
+		UserId:   data.UserId,
+		ExamId:   data.ExamId,
+		Price:    price,
+		AddedBy:  addedBy,
+		Coupon:   coupon,
+		Discount: discount,
 	})
-	if err != nil {
+	if err == database.ErrExamFull {
+		// the user gets a seat (and pays for it) once one frees up
+		waitlistEntry, err := database.AddUserToExamWaitlist(&database.NewExamWaitlistEntryData{
+			ExamId:  data.ExamId,
+			UserId:  data.UserId,
+			AddedBy: addedBy,
+			Coupon:  coupon,
+		})
+		if err != nil {
+			logging.UnexpectedError("ParticipateExam: Failed to add user to exam waitlist:", err)
+			return apiHandlers.SendErrInternalServerError(c)
+		}
+
+		return apiHandlers.SendResult(c, toWaitlistedParticipateResult(examInfo, waitlistEntry))
+	} else if err == database.ErrInsufficientBalance {
+		return apiHandlers.SendErrInsufficientBalance(c)
+	} else if err == database.ErrCurrencyMismatch {
+		return apiHandlers.SendErrCurrencyMismatch(c)
+	} else if err == database.ErrExamCouponNotUsable {
+		return apiHandlers.SendErrExamCouponNotUsable(c)
+	} else if err != nil {
 		logging.UnexpectedError("ParticipateExam: Failed to add user in exam:", err)
 		return apiHandlers.SendErrInternalServerError(c)
 	}
@@ -362,10 +515,11 @@ func ParticipateExamV1(c *fiber.Ctx) error {
 		ExamId:        givenExam.ExamId,
 		UserId:        givenExam.UserId,
 		Price:         givenExam.Price,
+		OriginalPrice: examInfo.Price,
 		AddedBy:       ssg.Clone(givenExam.AddedBy),
 		CreatedAt:     givenExam.CreatedAt,
-		StartsIn:      examInfo.ExamStartsIn(),
-		FinishesIn:    examInfo.ExamFinishesIn(),
+		StartsIn:      examInfo.ExamStartsInFor(accommodation),
+		FinishesIn:    examInfo.ExamFinishesInFor(accommodation),
 		QuestionCount: database.GetExamQuestionsCount(data.ExamId),
 	})
 }
@@ -422,9 +576,10 @@ func GetExamParticipantsV1(c *fiber.Ctx) error {
 		return apiHandlers.SendErrInternalServerError(c)
 	}
 
+	canSetScore := userInfo.CanSetScoreForExam(examInfo)
 	participantsInfo := make([]*ExamParticipantInfo, 0, len(participants))
 	for _, p := range participants {
-		participantsInfo = append(participantsInfo, &ExamParticipantInfo{
+		info := &ExamParticipantInfo{
 			UserId:     p.UserId,
 			FullName:   database.GetUserFullNameOrEmpty(p.UserId),
 			ExamId:     p.ExamId,
@@ -433,13 +588,19 @@ func GetExamParticipantsV1(c *fiber.Ctx) error {
 			ScoredBy:   ssg.Clone(p.ScoredBy),
 			CreatedAt:  p.CreatedAt,
 			FinalScore: ssg.Clone(p.FinalScore),
-		})
+		}
+
+		if canSetScore {
+			info.ClientMismatches = p.ClientMismatches
+			info.ClientIPChanges = p.ClientIPChanges
+		}
+		participantsInfo = append(participantsInfo, info)
 	}
 
 	return apiHandlers.SendResult(c, &GetExamParticipantsResult{
 		ExamId:       data.ExamId,
 		Participants: participantsInfo,
-		CanSetScore:  userInfo.CanSetScoreForExam(examInfo),
+		CanSetScore:  canSetScore,
 	})
 }
 
@@ -487,6 +648,29 @@ func CreateExamQuestionV1(c *fiber.Ctx) error {
 		return apiHandlers.SendErrPermissionDenied(c)
 	}
 
+	if data.Points != nil && *data.Points < 0 {
+		return apiHandlers.SendErrInvalidPoints(c)
+	}
+
+	if data.ContentFormat == "" {
+		data.ContentFormat = contentUtils.FormatPlain
+	} else if !contentUtils.IsFormatValid(data.ContentFormat) {
+		return apiHandlers.SendErrInvalidContentFormat(c, data.ContentFormat)
+	}
+
+	invalidField, err := sanitizeContentFields(data.ContentFormat, data.getContentFields())
+	if err != nil {
+		return apiHandlers.SendErrInvalidContent(c, invalidField+": "+err.Error())
+	}
+
+	isSectionValid, err := checkQuestionSection(examInfo, data.SectionId)
+	if err != nil {
+		logging.UnexpectedError("CreateExamQuestion: Failed to get exam section:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	} else if !isSectionValid {
+		return apiHandlers.SendErrExamSectionNotFound(c)
+	}
+
 	questionInfo, err := database.CreateNewExamQuestion(&database.NewExamQuestionData{
 		ExamId:        data.ExamId,
 		QuestionTitle: data.QuestionTitle,
@@ -495,12 +679,20 @@ func CreateExamQuestionV1(c *fiber.Ctx) error {
 		Option2:       data.Option2,
 		Option3:       data.Option3,
 		Option4:       data.Option4,
+		SectionId:     data.SectionId,
+		QuestionOrder: data.QuestionOrder,
+		ContentFormat: data.ContentFormat,
+		Explanation:   data.Explanation,
+		Points:        data.Points,
 	})
 	if err != nil {
 		logging.UnexpectedError("CreateExamQuestion: Failed to create new exam question:", err)
 		return apiHandlers.SendErrInternalServerError(c)
 	}
 
+	rendered := renderQuestionContent(questionInfo)
+	rendered.Explanation = renderQuestionExplanation(questionInfo)
+
 	return apiHandlers.SendResult(c, &CreateExamQuestionResult{
 		QuestionId:    questionInfo.QuestionId,
 		ExamId:        questionInfo.ExamId,
@@ -510,7 +702,13 @@ func CreateExamQuestionV1(c *fiber.Ctx) error {
 		Option2:       ssg.Clone(questionInfo.Option2),
 		Option3:       ssg.Clone(questionInfo.Option3),
 		Option4:       ssg.Clone(questionInfo.Option4),
+		SectionId:     ssg.Clone(questionInfo.SectionId),
+		QuestionOrder: questionInfo.QuestionOrder,
+		ContentFormat: questionInfo.ContentFormat,
 		CreatedAt:     questionInfo.CreatedAt,
+		Explanation:   ssg.Clone(questionInfo.Explanation),
+		Points:        questionInfo.Points,
+		Rendered:      rendered,
 	})
 }
 
@@ -558,6 +756,36 @@ func EditExamQuestionV1(c *fiber.Ctx) error {
 
 	if !userInfo.CanEditExamQuestion(examInfo) {
 		return apiHandlers.SendErrPermissionDenied(c)
+	} else if data.Points != nil && *data.Points < 0 {
+		return apiHandlers.SendErrInvalidPoints(c)
+	}
+
+	question, err := database.GetExamQuestion(data.ExamId, data.QuestionId)
+	if err != nil {
+		if err == database.ErrExamQuestionNotFound {
+			return apiHandlers.SendErrExamQuestionNotFound(c)
+		}
+		logging.UnexpectedError("EditExamQuestion: Failed to get exam question info:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	if data.ContentFormat == "" {
+		data.ContentFormat = question.ContentFormat
+	} else if !contentUtils.IsFormatValid(data.ContentFormat) {
+		return apiHandlers.SendErrInvalidContentFormat(c, data.ContentFormat)
+	}
+
+	invalidField, err := sanitizeContentFields(data.ContentFormat, data.getContentFields())
+	if err != nil {
+		return apiHandlers.SendErrInvalidContent(c, invalidField+": "+err.Error())
+	}
+
+	isSectionValid, err := checkQuestionSection(examInfo, data.SectionId)
+	if err != nil {
+		logging.UnexpectedError("EditExamQuestion: Failed to get exam section:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	} else if !isSectionValid {
+		return apiHandlers.SendErrExamSectionNotFound(c)
 	}
 
 	questionInfo, err := database.EditExamQuestion(&database.EditExamQuestionData{
@@ -569,12 +797,20 @@ func EditExamQuestionV1(c *fiber.Ctx) error {
 		Option2:       data.Option2,
 		Option3:       data.Option3,
 		Option4:       data.Option4,
+		SectionId:     data.SectionId,
+		QuestionOrder: data.QuestionOrder,
+		ContentFormat: data.ContentFormat,
+		Explanation:   data.Explanation,
+		Points:        data.Points,
 	})
 	if err != nil {
 		logging.UnexpectedError("EditExamQuestion: Failed to edit exam question:", err)
 		return apiHandlers.SendErrInternalServerError(c)
 	}
 
+	rendered := renderQuestionContent(questionInfo)
+	rendered.Explanation = renderQuestionExplanation(questionInfo)
+
 	return apiHandlers.SendResult(c, &EditExamQuestionResult{
 		QuestionId:    questionInfo.QuestionId,
 		ExamId:        questionInfo.ExamId,
@@ -584,7 +820,13 @@ func EditExamQuestionV1(c *fiber.Ctx) error {
 		Option2:       ssg.Clone(questionInfo.Option2),
 		Option3:       ssg.Clone(questionInfo.Option3),
 		Option4:       ssg.Clone(questionInfo.Option4),
+		SectionId:     ssg.Clone(questionInfo.SectionId),
+		QuestionOrder: questionInfo.QuestionOrder,
+		ContentFormat: questionInfo.ContentFormat,
 		CreatedAt:     questionInfo.CreatedAt,
+		Explanation:   ssg.Clone(questionInfo.Explanation),
+		Points:        questionInfo.Points,
+		Rendered:      rendered,
 	})
 }
 
@@ -596,6 +838,7 @@ func EditExamQuestionV1(c *fiber.Ctx) error {
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "Authorization token"
+// @Param Client-Device-ID header string false "Device id of the client taking the exam"
 // @Param data body GetExamQuestionsData true "Data needed to get questions of an exam"
 // @Success 200 {object} apiHandlers.EndpointResponse{result=GetExamQuestionsResult}
 // @Router /api/v1/exam/questions [post]
@@ -637,22 +880,129 @@ func GetExamQuestionsV1(c *fiber.Ctx) error {
 		userPov = userInfo.UserId
 	}
 
-	if !userInfo.CanPeekExamQuestions(examInfo.CreatedBy) &&
-		(!database.HasParticipatedInExam(userInfo.UserId, data.ExamId) ||
-			!examInfo.HasExamStarted()) {
-		return apiHandlers.SendErrNotParticipatedInExam(c)
-	}
-
-	questions, err := database.GetExamQuestions(&database.GetExamQuestionsData{
+	questionsFilter := &database.GetExamQuestionsData{
 		ExamId: data.ExamId,
 		Offset: data.Offset,
 		Limit:  data.Limit,
-	})
+	}
+	var sectionsInfo []*ExamSectionInfo
+	canPeekQuestions := userInfo.CanPeekExamQuestions(examInfo) ||
+		userInfo.CanReviewExam(examInfo)
+	if !canPeekQuestions {
+		accommodation := database.GetExamAccommodationOrNil(userInfo.UserId, data.ExamId)
+		if !database.HasParticipatedInExam(userInfo.UserId, data.ExamId) ||
+			!examInfo.HasExamStartedFor(accommodation) {
+			return apiHandlers.SendErrNotParticipatedInExam(c)
+		}
+
+		if !examInfo.HasExamFinishedFor(accommodation) {
+			if examInfo.IsAdaptive {
+				// the question bank of the exam must not be revealed while
+				// it's still running
+				return apiHandlers.SendErrAdaptiveExamQuestions(c)
+			}
+
+			deviceId := getClientDeviceId(c)
+			if !isDeviceIdValid(deviceId) {
+				return apiHandlers.SendErrInvalidDeviceId(c, deviceId)
+			}
+
+			attempt, clientStatus, err := checkAttemptClient(c, userInfo.UserId, examInfo, deviceId)
+			if err != nil {
+				logging.UnexpectedError("GetExamQuestions: Failed to check attempt client:", err)
+				return apiHandlers.SendErrInternalServerError(c)
+			} else if clientStatus != attemptClientAllowed {
+				return sendAttemptClientError(c, clientStatus)
+			}
+
+			allProgress, err := database.GetExamSectionsProgress(examInfo, accommodation, attempt)
+			if err != nil {
+				logging.UnexpectedError("GetExamQuestions: Failed to get exam sections progress:", err)
+				return apiHandlers.SendErrInternalServerError(c)
+			}
+
+			if len(allProgress) > 0 {
+				// only the questions of the sections the user is allowed to
+				// be in can be seen while taking the exam
+				questionsFilter.FilterSections = true
+				questionsFilter.IncludeUnsectioned = data.SectionId == nil
+				for _, current := range allProgress {
+					if !current.IsAnswerable() ||
+						(data.SectionId != nil && *data.SectionId != current.Section.SectionId) {
+						continue
+					}
+					questionsFilter.SectionIds = append(questionsFilter.SectionIds, current.Section.SectionId)
+				}
+
+				if data.SectionId != nil && len(questionsFilter.SectionIds) == 0 {
+					if findSectionProgress(allProgress, *data.SectionId) == nil {
+						return apiHandlers.SendErrExamSectionNotFound(c)
+					}
+					return apiHandlers.SendErrExamSectionClosed(c)
+				}
+
+				sectionsInfo = toExamSectionsInfo(allProgress)
+			}
+		}
+	}
+
+	if data.SectionId != nil && !questionsFilter.FilterSections {
+		questionsFilter.FilterSections = true
+		questionsFilter.SectionIds = []int{*data.SectionId}
+	}
+
+	if sectionsInfo == nil {
+		sections, err := database.GetExamSections(data.ExamId)
+		if err != nil {
+			logging.UnexpectedError("GetExamQuestions: Failed to get exam sections:", err)
+			return apiHandlers.SendErrInternalServerError(c)
+		}
+
+		sectionsInfo = make([]*ExamSectionInfo, 0, len(sections))
+		for _, section := range sections {
+			sectionsInfo = append(sectionsInfo, toExamSectionInfo(section, nil))
+		}
+	}
+
+	attemptNumber := data.AttemptNumber
+	if attemptNumber == 0 {
+		attemptNumber = 1
+		latestAttempt := database.GetLatestExamAttemptOrNil(userPov, data.ExamId)
+		if latestAttempt != nil {
+			attemptNumber = latestAttempt.AttemptNumber
+		}
+	}
+
+	questions, err := database.GetExamQuestions(questionsFilter)
 	if err != nil && err != pgx.ErrNoRows {
 		logging.UnexpectedError("GetExamQuestions: Failed to get exam questions:", err)
 		return apiHandlers.SendErrInternalServerError(c)
 	}
 
+	questionIds := make([]int, 0, len(questions))
+	for _, q := range questions {
+		questionIds = append(questionIds, q.QuestionId)
+	}
+
+	attachmentsMap := make(map[int][]*ExamAttachmentInfo)
+	if len(questionIds) > 0 {
+		attachments, err := database.GetQuestionsAttachments(&database.GetQuestionsAttachmentsOptions{
+			QuestionIds:   questionIds,
+			AnsweredBy:    userPov,
+			AttemptNumber: attemptNumber,
+		})
+		if err != nil {
+			logging.UnexpectedError("GetExamQuestions: Failed to get questions attachments:", err)
+			return apiHandlers.SendErrInternalServerError(c)
+		}
+
+		for _, attachment := range attachments {
+			attachmentsMap[attachment.QuestionId] = append(
+				attachmentsMap[attachment.QuestionId], toExamAttachmentInfo(attachment),
+			)
+		}
+	}
+
 	questionsInfo := make([]*ExamQuestionInfo, 0, len(questions))
 	for _, q := range questions {
 		info := &ExamQuestionInfo{
@@ -663,13 +1013,25 @@ func GetExamQuestionsV1(c *fiber.Ctx) error {
 			Option2:       q.Option2,
 			Option3:       q.Option3,
 			Option4:       q.Option4,
+			SectionId:     ssg.Clone(q.SectionId),
+			QuestionOrder: q.QuestionOrder,
+			ContentFormat: q.ContentFormat,
 			CreatedAt:     q.CreatedAt,
+			Attachments:   attachmentsMap[q.QuestionId],
+			Points:        q.Points,
+		}
+		if data.RenderHtml {
+			info.Rendered = renderQuestionContent(q)
+		}
+		if canPeekQuestions {
+			info.Metadata = toExamQuestionMetadataInfo(q)
 		}
 
 		givenAnswer := database.GetGivenAnswerOrNil(&database.GetGivenAnswerData{
-			ExamId:     q.ExamId,
-			QuestionId: q.QuestionId,
-			UserId:     userPov,
+			ExamId:        q.ExamId,
+			QuestionId:    q.QuestionId,
+			UserId:        userPov,
+			AttemptNumber: attemptNumber,
 		})
 		if givenAnswer != nil {
 			info.UserAnswer = &AnsweredQuestionInfo{
@@ -680,13 +1042,23 @@ func GetExamQuestionsV1(c *fiber.Ctx) error {
 				AnswerText:   ssg.Clone(givenAnswer.AnswerText),
 			}
 		}
+
+		if canPeekQuestions || (examInfo.IsPractice && info.UserAnswer != nil) {
+			// practice exams reveal the explanation once it's answered
+			info.Explanation = ssg.Clone(q.Explanation)
+			if info.Rendered != nil {
+				info.Rendered.Explanation = renderQuestionExplanation(q)
+			}
+		}
 		questionsInfo = append(questionsInfo, info)
 	}
 
 	return apiHandlers.SendResult(c, &GetExamQuestionsResult{
-		Pov:       userPov,
-		ExamId:    data.ExamId,
-		Questions: questionsInfo,
+		Pov:           userPov,
+		ExamId:        data.ExamId,
+		AttemptNumber: attemptNumber,
+		Questions:     questionsInfo,
+		Sections:      sectionsInfo,
 	})
 }
 
@@ -698,6 +1070,7 @@ func GetExamQuestionsV1(c *fiber.Ctx) error {
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "Authorization token"
+// @Param Client-Device-ID header string false "Device id of the client taking the exam"
 // @Param data body AnswerQuestionData true "Data needed to answer a question of an exam"
 // @Success 200 {object} apiHandlers.EndpointResponse{result=AnswerQuestionResult}
 // @Router /api/v1/exam/answer [post]
@@ -733,9 +1106,10 @@ func AnswerExamQuestionV1(c *fiber.Ctx) error {
 		return apiHandlers.SendErrExamNotFound(c)
 	}
 
-	if !examInfo.HasExamStarted() {
+	accommodation := database.GetExamAccommodationOrNil(userInfo.UserId, data.ExamId)
+	if !examInfo.HasExamStartedFor(accommodation) {
 		return apiHandlers.SendErrExamNotStarted(c)
-	} else if examInfo.HasExamFinished() {
+	} else if examInfo.HasExamFinishedFor(accommodation) {
 		return apiHandlers.SendErrExamFinished(c)
 	}
 
@@ -755,17 +1129,39 @@ func AnswerExamQuestionV1(c *fiber.Ctx) error {
 		return apiHandlers.SendErrNotParticipatedInExam(c)
 	}
 
+	deviceId := getClientDeviceId(c)
+	if !isDeviceIdValid(deviceId) {
+		return apiHandlers.SendErrInvalidDeviceId(c, deviceId)
+	}
+
+	attempt, clientStatus, err := checkAttemptClient(c, userInfo.UserId, examInfo, deviceId)
+	if err != nil {
+		logging.UnexpectedError("AnswerQuestion: Failed to check attempt client:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	} else if clientStatus != attemptClientAllowed {
+		return sendAttemptClientError(c, clientStatus)
+	}
+
+	answerStatus, err := checkQuestionAnswerable(examInfo, accommodation, attempt, question)
+	if err != nil {
+		logging.UnexpectedError("AnswerQuestion: Failed to check if the question is answerable:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	} else if answerStatus != questionAnswerable {
+		return sendQuestionAnswerError(c, answerStatus)
+	}
+
 	if data.ChosenOption != nil && !question.HasOption(*data.ChosenOption) {
 		return apiHandlers.SendErrInvalidAnswerOption(c)
 	}
 
 	givenAnswer, err := database.AnswerQuestion(&database.AnswerQuestionData{
-		ExamId:       data.ExamId,
-		QuestionId:   data.QuestionId,
-		AnsweredBy:   userInfo.UserId,
-		ChosenOption: data.ChosenOption,
-		SecondsTaken: data.SecondsTaken,
-		AnswerText:   data.AnswerText,
+		ExamId:        data.ExamId,
+		QuestionId:    data.QuestionId,
+		AnsweredBy:    userInfo.UserId,
+		AttemptNumber: attempt.AttemptNumber,
+		ChosenOption:  data.ChosenOption,
+		SecondsTaken:  data.SecondsTaken,
+		AnswerText:    data.AnswerText,
 	})
 	if err != nil {
 		logging.UnexpectedError("AnswerQuestion: Failed to answer question:", err)
@@ -775,12 +1171,28 @@ func AnswerExamQuestionV1(c *fiber.Ctx) error {
 		return apiHandlers.SendErrInternalServerError(c)
 	}
 
-	return apiHandlers.SendResult(c, &AnswerQuestionResult{
-		ExamId:     givenAnswer.ExamId,
-		QuestionId: givenAnswer.QuestionId,
-		AnsweredBy: givenAnswer.AnsweredBy,
-		AnsweredAt: givenAnswer.AnsweredAt,
-	})
+	if examInfo.IsAdaptive {
+		_, err = database.RecordExamAttemptItemAnswer(
+			attempt, question, question.IsCorrectOption(data.ChosenOption),
+		)
+		if err != nil {
+			logging.UnexpectedError("AnswerQuestion: Failed to record adaptive answer:", err)
+			return apiHandlers.SendErrInternalServerError(c)
+		}
+	}
+
+	result := &AnswerQuestionResult{
+		ExamId:        givenAnswer.ExamId,
+		QuestionId:    givenAnswer.QuestionId,
+		AnsweredBy:    givenAnswer.AnsweredBy,
+		AttemptNumber: givenAnswer.AttemptNumber,
+		AnsweredAt:    givenAnswer.AnsweredAt,
+	}
+	if examInfo.IsPractice {
+		result.Feedback = toAnswerFeedbackInfo(question, data.ChosenOption)
+	}
+
+	return apiHandlers.SendResult(c, result)
 }
 
 // SetExamScoreV1 godoc
@@ -830,17 +1242,28 @@ func SetExamScoreV1(c *fiber.Ctx) error {
 
 	if !userInfo.CanSetScoreForExam(examInfo) {
 		return apiHandlers.SendErrPermissionDenied(c)
+	} else if examInfo.IsPractice {
+		return apiHandlers.SendErrPracticeExamNotGraded(c)
 	}
 
 	if !database.HasParticipatedInExam(data.UserId, data.ExamId) {
 		return apiHandlers.SendErrNotParticipatedInExam(c)
 	}
 
+	if data.AttemptNumber != 0 {
+		latestAttempt := database.GetLatestExamAttemptOrNil(data.UserId, data.ExamId)
+		if data.AttemptNumber < 0 || latestAttempt == nil ||
+			data.AttemptNumber > latestAttempt.AttemptNumber {
+			return apiHandlers.SendErrAttemptNotFound(c)
+		}
+	}
+
 	scoreInfo, err := database.SetScoreForUserInExam(&database.NewScoreData{
-		ExamId:     data.ExamId,
-		UserId:     data.UserId,
-		FinalScore: data.Score,
-		ScoredBy:   userInfo.UserId,
+		ExamId:        data.ExamId,
+		UserId:        data.UserId,
+		FinalScore:    data.Score,
+		ScoredBy:      userInfo.UserId,
+		AttemptNumber: data.AttemptNumber,
 	})
 
 	if err != nil {
@@ -852,10 +1275,11 @@ func SetExamScoreV1(c *fiber.Ctx) error {
 	}
 
 	return apiHandlers.SendResult(c, &SetExamScoreResult{
-		ExamId:   scoreInfo.ExamId,
-		UserId:   scoreInfo.UserId,
-		Score:    *scoreInfo.FinalScore,
-		ScoredBy: *scoreInfo.ScoredBy,
+		ExamId:     scoreInfo.ExamId,
+		UserId:     scoreInfo.UserId,
+		Score:      data.Score,
+		ScoredBy:   userInfo.UserId,
+		FinalScore: ssg.Clone(scoreInfo.FinalScore),
 	})
 }
 
@@ -915,7 +1339,7 @@ func GetGivenExamV1(c *fiber.Ctx) error {
 
 // GetUserOngoingExamsV1 godoc
 // @Summary Get ongoing exams of a user
-// @Description Allows the user to get ongoing exams of a user.
+// @Description Allows the user to get the exams that are still open for a user (upcoming, in progress, or still possible to start within their window).
 // @ID getUserOngoingExamsV1
 // @Tags Exam
 // @Accept json
@@ -959,6 +1383,7 @@ func GetUserOngoingExamsV1(c *fiber.Ctx) error {
 			ExamId:    exam.ExamId,
 			ExamTitle: exam.ExamTitle,
 			StartTime: exam.StartTime,
+			EndTime:   exam.EndTime,
 		})
 	}
 
@@ -1023,3 +1448,5083 @@ func GetUserExamsHistoryV1(c *fiber.Ctx) error {
 		Exams: examsInfo,
 	})
 }
+
+// ResetAttemptBindingV1 godoc
+// @Summary Reset the client binding of an exam attempt
+// @Description Allows a teacher to unbind the attempt of a user from its client, so the user can continue the exam on another device.
+// @ID resetAttemptBindingV1
+// @Tags Exam
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param data body ResetAttemptBindingData true "Data needed to reset the client binding of an exam attempt"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=ResetAttemptBindingResult}
+// @Router /api/v1/exam/resetAttemptBinding [post]
+func ResetAttemptBindingV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	}
+
+	data := &ResetAttemptBindingData{}
+	if err := c.BodyParser(data); err != nil {
+		return apiHandlers.SendErrInvalidBodyData(c)
+	}
+
+	if data.ExamId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "exam_id")
+	} else if data.UserId == "" {
+		return apiHandlers.SendErrParameterRequired(c, "user_id")
+	}
+
+	examInfo := database.GetExamInfoOrNil(data.ExamId)
+	if examInfo == nil {
+		return apiHandlers.SendErrExamNotFound(c)
+	}
+
+	if !userInfo.CanResetAttemptBinding(examInfo) {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	err := database.ResetExamAttemptBinding(data.UserId, data.ExamId)
+	if err != nil {
+		if err == database.ErrExamAttemptBindingNotFound {
+			return apiHandlers.SendErrAttemptBindingNotFound(c)
+		}
+
+		logging.UnexpectedError("ResetAttemptBinding: Failed to reset attempt binding:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	return apiHandlers.SendResult(c, &ResetAttemptBindingResult{
+		ExamId:  data.ExamId,
+		UserId:  data.UserId,
+		ResetBy: userInfo.UserId,
+	})
+}
+
+// StartExamAttemptV1 godoc
+// @Summary Start an exam attempt
+// @Description Allows the user to start a new attempt of an exam (or resume their ongoing one), binding it to the current client (its device id; the changes of its ip address are only recorded). If the exam is protected by an access code, the code has to be provided.
+// @ID startExamAttemptV1
+// @Tags Exam
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param Client-Device-ID header string true "Device id of the client taking the exam"
+// @Param data body StartExamAttemptData true "Data needed to start an exam attempt"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=StartExamAttemptResult}
+// @Router /api/v1/exam/startAttempt [post]
+func StartExamAttemptV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	}
+
+	data := &StartExamAttemptData{}
+	if err := c.BodyParser(data); err != nil {
+		return apiHandlers.SendErrInvalidBodyData(c)
+	}
+
+	if data.ExamId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "exam_id")
+	}
+
+	deviceId := getClientDeviceId(c)
+	if !isDeviceIdValid(deviceId) {
+		return apiHandlers.SendErrInvalidDeviceId(c, deviceId)
+	}
+
+	examInfo := database.GetExamInfoOrNil(data.ExamId)
+	if examInfo == nil {
+		return apiHandlers.SendErrExamNotFound(c)
+	}
+
+	accommodation := database.GetExamAccommodationOrNil(userInfo.UserId, data.ExamId)
+	if !examInfo.HasExamStartedFor(accommodation) {
+		return apiHandlers.SendErrExamNotStarted(c)
+	} else if examInfo.HasExamFinishedFor(accommodation) {
+		return apiHandlers.SendErrExamFinished(c)
+	}
+
+	if !database.HasParticipatedInExam(userInfo.UserId, data.ExamId) {
+		return apiHandlers.SendErrNotParticipatedInExam(c)
+	}
+
+	attempt, err := database.GetLatestExamAttempt(userInfo.UserId, data.ExamId)
+	if err != nil && err != database.ErrExamAttemptNotFound {
+		logging.UnexpectedError("StartExamAttempt: Failed to get latest attempt:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	err = submitExpiredAttempt(examInfo, accommodation, attempt)
+	if err != nil {
+		logging.UnexpectedError("StartExamAttempt: Failed to submit expired attempt:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	startNew := attempt == nil || attempt.IsFinished()
+	if startNew && !examInfo.CanStartAttemptFor(accommodation) {
+		return apiHandlers.SendErrLateStartNotAllowed(c)
+	} else if startNew && attempt != nil {
+		if examInfo.GetAttemptsLeft(attempt) == 0 {
+			return apiHandlers.SendErrNoAttemptsLeft(c)
+		} else if waitTime := examInfo.RetakeAvailableIn(attempt); waitTime > 0 {
+			return apiHandlers.SendErrRetakeCooldown(c, waitTime)
+		}
+	}
+
+	if examInfo.RequiresAccessCode() {
+		if isAccessCodeRateLimited(userInfo.UserId, data.ExamId) {
+			return apiHandlers.SendErrTooManyAccessCodeAttempts(c)
+		} else if !verifyAccessCode(userInfo.UserId, examInfo, data.AccessCode) {
+			return apiHandlers.SendErrInvalidAccessCode(c)
+		}
+	}
+
+	if startNew {
+		attempt, err = database.StartExamAttempt(userInfo.UserId, data.ExamId)
+		if err != nil {
+			logging.UnexpectedError("StartExamAttempt: Failed to start exam attempt:", err)
+			return apiHandlers.SendErrInternalServerError(c)
+		}
+	}
+
+	clientData := &database.ExamAttemptClientData{
+		ExamId:    data.ExamId,
+		UserId:    userInfo.UserId,
+		IPAddress: fUtils.CopyString(c.IP()),
+		DeviceId:  deviceId,
+	}
+	binding, err := database.BindExamAttempt(clientData)
+	if err != nil {
+		logging.UnexpectedError("StartExamAttempt: Failed to bind exam attempt:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	if !binding.IsSameClient(clientData.DeviceId) {
+		err = database.FlagExamAttemptMismatch(clientData)
+		if err != nil {
+			logging.UnexpectedError("StartExamAttempt: Failed to flag attempt client:", err)
+			return apiHandlers.SendErrInternalServerError(c)
+		}
+
+		if appConfig.ShouldRejectForeignExamClients() {
+			return apiHandlers.SendErrForeignAttemptClient(c)
+		}
+	} else if binding.HasIPChanged(clientData.IPAddress) {
+		err = database.RecordExamAttemptIPChange(clientData)
+		if err != nil {
+			logging.UnexpectedError("StartExamAttempt: Failed to record ip change of attempt client:", err)
+			return apiHandlers.SendErrInternalServerError(c)
+		}
+	}
+
+	return apiHandlers.SendResult(c, &StartExamAttemptResult{
+		ExamId:        data.ExamId,
+		UserId:        userInfo.UserId,
+		AttemptNumber: attempt.AttemptNumber,
+		StartedAt:     attempt.StartedAt,
+		BoundAt:       binding.BoundAt,
+		FinishesIn:    examInfo.DeadlineInFor(accommodation, attempt),
+	})
+}
+
+// SetExamAccessCodeV1 godoc
+// @Summary Set the access code of an exam
+// @Description Allows the user to protect an exam with a static or rotating access code (or remove it).
+// @ID setExamAccessCodeV1
+// @Tags Exam
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param data body SetExamAccessCodeData true "Data needed to set the access code of an exam"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=ExamAccessCodeResult}
+// @Router /api/v1/exam/setAccessCode [post]
+func SetExamAccessCodeV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	} else if !userInfo.CanTryToEditExam() {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	data := &SetExamAccessCodeData{}
+	if err := c.BodyParser(data); err != nil {
+		return apiHandlers.SendErrInvalidBodyData(c)
+	}
+
+	if data.ExamId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "exam_id")
+	}
+
+	examInfo := database.GetExamInfoOrNil(data.ExamId)
+	if examInfo == nil {
+		return apiHandlers.SendErrExamNotFound(c)
+	}
+
+	if !userInfo.CanEditExam(examInfo) {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	var accessCode *string
+	switch data.AccessCodeType {
+	case database.AccessCodeTypeNone:
+		accessCode = nil
+	case database.AccessCodeTypeStatic:
+		data.AccessCode = strings.TrimSpace(data.AccessCode)
+		if data.AccessCode == "" {
+			return apiHandlers.SendErrParameterRequired(c, "access_code")
+		} else if len(data.AccessCode) > database.MaxAccessCodeLength {
+			return apiHandlers.SendErrBodyTooLong(c)
+		}
+		accessCode = &data.AccessCode
+	case database.AccessCodeTypeRotating:
+		secret := hashing.RandomString(RotatingAccessCodeSecretLength)
+		accessCode = &secret
+	default:
+		return apiHandlers.SendErrInvalidAccessCodeType(c, data.AccessCodeType)
+	}
+
+	examInfo, err := database.SetExamAccessCode(&database.SetExamAccessCodeData{
+		ExamId:             data.ExamId,
+		AccessCodeType:     data.AccessCodeType,
+		AccessCode:         accessCode,
+		AccessCodeInterval: data.RotationInterval,
+	})
+	if err != nil {
+		logging.UnexpectedError("SetExamAccessCode: Failed to set exam access code:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	return apiHandlers.SendResult(c, toExamAccessCodeResult(examInfo))
+}
+
+// GetExamAccessCodeV1 godoc
+// @Summary Get the current access code of an exam
+// @Description Allows the invigilator to get the access code of an exam that has to be announced right now.
+// @ID getExamAccessCodeV1
+// @Tags Exam
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param id query int true "Exam ID"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=ExamAccessCodeResult}
+// @Router /api/v1/exam/accessCode [get]
+func GetExamAccessCodeV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	}
+
+	examId := c.QueryInt("id")
+	if examId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "id")
+	}
+
+	examInfo := database.GetExamInfoOrNil(examId)
+	if examInfo == nil {
+		return apiHandlers.SendErrExamNotFound(c)
+	}
+
+	if !userInfo.CanEditExam(examInfo) {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	return apiHandlers.SendResult(c, toExamAccessCodeResult(examInfo))
+}
+
+// AddExamPrerequisiteV1 godoc
+// @Summary Add a prerequisite to an exam
+// @Description Allows the user to add a prerequisite to an exam; either passing another exam or reaching a level in a topic.
+// @ID addExamPrerequisiteV1
+// @Tags Exam
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param data body AddExamPrerequisiteData true "Data needed to add a prerequisite to an exam"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=ExamPrerequisiteInfo}
+// @Router /api/v1/exam/addPrerequisite [post]
+func AddExamPrerequisiteV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	} else if !userInfo.CanTryToEditExam() {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	data := &AddExamPrerequisiteData{}
+	if err := c.BodyParser(data); err != nil {
+		return apiHandlers.SendErrInvalidBodyData(c)
+	}
+
+	if data.ExamId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "exam_id")
+	}
+
+	examInfo := database.GetExamInfoOrNil(data.ExamId)
+	if examInfo == nil {
+		return apiHandlers.SendErrExamNotFound(c)
+	}
+
+	if !userInfo.CanEditExam(examInfo) {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	switch data.PrerequisiteType {
+	case database.PrerequisiteTypeExam:
+		if data.RequiredExamId == nil || *data.RequiredExamId == 0 {
+			return apiHandlers.SendErrParameterRequired(c, "required_exam_id")
+		} else if *data.RequiredExamId == data.ExamId {
+			return apiHandlers.SendErrInvalidPrerequisite(c, "an exam cannot require itself")
+		} else if database.GetExamInfoOrNil(*data.RequiredExamId) == nil {
+			return apiHandlers.SendErrExamNotFound(c)
+		}
+
+		if data.MinScore == nil {
+			minScore := float64(database.DefaultPrerequisiteMinScore)
+			data.MinScore = &minScore
+		} else if *data.MinScore < 0 || *data.MinScore > 100 {
+			return apiHandlers.SendErrInvalidPrerequisite(c, "min_score has to be between 0 and 100")
+		}
+
+		data.TopicId = nil
+		data.MinLevel = nil
+	case database.PrerequisiteTypeTopicLevel:
+		if data.TopicId == nil || *data.TopicId == 0 {
+			return apiHandlers.SendErrParameterRequired(c, "topic_id")
+		} else if data.MinLevel == nil {
+			return apiHandlers.SendErrParameterRequired(c, "min_level")
+		} else if *data.MinLevel < 1 {
+			return apiHandlers.SendErrInvalidPrerequisite(c, "min_level has to be at least 1")
+		}
+
+		_, err := database.GetTopicInfo(*data.TopicId)
+		if err == database.ErrTopicNotFound {
+			return apiHandlers.SendErrTopicNotFound(c)
+		} else if err != nil {
+			logging.UnexpectedError("AddExamPrerequisite: Failed to get topic info:", err)
+			return apiHandlers.SendErrInternalServerError(c)
+		}
+
+		data.RequiredExamId = nil
+		data.MinScore = nil
+	default:
+		return apiHandlers.SendErrInvalidPrerequisiteType(c, data.PrerequisiteType)
+	}
+
+	prerequisite, err := database.AddExamPrerequisite(&database.NewExamPrerequisiteData{
+		ExamId:           data.ExamId,
+		PrerequisiteType: data.PrerequisiteType,
+		RequiredExamId:   data.RequiredExamId,
+		MinScore:         data.MinScore,
+		TopicId:          data.TopicId,
+		MinLevel:         data.MinLevel,
+		AddedBy:          userInfo.UserId,
+	})
+	if err != nil {
+		logging.UnexpectedError("AddExamPrerequisite: Failed to add exam prerequisite:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	return apiHandlers.SendResult(c, toExamPrerequisitesInfo(
+		[]*database.ExamPrerequisite{prerequisite},
+	)[0])
+}
+
+// RemoveExamPrerequisiteV1 godoc
+// @Summary Remove a prerequisite from an exam
+// @Description Allows the user to remove a prerequisite from an exam.
+// @ID removeExamPrerequisiteV1
+// @Tags Exam
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param data body RemoveExamPrerequisiteData true "Data needed to remove a prerequisite from an exam"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=RemoveExamPrerequisiteResult}
+// @Router /api/v1/exam/removePrerequisite [post]
+func RemoveExamPrerequisiteV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	} else if !userInfo.CanTryToEditExam() {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	data := &RemoveExamPrerequisiteData{}
+	if err := c.BodyParser(data); err != nil {
+		return apiHandlers.SendErrInvalidBodyData(c)
+	}
+
+	if data.ExamId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "exam_id")
+	} else if data.PrerequisiteId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "prerequisite_id")
+	}
+
+	examInfo := database.GetExamInfoOrNil(data.ExamId)
+	if examInfo == nil {
+		return apiHandlers.SendErrExamNotFound(c)
+	}
+
+	if !userInfo.CanEditExam(examInfo) {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	err := database.RemoveExamPrerequisite(data.ExamId, data.PrerequisiteId)
+	if err == database.ErrExamPrerequisiteNotFound {
+		return apiHandlers.SendErrPrerequisiteNotFound(c)
+	} else if err != nil {
+		logging.UnexpectedError("RemoveExamPrerequisite: Failed to remove exam prerequisite:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	return apiHandlers.SendResult(c, &RemoveExamPrerequisiteResult{
+		ExamId:         data.ExamId,
+		PrerequisiteId: data.PrerequisiteId,
+		Removed:        true,
+	})
+}
+
+// GetExamPrerequisitesV1 godoc
+// @Summary Get prerequisites of an exam
+// @Description Allows the user to get all of the prerequisites of an exam.
+// @ID getExamPrerequisitesV1
+// @Tags Exam
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param id query int true "Exam ID"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=GetExamPrerequisitesResult}
+// @Router /api/v1/exam/prerequisites [get]
+func GetExamPrerequisitesV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	} else if !userInfo.CanGetExamInfo() {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	examId := c.QueryInt("id")
+	if examId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "id")
+	}
+
+	examInfo := database.GetExamInfoOrNil(examId)
+	if examInfo == nil {
+		return apiHandlers.SendErrExamNotFound(c)
+	}
+
+	prerequisites, err := database.GetExamPrerequisites(examId)
+	if err != nil {
+		logging.UnexpectedError("GetExamPrerequisites: Failed to get exam prerequisites:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	return apiHandlers.SendResult(c, &GetExamPrerequisitesResult{
+		ExamId:        examId,
+		Prerequisites: toExamPrerequisitesInfo(prerequisites),
+	})
+}
+
+// FinishExamAttemptV1 godoc
+// @Summary Finish an exam attempt
+// @Description Allows the user to finish their ongoing attempt of an exam, so they can retake the exam later (if the retake policy of the exam allows it).
+// @ID finishExamAttemptV1
+// @Tags Exam
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param Client-Device-ID header string true "Device id of the client taking the exam"
+// @Param data body FinishExamAttemptData true "Data needed to finish an exam attempt"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=FinishExamAttemptResult}
+// @Router /api/v1/exam/finishAttempt [post]
+func FinishExamAttemptV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	}
+
+	data := &FinishExamAttemptData{}
+	if err := c.BodyParser(data); err != nil {
+		return apiHandlers.SendErrInvalidBodyData(c)
+	}
+
+	if data.ExamId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "exam_id")
+	}
+
+	deviceId := getClientDeviceId(c)
+	if !isDeviceIdValid(deviceId) {
+		return apiHandlers.SendErrInvalidDeviceId(c, deviceId)
+	}
+
+	examInfo := database.GetExamInfoOrNil(data.ExamId)
+	if examInfo == nil {
+		return apiHandlers.SendErrExamNotFound(c)
+	}
+
+	if !database.HasParticipatedInExam(userInfo.UserId, data.ExamId) {
+		return apiHandlers.SendErrNotParticipatedInExam(c)
+	}
+
+	attempt, err := database.GetLatestExamAttempt(userInfo.UserId, data.ExamId)
+	if err == database.ErrExamAttemptNotFound {
+		return apiHandlers.SendErrAttemptNotStarted(c)
+	} else if err == nil {
+		accommodation := database.GetExamAccommodationOrNil(userInfo.UserId, data.ExamId)
+		err = submitExpiredAttempt(examInfo, accommodation, attempt)
+	}
+
+	if err != nil {
+		logging.UnexpectedError("FinishExamAttempt: Failed to get latest attempt:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	} else if attempt.IsFinished() {
+		return apiHandlers.SendErrAttemptFinished(c)
+	}
+
+	binding := database.GetExamAttemptBindingOrNil(userInfo.UserId, data.ExamId)
+	if binding != nil && !binding.IsSameClient(deviceId) &&
+		appConfig.ShouldRejectForeignExamClients() {
+		return apiHandlers.SendErrForeignAttemptClient(c)
+	}
+
+	err = database.FinishExamAttempt(attempt)
+	if err != nil {
+		logging.UnexpectedError("FinishExamAttempt: Failed to finish exam attempt:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	return apiHandlers.SendResult(c, &FinishExamAttemptResult{
+		ExamId:            data.ExamId,
+		UserId:            userInfo.UserId,
+		AttemptNumber:     attempt.AttemptNumber,
+		StartedAt:         attempt.StartedAt,
+		FinishedAt:        *attempt.FinishedAt,
+		AttemptsLeft:      examInfo.GetAttemptsLeft(attempt),
+		RetakeAvailableIn: examInfo.RetakeAvailableIn(attempt),
+	})
+}
+
+// GetExamAttemptsV1 godoc
+// @Summary Get attempts of a user in an exam
+// @Description Allows the user to get all of the attempts (and their scores) of a user in an exam.
+// @ID getExamAttemptsV1
+// @Tags Exam
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param id query int true "Exam ID"
+// @Param targetId query string false "Target user id"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=GetExamAttemptsResult}
+// @Router /api/v1/exam/attempts [get]
+func GetExamAttemptsV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	}
+
+	examId := c.QueryInt("id")
+	if examId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "id")
+	}
+
+	examInfo := database.GetExamInfoOrNil(examId)
+	if examInfo == nil {
+		return apiHandlers.SendErrExamNotFound(c)
+	}
+
+	// optional: provide another user's id to see their attempts
+	targetUserId := c.Query("targetId")
+	if targetUserId == "" {
+		targetUserId = userInfo.UserId
+	} else if targetUserId != userInfo.UserId &&
+		!userInfo.CanSetScoreForExam(examInfo) {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	givenExam, err := database.GetGivenExam(targetUserId, examId)
+	if err == database.ErrGivenExamNotFound || givenExam == nil {
+		return apiHandlers.SendErrGivenExamNotFound(c)
+	} else if err != nil {
+		logging.UnexpectedError("GetExamAttempts: Failed to get given exam info:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	attempts, err := database.GetExamAttempts(targetUserId, examId)
+	if err != nil {
+		logging.UnexpectedError("GetExamAttempts: Failed to get exam attempts:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	attemptsInfo := make([]*ExamAttemptInfo, 0, len(attempts))
+	for _, attempt := range attempts {
+		attemptsInfo = append(attemptsInfo, toExamAttemptInfo(attempt))
+	}
+
+	return apiHandlers.SendResult(c, &GetExamAttemptsResult{
+		ExamId:     examId,
+		UserId:     targetUserId,
+		FinalScore: ssg.Clone(givenExam.FinalScore),
+		Attempts:   attemptsInfo,
+	})
+}
+
+// SetExamRetakePolicyV1 godoc
+// @Summary Set the retake policy of an exam
+// @Description Allows the user to set the max number of attempts, the cooldown between attempts and the grading policy of an exam.
+// @ID setExamRetakePolicyV1
+// @Tags Exam
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param data body SetExamRetakePolicyData true "Data needed to set the retake policy of an exam"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=ExamRetakePolicyResult}
+// @Router /api/v1/exam/setRetakePolicy [post]
+func SetExamRetakePolicyV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	} else if !userInfo.CanTryToEditExam() {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	data := &SetExamRetakePolicyData{}
+	if err := c.BodyParser(data); err != nil {
+		return apiHandlers.SendErrInvalidBodyData(c)
+	}
+
+	if data.ExamId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "exam_id")
+	} else if data.MaxAttempts < 0 || data.AttemptCooldown < 0 {
+		return apiHandlers.SendErrInvalidBodyData(c)
+	}
+
+	if data.GradingPolicy == "" {
+		data.GradingPolicy = database.DefaultGradingPolicy
+	} else if !database.IsGradingPolicyValid(data.GradingPolicy) {
+		return apiHandlers.SendErrInvalidGradingPolicy(c, data.GradingPolicy)
+	}
+
+	examInfo := database.GetExamInfoOrNil(data.ExamId)
+	if examInfo == nil {
+		return apiHandlers.SendErrExamNotFound(c)
+	}
+
+	if !userInfo.CanEditExam(examInfo) {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	examInfo, err := database.SetExamRetakePolicy(&database.SetExamRetakePolicyData{
+		ExamId:          data.ExamId,
+		MaxAttempts:     data.MaxAttempts,
+		AttemptCooldown: data.AttemptCooldown,
+		GradingPolicy:   data.GradingPolicy,
+	})
+	if err != nil {
+		logging.UnexpectedError("SetExamRetakePolicy: Failed to set exam retake policy:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	return apiHandlers.SendResult(c, &ExamRetakePolicyResult{
+		ExamId:          examInfo.ExamId,
+		MaxAttempts:     examInfo.MaxAttempts,
+		AttemptCooldown: examInfo.AttemptCooldown,
+		GradingPolicy:   examInfo.GradingPolicy,
+	})
+}
+
+// SetExamAccommodationV1 godoc
+// @Summary Set the accommodation of a user in an exam
+// @Description Allows the user to grant (or update) an accommodation (extra minutes, a time multiplier or a custom window) to a participant of an exam.
+// @ID setExamAccommodationV1
+// @Tags Exam
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param data body SetExamAccommodationData true "Data needed to set the accommodation of a user"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=ExamAccommodationInfo}
+// @Router /api/v1/exam/setAccommodation [post]
+func SetExamAccommodationV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	} else if !userInfo.CanTryToEditExam() {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	data := &SetExamAccommodationData{}
+	if err := c.BodyParser(data); err != nil {
+		return apiHandlers.SendErrInvalidBodyData(c)
+	}
+
+	if data.ExamId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "exam_id")
+	} else if data.UserId == "" {
+		return apiHandlers.SendErrParameterRequired(c, "user_id")
+	}
+
+	if data.TimeMultiplier == 0 {
+		data.TimeMultiplier = 1
+	}
+
+	if data.ExtraMinutes < 0 {
+		return apiHandlers.SendErrInvalidAccommodation(c, "extra_minutes cannot be negative")
+	} else if data.TimeMultiplier < 1 ||
+		data.TimeMultiplier > database.MaxAccommodationTimeMultiplier {
+		return apiHandlers.SendErrInvalidAccommodation(c, "time_multiplier is out of range")
+	} else if data.CustomStart != nil && data.CustomEnd != nil &&
+		*data.CustomEnd <= *data.CustomStart {
+		return apiHandlers.SendErrInvalidAccommodation(c, "custom_end has to be after custom_start")
+	} else if data.Note != nil && len(*data.Note) > database.MaxAccommodationNoteLength {
+		return apiHandlers.SendErrInvalidAccommodation(c, "note is too long")
+	}
+
+	examInfo := database.GetExamInfoOrNil(data.ExamId)
+	if examInfo == nil {
+		return apiHandlers.SendErrExamNotFound(c)
+	}
+
+	if !userInfo.CanSetExamAccommodation(examInfo) {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	if !database.HasParticipatedInExam(data.UserId, data.ExamId) {
+		return apiHandlers.SendErrNotParticipatedInExam(c)
+	}
+
+	newData := &database.SetExamAccommodationData{
+		ExamId:         data.ExamId,
+		UserId:         data.UserId,
+		ExtraMinutes:   data.ExtraMinutes,
+		TimeMultiplier: data.TimeMultiplier,
+		Note:           data.Note,
+		GrantedBy:      userInfo.UserId,
+	}
+	if data.CustomStart != nil {
+		customStart := time.Unix(*data.CustomStart, 0)
+		newData.CustomStart = &customStart
+	}
+	if data.CustomEnd != nil {
+		customEnd := time.Unix(*data.CustomEnd, 0)
+		newData.CustomEnd = &customEnd
+	}
+
+	accommodation, err := database.SetExamAccommodation(newData)
+	if err != nil {
+		logging.UnexpectedError("SetExamAccommodation: Failed to set exam accommodation:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	return apiHandlers.SendResult(c, toExamAccommodationInfo(examInfo, accommodation))
+}
+
+// RemoveExamAccommodationV1 godoc
+// @Summary Remove the accommodation of a user in an exam
+// @Description Allows the user to remove the accommodation of a participant of an exam.
+// @ID removeExamAccommodationV1
+// @Tags Exam
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param data body RemoveExamAccommodationData true "Data needed to remove the accommodation of a user"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=RemoveExamAccommodationResult}
+// @Router /api/v1/exam/removeAccommodation [post]
+func RemoveExamAccommodationV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	} else if !userInfo.CanTryToEditExam() {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	data := &RemoveExamAccommodationData{}
+	if err := c.BodyParser(data); err != nil {
+		return apiHandlers.SendErrInvalidBodyData(c)
+	}
+
+	if data.ExamId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "exam_id")
+	} else if data.UserId == "" {
+		return apiHandlers.SendErrParameterRequired(c, "user_id")
+	}
+
+	examInfo := database.GetExamInfoOrNil(data.ExamId)
+	if examInfo == nil {
+		return apiHandlers.SendErrExamNotFound(c)
+	}
+
+	if !userInfo.CanSetExamAccommodation(examInfo) {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	err := database.RemoveExamAccommodation(data.UserId, data.ExamId)
+	if err == database.ErrExamAccommodationNotFound {
+		return apiHandlers.SendErrAccommodationNotFound(c)
+	} else if err != nil {
+		logging.UnexpectedError("RemoveExamAccommodation: Failed to remove exam accommodation:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	return apiHandlers.SendResult(c, &RemoveExamAccommodationResult{
+		ExamId:  data.ExamId,
+		UserId:  data.UserId,
+		Removed: true,
+	})
+}
+
+// GetExamAccommodationsV1 godoc
+// @Summary Get the accommodations of an exam
+// @Description Allows the user to get all of the accommodations granted to the participants of an exam.
+// @ID getExamAccommodationsV1
+// @Tags Exam
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param id query int true "Exam ID"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=GetExamAccommodationsResult}
+// @Router /api/v1/exam/accommodations [get]
+func GetExamAccommodationsV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	}
+
+	examId := c.QueryInt("id")
+	if examId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "id")
+	}
+
+	examInfo := database.GetExamInfoOrNil(examId)
+	if examInfo == nil {
+		return apiHandlers.SendErrExamNotFound(c)
+	}
+
+	if !userInfo.CanSetExamAccommodation(examInfo) {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	accommodations, err := database.GetExamAccommodations(examId)
+	if err != nil {
+		logging.UnexpectedError("GetExamAccommodations: Failed to get exam accommodations:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	accommodationsInfo := make([]*ExamAccommodationInfo, 0, len(accommodations))
+	for _, accommodation := range accommodations {
+		accommodationsInfo = append(accommodationsInfo, toExamAccommodationInfo(examInfo, accommodation))
+	}
+
+	return apiHandlers.SendResult(c, &GetExamAccommodationsResult{
+		ExamId:         examId,
+		Accommodations: accommodationsInfo,
+	})
+}
+
+// SetExamAvailabilityV1 godoc
+// @Summary Set the availability of an exam
+// @Description Allows the user to set the availability window (opens at/closes at), the late start rule and the deadline policy of an exam.
+// @ID setExamAvailabilityV1
+// @Tags Exam
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param data body SetExamAvailabilityData true "Data needed to set the availability of an exam"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=ExamAvailabilityResult}
+// @Router /api/v1/exam/setAvailability [post]
+func SetExamAvailabilityV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	} else if !userInfo.CanTryToEditExam() {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	data := &SetExamAvailabilityData{}
+	if err := c.BodyParser(data); err != nil {
+		return apiHandlers.SendErrInvalidBodyData(c)
+	}
+
+	if data.ExamId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "exam_id")
+	} else if data.LateStartLimit != nil && *data.LateStartLimit < 0 {
+		return apiHandlers.SendErrInvalidAvailability(c, "late_start_limit cannot be negative")
+	} else if data.GracePeriod < 0 {
+		return apiHandlers.SendErrInvalidAvailability(c, "grace_period cannot be negative")
+	}
+
+	if data.DeadlinePolicy == "" {
+		data.DeadlinePolicy = database.DefaultDeadlinePolicy
+	} else if !database.IsDeadlinePolicyValid(data.DeadlinePolicy) {
+		return apiHandlers.SendErrInvalidDeadlinePolicy(c, data.DeadlinePolicy)
+	}
+
+	examInfo := database.GetExamInfoOrNil(data.ExamId)
+	if examInfo == nil {
+		return apiHandlers.SendErrExamNotFound(c)
+	}
+
+	if !userInfo.CanEditExam(examInfo) {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	newData := &database.SetExamAvailabilityData{
+		ExamId:         data.ExamId,
+		LateStartLimit: data.LateStartLimit,
+		DeadlinePolicy: data.DeadlinePolicy,
+		GracePeriod:    data.GracePeriod,
+	}
+
+	opensAt := examInfo.ExamDate
+	if data.OpensAt != nil {
+		opensAt = time.Unix(*data.OpensAt, 0)
+		newData.OpensAt = &opensAt
+	}
+
+	if data.ClosesAt != nil {
+		closesAt := time.Unix(*data.ClosesAt, 0)
+		if closesAt.Before(opensAt.Add(time.Minute * time.Duration(examInfo.Duration))) {
+			return apiHandlers.SendErrInvalidAvailability(c, "the window has to be at least as long as the exam")
+		}
+		newData.ClosesAt = &closesAt
+	}
+
+	examInfo, err := database.SetExamAvailability(newData)
+	if err != nil {
+		logging.UnexpectedError("SetExamAvailability: Failed to set exam availability:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	return apiHandlers.SendResult(c, &ExamAvailabilityResult{
+		ExamId:         examInfo.ExamId,
+		OpensAt:        examInfo.GetOpensAt(),
+		ClosesAt:       examInfo.GetClosesAt(),
+		IsWindowed:     examInfo.IsWindowed(),
+		LateStartLimit: ssg.Clone(examInfo.LateStartLimit),
+		DeadlinePolicy: examInfo.DeadlinePolicy,
+		GracePeriod:    examInfo.GracePeriod,
+	})
+}
+
+// CreateExamSeriesV1 godoc
+// @Summary Create an exam series
+// @Description Allows the user to create a series of exams which are recreated from a template exam based on a recurrence rule.
+// @ID createExamSeriesV1
+// @Tags Exam
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param data body CreateExamSeriesData true "Data needed to create an exam series"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=ExamSeriesInfo}
+// @Router /api/v1/exam/createSeries [post]
+func CreateExamSeriesV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	} else if !userInfo.CanTryToEditExam() {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	data := &CreateExamSeriesData{
+		GenerateAhead: database.DefaultSeriesGenerateAhead,
+	}
+	if err := c.BodyParser(data); err != nil {
+		return apiHandlers.SendErrInvalidBodyData(c)
+	}
+
+	if data.TemplateExamId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "template_exam_id")
+	} else if data.SeriesTitle == "" {
+		return apiHandlers.SendErrParameterRequired(c, "series_title")
+	} else if data.RecurrenceRule == "" {
+		return apiHandlers.SendErrParameterRequired(c, "recurrence_rule")
+	} else if len(data.SeriesTitle) > database.MaxExamTitleLength {
+		return apiHandlers.SendErrBodyTooLong(c)
+	} else if data.GenerateAhead < 0 || data.GenerateAhead > database.MaxSeriesGenerateAhead {
+		return apiHandlers.SendErrInvalidBodyData(c)
+	}
+
+	rule, err := recurrenceUtils.ParseRecurrenceRule(data.RecurrenceRule)
+	if err != nil {
+		return apiHandlers.SendErrInvalidRecurrenceRule(c, err.Error())
+	}
+
+	templateInfo := database.GetExamInfoOrNil(data.TemplateExamId)
+	if templateInfo == nil {
+		return apiHandlers.SendErrExamNotFound(c)
+	}
+
+	if !userInfo.CanEditExam(templateInfo) {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	startsAt := templateInfo.ExamDate
+	if data.StartsAt != nil {
+		startsAt = time.Unix(*data.StartsAt, 0)
+	}
+
+	series, err := database.CreateExamSeries(&database.NewExamSeriesData{
+		TemplateExamId: data.TemplateExamId,
+		SeriesTitle:    data.SeriesTitle,
+		RecurrenceRule: rule.String(),
+		StartsAt:       startsAt,
+		GenerateAhead:  data.GenerateAhead,
+		CreatedBy:      userInfo.UserId,
+	})
+	if err != nil {
+		logging.UnexpectedError("CreateExamSeries: Failed to create exam series:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	// don't make the teacher wait for the scheduler to create the
+	// first occurrences.
+	_, err = database.GenerateExamSeriesOccurrences(series, time.Now())
+	if err != nil {
+		logging.UnexpectedError("CreateExamSeries: Failed to generate exam series occurrences:", err)
+	}
+
+	return apiHandlers.SendResult(c, toExamSeriesInfo(series))
+}
+
+// GetExamSeriesV1 godoc
+// @Summary Get an exam series
+// @Description Allows the user to get an exam series alongside its materialised, edited, skipped and upcoming occurrences.
+// @ID getExamSeriesV1
+// @Tags Exam
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param id query int true "Series ID"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=GetExamSeriesResult}
+// @Router /api/v1/exam/series [get]
+func GetExamSeriesV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	}
+
+	seriesId := c.QueryInt("id")
+	if seriesId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "id")
+	}
+
+	series, err := getExamSeriesForEdit(c, userInfo, seriesId)
+	if series == nil {
+		return err
+	}
+
+	occurrences, err := database.GetExamSeriesOccurrences(seriesId)
+	if err != nil {
+		logging.UnexpectedError("GetExamSeries: Failed to get exam series occurrences:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	occurrencesInfo := make([]*ExamSeriesOccurrenceInfo, 0, len(occurrences))
+	knownOccurrences := make(map[int64]bool, len(occurrences))
+	for _, occurrence := range occurrences {
+		knownOccurrences[occurrence.OccurrenceAt.Unix()] = true
+		occurrencesInfo = append(occurrencesInfo, toExamSeriesOccurrenceInfo(occurrence))
+	}
+
+	upcoming, _ := series.GetUpcomingOccurrences(time.Now(), database.MaxSeriesPreviewCount)
+	for _, occurrenceAt := range upcoming {
+		if knownOccurrences[occurrenceAt.Unix()] {
+			continue
+		}
+
+		occurrencesInfo = append(occurrencesInfo, &ExamSeriesOccurrenceInfo{
+			OccurrenceAt: occurrenceAt,
+		})
+	}
+
+	return apiHandlers.SendResult(c, &GetExamSeriesResult{
+		Series:      toExamSeriesInfo(series),
+		Occurrences: occurrencesInfo,
+	})
+}
+
+// EditExamSeriesV1 godoc
+// @Summary Edit an exam series
+// @Description Allows the user to edit an exam series; changes only affect the occurrences which are not materialised yet.
+// @ID editExamSeriesV1
+// @Tags Exam
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param data body EditExamSeriesData true "Data needed to edit an exam series"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=ExamSeriesInfo}
+// @Router /api/v1/exam/editSeries [post]
+func EditExamSeriesV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	} else if !userInfo.CanTryToEditExam() {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	data := &EditExamSeriesData{}
+	if err := c.BodyParser(data); err != nil {
+		return apiHandlers.SendErrInvalidBodyData(c)
+	}
+
+	if data.SeriesId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "series_id")
+	} else if len(data.SeriesTitle) > database.MaxExamTitleLength {
+		return apiHandlers.SendErrBodyTooLong(c)
+	} else if data.GenerateAhead != nil &&
+		(*data.GenerateAhead < 0 || *data.GenerateAhead > database.MaxSeriesGenerateAhead) {
+		return apiHandlers.SendErrInvalidBodyData(c)
+	}
+
+	series, err := getExamSeriesForEdit(c, userInfo, data.SeriesId)
+	if series == nil {
+		return err
+	}
+
+	newData := &database.EditExamSeriesData{
+		SeriesId:       series.SeriesId,
+		SeriesTitle:    series.SeriesTitle,
+		RecurrenceRule: series.RecurrenceRule,
+		GenerateAhead:  series.GenerateAhead,
+		IsActive:       series.IsActive,
+	}
+
+	if data.SeriesTitle != "" {
+		newData.SeriesTitle = data.SeriesTitle
+	}
+
+	if data.RecurrenceRule != "" {
+		rule, err := recurrenceUtils.ParseRecurrenceRule(data.RecurrenceRule)
+		if err != nil {
+			return apiHandlers.SendErrInvalidRecurrenceRule(c, err.Error())
+		}
+		newData.RecurrenceRule = rule.String()
+	}
+
+	if data.GenerateAhead != nil {
+		newData.GenerateAhead = *data.GenerateAhead
+	}
+
+	if data.IsActive != nil {
+		newData.IsActive = *data.IsActive
+	}
+
+	series, err = database.EditExamSeries(newData)
+	if err != nil {
+		logging.UnexpectedError("EditExamSeries: Failed to edit exam series:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	return apiHandlers.SendResult(c, toExamSeriesInfo(series))
+}
+
+// EditExamSeriesOccurrenceV1 godoc
+// @Summary Edit an occurrence of an exam series
+// @Description Allows the user to override the title, date or duration of a single occurrence of an exam series.
+// @ID editExamSeriesOccurrenceV1
+// @Tags Exam
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param data body EditExamSeriesOccurrenceData true "Data needed to edit an occurrence of an exam series"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=ExamSeriesOccurrenceInfo}
+// @Router /api/v1/exam/editSeriesOccurrence [post]
+func EditExamSeriesOccurrenceV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	} else if !userInfo.CanTryToEditExam() {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	data := &EditExamSeriesOccurrenceData{}
+	if err := c.BodyParser(data); err != nil {
+		return apiHandlers.SendErrInvalidBodyData(c)
+	}
+
+	if data.SeriesId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "series_id")
+	} else if data.OccurrenceAt == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "occurrence_at")
+	} else if data.ExamTitle != nil && len(*data.ExamTitle) > database.MaxExamTitleLength {
+		return apiHandlers.SendErrBodyTooLong(c)
+	} else if data.Duration != nil && *data.Duration <= 0 {
+		return apiHandlers.SendErrInvalidBodyData(c)
+	}
+
+	series, err := getExamSeriesForEdit(c, userInfo, data.SeriesId)
+	if series == nil {
+		return err
+	}
+
+	occurrenceAt := time.Unix(data.OccurrenceAt, 0)
+	if !series.IsOccurrence(occurrenceAt) {
+		return apiHandlers.SendErrInvalidSeriesOccurrence(c)
+	}
+
+	newData := &database.EditExamSeriesOccurrenceData{
+		SeriesId:     series.SeriesId,
+		OccurrenceAt: occurrenceAt,
+		ExamTitle:    data.ExamTitle,
+		Duration:     data.Duration,
+	}
+
+	if data.ExamDate != nil {
+		examDate := time.Unix(*data.ExamDate, 0)
+		newData.ExamDate = &examDate
+	}
+
+	occurrence, err := database.EditExamSeriesOccurrence(newData)
+	if err != nil {
+		logging.UnexpectedError("EditExamSeriesOccurrence: Failed to edit exam series occurrence:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	return apiHandlers.SendResult(c, toExamSeriesOccurrenceInfo(occurrence))
+}
+
+// SkipExamSeriesOccurrenceV1 godoc
+// @Summary Skip an occurrence of an exam series
+// @Description Allows the user to skip (or bring back) a single occurrence of an exam series; the exam of a skipped occurrence is removed if nobody has participated in it yet.
+// @ID skipExamSeriesOccurrenceV1
+// @Tags Exam
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param data body SkipExamSeriesOccurrenceData true "Data needed to skip an occurrence of an exam series"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=ExamSeriesOccurrenceInfo}
+// @Router /api/v1/exam/skipSeriesOccurrence [post]
+func SkipExamSeriesOccurrenceV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	} else if !userInfo.CanTryToEditExam() {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	data := &SkipExamSeriesOccurrenceData{
+		IsSkipped: true,
+	}
+	if err := c.BodyParser(data); err != nil {
+		return apiHandlers.SendErrInvalidBodyData(c)
+	}
+
+	if data.SeriesId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "series_id")
+	} else if data.OccurrenceAt == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "occurrence_at")
+	}
+
+	series, err := getExamSeriesForEdit(c, userInfo, data.SeriesId)
+	if series == nil {
+		return err
+	}
+
+	occurrenceAt := time.Unix(data.OccurrenceAt, 0)
+	if !series.IsOccurrence(occurrenceAt) {
+		return apiHandlers.SendErrInvalidSeriesOccurrence(c)
+	}
+
+	if data.IsSkipped {
+		current, err := database.GetExamSeriesOccurrence(series.SeriesId, occurrenceAt)
+		if err != nil {
+			logging.UnexpectedError("SkipExamSeriesOccurrence: Failed to get exam series occurrence:", err)
+			return apiHandlers.SendErrInternalServerError(c)
+		}
+
+		if current != nil && current.ExamId != nil &&
+			database.HasExamParticipants(*current.ExamId) {
+			return apiHandlers.SendErrOccurrenceHasParticipants(c)
+		}
+	}
+
+	occurrence, err := database.SkipExamSeriesOccurrence(series.SeriesId, occurrenceAt, data.IsSkipped)
+	if err != nil {
+		logging.UnexpectedError("SkipExamSeriesOccurrence: Failed to skip exam series occurrence:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	return apiHandlers.SendResult(c, toExamSeriesOccurrenceInfo(occurrence))
+}
+
+// InviteToExamV1 godoc
+// @Summary Invite users to an exam
+// @Description Allows the user to invite a list of users (by their user id or email address) to an exam; each invitee gets an email with a one-click join link.
+// @ID inviteToExamV1
+// @Tags Exam
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param data body InviteToExamData true "Data needed to invite users to an exam"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=InviteToExamResult}
+// @Router /api/v1/exam/invite [post]
+func InviteToExamV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	}
+
+	data := &InviteToExamData{}
+	if err := c.BodyParser(data); err != nil {
+		return apiHandlers.SendErrInvalidBodyData(c)
+	}
+
+	if data.ExamId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "exam_id")
+	} else if len(data.UserIds) == 0 && len(data.Emails) == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "user_ids")
+	} else if len(data.UserIds)+len(data.Emails) > database.MaxInviteesCount {
+		return apiHandlers.SendErrBodyTooLong(c)
+	}
+
+	examInfo := database.GetExamInfoOrNil(data.ExamId)
+	if examInfo == nil {
+		return apiHandlers.SendErrExamNotFound(c)
+	}
+
+	if !userInfo.CanAddOthersToExam(examInfo) {
+		return apiHandlers.SendErrPermissionDenied(c)
+	} else if examInfo.HasExamFinished() {
+		return apiHandlers.SendErrExamFinished(c)
+	}
+
+	result := &InviteToExamResult{
+		Invitations: make([]*ExamInvitationInfo, 0, len(data.UserIds)+len(data.Emails)),
+		Skipped:     []*SkippedInviteeInfo{},
+	}
+	skip := func(invitee, reason string) {
+		result.Skipped = append(result.Skipped, &SkippedInviteeInfo{
+			Invitee: invitee,
+			Reason:  reason,
+		})
+	}
+
+	var invitations []*database.ExamInvitation
+	inviteeNames := make(map[int]string)
+	invitedEmails := make(map[string]bool)
+	expiresAt := time.Now().Add(appConfig.GetExamInviteExpiration())
+
+	invite := func(invitee, email string, targetUser *database.UserInfo) error {
+		if invitedEmails[strings.ToLower(email)] {
+			skip(invitee, inviteeReasonDuplicate)
+			return nil
+		}
+
+		newData := &database.NewExamInvitationData{
+			ExamId:    data.ExamId,
+			Email:     email,
+			ExpiresAt: expiresAt,
+			InvitedBy: userInfo.UserId,
+		}
+
+		if targetUser != nil {
+			if targetUser.IsAdminOrOwner() {
+				skip(invitee, inviteeReasonCannotParticipate)
+				return nil
+			}
+
+			givenExam, _ := database.GetGivenExam(targetUser.UserId, data.ExamId)
+			if givenExam != nil {
+				skip(invitee, inviteeReasonAlreadyParticipated)
+				return nil
+			}
+
+			newData.UserId = ssg.Clone(&targetUser.UserId)
+		}
+
+		invitation, err := database.CreateExamInvitation(newData)
+		if err != nil {
+			return err
+		}
+
+		invitedEmails[strings.ToLower(email)] = true
+		invitations = append(invitations, invitation)
+		if targetUser != nil {
+			inviteeNames[invitation.InvitationId] = targetUser.FullName
+		}
+		result.Invitations = append(result.Invitations, toExamInvitationInfo(invitation))
+		return nil
+	}
+
+	for _, targetUserId := range data.UserIds {
+		targetUser, err := database.GetUserByUserId(targetUserId)
+		if err == database.ErrUserNotFound || (err == nil && targetUser == nil) {
+			skip(targetUserId, inviteeReasonUserNotFound)
+			continue
+		} else if err != nil {
+			logging.UnexpectedError("InviteToExam: Failed to get user info:", err)
+			return apiHandlers.SendErrInternalServerError(c)
+		}
+
+		err = invite(targetUserId, targetUser.Email, targetUser)
+		if err != nil {
+			logging.UnexpectedError("InviteToExam: Failed to create exam invitation:", err)
+			return apiHandlers.SendErrInternalServerError(c)
+		}
+	}
+
+	for _, email := range data.Emails {
+		email = strings.TrimSpace(email)
+		if !userHandlers.IsEmailValid(email) {
+			skip(email, inviteeReasonInvalidEmail)
+			continue
+		}
+
+		// the invitee might already have an account with this email
+		targetUser, err := database.GetUserByEmail(email)
+		if err != nil && err != database.ErrUserNotFound {
+			logging.UnexpectedError("InviteToExam: Failed to get user info by email:", err)
+			return apiHandlers.SendErrInternalServerError(c)
+		}
+
+		err = invite(email, email, targetUser)
+		if err != nil {
+			logging.UnexpectedError("InviteToExam: Failed to create exam invitation:", err)
+			return apiHandlers.SendErrInternalServerError(c)
+		}
+	}
+
+	if len(invitations) > 0 && emailUtils.IsEmailClientLoaded() {
+		result.EmailsSent = true
+		go sendExamInvitationEmails(userInfo, examInfo, invitations, inviteeNames)
+	}
+
+	return apiHandlers.SendResult(c, result)
+}
+
+// GetExamInvitationsV1 godoc
+// @Summary Get invitations of an exam
+// @Description Allows the user to get the invitations of an exam alongside their status.
+// @ID getExamInvitationsV1
+// @Tags Exam
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param id query int true "Exam ID"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=GetExamInvitationsResult}
+// @Router /api/v1/exam/invitations [get]
+func GetExamInvitationsV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	}
+
+	examId := c.QueryInt("id")
+	if examId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "id")
+	}
+
+	examInfo := database.GetExamInfoOrNil(examId)
+	if examInfo == nil {
+		return apiHandlers.SendErrExamNotFound(c)
+	} else if !userInfo.CanAddOthersToExam(examInfo) {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	invitations, err := database.GetExamInvitations(examId)
+	if err != nil {
+		logging.UnexpectedError("GetExamInvitations: Failed to get exam invitations:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	result := &GetExamInvitationsResult{
+		Invitations: make([]*ExamInvitationInfo, 0, len(invitations)),
+	}
+	for _, invitation := range invitations {
+		result.Invitations = append(result.Invitations, toExamInvitationInfo(invitation))
+	}
+
+	return apiHandlers.SendResult(c, result)
+}
+
+// GetExamInvitationInfoV1 godoc
+// @Summary Get information of an exam invitation
+// @Description Allows anyone with the join link of an invitation to see its exam and whether the invitee has to create an account first.
+// @ID getExamInvitationInfoV1
+// @Tags Exam
+// @Accept json
+// @Produce json
+// @Param invitationId query int true "Invitation ID"
+// @Param token query string true "Join token of the invitation"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=GetExamInvitationInfoResult}
+// @Router /api/v1/exam/invitationInfo [get]
+func GetExamInvitationInfoV1(c *fiber.Ctx) error {
+	invitationId := c.QueryInt("invitationId")
+	token := c.Query("token")
+	if invitationId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "invitationId")
+	} else if token == "" {
+		return apiHandlers.SendErrParameterRequired(c, "token")
+	}
+
+	invitation, err := database.GetExamInvitation(invitationId)
+	if err == database.ErrExamInvitationNotFound {
+		return apiHandlers.SendErrExamInvitationNotFound(c)
+	} else if err != nil {
+		logging.UnexpectedError("GetExamInvitationInfo: Failed to get exam invitation:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	} else if !invitation.VerifyJoinToken(token) {
+		return apiHandlers.SendErrInvalidInvitationToken(c)
+	}
+
+	examInfo := database.GetExamInfoOrNil(invitation.ExamId)
+	if examInfo == nil {
+		return apiHandlers.SendErrExamNotFound(c)
+	}
+
+	hasAccount := invitation.UserId != nil
+	if !hasAccount {
+		// the invitee might have created an account after being invited
+		targetUser, _ := database.GetUserByEmail(invitation.Email)
+		hasAccount = targetUser != nil
+	}
+
+	return apiHandlers.SendResult(c, &GetExamInvitationInfoResult{
+		InvitationId: invitation.InvitationId,
+		ExamId:       examInfo.ExamId,
+		ExamTitle:    examInfo.ExamTitle,
+		ExamDate:     examInfo.ExamDate,
+		Email:        invitation.Email,
+		Status:       invitation.GetStatus(),
+		ExpiresAt:    invitation.ExpiresAt,
+		HasAccount:   hasAccount,
+	})
+}
+
+// AcceptExamInvitationV1 godoc
+// @Summary Accept an exam invitation
+// @Description Allows the invited user to join the exam through the join link of their invitation.
+// @ID acceptExamInvitationV1
+// @Tags Exam
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param data body AcceptExamInvitationData true "Data needed to accept an exam invitation"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=ParticipateExamResult}
+// @Router /api/v1/exam/acceptInvitation [post]
+func AcceptExamInvitationV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	}
+
+	data := &AcceptExamInvitationData{}
+	if err := c.BodyParser(data); err != nil {
+		return apiHandlers.SendErrInvalidBodyData(c)
+	}
+
+	if data.InvitationId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "invitation_id")
+	} else if data.Token == "" {
+		return apiHandlers.SendErrParameterRequired(c, "token")
+	}
+
+	invitation, err := database.GetExamInvitation(data.InvitationId)
+	if err == database.ErrExamInvitationNotFound {
+		return apiHandlers.SendErrExamInvitationNotFound(c)
+	} else if err != nil {
+		logging.UnexpectedError("AcceptExamInvitation: Failed to get exam invitation:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	} else if !invitation.VerifyJoinToken(data.Token) {
+		return apiHandlers.SendErrInvalidInvitationToken(c)
+	} else if !invitation.IsFor(userInfo) || userInfo.IsAdminOrOwner() {
+		return apiHandlers.SendErrPermissionDenied(c)
+	} else if !invitation.IsPending() {
+		return apiHandlers.SendErrInvitationNotPending(c, invitation.GetStatus())
+	}
+
+	examInfo := database.GetExamInfoOrNil(invitation.ExamId)
+	if examInfo == nil {
+		return apiHandlers.SendErrExamNotFound(c)
+	} else if !examInfo.IsPublished() {
+		return apiHandlers.SendErrExamNotPublished(c)
+	}
+
+	accommodation := database.GetExamAccommodationOrNil(userInfo.UserId, examInfo.ExamId)
+	if examInfo.HasExamFinishedFor(accommodation) {
+		return apiHandlers.SendErrExamFinished(c)
+	}
+
+	// the invitee joins the exam (or its waitlist, if it's full), unless
+	// they have joined it some other way in the meantime
+	givenExam, waitlistEntry, err := database.JoinExamByInvitation(
+		invitation, examInfo, userInfo.UserId,
+	)
+	if err == database.ErrInsufficientBalance {
+		return apiHandlers.SendErrInsufficientBalance(c)
+	} else if err == database.ErrCurrencyMismatch {
+		return apiHandlers.SendErrCurrencyMismatch(c)
+	} else if err == database.ErrExamPrerequisitesNotMet {
+		return apiHandlers.SendErrPrerequisitesNotMet(c)
+	} else if err != nil {
+		logging.UnexpectedError("AcceptExamInvitation: Failed to join exam by invitation:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	} else if waitlistEntry != nil {
+		return apiHandlers.SendResult(c, toWaitlistedParticipateResult(examInfo, waitlistEntry))
+	}
+
+	return apiHandlers.SendResult(c, &ParticipateExamResult{
+		ExamId:        givenExam.ExamId,
+		UserId:        givenExam.UserId,
+		Price:         givenExam.Price,
+		OriginalPrice: examInfo.Price,
+		AddedBy:       ssg.Clone(givenExam.AddedBy),
+		CreatedAt:     givenExam.CreatedAt,
+		StartsIn:      examInfo.ExamStartsInFor(accommodation),
+		FinishesIn:    examInfo.ExamFinishesInFor(accommodation),
+		QuestionCount: database.GetExamQuestionsCount(examInfo.ExamId),
+	})
+}
+
+// SetExamCapacityV1 godoc
+// @Summary Set the capacity of an exam
+// @Description Allows the user to set the maximum number of participants of an exam; once the exam is full, the users are put on its waitlist.
+// @ID setExamCapacityV1
+// @Tags Exam
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param data body SetExamCapacityData true "Data needed to set the capacity of an exam"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=ExamCapacityResult}
+// @Router /api/v1/exam/setCapacity [post]
+func SetExamCapacityV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	} else if !userInfo.CanTryToEditExam() {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	data := &SetExamCapacityData{}
+	if err := c.BodyParser(data); err != nil {
+		return apiHandlers.SendErrInvalidBodyData(c)
+	}
+
+	if data.ExamId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "exam_id")
+	} else if data.Capacity != nil && *data.Capacity <= 0 {
+		return apiHandlers.SendErrInvalidBodyData(c)
+	}
+
+	examInfo := database.GetExamInfoOrNil(data.ExamId)
+	if examInfo == nil {
+		return apiHandlers.SendErrExamNotFound(c)
+	}
+
+	if !userInfo.CanEditExam(examInfo) {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	examInfo, err := database.SetExamCapacity(data.ExamId, data.Capacity)
+	if err != nil {
+		logging.UnexpectedError("SetExamCapacity: Failed to set exam capacity:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	// the capacity might have been raised
+	if !examInfo.HasExamFinished() {
+		promoteExamWaitlist(examInfo)
+	}
+
+	waitlist, err := database.GetExamWaitlist(data.ExamId)
+	if err != nil {
+		logging.UnexpectedError("SetExamCapacity: Failed to get exam waitlist:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	return apiHandlers.SendResult(c, &ExamCapacityResult{
+		ExamId:            examInfo.ExamId,
+		Capacity:          ssg.Clone(examInfo.Capacity),
+		ParticipantsCount: database.GetExamParticipantsCount(data.ExamId),
+		WaitlistCount:     len(waitlist),
+	})
+}
+
+// WithdrawFromExamV1 godoc
+// @Summary Withdraw from an exam
+// @Description Allows the user to withdraw (themselves or others) from an exam before it starts, or to leave its waitlist. The price of the exam is refunded to the wallet, and the freed seat is given to the next user on the waitlist.
+// @ID withdrawFromExamV1
+// @Tags Exam
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param data body WithdrawFromExamData true "Data needed to withdraw from an exam"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=WithdrawFromExamResult}
+// @Router /api/v1/exam/withdraw [post]
+func WithdrawFromExamV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	}
+
+	data := &WithdrawFromExamData{}
+	if err := c.BodyParser(data); err != nil {
+		return apiHandlers.SendErrInvalidBodyData(c)
+	}
+
+	if data.ExamId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "exam_id")
+	} else if data.UserId == "" {
+		data.UserId = userInfo.UserId
+	}
+
+	examInfo := database.GetExamInfoOrNil(data.ExamId)
+	if examInfo == nil {
+		return apiHandlers.SendErrExamNotFound(c)
+	}
+
+	if data.UserId != userInfo.UserId &&
+		!userInfo.CanAddOthersToExam(examInfo) {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	givenExam, err := database.GetGivenExam(data.UserId, data.ExamId)
+	if err == database.ErrGivenExamNotFound || (err == nil && givenExam == nil) {
+		err = database.RemoveUserFromExamWaitlist(data.UserId, data.ExamId)
+		if err == database.ErrWaitlistEntryNotFound {
+			return apiHandlers.SendErrNotParticipatedInExam(c)
+		} else if err != nil {
+			logging.UnexpectedError("WithdrawFromExam: Failed to remove user from exam waitlist:", err)
+			return apiHandlers.SendErrInternalServerError(c)
+		}
+
+		return apiHandlers.SendResult(c, &WithdrawFromExamResult{
+			ExamId:       data.ExamId,
+			UserId:       data.UserId,
+			LeftWaitlist: true,
+		})
+	} else if err != nil {
+		logging.UnexpectedError("WithdrawFromExam: Failed to get given exam:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	accommodation := database.GetExamAccommodationOrNil(data.UserId, data.ExamId)
+	if examInfo.HasExamStartedFor(accommodation) || examInfo.HasExamStarted() {
+		return apiHandlers.SendErrExamAlreadyStarted(c)
+	}
+
+	err = database.WithdrawUserFromExam(data.UserId, data.ExamId, userInfo.UserId)
+	if err != nil {
+		logging.UnexpectedError("WithdrawFromExam: Failed to withdraw user from exam:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	promoteExamWaitlist(examInfo)
+
+	return apiHandlers.SendResult(c, &WithdrawFromExamResult{
+		ExamId: data.ExamId,
+		UserId: data.UserId,
+	})
+}
+
+// GetExamWaitlistV1 godoc
+// @Summary Get the waitlist of an exam
+// @Description Allows the user to get the users waiting for a seat in an exam, in order.
+// @ID getExamWaitlistV1
+// @Tags Exam
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param id query int true "Exam ID"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=GetExamWaitlistResult}
+// @Router /api/v1/exam/waitlist [get]
+func GetExamWaitlistV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	}
+
+	examId := c.QueryInt("id")
+	if examId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "id")
+	}
+
+	examInfo := database.GetExamInfoOrNil(examId)
+	if examInfo == nil {
+		return apiHandlers.SendErrExamNotFound(c)
+	} else if !userInfo.CanAddOthersToExam(examInfo) {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	waitlist, err := database.GetExamWaitlist(examId)
+	if err != nil {
+		logging.UnexpectedError("GetExamWaitlist: Failed to get exam waitlist:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	result := &GetExamWaitlistResult{
+		ExamId:   examId,
+		Capacity: ssg.Clone(examInfo.Capacity),
+		Entries:  make([]*ExamWaitlistEntryInfo, 0, len(waitlist)),
+	}
+	for _, entry := range waitlist {
+		result.Entries = append(result.Entries, toExamWaitlistEntryInfo(entry))
+	}
+
+	return apiHandlers.SendResult(c, result)
+}
+
+// CreateExamSectionV1 godoc
+// @Summary Create a new section for an exam
+// @Description Allows the user to create a new section (with its own time limit and navigation) in an exam that has not started yet.
+// @ID createExamSectionV1
+// @Tags Exam
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param data body CreateExamSectionData true "Data needed to create a new section for an exam"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=ExamSectionInfo}
+// @Router /api/v1/exam/createSection [post]
+func CreateExamSectionV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	} else if !userInfo.CanTryToEditExam() {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	data := &CreateExamSectionData{}
+	if err := c.BodyParser(data); err != nil {
+		return apiHandlers.SendErrInvalidBodyData(c)
+	}
+
+	data.SectionTitle = strings.TrimSpace(data.SectionTitle)
+	if data.ExamId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "exam_id")
+	} else if data.SectionTitle == "" {
+		return apiHandlers.SendErrParameterRequired(c, "section_title")
+	} else if len(data.SectionTitle) > database.MaxSectionTitleLength {
+		return apiHandlers.SendErrBodyTooLong(c)
+	} else if data.Duration != nil && *data.Duration <= 0 {
+		return apiHandlers.SendErrInvalidBodyData(c)
+	}
+
+	examInfo := database.GetExamInfoOrNil(data.ExamId)
+	if examInfo == nil {
+		return apiHandlers.SendErrExamNotFound(c)
+	} else if !userInfo.CanEditExam(examInfo) {
+		return apiHandlers.SendErrPermissionDenied(c)
+	} else if examInfo.HasExamStarted() {
+		return apiHandlers.SendErrExamAlreadyStarted(c)
+	}
+
+	section, err := database.CreateExamSection(&database.NewExamSectionData{
+		ExamId:        data.ExamId,
+		SectionTitle:  data.SectionTitle,
+		SectionOrder:  data.SectionOrder,
+		Duration:      data.Duration,
+		IsForwardOnly: data.IsForwardOnly,
+	})
+	if err != nil {
+		logging.UnexpectedError("CreateExamSection: Failed to create exam section:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	return apiHandlers.SendResult(c, toExamSectionInfo(section, nil))
+}
+
+// EditExamSectionV1 godoc
+// @Summary Edit a section of an exam
+// @Description Allows the user to edit a section of an exam that has not started yet.
+// @ID editExamSectionV1
+// @Tags Exam
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param data body EditExamSectionData true "Data needed to edit a section of an exam"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=ExamSectionInfo}
+// @Router /api/v1/exam/editSection [post]
+func EditExamSectionV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	} else if !userInfo.CanTryToEditExam() {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	data := &EditExamSectionData{}
+	if err := c.BodyParser(data); err != nil {
+		return apiHandlers.SendErrInvalidBodyData(c)
+	}
+
+	data.SectionTitle = strings.TrimSpace(data.SectionTitle)
+	if data.SectionId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "section_id")
+	} else if data.SectionTitle == "" {
+		return apiHandlers.SendErrParameterRequired(c, "section_title")
+	} else if len(data.SectionTitle) > database.MaxSectionTitleLength {
+		return apiHandlers.SendErrBodyTooLong(c)
+	} else if data.Duration != nil && *data.Duration <= 0 {
+		return apiHandlers.SendErrInvalidBodyData(c)
+	}
+
+	section, err := database.GetExamSection(data.SectionId)
+	if err == database.ErrExamSectionNotFound {
+		return apiHandlers.SendErrExamSectionNotFound(c)
+	} else if err != nil {
+		logging.UnexpectedError("EditExamSection: Failed to get exam section:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	examInfo := database.GetExamInfoOrNil(section.ExamId)
+	if examInfo == nil {
+		return apiHandlers.SendErrExamNotFound(c)
+	} else if !userInfo.CanEditExam(examInfo) {
+		return apiHandlers.SendErrPermissionDenied(c)
+	} else if examInfo.HasExamStarted() {
+		return apiHandlers.SendErrExamAlreadyStarted(c)
+	}
+
+	section, err = database.EditExamSection(&database.EditExamSectionData{
+		SectionId:     data.SectionId,
+		SectionTitle:  data.SectionTitle,
+		SectionOrder:  data.SectionOrder,
+		Duration:      data.Duration,
+		IsForwardOnly: data.IsForwardOnly,
+	})
+	if err == database.ErrExamSectionNotFound {
+		return apiHandlers.SendErrExamSectionNotFound(c)
+	} else if err != nil {
+		logging.UnexpectedError("EditExamSection: Failed to edit exam section:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	return apiHandlers.SendResult(c, toExamSectionInfo(section, nil))
+}
+
+// DeleteExamSectionV1 godoc
+// @Summary Delete a section of an exam
+// @Description Allows the user to delete a section of an exam that has not started yet; the questions of the section are kept in the exam without a section.
+// @ID deleteExamSectionV1
+// @Tags Exam
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param data body DeleteExamSectionData true "Data needed to delete a section of an exam"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=DeleteExamSectionResult}
+// @Router /api/v1/exam/deleteSection [post]
+func DeleteExamSectionV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	} else if !userInfo.CanTryToEditExam() {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	data := &DeleteExamSectionData{}
+	if err := c.BodyParser(data); err != nil {
+		return apiHandlers.SendErrInvalidBodyData(c)
+	}
+
+	if data.SectionId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "section_id")
+	}
+
+	section, err := database.GetExamSection(data.SectionId)
+	if err == database.ErrExamSectionNotFound {
+		return apiHandlers.SendErrExamSectionNotFound(c)
+	} else if err != nil {
+		logging.UnexpectedError("DeleteExamSection: Failed to get exam section:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	examInfo := database.GetExamInfoOrNil(section.ExamId)
+	if examInfo == nil {
+		return apiHandlers.SendErrExamNotFound(c)
+	} else if !userInfo.CanEditExam(examInfo) {
+		return apiHandlers.SendErrPermissionDenied(c)
+	} else if examInfo.HasExamStarted() {
+		return apiHandlers.SendErrExamAlreadyStarted(c)
+	}
+
+	err = database.DeleteExamSection(data.SectionId)
+	if err == database.ErrExamSectionNotFound {
+		return apiHandlers.SendErrExamSectionNotFound(c)
+	} else if err != nil {
+		logging.UnexpectedError("DeleteExamSection: Failed to delete exam section:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	return apiHandlers.SendResult(c, &DeleteExamSectionResult{
+		SectionId: section.SectionId,
+		ExamId:    section.ExamId,
+	})
+}
+
+// GetExamSectionsV1 godoc
+// @Summary Get the sections of an exam
+// @Description Allows the user to get the sections of an exam in order; participants also get their progress in the sections of their latest attempt.
+// @ID getExamSectionsV1
+// @Tags Exam
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param id query int true "Exam ID"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=GetExamSectionsResult}
+// @Router /api/v1/exam/sections [get]
+func GetExamSectionsV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	}
+
+	examId := c.QueryInt("id")
+	if examId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "id")
+	}
+
+	examInfo := database.GetExamInfoOrNil(examId)
+	if examInfo == nil {
+		return apiHandlers.SendErrExamNotFound(c)
+	}
+
+	hasParticipated := database.HasParticipatedInExam(userInfo.UserId, examId)
+	if !hasParticipated && !userInfo.CanPeekExamQuestions(examInfo) {
+		return apiHandlers.SendErrNotParticipatedInExam(c)
+	}
+
+	var attempt *database.ExamAttempt
+	var accommodation *database.ExamAccommodation
+	if hasParticipated {
+		attempt = database.GetLatestExamAttemptOrNil(userInfo.UserId, examId)
+		accommodation = database.GetExamAccommodationOrNil(userInfo.UserId, examId)
+	}
+
+	allProgress, err := database.GetExamSectionsProgress(examInfo, accommodation, attempt)
+	if err != nil {
+		logging.UnexpectedError("GetExamSections: Failed to get exam sections progress:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	result := &GetExamSectionsResult{
+		ExamId:   examId,
+		Sections: make([]*ExamSectionInfo, 0, len(allProgress)),
+	}
+	for _, current := range allProgress {
+		if attempt == nil {
+			result.Sections = append(result.Sections, toExamSectionInfo(current.Section, nil))
+			continue
+		}
+		result.Sections = append(result.Sections, toExamSectionInfo(current.Section, current))
+	}
+	if attempt != nil {
+		result.AttemptNumber = attempt.AttemptNumber
+	}
+
+	return apiHandlers.SendResult(c, result)
+}
+
+// NextExamSectionV1 godoc
+// @Summary Move to the next section of an exam
+// @Description Allows the user to finish their current section of the exam and move to the next one; forward-only sections cannot be visited again afterwards.
+// @ID nextExamSectionV1
+// @Tags Exam
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param Client-Device-ID header string false "Device id of the client taking the exam"
+// @Param data body NextExamSectionData true "Data needed to move to the next section of an exam"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=NextExamSectionResult}
+// @Router /api/v1/exam/nextSection [post]
+func NextExamSectionV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	}
+
+	data := &NextExamSectionData{}
+	if err := c.BodyParser(data); err != nil {
+		return apiHandlers.SendErrInvalidBodyData(c)
+	}
+
+	if data.ExamId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "exam_id")
+	}
+
+	examInfo := database.GetExamInfoOrNil(data.ExamId)
+	if examInfo == nil {
+		return apiHandlers.SendErrExamNotFound(c)
+	}
+
+	accommodation := database.GetExamAccommodationOrNil(userInfo.UserId, data.ExamId)
+	if !examInfo.HasExamStartedFor(accommodation) {
+		return apiHandlers.SendErrExamNotStarted(c)
+	} else if examInfo.HasExamFinishedFor(accommodation) {
+		return apiHandlers.SendErrExamFinished(c)
+	}
+
+	if !database.HasParticipatedInExam(userInfo.UserId, data.ExamId) {
+		return apiHandlers.SendErrNotParticipatedInExam(c)
+	}
+
+	deviceId := getClientDeviceId(c)
+	if !isDeviceIdValid(deviceId) {
+		return apiHandlers.SendErrInvalidDeviceId(c, deviceId)
+	}
+
+	attempt, clientStatus, err := checkAttemptClient(c, userInfo.UserId, examInfo, deviceId)
+	if err != nil {
+		logging.UnexpectedError("NextExamSection: Failed to check attempt client:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	} else if clientStatus != attemptClientAllowed {
+		return sendAttemptClientError(c, clientStatus)
+	}
+
+	allProgress, err := database.GetExamSectionsProgress(examInfo, accommodation, attempt)
+	if err != nil {
+		logging.UnexpectedError("NextExamSection: Failed to get exam sections progress:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	var ongoing *database.ExamSectionProgress
+	for _, current := range allProgress {
+		if current.Status == database.SectionStatusOngoing {
+			ongoing = current
+			break
+		}
+	}
+	if ongoing == nil {
+		return apiHandlers.SendErrNoOngoingSection(c)
+	}
+
+	err = database.FinishExamAttemptSection(ongoing.Progress, time.Now())
+	if err != nil {
+		logging.UnexpectedError("NextExamSection: Failed to finish exam section:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	// loading the progress again enters the next section
+	allProgress, err = database.GetExamSectionsProgress(examInfo, accommodation, attempt)
+	if err != nil {
+		logging.UnexpectedError("NextExamSection: Failed to get exam sections progress:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	result := &NextExamSectionResult{
+		ExamId:        data.ExamId,
+		AttemptNumber: attempt.AttemptNumber,
+		Sections:      toExamSectionsInfo(allProgress),
+	}
+	for _, current := range allProgress {
+		if current.Status == database.SectionStatusOngoing {
+			result.CurrentSectionId = ssg.Clone(&current.Section.SectionId)
+			break
+		}
+	}
+
+	return apiHandlers.SendResult(c, result)
+}
+
+// SetExamAdaptiveV1 godoc
+// @Summary Set the adaptive settings of an exam
+// @Description Allows the user to make an exam adaptive (giving its questions one at a time, picked by the previous answers of the student) and to set when its attempts end.
+// @ID setExamAdaptiveV1
+// @Tags Exam
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param data body SetExamAdaptiveData true "Data needed to set the adaptive settings of an exam"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=ExamAdaptiveSettingsResult}
+// @Router /api/v1/exam/setAdaptive [post]
+func SetExamAdaptiveV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	} else if !userInfo.CanTryToEditExam() {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	data := &SetExamAdaptiveData{}
+	if err := c.BodyParser(data); err != nil {
+		return apiHandlers.SendErrInvalidBodyData(c)
+	}
+
+	if data.ExamId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "exam_id")
+	} else if (data.MaxItems != nil && *data.MaxItems <= 0) ||
+		(data.TargetStdError != nil && *data.TargetStdError <= 0) {
+		return apiHandlers.SendErrInvalidBodyData(c)
+	}
+
+	examInfo := database.GetExamInfoOrNil(data.ExamId)
+	if examInfo == nil {
+		return apiHandlers.SendErrExamNotFound(c)
+	} else if !userInfo.CanEditExam(examInfo) {
+		return apiHandlers.SendErrPermissionDenied(c)
+	} else if examInfo.HasExamStarted() {
+		return apiHandlers.SendErrExamAlreadyStarted(c)
+	}
+
+	examInfo, err := database.SetExamAdaptiveSettings(&database.SetExamAdaptiveSettingsData{
+		ExamId:         data.ExamId,
+		IsAdaptive:     data.IsAdaptive,
+		MaxItems:       data.MaxItems,
+		TargetStdError: data.TargetStdError,
+	})
+	if err != nil {
+		logging.UnexpectedError("SetExamAdaptive: Failed to set exam adaptive settings:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	questions, err := database.GetAdaptiveExamQuestions(data.ExamId)
+	if err != nil {
+		logging.UnexpectedError("SetExamAdaptive: Failed to get adaptive exam questions:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	return apiHandlers.SendResult(c, &ExamAdaptiveSettingsResult{
+		ExamId:         examInfo.ExamId,
+		IsAdaptive:     examInfo.IsAdaptive,
+		MaxItems:       ssg.Clone(examInfo.AdaptiveMaxItems),
+		TargetStdError: ssg.Clone(examInfo.AdaptiveTargetStdError),
+		QuestionsCount: len(questions),
+	})
+}
+
+// SetExamQuestionIrtV1 godoc
+// @Summary Set the answer key and the IRT parameters of a question
+// @Description Allows the user to set the correct option of a question and its parameters in the three-parameter logistic model; only the questions with an answer key are given in adaptive exams.
+// @ID setExamQuestionIrtV1
+// @Tags Exam
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param data body SetExamQuestionIrtData true "Data needed to set the answer key and the IRT parameters of a question"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=ExamQuestionIrtResult}
+// @Router /api/v1/exam/setQuestionIrt [post]
+func SetExamQuestionIrtV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	}
+
+	data := &SetExamQuestionIrtData{}
+	if err := c.BodyParser(data); err != nil {
+		return apiHandlers.SendErrInvalidBodyData(c)
+	}
+
+	if data.ExamId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "exam_id")
+	} else if data.QuestionId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "question_id")
+	}
+
+	params := irtUtils.ItemParams{
+		Discrimination: irtUtils.DefaultDiscrimination,
+		Difficulty:     data.Difficulty,
+		Guessing:       data.Guessing,
+	}
+	if data.Discrimination != nil {
+		params.Discrimination = *data.Discrimination
+	}
+	if !params.IsValid() {
+		return apiHandlers.SendErrInvalidBodyData(c)
+	}
+
+	examInfo := database.GetExamInfoOrNil(data.ExamId)
+	if examInfo == nil {
+		return apiHandlers.SendErrExamNotFound(c)
+	} else if !userInfo.CanEditExamQuestion(examInfo) {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	question, err := database.GetExamQuestion(data.ExamId, data.QuestionId)
+	if err == database.ErrExamQuestionNotFound ||
+		(err == nil && question.ExamId != data.ExamId) {
+		return apiHandlers.SendErrExamQuestionNotFound(c)
+	} else if err != nil {
+		logging.UnexpectedError("SetExamQuestionIrt: Failed to get exam question:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	if data.CorrectOption != nil && !question.HasOption(*data.CorrectOption) {
+		return apiHandlers.SendErrInvalidAnswerOption(c)
+	}
+
+	question, err = database.SetExamQuestionIrt(&database.SetExamQuestionIrtData{
+		ExamId:         data.ExamId,
+		QuestionId:     data.QuestionId,
+		CorrectOption:  data.CorrectOption,
+		Discrimination: params.Discrimination,
+		Difficulty:     params.Difficulty,
+		Guessing:       params.Guessing,
+	})
+	if err != nil {
+		logging.UnexpectedError("SetExamQuestionIrt: Failed to set exam question irt params:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	return apiHandlers.SendResult(c, &ExamQuestionIrtResult{
+		ExamId:         question.ExamId,
+		QuestionId:     question.QuestionId,
+		CorrectOption:  ssg.Clone(question.CorrectOption),
+		Discrimination: question.IrtDiscrimination,
+		Difficulty:     question.IrtDifficulty,
+		Guessing:       question.IrtGuessing,
+	})
+}
+
+// NextExamQuestionV1 godoc
+// @Summary Get the next question of an adaptive exam
+// @Description Allows the user to get the question they have to answer next in an adaptive exam; the question is picked by their previous answers. Once the estimate of their ability is precise enough (or enough questions have been given), the attempt ends and the final estimate is returned instead.
+// @ID nextExamQuestionV1
+// @Tags Exam
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param Client-Device-ID header string false "Device id of the client taking the exam"
+// @Param data body NextExamQuestionData true "Data needed to get the next question of an adaptive exam"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=NextExamQuestionResult}
+// @Router /api/v1/exam/nextQuestion [post]
+func NextExamQuestionV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	}
+
+	data := &NextExamQuestionData{}
+	if err := c.BodyParser(data); err != nil {
+		return apiHandlers.SendErrInvalidBodyData(c)
+	}
+
+	if data.ExamId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "exam_id")
+	}
+
+	examInfo := database.GetExamInfoOrNil(data.ExamId)
+	if examInfo == nil {
+		return apiHandlers.SendErrExamNotFound(c)
+	} else if !examInfo.IsAdaptive {
+		return apiHandlers.SendErrExamNotAdaptive(c)
+	}
+
+	accommodation := database.GetExamAccommodationOrNil(userInfo.UserId, data.ExamId)
+	if !examInfo.HasExamStartedFor(accommodation) {
+		return apiHandlers.SendErrExamNotStarted(c)
+	} else if examInfo.HasExamFinishedFor(accommodation) {
+		return apiHandlers.SendErrExamFinished(c)
+	}
+
+	if !database.HasParticipatedInExam(userInfo.UserId, data.ExamId) {
+		return apiHandlers.SendErrNotParticipatedInExam(c)
+	}
+
+	deviceId := getClientDeviceId(c)
+	if !isDeviceIdValid(deviceId) {
+		return apiHandlers.SendErrInvalidDeviceId(c, deviceId)
+	}
+
+	attempt, clientStatus, err := checkAttemptClient(c, userInfo.UserId, examInfo, deviceId)
+	if err != nil {
+		logging.UnexpectedError("NextExamQuestion: Failed to check attempt client:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	} else if clientStatus != attemptClientAllowed {
+		return sendAttemptClientError(c, clientStatus)
+	}
+
+	step, err := database.GetNextAdaptiveStep(examInfo, attempt)
+	if err == database.ErrNoAdaptiveQuestions {
+		return apiHandlers.SendErrNoAdaptiveQuestions(c)
+	} else if err != nil {
+		logging.UnexpectedError("NextExamQuestion: Failed to get next adaptive step:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	result := &NextExamQuestionResult{
+		ExamId:        data.ExamId,
+		AttemptNumber: attempt.AttemptNumber,
+		AnsweredCount: step.AnsweredCount,
+		IsFinished:    step.IsFinished,
+	}
+	if step.IsFinished {
+		result.AbilityEstimate = ssg.Clone(attempt.AbilityEstimate)
+		result.AbilityStdError = ssg.Clone(attempt.AbilityStdError)
+		return apiHandlers.SendResult(c, result)
+	}
+
+	result.ItemIndex = step.Item.ItemIndex
+	result.Question = &ExamQuestionInfo{
+		QuestionId:    step.Question.QuestionId,
+		QuestionTitle: step.Question.QuestionTitle,
+		Description:   ssg.Clone(step.Question.Description),
+		Option1:       ssg.Clone(step.Question.Option1),
+		Option2:       ssg.Clone(step.Question.Option2),
+		Option3:       ssg.Clone(step.Question.Option3),
+		Option4:       ssg.Clone(step.Question.Option4),
+		SectionId:     ssg.Clone(step.Question.SectionId),
+		QuestionOrder: step.Question.QuestionOrder,
+		ContentFormat: step.Question.ContentFormat,
+		CreatedAt:     step.Question.CreatedAt,
+		Points:        step.Question.Points,
+	}
+	if data.RenderHtml {
+		result.Question.Rendered = renderQuestionContent(step.Question)
+	}
+
+	return apiHandlers.SendResult(c, result)
+}
+
+// UploadExamAttachmentV1 godoc
+// @Summary Upload a file for a question of an exam
+// @Description Allows the user to attach an image, audio or pdf file to a question or to one of its options, or to upload a file as their answer to a question while taking the exam. The type of the file is detected from its content.
+// @ID uploadExamAttachmentV1
+// @Tags Exam
+// @Accept mpfd
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param Client-Device-ID header string false "Device id of the client taking the exam (required for the answer files)"
+// @Param exam_id formData int true "Exam ID"
+// @Param question_id formData int true "Question ID"
+// @Param attachment_type formData string false "What the file is attached to (question, option or answer); defaults to question"
+// @Param option_number formData int false "Number of the option (1-4) for the option files"
+// @Param file formData file true "The file to be uploaded"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=UploadExamAttachmentResult}
+// @Router /api/v1/exam/uploadAttachment [post]
+func UploadExamAttachmentV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	}
+
+	backend, err := storageUtils.GetStorageBackend()
+	if err != nil {
+		return apiHandlers.SendErrStorageNotAvailable(c)
+	}
+
+	examId, _ := strconv.Atoi(c.FormValue("exam_id"))
+	questionId, _ := strconv.Atoi(c.FormValue("question_id"))
+	attachmentType := c.FormValue("attachment_type", database.AttachmentTypeQuestion)
+	if examId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "exam_id")
+	} else if questionId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "question_id")
+	} else if !database.IsAttachmentTypeValid(attachmentType) {
+		return apiHandlers.SendErrInvalidBodyData(c)
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return apiHandlers.SendErrParameterRequired(c, "file")
+	} else if fileHeader.Size > appConfig.GetMaxAttachmentSize() {
+		return apiHandlers.SendErrFileTooLarge(c)
+	}
+
+	examInfo := database.GetExamInfoOrNil(examId)
+	if examInfo == nil {
+		return apiHandlers.SendErrExamNotFound(c)
+	}
+
+	question, err := database.GetExamQuestion(examId, questionId)
+	if err != nil {
+		if err == database.ErrExamQuestionNotFound {
+			return apiHandlers.SendErrExamQuestionNotFound(c)
+		}
+		logging.UnexpectedError("UploadExamAttachment: Failed to get exam question info:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	} else if question == nil {
+		logging.UnexpectedError("UploadExamAttachment: database returned nil for question, with no errors")
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	newData := &database.NewAttachmentData{
+		AttachmentType: attachmentType,
+		ExamId:         examId,
+		QuestionId:     questionId,
+		StorageBackend: backend.GetName(),
+		StorageKey:     getAttachmentStorageKey(examId, questionId),
+		FileName:       sanitizeAttachmentFileName(fileHeader.Filename),
+		UploadedBy:     userInfo.UserId,
+	}
+
+	if attachmentType == database.AttachmentTypeAnswer {
+		accommodation := database.GetExamAccommodationOrNil(userInfo.UserId, examId)
+		if !examInfo.HasExamStartedFor(accommodation) {
+			return apiHandlers.SendErrExamNotStarted(c)
+		} else if examInfo.HasExamFinishedFor(accommodation) {
+			return apiHandlers.SendErrExamFinished(c)
+		} else if !database.HasParticipatedInExam(userInfo.UserId, examId) {
+			return apiHandlers.SendErrNotParticipatedInExam(c)
+		}
+
+		deviceId := getClientDeviceId(c)
+		if !isDeviceIdValid(deviceId) {
+			return apiHandlers.SendErrInvalidDeviceId(c, deviceId)
+		}
+
+		attempt, clientStatus, err := checkAttemptClient(c, userInfo.UserId, examInfo, deviceId)
+		if err != nil {
+			logging.UnexpectedError("UploadExamAttachment: Failed to check attempt client:", err)
+			return apiHandlers.SendErrInternalServerError(c)
+		} else if clientStatus != attemptClientAllowed {
+			return sendAttemptClientError(c, clientStatus)
+		}
+
+		answerStatus, err := checkQuestionAnswerable(examInfo, accommodation, attempt, question)
+		if err != nil {
+			logging.UnexpectedError("UploadExamAttachment: Failed to check if the question is answerable:", err)
+			return apiHandlers.SendErrInternalServerError(c)
+		} else if answerStatus != questionAnswerable {
+			return sendQuestionAnswerError(c, answerStatus)
+		}
+
+		count, err := database.GetAnswerAttachmentsCount(questionId, userInfo.UserId, attempt.AttemptNumber)
+		if err != nil {
+			logging.UnexpectedError("UploadExamAttachment: Failed to get answer attachments count:", err)
+			return apiHandlers.SendErrInternalServerError(c)
+		} else if count >= database.MaxAnswerAttachmentsCount {
+			return apiHandlers.SendErrTooManyAttachments(c)
+		}
+
+		newData.AnsweredBy = &userInfo.UserId
+		newData.AttemptNumber = &attempt.AttemptNumber
+	} else {
+		if !userInfo.CanEditExamQuestion(examInfo) {
+			return apiHandlers.SendErrPermissionDenied(c)
+		}
+
+		if attachmentType == database.AttachmentTypeOption {
+			optionNumber, _ := strconv.Atoi(c.FormValue("option_number"))
+			if !question.HasOptionNumber(optionNumber) {
+				return apiHandlers.SendErrInvalidAnswerOption(c)
+			}
+			newData.OptionNumber = &optionNumber
+		}
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		logging.UnexpectedError("UploadExamAttachment: Failed to open the uploaded file:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+	fileData, err := io.ReadAll(file)
+	_ = file.Close()
+	if err != nil {
+		logging.UnexpectedError("UploadExamAttachment: Failed to read the uploaded file:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	fileType, err := storageUtils.CheckFile(
+		fileHeader.Filename, fileData, int64(len(fileData)), appConfig.GetMaxAttachmentSize(),
+	)
+	if err == storageUtils.ErrUnsupportedFileType {
+		return apiHandlers.SendErrUnsupportedFileType(c)
+	} else if err != nil {
+		return apiHandlers.SendErrFileTooLarge(c)
+	}
+
+	newData.ContentType = fileType.ContentType
+	newData.FileSize = int64(len(fileData))
+
+	err = backend.Put(newData.StorageKey, fileData, newData.ContentType)
+	if err != nil {
+		logging.UnexpectedError("UploadExamAttachment: Failed to store the file:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	attachment, err := database.CreateAttachment(newData)
+	if err != nil {
+		logging.UnexpectedError("UploadExamAttachment: Failed to create attachment:", err)
+		if err := backend.Delete(newData.StorageKey); err != nil {
+			logging.UnexpectedError("UploadExamAttachment: Failed to remove the stored file:", err)
+		}
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	return apiHandlers.SendResult(c, &UploadExamAttachmentResult{
+		Attachment: toExamAttachmentInfo(attachment),
+	})
+}
+
+// GetExamAttachmentV1 godoc
+// @Summary Download a file attached to a question of an exam
+// @Description Allows the user to download a file attached to a question or to one of its options, or uploaded as an answer.
+// @ID getExamAttachmentV1
+// @Tags Exam
+// @Produce octet-stream
+// @Param Authorization header string true "Authorization token"
+// @Param id query int true "Attachment ID"
+// @Success 200 {file} file
+// @Router /api/v1/exam/attachment [get]
+func GetExamAttachmentV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	}
+
+	attachmentId := c.QueryInt("id")
+	if attachmentId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "id")
+	}
+
+	attachment, err := database.GetAttachment(attachmentId)
+	if err == database.ErrAttachmentNotFound {
+		return apiHandlers.SendErrAttachmentNotFound(c)
+	} else if err != nil {
+		logging.UnexpectedError("GetExamAttachment: Failed to get attachment:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	examInfo := database.GetExamInfoOrNil(attachment.ExamId)
+	if examInfo == nil {
+		return apiHandlers.SendErrExamNotFound(c)
+	}
+
+	question, err := database.GetExamQuestion(attachment.ExamId, attachment.QuestionId)
+	if err != nil {
+		if err == database.ErrExamQuestionNotFound {
+			return apiHandlers.SendErrExamQuestionNotFound(c)
+		}
+		logging.UnexpectedError("GetExamAttachment: Failed to get exam question info:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	canSee, err := canSeeAttachment(userInfo, examInfo, question, attachment)
+	if err != nil {
+		logging.UnexpectedError("GetExamAttachment: Failed to check attachment access:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	} else if !canSee {
+		// don't reveal the existence of the attachment
+		return apiHandlers.SendErrAttachmentNotFound(c)
+	}
+
+	backend, err := storageUtils.GetStorageBackend()
+	if err != nil {
+		return apiHandlers.SendErrStorageNotAvailable(c)
+	} else if backend.GetName() != attachment.StorageBackend {
+		logging.UnexpectedError("GetExamAttachment: attachment", attachmentId,
+			"is stored in", attachment.StorageBackend, "which is not the loaded storage backend")
+		return apiHandlers.SendErrStorageNotAvailable(c)
+	}
+
+	reader, err := backend.Get(attachment.StorageKey)
+	if err == storageUtils.ErrObjectNotFound {
+		logging.UnexpectedError("GetExamAttachment: file of attachment", attachmentId, "is missing")
+		return apiHandlers.SendErrAttachmentNotFound(c)
+	} else if err != nil {
+		logging.UnexpectedError("GetExamAttachment: Failed to get the file:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	c.Set(fiber.HeaderContentType, attachment.ContentType)
+	c.Set(fiber.HeaderContentDisposition, `inline; filename="`+attachment.FileName+`"`)
+	c.Set(fiber.HeaderXContentTypeOptions, "nosniff")
+	return c.SendStream(reader, int(attachment.FileSize))
+}
+
+// GetExamAttachmentsV1 godoc
+// @Summary Get the files attached to a question of an exam
+// @Description Allows the user to get the files attached to a question and to its options, alongside the files they have uploaded as their answer in their latest attempt.
+// @ID getExamAttachmentsV1
+// @Tags Exam
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param examId query int true "Exam ID"
+// @Param questionId query int true "Question ID"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=GetExamAttachmentsResult}
+// @Router /api/v1/exam/attachments [get]
+func GetExamAttachmentsV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	}
+
+	examId := c.QueryInt("examId")
+	questionId := c.QueryInt("questionId")
+	if examId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "examId")
+	} else if questionId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "questionId")
+	}
+
+	examInfo := database.GetExamInfoOrNil(examId)
+	if examInfo == nil {
+		return apiHandlers.SendErrExamNotFound(c)
+	}
+
+	question, err := database.GetExamQuestion(examId, questionId)
+	if err != nil {
+		if err == database.ErrExamQuestionNotFound {
+			return apiHandlers.SendErrExamQuestionNotFound(c)
+		}
+		logging.UnexpectedError("GetExamAttachments: Failed to get exam question info:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	canSee, err := canSeeQuestionAttachments(userInfo, examInfo, question)
+	if err != nil {
+		logging.UnexpectedError("GetExamAttachments: Failed to check attachments access:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	} else if !canSee {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	opts := &database.GetQuestionsAttachmentsOptions{
+		QuestionIds: []int{questionId},
+	}
+	latestAttempt := database.GetLatestExamAttemptOrNil(userInfo.UserId, examId)
+	if latestAttempt != nil {
+		opts.AnsweredBy = userInfo.UserId
+		opts.AttemptNumber = latestAttempt.AttemptNumber
+	}
+
+	attachments, err := database.GetQuestionsAttachments(opts)
+	if err != nil {
+		logging.UnexpectedError("GetExamAttachments: Failed to get attachments:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	result := &GetExamAttachmentsResult{
+		ExamId:      examId,
+		QuestionId:  questionId,
+		Attachments: make([]*ExamAttachmentInfo, 0, len(attachments)),
+	}
+	for _, attachment := range attachments {
+		result.Attachments = append(result.Attachments, toExamAttachmentInfo(attachment))
+	}
+
+	return apiHandlers.SendResult(c, result)
+}
+
+// DeleteExamAttachmentV1 godoc
+// @Summary Delete a file attached to a question of an exam
+// @Description Allows the user to delete a file attached to a question or to one of its options, or a file they have uploaded as their answer while their attempt is still ongoing.
+// @ID deleteExamAttachmentV1
+// @Tags Exam
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param Client-Device-ID header string false "Device id of the client taking the exam (required for the answer files)"
+// @Param data body DeleteExamAttachmentData true "Data needed to delete an attachment"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=DeleteExamAttachmentResult}
+// @Router /api/v1/exam/deleteAttachment [post]
+func DeleteExamAttachmentV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	}
+
+	data := &DeleteExamAttachmentData{}
+	if err := c.BodyParser(data); err != nil {
+		return apiHandlers.SendErrInvalidBodyData(c)
+	}
+
+	if data.AttachmentId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "attachment_id")
+	}
+
+	attachment, err := database.GetAttachment(data.AttachmentId)
+	if err == database.ErrAttachmentNotFound {
+		return apiHandlers.SendErrAttachmentNotFound(c)
+	} else if err != nil {
+		logging.UnexpectedError("DeleteExamAttachment: Failed to get attachment:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	examInfo := database.GetExamInfoOrNil(attachment.ExamId)
+	if examInfo == nil {
+		return apiHandlers.SendErrExamNotFound(c)
+	}
+
+	if attachment.IsAnswer() {
+		if attachment.AnsweredBy == nil || *attachment.AnsweredBy != userInfo.UserId {
+			return apiHandlers.SendErrPermissionDenied(c)
+		}
+
+		deviceId := getClientDeviceId(c)
+		if !isDeviceIdValid(deviceId) {
+			return apiHandlers.SendErrInvalidDeviceId(c, deviceId)
+		}
+
+		// answers can only be changed while the attempt they were given in
+		// is still ongoing
+		attempt, clientStatus, err := checkAttemptClient(c, userInfo.UserId, examInfo, deviceId)
+		if err != nil {
+			logging.UnexpectedError("DeleteExamAttachment: Failed to check attempt client:", err)
+			return apiHandlers.SendErrInternalServerError(c)
+		} else if clientStatus != attemptClientAllowed {
+			return sendAttemptClientError(c, clientStatus)
+		} else if attachment.AttemptNumber == nil || *attachment.AttemptNumber != attempt.AttemptNumber {
+			return apiHandlers.SendErrAttemptFinished(c)
+		}
+	} else if !userInfo.CanEditExamQuestion(examInfo) {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	backend, err := storageUtils.GetStorageBackend()
+	if err != nil {
+		return apiHandlers.SendErrStorageNotAvailable(c)
+	} else if backend.GetName() != attachment.StorageBackend {
+		logging.UnexpectedError("DeleteExamAttachment: attachment", attachment.AttachmentId,
+			"is stored in", attachment.StorageBackend, "which is not the loaded storage backend")
+		return apiHandlers.SendErrStorageNotAvailable(c)
+	}
+
+	err = database.DeleteAttachment(attachment.AttachmentId)
+	if err == database.ErrAttachmentNotFound {
+		return apiHandlers.SendErrAttachmentNotFound(c)
+	} else if err != nil {
+		logging.UnexpectedError("DeleteExamAttachment: Failed to delete attachment:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	// the metadata is gone already, a leftover file is harmless
+	if err := backend.Delete(attachment.StorageKey); err != nil {
+		logging.UnexpectedError("DeleteExamAttachment: Failed to remove the stored file:", err)
+	}
+
+	return apiHandlers.SendResult(c, &DeleteExamAttachmentResult{
+		AttachmentId: attachment.AttachmentId,
+		QuestionId:   attachment.QuestionId,
+	})
+}
+
+// StartSimilarityCheckV1 godoc
+// @Summary Start a similarity check of the text answers of a question
+// @Description Allows a teacher to compare the text answers given to a question in the background, reporting the pairs of similar answers; the results are stored and can be reviewed later.
+// @ID startSimilarityCheckV1
+// @Tags Exam
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param data body StartSimilarityCheckData true "Data needed to start a similarity check"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=StartSimilarityCheckResult}
+// @Router /api/v1/exam/checkSimilarity [post]
+func StartSimilarityCheckV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	}
+
+	data := &StartSimilarityCheckData{}
+	if err := c.BodyParser(data); err != nil {
+		return apiHandlers.SendErrInvalidBodyData(c)
+	}
+
+	if data.ExamId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "exam_id")
+	} else if data.QuestionId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "question_id")
+	}
+
+	examInfo := database.GetExamInfoOrNil(data.ExamId)
+	if examInfo == nil {
+		return apiHandlers.SendErrExamNotFound(c)
+	}
+
+	if !userInfo.CanSetScoreForExam(examInfo) {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	_, err := database.GetExamQuestion(data.ExamId, data.QuestionId)
+	if err != nil {
+		if err == database.ErrExamQuestionNotFound {
+			return apiHandlers.SendErrExamQuestionNotFound(c)
+		}
+		logging.UnexpectedError("StartSimilarityCheck: Failed to get exam question info:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	options := &similarityUtils.CompareOptions{
+		ShingleSize: data.ShingleSize,
+		Threshold:   data.Threshold,
+	}
+	if err := similarityUtils.CheckOptions(options); err != nil {
+		return apiHandlers.SendErrInvalidSimilarityOptions(c, err.Error())
+	}
+
+	check, err := database.StartSimilarityCheck(&database.NewSimilarityCheckData{
+		ExamId:      data.ExamId,
+		QuestionId:  data.QuestionId,
+		RequestedBy: userInfo.UserId,
+		ShingleSize: options.ShingleSize,
+		Threshold:   options.Threshold,
+	})
+	if err == database.ErrSimilarityCheckRunning {
+		return apiHandlers.SendErrSimilarityCheckRunning(c)
+	} else if err != nil {
+		logging.UnexpectedError("StartSimilarityCheck: Failed to start similarity check:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	go runSimilarityCheck(check)
+
+	return apiHandlers.SendResult(c, &StartSimilarityCheckResult{
+		Check: toSimilarityCheckInfo(check),
+	})
+}
+
+// GetSimilarityChecksV1 godoc
+// @Summary Get the similarity checks of an exam
+// @Description Allows a teacher to get the similarity checks done on the answers of an exam, optionally only the ones of a single question.
+// @ID getSimilarityChecksV1
+// @Tags Exam
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param examId query int true "Exam ID"
+// @Param questionId query int false "Question ID"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=GetSimilarityChecksResult}
+// @Router /api/v1/exam/similarityChecks [get]
+func GetSimilarityChecksV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	}
+
+	examId := c.QueryInt("examId")
+	if examId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "examId")
+	}
+
+	examInfo := database.GetExamInfoOrNil(examId)
+	if examInfo == nil {
+		return apiHandlers.SendErrExamNotFound(c)
+	}
+
+	if !userInfo.CanSetScoreForExam(examInfo) {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	checks, err := database.GetSimilarityChecks(examId, c.QueryInt("questionId"))
+	if err != nil {
+		logging.UnexpectedError("GetSimilarityChecks: Failed to get similarity checks:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	result := &GetSimilarityChecksResult{
+		ExamId: examId,
+		Checks: make([]*SimilarityCheckInfo, 0, len(checks)),
+	}
+	for _, check := range checks {
+		result.Checks = append(result.Checks, toSimilarityCheckInfo(check))
+	}
+
+	return apiHandlers.SendResult(c, result)
+}
+
+// GetSimilarityCheckV1 godoc
+// @Summary Get a similarity check and its results
+// @Description Allows a teacher to get a similarity check, alongside the pairs of similar answers it has found once it has completed.
+// @ID getSimilarityCheckV1
+// @Tags Exam
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param id query int true "Similarity check ID"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=GetSimilarityCheckResult}
+// @Router /api/v1/exam/similarityCheck [get]
+func GetSimilarityCheckV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	}
+
+	checkId := c.QueryInt("id")
+	if checkId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "id")
+	}
+
+	check, err := database.GetSimilarityCheck(checkId)
+	if err == database.ErrSimilarityCheckNotFound {
+		return apiHandlers.SendErrSimilarityCheckNotFound(c)
+	} else if err != nil {
+		logging.UnexpectedError("GetSimilarityCheck: Failed to get similarity check:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	examInfo := database.GetExamInfoOrNil(check.ExamId)
+	if examInfo == nil {
+		return apiHandlers.SendErrExamNotFound(c)
+	}
+
+	if !userInfo.CanSetScoreForExam(examInfo) {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	result := &GetSimilarityCheckResult{
+		Check:   toSimilarityCheckInfo(check),
+		Matches: []*SimilarityMatchInfo{},
+	}
+	if check.CheckStatus == database.SimilarityCheckStatusCompleted {
+		matches, err := database.GetSimilarityMatches(check.CheckId)
+		if err != nil {
+			logging.UnexpectedError("GetSimilarityCheck: Failed to get similarity matches:", err)
+			return apiHandlers.SendErrInternalServerError(c)
+		}
+
+		for _, match := range matches {
+			result.Matches = append(result.Matches, toSimilarityMatchInfo(match))
+		}
+	}
+
+	return apiHandlers.SendResult(c, result)
+}
+
+// SetExamQuestionMetadataV1 godoc
+// @Summary Set the metadata of a question
+// @Description Allows the user to set the tags, the difficulty level, the learning objectives and the topic of a question, which are used to search for the questions across the exams.
+// @ID setExamQuestionMetadataV1
+// @Tags Exam
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param data body SetExamQuestionMetadataData true "Data needed to set the metadata of a question"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=SetExamQuestionMetadataResult}
+// @Router /api/v1/exam/setQuestionMetadata [post]
+func SetExamQuestionMetadataV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	}
+
+	data := &SetExamQuestionMetadataData{}
+	if err := c.BodyParser(data); err != nil {
+		return apiHandlers.SendErrInvalidBodyData(c)
+	}
+
+	if data.ExamId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "exam_id")
+	} else if data.QuestionId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "question_id")
+	}
+
+	tags, err := normalizeQuestionTags(data.Tags)
+	if err != nil {
+		return apiHandlers.SendErrInvalidQuestionMetadata(c, err.Error())
+	}
+
+	objectives, err := normalizeLearningObjectives(data.LearningObjectives)
+	if err != nil {
+		return apiHandlers.SendErrInvalidQuestionMetadata(c, err.Error())
+	}
+
+	if data.DifficultyLevel != nil && !database.IsQuestionDifficultyValid(*data.DifficultyLevel) {
+		return apiHandlers.SendErrInvalidQuestionMetadata(c, "unknown difficulty level")
+	}
+
+	examInfo := database.GetExamInfoOrNil(data.ExamId)
+	if examInfo == nil {
+		return apiHandlers.SendErrExamNotFound(c)
+	} else if !userInfo.CanEditExamQuestion(examInfo) {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	if data.TopicId != nil {
+		_, err = database.GetTopicInfo(*data.TopicId)
+		if err == database.ErrTopicNotFound {
+			return apiHandlers.SendErrTopicNotFound(c)
+		} else if err != nil {
+			logging.UnexpectedError("SetExamQuestionMetadata: Failed to get topic info:", err)
+			return apiHandlers.SendErrInternalServerError(c)
+		}
+	}
+
+	question, err := database.GetExamQuestion(data.ExamId, data.QuestionId)
+	if err == database.ErrExamQuestionNotFound ||
+		(err == nil && question.ExamId != data.ExamId) {
+		return apiHandlers.SendErrExamQuestionNotFound(c)
+	} else if err != nil {
+		logging.UnexpectedError("SetExamQuestionMetadata: Failed to get exam question:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	question, err = database.SetExamQuestionMetadata(&database.SetExamQuestionMetadataData{
+		ExamId:             data.ExamId,
+		QuestionId:         data.QuestionId,
+		Tags:               tags,
+		DifficultyLevel:    data.DifficultyLevel,
+		LearningObjectives: objectives,
+		TopicId:            data.TopicId,
+	})
+	if err != nil {
+		logging.UnexpectedError("SetExamQuestionMetadata: Failed to set exam question metadata:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	return apiHandlers.SendResult(c, &SetExamQuestionMetadataResult{
+		ExamId:     question.ExamId,
+		QuestionId: question.QuestionId,
+		Metadata:   toExamQuestionMetadataInfo(question),
+	})
+}
+
+// SearchExamQuestionsV1 godoc
+// @Summary Search for questions across the exams
+// @Description Allows the user to search for questions by their tags, difficulty level, topic and learning objectives, and by the text of their title and description. Only the questions the user is allowed to peek (the ones of the exams they have created or collaborate on, or all of them for the admins) are searched.
+// @ID searchExamQuestionsV1
+// @Tags Exam
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param data body SearchExamQuestionsData true "Data needed to search for questions"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=SearchExamQuestionsResult}
+// @Router /api/v1/exam/searchQuestions [post]
+func SearchExamQuestionsV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	}
+
+	data := &SearchExamQuestionsData{}
+	if err := c.BodyParser(data); err != nil {
+		return apiHandlers.SendErrInvalidBodyData(c)
+	}
+
+	tags, err := normalizeQuestionTags(data.Tags)
+	if err != nil {
+		return apiHandlers.SendErrInvalidQuestionMetadata(c, err.Error())
+	}
+
+	if data.DifficultyLevel != nil && !database.IsQuestionDifficultyValid(*data.DifficultyLevel) {
+		return apiHandlers.SendErrInvalidQuestionMetadata(c, "unknown difficulty level")
+	}
+
+	if data.Limit <= 0 {
+		data.Limit = database.DefaultSearchedQuestionsLimit
+	} else if data.Limit > database.MaxSearchedQuestionsLimit {
+		data.Limit = database.MaxSearchedQuestionsLimit
+	}
+	if data.Offset < 0 {
+		data.Offset = 0
+	}
+
+	searchData := &database.SearchExamQuestionsData{
+		SearchQuery:       strings.TrimSpace(data.SearchQuery),
+		Tags:              tags,
+		DifficultyLevel:   data.DifficultyLevel,
+		TopicId:           data.TopicId,
+		LearningObjective: strings.TrimSpace(data.LearningObjective),
+		Offset:            data.Offset,
+		Limit:             data.Limit,
+	}
+	if !userInfo.CanPeekAllExamQuestions() {
+		// same as CanPeekExamQuestions: the others can only peek the
+		// questions of the exams they have created or collaborate on
+		searchData.PeekableBy = &userInfo.UserId
+	}
+
+	questions, err := database.SearchExamQuestions(searchData)
+	if err != nil {
+		logging.UnexpectedError("SearchExamQuestions: Failed to search exam questions:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	result := &SearchExamQuestionsResult{
+		Questions: make([]*SearchedExamQuestionInfo, 0, len(questions)),
+	}
+	for _, q := range questions {
+		result.Questions = append(result.Questions, &SearchedExamQuestionInfo{
+			QuestionId:    q.QuestionId,
+			ExamId:        q.ExamId,
+			ExamTitle:     q.ExamTitle,
+			QuestionTitle: q.QuestionTitle,
+			Description:   q.Description,
+			ContentFormat: q.ContentFormat,
+			Metadata: &ExamQuestionMetadataInfo{
+				Tags:               q.Tags,
+				DifficultyLevel:    q.DifficultyLevel,
+				LearningObjectives: q.LearningObjectives,
+				TopicId:            q.TopicId,
+			},
+			CreatedAt: q.CreatedAt,
+		})
+	}
+
+	return apiHandlers.SendResult(c, result)
+}
+
+// SubmitExamForReviewV1 godoc
+// @Summary Submit an exam for review
+// @Description Allows the author of a draft exam to submit it for review; the exam becomes visible to the students once a reviewer approves it.
+// @ID submitExamForReviewV1
+// @Tags Exam
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param data body SubmitExamForReviewData true "Data needed to submit an exam for review"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=ExamReviewStatusResult}
+// @Router /api/v1/exam/submitForReview [post]
+func SubmitExamForReviewV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	}
+
+	data := &SubmitExamForReviewData{}
+	if err := c.BodyParser(data); err != nil {
+		return apiHandlers.SendErrInvalidBodyData(c)
+	}
+
+	if data.ExamId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "exam_id")
+	}
+
+	examInfo := database.GetExamInfoOrNil(data.ExamId)
+	if examInfo == nil {
+		return apiHandlers.SendErrExamNotFound(c)
+	} else if !userInfo.CanEditExam(examInfo) {
+		return apiHandlers.SendErrPermissionDenied(c)
+	} else if !examInfo.IsDraft() {
+		return apiHandlers.SendErrInvalidReviewStatus(c, examInfo.ReviewStatus)
+	}
+
+	examInfo, err := database.ChangeExamReviewStatus(&database.ChangeExamReviewStatusData{
+		ExamId:         data.ExamId,
+		ExpectedStatus: database.ExamReviewStatusDraft,
+		NewStatus:      database.ExamReviewStatusInReview,
+		ChangedBy:      userInfo.UserId,
+	})
+	if err == database.ErrExamReviewStatusChanged {
+		return apiHandlers.SendErrInvalidReviewStatus(c, database.ExamReviewStatusDraft)
+	} else if err != nil {
+		logging.UnexpectedError("SubmitExamForReview: Failed to change exam review status:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	return apiHandlers.SendResult(c, toExamReviewStatusResult(examInfo))
+}
+
+// ReviewExamV1 godoc
+// @Summary Approve an exam or send it back to draft
+// @Description Allows a reviewer (an admin, or a teacher marked as an exam reviewer) to publish an exam which is in review, or to send it back to its authors with a comment.
+// @ID reviewExamV1
+// @Tags Exam
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param data body ReviewExamData true "Data needed to review an exam"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=ExamReviewStatusResult}
+// @Router /api/v1/exam/review [post]
+func ReviewExamV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	}
+
+	data := &ReviewExamData{}
+	if err := c.BodyParser(data); err != nil {
+		return apiHandlers.SendErrInvalidBodyData(c)
+	}
+
+	if data.ExamId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "exam_id")
+	}
+
+	var commentText string
+	if data.Comment != nil {
+		commentText = strings.TrimSpace(*data.Comment)
+		if len(commentText) > database.MaxReviewCommentLength {
+			return apiHandlers.SendErrBodyTooLong(c)
+		}
+	}
+	if !data.Approve && commentText == "" {
+		// the authors need to know what has to be changed
+		return apiHandlers.SendErrParameterRequired(c, "comment")
+	}
+
+	examInfo := database.GetExamInfoOrNil(data.ExamId)
+	if examInfo == nil {
+		return apiHandlers.SendErrExamNotFound(c)
+	} else if !userInfo.CanReviewExam(examInfo) {
+		return apiHandlers.SendErrPermissionDenied(c)
+	} else if !examInfo.IsInReview() {
+		return apiHandlers.SendErrInvalidReviewStatus(c, examInfo.ReviewStatus)
+	}
+
+	newStatus := database.ExamReviewStatusDraft
+	if data.Approve {
+		newStatus = database.ExamReviewStatusPublished
+	}
+
+	examInfo, err := database.ChangeExamReviewStatus(&database.ChangeExamReviewStatusData{
+		ExamId:         data.ExamId,
+		ExpectedStatus: database.ExamReviewStatusInReview,
+		NewStatus:      newStatus,
+		ChangedBy:      userInfo.UserId,
+	})
+	if err == database.ErrExamReviewStatusChanged {
+		return apiHandlers.SendErrInvalidReviewStatus(c, database.ExamReviewStatusInReview)
+	} else if err != nil {
+		logging.UnexpectedError("ReviewExam: Failed to change exam review status:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	result := toExamReviewStatusResult(examInfo)
+	if commentText != "" {
+		comment, err := database.AddExamReviewComment(&database.NewExamReviewCommentData{
+			ExamId:      data.ExamId,
+			AuthorId:    userInfo.UserId,
+			CommentText: commentText,
+		})
+		if err != nil {
+			logging.UnexpectedError("ReviewExam: Failed to add review comment:", err)
+			return apiHandlers.SendErrInternalServerError(c)
+		}
+
+		result.Comment = toExamReviewCommentInfo(comment)
+	}
+
+	return apiHandlers.SendResult(c, result)
+}
+
+// GetExamReviewQueueV1 godoc
+// @Summary Get the exams waiting for a review
+// @Description Allows a reviewer to get the exams which have been submitted for review, the ones submitted the earliest first.
+// @ID getExamReviewQueueV1
+// @Tags Exam
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param offset query int false "Offset"
+// @Param limit query int false "Limit"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=GetExamReviewQueueResult}
+// @Router /api/v1/exam/reviewQueue [get]
+func GetExamReviewQueueV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	} else if !userInfo.CanReviewExams() {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	offset := c.QueryInt("offset")
+	limit := c.QueryInt("limit", database.DefaultReviewQueueLimit)
+	if offset < 0 {
+		offset = 0
+	}
+	if limit <= 0 || limit > database.MaxReviewQueueLimit {
+		limit = database.MaxReviewQueueLimit
+	}
+
+	exams, err := database.GetExamsInReview(offset, limit)
+	if err != nil {
+		logging.UnexpectedError("GetExamReviewQueue: Failed to get exams in review:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	result := &GetExamReviewQueueResult{
+		Exams: make([]*ExamReviewQueueEntry, 0, len(exams)),
+	}
+	for _, exam := range exams {
+		result.Exams = append(result.Exams, &ExamReviewQueueEntry{
+			ExamId:      exam.ExamId,
+			ExamTitle:   exam.ExamTitle,
+			CreatedBy:   exam.CreatedBy,
+			ExamDate:    exam.ExamDate,
+			SubmittedAt: ssg.Clone(exam.SubmittedAt),
+		})
+	}
+
+	return apiHandlers.SendResult(c, result)
+}
+
+// AddExamReviewCommentV1 godoc
+// @Summary Leave a review comment on an exam or on one of its questions
+// @Description Allows the reviewers and the authors of an exam to leave comments on the exam or on its individual questions.
+// @ID addExamReviewCommentV1
+// @Tags Exam
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param data body AddExamReviewCommentData true "Data needed to leave a review comment"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=ExamReviewCommentInfo}
+// @Router /api/v1/exam/addReviewComment [post]
+func AddExamReviewCommentV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	}
+
+	data := &AddExamReviewCommentData{}
+	if err := c.BodyParser(data); err != nil {
+		return apiHandlers.SendErrInvalidBodyData(c)
+	}
+
+	data.CommentText = strings.TrimSpace(data.CommentText)
+	if data.ExamId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "exam_id")
+	} else if data.CommentText == "" {
+		return apiHandlers.SendErrParameterRequired(c, "comment_text")
+	} else if len(data.CommentText) > database.MaxReviewCommentLength {
+		return apiHandlers.SendErrBodyTooLong(c)
+	}
+
+	examInfo := database.GetExamInfoOrNil(data.ExamId)
+	if examInfo == nil {
+		return apiHandlers.SendErrExamNotFound(c)
+	} else if !canCommentOnExamReview(userInfo, examInfo) {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	if data.QuestionId != nil {
+		question, err := database.GetExamQuestion(data.ExamId, *data.QuestionId)
+		if err == database.ErrExamQuestionNotFound ||
+			(err == nil && question.ExamId != data.ExamId) {
+			return apiHandlers.SendErrExamQuestionNotFound(c)
+		} else if err != nil {
+			logging.UnexpectedError("AddExamReviewComment: Failed to get exam question:", err)
+			return apiHandlers.SendErrInternalServerError(c)
+		}
+	}
+
+	comment, err := database.AddExamReviewComment(&database.NewExamReviewCommentData{
+		ExamId:      data.ExamId,
+		QuestionId:  data.QuestionId,
+		AuthorId:    userInfo.UserId,
+		CommentText: data.CommentText,
+	})
+	if err != nil {
+		logging.UnexpectedError("AddExamReviewComment: Failed to add review comment:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	return apiHandlers.SendResult(c, toExamReviewCommentInfo(comment))
+}
+
+// ResolveExamReviewCommentV1 godoc
+// @Summary Resolve a review comment
+// @Description Allows the authors of an exam (or the author of the comment) to mark a review comment as addressed.
+// @ID resolveExamReviewCommentV1
+// @Tags Exam
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param data body ResolveExamReviewCommentData true "Data needed to resolve a review comment"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=ExamReviewCommentInfo}
+// @Router /api/v1/exam/resolveReviewComment [post]
+func ResolveExamReviewCommentV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	}
+
+	data := &ResolveExamReviewCommentData{}
+	if err := c.BodyParser(data); err != nil {
+		return apiHandlers.SendErrInvalidBodyData(c)
+	}
+
+	if data.CommentId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "comment_id")
+	}
+
+	comment, err := database.GetExamReviewComment(data.CommentId)
+	if err == database.ErrReviewCommentNotFound {
+		return apiHandlers.SendErrReviewCommentNotFound(c)
+	} else if err != nil {
+		logging.UnexpectedError("ResolveExamReviewComment: Failed to get review comment:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	examInfo := database.GetExamInfoOrNil(comment.ExamId)
+	if examInfo == nil {
+		return apiHandlers.SendErrExamNotFound(c)
+	} else if comment.AuthorId != userInfo.UserId && !userInfo.CanEditExam(examInfo) {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	if !comment.IsResolved {
+		err = database.ResolveExamReviewComment(comment, userInfo.UserId)
+		if err != nil {
+			logging.UnexpectedError("ResolveExamReviewComment: Failed to resolve review comment:", err)
+			return apiHandlers.SendErrInternalServerError(c)
+		}
+	}
+
+	return apiHandlers.SendResult(c, toExamReviewCommentInfo(comment))
+}
+
+// GetExamReviewCommentsV1 godoc
+// @Summary Get the review comments of an exam
+// @Description Allows the reviewers and the authors of an exam to get the comments left on the exam and on its questions, the oldest ones first.
+// @ID getExamReviewCommentsV1
+// @Tags Exam
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param examId query int true "Exam ID"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=GetExamReviewCommentsResult}
+// @Router /api/v1/exam/reviewComments [get]
+func GetExamReviewCommentsV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	}
+
+	examId := c.QueryInt("examId")
+	if examId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "examId")
+	}
+
+	examInfo := database.GetExamInfoOrNil(examId)
+	if examInfo == nil {
+		return apiHandlers.SendErrExamNotFound(c)
+	} else if !canCommentOnExamReview(userInfo, examInfo) {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	comments, err := database.GetExamReviewComments(examId)
+	if err != nil {
+		logging.UnexpectedError("GetExamReviewComments: Failed to get review comments:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	result := &GetExamReviewCommentsResult{
+		ExamId:       examId,
+		ReviewStatus: examInfo.ReviewStatus,
+		Comments:     make([]*ExamReviewCommentInfo, 0, len(comments)),
+	}
+	for _, comment := range comments {
+		result.Comments = append(result.Comments, toExamReviewCommentInfo(comment))
+	}
+
+	return apiHandlers.SendResult(c, result)
+}
+
+// SetExamCollaboratorV1 godoc
+// @Summary Add a collaborator to an exam
+// @Description Allows the creator of an exam (or an admin) to add another teacher as a collaborator of the exam, or to change the role of an existing collaborator. Editors can edit the exam and its questions, graders can score the participants and viewers can only see the exam and its questions.
+// @ID setExamCollaboratorV1
+// @Tags Exam
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param data body SetExamCollaboratorData true "Data needed to set the collaborator"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=ExamCollaboratorInfo}
+// @Router /api/v1/exam/setCollaborator [post]
+func SetExamCollaboratorV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	}
+
+	data := &SetExamCollaboratorData{}
+	if err := c.BodyParser(data); err != nil {
+		return apiHandlers.SendErrInvalidBodyData(c)
+	}
+
+	if data.ExamId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "exam_id")
+	} else if data.UserId == "" {
+		return apiHandlers.SendErrParameterRequired(c, "user_id")
+	} else if data.CollaboratorRole == "" {
+		return apiHandlers.SendErrParameterRequired(c, "collaborator_role")
+	} else if !database.IsCollaboratorRoleValid(data.CollaboratorRole) {
+		return apiHandlers.SendErrInvalidCollaboratorRole(c, data.CollaboratorRole)
+	}
+
+	examInfo := database.GetExamInfoOrNil(data.ExamId)
+	if examInfo == nil {
+		return apiHandlers.SendErrExamNotFound(c)
+	} else if !userInfo.CanManageExamCollaborators(examInfo) {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	targetUser, err := database.GetUserByUserId(data.UserId)
+	if err != nil {
+		if err == database.ErrUserNotFound {
+			return apiHandlers.SendErrInvalidUserID(c)
+		}
+		logging.UnexpectedError("SetExamCollaborator: Failed to get user info:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	} else if targetUser == nil {
+		return apiHandlers.SendErrInvalidUserID(c)
+	} else if targetUser.Role != appValues.UserRoleTeacher ||
+		targetUser.UserId == examInfo.CreatedBy {
+		// owners and admins can already do everything, students can
+		// never be collaborators, and the creator already owns the exam
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	if database.GetExamCollaboratorOrNil(data.UserId, data.ExamId) == nil {
+		count, err := database.GetExamCollaboratorsCount(data.ExamId)
+		if err != nil {
+			logging.UnexpectedError("SetExamCollaborator: Failed to get collaborators count:", err)
+			return apiHandlers.SendErrInternalServerError(c)
+		} else if count >= database.MaxExamCollaboratorsCount {
+			return apiHandlers.SendErrTooManyCollaborators(c)
+		}
+	}
+
+	collaborator, err := database.SetExamCollaborator(&database.SetExamCollaboratorData{
+		ExamId:           data.ExamId,
+		UserId:           data.UserId,
+		CollaboratorRole: data.CollaboratorRole,
+		AddedBy:          &userInfo.UserId,
+	})
+	if err != nil {
+		logging.UnexpectedError("SetExamCollaborator: Failed to set collaborator:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	return apiHandlers.SendResult(c, toExamCollaboratorInfo(collaborator))
+}
+
+// RemoveExamCollaboratorV1 godoc
+// @Summary Remove a collaborator from an exam
+// @Description Allows the creator of an exam (or an admin) to remove a collaborator from the exam.
+// @ID removeExamCollaboratorV1
+// @Tags Exam
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param data body RemoveExamCollaboratorData true "Data needed to remove the collaborator"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=RemoveExamCollaboratorResult}
+// @Router /api/v1/exam/removeCollaborator [post]
+func RemoveExamCollaboratorV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	}
+
+	data := &RemoveExamCollaboratorData{}
+	if err := c.BodyParser(data); err != nil {
+		return apiHandlers.SendErrInvalidBodyData(c)
+	}
+
+	if data.ExamId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "exam_id")
+	} else if data.UserId == "" {
+		return apiHandlers.SendErrParameterRequired(c, "user_id")
+	}
+
+	examInfo := database.GetExamInfoOrNil(data.ExamId)
+	if examInfo == nil {
+		return apiHandlers.SendErrExamNotFound(c)
+	} else if !userInfo.CanManageExamCollaborators(examInfo) {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	err := database.RemoveExamCollaborator(data.UserId, data.ExamId)
+	if err == database.ErrExamCollaboratorNotFound {
+		return apiHandlers.SendErrExamCollaboratorNotFound(c)
+	} else if err != nil {
+		logging.UnexpectedError("RemoveExamCollaborator: Failed to remove collaborator:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	return apiHandlers.SendResult(c, &RemoveExamCollaboratorResult{
+		ExamId: data.ExamId,
+		UserId: data.UserId,
+	})
+}
+
+// GetExamCollaboratorsV1 godoc
+// @Summary Get the collaborators of an exam
+// @Description Allows the users who can peek the questions of an exam to get the list of its collaborators and their roles.
+// @ID getExamCollaboratorsV1
+// @Tags Exam
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param examId query int true "Exam ID"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=GetExamCollaboratorsResult}
+// @Router /api/v1/exam/collaborators [get]
+func GetExamCollaboratorsV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	}
+
+	examId := c.QueryInt("examId")
+	if examId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "examId")
+	}
+
+	examInfo := database.GetExamInfoOrNil(examId)
+	if examInfo == nil {
+		return apiHandlers.SendErrExamNotFound(c)
+	} else if !userInfo.CanPeekExamQuestions(examInfo) {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	collaborators, err := database.GetExamCollaborators(examId)
+	if err != nil {
+		logging.UnexpectedError("GetExamCollaborators: Failed to get collaborators:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	result := &GetExamCollaboratorsResult{
+		ExamId:        examId,
+		CreatedBy:     examInfo.CreatedBy,
+		CanManage:     userInfo.CanManageExamCollaborators(examInfo),
+		Collaborators: make([]*ExamCollaboratorInfo, 0, len(collaborators)),
+	}
+	for _, collaborator := range collaborators {
+		result.Collaborators = append(result.Collaborators, toExamCollaboratorInfo(collaborator))
+	}
+
+	return apiHandlers.SendResult(c, result)
+}
+
+// StartExamPreviewV1 godoc
+// @Summary Start a preview attempt in an exam
+// @Description Allows the teachers (and the reviewers) of an exam to take it exactly as a student would, to check its timing and grading. The answers of a preview are kept in a sandbox, so they neither make the user a participant of the exam nor affect its statistics.
+// @ID startExamPreviewV1
+// @Tags Exam
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param data body StartExamPreviewData true "Data needed to start a preview attempt"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=ExamPreviewResult}
+// @Router /api/v1/exam/startPreview [post]
+func StartExamPreviewV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	}
+
+	data := &StartExamPreviewData{}
+	if err := c.BodyParser(data); err != nil {
+		return apiHandlers.SendErrInvalidBodyData(c)
+	}
+
+	if data.ExamId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "exam_id")
+	}
+
+	examInfo := database.GetExamInfoOrNil(data.ExamId)
+	if examInfo == nil {
+		return apiHandlers.SendErrExamNotFound(c)
+	} else if !userInfo.CanPreviewExam(examInfo) {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	ongoingCount, err := database.GetOngoingPreviewAttemptsCount(data.ExamId, userInfo.UserId)
+	if err != nil {
+		logging.UnexpectedError("StartExamPreview: Failed to get ongoing previews count:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	} else if ongoingCount >= database.MaxOngoingPreviewAttempts {
+		return apiHandlers.SendErrTooManyPreviewAttempts(c)
+	}
+
+	preview, err := database.StartExamPreviewAttempt(data.ExamId, userInfo.UserId)
+	if err != nil {
+		logging.UnexpectedError("StartExamPreview: Failed to start preview:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	result, err := getExamPreviewResult(preview, examInfo, data.RenderHtml)
+	if err != nil {
+		logging.UnexpectedError("StartExamPreview: Failed to get preview result:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	return apiHandlers.SendResult(c, result)
+}
+
+// GetExamPreviewV1 godoc
+// @Summary Get a preview attempt
+// @Description Allows the user to get the questions of their preview attempt alongside the answers they have given in it; the score breakdown of the preview is returned once it has been finished.
+// @ID getExamPreviewV1
+// @Tags Exam
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param id query int true "Preview ID"
+// @Param renderHtml query bool false "Return the safe HTML variant of the content too"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=ExamPreviewResult}
+// @Router /api/v1/exam/preview [get]
+func GetExamPreviewV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	}
+
+	previewId := c.QueryInt("id")
+	if previewId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "id")
+	}
+
+	preview, err := database.GetExamPreviewAttempt(previewId)
+	if err == database.ErrPreviewAttemptNotFound ||
+		(err == nil && preview.UserId != userInfo.UserId) {
+		// previews are personal, nobody else needs to know about them
+		return apiHandlers.SendErrPreviewAttemptNotFound(c)
+	} else if err != nil {
+		logging.UnexpectedError("GetExamPreview: Failed to get preview:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	examInfo := database.GetExamInfoOrNil(preview.ExamId)
+	if examInfo == nil {
+		return apiHandlers.SendErrExamNotFound(c)
+	} else if !userInfo.CanPreviewExam(examInfo) {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	result, err := getExamPreviewResult(preview, examInfo, c.QueryBool("renderHtml"))
+	if err != nil {
+		logging.UnexpectedError("GetExamPreview: Failed to get preview result:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	return apiHandlers.SendResult(c, result)
+}
+
+// AnswerPreviewQuestionV1 godoc
+// @Summary Answer a question in a preview attempt
+// @Description Allows the user to answer a question of the exam in their preview attempt. The answer is only stored in the sandbox of the preview.
+// @ID answerPreviewQuestionV1
+// @Tags Exam
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param data body AnswerPreviewQuestionData true "Data needed to answer a question in a preview attempt"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=AnswerPreviewQuestionResult}
+// @Router /api/v1/exam/previewAnswer [post]
+func AnswerPreviewQuestionV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	}
+
+	data := &AnswerPreviewQuestionData{}
+	if err := c.BodyParser(data); err != nil {
+		return apiHandlers.SendErrInvalidBodyData(c)
+	}
+
+	if data.PreviewId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "preview_id")
+	} else if data.QuestionId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "question_id")
+	} else if data.ChosenOption == nil && data.AnswerText == nil {
+		return apiHandlers.SendErrParameterRequired(c, "chosen_option or answer")
+	}
+
+	preview, err := database.GetExamPreviewAttempt(data.PreviewId)
+	if err == database.ErrPreviewAttemptNotFound ||
+		(err == nil && preview.UserId != userInfo.UserId) {
+		return apiHandlers.SendErrPreviewAttemptNotFound(c)
+	} else if err != nil {
+		logging.UnexpectedError("AnswerPreviewQuestion: Failed to get preview:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	} else if preview.IsFinished() {
+		return apiHandlers.SendErrPreviewAttemptFinished(c)
+	}
+
+	examInfo := database.GetExamInfoOrNil(preview.ExamId)
+	if examInfo == nil {
+		return apiHandlers.SendErrExamNotFound(c)
+	} else if !userInfo.CanPreviewExam(examInfo) {
+		return apiHandlers.SendErrPermissionDenied(c)
+	} else if preview.HasDeadlinePassed(examInfo) {
+		return apiHandlers.SendErrPreviewTimeUp(c)
+	}
+
+	question, err := database.GetExamQuestion(preview.ExamId, data.QuestionId)
+	if err == database.ErrExamQuestionNotFound ||
+		(err == nil && question.ExamId != preview.ExamId) {
+		return apiHandlers.SendErrExamQuestionNotFound(c)
+	} else if err != nil {
+		logging.UnexpectedError("AnswerPreviewQuestion: Failed to get exam question:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	if data.ChosenOption != nil && !question.HasOption(*data.ChosenOption) {
+		return apiHandlers.SendErrInvalidAnswerOption(c)
+	}
+
+	answer, err := database.AnswerPreviewQuestion(&database.PreviewAnswerData{
+		PreviewId:    preview.PreviewId,
+		QuestionId:   data.QuestionId,
+		ChosenOption: data.ChosenOption,
+		AnswerText:   data.AnswerText,
+		SecondsTaken: data.SecondsTaken,
+	})
+	if err != nil {
+		logging.UnexpectedError("AnswerPreviewQuestion: Failed to answer question:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	result := &AnswerPreviewQuestionResult{
+		PreviewId:  answer.PreviewId,
+		QuestionId: answer.QuestionId,
+		AnsweredAt: answer.AnsweredAt,
+	}
+	if examInfo.IsPractice {
+		result.Feedback = toAnswerFeedbackInfo(question, data.ChosenOption)
+	}
+
+	return apiHandlers.SendResult(c, result)
+}
+
+// FinishExamPreviewV1 godoc
+// @Summary Finish a preview attempt
+// @Description Allows the user to finish their preview attempt and get its computed score breakdown. Finishing an already finished preview just returns its breakdown again.
+// @ID finishExamPreviewV1
+// @Tags Exam
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param data body FinishExamPreviewData true "Data needed to finish a preview attempt"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=ExamPreviewResult}
+// @Router /api/v1/exam/finishPreview [post]
+func FinishExamPreviewV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	}
+
+	data := &FinishExamPreviewData{}
+	if err := c.BodyParser(data); err != nil {
+		return apiHandlers.SendErrInvalidBodyData(c)
+	}
+
+	if data.PreviewId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "preview_id")
+	}
+
+	preview, err := database.GetExamPreviewAttempt(data.PreviewId)
+	if err == database.ErrPreviewAttemptNotFound ||
+		(err == nil && preview.UserId != userInfo.UserId) {
+		return apiHandlers.SendErrPreviewAttemptNotFound(c)
+	} else if err != nil {
+		logging.UnexpectedError("FinishExamPreview: Failed to get preview:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	examInfo := database.GetExamInfoOrNil(preview.ExamId)
+	if examInfo == nil {
+		return apiHandlers.SendErrExamNotFound(c)
+	} else if !userInfo.CanPreviewExam(examInfo) {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	err = database.FinishExamPreviewAttempt(preview)
+	if err == database.ErrPreviewAttemptNotFound {
+		// discarded in the meantime
+		return apiHandlers.SendErrPreviewAttemptNotFound(c)
+	} else if err != nil {
+		logging.UnexpectedError("FinishExamPreview: Failed to finish preview:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	result, err := getExamPreviewResult(preview, examInfo, false)
+	if err != nil {
+		logging.UnexpectedError("FinishExamPreview: Failed to get preview result:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	return apiHandlers.SendResult(c, result)
+}
+
+// DiscardExamPreviewV1 godoc
+// @Summary Discard a preview attempt
+// @Description Allows the user to throw away their preview attempt, alongside all of the answers given in it.
+// @ID discardExamPreviewV1
+// @Tags Exam
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param data body DiscardExamPreviewData true "Data needed to discard a preview attempt"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=DiscardExamPreviewResult}
+// @Router /api/v1/exam/discardPreview [post]
+func DiscardExamPreviewV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	}
+
+	data := &DiscardExamPreviewData{}
+	if err := c.BodyParser(data); err != nil {
+		return apiHandlers.SendErrInvalidBodyData(c)
+	}
+
+	if data.PreviewId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "preview_id")
+	}
+
+	preview, err := database.GetExamPreviewAttempt(data.PreviewId)
+	if err == database.ErrPreviewAttemptNotFound ||
+		(err == nil && preview.UserId != userInfo.UserId) {
+		return apiHandlers.SendErrPreviewAttemptNotFound(c)
+	} else if err != nil {
+		logging.UnexpectedError("DiscardExamPreview: Failed to get preview:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	err = database.DiscardExamPreviewAttempt(preview.PreviewId)
+	if err == database.ErrPreviewAttemptNotFound {
+		return apiHandlers.SendErrPreviewAttemptNotFound(c)
+	} else if err != nil {
+		logging.UnexpectedError("DiscardExamPreview: Failed to discard preview:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	return apiHandlers.SendResult(c, &DiscardExamPreviewResult{
+		PreviewId: preview.PreviewId,
+		ExamId:    preview.ExamId,
+	})
+}
+
+// GetExamPreviewsV1 godoc
+// @Summary Get the preview attempts of the user in an exam
+// @Description Allows the user to get the list of their preview attempts in an exam, the latest ones first.
+// @ID getExamPreviewsV1
+// @Tags Exam
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param examId query int true "Exam ID"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=GetExamPreviewsResult}
+// @Router /api/v1/exam/previews [get]
+func GetExamPreviewsV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	}
+
+	examId := c.QueryInt("examId")
+	if examId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "examId")
+	}
+
+	examInfo := database.GetExamInfoOrNil(examId)
+	if examInfo == nil {
+		return apiHandlers.SendErrExamNotFound(c)
+	} else if !userInfo.CanPreviewExam(examInfo) {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	previews, err := database.GetExamPreviewAttempts(examId, userInfo.UserId)
+	if err != nil {
+		logging.UnexpectedError("GetExamPreviews: Failed to get previews:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	result := &GetExamPreviewsResult{
+		ExamId:   examId,
+		Previews: make([]*ExamPreviewInfo, 0, len(previews)),
+	}
+	for _, preview := range previews {
+		result.Previews = append(result.Previews, toExamPreviewInfo(preview, examInfo))
+	}
+
+	return apiHandlers.SendResult(c, result)
+}
+
+// SetExamPracticeModeV1 godoc
+// @Summary Turn an exam into a practice exam
+// @Description Allows the user to turn an exam into a practice exam (or back into a graded one) before it starts. The attempts of the practice exams are unlimited and are not graded; the students get told whether their answer was right, alongside the explanation of the question, right after answering it. Their answers still earn them experience in the topic of the exam.
+// @ID setExamPracticeModeV1
+// @Tags Exam
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param data body SetExamPracticeModeData true "Data needed to set the practice mode of an exam"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=ExamPracticeModeResult}
+// @Router /api/v1/exam/setPracticeMode [post]
+func SetExamPracticeModeV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	} else if !userInfo.CanTryToEditExam() {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	data := &SetExamPracticeModeData{}
+	if err := c.BodyParser(data); err != nil {
+		return apiHandlers.SendErrInvalidBodyData(c)
+	}
+
+	if data.ExamId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "exam_id")
+	}
+
+	examInfo := database.GetExamInfoOrNil(data.ExamId)
+	if examInfo == nil {
+		return apiHandlers.SendErrExamNotFound(c)
+	} else if !userInfo.CanEditExam(examInfo) {
+		return apiHandlers.SendErrPermissionDenied(c)
+	} else if examInfo.HasExamStarted() {
+		// the attempts (and scores) taken so far would make no sense
+		// under the other mode
+		return apiHandlers.SendErrExamAlreadyStarted(c)
+	}
+
+	examInfo, err := database.SetExamPracticeMode(&database.SetExamPracticeModeData{
+		ExamId:     data.ExamId,
+		IsPractice: data.IsPractice,
+	})
+	if err != nil {
+		logging.UnexpectedError("SetExamPracticeMode: Failed to set exam practice mode:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	return apiHandlers.SendResult(c, &ExamPracticeModeResult{
+		ExamId:     examInfo.ExamId,
+		IsPractice: examInfo.IsPractice,
+	})
+}
+
+// SetAttemptReviewPolicyV1 godoc
+// @Summary Set the attempt review policy of an exam
+// @Description Allows the user to set when the students can review their attempts in an exam: never, after the exam closes or after its results are released.
+// @ID setAttemptReviewPolicyV1
+// @Tags Exam
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param data body SetAttemptReviewPolicyData true "Data needed to set the attempt review policy of an exam"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=AttemptReviewPolicyResult}
+// @Router /api/v1/exam/setAttemptReviewPolicy [post]
+func SetAttemptReviewPolicyV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	} else if !userInfo.CanTryToEditExam() {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	data := &SetAttemptReviewPolicyData{}
+	if err := c.BodyParser(data); err != nil {
+		return apiHandlers.SendErrInvalidBodyData(c)
+	}
+
+	if data.ExamId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "exam_id")
+	} else if data.AttemptReviewPolicy == "" {
+		return apiHandlers.SendErrParameterRequired(c, "attempt_review_policy")
+	} else if !database.IsAttemptReviewPolicyValid(data.AttemptReviewPolicy) {
+		return apiHandlers.SendErrInvalidAttemptReviewPolicy(c, data.AttemptReviewPolicy)
+	}
+
+	examInfo := database.GetExamInfoOrNil(data.ExamId)
+	if examInfo == nil {
+		return apiHandlers.SendErrExamNotFound(c)
+	} else if !userInfo.CanEditExam(examInfo) {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	examInfo, err := database.SetExamAttemptReviewPolicy(&database.SetExamAttemptReviewPolicyData{
+		ExamId:              data.ExamId,
+		AttemptReviewPolicy: data.AttemptReviewPolicy,
+	})
+	if err != nil {
+		logging.UnexpectedError("SetAttemptReviewPolicy: Failed to set attempt review policy:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	return apiHandlers.SendResult(c, &AttemptReviewPolicyResult{
+		ExamId:              examInfo.ExamId,
+		AttemptReviewPolicy: examInfo.AttemptReviewPolicy,
+	})
+}
+
+// ReleaseExamResultsV1 godoc
+// @Summary Release the results of an exam
+// @Description Allows the user to release the results of an exam (or to withdraw them), so the students can review their attempts in the exams with the after_release review policy.
+// @ID releaseExamResultsV1
+// @Tags Exam
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param data body ReleaseExamResultsData true "Data needed to release the results of an exam"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=ReleaseExamResultsResult}
+// @Router /api/v1/exam/releaseResults [post]
+func ReleaseExamResultsV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	} else if !userInfo.CanTryToScoreExam() {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	data := &ReleaseExamResultsData{}
+	if err := c.BodyParser(data); err != nil {
+		return apiHandlers.SendErrInvalidBodyData(c)
+	}
+
+	if data.ExamId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "exam_id")
+	}
+
+	examInfo := database.GetExamInfoOrNil(data.ExamId)
+	if examInfo == nil {
+		return apiHandlers.SendErrExamNotFound(c)
+	} else if !userInfo.CanSetScoreForExam(examInfo) {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	examInfo, err := database.SetExamResultsReleased(&database.SetExamResultsReleasedData{
+		ExamId:     data.ExamId,
+		IsReleased: data.IsReleased,
+		ReleasedBy: userInfo.UserId,
+	})
+	if err != nil {
+		logging.UnexpectedError("ReleaseExamResults: Failed to release exam results:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	return apiHandlers.SendResult(c, &ReleaseExamResultsResult{
+		ExamId:            examInfo.ExamId,
+		IsReleased:        examInfo.AreResultsReleased(),
+		ResultsReleasedBy: ssg.Clone(examInfo.ResultsReleasedBy),
+		ResultsReleasedAt: ssg.Clone(examInfo.ResultsReleasedAt),
+	})
+}
+
+// GradeAnswerV1 godoc
+// @Summary Grade an answer given in an attempt
+// @Description Allows the user to give points to an answer given in an attempt of an exam, and to leave a comment on it for the student.
+// @ID gradeAnswerV1
+// @Tags Exam
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param data body GradeAnswerData true "Data needed to grade an answer"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=GradeAnswerResult}
+// @Router /api/v1/exam/gradeAnswer [post]
+func GradeAnswerV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	} else if !userInfo.CanTryToScoreExam() {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	data := &GradeAnswerData{}
+	if err := c.BodyParser(data); err != nil {
+		return apiHandlers.SendErrInvalidBodyData(c)
+	}
+
+	if data.ExamId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "exam_id")
+	} else if data.QuestionId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "question_id")
+	} else if data.UserId == "" {
+		return apiHandlers.SendErrParameterRequired(c, "user_id")
+	} else if data.AttemptNumber == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "attempt_number")
+	}
+
+	if data.GraderComment != nil {
+		comment := strings.TrimSpace(*data.GraderComment)
+		if comment == "" {
+			data.GraderComment = nil
+		} else if len(comment) > database.MaxGraderCommentLength {
+			return apiHandlers.SendErrTextTooLong(c)
+		} else {
+			data.GraderComment = &comment
+		}
+	}
+
+	examInfo := database.GetExamInfoOrNil(data.ExamId)
+	if examInfo == nil {
+		return apiHandlers.SendErrExamNotFound(c)
+	} else if !userInfo.CanSetScoreForExam(examInfo) {
+		return apiHandlers.SendErrPermissionDenied(c)
+	} else if examInfo.IsPractice {
+		return apiHandlers.SendErrPracticeExamNotGraded(c)
+	}
+
+	question, err := database.GetExamQuestion(data.ExamId, data.QuestionId)
+	if err == database.ErrExamQuestionNotFound ||
+		(err == nil && question.ExamId != data.ExamId) {
+		return apiHandlers.SendErrExamQuestionNotFound(c)
+	} else if err != nil {
+		logging.UnexpectedError("GradeAnswer: Failed to get exam question:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	if data.PointsEarned != nil &&
+		(*data.PointsEarned < 0 || *data.PointsEarned > question.Points) {
+		return apiHandlers.SendErrInvalidPoints(c)
+	}
+
+	answer, err := database.GradeGivenAnswer(&database.GradeGivenAnswerData{
+		ExamId:        data.ExamId,
+		QuestionId:    data.QuestionId,
+		AnsweredBy:    data.UserId,
+		AttemptNumber: data.AttemptNumber,
+		PointsEarned:  data.PointsEarned,
+		GraderComment: data.GraderComment,
+		GradedBy:      userInfo.UserId,
+	})
+	if err == database.ErrGivenAnswerNotFound {
+		return apiHandlers.SendErrGivenAnswerNotFound(c)
+	} else if err != nil {
+		logging.UnexpectedError("GradeAnswer: Failed to grade given answer:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	return apiHandlers.SendResult(c, &GradeAnswerResult{
+		ExamId:        answer.ExamId,
+		QuestionId:    answer.QuestionId,
+		UserId:        answer.AnsweredBy,
+		AttemptNumber: answer.AttemptNumber,
+		Points:        question.Points,
+		PointsEarned:  answer.GetPointsEarned(question),
+		IsGraded:      answer.IsGraded(),
+		GraderComment: ssg.Clone(answer.GraderComment),
+		GradedBy:      ssg.Clone(answer.GradedBy),
+		GradedAt:      ssg.Clone(answer.GradedAt),
+	})
+}
+
+// GetAttemptReviewV1 godoc
+// @Summary Review an attempt in an exam
+// @Description Allows the user to review an attempt in an exam: every question alongside the answer given to it, the correct answer, the points earned, the comment of the grader and the explanation. The students can only review their own attempts, and only when the attempt review policy of the exam allows it.
+// @ID getAttemptReviewV1
+// @Tags Exam
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param id query int true "Exam ID"
+// @Param attemptNumber query int false "Attempt number (the latest attempt by default)"
+// @Param targetId query string false "Target user id"
+// @Param renderHtml query bool false "Return the safe HTML variant of the content too"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=GetAttemptReviewResult}
+// @Router /api/v1/exam/attemptReview [get]
+func GetAttemptReviewV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	}
+
+	examId := c.QueryInt("id")
+	if examId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "id")
+	}
+
+	examInfo := database.GetExamInfoOrNil(examId)
+	if examInfo == nil {
+		return apiHandlers.SendErrExamNotFound(c)
+	}
+
+	// optional: provide another user's id to review their attempt
+	canGrade := userInfo.CanSetScoreForExam(examInfo)
+	targetUserId := c.Query("targetId")
+	if targetUserId == "" {
+		targetUserId = userInfo.UserId
+	} else if targetUserId != userInfo.UserId && !canGrade {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	attempt, err := findExamAttempt(targetUserId, examId, c.QueryInt("attemptNumber"))
+	if err == database.ErrExamAttemptNotFound {
+		return apiHandlers.SendErrAttemptNotFound(c)
+	} else if err != nil {
+		logging.UnexpectedError("GetAttemptReview: Failed to get exam attempt:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	accommodation := database.GetExamAccommodationOrNil(targetUserId, examId)
+	err = submitExpiredAttempt(examInfo, accommodation, attempt)
+	if err != nil {
+		logging.UnexpectedError("GetAttemptReview: Failed to submit expired attempt:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	if !canGrade && (!attempt.IsFinished() ||
+		!examInfo.CanReviewAttemptFor(accommodation)) {
+		return apiHandlers.SendErrAttemptReviewNotAvailable(c)
+	}
+
+	result, err := getAttemptReviewResult(examInfo, attempt, c.QueryBool("renderHtml"))
+	if err != nil {
+		logging.UnexpectedError("GetAttemptReview: Failed to get attempt review:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	return apiHandlers.SendResult(c, result)
+}
+
+// SetExamGradeCategoryV1 godoc
+// @Summary Set the grade category of an exam
+// @Description Allows the user to set the grade category of its course an exam is counted in (or to leave it out of the course grade). Practice exams are never counted.
+// @ID setExamGradeCategoryV1
+// @Tags Exam
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param data body SetExamGradeCategoryData true "Data needed to set the grade category of an exam"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=ExamGradeCategoryResult}
+// @Router /api/v1/exam/setGradeCategory [post]
+func SetExamGradeCategoryV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	} else if !userInfo.CanTryToEditExam() {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	data := &SetExamGradeCategoryData{}
+	if err := c.BodyParser(data); err != nil {
+		return apiHandlers.SendErrInvalidBodyData(c)
+	}
+
+	if data.ExamId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "exam_id")
+	}
+
+	examInfo := database.GetExamInfoOrNil(data.ExamId)
+	if examInfo == nil {
+		return apiHandlers.SendErrExamNotFound(c)
+	} else if !userInfo.CanEditExam(examInfo) {
+		return apiHandlers.SendErrPermissionDenied(c)
+	} else if examInfo.IsPractice && data.CategoryId != nil {
+		return apiHandlers.SendErrPracticeExamNotGraded(c)
+	}
+
+	if data.CategoryId != nil {
+		category, err := database.GetCourseGradeCategory(*data.CategoryId)
+		if err == database.ErrGradeCategoryNotFound ||
+			(err == nil && category.CourseId != examInfo.CourseId) {
+			return apiHandlers.SendErrGradeCategoryNotFound(c)
+		} else if err != nil {
+			logging.UnexpectedError("SetExamGradeCategory: Failed to get grade category:", err)
+			return apiHandlers.SendErrInternalServerError(c)
+		}
+	}
+
+	examInfo, err := database.SetExamGradeCategory(&database.SetExamGradeCategoryData{
+		ExamId:     data.ExamId,
+		CategoryId: data.CategoryId,
+	})
+	if err != nil {
+		logging.UnexpectedError("SetExamGradeCategory: Failed to set exam grade category:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	return apiHandlers.SendResult(c, &ExamGradeCategoryResult{
+		ExamId:          examInfo.ExamId,
+		CourseId:        examInfo.CourseId,
+		GradeCategoryId: ssg.Clone(examInfo.GradeCategoryId),
+	})
+}
+
+// ExportExamParticipantsV1 godoc
+// @Summary Export the participants of an exam and their scores
+// @Description Allows the creator, a grader of an exam or an admin to download its participants alongside their scores as a csv or xlsx file. The file is streamed, so exams of any size can be exported.
+// @ID exportExamParticipantsV1
+// @Tags Exam
+// @Produce octet-stream
+// @Param Authorization header string true "Authorization token"
+// @Param id query int true "Exam ID"
+// @Param format query string false "Format of the file (csv or xlsx); defaults to csv"
+// @Param columns query string false "Comma separated keys of the columns to export, in order (user_id, full_name, email, joined_at, attempts, last_finished_at, final_score, score_percentage, scored_by); defaults to all of them"
+// @Success 200 {file} file
+// @Router /api/v1/exam/exportParticipants [get]
+func ExportExamParticipantsV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	} else if !userInfo.CanTryToScoreExam() {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	examId := c.QueryInt("id")
+	if examId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "id")
+	}
+
+	format := strings.ToLower(c.Query("format", exportUtils.FormatCsv))
+	if !exportUtils.IsFormatValid(format) {
+		return apiHandlers.SendErrInvalidExportFormat(c, format)
+	}
+
+	columns, unknownColumn := exportUtils.SelectColumns(participantsExportColumns, c.Query("columns"))
+	if unknownColumn != "" {
+		return apiHandlers.SendErrUnknownExportColumn(c, unknownColumn)
+	} else if len(columns) == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "columns")
+	}
+
+	examInfo := database.GetExamInfoOrNil(examId)
+	if examInfo == nil {
+		return apiHandlers.SendErrExamNotFound(c)
+	} else if !userInfo.CanExportExamResults(examInfo) {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	fileName := exportUtils.GetFileName("exam_"+strconv.Itoa(examInfo.ExamId)+"_participants", format)
+	c.Set(fiber.HeaderContentType, exportUtils.GetContentType(format))
+	c.Set(fiber.HeaderContentDisposition, `attachment; filename="`+fileName+`"`)
+	c.Set(fiber.HeaderXContentTypeOptions, "nosniff")
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		err := writeParticipantsExport(w, format, examInfo, columns)
+		if err != nil {
+			logging.UnexpectedError("ExportExamParticipants: Failed to write the export:", err)
+		}
+	})
+
+	return nil
+}
+
+// GetResultReportV1 godoc
+// @Summary Download the result report of a participant of an exam
+// @Description Allows the user to download a printable PDF report of the result of a participant in an exam: the exam info, their answers against the correct answers, the points of each question, their total and grade, the comments of the teachers and the trend of their scores across the course. The students can only get their own reports, and only when the attempt review policy of the exam allows it. The characters the report cannot show (e.g. of non-Latin texts) are replaced with question marks, and a note says so.
+// @ID getResultReportV1
+// @Tags Exam
+// @Produce application/pdf
+// @Param Authorization header string true "Authorization token"
+// @Param id query int true "Exam ID"
+// @Param attemptNumber query int false "Attempt number (the latest attempt by default)"
+// @Param targetId query string false "Target user id"
+// @Success 200 {file} file
+// @Router /api/v1/exam/resultReport [get]
+func GetResultReportV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	}
+
+	examId := c.QueryInt("id")
+	if examId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "id")
+	}
+
+	examInfo := database.GetExamInfoOrNil(examId)
+	if examInfo == nil {
+		return apiHandlers.SendErrExamNotFound(c)
+	}
+
+	// optional: provide another user's id to get their report
+	canGrade := userInfo.CanSetScoreForExam(examInfo)
+	targetUserId := c.Query("targetId")
+	if targetUserId == "" {
+		targetUserId = userInfo.UserId
+	} else if targetUserId != userInfo.UserId && !canGrade {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	givenExam, err := database.GetGivenExam(targetUserId, examId)
+	if err == database.ErrGivenExamNotFound {
+		return apiHandlers.SendErrNotParticipatedInExam(c)
+	} else if err != nil {
+		logging.UnexpectedError("GetResultReport: Failed to get given exam:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	student, err := database.GetUserByUserId(targetUserId)
+	if err == database.ErrUserNotFound {
+		return apiHandlers.SendErrInvalidUserID(c)
+	} else if err != nil {
+		logging.UnexpectedError("GetResultReport: Failed to get user info:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	attempt, err := findExamAttempt(targetUserId, examId, c.QueryInt("attemptNumber"))
+	if err == database.ErrExamAttemptNotFound {
+		return apiHandlers.SendErrAttemptNotFound(c)
+	} else if err != nil {
+		logging.UnexpectedError("GetResultReport: Failed to get exam attempt:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	accommodation := database.GetExamAccommodationOrNil(targetUserId, examId)
+	err = submitExpiredAttempt(examInfo, accommodation, attempt)
+	if err != nil {
+		logging.UnexpectedError("GetResultReport: Failed to submit expired attempt:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	if !canGrade && (!attempt.IsFinished() ||
+		!examInfo.CanReviewAttemptFor(accommodation)) {
+		return apiHandlers.SendErrAttemptReviewNotAvailable(c)
+	}
+
+	review, err := getAttemptReviewResult(examInfo, attempt, false)
+	if err != nil {
+		logging.UnexpectedError("GetResultReport: Failed to get attempt review:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	reportData := &resultReportData{
+		examInfo:  examInfo,
+		student:   student,
+		givenExam: givenExam,
+		review:    review,
+	}
+	if givenExam.FinalScore != nil {
+		reportData.scorePercentage, err = database.GetScorePercentage(*givenExam.FinalScore)
+		if err != nil {
+			logging.UnexpectedError("GetResultReport: Failed to get score percentage:", err)
+			return apiHandlers.SendErrInternalServerError(c)
+		}
+	}
+
+	courseInfo, err := database.GetCourseInfo(examInfo.CourseId)
+	if err != nil && err != database.ErrCourseNotFound {
+		logging.UnexpectedError("GetResultReport: Failed to get course info:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	} else if courseInfo != nil {
+		reportData.courseInfo = courseInfo
+		reportData.courseExams, err = database.GetCourseGradedExams(courseInfo.CourseId)
+		if err != nil {
+			logging.UnexpectedError("GetResultReport: Failed to get graded exams of the course:", err)
+			return apiHandlers.SendErrInternalServerError(c)
+		}
+
+		reportData.courseScores, err = database.GetCourseGradebookScores(courseInfo.CourseId, targetUserId)
+		if err != nil {
+			logging.UnexpectedError("GetResultReport: Failed to get course scores:", err)
+			return apiHandlers.SendErrInternalServerError(c)
+		}
+	}
+
+	fileName := exportUtils.GetFileName(
+		"result_"+strconv.Itoa(examInfo.ExamId)+"_"+targetUserId, "pdf",
+	)
+	c.Set(fiber.HeaderContentType, "application/pdf")
+	c.Set(fiber.HeaderContentDisposition, `attachment; filename="`+fileName+`"`)
+	c.Set(fiber.HeaderXContentTypeOptions, "nosniff")
+	return c.Send(buildResultReport(reportData).Bytes())
+}
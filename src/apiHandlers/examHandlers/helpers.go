@@ -1 +1,1571 @@
 package examHandlers
+
+import (
+	"ExamSphere/src/apiHandlers"
+	"ExamSphere/src/core/appConfig"
+	"ExamSphere/src/core/utils/contentUtils"
+	"ExamSphere/src/core/utils/emailUtils"
+	"ExamSphere/src/core/utils/exportUtils"
+	"ExamSphere/src/core/utils/gradebookUtils"
+	"ExamSphere/src/core/utils/hashing"
+	"ExamSphere/src/core/utils/logging"
+	"ExamSphere/src/core/utils/paymentUtils"
+	"ExamSphere/src/core/utils/pdfUtils"
+	"ExamSphere/src/core/utils/similarityUtils"
+	"ExamSphere/src/database"
+	"bufio"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ALiwoto/ssg/ssg"
+	"github.com/gofiber/fiber/v2"
+	fUtils "github.com/gofiber/fiber/v2/utils"
+	"github.com/jackc/pgx/v5"
+)
+
+// getClientDeviceId returns the device id sent by the client.
+func getClientDeviceId(c *fiber.Ctx) string {
+	return fUtils.CopyString(c.Get(DeviceIdHeader))
+}
+
+// isDeviceIdValid returns true if the device id sent by the client is
+// acceptable.
+func isDeviceIdValid(deviceId string) bool {
+	return deviceId != "" && len(deviceId) <= MaxDeviceIdLength
+}
+
+// checkAttemptClient makes sure the user has an ongoing attempt in the exam,
+// and that the request is coming from the client that the attempt is bound to.
+// If the user has no attempts yet, their first attempt is started implicitly
+// and if the attempt is not bound to any client yet, it will get bound to the
+// current client; unless the exam is protected by an access code, in which case
+// the attempt has to be started explicitly (by providing the code).
+// Requests coming from other clients are flagged, and they are also rejected
+// if the config says so.
+func checkAttemptClient(c *fiber.Ctx, userId string, examInfo *database.ExamInfo, deviceId string) (*database.ExamAttempt, attemptClientStatus, error) {
+	clientData := &database.ExamAttemptClientData{
+		ExamId:    examInfo.ExamId,
+		UserId:    userId,
+		IPAddress: fUtils.CopyString(c.IP()),
+		DeviceId:  deviceId,
+	}
+
+	accommodation := database.GetExamAccommodationOrNil(userId, examInfo.ExamId)
+	attempt, err := database.GetLatestExamAttempt(userId, examInfo.ExamId)
+	if err == database.ErrExamAttemptNotFound {
+		if examInfo.RequiresAccessCode() {
+			return nil, attemptClientNotStarted, nil
+		} else if !examInfo.CanStartAttemptFor(accommodation) {
+			return nil, attemptClientLateStart, nil
+		}
+		attempt, err = database.StartExamAttempt(userId, examInfo.ExamId)
+	}
+
+	if err == nil {
+		err = submitExpiredAttempt(examInfo, accommodation, attempt)
+	}
+
+	if err != nil {
+		return nil, attemptClientAllowed, err
+	} else if attempt.IsFinished() {
+		return attempt, attemptClientFinished, nil
+	}
+
+	var binding *database.ExamAttemptBinding
+	if examInfo.RequiresAccessCode() {
+		binding, err = database.GetExamAttemptBinding(userId, examInfo.ExamId)
+		if err == database.ErrExamAttemptBindingNotFound {
+			return attempt, attemptClientNotStarted, nil
+		}
+	} else {
+		binding, err = database.BindExamAttempt(clientData)
+	}
+
+	if err != nil {
+		return attempt, attemptClientAllowed, err
+	}
+
+	if binding.IsSameClient(clientData.DeviceId) {
+		if binding.HasIPChanged(clientData.IPAddress) {
+			// e.g. a mobile client which has switched networks
+			err = database.RecordExamAttemptIPChange(clientData)
+		}
+		return attempt, attemptClientAllowed, err
+	}
+
+	err = database.FlagExamAttemptMismatch(clientData)
+	if err != nil {
+		return attempt, attemptClientAllowed, err
+	}
+
+	if appConfig.ShouldRejectForeignExamClients() {
+		return attempt, attemptClientForeign, nil
+	}
+
+	return attempt, attemptClientAllowed, nil
+}
+
+// submitExpiredAttempt finishes the specified attempt (which can be nil) if its
+// deadline and the grace period of the exam have passed, as if the user has
+// submitted it right at that moment.
+func submitExpiredAttempt(
+	examInfo *database.ExamInfo,
+	accommodation *database.ExamAccommodation,
+	attempt *database.ExamAttempt,
+) error {
+	if attempt == nil || attempt.IsFinished() ||
+		!examInfo.HasDeadlinePassedFor(accommodation, attempt) {
+		return nil
+	}
+
+	return database.FinishExamAttemptAt(
+		attempt,
+		examInfo.GetDeadlineFor(accommodation, attempt).Add(examInfo.GetGracePeriod()),
+	)
+}
+
+// sendAttemptClientError sends the error related to the specified status
+// of the attempt client.
+func sendAttemptClientError(c *fiber.Ctx, status attemptClientStatus) error {
+	switch status {
+	case attemptClientNotStarted:
+		return apiHandlers.SendErrAttemptNotStarted(c)
+	case attemptClientFinished:
+		return apiHandlers.SendErrAttemptFinished(c)
+	case attemptClientLateStart:
+		return apiHandlers.SendErrLateStartNotAllowed(c)
+	default:
+		return apiHandlers.SendErrForeignAttemptClient(c)
+	}
+}
+
+// isAccessCodeRateLimited returns true if the user has entered too many wrong
+// access codes for the specified exam recently.
+func isAccessCodeRateLimited(userId string, examId int) bool {
+	entry := accessCodeGuessesMap.Get(userId + "_" + ssg.ToBase10(examId))
+	if entry == nil {
+		return false
+	}
+
+	entry.mut.Lock()
+	defer entry.mut.Unlock()
+
+	if time.Since(entry.LastTryAt) > appConfig.GetAccessCodeLockDuration() {
+		entry.TryCount = 0
+		return false
+	}
+
+	return entry.TryCount >= appConfig.GetMaxAccessCodeAttempts()
+}
+
+// verifyAccessCode checks the specified access code against the exam, and
+// keeps track of the wrong guesses of the user.
+func verifyAccessCode(userId string, examInfo *database.ExamInfo, code string) bool {
+	entryKey := userId + "_" + ssg.ToBase10(examInfo.ExamId)
+	if examInfo.IsAccessCodeValid(strings.TrimSpace(code)) {
+		accessCodeGuessesMap.Delete(entryKey)
+		return true
+	}
+
+	entry := accessCodeGuessesMap.Get(entryKey)
+	if entry == nil {
+		accessCodeGuessesMap.Add(entryKey, &accessCodeGuessEntry{
+			LastTryAt: time.Now(),
+			TryCount:  1,
+			mut:       &sync.Mutex{},
+		})
+		return false
+	}
+
+	entry.mut.Lock()
+	entry.TryCount++
+	entry.LastTryAt = time.Now()
+	entry.mut.Unlock()
+
+	return false
+}
+
+// toExamAccessCodeResult converts the access code settings of the exam to
+// its api result.
+func toExamAccessCodeResult(examInfo *database.ExamInfo) *ExamAccessCodeResult {
+	return &ExamAccessCodeResult{
+		ExamId:           examInfo.ExamId,
+		AccessCodeType:   examInfo.AccessCodeType,
+		CurrentCode:      examInfo.GetCurrentAccessCode(),
+		RotationInterval: examInfo.AccessCodeInterval,
+		ExpiresIn:        examInfo.AccessCodeExpiresIn(),
+	}
+}
+
+// newExamPrerequisiteInfo fills the api info of a prerequisite, alongside
+// the names of the exam/topic it refers to and a human-readable description.
+func newExamPrerequisiteInfo(info *ExamPrerequisiteInfo) *ExamPrerequisiteInfo {
+	switch info.PrerequisiteType {
+	case database.PrerequisiteTypeExam:
+		requiredExam := database.GetExamInfoOrNil(*info.RequiredExamId)
+		examName := "#" + ssg.ToBase10(*info.RequiredExamId)
+		if requiredExam != nil {
+			info.RequiredExamTitle = ssg.Clone(&requiredExam.ExamTitle)
+			examName = strconv.Quote(requiredExam.ExamTitle)
+		}
+
+		minScore := float64(0)
+		if info.MinScore != nil {
+			minScore = *info.MinScore
+		}
+		info.Description = fmt.Sprintf("Score at least %g%% in exam %s", minScore, examName)
+	case database.PrerequisiteTypeTopicLevel:
+		topicInfo, _ := database.GetTopicInfo(*info.TopicId)
+		topicName := "#" + ssg.ToBase10(*info.TopicId)
+		if topicInfo != nil {
+			info.TopicName = ssg.Clone(&topicInfo.TopicName)
+			topicName = strconv.Quote(topicInfo.TopicName)
+		}
+
+		info.Description = fmt.Sprintf("Reach level %d in topic %s", *info.MinLevel, topicName)
+	}
+
+	return info
+}
+
+// toExamPrerequisitesInfo converts the prerequisites of an exam to their
+// api info.
+func toExamPrerequisitesInfo(prerequisites []*database.ExamPrerequisite) []*ExamPrerequisiteInfo {
+	var result []*ExamPrerequisiteInfo
+	for _, current := range prerequisites {
+		result = append(result, newExamPrerequisiteInfo(&ExamPrerequisiteInfo{
+			PrerequisiteId:   current.PrerequisiteId,
+			PrerequisiteType: current.PrerequisiteType,
+			RequiredExamId:   current.RequiredExamId,
+			MinScore:         current.MinScore,
+			TopicId:          current.TopicId,
+			MinLevel:         current.MinLevel,
+		}))
+	}
+
+	return result
+}
+
+// getUnmetPrerequisitesInfo returns the api info of the prerequisites of the
+// exam that the user has not met yet.
+func getUnmetPrerequisitesInfo(userId string, examId int) []*ExamPrerequisiteInfo {
+	var result []*ExamPrerequisiteInfo
+	for _, current := range database.GetUnmetExamPrerequisitesOrNil(userId, examId) {
+		result = append(result, newExamPrerequisiteInfo(&ExamPrerequisiteInfo{
+			PrerequisiteId:   current.PrerequisiteId,
+			PrerequisiteType: current.PrerequisiteType,
+			RequiredExamId:   current.RequiredExamId,
+			MinScore:         current.MinScore,
+			TopicId:          current.TopicId,
+			MinLevel:         current.MinLevel,
+			CurrentValue:     current.CurrentValue,
+		}))
+	}
+
+	return result
+}
+
+// toExamAttemptInfo converts an attempt to its api info.
+func toExamAttemptInfo(attempt *database.ExamAttempt) *ExamAttemptInfo {
+	return &ExamAttemptInfo{
+		AttemptNumber: attempt.AttemptNumber,
+		StartedAt:     attempt.StartedAt,
+		FinishedAt:    ssg.Clone(attempt.FinishedAt),
+		FinalScore:    ssg.Clone(attempt.FinalScore),
+		ScoredBy:      ssg.Clone(attempt.ScoredBy),
+
+		AbilityEstimate: ssg.Clone(attempt.AbilityEstimate),
+		AbilityStdError: ssg.Clone(attempt.AbilityStdError),
+	}
+}
+
+func toExamAccommodationInfo(
+	examInfo *database.ExamInfo,
+	accommodation *database.ExamAccommodation,
+) *ExamAccommodationInfo {
+	return &ExamAccommodationInfo{
+		ExamId:         accommodation.ExamId,
+		UserId:         accommodation.UserId,
+		ExtraMinutes:   accommodation.ExtraMinutes,
+		TimeMultiplier: accommodation.TimeMultiplier,
+		CustomStart:    ssg.Clone(accommodation.CustomStart),
+		CustomEnd:      ssg.Clone(accommodation.CustomEnd),
+		Note:           ssg.Clone(accommodation.Note),
+		GrantedBy:      ssg.Clone(accommodation.GrantedBy),
+		CreatedAt:      accommodation.CreatedAt,
+		StartsAt:       examInfo.GetStartTimeFor(accommodation),
+		EndsAt:         examInfo.GetEndTimeFor(accommodation),
+	}
+}
+
+func toExamSeriesInfo(series *database.ExamSeries) *ExamSeriesInfo {
+	return &ExamSeriesInfo{
+		SeriesId:        series.SeriesId,
+		TemplateExamId:  series.TemplateExamId,
+		SeriesTitle:     series.SeriesTitle,
+		RecurrenceRule:  series.RecurrenceRule,
+		StartsAt:        series.StartsAt,
+		GenerateAhead:   series.GenerateAhead,
+		IsActive:        series.IsActive,
+		LastGeneratedAt: ssg.Clone(series.LastGeneratedAt),
+		CreatedBy:       series.CreatedBy,
+		CreatedAt:       series.CreatedAt,
+	}
+}
+
+func toExamSeriesOccurrenceInfo(occurrence *database.ExamSeriesOccurrence) *ExamSeriesOccurrenceInfo {
+	return &ExamSeriesOccurrenceInfo{
+		OccurrenceAt: occurrence.OccurrenceAt,
+		ExamId:       ssg.Clone(occurrence.ExamId),
+		IsSkipped:    occurrence.IsSkipped,
+		ExamTitle:    ssg.Clone(occurrence.ExamTitle),
+		ExamDate:     ssg.Clone(occurrence.ExamDate),
+		Duration:     ssg.Clone(occurrence.Duration),
+	}
+}
+
+// getExamSeriesForEdit gets the series and makes sure the user is allowed to
+// edit it (by being able to edit its template exam).
+// It sends the proper error to the client if anything goes wrong, in which
+// case the returned series is nil.
+func getExamSeriesForEdit(c *fiber.Ctx, userInfo *database.UserInfo, seriesId int) (*database.ExamSeries, error) {
+	series, err := database.GetExamSeries(seriesId)
+	if err == database.ErrExamSeriesNotFound {
+		return nil, apiHandlers.SendErrExamSeriesNotFound(c)
+	} else if err != nil {
+		logging.UnexpectedError("getExamSeriesForEdit: Failed to get exam series:", err)
+		return nil, apiHandlers.SendErrInternalServerError(c)
+	}
+
+	templateInfo := database.GetExamInfoOrNil(series.TemplateExamId)
+	if templateInfo == nil {
+		return nil, apiHandlers.SendErrExamNotFound(c)
+	} else if !userInfo.CanEditExam(templateInfo) {
+		return nil, apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	return series, nil
+}
+
+// isPriceValid returns true if the price can be charged from the wallets,
+// e.g. "149.99T".
+func isPriceValid(price string) bool {
+	_, currency, err := paymentUtils.ParsePrice(price)
+	return err == nil && currency == paymentUtils.DefaultCurrency
+}
+
+func toExamInvitationInfo(invitation *database.ExamInvitation) *ExamInvitationInfo {
+	return &ExamInvitationInfo{
+		InvitationId: invitation.InvitationId,
+		ExamId:       invitation.ExamId,
+		Email:        invitation.Email,
+		UserId:       ssg.Clone(invitation.UserId),
+		Status:       invitation.GetStatus(),
+		ExpiresAt:    invitation.ExpiresAt,
+		InvitedBy:    invitation.InvitedBy,
+		CreatedAt:    invitation.CreatedAt,
+		AcceptedAt:   ssg.Clone(invitation.AcceptedAt),
+	}
+}
+
+// getInvitationJoinLink returns the one-click join link of the invitation,
+// which is sent to the invitee.
+func getInvitationJoinLink(invitation *database.ExamInvitation) string {
+	return fmt.Sprintf(
+		"%s?invitationId=%d&token=%s",
+		appConfig.GetExamInviteBaseURL(),
+		invitation.InvitationId,
+		url.QueryEscape(invitation.GetJoinToken()),
+	)
+}
+
+// sendExamInvitationEmails sends the invitation emails in the background.
+func sendExamInvitationEmails(
+	inviter *database.UserInfo,
+	examInfo *database.ExamInfo,
+	invitations []*database.ExamInvitation,
+	inviteeNames map[int]string,
+) {
+	defer func() {
+		if r := recover(); r != nil {
+			logging.Error("sendExamInvitationEmails: failed to send invitation emails: ", r)
+		}
+	}()
+
+	for _, invitation := range invitations {
+		err := emailUtils.SendExamInvitationEmail(&emailUtils.ExamInvitationEmailData{
+			InviteeName: inviteeNames[invitation.InvitationId],
+			InviterName: inviter.FullName,
+			ExamTitle:   examInfo.ExamTitle,
+			ExamDate:    examInfo.ExamDate.Format(database.ExamDateLayout),
+			ExpiresAt:   invitation.ExpiresAt.Format(database.ExamDateLayout),
+			JoinLink:    getInvitationJoinLink(invitation),
+			EmailTo:     invitation.Email,
+		})
+		if err != nil {
+			logging.Error("sendExamInvitationEmails: failed to send invitation email to "+
+				invitation.Email+": ", err)
+		}
+	}
+}
+
+func toExamWaitlistEntryInfo(entry *database.ExamWaitlistEntry) *ExamWaitlistEntryInfo {
+	return &ExamWaitlistEntryInfo{
+		UserId:    entry.UserId,
+		Position:  entry.Position,
+		AddedBy:   ssg.Clone(entry.AddedBy),
+		CouponId:  ssg.Clone(entry.CouponId),
+		CreatedAt: entry.CreatedAt,
+	}
+}
+
+func toExamSectionInfo(section *database.ExamSection, progress *database.ExamSectionProgress) *ExamSectionInfo {
+	info := &ExamSectionInfo{
+		SectionId:     section.SectionId,
+		ExamId:        section.ExamId,
+		SectionTitle:  section.SectionTitle,
+		SectionOrder:  section.SectionOrder,
+		Duration:      ssg.Clone(section.Duration),
+		IsForwardOnly: section.IsForwardOnly,
+		CreatedAt:     section.CreatedAt,
+	}
+
+	if progress != nil {
+		info.Progress = &ExamSectionProgressInfo{
+			Status:   progress.Status,
+			Deadline: ssg.Clone(progress.Deadline),
+		}
+		if progress.Progress != nil {
+			info.Progress.StartedAt = ssg.Clone(&progress.Progress.StartedAt)
+			info.Progress.FinishedAt = ssg.Clone(progress.Progress.FinishedAt)
+		}
+	}
+
+	return info
+}
+
+// toExamSectionsInfo converts the sections of the exam (with the progress of
+// the user in them) to their api representation.
+func toExamSectionsInfo(allProgress []*database.ExamSectionProgress) []*ExamSectionInfo {
+	sectionsInfo := make([]*ExamSectionInfo, 0, len(allProgress))
+	for _, current := range allProgress {
+		sectionsInfo = append(sectionsInfo, toExamSectionInfo(current.Section, current))
+	}
+	return sectionsInfo
+}
+
+// findSectionProgress returns the progress of the specified section, or nil
+// if the section is not among the specified ones.
+func findSectionProgress(allProgress []*database.ExamSectionProgress, sectionId int) *database.ExamSectionProgress {
+	for _, current := range allProgress {
+		if current.Section.SectionId == sectionId {
+			return current
+		}
+	}
+	return nil
+}
+
+// checkQuestionSection returns false if the specified section (which can be
+// nil for the questions without a section) does not belong to the exam.
+func checkQuestionSection(examInfo *database.ExamInfo, sectionId *int) (bool, error) {
+	if sectionId == nil {
+		return true, nil
+	}
+
+	section, err := database.GetExamSection(*sectionId)
+	if err == database.ErrExamSectionNotFound {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	return section.ExamId == examInfo.ExamId, nil
+}
+
+// promoteExamWaitlist gives the free seats of the exam to its waitlist and
+// notifies the users who got a seat; the errors are only logged, since the
+// operation which freed the seats has already been done.
+func promoteExamWaitlist(examInfo *database.ExamInfo) {
+	promoted, err := database.PromoteExamWaitlist(examInfo.ExamId)
+	if err != nil {
+		logging.UnexpectedError("promoteExamWaitlist: failed to promote the waitlist of exam",
+			examInfo.ExamId, ":", err)
+	}
+
+	if len(promoted) == 0 || !emailUtils.IsEmailClientLoaded() {
+		return
+	}
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				logging.Error("promoteExamWaitlist: failed to send waitlist promotion emails: ", r)
+			}
+		}()
+
+		for _, givenExam := range promoted {
+			targetUser, err := database.GetUserByUserId(givenExam.UserId)
+			if err != nil || targetUser == nil {
+				continue
+			}
+
+			err = emailUtils.SendWaitlistPromotionEmail(&emailUtils.WaitlistPromotionEmailData{
+				UserFullName: targetUser.FullName,
+				ExamTitle:    examInfo.ExamTitle,
+				ExamDate:     examInfo.ExamDate.Format(database.ExamDateLayout),
+				Price:        givenExam.Price,
+				EmailTo:      targetUser.Email,
+			})
+			if err != nil {
+				logging.Error("promoteExamWaitlist: failed to send waitlist promotion email to "+
+					targetUser.Email+": ", err)
+			}
+		}
+	}()
+}
+
+func toWaitlistedParticipateResult(
+	examInfo *database.ExamInfo,
+	entry *database.ExamWaitlistEntry,
+) *ParticipateExamResult {
+	return &ParticipateExamResult{
+		ExamId:           entry.ExamId,
+		UserId:           entry.UserId,
+		OriginalPrice:    examInfo.Price,
+		AddedBy:          ssg.Clone(entry.AddedBy),
+		CreatedAt:        entry.CreatedAt,
+		StartsIn:         examInfo.ExamStartsIn(),
+		FinishesIn:       examInfo.ExamFinishesIn(),
+		IsWaitlisted:     true,
+		WaitlistPosition: entry.Position,
+	}
+}
+
+// checkQuestionAnswerable checks whether the user is currently allowed to
+// answer the question in their attempt: the question has to be the one
+// served to them in an adaptive exam, and its section (if any) has to be
+// open for them.
+func checkQuestionAnswerable(
+	examInfo *database.ExamInfo,
+	accommodation *database.ExamAccommodation,
+	attempt *database.ExamAttempt,
+	question *database.ExamQuestion,
+) (questionAnswerStatus, error) {
+	if examInfo.IsAdaptive {
+		currentItem, err := database.GetCurrentExamAttemptItem(attempt)
+		if err != nil {
+			return questionNotServed, err
+		} else if currentItem == nil || currentItem.QuestionId != question.QuestionId {
+			return questionNotServed, nil
+		}
+	} else if question.SectionId != nil {
+		allProgress, err := database.GetExamSectionsProgress(examInfo, accommodation, attempt)
+		if err != nil {
+			return questionSectionClosed, err
+		}
+
+		sectionProgress := findSectionProgress(allProgress, *question.SectionId)
+		if sectionProgress == nil || !sectionProgress.IsAnswerable() {
+			return questionSectionClosed, nil
+		}
+	}
+
+	return questionAnswerable, nil
+}
+
+func sendQuestionAnswerError(c *fiber.Ctx, status questionAnswerStatus) error {
+	if status == questionNotServed {
+		return apiHandlers.SendErrQuestionNotServed(c)
+	}
+	return apiHandlers.SendErrExamSectionClosed(c)
+}
+
+// canSeeQuestionAttachments returns true if the user is allowed to see the
+// files attached to the question (and to its options); participants can
+// only see them once the question has been revealed to them.
+func canSeeQuestionAttachments(
+	userInfo *database.UserInfo,
+	examInfo *database.ExamInfo,
+	question *database.ExamQuestion,
+) (bool, error) {
+	if userInfo.CanPeekExamQuestions(examInfo) {
+		return true, nil
+	}
+
+	accommodation := database.GetExamAccommodationOrNil(userInfo.UserId, examInfo.ExamId)
+	if !database.HasParticipatedInExam(userInfo.UserId, examInfo.ExamId) ||
+		!examInfo.HasExamStartedFor(accommodation) {
+		return false, nil
+	} else if examInfo.HasExamFinishedFor(accommodation) {
+		return true, nil
+	}
+
+	attempt := database.GetLatestExamAttemptOrNil(userInfo.UserId, examInfo.ExamId)
+	if attempt == nil {
+		return false, nil
+	}
+
+	if examInfo.IsAdaptive {
+		items, err := database.GetExamAttemptItems(attempt)
+		if err != nil {
+			return false, err
+		}
+
+		for _, item := range items {
+			if item.QuestionId == question.QuestionId {
+				return true, nil
+			}
+		}
+		return false, nil
+	} else if question.SectionId != nil {
+		allProgress, err := database.GetExamSectionsProgress(examInfo, accommodation, attempt)
+		if err != nil {
+			return false, err
+		}
+
+		sectionProgress := findSectionProgress(allProgress, *question.SectionId)
+		return sectionProgress != nil && sectionProgress.IsAnswerable(), nil
+	}
+
+	return true, nil
+}
+
+// canSeeAttachment returns true if the user is allowed to download the
+// attachment; the answer attachments can only be downloaded by the user
+// who uploaded them and by the users who can score the exam.
+func canSeeAttachment(
+	userInfo *database.UserInfo,
+	examInfo *database.ExamInfo,
+	question *database.ExamQuestion,
+	attachment *database.Attachment,
+) (bool, error) {
+	if attachment.IsAnswer() {
+		return (attachment.AnsweredBy != nil && *attachment.AnsweredBy == userInfo.UserId) ||
+			userInfo.CanSetScoreForExam(examInfo), nil
+	}
+
+	return canSeeQuestionAttachments(userInfo, examInfo, question)
+}
+
+// toExamAttachmentInfo converts the attachment to the form sent to the
+// clients; where the file is stored is never exposed.
+func toExamAttachmentInfo(attachment *database.Attachment) *ExamAttachmentInfo {
+	return &ExamAttachmentInfo{
+		AttachmentId:   attachment.AttachmentId,
+		AttachmentType: attachment.AttachmentType,
+		ExamId:         attachment.ExamId,
+		QuestionId:     attachment.QuestionId,
+		OptionNumber:   ssg.Clone(attachment.OptionNumber),
+		AnsweredBy:     ssg.Clone(attachment.AnsweredBy),
+		AttemptNumber:  ssg.Clone(attachment.AttemptNumber),
+		FileName:       attachment.FileName,
+		ContentType:    attachment.ContentType,
+		FileSize:       attachment.FileSize,
+		CreatedAt:      attachment.CreatedAt,
+	}
+}
+
+// getAttachmentStorageKey returns a new key for storing a file of the
+// question in the storage backend.
+func getAttachmentStorageKey(examId, questionId int) string {
+	return "exams/" + strconv.Itoa(examId) + "/" + strconv.Itoa(questionId) +
+		"/" + hashing.RandomCommonString(attachmentKeyRandomLength)
+}
+
+// sanitizeAttachmentFileName strips the directories and the characters
+// which are not safe to be put in a header from the name of an uploaded
+// file.
+func sanitizeAttachmentFileName(fileName string) string {
+	fileName = filepath.Base(strings.ReplaceAll(fileName, "\\", "/"))
+	fileName = strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f || r == '"' || r == '/' {
+			return -1
+		}
+		return r
+	}, fileName)
+
+	if fileName == "" || fileName == "." {
+		fileName = "file"
+	}
+
+	runes := []rune(fileName)
+	if len(runes) > database.MaxAttachmentFileNameLength {
+		fileName = string(runes[:database.MaxAttachmentFileNameLength])
+	}
+
+	return fileName
+}
+
+// sanitizeContentFields sanitises the (non-nil) fields in place; it returns
+// the name of the first invalid field alongside the reason.
+func sanitizeContentFields(format string, fields []contentField) (string, error) {
+	for _, field := range fields {
+		if field.value == nil {
+			continue
+		}
+
+		sanitized, err := contentUtils.SanitizeContent(format, *field.value)
+		if err != nil {
+			return field.name, err
+		}
+		*field.value = sanitized
+	}
+
+	return "", nil
+}
+
+// renderQuestionContent renders the content of the question as safe HTML;
+// the title and the options are rendered without any blocks.
+func renderQuestionContent(question *database.ExamQuestion) *RenderedQuestionContent {
+	format := question.ContentFormat
+	renderInline := func(value *string) *string {
+		if value == nil {
+			return nil
+		}
+		rendered := contentUtils.RenderInlineHTML(format, *value)
+		return &rendered
+	}
+
+	var description *string
+	if question.Description != nil {
+		rendered := contentUtils.RenderHTML(format, *question.Description)
+		description = &rendered
+	}
+
+	return &RenderedQuestionContent{
+		QuestionTitle: contentUtils.RenderInlineHTML(format, question.QuestionTitle),
+		Description:   description,
+		Option1:       renderInline(question.Option1),
+		Option2:       renderInline(question.Option2),
+		Option3:       renderInline(question.Option3),
+		Option4:       renderInline(question.Option4),
+	}
+}
+
+// renderQuestionExplanation renders the explanation of the question (if
+// any) as safe HTML.
+func renderQuestionExplanation(question *database.ExamQuestion) *string {
+	if question.Explanation == nil {
+		return nil
+	}
+
+	rendered := contentUtils.RenderHTML(question.ContentFormat, *question.Explanation)
+	return &rendered
+}
+
+// toAnswerFeedbackInfo checks the chosen option (which can be nil) against
+// the answer key of the question, for the instant feedback of the practice
+// exams.
+func toAnswerFeedbackInfo(question *database.ExamQuestion, chosenOption *string) *AnswerFeedbackInfo {
+	info := &AnswerFeedbackInfo{
+		CorrectOption:       ssg.Clone(question.CorrectOption),
+		Explanation:         ssg.Clone(question.Explanation),
+		RenderedExplanation: renderQuestionExplanation(question),
+	}
+	if question.HasAnswerKey() && chosenOption != nil {
+		isCorrect := question.IsCorrectOption(chosenOption)
+		info.IsCorrect = &isCorrect
+	}
+
+	return info
+}
+
+func toSimilarityCheckInfo(check *database.SimilarityCheck) *SimilarityCheckInfo {
+	return &SimilarityCheckInfo{
+		CheckId:      check.CheckId,
+		ExamId:       check.ExamId,
+		QuestionId:   check.QuestionId,
+		RequestedBy:  ssg.Clone(check.RequestedBy),
+		CheckStatus:  check.CheckStatus,
+		ShingleSize:  check.ShingleSize,
+		Threshold:    check.Threshold,
+		AnswersCount: check.AnswersCount,
+		MatchesCount: check.MatchesCount,
+		ErrorMessage: ssg.Clone(check.ErrorMessage),
+		StartedAt:    check.StartedAt,
+		FinishedAt:   ssg.Clone(check.FinishedAt),
+	}
+}
+
+func toSimilarityMatchInfo(match *database.SimilarityMatch) *SimilarityMatchInfo {
+	info := &SimilarityMatchInfo{
+		MatchId:       match.MatchId,
+		FirstUserId:   match.FirstUserId,
+		FirstAttempt:  match.FirstAttempt,
+		SecondUserId:  match.SecondUserId,
+		SecondAttempt: match.SecondAttempt,
+		Similarity:    match.Similarity,
+		Containment:   match.Containment,
+		Passages:      make([]*SimilarityPassageInfo, 0, len(match.Passages)),
+	}
+	for _, passage := range match.Passages {
+		info.Passages = append(info.Passages, &SimilarityPassageInfo{
+			FirstStart:  passage.FirstStart,
+			FirstEnd:    passage.FirstEnd,
+			SecondStart: passage.SecondStart,
+			SecondEnd:   passage.SecondEnd,
+			FirstText:   passage.FirstText,
+			SecondText:  passage.SecondText,
+		})
+	}
+
+	return info
+}
+
+// runSimilarityCheck compares the text answers of the question of a running
+// check and stores the results; it's meant to be run in the background, so
+// the errors are only logged and stored in the check.
+func runSimilarityCheck(check *database.SimilarityCheck) {
+	defer func() {
+		if r := recover(); r != nil {
+			logging.Error("runSimilarityCheck: similarity check panicked: ", r)
+			_ = database.FailSimilarityCheck(check.CheckId, "internal error")
+		}
+	}()
+
+	failCheck := func(message string, err error) {
+		logging.UnexpectedError("runSimilarityCheck: "+message+":", err)
+		if err := database.FailSimilarityCheck(check.CheckId, message); err != nil {
+			logging.UnexpectedError("runSimilarityCheck: Failed to mark the check as failed:", err)
+		}
+	}
+
+	answers, err := database.GetQuestionTextAnswers(check.ExamId, check.QuestionId)
+	if err != nil {
+		failCheck("Failed to get the answers", err)
+		return
+	}
+
+	documents := make([]*similarityUtils.Document, 0, len(answers))
+	for _, answer := range answers {
+		documents = append(documents, &similarityUtils.Document{
+			Owner: answer.AnsweredBy,
+			Text:  *answer.AnswerText,
+		})
+	}
+
+	pairs, err := similarityUtils.CompareDocuments(documents, &similarityUtils.CompareOptions{
+		ShingleSize: check.ShingleSize,
+		Threshold:   check.Threshold,
+	})
+	if err != nil {
+		failCheck("Failed to compare the answers", err)
+		return
+	}
+
+	matches := make([]*database.SimilarityMatch, 0, len(pairs))
+	for _, pair := range pairs {
+		first, second := answers[pair.First], answers[pair.Second]
+		match := &database.SimilarityMatch{
+			CheckId:       check.CheckId,
+			FirstUserId:   first.AnsweredBy,
+			FirstAttempt:  first.AttemptNumber,
+			SecondUserId:  second.AnsweredBy,
+			SecondAttempt: second.AttemptNumber,
+			Similarity:    pair.Similarity,
+			Containment:   pair.Containment,
+		}
+		for _, passage := range pair.Passages {
+			match.Passages = append(match.Passages, &database.SimilarityPassage{
+				FirstStart:  passage.FirstStart,
+				FirstEnd:    passage.FirstEnd,
+				SecondStart: passage.SecondStart,
+				SecondEnd:   passage.SecondEnd,
+				FirstText:   similarityUtils.GetPassageText(*first.AnswerText, passage.FirstStart, passage.FirstEnd),
+				SecondText:  similarityUtils.GetPassageText(*second.AnswerText, passage.SecondStart, passage.SecondEnd),
+			})
+		}
+		matches = append(matches, match)
+	}
+
+	err = database.CompleteSimilarityCheck(check.CheckId, len(answers), matches)
+	if err != nil {
+		failCheck("Failed to store the results", err)
+	}
+}
+
+// normalizeQuestionTags trims and lowercases the tags of a question and
+// removes the duplicates, returning an error if any of them is invalid.
+func normalizeQuestionTags(tags []string) ([]string, error) {
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" {
+			return nil, fmt.Errorf("tags cannot be empty")
+		} else if len([]rune(tag)) > database.MaxQuestionTagLength {
+			return nil, fmt.Errorf("tag %q is longer than %d characters", tag, database.MaxQuestionTagLength)
+		} else if seen[tag] {
+			continue
+		}
+
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+
+	if len(normalized) > database.MaxQuestionTagsCount {
+		return nil, fmt.Errorf("a question can have at most %d tags", database.MaxQuestionTagsCount)
+	}
+
+	return normalized, nil
+}
+
+// normalizeLearningObjectives trims the learning objectives of a question,
+// returning an error if any of them is invalid.
+func normalizeLearningObjectives(objectives []string) ([]string, error) {
+	if len(objectives) > database.MaxLearningObjectivesCount {
+		return nil, fmt.Errorf("a question can have at most %d learning objectives", database.MaxLearningObjectivesCount)
+	}
+
+	normalized := make([]string, 0, len(objectives))
+	for _, objective := range objectives {
+		objective = strings.TrimSpace(objective)
+		if objective == "" {
+			return nil, fmt.Errorf("learning objectives cannot be empty")
+		} else if len([]rune(objective)) > database.MaxLearningObjectiveLength {
+			return nil, fmt.Errorf("learning objectives cannot be longer than %d characters", database.MaxLearningObjectiveLength)
+		}
+
+		normalized = append(normalized, objective)
+	}
+
+	return normalized, nil
+}
+
+func toExamQuestionMetadataInfo(question *database.ExamQuestion) *ExamQuestionMetadataInfo {
+	return &ExamQuestionMetadataInfo{
+		Tags:               append([]string{}, question.Tags...),
+		DifficultyLevel:    ssg.Clone(question.DifficultyLevel),
+		LearningObjectives: append([]string{}, question.LearningObjectives...),
+		TopicId:            ssg.Clone(question.TopicId),
+	}
+}
+
+func toExamReviewCommentInfo(comment *database.ExamReviewComment) *ExamReviewCommentInfo {
+	return &ExamReviewCommentInfo{
+		CommentId:   comment.CommentId,
+		ExamId:      comment.ExamId,
+		QuestionId:  ssg.Clone(comment.QuestionId),
+		AuthorId:    comment.AuthorId,
+		CommentText: comment.CommentText,
+		IsResolved:  comment.IsResolved,
+		ResolvedBy:  ssg.Clone(comment.ResolvedBy),
+		ResolvedAt:  ssg.Clone(comment.ResolvedAt),
+		CreatedAt:   comment.CreatedAt,
+	}
+}
+
+func toExamReviewStatusResult(examInfo *database.ExamInfo) *ExamReviewStatusResult {
+	return &ExamReviewStatusResult{
+		ExamId:       examInfo.ExamId,
+		ReviewStatus: examInfo.ReviewStatus,
+		SubmittedAt:  ssg.Clone(examInfo.SubmittedAt),
+		ReviewedBy:   ssg.Clone(examInfo.ReviewedBy),
+		ReviewedAt:   ssg.Clone(examInfo.ReviewedAt),
+	}
+}
+
+// canCommentOnExamReview returns true if the user can leave (and read) the
+// review comments of the exam: its reviewers and its authors.
+func canCommentOnExamReview(userInfo *database.UserInfo, examInfo *database.ExamInfo) bool {
+	return userInfo.CanReviewExam(examInfo) || userInfo.CanEditExam(examInfo)
+}
+
+func toExamCollaboratorInfo(collaborator *database.ExamCollaborator) *ExamCollaboratorInfo {
+	return &ExamCollaboratorInfo{
+		ExamId:           collaborator.ExamId,
+		UserId:           collaborator.UserId,
+		CollaboratorRole: collaborator.CollaboratorRole,
+		AddedBy:          ssg.Clone(collaborator.AddedBy),
+		CreatedAt:        collaborator.CreatedAt,
+		UpdatedAt:        collaborator.UpdatedAt,
+	}
+}
+
+func toExamPreviewInfo(preview *database.ExamPreviewAttempt, examInfo *database.ExamInfo) *ExamPreviewInfo {
+	deadline := preview.GetDeadline(examInfo)
+	info := &ExamPreviewInfo{
+		PreviewId:      preview.PreviewId,
+		ExamId:         preview.ExamId,
+		UserId:         preview.UserId,
+		StartedAt:      preview.StartedAt,
+		FinishedAt:     ssg.Clone(preview.FinishedAt),
+		Deadline:       deadline,
+		TimeLimit:      int(examInfo.GetTimeLimitFor(nil).Minutes()),
+		ElapsedSeconds: int(preview.GetElapsedTime().Seconds()),
+	}
+
+	if preview.FinishedAt != nil {
+		info.IsOvertime = preview.FinishedAt.After(deadline)
+	} else {
+		info.IsOvertime = time.Now().After(deadline)
+	}
+
+	return info
+}
+
+// getExamPreviewResult gets the questions of the exam as a student would
+// see them, alongside the answers given in the preview (and the score
+// breakdown of it, if it has been finished).
+func getExamPreviewResult(
+	preview *database.ExamPreviewAttempt,
+	examInfo *database.ExamInfo,
+	renderHtml bool,
+) (*ExamPreviewResult, error) {
+	questions, err := database.GetExamQuestions(&database.GetExamQuestionsData{
+		ExamId: examInfo.ExamId,
+		Limit:  database.GetExamQuestionsCount(examInfo.ExamId),
+	})
+	if err != nil && err != pgx.ErrNoRows {
+		return nil, err
+	}
+
+	sections, err := database.GetExamSections(examInfo.ExamId)
+	if err != nil {
+		return nil, err
+	}
+
+	answers, err := database.GetExamPreviewAnswers(preview.PreviewId)
+	if err != nil {
+		return nil, err
+	}
+
+	answersMap := make(map[int]*database.ExamPreviewAnswer, len(answers))
+	for _, answer := range answers {
+		answersMap[answer.QuestionId] = answer
+	}
+
+	questionIds := make([]int, 0, len(questions))
+	for _, q := range questions {
+		questionIds = append(questionIds, q.QuestionId)
+	}
+
+	attachmentsMap := make(map[int][]*ExamAttachmentInfo)
+	if len(questionIds) > 0 {
+		// the answer attachments belong to the real attempts, so only the
+		// attachments of the questions (and their options) are needed
+		attachments, err := database.GetQuestionsAttachments(&database.GetQuestionsAttachmentsOptions{
+			QuestionIds: questionIds,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, attachment := range attachments {
+			attachmentsMap[attachment.QuestionId] = append(
+				attachmentsMap[attachment.QuestionId], toExamAttachmentInfo(attachment),
+			)
+		}
+	}
+
+	result := &ExamPreviewResult{
+		Preview:   toExamPreviewInfo(preview, examInfo),
+		Questions: make([]*ExamQuestionInfo, 0, len(questions)),
+		Sections:  make([]*ExamSectionInfo, 0, len(sections)),
+	}
+	for _, section := range sections {
+		result.Sections = append(result.Sections, toExamSectionInfo(section, nil))
+	}
+
+	for _, q := range questions {
+		info := &ExamQuestionInfo{
+			QuestionId:    q.QuestionId,
+			QuestionTitle: q.QuestionTitle,
+			Description:   q.Description,
+			Option1:       q.Option1,
+			Option2:       q.Option2,
+			Option3:       q.Option3,
+			Option4:       q.Option4,
+			SectionId:     ssg.Clone(q.SectionId),
+			QuestionOrder: q.QuestionOrder,
+			ContentFormat: q.ContentFormat,
+			CreatedAt:     q.CreatedAt,
+			Attachments:   attachmentsMap[q.QuestionId],
+			Points:        q.Points,
+		}
+		if renderHtml {
+			info.Rendered = renderQuestionContent(q)
+		}
+
+		if answer := answersMap[q.QuestionId]; answer != nil {
+			info.UserAnswer = &AnsweredQuestionInfo{
+				UserId:       preview.UserId,
+				QuestionId:   q.QuestionId,
+				ChosenOption: ssg.Clone(answer.ChosenOption),
+				SecondsTaken: answer.SecondsTaken,
+				AnswerText:   ssg.Clone(answer.AnswerText),
+			}
+
+			if examInfo.IsPractice {
+				info.Explanation = ssg.Clone(q.Explanation)
+				if info.Rendered != nil {
+					info.Rendered.Explanation = renderQuestionExplanation(q)
+				}
+			}
+		}
+		result.Questions = append(result.Questions, info)
+	}
+
+	if preview.IsFinished() {
+		result.Breakdown = computePreviewBreakdown(questions, sections, answersMap)
+	}
+
+	return result, nil
+}
+
+// computePreviewBreakdown grades the answers of a preview the way they
+// would be graded automatically: the questions with an answer key are
+// graded by the chosen option, the rest of them need a human grader.
+func computePreviewBreakdown(
+	questions []*database.ExamQuestion,
+	sections []*database.ExamSection,
+	answersMap map[int]*database.ExamPreviewAnswer,
+) *PreviewScoreBreakdown {
+	breakdown := &PreviewScoreBreakdown{
+		QuestionsCount: len(questions),
+		Questions:      make([]*PreviewQuestionResult, 0, len(questions)),
+		Sections:       make([]*PreviewSectionResult, 0, len(sections)),
+	}
+
+	sectionsMap := make(map[int]*PreviewSectionResult, len(sections))
+	for _, section := range sections {
+		sectionResult := &PreviewSectionResult{
+			SectionId:    section.SectionId,
+			SectionTitle: section.SectionTitle,
+			Duration:     ssg.Clone(section.Duration),
+		}
+		sectionsMap[section.SectionId] = sectionResult
+		breakdown.Sections = append(breakdown.Sections, sectionResult)
+	}
+
+	for _, q := range questions {
+		questionResult := &PreviewQuestionResult{
+			QuestionId:    q.QuestionId,
+			QuestionTitle: q.QuestionTitle,
+			SectionId:     ssg.Clone(q.SectionId),
+			CorrectOption: ssg.Clone(q.CorrectOption),
+			Explanation:   ssg.Clone(q.Explanation),
+		}
+
+		answer := answersMap[q.QuestionId]
+		if answer != nil {
+			questionResult.ChosenOption = ssg.Clone(answer.ChosenOption)
+			questionResult.AnswerText = ssg.Clone(answer.AnswerText)
+			questionResult.SecondsTaken = answer.SecondsTaken
+		}
+
+		if q.HasAnswerKey() {
+			breakdown.AutoGradedCount++
+		}
+
+		switch {
+		case answer == nil:
+			questionResult.Status = previewQuestionUnanswered
+			breakdown.UnansweredCount++
+		case !q.HasAnswerKey() || answer.ChosenOption == nil:
+			questionResult.Status = previewQuestionNeedsGrading
+			breakdown.NeedsGradingCount++
+		case q.IsCorrectOption(answer.ChosenOption):
+			questionResult.Status = previewQuestionCorrect
+			breakdown.CorrectCount++
+		default:
+			questionResult.Status = previewQuestionIncorrect
+			breakdown.IncorrectCount++
+		}
+		breakdown.TotalSecondsTaken += questionResult.SecondsTaken
+
+		if q.SectionId != nil && sectionsMap[*q.SectionId] != nil {
+			sectionResult := sectionsMap[*q.SectionId]
+			sectionResult.QuestionsCount++
+			sectionResult.SecondsTaken += questionResult.SecondsTaken
+			switch questionResult.Status {
+			case previewQuestionCorrect:
+				sectionResult.CorrectCount++
+			case previewQuestionIncorrect:
+				sectionResult.IncorrectCount++
+			case previewQuestionUnanswered:
+				sectionResult.UnansweredCount++
+			case previewQuestionNeedsGrading:
+				sectionResult.NeedsGradingCount++
+			}
+		}
+
+		breakdown.Questions = append(breakdown.Questions, questionResult)
+	}
+
+	for _, sectionResult := range breakdown.Sections {
+		sectionResult.IsOvertime = sectionResult.Duration != nil &&
+			sectionResult.SecondsTaken > *sectionResult.Duration*60
+	}
+
+	if breakdown.AutoGradedCount > 0 {
+		percentage := float64(breakdown.CorrectCount) * 100 / float64(breakdown.AutoGradedCount)
+		breakdown.ScorePercentage = &percentage
+	}
+
+	return breakdown
+}
+
+// getAttemptReviewResult returns every question of the exam alongside the
+// answer given to it in the attempt, its correct answer, the points it has
+// earned and the comment of its grader.
+func getAttemptReviewResult(
+	examInfo *database.ExamInfo,
+	attempt *database.ExamAttempt,
+	renderHtml bool,
+) (*GetAttemptReviewResult, error) {
+	questions, err := database.GetExamQuestions(&database.GetExamQuestionsData{
+		ExamId: examInfo.ExamId,
+		Limit:  database.GetExamQuestionsCount(examInfo.ExamId),
+	})
+	if err != nil && err != pgx.ErrNoRows {
+		return nil, err
+	}
+
+	answers, err := database.GetAttemptGivenAnswers(
+		attempt.UserId, attempt.ExamId, attempt.AttemptNumber,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	answersMap := make(map[int]*database.GivenAnswerInfo, len(answers))
+	for _, answer := range answers {
+		answersMap[answer.QuestionId] = answer
+	}
+
+	questionIds := make([]int, 0, len(questions))
+	for _, q := range questions {
+		questionIds = append(questionIds, q.QuestionId)
+	}
+
+	attachmentsMap := make(map[int][]*ExamAttachmentInfo)
+	if len(questionIds) > 0 {
+		attachments, err := database.GetQuestionsAttachments(&database.GetQuestionsAttachmentsOptions{
+			QuestionIds:   questionIds,
+			AnsweredBy:    attempt.UserId,
+			AttemptNumber: attempt.AttemptNumber,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, attachment := range attachments {
+			attachmentsMap[attachment.QuestionId] = append(
+				attachmentsMap[attachment.QuestionId], toExamAttachmentInfo(attachment),
+			)
+		}
+	}
+
+	result := &GetAttemptReviewResult{
+		ExamId:              examInfo.ExamId,
+		ExamTitle:           examInfo.ExamTitle,
+		UserId:              attempt.UserId,
+		Attempt:             toExamAttemptInfo(attempt),
+		AttemptReviewPolicy: examInfo.AttemptReviewPolicy,
+		ResultsReleasedAt:   ssg.Clone(examInfo.ResultsReleasedAt),
+		Questions:           make([]*AttemptReviewQuestionInfo, 0, len(questions)),
+	}
+	for _, q := range questions {
+		answer := answersMap[q.QuestionId]
+		if answer == nil && examInfo.IsAdaptive {
+			// adaptive attempts only contain the questions given to the user
+			continue
+		}
+
+		info := &AttemptReviewQuestionInfo{
+			QuestionId:    q.QuestionId,
+			QuestionTitle: q.QuestionTitle,
+			Description:   ssg.Clone(q.Description),
+			Option1:       ssg.Clone(q.Option1),
+			Option2:       ssg.Clone(q.Option2),
+			Option3:       ssg.Clone(q.Option3),
+			Option4:       ssg.Clone(q.Option4),
+			SectionId:     ssg.Clone(q.SectionId),
+			QuestionOrder: q.QuestionOrder,
+			ContentFormat: q.ContentFormat,
+			Attachments:   attachmentsMap[q.QuestionId],
+			CorrectOption: ssg.Clone(q.CorrectOption),
+			Points:        q.Points,
+			Explanation:   ssg.Clone(q.Explanation),
+		}
+		if renderHtml {
+			info.Rendered = renderQuestionContent(q)
+			info.Rendered.Explanation = renderQuestionExplanation(q)
+		}
+		result.TotalPoints += q.Points
+
+		if answer == nil {
+			// nothing to grade, nothing earned
+			var noPoints float64
+			info.PointsEarned = &noPoints
+			result.Questions = append(result.Questions, info)
+			continue
+		}
+
+		info.UserAnswer = &AnsweredQuestionInfo{
+			UserId:       answer.AnsweredBy,
+			QuestionId:   answer.QuestionId,
+			ChosenOption: ssg.Clone(answer.ChosenOption),
+			SecondsTaken: answer.SecondsTaken,
+			AnswerText:   ssg.Clone(answer.AnswerText),
+		}
+		if q.HasAnswerKey() && answer.ChosenOption != nil {
+			isCorrect := q.IsCorrectOption(answer.ChosenOption)
+			info.IsCorrect = &isCorrect
+		}
+
+		info.PointsEarned = answer.GetPointsEarned(q)
+		info.IsGraded = answer.IsGraded()
+		info.GraderComment = ssg.Clone(answer.GraderComment)
+		info.GradedAt = ssg.Clone(answer.GradedAt)
+		if info.PointsEarned == nil {
+			result.PendingCount++
+		} else {
+			result.PointsEarned += *info.PointsEarned
+		}
+
+		result.Questions = append(result.Questions, info)
+	}
+
+	return result, nil
+}
+
+// findExamAttempt finds the attempt of the user with the specified number
+// in the exam; the latest attempt is returned if attemptNumber is 0.
+func findExamAttempt(userId string, examId, attemptNumber int) (*database.ExamAttempt, error) {
+	latestAttempt, err := database.GetLatestExamAttempt(userId, examId)
+	if err != nil {
+		return nil, err
+	} else if attemptNumber == 0 || attemptNumber == latestAttempt.AttemptNumber {
+		// prefer the cached one, so it stays up to date
+		return latestAttempt, nil
+	}
+
+	attempts, err := database.GetExamAttempts(userId, examId)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, attempt := range attempts {
+		if attempt.AttemptNumber == attemptNumber {
+			return attempt, nil
+		}
+	}
+
+	return nil, database.ErrExamAttemptNotFound
+}
+
+// writeParticipantsExport writes the participants of the exam (alongside
+// their results) to w in the specified format, batch by batch; each batch
+// is flushed as soon as it's written, so the export is streamed to the
+// client.
+func writeParticipantsExport(w *bufio.Writer, format string, examInfo *database.ExamInfo, columns []exportUtils.Column) error {
+	writer, err := exportUtils.NewWriter(format, w, examInfo.ExamTitle)
+	if err != nil {
+		return err
+	}
+
+	if err = writer.WriteRow(exportUtils.GetHeaderRow(columns)); err != nil {
+		return err
+	}
+
+	afterUserId := ""
+	for {
+		participants, err := database.GetExamParticipantsExportBatch(
+			examInfo.ExamId, afterUserId, database.ExportBatchSize,
+		)
+		if err != nil {
+			return err
+		}
+
+		for _, participant := range participants {
+			err = writer.WriteRow(exportUtils.GetRow(columns, toParticipantExportValues(participant)))
+			if err != nil {
+				return err
+			}
+		}
+
+		if err = writer.Flush(); err != nil {
+			return err
+		} else if err = w.Flush(); err != nil {
+			return err
+		}
+
+		if len(participants) < database.ExportBatchSize {
+			break
+		}
+		afterUserId = participants[len(participants)-1].UserId
+	}
+
+	if err = writer.Close(); err != nil {
+		return err
+	}
+
+	return w.Flush()
+}
+
+func toParticipantExportValues(participant *database.ExamParticipantExportRow) map[string]exportUtils.Cell {
+	values := map[string]exportUtils.Cell{
+		"user_id":          exportUtils.TextCell(participant.UserId),
+		"full_name":        exportUtils.TextCell(participant.FullName),
+		"email":            exportUtils.TextCell(participant.Email),
+		"joined_at":        exportUtils.TextCell(participant.JoinedAt.Format(database.ExamDateLayout)),
+		"attempts":         exportUtils.NumberCell(float64(participant.AttemptsCount)),
+		"final_score":      exportUtils.OptionalTextCell(participant.FinalScore),
+		"score_percentage": exportUtils.OptionalNumberCell(participant.ScorePercentage),
+		"scored_by":        exportUtils.OptionalTextCell(participant.ScoredBy),
+	}
+	if participant.LastFinishedAt != nil {
+		values["last_finished_at"] = exportUtils.TextCell(
+			participant.LastFinishedAt.Format(database.ExamDateLayout),
+		)
+	}
+
+	return values
+}
+
+// buildResultReport writes the result report of a participant of an exam:
+// the exam info, their answers against the correct answers with the points
+// of each question, their total and grade, the comments of the teachers
+// and the trend of their scores across the course.
+func buildResultReport(data *resultReportData) *pdfUtils.Document {
+	examInfo, review := data.examInfo, data.review
+	doc := pdfUtils.NewDocument(examInfo.ExamTitle + " - " + data.student.FullName)
+
+	doc.Heading("Exam result report", pdfUtils.HeadingFontSize)
+	courseName := "-"
+	if data.courseInfo != nil {
+		courseName = data.courseInfo.CourseName
+	}
+	attemptInfo := "#" + strconv.Itoa(review.Attempt.AttemptNumber) +
+		", started at " + review.Attempt.StartedAt.Format(database.ExamDateLayout)
+	if review.Attempt.FinishedAt != nil {
+		attemptInfo += ", finished at " + review.Attempt.FinishedAt.Format(database.ExamDateLayout)
+	}
+	doc.KeyValues([][2]string{
+		{"Exam", examInfo.ExamTitle},
+		{"Course", courseName},
+		{"Exam date", examInfo.ExamDate.Format(database.ExamDateLayout)},
+		{"Duration", strconv.Itoa(examInfo.Duration) + " minutes"},
+		{"Student", data.student.FullName + " (" + data.student.UserId + ")"},
+		{"Attempt", attemptInfo},
+	})
+	doc.HorizontalRule()
+
+	doc.Heading("Total", pdfUtils.HeadingFontSize*0.75)
+	points := formatPoints(review.PointsEarned) + " / " + formatPoints(review.TotalPoints)
+	if review.PendingCount > 0 {
+		points += " (" + strconv.Itoa(review.PendingCount) + " answers are waiting to be graded)"
+	}
+	finalScore := "Not scored yet"
+	if data.givenExam.FinalScore != nil {
+		finalScore = *data.givenExam.FinalScore
+	}
+	percentage, grade := "-", "-"
+	if data.scorePercentage != nil {
+		percentage = strconv.FormatFloat(*data.scorePercentage, 'f', 2, 64) + "%"
+		grade = gradebookUtils.GetLetterGrade(*data.scorePercentage)
+	}
+	doc.KeyValues([][2]string{
+		{"Points", points},
+		{"Final score", finalScore},
+		{"Percentage", percentage},
+		{"Grade", grade},
+	})
+
+	doc.Heading("Answers", pdfUtils.HeadingFontSize*0.75)
+	questionColumnWidth := pdfUtils.ContentWidth() - reportNumberColumnWidth -
+		2*reportAnswerColumnWidth - reportPointsColumnWidth
+	columns := []pdfUtils.TableColumn{
+		{Title: "#", Width: reportNumberColumnWidth},
+		{Title: "Question", Width: questionColumnWidth},
+		{Title: "Answer", Width: reportAnswerColumnWidth},
+		{Title: "Correct answer", Width: reportAnswerColumnWidth},
+		{Title: "Points", Width: reportPointsColumnWidth},
+	}
+	rows := make([][]string, 0, len(review.Questions))
+	var comments []string
+	for i, question := range review.Questions {
+		answer := "No answer"
+		if question.UserAnswer != nil {
+			if question.UserAnswer.ChosenOption != nil {
+				answer = *question.UserAnswer.ChosenOption
+			} else if question.UserAnswer.AnswerText != nil {
+				answer = *question.UserAnswer.AnswerText
+			}
+		}
+
+		correctAnswer := "Graded by the teacher"
+		if question.CorrectOption != nil {
+			correctAnswer = *question.CorrectOption
+		}
+
+		earned := "Pending"
+		if question.PointsEarned != nil {
+			earned = formatPoints(*question.PointsEarned)
+		}
+
+		number := strconv.Itoa(i + 1)
+		rows = append(rows, []string{
+			number,
+			question.QuestionTitle,
+			answer,
+			correctAnswer,
+			earned + " / " + formatPoints(question.Points),
+		})
+
+		if question.GraderComment != nil && *question.GraderComment != "" {
+			comments = append(comments, "Question "+number+": "+*question.GraderComment)
+		}
+	}
+	doc.Table(columns, rows)
+
+	if len(comments) > 0 {
+		doc.Heading("Teacher comments", pdfUtils.HeadingFontSize*0.75)
+		for _, comment := range comments {
+			doc.Paragraph(comment)
+			doc.Spacer(pdfUtils.DefaultFontSize / 2)
+		}
+	}
+
+	if data.courseInfo != nil {
+		doc.Heading("Trend across the course", pdfUtils.HeadingFontSize*0.75)
+		doc.Note("Score percentages in the graded exams of " + data.courseInfo.CourseName + ".")
+		doc.Spacer(pdfUtils.DefaultFontSize)
+
+		scoresMap := make(map[int]*float64, len(data.courseScores))
+		for _, score := range data.courseScores {
+			scoresMap[score.ExamId] = score.ScorePercentage
+		}
+
+		chartPoints := make([]pdfUtils.ChartPoint, 0, len(data.courseExams))
+		for _, courseExam := range data.courseExams {
+			chartPoints = append(chartPoints, pdfUtils.ChartPoint{
+				Label:         courseExam.ExamTitle,
+				Value:         scoresMap[courseExam.ExamId],
+				IsHighlighted: courseExam.ExamId == examInfo.ExamId,
+			})
+		}
+		doc.LineChart(chartPoints, reportChartHeight)
+	}
+
+	doc.Spacer(pdfUtils.DefaultFontSize)
+	doc.Note("Generated at " + time.Now().Format(database.ExamDateLayout))
+	if doc.HasUnknownChars() {
+		doc.Note(reportUnknownCharsNote)
+	}
+
+	return doc
+}
+
+// formatPoints formats the points of the questions without the trailing
+// zeros (e.g. 1.5 or 2).
+func formatPoints(points float64) string {
+	return strconv.FormatFloat(points, 'f', -1, 64)
+}
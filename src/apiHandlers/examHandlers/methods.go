@@ -17,3 +17,31 @@ func (d *EditExamData) IsValid() bool {
 		d.Price != "" &&
 		d.Duration > 0
 }
+
+//-------------------------------------------------------------
+
+func (d *CreateExamQuestionData) getContentFields() []contentField {
+	return []contentField{
+		{name: "question_title", value: &d.QuestionTitle},
+		{name: "description", value: d.Description},
+		{name: "option1", value: d.Option1},
+		{name: "option2", value: d.Option2},
+		{name: "option3", value: d.Option3},
+		{name: "option4", value: d.Option4},
+		{name: "explanation", value: d.Explanation},
+	}
+}
+
+//-------------------------------------------------------------
+
+func (d *EditExamQuestionData) getContentFields() []contentField {
+	return []contentField{
+		{name: "question_title", value: &d.QuestionTitle},
+		{name: "description", value: d.Description},
+		{name: "option1", value: d.Option1},
+		{name: "option2", value: d.Option2},
+		{name: "option3", value: d.Option3},
+		{name: "option4", value: d.Option4},
+		{name: "explanation", value: d.Explanation},
+	}
+}
@@ -1,6 +1,10 @@
 package examHandlers
 
-import "time"
+import (
+	"ExamSphere/src/database"
+	"sync"
+	"time"
+)
 
 type CreateExamData struct {
 	CourseId        int    `json:"course_id" validate:"required"`
@@ -90,6 +94,72 @@ type GetExamInfoResult struct {
 	StartsIn           int       `json:"starts_in" default:"0"`
 	FinishesIn         int       `json:"finishes_in" default:"0"`
 	QuestionCount      int       `json:"question_count" default:"0"`
+	RequiresAccessCode bool      `json:"requires_access_code" default:"false"`
+	AccessCodeType     string    `json:"access_code_type" default:"none"`
+
+	MaxAttempts     int    `json:"max_attempts" default:"1"`
+	AttemptCooldown int    `json:"attempt_cooldown" default:"0"`
+	GradingPolicy   string `json:"grading_policy" default:"latest"`
+
+	// AttemptsLeft is the number of attempts the user still has in the
+	// exam; -1 means unlimited.
+	AttemptsLeft int `json:"attempts_left"`
+
+	// RetakeAvailableIn is the time (in seconds) the user has to wait
+	// before being able to start a new attempt.
+	RetakeAvailableIn int `json:"retake_available_in" default:"0"`
+
+	// OpensAt and ClosesAt are the window of the exam for the user.
+	OpensAt  time.Time `json:"opens_at"`
+	ClosesAt time.Time `json:"closes_at"`
+
+	// IsWindowed is true if the users can start the exam at any time
+	// between OpensAt and ClosesAt.
+	IsWindowed bool `json:"is_windowed" default:"false"`
+
+	// LatestStartAt is the latest time the user can start an attempt.
+	LatestStartAt time.Time `json:"latest_start_at"`
+
+	// Deadline is the personal deadline of the user in the exam.
+	Deadline       time.Time `json:"deadline"`
+	DeadlinePolicy string    `json:"deadline_policy" default:"auto_submit"`
+	GracePeriod    int       `json:"grace_period" default:"0"`
+
+	// UnmetPrerequisites is the list of the prerequisites of the exam that
+	// the user has not met yet (only filled if the user has not participated).
+	UnmetPrerequisites []*ExamPrerequisiteInfo `json:"unmet_prerequisites"`
+
+	// Capacity is the maximum number of participants of the exam (null
+	// means unlimited); WaitlistPosition is the position of the user in
+	// the waitlist of the exam (0 if the user is not waiting).
+	Capacity          *int `json:"capacity"`
+	ParticipantsCount int  `json:"participants_count" default:"0"`
+	WaitlistPosition  int  `json:"waitlist_position" default:"0"`
+
+	// IsAdaptive is true if the questions of the exam are given one at a
+	// time (through the nextQuestion endpoint).
+	IsAdaptive bool `json:"is_adaptive" default:"false"`
+
+	// ReviewStatus is one of "draft", "in_review" or "published"; only
+	// the published exams can be joined. CanReview is true if the user
+	// can approve the exam (or send it back to draft).
+	ReviewStatus string `json:"review_status"`
+	CanReview    bool   `json:"can_review" default:"false"`
+
+	// IsPractice is true if the exam is a practice exam: its attempts are
+	// unlimited, the answers are checked right away and it's not graded.
+	IsPractice bool `json:"is_practice" default:"false"`
+
+	// AttemptReviewPolicy is one of never, after_close or after_release.
+	// CanReviewAttempt is true if the user can review their attempts in
+	// the exam by now.
+	AttemptReviewPolicy string     `json:"attempt_review_policy"`
+	ResultsReleasedAt   *time.Time `json:"results_released_at"`
+	CanReviewAttempt    bool       `json:"can_review_attempt" default:"false"`
+
+	// GradeCategoryId is the grade category of the course the exam is
+	// counted in; nil means it's not counted in the course grade.
+	GradeCategoryId *int `json:"grade_category_id"`
 } // @name GetExamInfoResult
 
 type GetExamQuestionsData struct {
@@ -97,12 +167,28 @@ type GetExamQuestionsData struct {
 	ExamId int    `json:"exam_id"`
 	Offset int    `json:"offset"`
 	Limit  int    `json:"limit"`
+
+	// AttemptNumber is the attempt whose answers should be returned;
+	// 0 means the latest attempt.
+	AttemptNumber int `json:"attempt_number"`
+
+	// SectionId limits the questions to the ones of this section.
+	SectionId *int `json:"section_id"`
+
+	// RenderHtml asks for the safe HTML variant of the content of the
+	// questions to be returned alongside their source.
+	RenderHtml bool `json:"render_html"`
 } // @name GetExamQuestionsData
 
 type GetExamQuestionsResult struct {
-	Pov       string              `json:"pov"` // Point of view
-	ExamId    int                 `json:"exam_id"`
-	Questions []*ExamQuestionInfo `json:"questions"`
+	Pov           string              `json:"pov"` // Point of view
+	ExamId        int                 `json:"exam_id"`
+	AttemptNumber int                 `json:"attempt_number"`
+	Questions     []*ExamQuestionInfo `json:"questions"`
+
+	// Sections are the sections of the exam (alongside the progress of the
+	// user in them, while they are taking the exam).
+	Sections []*ExamSectionInfo `json:"sections"`
 } // @name GetExamQuestionsResult
 
 type ExamQuestionInfo struct {
@@ -113,10 +199,50 @@ type ExamQuestionInfo struct {
 	Option2       *string               `json:"option2"`
 	Option3       *string               `json:"option3"`
 	Option4       *string               `json:"option4"`
+	SectionId     *int                  `json:"section_id"`
+	QuestionOrder int                   `json:"question_order"`
+	ContentFormat string                `json:"content_format"`
 	CreatedAt     time.Time             `json:"created_at"`
 	UserAnswer    *AnsweredQuestionInfo `json:"user_answer"`
+	Attachments   []*ExamAttachmentInfo `json:"attachments"`
+
+	// Rendered is the safe HTML variant of the content; it's only set if
+	// it has been requested.
+	Rendered *RenderedQuestionContent `json:"rendered"`
+
+	// Metadata is only set for the users who can peek the questions of
+	// the exam.
+	Metadata *ExamQuestionMetadataInfo `json:"metadata"`
+
+	// Explanation is only set for the users who can peek the questions of
+	// the exam, and for the students of a practice exam once they have
+	// answered the question.
+	Explanation *string `json:"explanation"`
+
+	// Points is the maximum number of points the question is worth.
+	Points float64 `json:"points"`
 } // @name ExamQuestionInfo
 
+type ExamQuestionMetadataInfo struct {
+	Tags               []string `json:"tags"`
+	DifficultyLevel    *string  `json:"difficulty_level"`
+	LearningObjectives []string `json:"learning_objectives"`
+	TopicId            *int     `json:"topic_id"`
+} // @name ExamQuestionMetadataInfo
+
+type RenderedQuestionContent struct {
+	QuestionTitle string  `json:"question_title"`
+	Description   *string `json:"description"`
+	Option1       *string `json:"option1"`
+	Option2       *string `json:"option2"`
+	Option3       *string `json:"option3"`
+	Option4       *string `json:"option4"`
+
+	// Explanation is only set when the explanation of the question itself
+	// is returned.
+	Explanation *string `json:"explanation"`
+} // @name RenderedQuestionContent
+
 type AnsweredQuestionInfo struct {
 	UserId       string  `json:"user_id"`
 	QuestionId   int     `json:"question_id"`
@@ -136,17 +262,34 @@ type ParticipateExamData struct {
 
 	// Price is the price of the exam that user has already paid.
 	Price string `json:"price"`
+
+	// AccessCode is the code announced by the invigilator. It's only
+	// required when the user is participating in the exam themselves
+	// and the exam is protected by an access code.
+	AccessCode string `json:"access_code"`
+
+	// CouponCode is an optional discount coupon for the price of the exam.
+	CouponCode string `json:"coupon_code"`
 } // @name ParticipateExamData
 
 type ParticipateExamResult struct {
-	ExamId        int       `json:"exam_id"`
-	UserId        string    `json:"user_id"`
+	ExamId int    `json:"exam_id"`
+	UserId string `json:"user_id"`
+
+	// Price is the price actually paid for the exam (after the discount
+	// of the coupon), while OriginalPrice is the price of the exam.
 	Price         string    `json:"price"`
+	OriginalPrice string    `json:"original_price"`
 	AddedBy       *string   `json:"added_by"`
 	CreatedAt     time.Time `json:"created_at"`
 	StartsIn      int       `json:"starts_in" default:"0"`
 	FinishesIn    int       `json:"finishes_in" default:"0"`
 	QuestionCount int       `json:"question_count" default:"0"`
+
+	// IsWaitlisted is true if the exam is full and the user has been put
+	// on its waitlist instead; WaitlistPosition is their 1-based position.
+	IsWaitlisted     bool `json:"is_waitlisted" default:"false"`
+	WaitlistPosition int  `json:"waitlist_position" default:"0"`
 } // @name ParticipateExamResult
 
 type AnswerQuestionData struct {
@@ -158,12 +301,27 @@ type AnswerQuestionData struct {
 } // @name AnswerQuestionData
 
 type AnswerQuestionResult struct {
-	ExamId     int       `json:"exam_id"`
-	QuestionId int       `json:"question_id"`
-	AnsweredBy string    `json:"answered_by"`
-	AnsweredAt time.Time `json:"answered_at"`
+	ExamId        int       `json:"exam_id"`
+	QuestionId    int       `json:"question_id"`
+	AnsweredBy    string    `json:"answered_by"`
+	AttemptNumber int       `json:"attempt_number"`
+	AnsweredAt    time.Time `json:"answered_at"`
+
+	// Feedback is only set in the practice exams.
+	Feedback *AnswerFeedbackInfo `json:"feedback"`
 } // @name AnswerQuestionResult
 
+type AnswerFeedbackInfo struct {
+	// IsCorrect is null if the answer cannot be checked automatically: the
+	// question has no answer key, or no option was chosen.
+	IsCorrect     *bool   `json:"is_correct"`
+	CorrectOption *string `json:"correct_option"`
+	Explanation   *string `json:"explanation"`
+
+	// RenderedExplanation is the safe HTML variant of the explanation.
+	RenderedExplanation *string `json:"rendered_explanation"`
+} // @name AnswerFeedbackInfo
+
 type SetExamScoreData struct {
 	// ExamId is the exam we are trying to give this score to.
 	ExamId int `json:"exam_id"`
@@ -173,6 +331,10 @@ type SetExamScoreData struct {
 
 	// Score is the score we are trying to give to the user.
 	Score string `json:"score"`
+
+	// AttemptNumber is the attempt we are trying to score; 0 means
+	// the latest attempt of the user.
+	AttemptNumber int `json:"attempt_number"`
 } // @name SetExamScoreData
 
 type SetExamScoreResult struct {
@@ -180,6 +342,10 @@ type SetExamScoreResult struct {
 	UserId   string `json:"user_id"`
 	Score    string `json:"score"`
 	ScoredBy string `json:"scored_by"`
+
+	// FinalScore is the final score of the user in the exam, decided
+	// by the grading policy of the exam.
+	FinalScore *string `json:"final_score"`
 } // @name SetExamScoreResult
 
 type GetGivenExamData struct {
@@ -203,8 +369,11 @@ type GetUserOngoingExamsResult struct {
 
 type UserOngoingExamInfo struct {
 	ExamId    int       `json:"exam_id"`
-	ExamTitle int       `json:"course_id"`
+	ExamTitle string    `json:"exam_title"`
 	StartTime time.Time `json:"start_time"`
+
+	// EndTime is the personal deadline of the user in the exam.
+	EndTime time.Time `json:"end_time"`
 } // @name UserOngoingExamInfo
 
 type GetUsersExamHistoryData struct {
@@ -231,6 +400,20 @@ type CreateExamQuestionData struct {
 	Option2       *string `json:"option2"`
 	Option3       *string `json:"option3"`
 	Option4       *string `json:"option4"`
+	SectionId     *int    `json:"section_id"`
+	QuestionOrder int     `json:"question_order"`
+
+	// ContentFormat is one of plain (the default), markdown and
+	// markdown_latex.
+	ContentFormat string `json:"content_format"`
+
+	// Explanation explains the answer of the question; it's shown to the
+	// students of the practice exams right after they answer it.
+	Explanation *string `json:"explanation"`
+
+	// Points is the maximum number of points the question is worth; it's
+	// 1 by default.
+	Points *float64 `json:"points"`
 } // @name CreateExamQuestionData
 
 type CreateExamQuestionResult struct {
@@ -242,7 +425,15 @@ type CreateExamQuestionResult struct {
 	Option2       *string   `json:"option2"`
 	Option3       *string   `json:"option3"`
 	Option4       *string   `json:"option4"`
+	SectionId     *int      `json:"section_id"`
+	QuestionOrder int       `json:"question_order"`
+	ContentFormat string    `json:"content_format"`
 	CreatedAt     time.Time `json:"created_at"`
+	Explanation   *string   `json:"explanation"`
+	Points        float64   `json:"points"`
+
+	// Rendered is the safe HTML variant of the content, for previewing it.
+	Rendered *RenderedQuestionContent `json:"rendered"`
 } // @name CreateExamQuestionResult
 
 type EditExamQuestionData struct {
@@ -254,6 +445,20 @@ type EditExamQuestionData struct {
 	Option2       *string `json:"option2"`
 	Option3       *string `json:"option3"`
 	Option4       *string `json:"option4"`
+	SectionId     *int    `json:"section_id"`
+	QuestionOrder int     `json:"question_order"`
+
+	// ContentFormat is the new format of the content; the current format
+	// is kept if it's empty.
+	ContentFormat string `json:"content_format"`
+
+	// Explanation explains the answer of the question; it's shown to the
+	// students of the practice exams right after they answer it.
+	Explanation *string `json:"explanation"`
+
+	// Points is the maximum number of points the question is worth; the
+	// current points are kept if it's not set.
+	Points *float64 `json:"points"`
 } // @name EditExamQuestionData
 
 type EditExamQuestionResult struct {
@@ -265,7 +470,15 @@ type EditExamQuestionResult struct {
 	Option2       *string   `json:"option2"`
 	Option3       *string   `json:"option3"`
 	Option4       *string   `json:"option4"`
+	SectionId     *int      `json:"section_id"`
+	QuestionOrder int       `json:"question_order"`
+	ContentFormat string    `json:"content_format"`
 	CreatedAt     time.Time `json:"created_at"`
+	Explanation   *string   `json:"explanation"`
+	Points        float64   `json:"points"`
+
+	// Rendered is the safe HTML variant of the content, for previewing it.
+	Rendered *RenderedQuestionContent `json:"rendered"`
 } // @name EditExamQuestionResult
 
 type GetExamParticipantsData struct {
@@ -289,4 +502,1146 @@ type ExamParticipantInfo struct {
 	AddedBy    *string   `json:"added_by"`
 	ScoredBy   *string   `json:"scored_by"`
 	CreatedAt  time.Time `json:"created_at"`
+
+	// ClientMismatches is the number of requests received from a client
+	// other than the one the attempt of this participant is bound to.
+	// ClientIPChanges is the number of times the ip address of the client
+	// has changed during the attempt (which is allowed).
+	// They're only filled for users who can set score for the exam.
+	ClientMismatches int `json:"client_mismatches" default:"0"`
+	ClientIPChanges  int `json:"client_ip_changes" default:"0"`
 } // @name ExamParticipantInfo
+
+type ResetAttemptBindingData struct {
+	// ExamId is the exam that the attempt belongs to.
+	ExamId int `json:"exam_id"`
+
+	// UserId is the user whose attempt should be unbound from its client.
+	UserId string `json:"user_id"`
+} // @name ResetAttemptBindingData
+
+type ResetAttemptBindingResult struct {
+	ExamId  int    `json:"exam_id"`
+	UserId  string `json:"user_id"`
+	ResetBy string `json:"reset_by"`
+} // @name ResetAttemptBindingResult
+
+type StartExamAttemptData struct {
+	// ExamId is the exam the user is trying to start.
+	ExamId int `json:"exam_id"`
+
+	// AccessCode is the code announced by the invigilator, only required
+	// if the exam is protected by an access code.
+	AccessCode string `json:"access_code"`
+} // @name StartExamAttemptData
+
+type StartExamAttemptResult struct {
+	ExamId        int       `json:"exam_id"`
+	UserId        string    `json:"user_id"`
+	AttemptNumber int       `json:"attempt_number"`
+	StartedAt     time.Time `json:"started_at"`
+	BoundAt       time.Time `json:"bound_at"`
+	FinishesIn    int       `json:"finishes_in" default:"0"`
+} // @name StartExamAttemptResult
+
+type FinishExamAttemptData struct {
+	ExamId int `json:"exam_id"`
+} // @name FinishExamAttemptData
+
+type FinishExamAttemptResult struct {
+	ExamId        int       `json:"exam_id"`
+	UserId        string    `json:"user_id"`
+	AttemptNumber int       `json:"attempt_number"`
+	StartedAt     time.Time `json:"started_at"`
+	FinishedAt    time.Time `json:"finished_at"`
+
+	// AttemptsLeft is the number of attempts the user still has in the
+	// exam; -1 means unlimited.
+	AttemptsLeft int `json:"attempts_left"`
+
+	// RetakeAvailableIn is the time (in seconds) the user has to wait
+	// before being able to start a new attempt.
+	RetakeAvailableIn int `json:"retake_available_in" default:"0"`
+} // @name FinishExamAttemptResult
+
+type ExamAttemptInfo struct {
+	AttemptNumber int        `json:"attempt_number"`
+	StartedAt     time.Time  `json:"started_at"`
+	FinishedAt    *time.Time `json:"finished_at"`
+	FinalScore    *string    `json:"final_score"`
+	ScoredBy      *string    `json:"scored_by"`
+
+	// AbilityEstimate and AbilityStdError are the estimated ability of the
+	// user (and its standard error) in an adaptive attempt.
+	AbilityEstimate *float64 `json:"ability_estimate"`
+	AbilityStdError *float64 `json:"ability_std_error"`
+} // @name ExamAttemptInfo
+
+type GetExamAttemptsResult struct {
+	ExamId     int                `json:"exam_id"`
+	UserId     string             `json:"user_id"`
+	FinalScore *string            `json:"final_score"`
+	Attempts   []*ExamAttemptInfo `json:"attempts"`
+} // @name GetExamAttemptsResult
+
+type SetExamRetakePolicyData struct {
+	ExamId int `json:"exam_id"`
+
+	// MaxAttempts is the maximum number of attempts a user can have in
+	// the exam; 0 means unlimited.
+	MaxAttempts int `json:"max_attempts" default:"1"`
+
+	// AttemptCooldown is the time (in minutes) a user has to wait after
+	// finishing an attempt before starting the next one.
+	AttemptCooldown int `json:"attempt_cooldown" default:"0"`
+
+	// GradingPolicy decides how the final score is calculated from the
+	// attempts; it can be one of "best", "latest" or "average".
+	GradingPolicy string `json:"grading_policy" default:"latest"`
+} // @name SetExamRetakePolicyData
+
+type ExamRetakePolicyResult struct {
+	ExamId          int    `json:"exam_id"`
+	MaxAttempts     int    `json:"max_attempts"`
+	AttemptCooldown int    `json:"attempt_cooldown"`
+	GradingPolicy   string `json:"grading_policy"`
+} // @name ExamRetakePolicyResult
+
+type SetExamAvailabilityData struct {
+	ExamId int `json:"exam_id"`
+
+	// OpensAt is the unix timestamp of when the window of the exam opens;
+	// the exam date is used if it's not set.
+	OpensAt *int64 `json:"opens_at"`
+
+	// ClosesAt is the unix timestamp of when the window of the exam closes;
+	// if it's not set, the exam is a fixed session.
+	ClosesAt *int64 `json:"closes_at"`
+
+	// LateStartLimit is the time (in minutes) after the opening in which the
+	// users can still start the exam; not setting it means no limit.
+	LateStartLimit *int `json:"late_start_limit"`
+
+	// DeadlinePolicy is what happens at the deadline of the users; it can be
+	// one of "auto_submit" or "grace_period".
+	DeadlinePolicy string `json:"deadline_policy" default:"auto_submit"`
+
+	// GracePeriod is the time (in minutes) after the deadline in which the
+	// answers are still accepted (grace_period policy only).
+	GracePeriod int `json:"grace_period" default:"0"`
+} // @name SetExamAvailabilityData
+
+type ExamAvailabilityResult struct {
+	ExamId         int       `json:"exam_id"`
+	OpensAt        time.Time `json:"opens_at"`
+	ClosesAt       time.Time `json:"closes_at"`
+	IsWindowed     bool      `json:"is_windowed"`
+	LateStartLimit *int      `json:"late_start_limit"`
+	DeadlinePolicy string    `json:"deadline_policy"`
+	GracePeriod    int       `json:"grace_period"`
+} // @name ExamAvailabilityResult
+
+type SetExamAccessCodeData struct {
+	ExamId int `json:"exam_id"`
+
+	// AccessCodeType is the type of the access code; it can be one of
+	// "none", "static" or "rotating".
+	AccessCodeType string `json:"access_code_type"`
+
+	// AccessCode is the static access code; it's ignored for the other types.
+	AccessCode string `json:"access_code"`
+
+	// RotationInterval is the interval (in minutes) in which a rotating
+	// access code changes.
+	RotationInterval int `json:"rotation_interval" default:"5"`
+} // @name SetExamAccessCodeData
+
+type ExamAccessCodeResult struct {
+	ExamId           int    `json:"exam_id"`
+	AccessCodeType   string `json:"access_code_type"`
+	CurrentCode      string `json:"current_code"`
+	RotationInterval int    `json:"rotation_interval"`
+
+	// ExpiresIn is the time in seconds until the current rotating
+	// code changes.
+	ExpiresIn int `json:"expires_in" default:"0"`
+} // @name ExamAccessCodeResult
+
+type ExamPrerequisiteInfo struct {
+	PrerequisiteId    int      `json:"prerequisite_id"`
+	PrerequisiteType  string   `json:"prerequisite_type"`
+	RequiredExamId    *int     `json:"required_exam_id"`
+	RequiredExamTitle *string  `json:"required_exam_title"`
+	MinScore          *float64 `json:"min_score"`
+	TopicId           *int     `json:"topic_id"`
+	TopicName         *string  `json:"topic_name"`
+	MinLevel          *int     `json:"min_level"`
+
+	// CurrentValue is the best score percentage of the user in the required
+	// exam, or their current level in the topic; only filled for unmet
+	// prerequisites.
+	CurrentValue *float64 `json:"current_value"`
+
+	// Description is a human-readable explanation of the prerequisite.
+	Description string `json:"description"`
+} // @name ExamPrerequisiteInfo
+
+type AddExamPrerequisiteData struct {
+	ExamId int `json:"exam_id"`
+
+	// PrerequisiteType is the type of the prerequisite; it can be one of
+	// "exam" or "topic_level".
+	PrerequisiteType string `json:"prerequisite_type"`
+
+	// RequiredExamId is the exam the user has to pass (exam type only).
+	RequiredExamId *int `json:"required_exam_id"`
+
+	// MinScore is the minimum score percentage the user needs in the
+	// required exam (exam type only).
+	MinScore *float64 `json:"min_score" default:"50"`
+
+	// TopicId is the topic the user needs a level in (topic_level type only).
+	TopicId *int `json:"topic_id"`
+
+	// MinLevel is the minimum level the user needs in the topic
+	// (topic_level type only).
+	MinLevel *int `json:"min_level"`
+} // @name AddExamPrerequisiteData
+
+type RemoveExamPrerequisiteData struct {
+	ExamId         int `json:"exam_id"`
+	PrerequisiteId int `json:"prerequisite_id"`
+} // @name RemoveExamPrerequisiteData
+
+type RemoveExamPrerequisiteResult struct {
+	ExamId         int  `json:"exam_id"`
+	PrerequisiteId int  `json:"prerequisite_id"`
+	Removed        bool `json:"removed"`
+} // @name RemoveExamPrerequisiteResult
+
+type GetExamPrerequisitesResult struct {
+	ExamId        int                     `json:"exam_id"`
+	Prerequisites []*ExamPrerequisiteInfo `json:"prerequisites"`
+} // @name GetExamPrerequisitesResult
+
+type SetExamAccommodationData struct {
+	ExamId int    `json:"exam_id"`
+	UserId string `json:"user_id"`
+
+	// ExtraMinutes is the number of minutes added to the duration of the
+	// exam for the user.
+	ExtraMinutes int `json:"extra_minutes" default:"0"`
+
+	// TimeMultiplier is applied to the duration of the exam for the
+	// user (e.g. 1.5); 0 means no multiplier.
+	TimeMultiplier float64 `json:"time_multiplier" default:"1"`
+
+	// CustomStart is the unix timestamp of the custom start time of the
+	// exam for the user (optional).
+	CustomStart *int64 `json:"custom_start"`
+
+	// CustomEnd is the unix timestamp of the custom close time of the
+	// exam for the user (optional).
+	CustomEnd *int64 `json:"custom_end"`
+
+	Note *string `json:"note"`
+} // @name SetExamAccommodationData
+
+type ExamAccommodationInfo struct {
+	ExamId         int        `json:"exam_id"`
+	UserId         string     `json:"user_id"`
+	ExtraMinutes   int        `json:"extra_minutes"`
+	TimeMultiplier float64    `json:"time_multiplier"`
+	CustomStart    *time.Time `json:"custom_start"`
+	CustomEnd      *time.Time `json:"custom_end"`
+	Note           *string    `json:"note"`
+	GrantedBy      *string    `json:"granted_by"`
+	CreatedAt      time.Time  `json:"created_at"`
+
+	// StartsAt is the effective start time of the exam for the user.
+	StartsAt time.Time `json:"starts_at"`
+
+	// EndsAt is the effective close time of the exam for the user.
+	EndsAt time.Time `json:"ends_at"`
+} // @name ExamAccommodationInfo
+
+type RemoveExamAccommodationData struct {
+	ExamId int    `json:"exam_id"`
+	UserId string `json:"user_id"`
+} // @name RemoveExamAccommodationData
+
+type RemoveExamAccommodationResult struct {
+	ExamId  int    `json:"exam_id"`
+	UserId  string `json:"user_id"`
+	Removed bool   `json:"removed"`
+} // @name RemoveExamAccommodationResult
+
+type GetExamAccommodationsResult struct {
+	ExamId         int                      `json:"exam_id"`
+	Accommodations []*ExamAccommodationInfo `json:"accommodations"`
+} // @name GetExamAccommodationsResult
+
+type CreateExamSeriesData struct {
+	// TemplateExamId is the exam every occurrence of the series is
+	// copied from (including its questions).
+	TemplateExamId int    `json:"template_exam_id"`
+	SeriesTitle    string `json:"series_title"`
+
+	// RecurrenceRule is an RRULE-like rule; supported parts are FREQ
+	// (DAILY, WEEKLY or MONTHLY), INTERVAL, BYDAY, COUNT and UNTIL.
+	// e.g. "FREQ=WEEKLY;BYDAY=MO,WE;COUNT=10"
+	RecurrenceRule string `json:"recurrence_rule"`
+
+	// StartsAt is the unix timestamp of the first occurrence; the date of
+	// the template exam is used if it's not set.
+	StartsAt *int64 `json:"starts_at"`
+
+	// GenerateAhead is the number of days the occurrences are created
+	// ahead of time.
+	GenerateAhead int `json:"generate_ahead" default:"7"`
+} // @name CreateExamSeriesData
+
+type ExamSeriesInfo struct {
+	SeriesId        int        `json:"series_id"`
+	TemplateExamId  int        `json:"template_exam_id"`
+	SeriesTitle     string     `json:"series_title"`
+	RecurrenceRule  string     `json:"recurrence_rule"`
+	StartsAt        time.Time  `json:"starts_at"`
+	GenerateAhead   int        `json:"generate_ahead"`
+	IsActive        bool       `json:"is_active"`
+	LastGeneratedAt *time.Time `json:"last_generated_at"`
+	CreatedBy       string     `json:"created_by"`
+	CreatedAt       time.Time  `json:"created_at"`
+} // @name ExamSeriesInfo
+
+type ExamSeriesOccurrenceInfo struct {
+	OccurrenceAt time.Time `json:"occurrence_at"`
+
+	// ExamId is the id of the exam created for this occurrence; it's
+	// null if the occurrence is not materialised yet.
+	ExamId    *int `json:"exam_id"`
+	IsSkipped bool `json:"is_skipped"`
+
+	// ExamTitle, ExamDate and Duration are the overrides of this
+	// occurrence (null means no override).
+	ExamTitle *string    `json:"exam_title"`
+	ExamDate  *time.Time `json:"exam_date"`
+	Duration  *int       `json:"duration"`
+} // @name ExamSeriesOccurrenceInfo
+
+type GetExamSeriesResult struct {
+	Series *ExamSeriesInfo `json:"series"`
+
+	// Occurrences contains the materialised, edited and skipped occurrences
+	// of the series, alongside its next upcoming occurrences.
+	Occurrences []*ExamSeriesOccurrenceInfo `json:"occurrences"`
+} // @name GetExamSeriesResult
+
+type EditExamSeriesData struct {
+	SeriesId int `json:"series_id"`
+
+	// SeriesTitle, RecurrenceRule, GenerateAhead and IsActive are left
+	// unchanged if they are not set.
+	SeriesTitle    string `json:"series_title"`
+	RecurrenceRule string `json:"recurrence_rule"`
+	GenerateAhead  *int   `json:"generate_ahead"`
+	IsActive       *bool  `json:"is_active"`
+} // @name EditExamSeriesData
+
+type EditExamSeriesOccurrenceData struct {
+	SeriesId int `json:"series_id"`
+
+	// OccurrenceAt is the unix timestamp of the occurrence (as calculated
+	// by the recurrence rule).
+	OccurrenceAt int64 `json:"occurrence_at"`
+
+	// ExamTitle, ExamDate (unix timestamp) and Duration override the ones
+	// of the template exam; null means no override.
+	ExamTitle *string `json:"exam_title"`
+	ExamDate  *int64  `json:"exam_date"`
+	Duration  *int    `json:"duration"`
+} // @name EditExamSeriesOccurrenceData
+
+type SkipExamSeriesOccurrenceData struct {
+	SeriesId     int   `json:"series_id"`
+	OccurrenceAt int64 `json:"occurrence_at"`
+
+	// IsSkipped can be set to false to bring back a skipped occurrence.
+	IsSkipped bool `json:"is_skipped" default:"true"`
+} // @name SkipExamSeriesOccurrenceData
+
+type InviteToExamData struct {
+	ExamId int `json:"exam_id"`
+
+	// UserIds and Emails are the invitees; emails which don't belong to
+	// any account are invited to create one.
+	UserIds []string `json:"user_ids"`
+	Emails  []string `json:"emails"`
+} // @name InviteToExamData
+
+type ExamInvitationInfo struct {
+	InvitationId int        `json:"invitation_id"`
+	ExamId       int        `json:"exam_id"`
+	Email        string     `json:"email"`
+	UserId       *string    `json:"user_id"`
+	Status       string     `json:"status"`
+	ExpiresAt    time.Time  `json:"expires_at"`
+	InvitedBy    string     `json:"invited_by"`
+	CreatedAt    time.Time  `json:"created_at"`
+	AcceptedAt   *time.Time `json:"accepted_at"`
+} // @name ExamInvitationInfo
+
+type SkippedInviteeInfo struct {
+	Invitee string `json:"invitee"`
+	Reason  string `json:"reason"`
+} // @name SkippedInviteeInfo
+
+type InviteToExamResult struct {
+	Invitations []*ExamInvitationInfo `json:"invitations"`
+	Skipped     []*SkippedInviteeInfo `json:"skipped"`
+
+	// EmailsSent is false if the email client is not loaded, in which
+	// case the invitees have to be informed some other way.
+	EmailsSent bool `json:"emails_sent"`
+} // @name InviteToExamResult
+
+type GetExamInvitationsResult struct {
+	Invitations []*ExamInvitationInfo `json:"invitations"`
+} // @name GetExamInvitationsResult
+
+type GetExamInvitationInfoResult struct {
+	InvitationId int       `json:"invitation_id"`
+	ExamId       int       `json:"exam_id"`
+	ExamTitle    string    `json:"exam_title"`
+	ExamDate     time.Time `json:"exam_date"`
+	Email        string    `json:"email"`
+	Status       string    `json:"status"`
+	ExpiresAt    time.Time `json:"expires_at"`
+
+	// HasAccount is false if the invitee has to create an account
+	// before accepting the invitation.
+	HasAccount bool `json:"has_account"`
+} // @name GetExamInvitationInfoResult
+
+type AcceptExamInvitationData struct {
+	InvitationId int    `json:"invitation_id"`
+	Token        string `json:"token"`
+} // @name AcceptExamInvitationData
+
+type SetExamCapacityData struct {
+	ExamId int `json:"exam_id"`
+
+	// Capacity is the maximum number of participants; null removes the
+	// limit. Raising it gives the new seats to the waitlist.
+	Capacity *int `json:"capacity"`
+} // @name SetExamCapacityData
+
+type ExamCapacityResult struct {
+	ExamId            int  `json:"exam_id"`
+	Capacity          *int `json:"capacity"`
+	ParticipantsCount int  `json:"participants_count"`
+	WaitlistCount     int  `json:"waitlist_count"`
+} // @name ExamCapacityResult
+
+type WithdrawFromExamData struct {
+	ExamId int `json:"exam_id"`
+
+	// UserId is the user who is withdrawing from the exam; it should be
+	// set to the user's own id when they are withdrawing themselves.
+	UserId string `json:"user_id"`
+} // @name WithdrawFromExamData
+
+type WithdrawFromExamResult struct {
+	ExamId int    `json:"exam_id"`
+	UserId string `json:"user_id"`
+
+	// LeftWaitlist is true if the user was only on the waitlist of the
+	// exam, in which case nothing is refunded.
+	LeftWaitlist bool `json:"left_waitlist"`
+} // @name WithdrawFromExamResult
+
+type ExamWaitlistEntryInfo struct {
+	UserId    string    `json:"user_id"`
+	Position  int       `json:"position"`
+	AddedBy   *string   `json:"added_by"`
+	CouponId  *int      `json:"coupon_id"`
+	CreatedAt time.Time `json:"created_at"`
+} // @name ExamWaitlistEntryInfo
+
+type GetExamWaitlistResult struct {
+	ExamId   int                      `json:"exam_id"`
+	Capacity *int                     `json:"capacity"`
+	Entries  []*ExamWaitlistEntryInfo `json:"entries"`
+} // @name GetExamWaitlistResult
+
+type CreateExamSectionData struct {
+	ExamId       int    `json:"exam_id"`
+	SectionTitle string `json:"section_title"`
+	SectionOrder int    `json:"section_order"`
+
+	// Duration is the time limit of the section in minutes; null means
+	// only the deadline of the exam applies.
+	Duration      *int `json:"duration"`
+	IsForwardOnly bool `json:"is_forward_only"`
+} // @name CreateExamSectionData
+
+type EditExamSectionData struct {
+	SectionId     int    `json:"section_id"`
+	SectionTitle  string `json:"section_title"`
+	SectionOrder  int    `json:"section_order"`
+	Duration      *int   `json:"duration"`
+	IsForwardOnly bool   `json:"is_forward_only"`
+} // @name EditExamSectionData
+
+type DeleteExamSectionData struct {
+	SectionId int `json:"section_id"`
+} // @name DeleteExamSectionData
+
+type DeleteExamSectionResult struct {
+	SectionId int `json:"section_id"`
+	ExamId    int `json:"exam_id"`
+} // @name DeleteExamSectionResult
+
+type ExamSectionInfo struct {
+	SectionId     int       `json:"section_id"`
+	ExamId        int       `json:"exam_id"`
+	SectionTitle  string    `json:"section_title"`
+	SectionOrder  int       `json:"section_order"`
+	Duration      *int      `json:"duration"`
+	IsForwardOnly bool      `json:"is_forward_only"`
+	CreatedAt     time.Time `json:"created_at"`
+
+	// Progress is the state of the user's attempt in the section; it's
+	// null if the user is not taking the exam.
+	Progress *ExamSectionProgressInfo `json:"progress"`
+} // @name ExamSectionInfo
+
+type ExamSectionProgressInfo struct {
+	// Status is one of upcoming, ongoing, open, locked or expired; the
+	// questions of the section can only be answered while it's ongoing
+	// or open.
+	Status     string     `json:"status"`
+	StartedAt  *time.Time `json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at"`
+	Deadline   *time.Time `json:"deadline"`
+} // @name ExamSectionProgressInfo
+
+type GetExamSectionsResult struct {
+	ExamId        int                `json:"exam_id"`
+	AttemptNumber int                `json:"attempt_number"`
+	Sections      []*ExamSectionInfo `json:"sections"`
+} // @name GetExamSectionsResult
+
+type NextExamSectionData struct {
+	ExamId int `json:"exam_id"`
+} // @name NextExamSectionData
+
+type NextExamSectionResult struct {
+	ExamId        int                `json:"exam_id"`
+	AttemptNumber int                `json:"attempt_number"`
+	Sections      []*ExamSectionInfo `json:"sections"`
+
+	// CurrentSectionId is the section the user has moved to; it's null if
+	// there are no sections left.
+	CurrentSectionId *int `json:"current_section_id"`
+} // @name NextExamSectionResult
+
+type SetExamAdaptiveData struct {
+	ExamId     int  `json:"exam_id"`
+	IsAdaptive bool `json:"is_adaptive"`
+
+	// MaxItems is the maximum number of questions given in an attempt;
+	// null means no limit.
+	MaxItems *int `json:"max_items"`
+
+	// TargetStdError is the standard error of the ability estimate at
+	// which an attempt ends; null means no precision target.
+	TargetStdError *float64 `json:"target_se"`
+} // @name SetExamAdaptiveData
+
+type SetExamPracticeModeData struct {
+	ExamId     int  `json:"exam_id"`
+	IsPractice bool `json:"is_practice"`
+} // @name SetExamPracticeModeData
+
+type ExamPracticeModeResult struct {
+	ExamId     int  `json:"exam_id"`
+	IsPractice bool `json:"is_practice"`
+} // @name ExamPracticeModeResult
+
+type ExamAdaptiveSettingsResult struct {
+	ExamId         int      `json:"exam_id"`
+	IsAdaptive     bool     `json:"is_adaptive"`
+	MaxItems       *int     `json:"max_items"`
+	TargetStdError *float64 `json:"target_se"`
+
+	// QuestionsCount is the number of questions which can be given in the
+	// adaptive attempts (the ones with an answer key).
+	QuestionsCount int `json:"questions_count"`
+} // @name ExamAdaptiveSettingsResult
+
+type SetExamQuestionIrtData struct {
+	ExamId     int `json:"exam_id"`
+	QuestionId int `json:"question_id"`
+
+	// CorrectOption is the answer key of the question; it has to be one of
+	// the options of the question. Null removes the answer key.
+	CorrectOption *string `json:"correct_option"`
+
+	// Discrimination (a), Difficulty (b) and Guessing (c) are the
+	// parameters of the question in the three-parameter logistic model;
+	// the discrimination is 1 if not set.
+	Discrimination *float64 `json:"discrimination"`
+	Difficulty     float64  `json:"difficulty"`
+	Guessing       float64  `json:"guessing"`
+} // @name SetExamQuestionIrtData
+
+type ExamQuestionIrtResult struct {
+	ExamId         int     `json:"exam_id"`
+	QuestionId     int     `json:"question_id"`
+	CorrectOption  *string `json:"correct_option"`
+	Discrimination float64 `json:"discrimination"`
+	Difficulty     float64 `json:"difficulty"`
+	Guessing       float64 `json:"guessing"`
+} // @name ExamQuestionIrtResult
+
+type NextExamQuestionData struct {
+	ExamId     int  `json:"exam_id"`
+	RenderHtml bool `json:"render_html"`
+} // @name NextExamQuestionData
+
+type NextExamQuestionResult struct {
+	ExamId        int `json:"exam_id"`
+	AttemptNumber int `json:"attempt_number"`
+
+	// ItemIndex is the position of the question in the attempt (starting
+	// from 1); Question is null once the attempt has ended.
+	ItemIndex int               `json:"item_index"`
+	Question  *ExamQuestionInfo `json:"question"`
+
+	AnsweredCount int  `json:"answered_count"`
+	IsFinished    bool `json:"is_finished"`
+
+	// AbilityEstimate and AbilityStdError are the final estimate of the
+	// ability of the user; they are only set once the attempt has ended.
+	AbilityEstimate *float64 `json:"ability_estimate"`
+	AbilityStdError *float64 `json:"ability_std_error"`
+} // @name NextExamQuestionResult
+
+type ExamAttachmentInfo struct {
+	AttachmentId   int       `json:"attachment_id"`
+	AttachmentType string    `json:"attachment_type"`
+	ExamId         int       `json:"exam_id"`
+	QuestionId     int       `json:"question_id"`
+	OptionNumber   *int      `json:"option_number"`
+	AnsweredBy     *string   `json:"answered_by"`
+	AttemptNumber  *int      `json:"attempt_number"`
+	FileName       string    `json:"file_name"`
+	ContentType    string    `json:"content_type"`
+	FileSize       int64     `json:"file_size"`
+	CreatedAt      time.Time `json:"created_at"`
+} // @name ExamAttachmentInfo
+
+type UploadExamAttachmentResult struct {
+	Attachment *ExamAttachmentInfo `json:"attachment"`
+} // @name UploadExamAttachmentResult
+
+type GetExamAttachmentsResult struct {
+	ExamId     int `json:"exam_id"`
+	QuestionId int `json:"question_id"`
+
+	// Attachments contains the files attached to the question and to its
+	// options, alongside the files the user has uploaded as their answer
+	// in their latest attempt.
+	Attachments []*ExamAttachmentInfo `json:"attachments"`
+} // @name GetExamAttachmentsResult
+
+type DeleteExamAttachmentData struct {
+	AttachmentId int `json:"attachment_id"`
+} // @name DeleteExamAttachmentData
+
+type DeleteExamAttachmentResult struct {
+	AttachmentId int `json:"attachment_id"`
+	QuestionId   int `json:"question_id"`
+} // @name DeleteExamAttachmentResult
+
+type StartSimilarityCheckData struct {
+	ExamId     int `json:"exam_id"`
+	QuestionId int `json:"question_id"`
+
+	// ShingleSize is the number of the words in the compared shingles, and
+	// Threshold is the similarity (0-1) from which the pairs of answers are
+	// reported; the defaults are used if they are 0.
+	ShingleSize int     `json:"shingle_size"`
+	Threshold   float64 `json:"threshold"`
+} // @name StartSimilarityCheckData
+
+type SimilarityCheckInfo struct {
+	CheckId      int        `json:"check_id"`
+	ExamId       int        `json:"exam_id"`
+	QuestionId   int        `json:"question_id"`
+	RequestedBy  *string    `json:"requested_by"`
+	CheckStatus  string     `json:"check_status"`
+	ShingleSize  int        `json:"shingle_size"`
+	Threshold    float64    `json:"threshold"`
+	AnswersCount int        `json:"answers_count"`
+	MatchesCount int        `json:"matches_count"`
+	ErrorMessage *string    `json:"error_message"`
+	StartedAt    time.Time  `json:"started_at"`
+	FinishedAt   *time.Time `json:"finished_at"`
+} // @name SimilarityCheckInfo
+
+type SimilarityMatchInfo struct {
+	MatchId       int                      `json:"match_id"`
+	FirstUserId   string                   `json:"first_user_id"`
+	FirstAttempt  int                      `json:"first_attempt"`
+	SecondUserId  string                   `json:"second_user_id"`
+	SecondAttempt int                      `json:"second_attempt"`
+	Similarity    float64                  `json:"similarity"`
+	Containment   float64                  `json:"containment"`
+	Passages      []*SimilarityPassageInfo `json:"passages"`
+} // @name SimilarityMatchInfo
+
+type SimilarityPassageInfo struct {
+	// the offsets are byte offsets in the texts of the answers
+	FirstStart  int    `json:"first_start"`
+	FirstEnd    int    `json:"first_end"`
+	SecondStart int    `json:"second_start"`
+	SecondEnd   int    `json:"second_end"`
+	FirstText   string `json:"first_text"`
+	SecondText  string `json:"second_text"`
+} // @name SimilarityPassageInfo
+
+type StartSimilarityCheckResult struct {
+	Check *SimilarityCheckInfo `json:"check"`
+} // @name StartSimilarityCheckResult
+
+type GetSimilarityChecksResult struct {
+	ExamId int                    `json:"exam_id"`
+	Checks []*SimilarityCheckInfo `json:"checks"`
+} // @name GetSimilarityChecksResult
+
+type GetSimilarityCheckResult struct {
+	Check *SimilarityCheckInfo `json:"check"`
+
+	// Matches are the reported pairs of answers (the most similar first);
+	// they are only set once the check has completed.
+	Matches []*SimilarityMatchInfo `json:"matches"`
+} // @name GetSimilarityCheckResult
+
+type SetExamQuestionMetadataData struct {
+	ExamId     int `json:"exam_id"`
+	QuestionId int `json:"question_id"`
+
+	// Tags are stored in lowercase; DifficultyLevel is one of "easy",
+	// "medium" and "hard" (null removes it).
+	Tags               []string `json:"tags"`
+	DifficultyLevel    *string  `json:"difficulty_level"`
+	LearningObjectives []string `json:"learning_objectives"`
+	TopicId            *int     `json:"topic_id"`
+} // @name SetExamQuestionMetadataData
+
+type SetExamQuestionMetadataResult struct {
+	ExamId     int                       `json:"exam_id"`
+	QuestionId int                       `json:"question_id"`
+	Metadata   *ExamQuestionMetadataInfo `json:"metadata"`
+} // @name SetExamQuestionMetadataResult
+
+type SearchExamQuestionsData struct {
+	// SearchQuery is searched (full-text) in the title and the description
+	// of the questions.
+	SearchQuery string `json:"search_query"`
+
+	// Tags are the tags the questions must all have.
+	Tags              []string `json:"tags"`
+	DifficultyLevel   *string  `json:"difficulty_level"`
+	TopicId           *int     `json:"topic_id"`
+	LearningObjective string   `json:"learning_objective"`
+	Offset            int      `json:"offset"`
+	Limit             int      `json:"limit"`
+} // @name SearchExamQuestionsData
+
+type SearchedExamQuestionInfo struct {
+	QuestionId    int                       `json:"question_id"`
+	ExamId        int                       `json:"exam_id"`
+	ExamTitle     string                    `json:"exam_title"`
+	QuestionTitle string                    `json:"question_title"`
+	Description   *string                   `json:"description"`
+	ContentFormat string                    `json:"content_format"`
+	Metadata      *ExamQuestionMetadataInfo `json:"metadata"`
+	CreatedAt     time.Time                 `json:"created_at"`
+} // @name SearchedExamQuestionInfo
+
+type SearchExamQuestionsResult struct {
+	Questions []*SearchedExamQuestionInfo `json:"questions"`
+} // @name SearchExamQuestionsResult
+
+type SubmitExamForReviewData struct {
+	ExamId int `json:"exam_id"`
+} // @name SubmitExamForReviewData
+
+type ReviewExamData struct {
+	ExamId int `json:"exam_id"`
+
+	// Approve publishes the exam if true, otherwise the exam is sent back
+	// to draft; Comment is added to the review comments of the exam.
+	Approve bool    `json:"approve"`
+	Comment *string `json:"comment"`
+} // @name ReviewExamData
+
+type ExamReviewStatusResult struct {
+	ExamId       int                    `json:"exam_id"`
+	ReviewStatus string                 `json:"review_status"`
+	SubmittedAt  *time.Time             `json:"submitted_at"`
+	ReviewedBy   *string                `json:"reviewed_by"`
+	ReviewedAt   *time.Time             `json:"reviewed_at"`
+	Comment      *ExamReviewCommentInfo `json:"comment"`
+} // @name ExamReviewStatusResult
+
+type ExamReviewQueueEntry struct {
+	ExamId      int        `json:"exam_id"`
+	ExamTitle   string     `json:"exam_title"`
+	CreatedBy   string     `json:"created_by"`
+	ExamDate    time.Time  `json:"exam_date"`
+	SubmittedAt *time.Time `json:"submitted_at"`
+} // @name ExamReviewQueueEntry
+
+type GetExamReviewQueueResult struct {
+	Exams []*ExamReviewQueueEntry `json:"exams"`
+} // @name GetExamReviewQueueResult
+
+type AddExamReviewCommentData struct {
+	ExamId int `json:"exam_id"`
+
+	// QuestionId is the commented question; null means the comment is on
+	// the whole exam.
+	QuestionId  *int   `json:"question_id"`
+	CommentText string `json:"comment_text"`
+} // @name AddExamReviewCommentData
+
+type ResolveExamReviewCommentData struct {
+	CommentId int `json:"comment_id"`
+} // @name ResolveExamReviewCommentData
+
+type ExamReviewCommentInfo struct {
+	CommentId   int        `json:"comment_id"`
+	ExamId      int        `json:"exam_id"`
+	QuestionId  *int       `json:"question_id"`
+	AuthorId    string     `json:"author_id"`
+	CommentText string     `json:"comment_text"`
+	IsResolved  bool       `json:"is_resolved"`
+	ResolvedBy  *string    `json:"resolved_by"`
+	ResolvedAt  *time.Time `json:"resolved_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+} // @name ExamReviewCommentInfo
+
+type GetExamReviewCommentsResult struct {
+	ExamId       int                      `json:"exam_id"`
+	ReviewStatus string                   `json:"review_status"`
+	Comments     []*ExamReviewCommentInfo `json:"comments"`
+} // @name GetExamReviewCommentsResult
+
+type SetExamCollaboratorData struct {
+	ExamId int    `json:"exam_id"`
+	UserId string `json:"user_id"`
+
+	// CollaboratorRole is one of "editor", "grader" or "viewer".
+	CollaboratorRole string `json:"collaborator_role"`
+} // @name SetExamCollaboratorData
+
+type RemoveExamCollaboratorData struct {
+	ExamId int    `json:"exam_id"`
+	UserId string `json:"user_id"`
+} // @name RemoveExamCollaboratorData
+
+type RemoveExamCollaboratorResult struct {
+	ExamId int    `json:"exam_id"`
+	UserId string `json:"user_id"`
+} // @name RemoveExamCollaboratorResult
+
+type ExamCollaboratorInfo struct {
+	ExamId           int       `json:"exam_id"`
+	UserId           string    `json:"user_id"`
+	CollaboratorRole string    `json:"collaborator_role"`
+	AddedBy          *string   `json:"added_by"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+} // @name ExamCollaboratorInfo
+
+type GetExamCollaboratorsResult struct {
+	ExamId        int                     `json:"exam_id"`
+	CreatedBy     string                  `json:"created_by"`
+	CanManage     bool                    `json:"can_manage"`
+	Collaborators []*ExamCollaboratorInfo `json:"collaborators"`
+} // @name GetExamCollaboratorsResult
+
+type StartExamPreviewData struct {
+	ExamId int `json:"exam_id"`
+
+	// RenderHtml is true if the safe HTML variant of the content of the
+	// questions should be returned too.
+	RenderHtml bool `json:"render_html"`
+} // @name StartExamPreviewData
+
+type ExamPreviewInfo struct {
+	PreviewId  int        `json:"preview_id"`
+	ExamId     int        `json:"exam_id"`
+	UserId     string     `json:"user_id"`
+	StartedAt  time.Time  `json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at"`
+
+	// Deadline is the time the preview runs out of time, the same as for
+	// a student who has started their attempt at StartedAt.
+	Deadline       time.Time `json:"deadline"`
+	TimeLimit      int       `json:"time_limit"`
+	ElapsedSeconds int       `json:"elapsed_seconds"`
+	IsOvertime     bool      `json:"is_overtime"`
+} // @name ExamPreviewInfo
+
+type ExamPreviewResult struct {
+	Preview   *ExamPreviewInfo    `json:"preview"`
+	Questions []*ExamQuestionInfo `json:"questions"`
+	Sections  []*ExamSectionInfo  `json:"sections"`
+
+	// Breakdown is the computed score of the preview; it's only set once
+	// the preview has been finished.
+	Breakdown *PreviewScoreBreakdown `json:"breakdown"`
+} // @name ExamPreviewResult
+
+type PreviewScoreBreakdown struct {
+	QuestionsCount    int `json:"questions_count"`
+	CorrectCount      int `json:"correct_count"`
+	IncorrectCount    int `json:"incorrect_count"`
+	UnansweredCount   int `json:"unanswered_count"`
+	NeedsGradingCount int `json:"needs_grading_count"`
+
+	// AutoGradedCount is the number of the questions which have an answer
+	// key; ScorePercentage is the score of the preview out of these ones,
+	// and is null if none of the questions have an answer key.
+	AutoGradedCount   int      `json:"auto_graded_count"`
+	ScorePercentage   *float64 `json:"score_percentage"`
+	TotalSecondsTaken int      `json:"total_seconds_taken"`
+
+	Questions []*PreviewQuestionResult `json:"questions"`
+	Sections  []*PreviewSectionResult  `json:"sections"`
+} // @name PreviewScoreBreakdown
+
+type PreviewQuestionResult struct {
+	QuestionId    int     `json:"question_id"`
+	QuestionTitle string  `json:"question_title"`
+	SectionId     *int    `json:"section_id"`
+	ChosenOption  *string `json:"chosen_option"`
+	AnswerText    *string `json:"answer"`
+	CorrectOption *string `json:"correct_option"`
+	Explanation   *string `json:"explanation"`
+	SecondsTaken  int     `json:"seconds_taken"`
+
+	// Status is one of correct, incorrect, unanswered or needs_grading.
+	Status string `json:"status"`
+} // @name PreviewQuestionResult
+
+type PreviewSectionResult struct {
+	SectionId         int    `json:"section_id"`
+	SectionTitle      string `json:"section_title"`
+	Duration          *int   `json:"duration"`
+	QuestionsCount    int    `json:"questions_count"`
+	CorrectCount      int    `json:"correct_count"`
+	IncorrectCount    int    `json:"incorrect_count"`
+	UnansweredCount   int    `json:"unanswered_count"`
+	NeedsGradingCount int    `json:"needs_grading_count"`
+	SecondsTaken      int    `json:"seconds_taken"`
+
+	// IsOvertime is true if answering the questions of the section took
+	// longer than its time limit.
+	IsOvertime bool `json:"is_overtime"`
+} // @name PreviewSectionResult
+
+type AnswerPreviewQuestionData struct {
+	PreviewId    int     `json:"preview_id"`
+	QuestionId   int     `json:"question_id"`
+	ChosenOption *string `json:"chosen_option"`
+	SecondsTaken int     `json:"seconds_taken"`
+	AnswerText   *string `json:"answer_text"`
+} // @name AnswerPreviewQuestionData
+
+type AnswerPreviewQuestionResult struct {
+	PreviewId  int       `json:"preview_id"`
+	QuestionId int       `json:"question_id"`
+	AnsweredAt time.Time `json:"answered_at"`
+
+	// Feedback is only set in the previews of the practice exams, the same
+	// as it would be for a student.
+	Feedback *AnswerFeedbackInfo `json:"feedback"`
+} // @name AnswerPreviewQuestionResult
+
+type FinishExamPreviewData struct {
+	PreviewId int `json:"preview_id"`
+} // @name FinishExamPreviewData
+
+type DiscardExamPreviewData struct {
+	PreviewId int `json:"preview_id"`
+} // @name DiscardExamPreviewData
+
+type DiscardExamPreviewResult struct {
+	PreviewId int `json:"preview_id"`
+	ExamId    int `json:"exam_id"`
+} // @name DiscardExamPreviewResult
+
+type GetExamPreviewsResult struct {
+	ExamId   int                `json:"exam_id"`
+	Previews []*ExamPreviewInfo `json:"previews"`
+} // @name GetExamPreviewsResult
+
+type SetAttemptReviewPolicyData struct {
+	ExamId int `json:"exam_id"`
+
+	// AttemptReviewPolicy is one of never (the default), after_close and
+	// after_release.
+	AttemptReviewPolicy string `json:"attempt_review_policy"`
+} // @name SetAttemptReviewPolicyData
+
+type AttemptReviewPolicyResult struct {
+	ExamId              int    `json:"exam_id"`
+	AttemptReviewPolicy string `json:"attempt_review_policy"`
+} // @name AttemptReviewPolicyResult
+
+type ReleaseExamResultsData struct {
+	ExamId int `json:"exam_id"`
+
+	// IsReleased is false to withdraw the results released before.
+	IsReleased bool `json:"is_released"`
+} // @name ReleaseExamResultsData
+
+type ReleaseExamResultsResult struct {
+	ExamId            int        `json:"exam_id"`
+	IsReleased        bool       `json:"is_released"`
+	ResultsReleasedBy *string    `json:"results_released_by"`
+	ResultsReleasedAt *time.Time `json:"results_released_at"`
+} // @name ReleaseExamResultsResult
+
+type GradeAnswerData struct {
+	ExamId        int    `json:"exam_id"`
+	QuestionId    int    `json:"question_id"`
+	UserId        string `json:"user_id"`
+	AttemptNumber int    `json:"attempt_number"`
+
+	// PointsEarned is the number of points given to the answer, between
+	// 0 and the points of the question; if it's not set, the answer is
+	// graded by the answer key of the question (if any).
+	PointsEarned  *float64 `json:"points_earned"`
+	GraderComment *string  `json:"grader_comment"`
+} // @name GradeAnswerData
+
+type GradeAnswerResult struct {
+	ExamId        int        `json:"exam_id"`
+	QuestionId    int        `json:"question_id"`
+	UserId        string     `json:"user_id"`
+	AttemptNumber int        `json:"attempt_number"`
+	Points        float64    `json:"points"`
+	PointsEarned  *float64   `json:"points_earned"`
+	IsGraded      bool       `json:"is_graded"`
+	GraderComment *string    `json:"grader_comment"`
+	GradedBy      *string    `json:"graded_by"`
+	GradedAt      *time.Time `json:"graded_at"`
+} // @name GradeAnswerResult
+
+type GetAttemptReviewResult struct {
+	ExamId              int              `json:"exam_id"`
+	ExamTitle           string           `json:"exam_title"`
+	UserId              string           `json:"user_id"`
+	Attempt             *ExamAttemptInfo `json:"attempt"`
+	AttemptReviewPolicy string           `json:"attempt_review_policy"`
+	ResultsReleasedAt   *time.Time       `json:"results_released_at"`
+
+	// TotalPoints is the sum of the points of the questions; PointsEarned
+	// is the sum of the points earned by the answers which are graded.
+	// PendingCount is the number of answers still waiting to be graded.
+	TotalPoints  float64 `json:"total_points"`
+	PointsEarned float64 `json:"points_earned"`
+	PendingCount int     `json:"pending_count"`
+
+	Questions []*AttemptReviewQuestionInfo `json:"questions"`
+} // @name GetAttemptReviewResult
+
+type SetExamGradeCategoryData struct {
+	ExamId int `json:"exam_id"`
+
+	// CategoryId is the grade category of the course of the exam; nil
+	// means the exam is not counted in the course grade.
+	CategoryId *int `json:"category_id"`
+} // @name SetExamGradeCategoryData
+
+type ExamGradeCategoryResult struct {
+	ExamId          int  `json:"exam_id"`
+	CourseId        int  `json:"course_id"`
+	GradeCategoryId *int `json:"grade_category_id"`
+} // @name ExamGradeCategoryResult
+
+type AttemptReviewQuestionInfo struct {
+	QuestionId    int                   `json:"question_id"`
+	QuestionTitle string                `json:"question_title"`
+	Description   *string               `json:"description"`
+	Option1       *string               `json:"option1"`
+	Option2       *string               `json:"option2"`
+	Option3       *string               `json:"option3"`
+	Option4       *string               `json:"option4"`
+	SectionId     *int                  `json:"section_id"`
+	QuestionOrder int                   `json:"question_order"`
+	ContentFormat string                `json:"content_format"`
+	Attachments   []*ExamAttachmentInfo `json:"attachments"`
+
+	// Rendered is the safe HTML variant of the content (and of the
+	// explanation); it's only set if it has been requested.
+	Rendered *RenderedQuestionContent `json:"rendered"`
+
+	// UserAnswer is nil if the question has not been answered.
+	UserAnswer *AnsweredQuestionInfo `json:"user_answer"`
+
+	// CorrectOption is nil if the question has no answer key; IsCorrect
+	// is nil if the question has no answer key or has not been answered.
+	CorrectOption *string `json:"correct_option"`
+	IsCorrect     *bool   `json:"is_correct"`
+
+	// Points is the maximum number of points of the question; PointsEarned
+	// is nil if the answer is still waiting to be graded.
+	Points        float64    `json:"points"`
+	PointsEarned  *float64   `json:"points_earned"`
+	IsGraded      bool       `json:"is_graded"`
+	GraderComment *string    `json:"grader_comment"`
+	GradedAt      *time.Time `json:"graded_at"`
+	Explanation   *string    `json:"explanation"`
+} // @name AttemptReviewQuestionInfo
+
+type accessCodeGuessEntry struct {
+	LastTryAt time.Time
+	TryCount  int
+	mut       *sync.Mutex
+}
+
+type attemptClientStatus int
+
+type questionAnswerStatus int
+
+type contentField struct {
+	name  string
+	value *string
+}
+
+// resultReportData holds everything the result report of a participant
+// of an exam is made of.
+type resultReportData struct {
+	examInfo   *database.ExamInfo
+	courseInfo *database.CourseInfo
+	student    *database.UserInfo
+	givenExam  *database.GivenExam
+	review     *GetAttemptReviewResult
+
+	// scorePercentage is nil if the exam has not been scored yet (or its
+	// score cannot be converted to a percentage).
+	scorePercentage *float64
+
+	// courseExams are the graded exams of the course, and courseScores are
+	// the scores of the participant in them.
+	courseExams  []*database.ExamInfo
+	courseScores []*database.CourseGradebookScore
+}
@@ -1 +1,35 @@
 package examHandlers
+
+import (
+	"ExamSphere/src/core/utils/exportUtils"
+	"time"
+
+	"github.com/ALiwoto/ssg/ssg"
+)
+
+var (
+	accessCodeGuessesMap = func() *ssg.SafeEMap[string, accessCodeGuessEntry] {
+		m := ssg.NewSafeEMap[string, accessCodeGuessEntry]()
+		m.SetExpiration(time.Hour)
+		m.SetInterval(time.Hour)
+		m.EnableChecking()
+
+		return m
+	}()
+)
+
+var (
+	// participantsExportColumns are the columns which can be selected
+	// while exporting the participants of an exam.
+	participantsExportColumns = []exportUtils.Column{
+		{Key: "user_id", Title: "User ID"},
+		{Key: "full_name", Title: "Full name"},
+		{Key: "email", Title: "Email"},
+		{Key: "joined_at", Title: "Joined at"},
+		{Key: "attempts", Title: "Attempts"},
+		{Key: "last_finished_at", Title: "Last finished at"},
+		{Key: "final_score", Title: "Final score"},
+		{Key: "score_percentage", Title: "Score percentage"},
+		{Key: "scored_by", Title: "Scored by"},
+	}
+)
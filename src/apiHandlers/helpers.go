@@ -579,3 +579,667 @@ func SendErrBodyTooLong(c *fiber.Ctx) error {
 		Origin:    c.Path(),
 	})
 }
+
+func SendErrInvalidDeviceId(c *fiber.Ctx, deviceId string) error {
+	return SendError(fiber.StatusBadRequest, c, &EndpointError{
+		ErrorCode: ErrCodeInvalidDeviceId,
+		Message:   fmt.Sprintf(ErrInvalidDeviceId, deviceId),
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrForeignAttemptClient(c *fiber.Ctx) error {
+	return SendError(fiber.StatusForbidden, c, &EndpointError{
+		ErrorCode: ErrCodeForeignAttemptClient,
+		Message:   ErrForeignAttemptClient,
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrAttemptBindingNotFound(c *fiber.Ctx) error {
+	return SendError(fiber.StatusNotFound, c, &EndpointError{
+		ErrorCode: ErrCodeAttemptBindingNotFound,
+		Message:   ErrAttemptBindingNotFound,
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrInvalidAccessCode(c *fiber.Ctx) error {
+	return SendError(fiber.StatusBadRequest, c, &EndpointError{
+		ErrorCode: ErrCodeInvalidAccessCode,
+		Message:   ErrInvalidAccessCode,
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrTooManyAccessCodeAttempts(c *fiber.Ctx) error {
+	return SendError(fiber.StatusBadRequest, c, &EndpointError{
+		ErrorCode: ErrCodeTooManyAccessCodeAttempts,
+		Message:   ErrTooManyAccessCodeAttempts,
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrAttemptNotStarted(c *fiber.Ctx) error {
+	return SendError(fiber.StatusBadRequest, c, &EndpointError{
+		ErrorCode: ErrCodeAttemptNotStarted,
+		Message:   ErrAttemptNotStarted,
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrInvalidAccessCodeType(c *fiber.Ctx, codeType string) error {
+	return SendError(fiber.StatusBadRequest, c, &EndpointError{
+		ErrorCode: ErrCodeInvalidAccessCodeType,
+		Message:   fmt.Sprintf(ErrInvalidAccessCodeType, codeType),
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrInvalidPrerequisiteType(c *fiber.Ctx, prerequisiteType string) error {
+	return SendError(fiber.StatusBadRequest, c, &EndpointError{
+		ErrorCode: ErrCodeInvalidPrerequisiteType,
+		Message:   fmt.Sprintf(ErrInvalidPrerequisiteType, prerequisiteType),
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrPrerequisiteNotFound(c *fiber.Ctx) error {
+	return SendError(fiber.StatusNotFound, c, &EndpointError{
+		ErrorCode: ErrCodePrerequisiteNotFound,
+		Message:   ErrPrerequisiteNotFound,
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrInvalidPrerequisite(c *fiber.Ctx, reason string) error {
+	return SendError(fiber.StatusBadRequest, c, &EndpointError{
+		ErrorCode: ErrCodeInvalidPrerequisite,
+		Message:   fmt.Sprintf(ErrInvalidPrerequisite, reason),
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrPrerequisitesNotMet(c *fiber.Ctx) error {
+	return SendError(fiber.StatusForbidden, c, &EndpointError{
+		ErrorCode: ErrCodePrerequisitesNotMet,
+		Message:   ErrPrerequisitesNotMet,
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrAttemptFinished(c *fiber.Ctx) error {
+	return SendError(fiber.StatusForbidden, c, &EndpointError{
+		ErrorCode: ErrCodeAttemptFinished,
+		Message:   ErrAttemptFinished,
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrNoAttemptsLeft(c *fiber.Ctx) error {
+	return SendError(fiber.StatusForbidden, c, &EndpointError{
+		ErrorCode: ErrCodeNoAttemptsLeft,
+		Message:   ErrNoAttemptsLeft,
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrRetakeCooldown(c *fiber.Ctx, seconds int) error {
+	return SendError(fiber.StatusTooManyRequests, c, &EndpointError{
+		ErrorCode: ErrCodeRetakeCooldown,
+		Message:   fmt.Sprintf(ErrRetakeCooldown, seconds),
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrAttemptNotFound(c *fiber.Ctx) error {
+	return SendError(fiber.StatusNotFound, c, &EndpointError{
+		ErrorCode: ErrCodeAttemptNotFound,
+		Message:   ErrAttemptNotFound,
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrInvalidGradingPolicy(c *fiber.Ctx, gradingPolicy string) error {
+	return SendError(fiber.StatusBadRequest, c, &EndpointError{
+		ErrorCode: ErrCodeInvalidGradingPolicy,
+		Message:   fmt.Sprintf(ErrInvalidGradingPolicy, gradingPolicy),
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrAccommodationNotFound(c *fiber.Ctx) error {
+	return SendError(fiber.StatusNotFound, c, &EndpointError{
+		ErrorCode: ErrCodeAccommodationNotFound,
+		Message:   ErrAccommodationNotFound,
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrInvalidAccommodation(c *fiber.Ctx, reason string) error {
+	return SendError(fiber.StatusBadRequest, c, &EndpointError{
+		ErrorCode: ErrCodeInvalidAccommodation,
+		Message:   fmt.Sprintf(ErrInvalidAccommodation, reason),
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrLateStartNotAllowed(c *fiber.Ctx) error {
+	return SendError(fiber.StatusForbidden, c, &EndpointError{
+		ErrorCode: ErrCodeLateStartNotAllowed,
+		Message:   ErrLateStartNotAllowed,
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrInvalidAvailability(c *fiber.Ctx, reason string) error {
+	return SendError(fiber.StatusBadRequest, c, &EndpointError{
+		ErrorCode: ErrCodeInvalidAvailability,
+		Message:   fmt.Sprintf(ErrInvalidAvailability, reason),
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrInvalidDeadlinePolicy(c *fiber.Ctx, deadlinePolicy string) error {
+	return SendError(fiber.StatusBadRequest, c, &EndpointError{
+		ErrorCode: ErrCodeInvalidDeadlinePolicy,
+		Message:   fmt.Sprintf(ErrInvalidDeadlinePolicy, deadlinePolicy),
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrExamSeriesNotFound(c *fiber.Ctx) error {
+	return SendError(fiber.StatusNotFound, c, &EndpointError{
+		ErrorCode: ErrCodeExamSeriesNotFound,
+		Message:   ErrExamSeriesNotFound,
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrInvalidRecurrenceRule(c *fiber.Ctx, reason string) error {
+	return SendError(fiber.StatusBadRequest, c, &EndpointError{
+		ErrorCode: ErrCodeInvalidRecurrenceRule,
+		Message:   fmt.Sprintf(ErrInvalidRecurrenceRule, reason),
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrInvalidSeriesOccurrence(c *fiber.Ctx) error {
+	return SendError(fiber.StatusBadRequest, c, &EndpointError{
+		ErrorCode: ErrCodeInvalidSeriesOccurrence,
+		Message:   ErrInvalidSeriesOccurrence,
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrOccurrenceHasParticipants(c *fiber.Ctx) error {
+	return SendError(fiber.StatusConflict, c, &EndpointError{
+		ErrorCode: ErrCodeOccurrenceHasParticipants,
+		Message:   ErrOccurrenceHasParticipants,
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrInvalidPrice(c *fiber.Ctx, price string) error {
+	return SendError(fiber.StatusBadRequest, c, &EndpointError{
+		ErrorCode: ErrCodeInvalidPrice,
+		Message:   fmt.Sprintf(ErrInvalidPrice, price),
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrInsufficientBalance(c *fiber.Ctx) error {
+	return SendError(fiber.StatusPaymentRequired, c, &EndpointError{
+		ErrorCode: ErrCodeInsufficientBalance,
+		Message:   ErrInsufficientBalance,
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrWalletTransactionNotFound(c *fiber.Ctx) error {
+	return SendError(fiber.StatusNotFound, c, &EndpointError{
+		ErrorCode: ErrCodeWalletTransactionNotFound,
+		Message:   ErrWalletTransactionNotFound,
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrRefundExceedsAmount(c *fiber.Ctx) error {
+	return SendError(fiber.StatusBadRequest, c, &EndpointError{
+		ErrorCode: ErrCodeRefundExceedsAmount,
+		Message:   ErrRefundExceedsAmount,
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrPaymentFailed(c *fiber.Ctx, reason string) error {
+	return SendError(fiber.StatusBadGateway, c, &EndpointError{
+		ErrorCode: ErrCodePaymentFailed,
+		Message:   fmt.Sprintf(ErrPaymentFailed, reason),
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrInvalidAmount(c *fiber.Ctx, amount string) error {
+	return SendError(fiber.StatusBadRequest, c, &EndpointError{
+		ErrorCode: ErrCodeInvalidAmount,
+		Message:   fmt.Sprintf(ErrInvalidAmount, amount),
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrTransactionNotRefundable(c *fiber.Ctx) error {
+	return SendError(fiber.StatusBadRequest, c, &EndpointError{
+		ErrorCode: ErrCodeTransactionNotRefundable,
+		Message:   ErrTransactionNotRefundable,
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrExamCouponNotFound(c *fiber.Ctx) error {
+	return SendError(fiber.StatusNotFound, c, &EndpointError{
+		ErrorCode: ErrCodeExamCouponNotFound,
+		Message:   ErrExamCouponNotFound,
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrExamCouponNotUsable(c *fiber.Ctx) error {
+	return SendError(fiber.StatusBadRequest, c, &EndpointError{
+		ErrorCode: ErrCodeExamCouponNotUsable,
+		Message:   ErrExamCouponNotUsable,
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrInvalidCouponCode(c *fiber.Ctx, couponCode string) error {
+	return SendError(fiber.StatusBadRequest, c, &EndpointError{
+		ErrorCode: ErrCodeInvalidCouponCode,
+		Message:   fmt.Sprintf(ErrInvalidCouponCode, couponCode),
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrInvalidDiscount(c *fiber.Ctx, reason string) error {
+	return SendError(fiber.StatusBadRequest, c, &EndpointError{
+		ErrorCode: ErrCodeInvalidDiscount,
+		Message:   fmt.Sprintf(ErrInvalidDiscount, reason),
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrCouponCodeExists(c *fiber.Ctx) error {
+	return SendError(fiber.StatusConflict, c, &EndpointError{
+		ErrorCode: ErrCodeCouponCodeExists,
+		Message:   ErrCouponCodeExists,
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrExamInvitationNotFound(c *fiber.Ctx) error {
+	return SendError(fiber.StatusNotFound, c, &EndpointError{
+		ErrorCode: ErrCodeExamInvitationNotFound,
+		Message:   ErrExamInvitationNotFound,
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrInvalidInvitationToken(c *fiber.Ctx) error {
+	return SendError(fiber.StatusForbidden, c, &EndpointError{
+		ErrorCode: ErrCodeInvalidInvitationToken,
+		Message:   ErrInvalidInvitationToken,
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrInvitationNotPending(c *fiber.Ctx, status string) error {
+	return SendError(fiber.StatusConflict, c, &EndpointError{
+		ErrorCode: ErrCodeInvitationNotPending,
+		Message:   fmt.Sprintf(ErrInvitationNotPending, status),
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrExamAlreadyStarted(c *fiber.Ctx) error {
+	return SendError(fiber.StatusConflict, c, &EndpointError{
+		ErrorCode: ErrCodeExamAlreadyStarted,
+		Message:   ErrExamAlreadyStarted,
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrExamSectionNotFound(c *fiber.Ctx) error {
+	return SendError(fiber.StatusNotFound, c, &EndpointError{
+		ErrorCode: ErrCodeExamSectionNotFound,
+		Message:   ErrExamSectionNotFound,
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrExamSectionClosed(c *fiber.Ctx) error {
+	return SendError(fiber.StatusForbidden, c, &EndpointError{
+		ErrorCode: ErrCodeExamSectionClosed,
+		Message:   ErrExamSectionClosed,
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrNoOngoingSection(c *fiber.Ctx) error {
+	return SendError(fiber.StatusConflict, c, &EndpointError{
+		ErrorCode: ErrCodeNoOngoingSection,
+		Message:   ErrNoOngoingSection,
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrExamNotAdaptive(c *fiber.Ctx) error {
+	return SendError(fiber.StatusBadRequest, c, &EndpointError{
+		ErrorCode: ErrCodeExamNotAdaptive,
+		Message:   ErrExamNotAdaptive,
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrAdaptiveExamQuestions(c *fiber.Ctx) error {
+	return SendError(fiber.StatusForbidden, c, &EndpointError{
+		ErrorCode: ErrCodeAdaptiveExamQuestions,
+		Message:   ErrAdaptiveExamQuestions,
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrQuestionNotServed(c *fiber.Ctx) error {
+	return SendError(fiber.StatusConflict, c, &EndpointError{
+		ErrorCode: ErrCodeQuestionNotServed,
+		Message:   ErrQuestionNotServed,
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrNoAdaptiveQuestions(c *fiber.Ctx) error {
+	return SendError(fiber.StatusConflict, c, &EndpointError{
+		ErrorCode: ErrCodeNoAdaptiveQuestions,
+		Message:   ErrNoAdaptiveQuestions,
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrAttachmentNotFound(c *fiber.Ctx) error {
+	return SendError(fiber.StatusNotFound, c, &EndpointError{
+		ErrorCode: ErrCodeAttachmentNotFound,
+		Message:   ErrAttachmentNotFound,
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrUnsupportedFileType(c *fiber.Ctx) error {
+	return SendError(fiber.StatusUnsupportedMediaType, c, &EndpointError{
+		ErrorCode: ErrCodeUnsupportedFileType,
+		Message:   ErrUnsupportedFileType,
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrFileTooLarge(c *fiber.Ctx) error {
+	return SendError(fiber.StatusRequestEntityTooLarge, c, &EndpointError{
+		ErrorCode: ErrCodeFileTooLarge,
+		Message:   ErrFileTooLarge,
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrStorageNotAvailable(c *fiber.Ctx) error {
+	return SendError(fiber.StatusServiceUnavailable, c, &EndpointError{
+		ErrorCode: ErrCodeStorageNotAvailable,
+		Message:   ErrStorageNotAvailable,
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrTooManyAttachments(c *fiber.Ctx) error {
+	return SendError(fiber.StatusConflict, c, &EndpointError{
+		ErrorCode: ErrCodeTooManyAttachments,
+		Message:   ErrTooManyAttachments,
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrInvalidContentFormat(c *fiber.Ctx, format string) error {
+	return SendError(fiber.StatusBadRequest, c, &EndpointError{
+		ErrorCode: ErrCodeInvalidContentFormat,
+		Message:   fmt.Sprintf(ErrInvalidContentFormat, format),
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrInvalidContent(c *fiber.Ctx, details string) error {
+	return SendError(fiber.StatusBadRequest, c, &EndpointError{
+		ErrorCode: ErrCodeInvalidContent,
+		Message:   fmt.Sprintf(ErrInvalidContent, details),
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrSimilarityCheckNotFound(c *fiber.Ctx) error {
+	return SendError(fiber.StatusNotFound, c, &EndpointError{
+		ErrorCode: ErrCodeSimilarityCheckNotFound,
+		Message:   ErrSimilarityCheckNotFound,
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrSimilarityCheckRunning(c *fiber.Ctx) error {
+	return SendError(fiber.StatusConflict, c, &EndpointError{
+		ErrorCode: ErrCodeSimilarityCheckRunning,
+		Message:   ErrSimilarityCheckRunning,
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrInvalidSimilarityOptions(c *fiber.Ctx, details string) error {
+	return SendError(fiber.StatusBadRequest, c, &EndpointError{
+		ErrorCode: ErrCodeInvalidSimilarityOptions,
+		Message:   fmt.Sprintf(ErrInvalidSimilarityOptions, details),
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrInvalidQuestionMetadata(c *fiber.Ctx, details string) error {
+	return SendError(fiber.StatusBadRequest, c, &EndpointError{
+		ErrorCode: ErrCodeInvalidQuestionMetadata,
+		Message:   fmt.Sprintf(ErrInvalidQuestionMetadata, details),
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrExamNotPublished(c *fiber.Ctx) error {
+	return SendError(fiber.StatusForbidden, c, &EndpointError{
+		ErrorCode: ErrCodeExamNotPublished,
+		Message:   ErrExamNotPublished,
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrInvalidReviewStatus(c *fiber.Ctx, status string) error {
+	return SendError(fiber.StatusConflict, c, &EndpointError{
+		ErrorCode: ErrCodeInvalidReviewStatus,
+		Message:   fmt.Sprintf(ErrInvalidReviewStatus, status),
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrReviewCommentNotFound(c *fiber.Ctx) error {
+	return SendError(fiber.StatusNotFound, c, &EndpointError{
+		ErrorCode: ErrCodeReviewCommentNotFound,
+		Message:   ErrReviewCommentNotFound,
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrExamCollaboratorNotFound(c *fiber.Ctx) error {
+	return SendError(fiber.StatusNotFound, c, &EndpointError{
+		ErrorCode: ErrCodeExamCollaboratorNotFound,
+		Message:   ErrExamCollaboratorNotFound,
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrInvalidCollaboratorRole(c *fiber.Ctx, role string) error {
+	return SendError(fiber.StatusBadRequest, c, &EndpointError{
+		ErrorCode: ErrCodeInvalidCollaboratorRole,
+		Message:   fmt.Sprintf(ErrInvalidCollaboratorRole, role),
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrTooManyCollaborators(c *fiber.Ctx) error {
+	return SendError(fiber.StatusConflict, c, &EndpointError{
+		ErrorCode: ErrCodeTooManyCollaborators,
+		Message:   ErrTooManyCollaborators,
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrPreviewAttemptNotFound(c *fiber.Ctx) error {
+	return SendError(fiber.StatusNotFound, c, &EndpointError{
+		ErrorCode: ErrCodePreviewAttemptNotFound,
+		Message:   ErrPreviewAttemptNotFound,
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrPreviewAttemptFinished(c *fiber.Ctx) error {
+	return SendError(fiber.StatusConflict, c, &EndpointError{
+		ErrorCode: ErrCodePreviewAttemptFinished,
+		Message:   ErrPreviewAttemptFinished,
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrTooManyPreviewAttempts(c *fiber.Ctx) error {
+	return SendError(fiber.StatusConflict, c, &EndpointError{
+		ErrorCode: ErrCodeTooManyPreviewAttempts,
+		Message:   ErrTooManyPreviewAttempts,
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrPreviewTimeUp(c *fiber.Ctx) error {
+	return SendError(fiber.StatusConflict, c, &EndpointError{
+		ErrorCode: ErrCodePreviewTimeUp,
+		Message:   ErrPreviewTimeUp,
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrPracticeExamNotGraded(c *fiber.Ctx) error {
+	return SendError(fiber.StatusConflict, c, &EndpointError{
+		ErrorCode: ErrCodePracticeExamNotGraded,
+		Message:   ErrPracticeExamNotGraded,
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrAttemptReviewNotAvailable(c *fiber.Ctx) error {
+	return SendError(fiber.StatusForbidden, c, &EndpointError{
+		ErrorCode: ErrCodeAttemptReviewNotAvailable,
+		Message:   ErrAttemptReviewNotAvailable,
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrInvalidAttemptReviewPolicy(c *fiber.Ctx, policy string) error {
+	return SendError(fiber.StatusBadRequest, c, &EndpointError{
+		ErrorCode: ErrCodeInvalidAttemptReviewPolicy,
+		Message:   fmt.Sprintf(ErrInvalidAttemptReviewPolicy, policy),
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrInvalidPoints(c *fiber.Ctx) error {
+	return SendError(fiber.StatusBadRequest, c, &EndpointError{
+		ErrorCode: ErrCodeInvalidPoints,
+		Message:   ErrInvalidPoints,
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrGivenAnswerNotFound(c *fiber.Ctx) error {
+	return SendError(fiber.StatusNotFound, c, &EndpointError{
+		ErrorCode: ErrCodeGivenAnswerNotFound,
+		Message:   ErrGivenAnswerNotFound,
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrGradeCategoryNotFound(c *fiber.Ctx) error {
+	return SendError(fiber.StatusNotFound, c, &EndpointError{
+		ErrorCode: ErrCodeGradeCategoryNotFound,
+		Message:   ErrGradeCategoryNotFound,
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrGradeCategoryAlreadyExists(c *fiber.Ctx) error {
+	return SendError(fiber.StatusConflict, c, &EndpointError{
+		ErrorCode: ErrCodeGradeCategoryAlreadyExists,
+		Message:   ErrGradeCategoryAlreadyExists,
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrTooManyGradeCategories(c *fiber.Ctx) error {
+	return SendError(fiber.StatusConflict, c, &EndpointError{
+		ErrorCode: ErrCodeTooManyGradeCategories,
+		Message:   ErrTooManyGradeCategories,
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrInvalidGradeCategoryWeight(c *fiber.Ctx) error {
+	return SendError(fiber.StatusBadRequest, c, &EndpointError{
+		ErrorCode: ErrCodeInvalidGradeCategoryWeight,
+		Message:   ErrInvalidGradeCategoryWeight,
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrInvalidDropLowestCount(c *fiber.Ctx) error {
+	return SendError(fiber.StatusBadRequest, c, &EndpointError{
+		ErrorCode: ErrCodeInvalidDropLowestCount,
+		Message:   ErrInvalidDropLowestCount,
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrGradeCategoryNameTooLong(c *fiber.Ctx, maxLen int) error {
+	return SendError(fiber.StatusBadRequest, c, &EndpointError{
+		ErrorCode: ErrCodeGradeCategoryNameTooLong,
+		Message:   fmt.Sprintf(ErrGradeCategoryNameTooLong, maxLen),
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrInvalidExportFormat(c *fiber.Ctx, format string) error {
+	return SendError(fiber.StatusBadRequest, c, &EndpointError{
+		ErrorCode: ErrCodeInvalidExportFormat,
+		Message:   fmt.Sprintf(ErrInvalidExportFormat, format),
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrUnknownExportColumn(c *fiber.Ctx, column string) error {
+	return SendError(fiber.StatusBadRequest, c, &EndpointError{
+		ErrorCode: ErrCodeUnknownExportColumn,
+		Message:   fmt.Sprintf(ErrUnknownExportColumn, column),
+		Origin:    c.Path(),
+	})
+}
+
+func SendErrCurrencyMismatch(c *fiber.Ctx) error {
+	return SendError(fiber.StatusBadRequest, c, &EndpointError{
+		ErrorCode: ErrCodeCurrencyMismatch,
+		Message:   ErrCurrencyMismatch,
+		Origin:    c.Path(),
+	})
+}
@@ -57,9 +57,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/course/courseParticipants": {
+        "/api/v1/coupon/check": {
             "post": {
-                "description": "Allows a user to get all participants of a course.",
+                "description": "Allows the user to check whether they can use a coupon for an exam, and the price they would pay with it.",
                 "consumes": [
                     "application/json"
                 ],
@@ -67,10 +67,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Course"
+                    "Coupon"
                 ],
-                "summary": "Get course participants",
-                "operationId": "getCourseParticipantsV1",
+                "summary": "Check a coupon for an exam",
+                "operationId": "checkCouponV1",
                 "parameters": [
                     {
                         "type": "string",
@@ -80,12 +80,12 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Data needed to get course participants",
+                        "description": "Data needed to check a coupon",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/GetCourseParticipantsData"
+                            "$ref": "#/definitions/CheckCouponData"
                         }
                     }
                 ],
@@ -101,7 +101,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "result": {
-                                            "$ref": "#/definitions/GetCourseParticipantsResult"
+                                            "$ref": "#/definitions/CheckCouponResult"
                                         }
                                     }
                                 }
@@ -111,9 +111,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/course/create": {
+        "/api/v1/coupon/create": {
             "post": {
-                "description": "Allows a user to create a new course.",
+                "description": "Allows the user to create a percentage or fixed discount coupon for an exam, a course or a topic. Teachers can only create coupons for their own exams and courses.",
                 "consumes": [
                     "application/json"
                 ],
@@ -121,10 +121,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Course"
+                    "Coupon"
                 ],
-                "summary": "Create a new course",
-                "operationId": "createCourseV1",
+                "summary": "Create a discount coupon",
+                "operationId": "createCouponV1",
                 "parameters": [
                     {
                         "type": "string",
@@ -134,12 +134,12 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Data needed to create a new course",
+                        "description": "Data needed to create a coupon",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/CreateCourseData"
+                            "$ref": "#/definitions/CreateCouponData"
                         }
                     }
                 ],
@@ -155,7 +155,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "result": {
-                                            "$ref": "#/definitions/CreateCourseResult"
+                                            "$ref": "#/definitions/CouponInfo"
                                         }
                                     }
                                 }
@@ -165,9 +165,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/course/createdCourses": {
+        "/api/v1/coupon/deactivate": {
             "post": {
-                "description": "Allows a user to get all courses created by a user.",
+                "description": "Allows the creator of a coupon (and admins) to make it unusable from now on.",
                 "consumes": [
                     "application/json"
                 ],
@@ -175,10 +175,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Course"
+                    "Coupon"
                 ],
-                "summary": "Get created courses",
-                "operationId": "getCreatedCoursesV1",
+                "summary": "Deactivate a coupon",
+                "operationId": "deactivateCouponV1",
                 "parameters": [
                     {
                         "type": "string",
@@ -188,12 +188,12 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Data needed to get created courses",
+                        "description": "Data needed to deactivate a coupon",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/GetCreatedCoursesData"
+                            "$ref": "#/definitions/DeactivateCouponData"
                         }
                     }
                 ],
@@ -209,7 +209,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "result": {
-                                            "$ref": "#/definitions/GetCreatedCoursesResult"
+                                            "$ref": "#/definitions/CouponInfo"
                                         }
                                     }
                                 }
@@ -219,9 +219,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/course/edit": {
-            "post": {
-                "description": "Allows a user to edit a course.",
+        "/api/v1/coupon/info": {
+            "get": {
+                "description": "Allows the creator of a coupon (and admins) to get its details, including how many times it has been used.",
                 "consumes": [
                     "application/json"
                 ],
@@ -229,10 +229,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Course"
+                    "Coupon"
                 ],
-                "summary": "Edit a course",
-                "operationId": "editCourseV1",
+                "summary": "Get the details of a coupon",
+                "operationId": "getCouponInfoV1",
                 "parameters": [
                     {
                         "type": "string",
@@ -242,13 +242,11 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Data needed to edit a course",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/EditCourseData"
-                        }
+                        "type": "string",
+                        "description": "Coupon code",
+                        "name": "code",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -263,7 +261,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "result": {
-                                            "$ref": "#/definitions/EditCourseResult"
+                                            "$ref": "#/definitions/CouponInfo"
                                         }
                                     }
                                 }
@@ -273,9 +271,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/course/info": {
+        "/api/v1/coupon/list": {
             "get": {
-                "description": "Allows a user to get information about a course by its id.",
+                "description": "Allows the user to get the coupons they have created (admins get all of the coupons), most recent ones first.",
                 "consumes": [
                     "application/json"
                 ],
@@ -283,10 +281,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Course"
+                    "Coupon"
                 ],
-                "summary": "Get course information",
-                "operationId": "getCourseInfoV1",
+                "summary": "Get the coupons",
+                "operationId": "getCouponsV1",
                 "parameters": [
                     {
                         "type": "string",
@@ -297,10 +295,15 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "Course ID",
-                        "name": "id",
-                        "in": "query",
-                        "required": true
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -315,7 +318,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "result": {
-                                            "$ref": "#/definitions/GetCourseInfoResult"
+                                            "$ref": "#/definitions/GetCouponsResult"
                                         }
                                     }
                                 }
@@ -325,9 +328,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/course/search": {
+        "/api/v1/course/courseParticipants": {
             "post": {
-                "description": "Allows a user to search for courses by their name. Pass empty string to get all courses.",
+                "description": "Allows a user to get all participants of a course.",
                 "consumes": [
                     "application/json"
                 ],
@@ -337,8 +340,8 @@ const docTemplate = `{
                 "tags": [
                     "Course"
                 ],
-                "summary": "Search for courses",
-                "operationId": "searchCourseV1",
+                "summary": "Get course participants",
+                "operationId": "getCourseParticipantsV1",
                 "parameters": [
                     {
                         "type": "string",
@@ -348,12 +351,12 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Data needed to search for courses",
+                        "description": "Data needed to get course participants",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/SearchCourseData"
+                            "$ref": "#/definitions/GetCourseParticipantsData"
                         }
                     }
                 ],
@@ -369,7 +372,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "result": {
-                                            "$ref": "#/definitions/SearchCourseResult"
+                                            "$ref": "#/definitions/GetCourseParticipantsResult"
                                         }
                                     }
                                 }
@@ -379,9 +382,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/course/userCourses": {
+        "/api/v1/course/create": {
             "post": {
-                "description": "Allows a user to get all courses participated by a user.",
+                "description": "Allows a user to create a new course.",
                 "consumes": [
                     "application/json"
                 ],
@@ -391,8 +394,8 @@ const docTemplate = `{
                 "tags": [
                     "Course"
                 ],
-                "summary": "Get user courses",
-                "operationId": "getUserCoursesV1",
+                "summary": "Create a new course",
+                "operationId": "createCourseV1",
                 "parameters": [
                     {
                         "type": "string",
@@ -402,12 +405,12 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Data needed to get user courses",
+                        "description": "Data needed to create a new course",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/GetUserCoursesData"
+                            "$ref": "#/definitions/CreateCourseData"
                         }
                     }
                 ],
@@ -423,7 +426,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "result": {
-                                            "$ref": "#/definitions/GetUserCoursesResult"
+                                            "$ref": "#/definitions/CreateCourseResult"
                                         }
                                     }
                                 }
@@ -433,9 +436,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/exam/answer": {
+        "/api/v1/course/createGradeCategory": {
             "post": {
-                "description": "Allows the user to answer a question of an exam.",
+                "description": "Allows a user to add a weighted grade category (e.g. quizzes 20%) to the gradebook of a course, optionally dropping the lowest scores of its exams.",
                 "consumes": [
                     "application/json"
                 ],
@@ -443,10 +446,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Exam"
+                    "Course"
                 ],
-                "summary": "Answer a question of an exam",
-                "operationId": "answerExamQuestionV1",
+                "summary": "Create a grade category in a course",
+                "operationId": "createGradeCategoryV1",
                 "parameters": [
                     {
                         "type": "string",
@@ -456,12 +459,12 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Data needed to answer a question of an exam",
+                        "description": "Data needed to create a grade category",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/AnswerQuestionData"
+                            "$ref": "#/definitions/CreateGradeCategoryData"
                         }
                     }
                 ],
@@ -477,7 +480,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "result": {
-                                            "$ref": "#/definitions/AnswerQuestionResult"
+                                            "$ref": "#/definitions/GradeCategoryInfo"
                                         }
                                     }
                                 }
@@ -487,9 +490,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/exam/create": {
+        "/api/v1/course/createdCourses": {
             "post": {
-                "description": "Allows the user to create a new exam.",
+                "description": "Allows a user to get all courses created by a user.",
                 "consumes": [
                     "application/json"
                 ],
@@ -497,10 +500,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Exam"
+                    "Course"
                 ],
-                "summary": "Create a new exam",
-                "operationId": "createExamV1",
+                "summary": "Get created courses",
+                "operationId": "getCreatedCoursesV1",
                 "parameters": [
                     {
                         "type": "string",
@@ -510,12 +513,12 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Data needed to create a new exam",
+                        "description": "Data needed to get created courses",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/CreateExamData"
+                            "$ref": "#/definitions/GetCreatedCoursesData"
                         }
                     }
                 ],
@@ -531,7 +534,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "result": {
-                                            "$ref": "#/definitions/CreateExamResult"
+                                            "$ref": "#/definitions/GetCreatedCoursesResult"
                                         }
                                     }
                                 }
@@ -541,9 +544,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/exam/createQuestion": {
+        "/api/v1/course/deleteGradeCategory": {
             "post": {
-                "description": "Allows the user to create a new question for an exam.",
+                "description": "Allows a user to delete a grade category of a course; its exams are not counted in the course grade anymore.",
                 "consumes": [
                     "application/json"
                 ],
@@ -551,10 +554,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Exam"
+                    "Course"
                 ],
-                "summary": "Create a new question for an exam",
-                "operationId": "createExamQuestionV1",
+                "summary": "Delete a grade category of a course",
+                "operationId": "deleteGradeCategoryV1",
                 "parameters": [
                     {
                         "type": "string",
@@ -564,12 +567,12 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Data needed to create a new question for an exam",
+                        "description": "Data needed to delete a grade category",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/CreateExamQuestionData"
+                            "$ref": "#/definitions/DeleteGradeCategoryData"
                         }
                     }
                 ],
@@ -585,7 +588,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "result": {
-                                            "$ref": "#/definitions/CreateExamQuestionResult"
+                                            "$ref": "#/definitions/DeleteGradeCategoryResult"
                                         }
                                     }
                                 }
@@ -595,9 +598,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/exam/edit": {
+        "/api/v1/course/edit": {
             "post": {
-                "description": "Allows the user to edit an exam.",
+                "description": "Allows a user to edit a course.",
                 "consumes": [
                     "application/json"
                 ],
@@ -605,10 +608,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Exam"
+                    "Course"
                 ],
-                "summary": "Edit an exam",
-                "operationId": "editExamV1",
+                "summary": "Edit a course",
+                "operationId": "editCourseV1",
                 "parameters": [
                     {
                         "type": "string",
@@ -618,12 +621,12 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Data needed to edit an exam",
+                        "description": "Data needed to edit a course",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/EditExamData"
+                            "$ref": "#/definitions/EditCourseData"
                         }
                     }
                 ],
@@ -639,7 +642,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "result": {
-                                            "$ref": "#/definitions/EditExamResult"
+                                            "$ref": "#/definitions/EditCourseResult"
                                         }
                                     }
                                 }
@@ -649,9 +652,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/exam/editQuestion": {
+        "/api/v1/course/editGradeCategory": {
             "post": {
-                "description": "Allows the user to edit a question of an exam.",
+                "description": "Allows a user to edit the name, the weight and the number of the dropped scores of a grade category.",
                 "consumes": [
                     "application/json"
                 ],
@@ -659,10 +662,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Exam"
+                    "Course"
                 ],
-                "summary": "Edit a question of an exam",
-                "operationId": "editExamQuestionV1",
+                "summary": "Edit a grade category of a course",
+                "operationId": "editGradeCategoryV1",
                 "parameters": [
                     {
                         "type": "string",
@@ -672,12 +675,12 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Data needed to edit a question of an exam",
+                        "description": "Data needed to edit a grade category",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/EditExamQuestionData"
+                            "$ref": "#/definitions/EditGradeCategoryData"
                         }
                     }
                 ],
@@ -693,7 +696,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "result": {
-                                            "$ref": "#/definitions/EditExamQuestionResult"
+                                            "$ref": "#/definitions/GradeCategoryInfo"
                                         }
                                     }
                                 }
@@ -703,20 +706,17 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/exam/givenExam": {
-            "post": {
-                "description": "Allows the user to get information about an exam that a user has participated in.",
-                "consumes": [
-                    "application/json"
-                ],
+        "/api/v1/course/exportGradebook": {
+            "get": {
+                "description": "Allows the users who can see the gradebook of a course to download it as a csv or xlsx file. Teachers who do not manage the gradebook only get the scores of the exams they have created or grade as collaborators, without the category and course grades. The file is streamed, so courses of any size can be exported.",
                 "produces": [
-                    "application/json"
+                    "application/octet-stream"
                 ],
                 "tags": [
-                    "Exam"
+                    "Course"
                 ],
-                "summary": "Get information about an exam that a user has participated in",
-                "operationId": "getGivenExamV1",
+                "summary": "Export the gradebook of a course",
+                "operationId": "exportCourseGradebookV1",
                 "parameters": [
                     {
                         "type": "string",
@@ -726,40 +726,38 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Data needed to get information about an exam that a user has participated in",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/GetGivenExamData"
-                        }
+                        "type": "integer",
+                        "description": "Course ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Format of the file (csv or xlsx); defaults to csv",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated keys of the columns to export, in order (user_id, full_name, exam_scores, category_grades, course_grade); defaults to all of them",
+                        "name": "columns",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/EndpointResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "result": {
-                                            "$ref": "#/definitions/GetGivenExamData"
-                                        }
-                                    }
-                                }
-                            ]
+                            "type": "file"
                         }
                     }
                 }
             }
         },
-        "/api/v1/exam/info": {
+        "/api/v1/course/gradeCategories": {
             "get": {
-                "description": "Allows the user to get information about an exam.",
+                "description": "Allows a user to get the weighted grade categories of a course.",
                 "consumes": [
                     "application/json"
                 ],
@@ -767,10 +765,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Exam"
+                    "Course"
                 ],
-                "summary": "Get information about an exam",
-                "operationId": "getExamInfoV1",
+                "summary": "Get grade categories of a course",
+                "operationId": "getGradeCategoriesV1",
                 "parameters": [
                     {
                         "type": "string",
@@ -781,7 +779,7 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "Exam ID",
+                        "description": "Course ID",
                         "name": "id",
                         "in": "query",
                         "required": true
@@ -799,7 +797,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "result": {
-                                            "$ref": "#/definitions/GetExamInfoResult"
+                                            "$ref": "#/definitions/GetGradeCategoriesResult"
                                         }
                                     }
                                 }
@@ -809,9 +807,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/exam/participants": {
-            "post": {
-                "description": "Allows the user to get participants of an exam.",
+        "/api/v1/course/gradebook": {
+            "get": {
+                "description": "Allows a user to get the gradebook of a course: the scores of every participant in every graded exam of the course, their grades in the categories and their computed course grade.",
                 "consumes": [
                     "application/json"
                 ],
@@ -819,10 +817,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Exam"
+                    "Course"
                 ],
-                "summary": "Get participants of an exam",
-                "operationId": "getExamParticipantsV1",
+                "summary": "Get the gradebook of a course",
+                "operationId": "getCourseGradebookV1",
                 "parameters": [
                     {
                         "type": "string",
@@ -832,13 +830,11 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Data needed to get participants of an exam",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/GetExamParticipantsData"
-                        }
+                        "type": "integer",
+                        "description": "Course ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -853,7 +849,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "result": {
-                                            "$ref": "#/definitions/GetExamParticipantsResult"
+                                            "$ref": "#/definitions/GetCourseGradebookResult"
                                         }
                                     }
                                 }
@@ -863,9 +859,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/exam/participate": {
-            "post": {
-                "description": "Allows the user to participate in an exam.",
+        "/api/v1/course/info": {
+            "get": {
+                "description": "Allows a user to get information about a course by its id.",
                 "consumes": [
                     "application/json"
                 ],
@@ -873,10 +869,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Exam"
+                    "Course"
                 ],
-                "summary": "Participate in an exam",
-                "operationId": "participateExamV1",
+                "summary": "Get course information",
+                "operationId": "getCourseInfoV1",
                 "parameters": [
                     {
                         "type": "string",
@@ -886,13 +882,11 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Data needed to participate in an exam",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/ParticipateExamData"
-                        }
+                        "type": "integer",
+                        "description": "Course ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -907,7 +901,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "result": {
-                                            "$ref": "#/definitions/ParticipateExamResult"
+                                            "$ref": "#/definitions/GetCourseInfoResult"
                                         }
                                     }
                                 }
@@ -917,9 +911,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/exam/questions": {
-            "post": {
-                "description": "Allows the user to get questions of an exam.",
+        "/api/v1/course/myGrades": {
+            "get": {
+                "description": "Allows a user to get their own row of the gradebook of a course: their scores in the graded exams of the course, their grades in the categories and their computed course grade.",
                 "consumes": [
                     "application/json"
                 ],
@@ -927,10 +921,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Exam"
+                    "Course"
                 ],
-                "summary": "Get questions of an exam",
-                "operationId": "getExamQuestionsV1",
+                "summary": "Get own grades in a course",
+                "operationId": "getMyCourseGradesV1",
                 "parameters": [
                     {
                         "type": "string",
@@ -940,13 +934,11 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Data needed to get questions of an exam",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/GetExamQuestionsData"
-                        }
+                        "type": "integer",
+                        "description": "Course ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -961,7 +953,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "result": {
-                                            "$ref": "#/definitions/GetExamQuestionsResult"
+                                            "$ref": "#/definitions/GetMyCourseGradesResult"
                                         }
                                     }
                                 }
@@ -971,9 +963,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/exam/search": {
+        "/api/v1/course/search": {
             "post": {
-                "description": "Allows the user to search exams.",
+                "description": "Allows a user to search for courses by their name. Pass empty string to get all courses.",
                 "consumes": [
                     "application/json"
                 ],
@@ -981,10 +973,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Exam"
+                    "Course"
                 ],
-                "summary": "Search exams",
-                "operationId": "searchExamV1",
+                "summary": "Search for courses",
+                "operationId": "searchCourseV1",
                 "parameters": [
                     {
                         "type": "string",
@@ -994,12 +986,12 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Data needed to search exams",
+                        "description": "Data needed to search for courses",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/SearchExamData"
+                            "$ref": "#/definitions/SearchCourseData"
                         }
                     }
                 ],
@@ -1015,7 +1007,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "result": {
-                                            "$ref": "#/definitions/SearchExamResult"
+                                            "$ref": "#/definitions/SearchCourseResult"
                                         }
                                     }
                                 }
@@ -1025,9 +1017,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/exam/setScore": {
+        "/api/v1/course/userCourses": {
             "post": {
-                "description": "Allows the user to set score for a user in an exam.",
+                "description": "Allows a user to get all courses participated by a user.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1035,10 +1027,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Exam"
+                    "Course"
                 ],
-                "summary": "Set score for a user in an exam",
-                "operationId": "setExamScoreV1",
+                "summary": "Get user courses",
+                "operationId": "getUserCoursesV1",
                 "parameters": [
                     {
                         "type": "string",
@@ -1048,12 +1040,12 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Data needed to set score for a user in an exam",
+                        "description": "Data needed to get user courses",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/SetExamScoreData"
+                            "$ref": "#/definitions/GetUserCoursesData"
                         }
                     }
                 ],
@@ -1069,7 +1061,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "result": {
-                                            "$ref": "#/definitions/SetExamScoreResult"
+                                            "$ref": "#/definitions/GetUserCoursesResult"
                                         }
                                     }
                                 }
@@ -1079,9 +1071,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/exam/userExamsHistory": {
+        "/api/v1/exam/acceptInvitation": {
             "post": {
-                "description": "Allows the user to get history of exams of a user.",
+                "description": "Allows the invited user to join the exam through the join link of their invitation.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1091,8 +1083,8 @@ const docTemplate = `{
                 "tags": [
                     "Exam"
                 ],
-                "summary": "Get history of exams of a user",
-                "operationId": "getUserExamsHistoryV1",
+                "summary": "Accept an exam invitation",
+                "operationId": "acceptExamInvitationV1",
                 "parameters": [
                     {
                         "type": "string",
@@ -1102,12 +1094,12 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Data needed to get history of exams of a user",
+                        "description": "Data needed to accept an exam invitation",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/GetUsersExamHistoryData"
+                            "$ref": "#/definitions/AcceptExamInvitationData"
                         }
                     }
                 ],
@@ -1123,7 +1115,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "result": {
-                                            "$ref": "#/definitions/GetUsersExamHistoryResult"
+                                            "$ref": "#/definitions/ParticipateExamResult"
                                         }
                                     }
                                 }
@@ -1133,9 +1125,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/exam/userOngoingExams": {
+        "/api/v1/exam/accessCode": {
             "get": {
-                "description": "Allows the user to get ongoing exams of a user.",
+                "description": "Allows the invigilator to get the access code of an exam that has to be announced right now.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1145,8 +1137,8 @@ const docTemplate = `{
                 "tags": [
                     "Exam"
                 ],
-                "summary": "Get ongoing exams of a user",
-                "operationId": "getUserOngoingExamsV1",
+                "summary": "Get the current access code of an exam",
+                "operationId": "getExamAccessCodeV1",
                 "parameters": [
                     {
                         "type": "string",
@@ -1156,10 +1148,11 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Target user id",
-                        "name": "targetId",
-                        "in": "query"
+                        "type": "integer",
+                        "description": "Exam ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -1174,7 +1167,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "result": {
-                                            "$ref": "#/definitions/GetUserOngoingExamsResult"
+                                            "$ref": "#/definitions/ExamAccessCodeResult"
                                         }
                                     }
                                 }
@@ -1184,9 +1177,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/topic/allUserTopicStats": {
+        "/api/v1/exam/accommodations": {
             "get": {
-                "description": "Get all user topic stats",
+                "description": "Allows the user to get all of the accommodations granted to the participants of an exam.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1194,10 +1187,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Topic"
+                    "Exam"
                 ],
-                "summary": "Get all user topic stats",
-                "operationId": "getAllUserTopicStatsV1",
+                "summary": "Get the accommodations of an exam",
+                "operationId": "getExamAccommodationsV1",
                 "parameters": [
                     {
                         "type": "string",
@@ -1205,6 +1198,13 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Exam ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -1219,7 +1219,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "result": {
-                                            "$ref": "#/definitions/GetAllUserTopicStatsResult"
+                                            "$ref": "#/definitions/GetExamAccommodationsResult"
                                         }
                                     }
                                 }
@@ -1229,9 +1229,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/topic/create": {
+        "/api/v1/exam/addPrerequisite": {
             "post": {
-                "description": "Create a new topic",
+                "description": "Allows the user to add a prerequisite to an exam; either passing another exam or reaching a level in a topic.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1239,10 +1239,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Topic"
+                    "Exam"
                 ],
-                "summary": "Create a new topic",
-                "operationId": "createTopicV1",
+                "summary": "Add a prerequisite to an exam",
+                "operationId": "addExamPrerequisiteV1",
                 "parameters": [
                     {
                         "type": "string",
@@ -1252,12 +1252,12 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Data needed to create a new topic",
+                        "description": "Data needed to add a prerequisite to an exam",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/CreateNewTopicData"
+                            "$ref": "#/definitions/AddExamPrerequisiteData"
                         }
                     }
                 ],
@@ -1273,7 +1273,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "result": {
-                                            "$ref": "#/definitions/CreateNewTopicResult"
+                                            "$ref": "#/definitions/ExamPrerequisiteInfo"
                                         }
                                     }
                                 }
@@ -1283,9 +1283,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/topic/delete": {
-            "delete": {
-                "description": "Allows moderators to delete a topic. All courses and exams related to the topic will be deleted as well.",
+        "/api/v1/exam/addReviewComment": {
+            "post": {
+                "description": "Allows the reviewers and the authors of an exam to leave comments on the exam or on its individual questions.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1293,10 +1293,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Topic"
+                    "Exam"
                 ],
-                "summary": "Delete a topic",
-                "operationId": "deleteTopicV1",
+                "summary": "Leave a review comment on an exam or on one of its questions",
+                "operationId": "addExamReviewCommentV1",
                 "parameters": [
                     {
                         "type": "string",
@@ -1306,11 +1306,13 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Topic ID",
-                        "name": "id",
-                        "in": "query",
-                        "required": true
+                        "description": "Data needed to leave a review comment",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/AddExamReviewCommentData"
+                        }
                     }
                 ],
                 "responses": {
@@ -1325,7 +1327,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "result": {
-                                            "type": "boolean"
+                                            "$ref": "#/definitions/ExamReviewCommentInfo"
                                         }
                                     }
                                 }
@@ -1335,9 +1337,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/topic/info": {
-            "get": {
-                "description": "Get topic info",
+        "/api/v1/exam/answer": {
+            "post": {
+                "description": "Allows the user to answer a question of an exam.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1345,10 +1347,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Topic"
+                    "Exam"
                 ],
-                "summary": "Get topic info",
-                "operationId": "getTopicInfoV1",
+                "summary": "Answer a question of an exam",
+                "operationId": "answerExamQuestionV1",
                 "parameters": [
                     {
                         "type": "string",
@@ -1358,11 +1360,19 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Topic ID",
-                        "name": "id",
-                        "in": "query",
-                        "required": true
+                        "type": "string",
+                        "description": "Device id of the client taking the exam",
+                        "name": "Client-Device-ID",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Data needed to answer a question of an exam",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/AnswerQuestionData"
+                        }
                     }
                 ],
                 "responses": {
@@ -1377,7 +1387,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "result": {
-                                            "$ref": "#/definitions/GetTopicInfoResult"
+                                            "$ref": "#/definitions/AnswerQuestionResult"
                                         }
                                     }
                                 }
@@ -1387,20 +1397,17 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/topic/search": {
-            "post": {
-                "description": "Search for topics",
-                "consumes": [
-                    "application/json"
-                ],
+        "/api/v1/exam/attachment": {
+            "get": {
+                "description": "Allows the user to download a file attached to a question or to one of its options, or uploaded as an answer.",
                 "produces": [
-                    "application/json"
+                    "application/octet-stream"
                 ],
                 "tags": [
-                    "Topic"
+                    "Exam"
                 ],
-                "summary": "Search for topics",
-                "operationId": "searchTopicV1",
+                "summary": "Download a file attached to a question of an exam",
+                "operationId": "getExamAttachmentV1",
                 "parameters": [
                     {
                         "type": "string",
@@ -1410,40 +1417,26 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Data needed to search for topics",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/SearchTopicData"
-                        }
+                        "type": "integer",
+                        "description": "Attachment ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/EndpointResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "result": {
-                                            "$ref": "#/definitions/SearchTopicResult"
-                                        }
-                                    }
-                                }
-                            ]
+                            "type": "file"
                         }
                     }
                 }
             }
         },
-        "/api/v1/topic/userTopicStat": {
-            "post": {
-                "description": "Get user topic stat",
+        "/api/v1/exam/attachments": {
+            "get": {
+                "description": "Allows the user to get the files attached to a question and to its options, alongside the files they have uploaded as their answer in their latest attempt.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1451,10 +1444,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Topic"
+                    "Exam"
                 ],
-                "summary": "Get user topic stat",
-                "operationId": "getUserTopicStatV1",
+                "summary": "Get the files attached to a question of an exam",
+                "operationId": "getExamAttachmentsV1",
                 "parameters": [
                     {
                         "type": "string",
@@ -1464,13 +1457,18 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Data needed to get user topic stat",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/GetUserTopicStatData"
-                        }
+                        "type": "integer",
+                        "description": "Exam ID",
+                        "name": "examId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Question ID",
+                        "name": "questionId",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -1485,7 +1483,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "result": {
-                                            "$ref": "#/definitions/GetUserTopicStatResult"
+                                            "$ref": "#/definitions/GetExamAttachmentsResult"
                                         }
                                     }
                                 }
@@ -1495,9 +1493,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/user/ban": {
-            "post": {
-                "description": "Allows a user to ban another user",
+        "/api/v1/exam/attemptReview": {
+            "get": {
+                "description": "Allows the user to review an attempt in an exam: every question alongside the answer given to it, the correct answer, the points earned, the comment of the grader and the explanation. The students can only review their own attempts, and only when the attempt review policy of the exam allows it.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1505,10 +1503,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Exam"
                 ],
-                "summary": "Ban a user",
-                "operationId": "banUserV1",
+                "summary": "Review an attempt in an exam",
+                "operationId": "getAttemptReviewV1",
                 "parameters": [
                     {
                         "type": "string",
@@ -1518,13 +1516,29 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Ban user data",
-                        "name": "banUserData",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/BanUserData"
-                        }
+                        "type": "integer",
+                        "description": "Exam ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Attempt number (the latest attempt by default)",
+                        "name": "attemptNumber",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Target user id",
+                        "name": "targetId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Return the safe HTML variant of the content too",
+                        "name": "renderHtml",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -1539,7 +1553,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "result": {
-                                            "$ref": "#/definitions/BanUserResult"
+                                            "$ref": "#/definitions/GetAttemptReviewResult"
                                         }
                                     }
                                 }
@@ -1549,9 +1563,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/user/changePassword": {
-            "post": {
-                "description": "Allows a user to change a user's password.",
+        "/api/v1/exam/attempts": {
+            "get": {
+                "description": "Allows the user to get all of the attempts (and their scores) of a user in an exam.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1559,10 +1573,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Exam"
                 ],
-                "summary": "Change a user's password",
-                "operationId": "changePasswordV1",
+                "summary": "Get attempts of a user in an exam",
+                "operationId": "getExamAttemptsV1",
                 "parameters": [
                     {
                         "type": "string",
@@ -1572,13 +1586,17 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Change password data",
-                        "name": "changePasswordData",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/ChangePasswordData"
-                        }
+                        "type": "integer",
+                        "description": "Exam ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Target user id",
+                        "name": "targetId",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -1593,7 +1611,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "result": {
-                                            "$ref": "#/definitions/ChangePasswordResult"
+                                            "$ref": "#/definitions/GetExamAttemptsResult"
                                         }
                                     }
                                 }
@@ -1603,9 +1621,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/user/confirmAccount": {
+        "/api/v1/exam/checkSimilarity": {
             "post": {
-                "description": "Allows a user to confirm their account",
+                "description": "Allows a teacher to compare the text answers given to a question in the background, reporting the pairs of similar answers; the results are stored and can be reviewed later.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1613,18 +1631,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Exam"
                 ],
-                "summary": "Confirm account",
-                "operationId": "confirmAccountV1",
+                "summary": "Start a similarity check of the text answers of a question",
+                "operationId": "startSimilarityCheckV1",
                 "parameters": [
                     {
-                        "description": "Confirm account data",
-                        "name": "confirmAccountData",
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Data needed to start a similarity check",
+                        "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/ConfirmAccountData"
+                            "$ref": "#/definitions/StartSimilarityCheckData"
                         }
                     }
                 ],
@@ -1640,7 +1665,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "result": {
-                                            "type": "boolean"
+                                            "$ref": "#/definitions/StartSimilarityCheckResult"
                                         }
                                     }
                                 }
@@ -1650,9 +1675,61 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/user/confirmChangePassword": {
+        "/api/v1/exam/collaborators": {
+            "get": {
+                "description": "Allows the users who can peek the questions of an exam to get the list of its collaborators and their roles.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exam"
+                ],
+                "summary": "Get the collaborators of an exam",
+                "operationId": "getExamCollaboratorsV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Exam ID",
+                        "name": "examId",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/GetExamCollaboratorsResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/exam/create": {
             "post": {
-                "description": "Allows a user to confirm changing their own's password (from redirected page)",
+                "description": "Allows the user to create a new exam.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1660,18 +1737,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Exam"
                 ],
-                "summary": "Confirm changing your own's password",
-                "operationId": "confirmChangePasswordV1",
+                "summary": "Create a new exam",
+                "operationId": "createExamV1",
                 "parameters": [
                     {
-                        "description": "Confirm change password data",
-                        "name": "confirmChangePasswordData",
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Data needed to create a new exam",
+                        "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/ConfirmChangePasswordData"
+                            "$ref": "#/definitions/CreateExamData"
                         }
                     }
                 ],
@@ -1687,7 +1771,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "result": {
-                                            "type": "boolean"
+                                            "$ref": "#/definitions/CreateExamResult"
                                         }
                                     }
                                 }
@@ -1697,9 +1781,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/user/create": {
+        "/api/v1/exam/createQuestion": {
             "post": {
-                "description": "Allows a user to create a new user",
+                "description": "Allows the user to create a new question for an exam.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1707,10 +1791,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Exam"
                 ],
-                "summary": "Create a new user",
-                "operationId": "createUserV1",
+                "summary": "Create a new question for an exam",
+                "operationId": "createExamQuestionV1",
                 "parameters": [
                     {
                         "type": "string",
@@ -1720,12 +1804,12 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Create user data",
-                        "name": "createUserData",
+                        "description": "Data needed to create a new question for an exam",
+                        "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/CreateUserData"
+                            "$ref": "#/definitions/CreateExamQuestionData"
                         }
                     }
                 ],
@@ -1741,7 +1825,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "result": {
-                                            "$ref": "#/definitions/CreateUserResult"
+                                            "$ref": "#/definitions/CreateExamQuestionResult"
                                         }
                                     }
                                 }
@@ -1751,9 +1835,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/user/edit": {
+        "/api/v1/exam/createSection": {
             "post": {
-                "description": "Allows a user to edit another user. Users are not allowed to edit their own information.",
+                "description": "Allows the user to create a new section (with its own time limit and navigation) in an exam that has not started yet.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1761,10 +1845,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Exam"
                 ],
-                "summary": "Edit a user's basic information",
-                "operationId": "editUserV1",
+                "summary": "Create a new section for an exam",
+                "operationId": "createExamSectionV1",
                 "parameters": [
                     {
                         "type": "string",
@@ -1774,12 +1858,12 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Edit user data",
-                        "name": "editUserData",
+                        "description": "Data needed to create a new section for an exam",
+                        "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/EditUserData"
+                            "$ref": "#/definitions/CreateExamSectionData"
                         }
                     }
                 ],
@@ -1795,7 +1879,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "result": {
-                                            "$ref": "#/definitions/EditUserResult"
+                                            "$ref": "#/definitions/ExamSectionInfo"
                                         }
                                     }
                                 }
@@ -1805,17 +1889,20 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/user/info": {
-            "get": {
-                "description": "Allows a user to get another user's information by their user ID",
+        "/api/v1/exam/createSeries": {
+            "post": {
+                "description": "Allows the user to create a series of exams which are recreated from a template exam based on a recurrence rule.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Exam"
                 ],
-                "summary": "Get a user's information",
-                "operationId": "getUserInfoV1",
+                "summary": "Create an exam series",
+                "operationId": "createExamSeriesV1",
                 "parameters": [
                     {
                         "type": "string",
@@ -1825,11 +1912,13 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "query",
-                        "required": true
+                        "description": "Data needed to create an exam series",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/CreateExamSeriesData"
+                        }
                     }
                 ],
                 "responses": {
@@ -1844,7 +1933,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "result": {
-                                            "$ref": "#/definitions/GetUserInfoResult"
+                                            "$ref": "#/definitions/ExamSeriesInfo"
                                         }
                                     }
                                 }
@@ -1854,9 +1943,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/user/login": {
+        "/api/v1/exam/deleteAttachment": {
             "post": {
-                "description": "Allows a user to login to the system and obtain access/refresh tokens",
+                "description": "Allows the user to delete a file attached to a question or to one of its options, or a file they have uploaded as their answer while their attempt is still ongoing.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1864,18 +1953,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Exam"
                 ],
-                "summary": "Login to the system",
-                "operationId": "loginV1",
+                "summary": "Delete a file attached to a question of an exam",
+                "operationId": "deleteExamAttachmentV1",
                 "parameters": [
                     {
-                        "description": "Login data",
-                        "name": "loginData",
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Device id of the client taking the exam (required for the answer files)",
+                        "name": "Client-Device-ID",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Data needed to delete an attachment",
+                        "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/LoginData"
+                            "$ref": "#/definitions/DeleteExamAttachmentData"
                         }
                     }
                 ],
@@ -1891,7 +1993,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "result": {
-                                            "$ref": "#/definitions/LoginResult"
+                                            "$ref": "#/definitions/DeleteExamAttachmentResult"
                                         }
                                     }
                                 }
@@ -1901,17 +2003,20 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/user/me": {
-            "get": {
-                "description": "Allows a user to get their own information",
+        "/api/v1/exam/deleteSection": {
+            "post": {
+                "description": "Allows the user to delete a section of an exam that has not started yet; the questions of the section are kept in the exam without a section.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Exam"
                 ],
-                "summary": "Get the user's information",
-                "operationId": "getMeV1",
+                "summary": "Delete a section of an exam",
+                "operationId": "deleteExamSectionV1",
                 "parameters": [
                     {
                         "type": "string",
@@ -1919,6 +2024,15 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "description": "Data needed to delete a section of an exam",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/DeleteExamSectionData"
+                        }
                     }
                 ],
                 "responses": {
@@ -1933,7 +2047,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "result": {
-                                            "$ref": "#/definitions/GetMeResult"
+                                            "$ref": "#/definitions/DeleteExamSectionResult"
                                         }
                                     }
                                 }
@@ -1943,24 +2057,36 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/user/reAuth": {
+        "/api/v1/exam/discardPreview": {
             "post": {
-                "description": "Allows a user to refresh their access token",
+                "description": "Allows the user to throw away their preview attempt, alongside all of the answers given in it.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Exam"
                 ],
-                "summary": "Refresh the access token",
-                "operationId": "reAuthV1",
+                "summary": "Discard a preview attempt",
+                "operationId": "discardExamPreviewV1",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Refresh token",
+                        "description": "Authorization token",
                         "name": "Authorization",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "description": "Data needed to discard a preview attempt",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/DiscardExamPreviewData"
+                        }
                     }
                 ],
                 "responses": {
@@ -1975,7 +2101,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "result": {
-                                            "$ref": "#/definitions/AuthResult"
+                                            "$ref": "#/definitions/DiscardExamPreviewResult"
                                         }
                                     }
                                 }
@@ -1985,9 +2111,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/user/search": {
+        "/api/v1/exam/edit": {
             "post": {
-                "description": "Allows a user to search for users",
+                "description": "Allows the user to edit an exam.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1995,10 +2121,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Exam"
                 ],
-                "summary": "Search users",
-                "operationId": "searchUserV1",
+                "summary": "Edit an exam",
+                "operationId": "editExamV1",
                 "parameters": [
                     {
                         "type": "string",
@@ -2008,12 +2134,12 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Search user data",
-                        "name": "searchUserData",
+                        "description": "Data needed to edit an exam",
+                        "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/SearchUserData"
+                            "$ref": "#/definitions/EditExamData"
                         }
                     }
                 ],
@@ -2029,7 +2155,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "result": {
-                                            "$ref": "#/definitions/SearchUserResult"
+                                            "$ref": "#/definitions/EditExamResult"
                                         }
                                     }
                                 }
@@ -2038,45 +2164,4503 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "APIErrorCode": {
-            "type": "integer",
-            "enum": [
-                2100,
-                2101,
-                2102,
-                2103,
-                2104,
-                2105,
-                2106,
-                2107,
-                2108,
-                2109,
-                2110,
-                2111,
-                2112,
-                2113,
-                2114,
-                2115,
-                2116,
-                2117,
-                2118,
-                2119,
-                2120,
-                2121,
-                2122,
-                2123,
-                2124,
-                2125,
-                2126,
-                2127,
-                2128,
-                2129,
-                2130,
-                2131,
-                2132,
+        },
+        "/api/v1/exam/editQuestion": {
+            "post": {
+                "description": "Allows the user to edit a question of an exam.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exam"
+                ],
+                "summary": "Edit a question of an exam",
+                "operationId": "editExamQuestionV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Data needed to edit a question of an exam",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/EditExamQuestionData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/EditExamQuestionResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/exam/editSection": {
+            "post": {
+                "description": "Allows the user to edit a section of an exam that has not started yet.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exam"
+                ],
+                "summary": "Edit a section of an exam",
+                "operationId": "editExamSectionV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Data needed to edit a section of an exam",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/EditExamSectionData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/ExamSectionInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/exam/editSeries": {
+            "post": {
+                "description": "Allows the user to edit an exam series; changes only affect the occurrences which are not materialised yet.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exam"
+                ],
+                "summary": "Edit an exam series",
+                "operationId": "editExamSeriesV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Data needed to edit an exam series",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/EditExamSeriesData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/ExamSeriesInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/exam/editSeriesOccurrence": {
+            "post": {
+                "description": "Allows the user to override the title, date or duration of a single occurrence of an exam series.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exam"
+                ],
+                "summary": "Edit an occurrence of an exam series",
+                "operationId": "editExamSeriesOccurrenceV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Data needed to edit an occurrence of an exam series",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/EditExamSeriesOccurrenceData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/ExamSeriesOccurrenceInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/exam/exportParticipants": {
+            "get": {
+                "description": "Allows the creator, a grader of an exam or an admin to download its participants alongside their scores as a csv or xlsx file. The file is streamed, so exams of any size can be exported.",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "Exam"
+                ],
+                "summary": "Export the participants of an exam and their scores",
+                "operationId": "exportExamParticipantsV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Exam ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Format of the file (csv or xlsx); defaults to csv",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated keys of the columns to export, in order (user_id, full_name, email, joined_at, attempts, last_finished_at, final_score, score_percentage, scored_by); defaults to all of them",
+                        "name": "columns",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/exam/finishAttempt": {
+            "post": {
+                "description": "Allows the user to finish their ongoing attempt of an exam, so they can retake the exam later (if the retake policy of the exam allows it).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exam"
+                ],
+                "summary": "Finish an exam attempt",
+                "operationId": "finishExamAttemptV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Device id of the client taking the exam",
+                        "name": "Client-Device-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Data needed to finish an exam attempt",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/FinishExamAttemptData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/FinishExamAttemptResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/exam/finishPreview": {
+            "post": {
+                "description": "Allows the user to finish their preview attempt and get its computed score breakdown. Finishing an already finished preview just returns its breakdown again.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exam"
+                ],
+                "summary": "Finish a preview attempt",
+                "operationId": "finishExamPreviewV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Data needed to finish a preview attempt",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/FinishExamPreviewData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/ExamPreviewResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/exam/givenExam": {
+            "post": {
+                "description": "Allows the user to get information about an exam that a user has participated in.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exam"
+                ],
+                "summary": "Get information about an exam that a user has participated in",
+                "operationId": "getGivenExamV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Data needed to get information about an exam that a user has participated in",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/GetGivenExamData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/GetGivenExamData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/exam/gradeAnswer": {
+            "post": {
+                "description": "Allows the user to give points to an answer given in an attempt of an exam, and to leave a comment on it for the student.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exam"
+                ],
+                "summary": "Grade an answer given in an attempt",
+                "operationId": "gradeAnswerV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Data needed to grade an answer",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/GradeAnswerData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/GradeAnswerResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/exam/info": {
+            "get": {
+                "description": "Allows the user to get information about an exam.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exam"
+                ],
+                "summary": "Get information about an exam",
+                "operationId": "getExamInfoV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Exam ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/GetExamInfoResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/exam/invitationInfo": {
+            "get": {
+                "description": "Allows anyone with the join link of an invitation to see its exam and whether the invitee has to create an account first.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exam"
+                ],
+                "summary": "Get information of an exam invitation",
+                "operationId": "getExamInvitationInfoV1",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Invitation ID",
+                        "name": "invitationId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Join token of the invitation",
+                        "name": "token",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/GetExamInvitationInfoResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/exam/invitations": {
+            "get": {
+                "description": "Allows the user to get the invitations of an exam alongside their status.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exam"
+                ],
+                "summary": "Get invitations of an exam",
+                "operationId": "getExamInvitationsV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Exam ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/GetExamInvitationsResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/exam/invite": {
+            "post": {
+                "description": "Allows the user to invite a list of users (by their user id or email address) to an exam; each invitee gets an email with a one-click join link.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exam"
+                ],
+                "summary": "Invite users to an exam",
+                "operationId": "inviteToExamV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Data needed to invite users to an exam",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/InviteToExamData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/InviteToExamResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/exam/nextQuestion": {
+            "post": {
+                "description": "Allows the user to get the question they have to answer next in an adaptive exam; the question is picked by their previous answers. Once the estimate of their ability is precise enough (or enough questions have been given), the attempt ends and the final estimate is returned instead.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exam"
+                ],
+                "summary": "Get the next question of an adaptive exam",
+                "operationId": "nextExamQuestionV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Device id of the client taking the exam",
+                        "name": "Client-Device-ID",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Data needed to get the next question of an adaptive exam",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/NextExamQuestionData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/NextExamQuestionResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/exam/nextSection": {
+            "post": {
+                "description": "Allows the user to finish their current section of the exam and move to the next one; forward-only sections cannot be visited again afterwards.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exam"
+                ],
+                "summary": "Move to the next section of an exam",
+                "operationId": "nextExamSectionV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Device id of the client taking the exam",
+                        "name": "Client-Device-ID",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Data needed to move to the next section of an exam",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/NextExamSectionData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/NextExamSectionResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/exam/participants": {
+            "post": {
+                "description": "Allows the user to get participants of an exam.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exam"
+                ],
+                "summary": "Get participants of an exam",
+                "operationId": "getExamParticipantsV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Data needed to get participants of an exam",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/GetExamParticipantsData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/GetExamParticipantsResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/exam/participate": {
+            "post": {
+                "description": "Allows the user to participate in an exam.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exam"
+                ],
+                "summary": "Participate in an exam",
+                "operationId": "participateExamV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Data needed to participate in an exam",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ParticipateExamData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/ParticipateExamResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/exam/prerequisites": {
+            "get": {
+                "description": "Allows the user to get all of the prerequisites of an exam.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exam"
+                ],
+                "summary": "Get prerequisites of an exam",
+                "operationId": "getExamPrerequisitesV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Exam ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/GetExamPrerequisitesResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/exam/preview": {
+            "get": {
+                "description": "Allows the user to get the questions of their preview attempt alongside the answers they have given in it; the score breakdown of the preview is returned once it has been finished.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exam"
+                ],
+                "summary": "Get a preview attempt",
+                "operationId": "getExamPreviewV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Preview ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Return the safe HTML variant of the content too",
+                        "name": "renderHtml",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/ExamPreviewResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/exam/previewAnswer": {
+            "post": {
+                "description": "Allows the user to answer a question of the exam in their preview attempt. The answer is only stored in the sandbox of the preview.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exam"
+                ],
+                "summary": "Answer a question in a preview attempt",
+                "operationId": "answerPreviewQuestionV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Data needed to answer a question in a preview attempt",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/AnswerPreviewQuestionData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/AnswerPreviewQuestionResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/exam/previews": {
+            "get": {
+                "description": "Allows the user to get the list of their preview attempts in an exam, the latest ones first.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exam"
+                ],
+                "summary": "Get the preview attempts of the user in an exam",
+                "operationId": "getExamPreviewsV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Exam ID",
+                        "name": "examId",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/GetExamPreviewsResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/exam/questions": {
+            "post": {
+                "description": "Allows the user to get questions of an exam.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exam"
+                ],
+                "summary": "Get questions of an exam",
+                "operationId": "getExamQuestionsV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Device id of the client taking the exam",
+                        "name": "Client-Device-ID",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Data needed to get questions of an exam",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/GetExamQuestionsData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/GetExamQuestionsResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/exam/releaseResults": {
+            "post": {
+                "description": "Allows the user to release the results of an exam (or to withdraw them), so the students can review their attempts in the exams with the after_release review policy.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exam"
+                ],
+                "summary": "Release the results of an exam",
+                "operationId": "releaseExamResultsV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Data needed to release the results of an exam",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ReleaseExamResultsData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/ReleaseExamResultsResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/exam/removeAccommodation": {
+            "post": {
+                "description": "Allows the user to remove the accommodation of a participant of an exam.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exam"
+                ],
+                "summary": "Remove the accommodation of a user in an exam",
+                "operationId": "removeExamAccommodationV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Data needed to remove the accommodation of a user",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/RemoveExamAccommodationData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/RemoveExamAccommodationResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/exam/removeCollaborator": {
+            "post": {
+                "description": "Allows the creator of an exam (or an admin) to remove a collaborator from the exam.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exam"
+                ],
+                "summary": "Remove a collaborator from an exam",
+                "operationId": "removeExamCollaboratorV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Data needed to remove the collaborator",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/RemoveExamCollaboratorData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/RemoveExamCollaboratorResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/exam/removePrerequisite": {
+            "post": {
+                "description": "Allows the user to remove a prerequisite from an exam.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exam"
+                ],
+                "summary": "Remove a prerequisite from an exam",
+                "operationId": "removeExamPrerequisiteV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Data needed to remove a prerequisite from an exam",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/RemoveExamPrerequisiteData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/RemoveExamPrerequisiteResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/exam/resetAttemptBinding": {
+            "post": {
+                "description": "Allows a teacher to unbind the attempt of a user from its client, so the user can continue the exam on another device.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exam"
+                ],
+                "summary": "Reset the client binding of an exam attempt",
+                "operationId": "resetAttemptBindingV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Data needed to reset the client binding of an exam attempt",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ResetAttemptBindingData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/ResetAttemptBindingResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/exam/resolveReviewComment": {
+            "post": {
+                "description": "Allows the authors of an exam (or the author of the comment) to mark a review comment as addressed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exam"
+                ],
+                "summary": "Resolve a review comment",
+                "operationId": "resolveExamReviewCommentV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Data needed to resolve a review comment",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ResolveExamReviewCommentData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/ExamReviewCommentInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/exam/resultReport": {
+            "get": {
+                "description": "Allows the user to download a printable PDF report of the result of a participant in an exam: the exam info, their answers against the correct answers, the points of each question, their total and grade, the comments of the teachers and the trend of their scores across the course. The students can only get their own reports, and only when the attempt review policy of the exam allows it. The characters the report cannot show (e.g. of non-Latin texts) are replaced with question marks, and a note says so.",
+                "produces": [
+                    "application/pdf"
+                ],
+                "tags": [
+                    "Exam"
+                ],
+                "summary": "Download the result report of a participant of an exam",
+                "operationId": "getResultReportV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Exam ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Attempt number (the latest attempt by default)",
+                        "name": "attemptNumber",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Target user id",
+                        "name": "targetId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/exam/review": {
+            "post": {
+                "description": "Allows a reviewer (an admin, or a teacher marked as an exam reviewer) to publish an exam which is in review, or to send it back to its authors with a comment.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exam"
+                ],
+                "summary": "Approve an exam or send it back to draft",
+                "operationId": "reviewExamV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Data needed to review an exam",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ReviewExamData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/ExamReviewStatusResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/exam/reviewComments": {
+            "get": {
+                "description": "Allows the reviewers and the authors of an exam to get the comments left on the exam and on its questions, the oldest ones first.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exam"
+                ],
+                "summary": "Get the review comments of an exam",
+                "operationId": "getExamReviewCommentsV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Exam ID",
+                        "name": "examId",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/GetExamReviewCommentsResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/exam/reviewQueue": {
+            "get": {
+                "description": "Allows a reviewer to get the exams which have been submitted for review, the ones submitted the earliest first.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exam"
+                ],
+                "summary": "Get the exams waiting for a review",
+                "operationId": "getExamReviewQueueV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/GetExamReviewQueueResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/exam/search": {
+            "post": {
+                "description": "Allows the user to search exams.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exam"
+                ],
+                "summary": "Search exams",
+                "operationId": "searchExamV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Data needed to search exams",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/SearchExamData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/SearchExamResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/exam/searchQuestions": {
+            "post": {
+                "description": "Allows the user to search for questions by their tags, difficulty level, topic and learning objectives, and by the text of their title and description. Only the questions the user is allowed to peek (the ones of the exams they have created or collaborate on, or all of them for the admins) are searched.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exam"
+                ],
+                "summary": "Search for questions across the exams",
+                "operationId": "searchExamQuestionsV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Data needed to search for questions",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/SearchExamQuestionsData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/SearchExamQuestionsResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/exam/sections": {
+            "get": {
+                "description": "Allows the user to get the sections of an exam in order; participants also get their progress in the sections of their latest attempt.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exam"
+                ],
+                "summary": "Get the sections of an exam",
+                "operationId": "getExamSectionsV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Exam ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/GetExamSectionsResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/exam/series": {
+            "get": {
+                "description": "Allows the user to get an exam series alongside its materialised, edited, skipped and upcoming occurrences.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exam"
+                ],
+                "summary": "Get an exam series",
+                "operationId": "getExamSeriesV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Series ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/GetExamSeriesResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/exam/setAccessCode": {
+            "post": {
+                "description": "Allows the user to protect an exam with a static or rotating access code (or remove it).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exam"
+                ],
+                "summary": "Set the access code of an exam",
+                "operationId": "setExamAccessCodeV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Data needed to set the access code of an exam",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/SetExamAccessCodeData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/ExamAccessCodeResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/exam/setAccommodation": {
+            "post": {
+                "description": "Allows the user to grant (or update) an accommodation (extra minutes, a time multiplier or a custom window) to a participant of an exam.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exam"
+                ],
+                "summary": "Set the accommodation of a user in an exam",
+                "operationId": "setExamAccommodationV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Data needed to set the accommodation of a user",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/SetExamAccommodationData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/ExamAccommodationInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/exam/setAdaptive": {
+            "post": {
+                "description": "Allows the user to make an exam adaptive (giving its questions one at a time, picked by the previous answers of the student) and to set when its attempts end.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exam"
+                ],
+                "summary": "Set the adaptive settings of an exam",
+                "operationId": "setExamAdaptiveV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Data needed to set the adaptive settings of an exam",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/SetExamAdaptiveData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/ExamAdaptiveSettingsResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/exam/setAttemptReviewPolicy": {
+            "post": {
+                "description": "Allows the user to set when the students can review their attempts in an exam: never, after the exam closes or after its results are released.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exam"
+                ],
+                "summary": "Set the attempt review policy of an exam",
+                "operationId": "setAttemptReviewPolicyV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Data needed to set the attempt review policy of an exam",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/SetAttemptReviewPolicyData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/AttemptReviewPolicyResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/exam/setAvailability": {
+            "post": {
+                "description": "Allows the user to set the availability window (opens at/closes at), the late start rule and the deadline policy of an exam.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exam"
+                ],
+                "summary": "Set the availability of an exam",
+                "operationId": "setExamAvailabilityV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Data needed to set the availability of an exam",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/SetExamAvailabilityData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/ExamAvailabilityResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/exam/setCapacity": {
+            "post": {
+                "description": "Allows the user to set the maximum number of participants of an exam; once the exam is full, the users are put on its waitlist.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exam"
+                ],
+                "summary": "Set the capacity of an exam",
+                "operationId": "setExamCapacityV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Data needed to set the capacity of an exam",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/SetExamCapacityData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/ExamCapacityResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/exam/setCollaborator": {
+            "post": {
+                "description": "Allows the creator of an exam (or an admin) to add another teacher as a collaborator of the exam, or to change the role of an existing collaborator. Editors can edit the exam and its questions, graders can score the participants and viewers can only see the exam and its questions.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exam"
+                ],
+                "summary": "Add a collaborator to an exam",
+                "operationId": "setExamCollaboratorV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Data needed to set the collaborator",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/SetExamCollaboratorData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/ExamCollaboratorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/exam/setGradeCategory": {
+            "post": {
+                "description": "Allows the user to set the grade category of its course an exam is counted in (or to leave it out of the course grade). Practice exams are never counted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exam"
+                ],
+                "summary": "Set the grade category of an exam",
+                "operationId": "setExamGradeCategoryV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Data needed to set the grade category of an exam",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/SetExamGradeCategoryData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/ExamGradeCategoryResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/exam/setPracticeMode": {
+            "post": {
+                "description": "Allows the user to turn an exam into a practice exam (or back into a graded one) before it starts. The attempts of the practice exams are unlimited and are not graded; the students get told whether their answer was right, alongside the explanation of the question, right after answering it. Their answers still earn them experience in the topic of the exam.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exam"
+                ],
+                "summary": "Turn an exam into a practice exam",
+                "operationId": "setExamPracticeModeV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Data needed to set the practice mode of an exam",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/SetExamPracticeModeData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/ExamPracticeModeResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/exam/setQuestionIrt": {
+            "post": {
+                "description": "Allows the user to set the correct option of a question and its parameters in the three-parameter logistic model; only the questions with an answer key are given in adaptive exams.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exam"
+                ],
+                "summary": "Set the answer key and the IRT parameters of a question",
+                "operationId": "setExamQuestionIrtV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Data needed to set the answer key and the IRT parameters of a question",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/SetExamQuestionIrtData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/ExamQuestionIrtResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/exam/setQuestionMetadata": {
+            "post": {
+                "description": "Allows the user to set the tags, the difficulty level, the learning objectives and the topic of a question, which are used to search for the questions across the exams.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exam"
+                ],
+                "summary": "Set the metadata of a question",
+                "operationId": "setExamQuestionMetadataV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Data needed to set the metadata of a question",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/SetExamQuestionMetadataData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/SetExamQuestionMetadataResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/exam/setRetakePolicy": {
+            "post": {
+                "description": "Allows the user to set the max number of attempts, the cooldown between attempts and the grading policy of an exam.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exam"
+                ],
+                "summary": "Set the retake policy of an exam",
+                "operationId": "setExamRetakePolicyV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Data needed to set the retake policy of an exam",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/SetExamRetakePolicyData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/ExamRetakePolicyResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/exam/setScore": {
+            "post": {
+                "description": "Allows the user to set score for a user in an exam.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exam"
+                ],
+                "summary": "Set score for a user in an exam",
+                "operationId": "setExamScoreV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Data needed to set score for a user in an exam",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/SetExamScoreData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/SetExamScoreResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/exam/similarityCheck": {
+            "get": {
+                "description": "Allows a teacher to get a similarity check, alongside the pairs of similar answers it has found once it has completed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exam"
+                ],
+                "summary": "Get a similarity check and its results",
+                "operationId": "getSimilarityCheckV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Similarity check ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/GetSimilarityCheckResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/exam/similarityChecks": {
+            "get": {
+                "description": "Allows a teacher to get the similarity checks done on the answers of an exam, optionally only the ones of a single question.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exam"
+                ],
+                "summary": "Get the similarity checks of an exam",
+                "operationId": "getSimilarityChecksV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Exam ID",
+                        "name": "examId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Question ID",
+                        "name": "questionId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/GetSimilarityChecksResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/exam/skipSeriesOccurrence": {
+            "post": {
+                "description": "Allows the user to skip (or bring back) a single occurrence of an exam series; the exam of a skipped occurrence is removed if nobody has participated in it yet.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exam"
+                ],
+                "summary": "Skip an occurrence of an exam series",
+                "operationId": "skipExamSeriesOccurrenceV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Data needed to skip an occurrence of an exam series",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/SkipExamSeriesOccurrenceData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/ExamSeriesOccurrenceInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/exam/startAttempt": {
+            "post": {
+                "description": "Allows the user to start a new attempt of an exam (or resume their ongoing one), binding it to the current client (its device id; the changes of its ip address are only recorded). If the exam is protected by an access code, the code has to be provided.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exam"
+                ],
+                "summary": "Start an exam attempt",
+                "operationId": "startExamAttemptV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Device id of the client taking the exam",
+                        "name": "Client-Device-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Data needed to start an exam attempt",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/StartExamAttemptData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/StartExamAttemptResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/exam/startPreview": {
+            "post": {
+                "description": "Allows the teachers (and the reviewers) of an exam to take it exactly as a student would, to check its timing and grading. The answers of a preview are kept in a sandbox, so they neither make the user a participant of the exam nor affect its statistics.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exam"
+                ],
+                "summary": "Start a preview attempt in an exam",
+                "operationId": "startExamPreviewV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Data needed to start a preview attempt",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/StartExamPreviewData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/ExamPreviewResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/exam/submitForReview": {
+            "post": {
+                "description": "Allows the author of a draft exam to submit it for review; the exam becomes visible to the students once a reviewer approves it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exam"
+                ],
+                "summary": "Submit an exam for review",
+                "operationId": "submitExamForReviewV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Data needed to submit an exam for review",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/SubmitExamForReviewData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/ExamReviewStatusResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/exam/uploadAttachment": {
+            "post": {
+                "description": "Allows the user to attach an image, audio or pdf file to a question or to one of its options, or to upload a file as their answer to a question while taking the exam. The type of the file is detected from its content.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exam"
+                ],
+                "summary": "Upload a file for a question of an exam",
+                "operationId": "uploadExamAttachmentV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Device id of the client taking the exam (required for the answer files)",
+                        "name": "Client-Device-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Exam ID",
+                        "name": "exam_id",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Question ID",
+                        "name": "question_id",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "What the file is attached to (question, option or answer); defaults to question",
+                        "name": "attachment_type",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of the option (1-4) for the option files",
+                        "name": "option_number",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "The file to be uploaded",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/UploadExamAttachmentResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/exam/userExamsHistory": {
+            "post": {
+                "description": "Allows the user to get history of exams of a user.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exam"
+                ],
+                "summary": "Get history of exams of a user",
+                "operationId": "getUserExamsHistoryV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Data needed to get history of exams of a user",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/GetUsersExamHistoryData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/GetUsersExamHistoryResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/exam/userOngoingExams": {
+            "get": {
+                "description": "Allows the user to get the exams that are still open for a user (upcoming, in progress, or still possible to start within their window).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exam"
+                ],
+                "summary": "Get ongoing exams of a user",
+                "operationId": "getUserOngoingExamsV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Target user id",
+                        "name": "targetId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/GetUserOngoingExamsResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/exam/waitlist": {
+            "get": {
+                "description": "Allows the user to get the users waiting for a seat in an exam, in order.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exam"
+                ],
+                "summary": "Get the waitlist of an exam",
+                "operationId": "getExamWaitlistV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Exam ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/GetExamWaitlistResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/exam/withdraw": {
+            "post": {
+                "description": "Allows the user to withdraw (themselves or others) from an exam before it starts, or to leave its waitlist. The price of the exam is refunded to the wallet, and the freed seat is given to the next user on the waitlist.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exam"
+                ],
+                "summary": "Withdraw from an exam",
+                "operationId": "withdrawFromExamV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Data needed to withdraw from an exam",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/WithdrawFromExamData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/WithdrawFromExamResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/topic/allUserTopicStats": {
+            "get": {
+                "description": "Get all user topic stats",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Topic"
+                ],
+                "summary": "Get all user topic stats",
+                "operationId": "getAllUserTopicStatsV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/GetAllUserTopicStatsResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/topic/create": {
+            "post": {
+                "description": "Create a new topic",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Topic"
+                ],
+                "summary": "Create a new topic",
+                "operationId": "createTopicV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Data needed to create a new topic",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/CreateNewTopicData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/CreateNewTopicResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/topic/delete": {
+            "delete": {
+                "description": "Allows moderators to delete a topic. All courses and exams related to the topic will be deleted as well.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Topic"
+                ],
+                "summary": "Delete a topic",
+                "operationId": "deleteTopicV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Topic ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "type": "boolean"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/topic/info": {
+            "get": {
+                "description": "Get topic info",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Topic"
+                ],
+                "summary": "Get topic info",
+                "operationId": "getTopicInfoV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Topic ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/GetTopicInfoResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/topic/search": {
+            "post": {
+                "description": "Search for topics",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Topic"
+                ],
+                "summary": "Search for topics",
+                "operationId": "searchTopicV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Data needed to search for topics",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/SearchTopicData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/SearchTopicResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/topic/userTopicStat": {
+            "post": {
+                "description": "Get user topic stat",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Topic"
+                ],
+                "summary": "Get user topic stat",
+                "operationId": "getUserTopicStatV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Data needed to get user topic stat",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/GetUserTopicStatData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/GetUserTopicStatResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user/ban": {
+            "post": {
+                "description": "Allows a user to ban another user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Ban a user",
+                "operationId": "banUserV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Ban user data",
+                        "name": "banUserData",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/BanUserData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/BanUserResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user/changePassword": {
+            "post": {
+                "description": "Allows a user to change a user's password.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Change a user's password",
+                "operationId": "changePasswordV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Change password data",
+                        "name": "changePasswordData",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ChangePasswordData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/ChangePasswordResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user/confirmAccount": {
+            "post": {
+                "description": "Allows a user to confirm their account",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Confirm account",
+                "operationId": "confirmAccountV1",
+                "parameters": [
+                    {
+                        "description": "Confirm account data",
+                        "name": "confirmAccountData",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ConfirmAccountData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "type": "boolean"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user/confirmChangePassword": {
+            "post": {
+                "description": "Allows a user to confirm changing their own's password (from redirected page)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Confirm changing your own's password",
+                "operationId": "confirmChangePasswordV1",
+                "parameters": [
+                    {
+                        "description": "Confirm change password data",
+                        "name": "confirmChangePasswordData",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ConfirmChangePasswordData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "type": "boolean"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user/create": {
+            "post": {
+                "description": "Allows a user to create a new user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Create a new user",
+                "operationId": "createUserV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Create user data",
+                        "name": "createUserData",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/CreateUserData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/CreateUserResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user/createFromInvitation": {
+            "post": {
+                "description": "Allows the invitee of an exam, who doesn't have an account yet, to create a student account with the invited email address. The new user joins the exam of the invitation right away, if possible.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Create a new user from an exam invitation",
+                "operationId": "createUserFromInvitationV1",
+                "parameters": [
+                    {
+                        "description": "Data needed to create a user from an exam invitation",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/CreateUserFromInvitationData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/CreateUserFromInvitationResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user/edit": {
+            "post": {
+                "description": "Allows a user to edit another user. Users are not allowed to edit their own information.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Edit a user's basic information",
+                "operationId": "editUserV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Edit user data",
+                        "name": "editUserData",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/EditUserData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/EditUserResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user/info": {
+            "get": {
+                "description": "Allows a user to get another user's information by their user ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Get a user's information",
+                "operationId": "getUserInfoV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/GetUserInfoResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user/login": {
+            "post": {
+                "description": "Allows a user to login to the system and obtain access/refresh tokens",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Login to the system",
+                "operationId": "loginV1",
+                "parameters": [
+                    {
+                        "description": "Login data",
+                        "name": "loginData",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/LoginData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/LoginResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user/me": {
+            "get": {
+                "description": "Allows a user to get their own information",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Get the user's information",
+                "operationId": "getMeV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/GetMeResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user/reAuth": {
+            "post": {
+                "description": "Allows a user to refresh their access token",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Refresh the access token",
+                "operationId": "reAuthV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Refresh token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/AuthResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user/search": {
+            "post": {
+                "description": "Allows a user to search for users",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Search users",
+                "operationId": "searchUserV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Search user data",
+                        "name": "searchUserData",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/SearchUserData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/SearchUserResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user/setExamReviewer": {
+            "post": {
+                "description": "Allows an admin to choose which teachers can review (and approve) the exams of the other teachers.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Set whether a teacher can review exams",
+                "operationId": "setExamReviewerV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Data needed to set whether a teacher can review exams",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/SetExamReviewerData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/SetExamReviewerResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/wallet/info": {
+            "get": {
+                "description": "Allows the user to get their own wallet (or the wallet of another user, for admins).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Wallet"
+                ],
+                "summary": "Get the wallet of a user",
+                "operationId": "getWalletV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Target user id",
+                        "name": "targetId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/WalletInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/wallet/refund": {
+            "post": {
+                "description": "Allows the admins to refund (a part of) a transaction. Refunding an exam payment puts the money back in the wallet of the user; refunding a top-up takes it out of the wallet and gives it back through the payment provider.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Wallet"
+                ],
+                "summary": "Refund a wallet transaction",
+                "operationId": "refundTransactionV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Data needed to refund a transaction",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/RefundTransactionData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/RefundTransactionResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/wallet/topUp": {
+            "post": {
+                "description": "Allows the admins to top up the wallet of a user through the payment provider.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Wallet"
+                ],
+                "summary": "Top up the wallet of a user",
+                "operationId": "topUpWalletV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Data needed to top up a wallet",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/TopUpWalletData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/TopUpWalletResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/wallet/transactions": {
+            "get": {
+                "description": "Allows the user to get the transactions of their own wallet (or the wallet of another user, for admins), most recent ones first.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Wallet"
+                ],
+                "summary": "Get the wallet transactions of a user",
+                "operationId": "getWalletTransactionsV1",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Target user id",
+                        "name": "targetId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/EndpointResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "result": {
+                                            "$ref": "#/definitions/GetWalletTransactionsResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "APIErrorCode": {
+            "type": "integer",
+            "enum": [
+                2100,
+                2101,
+                2102,
+                2103,
+                2104,
+                2105,
+                2106,
+                2107,
+                2108,
+                2109,
+                2110,
+                2111,
+                2112,
+                2113,
+                2114,
+                2115,
+                2116,
+                2117,
+                2118,
+                2119,
+                2120,
+                2121,
+                2122,
+                2123,
+                2124,
+                2125,
+                2126,
+                2127,
+                2128,
+                2129,
+                2130,
+                2131,
+                2132,
                 2133,
                 2134,
                 2135,
@@ -2100,571 +6684,3654 @@ const docTemplate = `{
                 2153,
                 2154,
                 2155,
-                2156
+                2156,
+                2157,
+                2158,
+                2159,
+                2160,
+                2161,
+                2162,
+                2163,
+                2164,
+                2165,
+                2166,
+                2167,
+                2168,
+                2169,
+                2170,
+                2171,
+                2172,
+                2173,
+                2174,
+                2175,
+                2176,
+                2177,
+                2178,
+                2179,
+                2180,
+                2181,
+                2182,
+                2183,
+                2184,
+                2185,
+                2186,
+                2187,
+                2188,
+                2189,
+                2190,
+                2191,
+                2192,
+                2193,
+                2194,
+                2195,
+                2196,
+                2197,
+                2198,
+                2199,
+                2200,
+                2201,
+                2202,
+                2203,
+                2204,
+                2205,
+                2206,
+                2207,
+                2208,
+                2209,
+                2210,
+                2211,
+                2212,
+                2213,
+                2214,
+                2215,
+                2216,
+                2217,
+                2218,
+                2219,
+                2220,
+                2221,
+                2222,
+                2223,
+                2224,
+                2225,
+                2226,
+                2227,
+                2228,
+                2229,
+                2230,
+                2231,
+                2232,
+                2233,
+                2234,
+                2235,
+                2236,
+                2237,
+                2238,
+                2239
+            ],
+            "x-enum-varnames": [
+                "ErrCodeMalformedJWT",
+                "ErrCodeInvalidJWT",
+                "ErrCodeInvalidBodyData",
+                "ErrCodeInvalidUsernamePass",
+                "ErrCodeInvalidAuth",
+                "ErrCodePermissionDenied",
+                "ErrCodeInvalidInputPass",
+                "ErrCodeUsernameExists",
+                "ErrCodeInternalServerError",
+                "ErrCodeInvalidFileData",
+                "ErrCodeInvalidPhoneNumber",
+                "ErrCodePhoneNumberAlreadyImported",
+                "ErrCodeInvalidUserID",
+                "ErrCodeNoPhonesDonated",
+                "ErrCodeAgentNotConnected",
+                "ErrCodeInvalidPagination",
+                "ErrCodeMaxContactImportLimit",
+                "ErrCodePhoneNumberNotFound",
+                "ErrCodeParameterRequired",
+                "ErrCodeUserBanned",
+                "ErrCodeLabelInfoNotFound",
+                "ErrCodeLabelAlreadyApplied",
+                "ErrCodeLabelAlreadyExistsByName",
+                "ErrCodeTooManyChatLabelInfo",
+                "ErrCodeLabelNameTooLong",
+                "ErrCodeLabelDescriptionTooLong",
+                "ErrCodeInvalidColor",
+                "ErrCodeLabelNotApplied",
+                "ErrCodeCannotDeleteBuiltInLabel",
+                "ErrCodeDuplicatePhoneNumber",
+                "ErrCodePhoneNotWorking",
+                "ErrCodeInvalidPmsPass",
+                "ErrCodeInvalidAgentId",
+                "ErrCodeInvalidAppSettingName",
+                "ErrCodeAppSettingNotFound",
+                "ErrCodeTextEmpty",
+                "ErrCodeTextTooLong",
+                "ErrCodeInvalidClientRId",
+                "ErrCodeInvalidCaptcha",
+                "ErrCodeQueryParameterNotProvided",
+                "ErrCodeTooManyPasswordChangeAttempts",
+                "ErrCodeRequestExpired",
+                "ErrCodeInvalidEmail",
+                "ErrCodeCourseAlreadyExists",
+                "ErrCodeCourseNotFound",
+                "ErrCodeExamNotFound",
+                "ErrCodeNotParticipatedInExam",
+                "ErrCodeExamNotStarted",
+                "ErrCodeExamFinished",
+                "ErrCodeExamQuestionNotFound",
+                "ErrCodeInvalidAnswerOption",
+                "ErrCodeGivenExamNotFound",
+                "ErrCodeAccountAlreadyConfirmed",
+                "ErrCodeEmailAlreadyExists",
+                "ErrCodeTopicNameExists",
+                "ErrCodeTopicNotFound",
+                "ErrCodeBodyTooLong",
+                "ErrCodeInvalidDeviceId",
+                "ErrCodeForeignAttemptClient",
+                "ErrCodeAttemptBindingNotFound",
+                "ErrCodeInvalidAccessCode",
+                "ErrCodeTooManyAccessCodeAttempts",
+                "ErrCodeAttemptNotStarted",
+                "ErrCodeInvalidAccessCodeType",
+                "ErrCodeInvalidPrerequisiteType",
+                "ErrCodePrerequisiteNotFound",
+                "ErrCodeInvalidPrerequisite",
+                "ErrCodePrerequisitesNotMet",
+                "ErrCodeAttemptFinished",
+                "ErrCodeNoAttemptsLeft",
+                "ErrCodeRetakeCooldown",
+                "ErrCodeAttemptNotFound",
+                "ErrCodeInvalidGradingPolicy",
+                "ErrCodeAccommodationNotFound",
+                "ErrCodeInvalidAccommodation",
+                "ErrCodeLateStartNotAllowed",
+                "ErrCodeInvalidAvailability",
+                "ErrCodeInvalidDeadlinePolicy",
+                "ErrCodeExamSeriesNotFound",
+                "ErrCodeInvalidRecurrenceRule",
+                "ErrCodeInvalidSeriesOccurrence",
+                "ErrCodeOccurrenceHasParticipants",
+                "ErrCodeInvalidPrice",
+                "ErrCodeInsufficientBalance",
+                "ErrCodeWalletTransactionNotFound",
+                "ErrCodeRefundExceedsAmount",
+                "ErrCodePaymentFailed",
+                "ErrCodeInvalidAmount",
+                "ErrCodeTransactionNotRefundable",
+                "ErrCodeExamCouponNotFound",
+                "ErrCodeExamCouponNotUsable",
+                "ErrCodeInvalidCouponCode",
+                "ErrCodeInvalidDiscount",
+                "ErrCodeCouponCodeExists",
+                "ErrCodeExamInvitationNotFound",
+                "ErrCodeInvalidInvitationToken",
+                "ErrCodeInvitationNotPending",
+                "ErrCodeExamAlreadyStarted",
+                "ErrCodeExamSectionNotFound",
+                "ErrCodeExamSectionClosed",
+                "ErrCodeNoOngoingSection",
+                "ErrCodeExamNotAdaptive",
+                "ErrCodeAdaptiveExamQuestions",
+                "ErrCodeQuestionNotServed",
+                "ErrCodeNoAdaptiveQuestions",
+                "ErrCodeAttachmentNotFound",
+                "ErrCodeUnsupportedFileType",
+                "ErrCodeFileTooLarge",
+                "ErrCodeStorageNotAvailable",
+                "ErrCodeTooManyAttachments",
+                "ErrCodeInvalidContentFormat",
+                "ErrCodeInvalidContent",
+                "ErrCodeSimilarityCheckNotFound",
+                "ErrCodeSimilarityCheckRunning",
+                "ErrCodeInvalidSimilarityOptions",
+                "ErrCodeInvalidQuestionMetadata",
+                "ErrCodeExamNotPublished",
+                "ErrCodeInvalidReviewStatus",
+                "ErrCodeReviewCommentNotFound",
+                "ErrCodeExamCollaboratorNotFound",
+                "ErrCodeInvalidCollaboratorRole",
+                "ErrCodeTooManyCollaborators",
+                "ErrCodePreviewAttemptNotFound",
+                "ErrCodePreviewAttemptFinished",
+                "ErrCodeTooManyPreviewAttempts",
+                "ErrCodePreviewTimeUp",
+                "ErrCodePracticeExamNotGraded",
+                "ErrCodeAttemptReviewNotAvailable",
+                "ErrCodeInvalidAttemptReviewPolicy",
+                "ErrCodeInvalidPoints",
+                "ErrCodeGivenAnswerNotFound",
+                "ErrCodeGradeCategoryNotFound",
+                "ErrCodeGradeCategoryAlreadyExists",
+                "ErrCodeTooManyGradeCategories",
+                "ErrCodeInvalidGradeCategoryWeight",
+                "ErrCodeInvalidDropLowestCount",
+                "ErrCodeGradeCategoryNameTooLong",
+                "ErrCodeInvalidExportFormat",
+                "ErrCodeUnknownExportColumn",
+                "ErrCodeCurrencyMismatch"
+            ]
+        },
+        "AcceptExamInvitationData": {
+            "type": "object",
+            "properties": {
+                "invitation_id": {
+                    "type": "integer"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "AddExamPrerequisiteData": {
+            "type": "object",
+            "properties": {
+                "exam_id": {
+                    "type": "integer"
+                },
+                "min_level": {
+                    "description": "MinLevel is the minimum level the user needs in the topic\n(topic_level type only).",
+                    "type": "integer"
+                },
+                "min_score": {
+                    "description": "MinScore is the minimum score percentage the user needs in the\nrequired exam (exam type only).",
+                    "type": "number",
+                    "default": 50
+                },
+                "prerequisite_type": {
+                    "description": "PrerequisiteType is the type of the prerequisite; it can be one of\n\"exam\" or \"topic_level\".",
+                    "type": "string"
+                },
+                "required_exam_id": {
+                    "description": "RequiredExamId is the exam the user has to pass (exam type only).",
+                    "type": "integer"
+                },
+                "topic_id": {
+                    "description": "TopicId is the topic the user needs a level in (topic_level type only).",
+                    "type": "integer"
+                }
+            }
+        },
+        "AddExamReviewCommentData": {
+            "type": "object",
+            "properties": {
+                "comment_text": {
+                    "type": "string"
+                },
+                "exam_id": {
+                    "type": "integer"
+                },
+                "question_id": {
+                    "description": "QuestionId is the commented question; null means the comment is on\nthe whole exam.",
+                    "type": "integer"
+                }
+            }
+        },
+        "AnswerFeedbackInfo": {
+            "type": "object",
+            "properties": {
+                "correct_option": {
+                    "type": "string"
+                },
+                "explanation": {
+                    "type": "string"
+                },
+                "is_correct": {
+                    "description": "IsCorrect is null if the answer cannot be checked automatically: the\nquestion has no answer key, or no option was chosen.",
+                    "type": "boolean"
+                },
+                "rendered_explanation": {
+                    "description": "RenderedExplanation is the safe HTML variant of the explanation.",
+                    "type": "string"
+                }
+            }
+        },
+        "AnswerPreviewQuestionData": {
+            "type": "object",
+            "properties": {
+                "answer_text": {
+                    "type": "string"
+                },
+                "chosen_option": {
+                    "type": "string"
+                },
+                "preview_id": {
+                    "type": "integer"
+                },
+                "question_id": {
+                    "type": "integer"
+                },
+                "seconds_taken": {
+                    "type": "integer"
+                }
+            }
+        },
+        "AnswerPreviewQuestionResult": {
+            "type": "object",
+            "properties": {
+                "answered_at": {
+                    "type": "string"
+                },
+                "feedback": {
+                    "description": "Feedback is only set in the previews of the practice exams, the same\nas it would be for a student.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/AnswerFeedbackInfo"
+                        }
+                    ]
+                },
+                "preview_id": {
+                    "type": "integer"
+                },
+                "question_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "AnswerQuestionData": {
+            "type": "object",
+            "properties": {
+                "answer_text": {
+                    "type": "string"
+                },
+                "chosen_option": {
+                    "type": "string"
+                },
+                "exam_id": {
+                    "type": "integer"
+                },
+                "question_id": {
+                    "type": "integer"
+                },
+                "seconds_taken": {
+                    "type": "integer"
+                }
+            }
+        },
+        "AnswerQuestionResult": {
+            "type": "object",
+            "properties": {
+                "answered_at": {
+                    "type": "string"
+                },
+                "answered_by": {
+                    "type": "string"
+                },
+                "attempt_number": {
+                    "type": "integer"
+                },
+                "exam_id": {
+                    "type": "integer"
+                },
+                "feedback": {
+                    "description": "Feedback is only set in the practice exams.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/AnswerFeedbackInfo"
+                        }
+                    ]
+                },
+                "question_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "AnsweredQuestionInfo": {
+            "type": "object",
+            "properties": {
+                "answer": {
+                    "type": "string"
+                },
+                "chosen_option": {
+                    "type": "string"
+                },
+                "question_id": {
+                    "type": "integer"
+                },
+                "seconds_taken": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "AttemptReviewPolicyResult": {
+            "type": "object",
+            "properties": {
+                "attempt_review_policy": {
+                    "type": "string"
+                },
+                "exam_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "AttemptReviewQuestionInfo": {
+            "type": "object",
+            "properties": {
+                "attachments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ExamAttachmentInfo"
+                    }
+                },
+                "content_format": {
+                    "type": "string"
+                },
+                "correct_option": {
+                    "description": "CorrectOption is nil if the question has no answer key; IsCorrect\nis nil if the question has no answer key or has not been answered.",
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "explanation": {
+                    "type": "string"
+                },
+                "graded_at": {
+                    "type": "string"
+                },
+                "grader_comment": {
+                    "type": "string"
+                },
+                "is_correct": {
+                    "type": "boolean"
+                },
+                "is_graded": {
+                    "type": "boolean"
+                },
+                "option1": {
+                    "type": "string"
+                },
+                "option2": {
+                    "type": "string"
+                },
+                "option3": {
+                    "type": "string"
+                },
+                "option4": {
+                    "type": "string"
+                },
+                "points": {
+                    "description": "Points is the maximum number of points of the question; PointsEarned\nis nil if the answer is still waiting to be graded.",
+                    "type": "number"
+                },
+                "points_earned": {
+                    "type": "number"
+                },
+                "question_id": {
+                    "type": "integer"
+                },
+                "question_order": {
+                    "type": "integer"
+                },
+                "question_title": {
+                    "type": "string"
+                },
+                "rendered": {
+                    "description": "Rendered is the safe HTML variant of the content (and of the\nexplanation); it's only set if it has been requested.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/RenderedQuestionContent"
+                        }
+                    ]
+                },
+                "section_id": {
+                    "type": "integer"
+                },
+                "user_answer": {
+                    "description": "UserAnswer is nil if the question has not been answered.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/AnsweredQuestionInfo"
+                        }
+                    ]
+                }
+            }
+        },
+        "AuthResult": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string"
+                },
+                "expiration": {
+                    "type": "integer"
+                },
+                "full_name": {
+                    "type": "string"
+                },
+                "refresh_token": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "BanUserData": {
+            "type": "object",
+            "properties": {
+                "ban_reason": {
+                    "type": "string"
+                },
+                "is_banned": {
+                    "type": "boolean"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "BanUserResult": {
+            "type": "object",
+            "properties": {
+                "ban_reason": {
+                    "type": "string"
+                },
+                "is_banned": {
+                    "type": "boolean"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "ChangePasswordData": {
+            "type": "object",
+            "properties": {
+                "lang": {
+                    "type": "string",
+                    "default": "en"
+                },
+                "new_password": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "ChangePasswordResult": {
+            "type": "object",
+            "properties": {
+                "email_sent": {
+                    "type": "boolean"
+                },
+                "lang": {
+                    "type": "string",
+                    "default": "en"
+                },
+                "password_changed": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "CheckCouponData": {
+            "type": "object",
+            "properties": {
+                "coupon_code": {
+                    "type": "string"
+                },
+                "exam_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "CheckCouponResult": {
+            "type": "object",
+            "properties": {
+                "coupon_code": {
+                    "type": "string"
+                },
+                "discount": {
+                    "type": "string"
+                },
+                "exam_id": {
+                    "type": "integer"
+                },
+                "original_price": {
+                    "type": "string"
+                },
+                "price": {
+                    "description": "Price is the price the user has to pay when using the coupon.",
+                    "type": "string"
+                }
+            }
+        },
+        "ConfirmAccountData": {
+            "type": "object",
+            "properties": {
+                "confirm_token": {
+                    "type": "string"
+                },
+                "lt_token": {
+                    "type": "string"
+                },
+                "raw_password": {
+                    "type": "string"
+                },
+                "rl_token": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "ConfirmChangePasswordData": {
+            "type": "object",
+            "properties": {
+                "new_password": {
+                    "type": "string"
+                },
+                "rq_id": {
+                    "type": "string"
+                },
+                "rt_hash": {
+                    "type": "string"
+                },
+                "rt_param": {
+                    "type": "string"
+                },
+                "rt_verifier": {
+                    "type": "string"
+                }
+            }
+        },
+        "CouponInfo": {
+            "type": "object",
+            "properties": {
+                "coupon_code": {
+                    "type": "string"
+                },
+                "coupon_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "discount": {
+                    "description": "Discount is either a percentage (e.g. \"20%\") or an amount\n(e.g. \"20T\").",
+                    "type": "string"
+                },
+                "discount_type": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "max_uses": {
+                    "type": "integer"
+                },
+                "max_uses_per_user": {
+                    "type": "integer"
+                },
+                "scope_id": {
+                    "type": "integer"
+                },
+                "scope_type": {
+                    "type": "string"
+                },
+                "uses_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "CourseParticipantInfo": {
+            "type": "object",
+            "properties": {
+                "full_name": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "CreateCouponData": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "description": "Amount is the fixed discount (e.g. \"20T\"), only used for the\nfixed coupons.",
+                    "type": "string"
+                },
+                "coupon_code": {
+                    "description": "CouponCode is the code entered by the users; it's case-insensitive\nand may only contain letters, digits, '_' and '-'.",
+                    "type": "string"
+                },
+                "discount_type": {
+                    "description": "DiscountType is either \"percentage\" or \"fixed\".",
+                    "type": "string"
+                },
+                "expires_at": {
+                    "description": "ExpiresAt is the unix timestamp after which the coupon cannot be\nused anymore.",
+                    "type": "integer"
+                },
+                "max_uses": {
+                    "description": "MaxUses and MaxUsesPerUser are the usage limits of the coupon;\nnull means unlimited.",
+                    "type": "integer"
+                },
+                "max_uses_per_user": {
+                    "type": "integer"
+                },
+                "percentage": {
+                    "description": "Percentage is the discount percentage (1-100), only used for the\npercentage coupons.",
+                    "type": "integer"
+                },
+                "scope_id": {
+                    "type": "integer"
+                },
+                "scope_type": {
+                    "description": "ScopeType is one of \"exam\", \"course\" or \"topic\"; ScopeId is the id\nof the exam, course or topic the coupon can be used for.",
+                    "type": "string"
+                }
+            }
+        },
+        "CreateCourseData": {
+            "type": "object",
+            "properties": {
+                "course_description": {
+                    "type": "string"
+                },
+                "course_name": {
+                    "type": "string"
+                },
+                "topic_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "CreateCourseResult": {
+            "type": "object",
+            "properties": {
+                "added_by": {
+                    "type": "string"
+                },
+                "course_description": {
+                    "type": "string"
+                },
+                "course_id": {
+                    "type": "integer"
+                },
+                "course_name": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "topic_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "CreateExamData": {
+            "type": "object",
+            "required": [
+                "course_id",
+                "exam_description",
+                "exam_title"
             ],
-            "x-enum-varnames": [
-                "ErrCodeMalformedJWT",
-                "ErrCodeInvalidJWT",
-                "ErrCodeInvalidBodyData",
-                "ErrCodeInvalidUsernamePass",
-                "ErrCodeInvalidAuth",
-                "ErrCodePermissionDenied",
-                "ErrCodeInvalidInputPass",
-                "ErrCodeUsernameExists",
-                "ErrCodeInternalServerError",
-                "ErrCodeInvalidFileData",
-                "ErrCodeInvalidPhoneNumber",
-                "ErrCodePhoneNumberAlreadyImported",
-                "ErrCodeInvalidUserID",
-                "ErrCodeNoPhonesDonated",
-                "ErrCodeAgentNotConnected",
-                "ErrCodeInvalidPagination",
-                "ErrCodeMaxContactImportLimit",
-                "ErrCodePhoneNumberNotFound",
-                "ErrCodeParameterRequired",
-                "ErrCodeUserBanned",
-                "ErrCodeLabelInfoNotFound",
-                "ErrCodeLabelAlreadyApplied",
-                "ErrCodeLabelAlreadyExistsByName",
-                "ErrCodeTooManyChatLabelInfo",
-                "ErrCodeLabelNameTooLong",
-                "ErrCodeLabelDescriptionTooLong",
-                "ErrCodeInvalidColor",
-                "ErrCodeLabelNotApplied",
-                "ErrCodeCannotDeleteBuiltInLabel",
-                "ErrCodeDuplicatePhoneNumber",
-                "ErrCodePhoneNotWorking",
-                "ErrCodeInvalidPmsPass",
-                "ErrCodeInvalidAgentId",
-                "ErrCodeInvalidAppSettingName",
-                "ErrCodeAppSettingNotFound",
-                "ErrCodeTextEmpty",
-                "ErrCodeTextTooLong",
-                "ErrCodeInvalidClientRId",
-                "ErrCodeInvalidCaptcha",
-                "ErrCodeQueryParameterNotProvided",
-                "ErrCodeTooManyPasswordChangeAttempts",
-                "ErrCodeRequestExpired",
-                "ErrCodeInvalidEmail",
-                "ErrCodeCourseAlreadyExists",
-                "ErrCodeCourseNotFound",
-                "ErrCodeExamNotFound",
-                "ErrCodeNotParticipatedInExam",
-                "ErrCodeExamNotStarted",
-                "ErrCodeExamFinished",
-                "ErrCodeExamQuestionNotFound",
-                "ErrCodeInvalidAnswerOption",
-                "ErrCodeGivenExamNotFound",
-                "ErrCodeAccountAlreadyConfirmed",
-                "ErrCodeEmailAlreadyExists",
-                "ErrCodeTopicNameExists",
-                "ErrCodeTopicNotFound",
-                "ErrCodeBodyTooLong"
-            ]
+            "properties": {
+                "course_id": {
+                    "type": "integer"
+                },
+                "duration": {
+                    "type": "integer",
+                    "default": 60
+                },
+                "exam_date": {
+                    "type": "integer"
+                },
+                "exam_description": {
+                    "type": "string"
+                },
+                "exam_title": {
+                    "type": "string"
+                },
+                "is_public": {
+                    "type": "boolean",
+                    "default": false
+                },
+                "price": {
+                    "type": "string",
+                    "default": "0T"
+                }
+            }
+        },
+        "CreateExamQuestionData": {
+            "type": "object",
+            "properties": {
+                "content_format": {
+                    "description": "ContentFormat is one of plain (the default), markdown and\nmarkdown_latex.",
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "exam_id": {
+                    "type": "integer"
+                },
+                "explanation": {
+                    "description": "Explanation explains the answer of the question; it's shown to the\nstudents of the practice exams right after they answer it.",
+                    "type": "string"
+                },
+                "option1": {
+                    "type": "string"
+                },
+                "option2": {
+                    "type": "string"
+                },
+                "option3": {
+                    "type": "string"
+                },
+                "option4": {
+                    "type": "string"
+                },
+                "points": {
+                    "description": "Points is the maximum number of points the question is worth; it's\n1 by default.",
+                    "type": "number"
+                },
+                "question_order": {
+                    "type": "integer"
+                },
+                "question_title": {
+                    "type": "string"
+                },
+                "section_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "CreateExamQuestionResult": {
+            "type": "object",
+            "properties": {
+                "content_format": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "exam_id": {
+                    "type": "integer"
+                },
+                "explanation": {
+                    "type": "string"
+                },
+                "option1": {
+                    "type": "string"
+                },
+                "option2": {
+                    "type": "string"
+                },
+                "option3": {
+                    "type": "string"
+                },
+                "option4": {
+                    "type": "string"
+                },
+                "points": {
+                    "type": "number"
+                },
+                "question_id": {
+                    "type": "integer"
+                },
+                "question_order": {
+                    "type": "integer"
+                },
+                "question_title": {
+                    "type": "string"
+                },
+                "rendered": {
+                    "description": "Rendered is the safe HTML variant of the content, for previewing it.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/RenderedQuestionContent"
+                        }
+                    ]
+                },
+                "section_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "CreateExamResult": {
+            "type": "object",
+            "properties": {
+                "course_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "duration": {
+                    "type": "integer"
+                },
+                "exam_date": {
+                    "type": "string"
+                },
+                "exam_id": {
+                    "type": "integer"
+                },
+                "is_public": {
+                    "type": "boolean"
+                },
+                "price": {
+                    "type": "string"
+                }
+            }
+        },
+        "CreateExamSectionData": {
+            "type": "object",
+            "properties": {
+                "duration": {
+                    "description": "Duration is the time limit of the section in minutes; null means\nonly the deadline of the exam applies.",
+                    "type": "integer"
+                },
+                "exam_id": {
+                    "type": "integer"
+                },
+                "is_forward_only": {
+                    "type": "boolean"
+                },
+                "section_order": {
+                    "type": "integer"
+                },
+                "section_title": {
+                    "type": "string"
+                }
+            }
+        },
+        "CreateExamSeriesData": {
+            "type": "object",
+            "properties": {
+                "generate_ahead": {
+                    "description": "GenerateAhead is the number of days the occurrences are created\nahead of time.",
+                    "type": "integer",
+                    "default": 7
+                },
+                "recurrence_rule": {
+                    "description": "RecurrenceRule is an RRULE-like rule; supported parts are FREQ\n(DAILY, WEEKLY or MONTHLY), INTERVAL, BYDAY, COUNT and UNTIL.\ne.g. \"FREQ=WEEKLY;BYDAY=MO,WE;COUNT=10\"",
+                    "type": "string"
+                },
+                "series_title": {
+                    "type": "string"
+                },
+                "starts_at": {
+                    "description": "StartsAt is the unix timestamp of the first occurrence; the date of\nthe template exam is used if it's not set.",
+                    "type": "integer"
+                },
+                "template_exam_id": {
+                    "description": "TemplateExamId is the exam every occurrence of the series is\ncopied from (including its questions).",
+                    "type": "integer"
+                }
+            }
+        },
+        "CreateGradeCategoryData": {
+            "type": "object",
+            "properties": {
+                "category_name": {
+                    "type": "string"
+                },
+                "course_id": {
+                    "type": "integer"
+                },
+                "drop_lowest": {
+                    "description": "DropLowest is the number of the lowest scores of the category which\nare not counted.",
+                    "type": "integer"
+                },
+                "weight": {
+                    "description": "Weight is the share of the category in the course grade (between\n0 and 100); the weights are normalised, so they don't have to add\nup to 100.",
+                    "type": "number"
+                }
+            }
+        },
+        "CreateNewTopicData": {
+            "type": "object",
+            "properties": {
+                "topic_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "CreateNewTopicResult": {
+            "type": "object",
+            "properties": {
+                "topic_id": {
+                    "type": "integer"
+                },
+                "topic_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "CreateUserData": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "full_name": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "phone_number": {
+                    "type": "string"
+                },
+                "primary_language": {
+                    "type": "string"
+                },
+                "role": {
+                    "$ref": "#/definitions/UserRole"
+                },
+                "setup_completed": {
+                    "type": "boolean"
+                },
+                "user_address": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "CreateUserFromInvitationData": {
+            "type": "object",
+            "properties": {
+                "full_name": {
+                    "type": "string"
+                },
+                "invitation_id": {
+                    "type": "integer"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "CreateUserFromInvitationResult": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "exam_id": {
+                    "type": "integer"
+                },
+                "full_name": {
+                    "type": "string"
+                },
+                "invitation_status": {
+                    "description": "InvitationStatus is accepted if the user has joined the exam (or its\nwaitlist) right away; the invitation stays pending (linked to the\nnew user) if the exam cannot be joined yet, e.g. when it has to be\npaid for first.",
+                    "type": "string"
+                },
+                "is_waitlisted": {
+                    "description": "IsWaitlisted is true if the exam is full and the user has been put\non its waitlist instead; WaitlistPosition is their 1-based position.",
+                    "type": "boolean",
+                    "default": false
+                },
+                "role": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "waitlist_position": {
+                    "type": "integer",
+                    "default": 0
+                }
+            }
+        },
+        "CreateUserResult": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "full_name": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "DeactivateCouponData": {
+            "type": "object",
+            "properties": {
+                "coupon_code": {
+                    "type": "string"
+                }
+            }
+        },
+        "DeleteExamAttachmentData": {
+            "type": "object",
+            "properties": {
+                "attachment_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "DeleteExamAttachmentResult": {
+            "type": "object",
+            "properties": {
+                "attachment_id": {
+                    "type": "integer"
+                },
+                "question_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "DeleteExamSectionData": {
+            "type": "object",
+            "properties": {
+                "section_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "DeleteExamSectionResult": {
+            "type": "object",
+            "properties": {
+                "exam_id": {
+                    "type": "integer"
+                },
+                "section_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "DeleteGradeCategoryData": {
+            "type": "object",
+            "properties": {
+                "category_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "DeleteGradeCategoryResult": {
+            "type": "object",
+            "properties": {
+                "category_id": {
+                    "type": "integer"
+                },
+                "course_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "DiscardExamPreviewData": {
+            "type": "object",
+            "properties": {
+                "preview_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "DiscardExamPreviewResult": {
+            "type": "object",
+            "properties": {
+                "exam_id": {
+                    "type": "integer"
+                },
+                "preview_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "EditCourseData": {
+            "type": "object",
+            "properties": {
+                "course_description": {
+                    "type": "string"
+                },
+                "course_id": {
+                    "type": "integer"
+                },
+                "course_name": {
+                    "type": "string"
+                },
+                "topic_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "EditCourseResult": {
+            "type": "object",
+            "properties": {
+                "added_by": {
+                    "type": "string"
+                },
+                "course_description": {
+                    "type": "string"
+                },
+                "course_id": {
+                    "type": "integer"
+                },
+                "course_name": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "EditExamData": {
+            "type": "object",
+            "properties": {
+                "course_id": {
+                    "type": "integer"
+                },
+                "duration": {
+                    "type": "integer",
+                    "default": 60
+                },
+                "exam_date": {
+                    "type": "integer"
+                },
+                "exam_description": {
+                    "type": "string"
+                },
+                "exam_id": {
+                    "type": "integer"
+                },
+                "exam_title": {
+                    "type": "string"
+                },
+                "is_public": {
+                    "type": "boolean",
+                    "default": false
+                },
+                "price": {
+                    "type": "string",
+                    "default": "0T"
+                }
+            }
+        },
+        "EditExamQuestionData": {
+            "type": "object",
+            "properties": {
+                "content_format": {
+                    "description": "ContentFormat is the new format of the content; the current format\nis kept if it's empty.",
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "exam_id": {
+                    "type": "integer"
+                },
+                "explanation": {
+                    "description": "Explanation explains the answer of the question; it's shown to the\nstudents of the practice exams right after they answer it.",
+                    "type": "string"
+                },
+                "option1": {
+                    "type": "string"
+                },
+                "option2": {
+                    "type": "string"
+                },
+                "option3": {
+                    "type": "string"
+                },
+                "option4": {
+                    "type": "string"
+                },
+                "points": {
+                    "description": "Points is the maximum number of points the question is worth; the\ncurrent points are kept if it's not set.",
+                    "type": "number"
+                },
+                "question_id": {
+                    "type": "integer"
+                },
+                "question_order": {
+                    "type": "integer"
+                },
+                "question_title": {
+                    "type": "string"
+                },
+                "section_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "EditExamQuestionResult": {
+            "type": "object",
+            "properties": {
+                "content_format": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "exam_id": {
+                    "type": "integer"
+                },
+                "explanation": {
+                    "type": "string"
+                },
+                "option1": {
+                    "type": "string"
+                },
+                "option2": {
+                    "type": "string"
+                },
+                "option3": {
+                    "type": "string"
+                },
+                "option4": {
+                    "type": "string"
+                },
+                "points": {
+                    "type": "number"
+                },
+                "question_id": {
+                    "type": "integer"
+                },
+                "question_order": {
+                    "type": "integer"
+                },
+                "question_title": {
+                    "type": "string"
+                },
+                "rendered": {
+                    "description": "Rendered is the safe HTML variant of the content, for previewing it.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/RenderedQuestionContent"
+                        }
+                    ]
+                },
+                "section_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "EditExamResult": {
+            "type": "object",
+            "properties": {
+                "course_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "duration": {
+                    "type": "integer"
+                },
+                "exam_date": {
+                    "type": "string"
+                },
+                "exam_description": {
+                    "type": "string"
+                },
+                "exam_id": {
+                    "type": "integer"
+                },
+                "exam_title": {
+                    "type": "string"
+                },
+                "is_public": {
+                    "type": "boolean"
+                },
+                "price": {
+                    "type": "string"
+                }
+            }
+        },
+        "EditExamSectionData": {
+            "type": "object",
+            "properties": {
+                "duration": {
+                    "type": "integer"
+                },
+                "is_forward_only": {
+                    "type": "boolean"
+                },
+                "section_id": {
+                    "type": "integer"
+                },
+                "section_order": {
+                    "type": "integer"
+                },
+                "section_title": {
+                    "type": "string"
+                }
+            }
+        },
+        "EditExamSeriesData": {
+            "type": "object",
+            "properties": {
+                "generate_ahead": {
+                    "type": "integer"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "recurrence_rule": {
+                    "type": "string"
+                },
+                "series_id": {
+                    "type": "integer"
+                },
+                "series_title": {
+                    "description": "SeriesTitle, RecurrenceRule, GenerateAhead and IsActive are left\nunchanged if they are not set.",
+                    "type": "string"
+                }
+            }
+        },
+        "EditExamSeriesOccurrenceData": {
+            "type": "object",
+            "properties": {
+                "duration": {
+                    "type": "integer"
+                },
+                "exam_date": {
+                    "type": "integer"
+                },
+                "exam_title": {
+                    "description": "ExamTitle, ExamDate (unix timestamp) and Duration override the ones\nof the template exam; null means no override.",
+                    "type": "string"
+                },
+                "occurrence_at": {
+                    "description": "OccurrenceAt is the unix timestamp of the occurrence (as calculated\nby the recurrence rule).",
+                    "type": "integer"
+                },
+                "series_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "EditGradeCategoryData": {
+            "type": "object",
+            "properties": {
+                "category_id": {
+                    "type": "integer"
+                },
+                "category_name": {
+                    "type": "string"
+                },
+                "drop_lowest": {
+                    "type": "integer"
+                },
+                "weight": {
+                    "type": "number"
+                }
+            }
+        },
+        "EditUserData": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "full_name": {
+                    "type": "string"
+                },
+                "phone_number": {
+                    "type": "string"
+                },
+                "user_address": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "EditUserResult": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "full_name": {
+                    "type": "string"
+                },
+                "role": {
+                    "$ref": "#/definitions/UserRole"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "EndpointError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "$ref": "#/definitions/APIErrorCode"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "origin": {
+                    "type": "string"
+                }
+            }
+        },
+        "EndpointResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "$ref": "#/definitions/EndpointError"
+                },
+                "result": {},
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "ExamAccessCodeResult": {
+            "type": "object",
+            "properties": {
+                "access_code_type": {
+                    "type": "string"
+                },
+                "current_code": {
+                    "type": "string"
+                },
+                "exam_id": {
+                    "type": "integer"
+                },
+                "expires_in": {
+                    "description": "ExpiresIn is the time in seconds until the current rotating\ncode changes.",
+                    "type": "integer",
+                    "default": 0
+                },
+                "rotation_interval": {
+                    "type": "integer"
+                }
+            }
+        },
+        "ExamAccommodationInfo": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "custom_end": {
+                    "type": "string"
+                },
+                "custom_start": {
+                    "type": "string"
+                },
+                "ends_at": {
+                    "description": "EndsAt is the effective close time of the exam for the user.",
+                    "type": "string"
+                },
+                "exam_id": {
+                    "type": "integer"
+                },
+                "extra_minutes": {
+                    "type": "integer"
+                },
+                "granted_by": {
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "starts_at": {
+                    "description": "StartsAt is the effective start time of the exam for the user.",
+                    "type": "string"
+                },
+                "time_multiplier": {
+                    "type": "number"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "ExamAdaptiveSettingsResult": {
+            "type": "object",
+            "properties": {
+                "exam_id": {
+                    "type": "integer"
+                },
+                "is_adaptive": {
+                    "type": "boolean"
+                },
+                "max_items": {
+                    "type": "integer"
+                },
+                "questions_count": {
+                    "description": "QuestionsCount is the number of questions which can be given in the\nadaptive attempts (the ones with an answer key).",
+                    "type": "integer"
+                },
+                "target_se": {
+                    "type": "number"
+                }
+            }
+        },
+        "ExamAttachmentInfo": {
+            "type": "object",
+            "properties": {
+                "answered_by": {
+                    "type": "string"
+                },
+                "attachment_id": {
+                    "type": "integer"
+                },
+                "attachment_type": {
+                    "type": "string"
+                },
+                "attempt_number": {
+                    "type": "integer"
+                },
+                "content_type": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "exam_id": {
+                    "type": "integer"
+                },
+                "file_name": {
+                    "type": "string"
+                },
+                "file_size": {
+                    "type": "integer"
+                },
+                "option_number": {
+                    "type": "integer"
+                },
+                "question_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "ExamAttemptInfo": {
+            "type": "object",
+            "properties": {
+                "ability_estimate": {
+                    "description": "AbilityEstimate and AbilityStdError are the estimated ability of the\nuser (and its standard error) in an adaptive attempt.",
+                    "type": "number"
+                },
+                "ability_std_error": {
+                    "type": "number"
+                },
+                "attempt_number": {
+                    "type": "integer"
+                },
+                "final_score": {
+                    "type": "string"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "scored_by": {
+                    "type": "string"
+                },
+                "started_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "ExamAvailabilityResult": {
+            "type": "object",
+            "properties": {
+                "closes_at": {
+                    "type": "string"
+                },
+                "deadline_policy": {
+                    "type": "string"
+                },
+                "exam_id": {
+                    "type": "integer"
+                },
+                "grace_period": {
+                    "type": "integer"
+                },
+                "is_windowed": {
+                    "type": "boolean"
+                },
+                "late_start_limit": {
+                    "type": "integer"
+                },
+                "opens_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "ExamCapacityResult": {
+            "type": "object",
+            "properties": {
+                "capacity": {
+                    "type": "integer"
+                },
+                "exam_id": {
+                    "type": "integer"
+                },
+                "participants_count": {
+                    "type": "integer"
+                },
+                "waitlist_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "ExamCollaboratorInfo": {
+            "type": "object",
+            "properties": {
+                "added_by": {
+                    "type": "string"
+                },
+                "collaborator_role": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "exam_id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "ExamGradeCategoryResult": {
+            "type": "object",
+            "properties": {
+                "course_id": {
+                    "type": "integer"
+                },
+                "exam_id": {
+                    "type": "integer"
+                },
+                "grade_category_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "ExamInvitationInfo": {
+            "type": "object",
+            "properties": {
+                "accepted_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "exam_id": {
+                    "type": "integer"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "invitation_id": {
+                    "type": "integer"
+                },
+                "invited_by": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "ExamParticipantInfo": {
+            "type": "object",
+            "properties": {
+                "added_by": {
+                    "type": "string"
+                },
+                "client_ip_changes": {
+                    "type": "integer",
+                    "default": 0
+                },
+                "client_mismatches": {
+                    "description": "ClientMismatches is the number of requests received from a client\nother than the one the attempt of this participant is bound to.\nClientIPChanges is the number of times the ip address of the client\nhas changed during the attempt (which is allowed).\nThey're only filled for users who can set score for the exam.",
+                    "type": "integer",
+                    "default": 0
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "exam_id": {
+                    "type": "integer"
+                },
+                "final_score": {
+                    "type": "string"
+                },
+                "full_name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "string"
+                },
+                "scored_by": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "ExamPracticeModeResult": {
+            "type": "object",
+            "properties": {
+                "exam_id": {
+                    "type": "integer"
+                },
+                "is_practice": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "ExamPrerequisiteInfo": {
+            "type": "object",
+            "properties": {
+                "current_value": {
+                    "description": "CurrentValue is the best score percentage of the user in the required\nexam, or their current level in the topic; only filled for unmet\nprerequisites.",
+                    "type": "number"
+                },
+                "description": {
+                    "description": "Description is a human-readable explanation of the prerequisite.",
+                    "type": "string"
+                },
+                "min_level": {
+                    "type": "integer"
+                },
+                "min_score": {
+                    "type": "number"
+                },
+                "prerequisite_id": {
+                    "type": "integer"
+                },
+                "prerequisite_type": {
+                    "type": "string"
+                },
+                "required_exam_id": {
+                    "type": "integer"
+                },
+                "required_exam_title": {
+                    "type": "string"
+                },
+                "topic_id": {
+                    "type": "integer"
+                },
+                "topic_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "ExamPreviewInfo": {
+            "type": "object",
+            "properties": {
+                "deadline": {
+                    "description": "Deadline is the time the preview runs out of time, the same as for\na student who has started their attempt at StartedAt.",
+                    "type": "string"
+                },
+                "elapsed_seconds": {
+                    "type": "integer"
+                },
+                "exam_id": {
+                    "type": "integer"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "is_overtime": {
+                    "type": "boolean"
+                },
+                "preview_id": {
+                    "type": "integer"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "time_limit": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "ExamPreviewResult": {
+            "type": "object",
+            "properties": {
+                "breakdown": {
+                    "description": "Breakdown is the computed score of the preview; it's only set once\nthe preview has been finished.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/PreviewScoreBreakdown"
+                        }
+                    ]
+                },
+                "preview": {
+                    "$ref": "#/definitions/ExamPreviewInfo"
+                },
+                "questions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ExamQuestionInfo"
+                    }
+                },
+                "sections": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ExamSectionInfo"
+                    }
+                }
+            }
+        },
+        "ExamQuestionInfo": {
+            "type": "object",
+            "properties": {
+                "attachments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ExamAttachmentInfo"
+                    }
+                },
+                "content_format": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "explanation": {
+                    "description": "Explanation is only set for the users who can peek the questions of\nthe exam, and for the students of a practice exam once they have\nanswered the question.",
+                    "type": "string"
+                },
+                "metadata": {
+                    "description": "Metadata is only set for the users who can peek the questions of\nthe exam.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/ExamQuestionMetadataInfo"
+                        }
+                    ]
+                },
+                "option1": {
+                    "type": "string"
+                },
+                "option2": {
+                    "type": "string"
+                },
+                "option3": {
+                    "type": "string"
+                },
+                "option4": {
+                    "type": "string"
+                },
+                "points": {
+                    "description": "Points is the maximum number of points the question is worth.",
+                    "type": "number"
+                },
+                "question_id": {
+                    "type": "integer"
+                },
+                "question_order": {
+                    "type": "integer"
+                },
+                "question_title": {
+                    "type": "string"
+                },
+                "rendered": {
+                    "description": "Rendered is the safe HTML variant of the content; it's only set if\nit has been requested.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/RenderedQuestionContent"
+                        }
+                    ]
+                },
+                "section_id": {
+                    "type": "integer"
+                },
+                "user_answer": {
+                    "$ref": "#/definitions/AnsweredQuestionInfo"
+                }
+            }
+        },
+        "ExamQuestionIrtResult": {
+            "type": "object",
+            "properties": {
+                "correct_option": {
+                    "type": "string"
+                },
+                "difficulty": {
+                    "type": "number"
+                },
+                "discrimination": {
+                    "type": "number"
+                },
+                "exam_id": {
+                    "type": "integer"
+                },
+                "guessing": {
+                    "type": "number"
+                },
+                "question_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "ExamQuestionMetadataInfo": {
+            "type": "object",
+            "properties": {
+                "difficulty_level": {
+                    "type": "string"
+                },
+                "learning_objectives": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "topic_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "ExamRetakePolicyResult": {
+            "type": "object",
+            "properties": {
+                "attempt_cooldown": {
+                    "type": "integer"
+                },
+                "exam_id": {
+                    "type": "integer"
+                },
+                "grading_policy": {
+                    "type": "string"
+                },
+                "max_attempts": {
+                    "type": "integer"
+                }
+            }
+        },
+        "ExamReviewCommentInfo": {
+            "type": "object",
+            "properties": {
+                "author_id": {
+                    "type": "string"
+                },
+                "comment_id": {
+                    "type": "integer"
+                },
+                "comment_text": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "exam_id": {
+                    "type": "integer"
+                },
+                "is_resolved": {
+                    "type": "boolean"
+                },
+                "question_id": {
+                    "type": "integer"
+                },
+                "resolved_at": {
+                    "type": "string"
+                },
+                "resolved_by": {
+                    "type": "string"
+                }
+            }
+        },
+        "ExamReviewQueueEntry": {
+            "type": "object",
+            "properties": {
+                "created_by": {
+                    "type": "string"
+                },
+                "exam_date": {
+                    "type": "string"
+                },
+                "exam_id": {
+                    "type": "integer"
+                },
+                "exam_title": {
+                    "type": "string"
+                },
+                "submitted_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "ExamReviewStatusResult": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "$ref": "#/definitions/ExamReviewCommentInfo"
+                },
+                "exam_id": {
+                    "type": "integer"
+                },
+                "review_status": {
+                    "type": "string"
+                },
+                "reviewed_at": {
+                    "type": "string"
+                },
+                "reviewed_by": {
+                    "type": "string"
+                },
+                "submitted_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "ExamSectionInfo": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "duration": {
+                    "type": "integer"
+                },
+                "exam_id": {
+                    "type": "integer"
+                },
+                "is_forward_only": {
+                    "type": "boolean"
+                },
+                "progress": {
+                    "description": "Progress is the state of the user's attempt in the section; it's\nnull if the user is not taking the exam.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/ExamSectionProgressInfo"
+                        }
+                    ]
+                },
+                "section_id": {
+                    "type": "integer"
+                },
+                "section_order": {
+                    "type": "integer"
+                },
+                "section_title": {
+                    "type": "string"
+                }
+            }
+        },
+        "ExamSectionProgressInfo": {
+            "type": "object",
+            "properties": {
+                "deadline": {
+                    "type": "string"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Status is one of upcoming, ongoing, open, locked or expired; the\nquestions of the section can only be answered while it's ongoing\nor open.",
+                    "type": "string"
+                }
+            }
+        },
+        "ExamSeriesInfo": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "generate_ahead": {
+                    "type": "integer"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "last_generated_at": {
+                    "type": "string"
+                },
+                "recurrence_rule": {
+                    "type": "string"
+                },
+                "series_id": {
+                    "type": "integer"
+                },
+                "series_title": {
+                    "type": "string"
+                },
+                "starts_at": {
+                    "type": "string"
+                },
+                "template_exam_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "ExamSeriesOccurrenceInfo": {
+            "type": "object",
+            "properties": {
+                "duration": {
+                    "type": "integer"
+                },
+                "exam_date": {
+                    "type": "string"
+                },
+                "exam_id": {
+                    "description": "ExamId is the id of the exam created for this occurrence; it's\nnull if the occurrence is not materialised yet.",
+                    "type": "integer"
+                },
+                "exam_title": {
+                    "description": "ExamTitle, ExamDate and Duration are the overrides of this\noccurrence (null means no override).",
+                    "type": "string"
+                },
+                "is_skipped": {
+                    "type": "boolean"
+                },
+                "occurrence_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "ExamWaitlistEntryInfo": {
+            "type": "object",
+            "properties": {
+                "added_by": {
+                    "type": "string"
+                },
+                "coupon_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "FinishExamAttemptData": {
+            "type": "object",
+            "properties": {
+                "exam_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "FinishExamAttemptResult": {
+            "type": "object",
+            "properties": {
+                "attempt_number": {
+                    "type": "integer"
+                },
+                "attempts_left": {
+                    "description": "AttemptsLeft is the number of attempts the user still has in the\nexam; -1 means unlimited.",
+                    "type": "integer"
+                },
+                "exam_id": {
+                    "type": "integer"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "retake_available_in": {
+                    "description": "RetakeAvailableIn is the time (in seconds) the user has to wait\nbefore being able to start a new attempt.",
+                    "type": "integer",
+                    "default": 0
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "FinishExamPreviewData": {
+            "type": "object",
+            "properties": {
+                "preview_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "GetAllUserTopicStatsResult": {
+            "type": "object",
+            "properties": {
+                "stats": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/UserTopicStatInfo"
+                    }
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "GetAttemptReviewResult": {
+            "type": "object",
+            "properties": {
+                "attempt": {
+                    "$ref": "#/definitions/ExamAttemptInfo"
+                },
+                "attempt_review_policy": {
+                    "type": "string"
+                },
+                "exam_id": {
+                    "type": "integer"
+                },
+                "exam_title": {
+                    "type": "string"
+                },
+                "pending_count": {
+                    "type": "integer"
+                },
+                "points_earned": {
+                    "type": "number"
+                },
+                "questions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/AttemptReviewQuestionInfo"
+                    }
+                },
+                "results_released_at": {
+                    "type": "string"
+                },
+                "total_points": {
+                    "description": "TotalPoints is the sum of the points of the questions; PointsEarned\nis the sum of the points earned by the answers which are graded.\nPendingCount is the number of answers still waiting to be graded.",
+                    "type": "number"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "GetCouponsResult": {
+            "type": "object",
+            "properties": {
+                "coupons": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/CouponInfo"
+                    }
+                }
+            }
+        },
+        "GetCourseGradebookResult": {
+            "type": "object",
+            "properties": {
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/GradeCategoryInfo"
+                    }
+                },
+                "course_id": {
+                    "type": "integer"
+                },
+                "course_name": {
+                    "type": "string"
+                },
+                "exams": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/GradebookExamInfo"
+                    }
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/GradebookRowInfo"
+                    }
+                }
+            }
+        },
+        "GetCourseInfoResult": {
+            "type": "object",
+            "properties": {
+                "added_by": {
+                    "type": "string"
+                },
+                "course_description": {
+                    "type": "string"
+                },
+                "course_id": {
+                    "type": "integer"
+                },
+                "course_name": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "topic_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "GetCourseParticipantsData": {
+            "type": "object",
+            "properties": {
+                "course_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "GetCourseParticipantsResult": {
+            "type": "object",
+            "properties": {
+                "participants": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/CourseParticipantInfo"
+                    }
+                }
+            }
+        },
+        "GetCreatedCoursesData": {
+            "type": "object",
+            "properties": {
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "GetCreatedCoursesResult": {
+            "type": "object",
+            "properties": {
+                "courses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/SearchedCourseInfo"
+                    }
+                }
+            }
+        },
+        "GetExamAccommodationsResult": {
+            "type": "object",
+            "properties": {
+                "accommodations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ExamAccommodationInfo"
+                    }
+                },
+                "exam_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "GetExamAttachmentsResult": {
+            "type": "object",
+            "properties": {
+                "attachments": {
+                    "description": "Attachments contains the files attached to the question and to its\noptions, alongside the files the user has uploaded as their answer\nin their latest attempt.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ExamAttachmentInfo"
+                    }
+                },
+                "exam_id": {
+                    "type": "integer"
+                },
+                "question_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "GetExamAttemptsResult": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ExamAttemptInfo"
+                    }
+                },
+                "exam_id": {
+                    "type": "integer"
+                },
+                "final_score": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "GetExamCollaboratorsResult": {
+            "type": "object",
+            "properties": {
+                "can_manage": {
+                    "type": "boolean"
+                },
+                "collaborators": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ExamCollaboratorInfo"
+                    }
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "exam_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "GetExamInfoResult": {
+            "type": "object",
+            "properties": {
+                "access_code_type": {
+                    "type": "string",
+                    "default": "none"
+                },
+                "attempt_cooldown": {
+                    "type": "integer",
+                    "default": 0
+                },
+                "attempt_review_policy": {
+                    "description": "AttemptReviewPolicy is one of never, after_close or after_release.\nCanReviewAttempt is true if the user can review their attempts in\nthe exam by now.",
+                    "type": "string"
+                },
+                "attempts_left": {
+                    "description": "AttemptsLeft is the number of attempts the user still has in the\nexam; -1 means unlimited.",
+                    "type": "integer"
+                },
+                "can_add_others_to_exam": {
+                    "type": "boolean",
+                    "default": false
+                },
+                "can_edit_question": {
+                    "type": "boolean",
+                    "default": false
+                },
+                "can_participate": {
+                    "type": "boolean",
+                    "default": false
+                },
+                "can_review": {
+                    "type": "boolean",
+                    "default": false
+                },
+                "can_review_attempt": {
+                    "type": "boolean",
+                    "default": false
+                },
+                "capacity": {
+                    "description": "Capacity is the maximum number of participants of the exam (null\nmeans unlimited); WaitlistPosition is the position of the user in\nthe waitlist of the exam (0 if the user is not waiting).",
+                    "type": "integer"
+                },
+                "closes_at": {
+                    "type": "string"
+                },
+                "course_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "deadline": {
+                    "description": "Deadline is the personal deadline of the user in the exam.",
+                    "type": "string"
+                },
+                "deadline_policy": {
+                    "type": "string",
+                    "default": "auto_submit"
+                },
+                "duration": {
+                    "type": "integer"
+                },
+                "exam_date": {
+                    "type": "string"
+                },
+                "exam_description": {
+                    "type": "string"
+                },
+                "exam_id": {
+                    "type": "integer"
+                },
+                "exam_title": {
+                    "type": "string"
+                },
+                "finishes_in": {
+                    "type": "integer",
+                    "default": 0
+                },
+                "grace_period": {
+                    "type": "integer",
+                    "default": 0
+                },
+                "grade_category_id": {
+                    "description": "GradeCategoryId is the grade category of the course the exam is\ncounted in; nil means it's not counted in the course grade.",
+                    "type": "integer"
+                },
+                "grading_policy": {
+                    "type": "string",
+                    "default": "latest"
+                },
+                "has_finished": {
+                    "type": "boolean",
+                    "default": false
+                },
+                "has_participated": {
+                    "type": "boolean",
+                    "default": false
+                },
+                "has_started": {
+                    "type": "boolean"
+                },
+                "is_adaptive": {
+                    "description": "IsAdaptive is true if the questions of the exam are given one at a\ntime (through the nextQuestion endpoint).",
+                    "type": "boolean",
+                    "default": false
+                },
+                "is_practice": {
+                    "description": "IsPractice is true if the exam is a practice exam: its attempts are\nunlimited, the answers are checked right away and it's not graded.",
+                    "type": "boolean",
+                    "default": false
+                },
+                "is_public": {
+                    "type": "boolean"
+                },
+                "is_windowed": {
+                    "description": "IsWindowed is true if the users can start the exam at any time\nbetween OpensAt and ClosesAt.",
+                    "type": "boolean",
+                    "default": false
+                },
+                "latest_start_at": {
+                    "description": "LatestStartAt is the latest time the user can start an attempt.",
+                    "type": "string"
+                },
+                "max_attempts": {
+                    "type": "integer",
+                    "default": 1
+                },
+                "opens_at": {
+                    "description": "OpensAt and ClosesAt are the window of the exam for the user.",
+                    "type": "string"
+                },
+                "participants_count": {
+                    "type": "integer",
+                    "default": 0
+                },
+                "price": {
+                    "type": "string"
+                },
+                "question_count": {
+                    "type": "integer",
+                    "default": 0
+                },
+                "requires_access_code": {
+                    "type": "boolean",
+                    "default": false
+                },
+                "results_released_at": {
+                    "type": "string"
+                },
+                "retake_available_in": {
+                    "description": "RetakeAvailableIn is the time (in seconds) the user has to wait\nbefore being able to start a new attempt.",
+                    "type": "integer",
+                    "default": 0
+                },
+                "review_status": {
+                    "description": "ReviewStatus is one of \"draft\", \"in_review\" or \"published\"; only\nthe published exams can be joined. CanReview is true if the user\ncan approve the exam (or send it back to draft).",
+                    "type": "string"
+                },
+                "starts_in": {
+                    "type": "integer",
+                    "default": 0
+                },
+                "unmet_prerequisites": {
+                    "description": "UnmetPrerequisites is the list of the prerequisites of the exam that\nthe user has not met yet (only filled if the user has not participated).",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ExamPrerequisiteInfo"
+                    }
+                },
+                "waitlist_position": {
+                    "type": "integer",
+                    "default": 0
+                }
+            }
+        },
+        "GetExamInvitationInfoResult": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "exam_date": {
+                    "type": "string"
+                },
+                "exam_id": {
+                    "type": "integer"
+                },
+                "exam_title": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "has_account": {
+                    "description": "HasAccount is false if the invitee has to create an account\nbefore accepting the invitation.",
+                    "type": "boolean"
+                },
+                "invitation_id": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "GetExamInvitationsResult": {
+            "type": "object",
+            "properties": {
+                "invitations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ExamInvitationInfo"
+                    }
+                }
+            }
+        },
+        "GetExamParticipantsData": {
+            "type": "object",
+            "properties": {
+                "exam_id": {
+                    "type": "integer"
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                }
+            }
+        },
+        "GetExamParticipantsResult": {
+            "type": "object",
+            "properties": {
+                "can_set_score": {
+                    "type": "boolean",
+                    "default": false
+                },
+                "exam_id": {
+                    "type": "integer"
+                },
+                "participants": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ExamParticipantInfo"
+                    }
+                }
+            }
         },
-        "AnswerQuestionData": {
+        "GetExamPrerequisitesResult": {
             "type": "object",
             "properties": {
-                "answer_text": {
-                    "type": "string"
+                "exam_id": {
+                    "type": "integer"
                 },
-                "chosen_option": {
-                    "type": "string"
+                "prerequisites": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ExamPrerequisiteInfo"
+                    }
+                }
+            }
+        },
+        "GetExamPreviewsResult": {
+            "type": "object",
+            "properties": {
+                "exam_id": {
+                    "type": "integer"
+                },
+                "previews": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ExamPreviewInfo"
+                    }
+                }
+            }
+        },
+        "GetExamQuestionsData": {
+            "type": "object",
+            "properties": {
+                "attempt_number": {
+                    "description": "AttemptNumber is the attempt whose answers should be returned;\n0 means the latest attempt.",
+                    "type": "integer"
                 },
                 "exam_id": {
                     "type": "integer"
                 },
-                "question_id": {
+                "limit": {
                     "type": "integer"
                 },
-                "seconds_taken": {
+                "offset": {
+                    "type": "integer"
+                },
+                "pov": {
+                    "description": "Point of view",
+                    "type": "string"
+                },
+                "render_html": {
+                    "description": "RenderHtml asks for the safe HTML variant of the content of the\nquestions to be returned alongside their source.",
+                    "type": "boolean"
+                },
+                "section_id": {
+                    "description": "SectionId limits the questions to the ones of this section.",
                     "type": "integer"
                 }
             }
         },
-        "AnswerQuestionResult": {
+        "GetExamQuestionsResult": {
             "type": "object",
             "properties": {
-                "answered_at": {
+                "attempt_number": {
+                    "type": "integer"
+                },
+                "exam_id": {
+                    "type": "integer"
+                },
+                "pov": {
+                    "description": "Point of view",
                     "type": "string"
                 },
-                "answered_by": {
+                "questions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ExamQuestionInfo"
+                    }
+                },
+                "sections": {
+                    "description": "Sections are the sections of the exam (alongside the progress of the\nuser in them, while they are taking the exam).",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ExamSectionInfo"
+                    }
+                }
+            }
+        },
+        "GetExamReviewCommentsResult": {
+            "type": "object",
+            "properties": {
+                "comments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ExamReviewCommentInfo"
+                    }
+                },
+                "exam_id": {
+                    "type": "integer"
+                },
+                "review_status": {
                     "type": "string"
+                }
+            }
+        },
+        "GetExamReviewQueueResult": {
+            "type": "object",
+            "properties": {
+                "exams": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ExamReviewQueueEntry"
+                    }
+                }
+            }
+        },
+        "GetExamSectionsResult": {
+            "type": "object",
+            "properties": {
+                "attempt_number": {
+                    "type": "integer"
                 },
                 "exam_id": {
                     "type": "integer"
                 },
-                "question_id": {
+                "sections": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ExamSectionInfo"
+                    }
+                }
+            }
+        },
+        "GetExamSeriesResult": {
+            "type": "object",
+            "properties": {
+                "occurrences": {
+                    "description": "Occurrences contains the materialised, edited and skipped occurrences\nof the series, alongside its next upcoming occurrences.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ExamSeriesOccurrenceInfo"
+                    }
+                },
+                "series": {
+                    "$ref": "#/definitions/ExamSeriesInfo"
+                }
+            }
+        },
+        "GetExamWaitlistResult": {
+            "type": "object",
+            "properties": {
+                "capacity": {
+                    "type": "integer"
+                },
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ExamWaitlistEntryInfo"
+                    }
+                },
+                "exam_id": {
                     "type": "integer"
                 }
             }
         },
-        "AnsweredQuestionInfo": {
+        "GetGivenExamData": {
             "type": "object",
             "properties": {
-                "answer": {
+                "added_by": {
                     "type": "string"
                 },
-                "chosen_option": {
+                "created_at": {
                     "type": "string"
                 },
-                "question_id": {
+                "exam_id": {
                     "type": "integer"
                 },
-                "seconds_taken": {
-                    "type": "integer"
+                "final_score": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "string"
+                },
+                "scored_by": {
+                    "type": "string"
                 },
                 "user_id": {
                     "type": "string"
                 }
             }
         },
-        "AuthResult": {
+        "GetGradeCategoriesResult": {
             "type": "object",
             "properties": {
-                "access_token": {
-                    "type": "string"
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/GradeCategoryInfo"
+                    }
                 },
-                "expiration": {
+                "course_id": {
                     "type": "integer"
                 },
+                "total_weight": {
+                    "type": "number"
+                }
+            }
+        },
+        "GetMeResult": {
+            "type": "object",
+            "properties": {
+                "can_review_exams": {
+                    "description": "CanReviewExams is true if the user can review (and approve) the\nexams of the others.",
+                    "type": "boolean"
+                },
                 "full_name": {
                     "type": "string"
                 },
-                "refresh_token": {
+                "role": {
+                    "$ref": "#/definitions/UserRole"
+                },
+                "user_id": {
                     "type": "string"
+                }
+            }
+        },
+        "GetMyCourseGradesResult": {
+            "type": "object",
+            "properties": {
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/GradeCategoryInfo"
+                    }
                 },
-                "role": {
+                "course_id": {
+                    "type": "integer"
+                },
+                "course_name": {
                     "type": "string"
                 },
+                "exams": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/GradebookExamInfo"
+                    }
+                },
+                "row": {
+                    "$ref": "#/definitions/GradebookRowInfo"
+                }
+            }
+        },
+        "GetSimilarityCheckResult": {
+            "type": "object",
+            "properties": {
+                "check": {
+                    "$ref": "#/definitions/SimilarityCheckInfo"
+                },
+                "matches": {
+                    "description": "Matches are the reported pairs of answers (the most similar first);\nthey are only set once the check has completed.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/SimilarityMatchInfo"
+                    }
+                }
+            }
+        },
+        "GetSimilarityChecksResult": {
+            "type": "object",
+            "properties": {
+                "checks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/SimilarityCheckInfo"
+                    }
+                },
+                "exam_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "GetTopicInfoResult": {
+            "type": "object",
+            "properties": {
+                "topic_id": {
+                    "type": "integer"
+                },
+                "topic_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "GetUserCoursesData": {
+            "type": "object",
+            "properties": {
                 "user_id": {
                     "type": "string"
                 }
             }
         },
-        "BanUserData": {
+        "GetUserCoursesResult": {
+            "type": "object",
+            "properties": {
+                "courses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/UserParticipatedCourse"
+                    }
+                }
+            }
+        },
+        "GetUserInfoResult": {
             "type": "object",
             "properties": {
                 "ban_reason": {
                     "type": "string"
                 },
+                "created_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "full_name": {
+                    "type": "string"
+                },
                 "is_banned": {
                     "type": "boolean"
                 },
+                "role": {
+                    "$ref": "#/definitions/UserRole"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "GetUserOngoingExamsResult": {
+            "type": "object",
+            "properties": {
+                "exams": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/UserOngoingExamInfo"
+                    }
+                }
+            }
+        },
+        "GetUserTopicStatData": {
+            "type": "object",
+            "properties": {
+                "topic_id": {
+                    "type": "integer"
+                },
                 "user_id": {
                     "type": "string"
                 }
             }
         },
-        "BanUserResult": {
+        "GetUserTopicStatResult": {
             "type": "object",
             "properties": {
-                "ban_reason": {
-                    "type": "string"
-                },
-                "is_banned": {
-                    "type": "boolean"
-                },
-                "user_id": {
-                    "type": "string"
+                "stat": {
+                    "$ref": "#/definitions/UserTopicStatInfo"
                 }
             }
         },
-        "ChangePasswordData": {
+        "GetUsersExamHistoryData": {
             "type": "object",
             "properties": {
-                "lang": {
-                    "type": "string",
-                    "default": "en"
+                "limit": {
+                    "type": "integer"
                 },
-                "new_password": {
-                    "type": "string"
+                "offset": {
+                    "type": "integer"
                 },
                 "user_id": {
                     "type": "string"
                 }
             }
         },
-        "ChangePasswordResult": {
+        "GetUsersExamHistoryResult": {
             "type": "object",
             "properties": {
-                "email_sent": {
-                    "type": "boolean"
-                },
-                "lang": {
-                    "type": "string",
-                    "default": "en"
-                },
-                "password_changed": {
-                    "type": "boolean"
+                "exams": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/UserExamHistoryInfo"
+                    }
                 }
             }
         },
-        "ConfirmAccountData": {
+        "GetWalletTransactionsResult": {
             "type": "object",
             "properties": {
-                "confirm_token": {
-                    "type": "string"
+                "transactions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/WalletTransactionInfo"
+                    }
+                }
+            }
+        },
+        "GradeAnswerData": {
+            "type": "object",
+            "properties": {
+                "attempt_number": {
+                    "type": "integer"
                 },
-                "lt_token": {
-                    "type": "string"
+                "exam_id": {
+                    "type": "integer"
                 },
-                "raw_password": {
+                "grader_comment": {
                     "type": "string"
                 },
-                "rl_token": {
-                    "type": "string"
+                "points_earned": {
+                    "description": "PointsEarned is the number of points given to the answer, between\n0 and the points of the question; if it's not set, the answer is\ngraded by the answer key of the question (if any).",
+                    "type": "number"
+                },
+                "question_id": {
+                    "type": "integer"
                 },
                 "user_id": {
                     "type": "string"
                 }
             }
         },
-        "ConfirmChangePasswordData": {
+        "GradeAnswerResult": {
             "type": "object",
             "properties": {
-                "new_password": {
-                    "type": "string"
+                "attempt_number": {
+                    "type": "integer"
                 },
-                "rq_id": {
+                "exam_id": {
+                    "type": "integer"
+                },
+                "graded_at": {
                     "type": "string"
                 },
-                "rt_hash": {
+                "graded_by": {
                     "type": "string"
                 },
-                "rt_param": {
+                "grader_comment": {
                     "type": "string"
                 },
-                "rt_verifier": {
+                "is_graded": {
+                    "type": "boolean"
+                },
+                "points": {
+                    "type": "number"
+                },
+                "points_earned": {
+                    "type": "number"
+                },
+                "question_id": {
+                    "type": "integer"
+                },
+                "user_id": {
                     "type": "string"
                 }
             }
         },
-        "CourseParticipantInfo": {
+        "GradeCategoryInfo": {
             "type": "object",
             "properties": {
-                "full_name": {
+                "added_by": {
                     "type": "string"
                 },
-                "user_id": {
+                "category_id": {
+                    "type": "integer"
+                },
+                "category_name": {
+                    "type": "string"
+                },
+                "course_id": {
+                    "type": "integer"
+                },
+                "created_at": {
                     "type": "string"
+                },
+                "drop_lowest": {
+                    "type": "integer"
+                },
+                "weight": {
+                    "type": "number"
                 }
             }
         },
-        "CreateCourseData": {
+        "GradebookCategoryGrade": {
             "type": "object",
             "properties": {
-                "course_description": {
-                    "type": "string"
-                },
-                "course_name": {
-                    "type": "string"
+                "average": {
+                    "description": "Average is nil if none of the exams of the category are scored yet.",
+                    "type": "number"
                 },
-                "topic_id": {
+                "category_id": {
                     "type": "integer"
                 }
             }
         },
-        "CreateCourseResult": {
+        "GradebookExamInfo": {
             "type": "object",
             "properties": {
-                "added_by": {
-                    "type": "string"
-                },
-                "course_description": {
+                "exam_date": {
                     "type": "string"
                 },
-                "course_id": {
+                "exam_id": {
                     "type": "integer"
                 },
-                "course_name": {
-                    "type": "string"
-                },
-                "created_at": {
+                "exam_title": {
                     "type": "string"
                 },
-                "topic_id": {
+                "grade_category_id": {
+                    "description": "GradeCategoryId is nil if the exam is not counted in the course grade.",
                     "type": "integer"
                 }
             }
         },
-        "CreateExamData": {
+        "GradebookRowInfo": {
             "type": "object",
-            "required": [
-                "course_id",
-                "exam_description",
-                "exam_title"
-            ],
             "properties": {
-                "course_id": {
-                    "type": "integer"
-                },
-                "duration": {
-                    "type": "integer",
-                    "default": 60
-                },
-                "exam_date": {
-                    "type": "integer"
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/GradebookCategoryGrade"
+                    }
                 },
-                "exam_description": {
-                    "type": "string"
+                "course_grade": {
+                    "description": "CourseGrade is the weighted average of the grades of the categories\nscored so far; nil if there are none.",
+                    "type": "number"
                 },
-                "exam_title": {
+                "full_name": {
                     "type": "string"
                 },
-                "is_public": {
-                    "type": "boolean",
-                    "default": false
+                "scores": {
+                    "description": "Scores are in the same order as the exams of the gradebook.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/GradebookScoreInfo"
+                    }
                 },
-                "price": {
-                    "type": "string",
-                    "default": "0T"
+                "user_id": {
+                    "type": "string"
                 }
             }
         },
-        "CreateExamQuestionData": {
+        "GradebookScoreInfo": {
             "type": "object",
             "properties": {
-                "description": {
-                    "type": "string"
-                },
                 "exam_id": {
                     "type": "integer"
                 },
-                "option1": {
-                    "type": "string"
-                },
-                "option2": {
-                    "type": "string"
-                },
-                "option3": {
+                "final_score": {
                     "type": "string"
                 },
-                "option4": {
-                    "type": "string"
+                "is_dropped": {
+                    "description": "IsDropped is true if the score is one of the lowest scores of its\ncategory, which are not counted.",
+                    "type": "boolean"
                 },
-                "question_title": {
-                    "type": "string"
+                "score_percentage": {
+                    "description": "ScorePercentage is nil if the exam has not been scored yet.",
+                    "type": "number"
                 }
             }
         },
-        "CreateExamQuestionResult": {
+        "InviteToExamData": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "type": "string"
-                },
-                "description": {
-                    "type": "string"
+                "emails": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
                 "exam_id": {
                     "type": "integer"
                 },
-                "option1": {
-                    "type": "string"
+                "user_ids": {
+                    "description": "UserIds and Emails are the invitees; emails which don't belong to\nany account are invited to create one.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "InviteToExamResult": {
+            "type": "object",
+            "properties": {
+                "emails_sent": {
+                    "description": "EmailsSent is false if the email client is not loaded, in which\ncase the invitees have to be informed some other way.",
+                    "type": "boolean"
                 },
-                "option2": {
+                "invitations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ExamInvitationInfo"
+                    }
+                },
+                "skipped": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/SkippedInviteeInfo"
+                    }
+                }
+            }
+        },
+        "LoginData": {
+            "type": "object",
+            "properties": {
+                "captcha_answer": {
                     "type": "string"
                 },
-                "option3": {
+                "captcha_id": {
                     "type": "string"
                 },
-                "option4": {
+                "client_rid": {
                     "type": "string"
                 },
-                "question_id": {
-                    "type": "integer"
+                "password": {
+                    "type": "string"
                 },
-                "question_title": {
+                "user_id": {
                     "type": "string"
                 }
             }
         },
-        "CreateExamResult": {
+        "LoginResult": {
             "type": "object",
             "properties": {
-                "course_id": {
-                    "type": "integer"
-                },
-                "created_at": {
-                    "type": "string"
-                },
-                "created_by": {
+                "access_token": {
                     "type": "string"
                 },
-                "duration": {
+                "expiration": {
                     "type": "integer"
                 },
-                "exam_date": {
+                "full_name": {
                     "type": "string"
                 },
-                "exam_id": {
-                    "type": "integer"
+                "refresh_token": {
+                    "type": "string"
                 },
-                "is_public": {
-                    "type": "boolean"
+                "role": {
+                    "$ref": "#/definitions/UserRole"
                 },
-                "price": {
-                    "type": "string"
-                }
-            }
-        },
-        "CreateNewTopicData": {
-            "type": "object",
-            "properties": {
-                "topic_name": {
+                "user_id": {
                     "type": "string"
                 }
             }
         },
-        "CreateNewTopicResult": {
+        "NextExamQuestionData": {
             "type": "object",
             "properties": {
-                "topic_id": {
+                "exam_id": {
                     "type": "integer"
                 },
-                "topic_name": {
-                    "type": "string"
+                "render_html": {
+                    "type": "boolean"
                 }
             }
         },
-        "CreateUserData": {
+        "NextExamQuestionResult": {
             "type": "object",
-            "properties": {
-                "email": {
-                    "type": "string"
-                },
-                "full_name": {
-                    "type": "string"
+            "properties": {
+                "ability_estimate": {
+                    "description": "AbilityEstimate and AbilityStdError are the final estimate of the\nability of the user; they are only set once the attempt has ended.",
+                    "type": "number"
                 },
-                "password": {
-                    "type": "string"
+                "ability_std_error": {
+                    "type": "number"
                 },
-                "phone_number": {
-                    "type": "string"
+                "answered_count": {
+                    "type": "integer"
                 },
-                "primary_language": {
-                    "type": "string"
+                "attempt_number": {
+                    "type": "integer"
                 },
-                "role": {
-                    "$ref": "#/definitions/UserRole"
+                "exam_id": {
+                    "type": "integer"
                 },
-                "setup_completed": {
+                "is_finished": {
                     "type": "boolean"
                 },
-                "user_address": {
-                    "type": "string"
+                "item_index": {
+                    "description": "ItemIndex is the position of the question in the attempt (starting\nfrom 1); Question is null once the attempt has ended.",
+                    "type": "integer"
                 },
-                "user_id": {
-                    "type": "string"
+                "question": {
+                    "$ref": "#/definitions/ExamQuestionInfo"
                 }
             }
         },
-        "CreateUserResult": {
+        "NextExamSectionData": {
             "type": "object",
             "properties": {
-                "email": {
-                    "type": "string"
-                },
-                "full_name": {
-                    "type": "string"
-                },
-                "role": {
-                    "type": "string"
-                },
-                "user_id": {
-                    "type": "string"
+                "exam_id": {
+                    "type": "integer"
                 }
             }
         },
-        "EditCourseData": {
+        "NextExamSectionResult": {
             "type": "object",
             "properties": {
-                "course_description": {
-                    "type": "string"
-                },
-                "course_id": {
+                "attempt_number": {
                     "type": "integer"
                 },
-                "course_name": {
-                    "type": "string"
+                "current_section_id": {
+                    "description": "CurrentSectionId is the section the user has moved to; it's null if\nthere are no sections left.",
+                    "type": "integer"
                 },
-                "topic_id": {
+                "exam_id": {
                     "type": "integer"
+                },
+                "sections": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ExamSectionInfo"
+                    }
                 }
             }
         },
-        "EditCourseResult": {
+        "ParticipateExamData": {
             "type": "object",
             "properties": {
-                "added_by": {
+                "access_code": {
+                    "description": "AccessCode is the code announced by the invigilator. It's only\nrequired when the user is participating in the exam themselves\nand the exam is protected by an access code.",
                     "type": "string"
                 },
-                "course_description": {
+                "coupon_code": {
+                    "description": "CouponCode is an optional discount coupon for the price of the exam.",
                     "type": "string"
                 },
-                "course_id": {
+                "exam_id": {
+                    "description": "ExamId is the exam the user is trying to participate in.",
                     "type": "integer"
                 },
-                "course_name": {
+                "price": {
+                    "description": "Price is the price of the exam that user has already paid.",
                     "type": "string"
                 },
-                "created_at": {
+                "user_id": {
+                    "description": "UserId is the user who is trying to participate in the exam.\nIf the user is trying to participate in the exam themselves,\nthis field should be set to their own user id.",
                     "type": "string"
                 }
             }
         },
-        "EditExamData": {
+        "ParticipateExamResult": {
             "type": "object",
             "properties": {
-                "course_id": {
-                    "type": "integer"
-                },
-                "duration": {
-                    "type": "integer",
-                    "default": 60
-                },
-                "exam_date": {
-                    "type": "integer"
+                "added_by": {
+                    "type": "string"
                 },
-                "exam_description": {
+                "created_at": {
                     "type": "string"
                 },
                 "exam_id": {
                     "type": "integer"
                 },
-                "exam_title": {
-                    "type": "string"
+                "finishes_in": {
+                    "type": "integer",
+                    "default": 0
                 },
-                "is_public": {
+                "is_waitlisted": {
+                    "description": "IsWaitlisted is true if the exam is full and the user has been put\non its waitlist instead; WaitlistPosition is their 1-based position.",
                     "type": "boolean",
                     "default": false
                 },
+                "original_price": {
+                    "type": "string"
+                },
                 "price": {
-                    "type": "string",
-                    "default": "0T"
+                    "description": "Price is the price actually paid for the exam (after the discount\nof the coupon), while OriginalPrice is the price of the exam.",
+                    "type": "string"
+                },
+                "question_count": {
+                    "type": "integer",
+                    "default": 0
+                },
+                "starts_in": {
+                    "type": "integer",
+                    "default": 0
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "waitlist_position": {
+                    "type": "integer",
+                    "default": 0
                 }
             }
         },
-        "EditExamQuestionData": {
+        "PreviewQuestionResult": {
             "type": "object",
             "properties": {
-                "description": {
-                    "type": "string"
-                },
-                "exam_id": {
-                    "type": "integer"
-                },
-                "option1": {
+                "answer": {
                     "type": "string"
                 },
-                "option2": {
+                "chosen_option": {
                     "type": "string"
                 },
-                "option3": {
+                "correct_option": {
                     "type": "string"
                 },
-                "option4": {
+                "explanation": {
                     "type": "string"
                 },
                 "question_id": {
@@ -2672,178 +10339,232 @@ const docTemplate = `{
                 },
                 "question_title": {
                     "type": "string"
+                },
+                "seconds_taken": {
+                    "type": "integer"
+                },
+                "section_id": {
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "Status is one of correct, incorrect, unanswered or needs_grading.",
+                    "type": "string"
                 }
             }
         },
-        "EditExamQuestionResult": {
+        "PreviewScoreBreakdown": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "type": "string"
+                "auto_graded_count": {
+                    "description": "AutoGradedCount is the number of the questions which have an answer\nkey; ScorePercentage is the score of the preview out of these ones,\nand is null if none of the questions have an answer key.",
+                    "type": "integer"
                 },
-                "description": {
-                    "type": "string"
+                "correct_count": {
+                    "type": "integer"
                 },
-                "exam_id": {
+                "incorrect_count": {
                     "type": "integer"
                 },
-                "option1": {
-                    "type": "string"
+                "needs_grading_count": {
+                    "type": "integer"
                 },
-                "option2": {
-                    "type": "string"
+                "questions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/PreviewQuestionResult"
+                    }
                 },
-                "option3": {
-                    "type": "string"
+                "questions_count": {
+                    "type": "integer"
                 },
-                "option4": {
-                    "type": "string"
+                "score_percentage": {
+                    "type": "number"
                 },
-                "question_id": {
+                "sections": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/PreviewSectionResult"
+                    }
+                },
+                "total_seconds_taken": {
                     "type": "integer"
                 },
-                "question_title": {
-                    "type": "string"
+                "unanswered_count": {
+                    "type": "integer"
                 }
             }
         },
-        "EditExamResult": {
+        "PreviewSectionResult": {
             "type": "object",
             "properties": {
-                "course_id": {
+                "correct_count": {
                     "type": "integer"
                 },
-                "created_at": {
-                    "type": "string"
-                },
-                "created_by": {
-                    "type": "string"
-                },
                 "duration": {
                     "type": "integer"
                 },
-                "exam_date": {
-                    "type": "string"
+                "incorrect_count": {
+                    "type": "integer"
                 },
-                "exam_description": {
-                    "type": "string"
+                "is_overtime": {
+                    "description": "IsOvertime is true if answering the questions of the section took\nlonger than its time limit.",
+                    "type": "boolean"
                 },
-                "exam_id": {
+                "needs_grading_count": {
                     "type": "integer"
                 },
-                "exam_title": {
-                    "type": "string"
+                "questions_count": {
+                    "type": "integer"
                 },
-                "is_public": {
-                    "type": "boolean"
+                "seconds_taken": {
+                    "type": "integer"
                 },
-                "price": {
+                "section_id": {
+                    "type": "integer"
+                },
+                "section_title": {
                     "type": "string"
+                },
+                "unanswered_count": {
+                    "type": "integer"
                 }
             }
         },
-        "EditUserData": {
+        "RefundTransactionData": {
             "type": "object",
             "properties": {
-                "email": {
+                "amount": {
+                    "description": "Amount is the amount to refund (e.g. \"50T\"); the whole refundable\namount of the transaction is refunded if it's not set.",
                     "type": "string"
                 },
-                "full_name": {
+                "description": {
                     "type": "string"
                 },
-                "phone_number": {
-                    "type": "string"
+                "transaction_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "RefundTransactionResult": {
+            "type": "object",
+            "properties": {
+                "transaction": {
+                    "$ref": "#/definitions/WalletTransactionInfo"
                 },
-                "user_address": {
-                    "type": "string"
+                "wallet": {
+                    "$ref": "#/definitions/WalletInfo"
+                }
+            }
+        },
+        "ReleaseExamResultsData": {
+            "type": "object",
+            "properties": {
+                "exam_id": {
+                    "type": "integer"
                 },
-                "user_id": {
-                    "type": "string"
+                "is_released": {
+                    "description": "IsReleased is false to withdraw the results released before.",
+                    "type": "boolean"
                 }
             }
         },
-        "EditUserResult": {
+        "ReleaseExamResultsResult": {
             "type": "object",
             "properties": {
-                "email": {
-                    "type": "string"
+                "exam_id": {
+                    "type": "integer"
                 },
-                "full_name": {
+                "is_released": {
+                    "type": "boolean"
+                },
+                "results_released_at": {
                     "type": "string"
                 },
-                "role": {
-                    "$ref": "#/definitions/UserRole"
+                "results_released_by": {
+                    "type": "string"
+                }
+            }
+        },
+        "RemoveExamAccommodationData": {
+            "type": "object",
+            "properties": {
+                "exam_id": {
+                    "type": "integer"
                 },
                 "user_id": {
                     "type": "string"
                 }
             }
         },
-        "EndpointError": {
+        "RemoveExamAccommodationResult": {
             "type": "object",
             "properties": {
-                "code": {
-                    "$ref": "#/definitions/APIErrorCode"
+                "exam_id": {
+                    "type": "integer"
                 },
-                "date": {
+                "removed": {
+                    "type": "boolean"
+                },
+                "user_id": {
                     "type": "string"
+                }
+            }
+        },
+        "RemoveExamCollaboratorData": {
+            "type": "object",
+            "properties": {
+                "exam_id": {
+                    "type": "integer"
                 },
-                "message": {
+                "user_id": {
                     "type": "string"
+                }
+            }
+        },
+        "RemoveExamCollaboratorResult": {
+            "type": "object",
+            "properties": {
+                "exam_id": {
+                    "type": "integer"
                 },
-                "origin": {
+                "user_id": {
                     "type": "string"
                 }
             }
         },
-        "EndpointResponse": {
+        "RemoveExamPrerequisiteData": {
             "type": "object",
             "properties": {
-                "error": {
-                    "$ref": "#/definitions/EndpointError"
+                "exam_id": {
+                    "type": "integer"
                 },
-                "result": {},
-                "success": {
-                    "type": "boolean"
+                "prerequisite_id": {
+                    "type": "integer"
                 }
             }
         },
-        "ExamParticipantInfo": {
+        "RemoveExamPrerequisiteResult": {
             "type": "object",
             "properties": {
-                "added_by": {
-                    "type": "string"
-                },
-                "created_at": {
-                    "type": "string"
-                },
                 "exam_id": {
                     "type": "integer"
                 },
-                "final_score": {
-                    "type": "string"
-                },
-                "full_name": {
-                    "type": "string"
-                },
-                "price": {
-                    "type": "string"
-                },
-                "scored_by": {
-                    "type": "string"
+                "prerequisite_id": {
+                    "type": "integer"
                 },
-                "user_id": {
-                    "type": "string"
+                "removed": {
+                    "type": "boolean"
                 }
             }
         },
-        "ExamQuestionInfo": {
+        "RenderedQuestionContent": {
             "type": "object",
             "properties": {
-                "created_at": {
+                "description": {
                     "type": "string"
                 },
-                "description": {
+                "explanation": {
+                    "description": "Explanation is only set when the explanation of the question itself\nis returned.",
                     "type": "string"
                 },
                 "option1": {
@@ -2858,82 +10579,76 @@ const docTemplate = `{
                 "option4": {
                     "type": "string"
                 },
-                "question_id": {
-                    "type": "integer"
-                },
                 "question_title": {
                     "type": "string"
-                },
-                "user_answer": {
-                    "$ref": "#/definitions/AnsweredQuestionInfo"
                 }
             }
         },
-        "GetAllUserTopicStatsResult": {
+        "ResetAttemptBindingData": {
             "type": "object",
             "properties": {
-                "stats": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/UserTopicStatInfo"
-                    }
+                "exam_id": {
+                    "description": "ExamId is the exam that the attempt belongs to.",
+                    "type": "integer"
                 },
                 "user_id": {
+                    "description": "UserId is the user whose attempt should be unbound from its client.",
                     "type": "string"
                 }
             }
         },
-        "GetCourseInfoResult": {
+        "ResetAttemptBindingResult": {
             "type": "object",
             "properties": {
-                "added_by": {
-                    "type": "string"
-                },
-                "course_description": {
-                    "type": "string"
-                },
-                "course_id": {
+                "exam_id": {
                     "type": "integer"
                 },
-                "course_name": {
+                "reset_by": {
                     "type": "string"
                 },
-                "created_at": {
+                "user_id": {
                     "type": "string"
-                },
-                "topic_id": {
-                    "type": "integer"
                 }
             }
         },
-        "GetCourseParticipantsData": {
+        "ResolveExamReviewCommentData": {
             "type": "object",
             "properties": {
-                "course_id": {
+                "comment_id": {
                     "type": "integer"
                 }
             }
         },
-        "GetCourseParticipantsResult": {
+        "ReviewExamData": {
             "type": "object",
             "properties": {
-                "participants": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/CourseParticipantInfo"
-                    }
+                "approve": {
+                    "description": "Approve publishes the exam if true, otherwise the exam is sent back\nto draft; Comment is added to the review comments of the exam.",
+                    "type": "boolean"
+                },
+                "comment": {
+                    "type": "string"
+                },
+                "exam_id": {
+                    "type": "integer"
                 }
             }
         },
-        "GetCreatedCoursesData": {
+        "SearchCourseData": {
             "type": "object",
             "properties": {
-                "user_id": {
+                "course_name": {
                     "type": "string"
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
                 }
             }
         },
-        "GetCreatedCoursesResult": {
+        "SearchCourseResult": {
             "type": "object",
             "properties": {
                 "courses": {
@@ -2944,185 +10659,211 @@ const docTemplate = `{
                 }
             }
         },
-        "GetExamInfoResult": {
+        "SearchExamData": {
             "type": "object",
+            "required": [
+                "limit",
+                "offset",
+                "search_query"
+            ],
             "properties": {
-                "can_add_others_to_exam": {
-                    "type": "boolean",
-                    "default": false
-                },
-                "can_edit_question": {
-                    "type": "boolean",
-                    "default": false
-                },
-                "can_participate": {
-                    "type": "boolean",
-                    "default": false
+                "limit": {
+                    "type": "integer"
                 },
-                "course_id": {
+                "offset": {
                     "type": "integer"
                 },
-                "created_at": {
+                "search_query": {
                     "type": "string"
-                },
-                "created_by": {
+                }
+            }
+        },
+        "SearchExamQuestionsData": {
+            "type": "object",
+            "properties": {
+                "difficulty_level": {
                     "type": "string"
                 },
-                "duration": {
-                    "type": "integer"
-                },
-                "exam_date": {
+                "learning_objective": {
                     "type": "string"
                 },
-                "exam_description": {
-                    "type": "string"
+                "limit": {
+                    "type": "integer"
                 },
-                "exam_id": {
+                "offset": {
                     "type": "integer"
                 },
-                "exam_title": {
+                "search_query": {
+                    "description": "SearchQuery is searched (full-text) in the title and the description\nof the questions.",
                     "type": "string"
                 },
-                "finishes_in": {
-                    "type": "integer",
-                    "default": 0
-                },
-                "has_finished": {
-                    "type": "boolean",
-                    "default": false
-                },
-                "has_participated": {
-                    "type": "boolean",
-                    "default": false
-                },
-                "has_started": {
-                    "type": "boolean"
-                },
-                "is_public": {
-                    "type": "boolean"
+                "tags": {
+                    "description": "Tags are the tags the questions must all have.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "price": {
+                "topic_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "SearchExamQuestionsResult": {
+            "type": "object",
+            "properties": {
+                "questions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/SearchedExamQuestionInfo"
+                    }
+                }
+            }
+        },
+        "SearchExamResult": {
+            "type": "object",
+            "properties": {
+                "exams": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/SearchedExamInfo"
+                    }
+                }
+            }
+        },
+        "SearchTopicData": {
+            "type": "object",
+            "properties": {
+                "topic_name": {
                     "type": "string"
-                },
-                "question_count": {
-                    "type": "integer",
-                    "default": 0
-                },
-                "starts_in": {
-                    "type": "integer",
-                    "default": 0
                 }
             }
         },
-        "GetExamParticipantsData": {
+        "SearchTopicResult": {
+            "type": "object",
+            "properties": {
+                "topics": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/SearchedTopicInfo"
+                    }
+                }
+            }
+        },
+        "SearchUserData": {
             "type": "object",
             "properties": {
-                "exam_id": {
-                    "type": "integer"
-                },
                 "limit": {
-                    "type": "integer"
+                    "type": "integer",
+                    "minimum": 1
                 },
                 "offset": {
                     "type": "integer"
+                },
+                "query": {
+                    "type": "string"
                 }
             }
         },
-        "GetExamParticipantsResult": {
+        "SearchUserResult": {
             "type": "object",
             "properties": {
-                "can_set_score": {
-                    "type": "boolean",
-                    "default": false
-                },
-                "exam_id": {
-                    "type": "integer"
-                },
-                "participants": {
+                "users": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/ExamParticipantInfo"
+                        "$ref": "#/definitions/SearchedUserInfo"
                     }
                 }
             }
         },
-        "GetExamQuestionsData": {
+        "SearchedCourseInfo": {
             "type": "object",
             "properties": {
-                "exam_id": {
-                    "type": "integer"
+                "added_by": {
+                    "type": "string"
                 },
-                "limit": {
-                    "type": "integer"
+                "course_description": {
+                    "type": "string"
                 },
-                "offset": {
+                "course_id": {
                     "type": "integer"
                 },
-                "pov": {
-                    "description": "Point of view",
+                "course_name": {
+                    "type": "string"
+                },
+                "created_at": {
                     "type": "string"
+                },
+                "topic_id": {
+                    "type": "integer"
                 }
             }
         },
-        "GetExamQuestionsResult": {
+        "SearchedExamInfo": {
             "type": "object",
             "properties": {
+                "course_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "duration": {
+                    "type": "integer"
+                },
+                "exam_date": {
+                    "type": "string"
+                },
+                "exam_description": {
+                    "type": "string"
+                },
                 "exam_id": {
                     "type": "integer"
                 },
-                "pov": {
-                    "description": "Point of view",
+                "exam_title": {
                     "type": "string"
                 },
-                "questions": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/ExamQuestionInfo"
-                    }
+                "is_public": {
+                    "type": "boolean"
+                },
+                "price": {
+                    "type": "string"
                 }
             }
         },
-        "GetGivenExamData": {
+        "SearchedExamQuestionInfo": {
             "type": "object",
             "properties": {
-                "added_by": {
+                "content_format": {
                     "type": "string"
                 },
                 "created_at": {
                     "type": "string"
                 },
-                "exam_id": {
-                    "type": "integer"
-                },
-                "final_score": {
+                "description": {
                     "type": "string"
                 },
-                "price": {
-                    "type": "string"
+                "exam_id": {
+                    "type": "integer"
                 },
-                "scored_by": {
+                "exam_title": {
                     "type": "string"
                 },
-                "user_id": {
-                    "type": "string"
-                }
-            }
-        },
-        "GetMeResult": {
-            "type": "object",
-            "properties": {
-                "full_name": {
-                    "type": "string"
+                "metadata": {
+                    "$ref": "#/definitions/ExamQuestionMetadataInfo"
                 },
-                "role": {
-                    "$ref": "#/definitions/UserRole"
+                "question_id": {
+                    "type": "integer"
                 },
-                "user_id": {
+                "question_title": {
                     "type": "string"
                 }
             }
         },
-        "GetTopicInfoResult": {
+        "SearchedTopicInfo": {
             "type": "object",
             "properties": {
                 "topic_id": {
@@ -3133,26 +10874,7 @@ const docTemplate = `{
                 }
             }
         },
-        "GetUserCoursesData": {
-            "type": "object",
-            "properties": {
-                "user_id": {
-                    "type": "string"
-                }
-            }
-        },
-        "GetUserCoursesResult": {
-            "type": "object",
-            "properties": {
-                "courses": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/UserParticipatedCourse"
-                    }
-                }
-            }
-        },
-        "GetUserInfoResult": {
+        "SearchedUserInfo": {
             "type": "object",
             "properties": {
                 "ban_reason": {
@@ -3178,375 +10900,541 @@ const docTemplate = `{
                 }
             }
         },
-        "GetUserOngoingExamsResult": {
+        "SetAttemptReviewPolicyData": {
             "type": "object",
             "properties": {
-                "exams": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/UserOngoingExamInfo"
-                    }
+                "attempt_review_policy": {
+                    "description": "AttemptReviewPolicy is one of never (the default), after_close and\nafter_release.",
+                    "type": "string"
+                },
+                "exam_id": {
+                    "type": "integer"
                 }
             }
         },
-        "GetUserTopicStatData": {
+        "SetExamAccessCodeData": {
             "type": "object",
             "properties": {
-                "topic_id": {
+                "access_code": {
+                    "description": "AccessCode is the static access code; it's ignored for the other types.",
+                    "type": "string"
+                },
+                "access_code_type": {
+                    "description": "AccessCodeType is the type of the access code; it can be one of\n\"none\", \"static\" or \"rotating\".",
+                    "type": "string"
+                },
+                "exam_id": {
+                    "type": "integer"
+                },
+                "rotation_interval": {
+                    "description": "RotationInterval is the interval (in minutes) in which a rotating\naccess code changes.",
+                    "type": "integer",
+                    "default": 5
+                }
+            }
+        },
+        "SetExamAccommodationData": {
+            "type": "object",
+            "properties": {
+                "custom_end": {
+                    "description": "CustomEnd is the unix timestamp of the custom close time of the\nexam for the user (optional).",
+                    "type": "integer"
+                },
+                "custom_start": {
+                    "description": "CustomStart is the unix timestamp of the custom start time of the\nexam for the user (optional).",
+                    "type": "integer"
+                },
+                "exam_id": {
                     "type": "integer"
                 },
+                "extra_minutes": {
+                    "description": "ExtraMinutes is the number of minutes added to the duration of the\nexam for the user.",
+                    "type": "integer",
+                    "default": 0
+                },
+                "note": {
+                    "type": "string"
+                },
+                "time_multiplier": {
+                    "description": "TimeMultiplier is applied to the duration of the exam for the\nuser (e.g. 1.5); 0 means no multiplier.",
+                    "type": "number",
+                    "default": 1
+                },
                 "user_id": {
                     "type": "string"
                 }
             }
         },
-        "GetUserTopicStatResult": {
+        "SetExamAdaptiveData": {
             "type": "object",
             "properties": {
-                "stat": {
-                    "$ref": "#/definitions/UserTopicStatInfo"
+                "exam_id": {
+                    "type": "integer"
+                },
+                "is_adaptive": {
+                    "type": "boolean"
+                },
+                "max_items": {
+                    "description": "MaxItems is the maximum number of questions given in an attempt;\nnull means no limit.",
+                    "type": "integer"
+                },
+                "target_se": {
+                    "description": "TargetStdError is the standard error of the ability estimate at\nwhich an attempt ends; null means no precision target.",
+                    "type": "number"
                 }
             }
         },
-        "GetUsersExamHistoryData": {
+        "SetExamAvailabilityData": {
             "type": "object",
             "properties": {
-                "limit": {
+                "closes_at": {
+                    "description": "ClosesAt is the unix timestamp of when the window of the exam closes;\nif it's not set, the exam is a fixed session.",
                     "type": "integer"
                 },
-                "offset": {
+                "deadline_policy": {
+                    "description": "DeadlinePolicy is what happens at the deadline of the users; it can be\none of \"auto_submit\" or \"grace_period\".",
+                    "type": "string",
+                    "default": "auto_submit"
+                },
+                "exam_id": {
                     "type": "integer"
                 },
-                "user_id": {
-                    "type": "string"
+                "grace_period": {
+                    "description": "GracePeriod is the time (in minutes) after the deadline in which the\nanswers are still accepted (grace_period policy only).",
+                    "type": "integer",
+                    "default": 0
+                },
+                "late_start_limit": {
+                    "description": "LateStartLimit is the time (in minutes) after the opening in which the\nusers can still start the exam; not setting it means no limit.",
+                    "type": "integer"
+                },
+                "opens_at": {
+                    "description": "OpensAt is the unix timestamp of when the window of the exam opens;\nthe exam date is used if it's not set.",
+                    "type": "integer"
                 }
             }
         },
-        "GetUsersExamHistoryResult": {
+        "SetExamCapacityData": {
             "type": "object",
             "properties": {
-                "exams": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/UserExamHistoryInfo"
-                    }
+                "capacity": {
+                    "description": "Capacity is the maximum number of participants; null removes the\nlimit. Raising it gives the new seats to the waitlist.",
+                    "type": "integer"
+                },
+                "exam_id": {
+                    "type": "integer"
                 }
             }
         },
-        "LoginData": {
+        "SetExamCollaboratorData": {
             "type": "object",
             "properties": {
-                "captcha_answer": {
-                    "type": "string"
-                },
-                "captcha_id": {
-                    "type": "string"
-                },
-                "client_rid": {
+                "collaborator_role": {
+                    "description": "CollaboratorRole is one of \"editor\", \"grader\" or \"viewer\".",
                     "type": "string"
                 },
-                "password": {
-                    "type": "string"
+                "exam_id": {
+                    "type": "integer"
                 },
                 "user_id": {
                     "type": "string"
                 }
             }
         },
-        "LoginResult": {
+        "SetExamGradeCategoryData": {
             "type": "object",
             "properties": {
-                "access_token": {
-                    "type": "string"
-                },
-                "expiration": {
+                "category_id": {
+                    "description": "CategoryId is the grade category of the course of the exam; nil\nmeans the exam is not counted in the course grade.",
                     "type": "integer"
                 },
-                "full_name": {
-                    "type": "string"
-                },
-                "refresh_token": {
-                    "type": "string"
-                },
-                "role": {
-                    "$ref": "#/definitions/UserRole"
-                },
-                "user_id": {
-                    "type": "string"
+                "exam_id": {
+                    "type": "integer"
                 }
             }
         },
-        "ParticipateExamData": {
+        "SetExamPracticeModeData": {
             "type": "object",
             "properties": {
                 "exam_id": {
-                    "description": "ExamId is the exam the user is trying to participate in.",
                     "type": "integer"
                 },
-                "price": {
-                    "description": "Price is the price of the exam that user has already paid.",
-                    "type": "string"
-                },
-                "user_id": {
-                    "description": "UserId is the user who is trying to participate in the exam.\nIf the user is trying to participate in the exam themselves,\nthis field should be set to their own user id.",
-                    "type": "string"
+                "is_practice": {
+                    "type": "boolean"
                 }
             }
         },
-        "ParticipateExamResult": {
+        "SetExamQuestionIrtData": {
             "type": "object",
             "properties": {
-                "added_by": {
+                "correct_option": {
+                    "description": "CorrectOption is the answer key of the question; it has to be one of\nthe options of the question. Null removes the answer key.",
                     "type": "string"
                 },
-                "created_at": {
-                    "type": "string"
+                "difficulty": {
+                    "type": "number"
+                },
+                "discrimination": {
+                    "description": "Discrimination (a), Difficulty (b) and Guessing (c) are the\nparameters of the question in the three-parameter logistic model;\nthe discrimination is 1 if not set.",
+                    "type": "number"
                 },
                 "exam_id": {
                     "type": "integer"
                 },
-                "finishes_in": {
-                    "type": "integer",
-                    "default": 0
-                },
-                "price": {
-                    "type": "string"
-                },
-                "question_count": {
-                    "type": "integer",
-                    "default": 0
-                },
-                "starts_in": {
-                    "type": "integer",
-                    "default": 0
+                "guessing": {
+                    "type": "number"
                 },
-                "user_id": {
-                    "type": "string"
+                "question_id": {
+                    "type": "integer"
                 }
             }
         },
-        "SearchCourseData": {
+        "SetExamQuestionMetadataData": {
             "type": "object",
             "properties": {
-                "course_name": {
+                "difficulty_level": {
                     "type": "string"
                 },
-                "limit": {
+                "exam_id": {
                     "type": "integer"
                 },
-                "offset": {
+                "learning_objectives": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "question_id": {
                     "type": "integer"
-                }
-            }
-        },
-        "SearchCourseResult": {
-            "type": "object",
-            "properties": {
-                "courses": {
+                },
+                "tags": {
+                    "description": "Tags are stored in lowercase; DifficultyLevel is one of \"easy\",\n\"medium\" and \"hard\" (null removes it).",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/SearchedCourseInfo"
+                        "type": "string"
                     }
+                },
+                "topic_id": {
+                    "type": "integer"
                 }
             }
         },
-        "SearchExamData": {
+        "SetExamQuestionMetadataResult": {
             "type": "object",
-            "required": [
-                "limit",
-                "offset",
-                "search_query"
-            ],
             "properties": {
-                "limit": {
+                "exam_id": {
                     "type": "integer"
                 },
-                "offset": {
-                    "type": "integer"
+                "metadata": {
+                    "$ref": "#/definitions/ExamQuestionMetadataInfo"
                 },
-                "search_query": {
-                    "type": "string"
+                "question_id": {
+                    "type": "integer"
                 }
             }
         },
-        "SearchExamResult": {
+        "SetExamRetakePolicyData": {
             "type": "object",
             "properties": {
-                "exams": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/SearchedExamInfo"
-                    }
+                "attempt_cooldown": {
+                    "description": "AttemptCooldown is the time (in minutes) a user has to wait after\nfinishing an attempt before starting the next one.",
+                    "type": "integer",
+                    "default": 0
+                },
+                "exam_id": {
+                    "type": "integer"
+                },
+                "grading_policy": {
+                    "description": "GradingPolicy decides how the final score is calculated from the\nattempts; it can be one of \"best\", \"latest\" or \"average\".",
+                    "type": "string",
+                    "default": "latest"
+                },
+                "max_attempts": {
+                    "description": "MaxAttempts is the maximum number of attempts a user can have in\nthe exam; 0 means unlimited.",
+                    "type": "integer",
+                    "default": 1
                 }
             }
         },
-        "SearchTopicData": {
+        "SetExamReviewerData": {
             "type": "object",
             "properties": {
-                "topic_name": {
+                "is_exam_reviewer": {
+                    "type": "boolean"
+                },
+                "user_id": {
                     "type": "string"
                 }
             }
         },
-        "SearchTopicResult": {
+        "SetExamReviewerResult": {
             "type": "object",
             "properties": {
-                "topics": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/SearchedTopicInfo"
-                    }
+                "is_exam_reviewer": {
+                    "type": "boolean"
+                },
+                "user_id": {
+                    "type": "string"
                 }
             }
         },
-        "SearchUserData": {
+        "SetExamScoreData": {
             "type": "object",
             "properties": {
-                "limit": {
-                    "type": "integer",
-                    "minimum": 1
+                "attempt_number": {
+                    "description": "AttemptNumber is the attempt we are trying to score; 0 means\nthe latest attempt of the user.",
+                    "type": "integer"
                 },
-                "offset": {
+                "exam_id": {
+                    "description": "ExamId is the exam we are trying to give this score to.",
                     "type": "integer"
                 },
-                "query": {
+                "score": {
+                    "description": "Score is the score we are trying to give to the user.",
+                    "type": "string"
+                },
+                "user_id": {
+                    "description": "UserId is the person we are trying to give this score to.",
                     "type": "string"
                 }
             }
         },
-        "SearchUserResult": {
+        "SetExamScoreResult": {
             "type": "object",
             "properties": {
-                "users": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/SearchedUserInfo"
-                    }
+                "exam_id": {
+                    "type": "integer"
+                },
+                "final_score": {
+                    "description": "FinalScore is the final score of the user in the exam, decided\nby the grading policy of the exam.",
+                    "type": "string"
+                },
+                "score": {
+                    "type": "string"
+                },
+                "scored_by": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
                 }
             }
         },
-        "SearchedCourseInfo": {
+        "SimilarityCheckInfo": {
             "type": "object",
             "properties": {
-                "added_by": {
+                "answers_count": {
+                    "type": "integer"
+                },
+                "check_id": {
+                    "type": "integer"
+                },
+                "check_status": {
                     "type": "string"
                 },
-                "course_description": {
+                "error_message": {
                     "type": "string"
                 },
-                "course_id": {
+                "exam_id": {
                     "type": "integer"
                 },
-                "course_name": {
+                "finished_at": {
                     "type": "string"
                 },
-                "created_at": {
+                "matches_count": {
+                    "type": "integer"
+                },
+                "question_id": {
+                    "type": "integer"
+                },
+                "requested_by": {
                     "type": "string"
                 },
-                "topic_id": {
+                "shingle_size": {
                     "type": "integer"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "threshold": {
+                    "type": "number"
                 }
             }
         },
-        "SearchedExamInfo": {
+        "SimilarityMatchInfo": {
             "type": "object",
             "properties": {
-                "course_id": {
+                "containment": {
+                    "type": "number"
+                },
+                "first_attempt": {
                     "type": "integer"
                 },
-                "created_at": {
+                "first_user_id": {
                     "type": "string"
                 },
-                "created_by": {
-                    "type": "string"
+                "match_id": {
+                    "type": "integer"
                 },
-                "duration": {
+                "passages": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/SimilarityPassageInfo"
+                    }
+                },
+                "second_attempt": {
                     "type": "integer"
                 },
-                "exam_date": {
+                "second_user_id": {
                     "type": "string"
                 },
-                "exam_description": {
-                    "type": "string"
+                "similarity": {
+                    "type": "number"
+                }
+            }
+        },
+        "SimilarityPassageInfo": {
+            "type": "object",
+            "properties": {
+                "first_end": {
+                    "type": "integer"
                 },
-                "exam_id": {
+                "first_start": {
+                    "description": "the offsets are byte offsets in the texts of the answers",
                     "type": "integer"
                 },
-                "exam_title": {
+                "first_text": {
                     "type": "string"
                 },
-                "is_public": {
-                    "type": "boolean"
+                "second_end": {
+                    "type": "integer"
                 },
-                "price": {
+                "second_start": {
+                    "type": "integer"
+                },
+                "second_text": {
                     "type": "string"
                 }
             }
         },
-        "SearchedTopicInfo": {
+        "SkipExamSeriesOccurrenceData": {
             "type": "object",
             "properties": {
-                "topic_id": {
+                "is_skipped": {
+                    "description": "IsSkipped can be set to false to bring back a skipped occurrence.",
+                    "type": "boolean",
+                    "default": true
+                },
+                "occurrence_at": {
                     "type": "integer"
                 },
-                "topic_name": {
-                    "type": "string"
+                "series_id": {
+                    "type": "integer"
                 }
             }
         },
-        "SearchedUserInfo": {
+        "SkippedInviteeInfo": {
             "type": "object",
             "properties": {
-                "ban_reason": {
+                "invitee": {
                     "type": "string"
                 },
-                "created_at": {
+                "reason": {
                     "type": "string"
-                },
-                "email": {
+                }
+            }
+        },
+        "StartExamAttemptData": {
+            "type": "object",
+            "properties": {
+                "access_code": {
+                    "description": "AccessCode is the code announced by the invigilator, only required\nif the exam is protected by an access code.",
                     "type": "string"
                 },
-                "full_name": {
+                "exam_id": {
+                    "description": "ExamId is the exam the user is trying to start.",
+                    "type": "integer"
+                }
+            }
+        },
+        "StartExamAttemptResult": {
+            "type": "object",
+            "properties": {
+                "attempt_number": {
+                    "type": "integer"
+                },
+                "bound_at": {
                     "type": "string"
                 },
-                "is_banned": {
-                    "type": "boolean"
+                "exam_id": {
+                    "type": "integer"
                 },
-                "role": {
-                    "$ref": "#/definitions/UserRole"
+                "finishes_in": {
+                    "type": "integer",
+                    "default": 0
+                },
+                "started_at": {
+                    "type": "string"
                 },
                 "user_id": {
                     "type": "string"
                 }
             }
         },
-        "SetExamScoreData": {
+        "StartExamPreviewData": {
             "type": "object",
             "properties": {
                 "exam_id": {
-                    "description": "ExamId is the exam we are trying to give this score to.",
                     "type": "integer"
                 },
-                "score": {
-                    "description": "Score is the score we are trying to give to the user.",
-                    "type": "string"
-                },
-                "user_id": {
-                    "description": "UserId is the person we are trying to give this score to.",
-                    "type": "string"
+                "render_html": {
+                    "description": "RenderHtml is true if the safe HTML variant of the content of the\nquestions should be returned too.",
+                    "type": "boolean"
                 }
             }
         },
-        "SetExamScoreResult": {
+        "StartSimilarityCheckData": {
             "type": "object",
             "properties": {
                 "exam_id": {
                     "type": "integer"
                 },
-                "score": {
+                "question_id": {
+                    "type": "integer"
+                },
+                "shingle_size": {
+                    "description": "ShingleSize is the number of the words in the compared shingles, and\nThreshold is the similarity (0-1) from which the pairs of answers are\nreported; the defaults are used if they are 0.",
+                    "type": "integer"
+                },
+                "threshold": {
+                    "type": "number"
+                }
+            }
+        },
+        "StartSimilarityCheckResult": {
+            "type": "object",
+            "properties": {
+                "check": {
+                    "$ref": "#/definitions/SimilarityCheckInfo"
+                }
+            }
+        },
+        "SubmitExamForReviewData": {
+            "type": "object",
+            "properties": {
+                "exam_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "TopUpWalletData": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "description": "Amount is written the same way the prices are, e.g. \"100T\".",
                     "type": "string"
                 },
-                "scored_by": {
+                "description": {
                     "type": "string"
                 },
                 "user_id": {
@@ -3554,6 +11442,25 @@ const docTemplate = `{
                 }
             }
         },
+        "TopUpWalletResult": {
+            "type": "object",
+            "properties": {
+                "transaction": {
+                    "$ref": "#/definitions/WalletTransactionInfo"
+                },
+                "wallet": {
+                    "$ref": "#/definitions/WalletInfo"
+                }
+            }
+        },
+        "UploadExamAttachmentResult": {
+            "type": "object",
+            "properties": {
+                "attachment": {
+                    "$ref": "#/definitions/ExamAttachmentInfo"
+                }
+            }
+        },
         "UserExamHistoryInfo": {
             "type": "object",
             "properties": {
@@ -3571,12 +11478,16 @@ const docTemplate = `{
         "UserOngoingExamInfo": {
             "type": "object",
             "properties": {
-                "course_id": {
-                    "type": "integer"
+                "end_time": {
+                    "description": "EndTime is the personal deadline of the user in the exam.",
+                    "type": "string"
                 },
                 "exam_id": {
                     "type": "integer"
                 },
+                "exam_title": {
+                    "type": "string"
+                },
                 "start_time": {
                     "type": "string"
                 }
@@ -3634,6 +11545,93 @@ const docTemplate = `{
                 }
             }
         },
+        "WalletInfo": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "description": "Balance is formatted the same way the prices are, e.g. \"149.99T\".",
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "WalletTransactionInfo": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "exam_id": {
+                    "type": "integer"
+                },
+                "provider": {
+                    "type": "string"
+                },
+                "provider_reference": {
+                    "type": "string"
+                },
+                "refund_of": {
+                    "type": "integer"
+                },
+                "refunded_amount": {
+                    "type": "string"
+                },
+                "transaction_id": {
+                    "type": "integer"
+                },
+                "transaction_type": {
+                    "description": "TransactionType is one of \"top_up\", \"exam_payment\" or \"refund\".",
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "WithdrawFromExamData": {
+            "type": "object",
+            "properties": {
+                "exam_id": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "description": "UserId is the user who is withdrawing from the exam; it should be\nset to the user's own id when they are withdrawing themselves.",
+                    "type": "string"
+                }
+            }
+        },
+        "WithdrawFromExamResult": {
+            "type": "object",
+            "properties": {
+                "exam_id": {
+                    "type": "integer"
+                },
+                "left_waitlist": {
+                    "description": "LeftWaitlist is true if the user was only on the waitlist of the\nexam, in which case nothing is refunded.",
+                    "type": "boolean"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
         "captchaHandlers.GetCaptchaResult": {
             "type": "object",
             "properties": {
@@ -143,6 +143,8 @@ func GetMeV1(c *fiber.Ctx) error {
 		UserId:   userInfo.UserId,
 		FullName: userInfo.FullName,
 		Role:     userInfo.Role,
+
+		CanReviewExams: userInfo.CanReviewExams(),
 	})
 }
 
@@ -487,6 +489,67 @@ func BanUserV1(c *fiber.Ctx) error {
 	})
 }
 
+// SetExamReviewerV1 godoc
+// @Summary Set whether a teacher can review exams
+// @Description Allows an admin to choose which teachers can review (and approve) the exams of the other teachers.
+// @ID setExamReviewerV1
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param data body SetExamReviewerData true "Data needed to set whether a teacher can review exams"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=SetExamReviewerResult}
+// @Router /api/v1/user/setExamReviewer [post]
+func SetExamReviewerV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	} else if !userInfo.CanSetExamReviewer() {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	data := &SetExamReviewerData{}
+	if err := c.BodyParser(data); err != nil {
+		return apiHandlers.SendErrInvalidBodyData(c)
+	}
+
+	if data.UserId == "" {
+		return apiHandlers.SendErrParameterRequired(c, "user_id")
+	}
+
+	targetUserInfo, err := database.GetUserByUserId(data.UserId)
+	if err != nil {
+		if err == database.ErrUserNotFound {
+			return apiHandlers.SendErrInvalidUserID(c)
+		}
+
+		logging.Error("SetExamReviewerV1: failed to get user: ", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	} else if targetUserInfo.Role != appValues.UserRoleTeacher {
+		// owners and admins can always review the exams, and students
+		// never can
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	targetUserInfo, err = database.SetExamReviewer(data)
+	if err != nil {
+		logging.Error("SetExamReviewerV1: failed to set exam reviewer: ", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	return apiHandlers.SendResult(c, &SetExamReviewerResult{
+		UserId:         targetUserInfo.UserId,
+		IsExamReviewer: targetUserInfo.IsExamReviewer,
+	})
+}
+
 // ChangePasswordV1 godoc
 // @Summary Change a user's password
 // @Description Allows a user to change a user's password.
@@ -700,3 +763,111 @@ func ConfirmAccountV1(c *fiber.Ctx) error {
 
 	return apiHandlers.SendResult(c, true)
 }
+
+// CreateUserFromInvitationV1 godoc
+// @Summary Create a new user from an exam invitation
+// @Description Allows the invitee of an exam, who doesn't have an account yet, to create a student account with the invited email address. The new user joins the exam of the invitation right away, if possible.
+// @ID createUserFromInvitationV1
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param data body CreateUserFromInvitationData true "Data needed to create a user from an exam invitation"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=CreateUserFromInvitationResult}
+// @Router /api/v1/user/createFromInvitation [post]
+func CreateUserFromInvitationV1(c *fiber.Ctx) error {
+	if isRateLimited(c) {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	data := &CreateUserFromInvitationData{}
+	if err := c.BodyParser(data); err != nil {
+		return apiHandlers.SendErrInvalidBodyData(c)
+	}
+
+	if data.InvitationId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "invitation_id")
+	} else if data.Token == "" {
+		return apiHandlers.SendErrParameterRequired(c, "token")
+	} else if data.FullName == "" {
+		return apiHandlers.SendErrParameterRequired(c, "full_name")
+	}
+
+	invitation, err := database.GetExamInvitation(data.InvitationId)
+	if err == database.ErrExamInvitationNotFound {
+		return apiHandlers.SendErrExamInvitationNotFound(c)
+	} else if err != nil {
+		logging.UnexpectedError("CreateUserFromInvitationV1: failed to get exam invitation: ", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	} else if !invitation.VerifyJoinToken(data.Token) {
+		return apiHandlers.SendErrInvalidInvitationToken(c)
+	} else if !invitation.IsPending() {
+		return apiHandlers.SendErrInvitationNotPending(c, invitation.GetStatus())
+	}
+
+	createUserMutex.Lock()
+	defer createUserMutex.Unlock()
+
+	if IsInvalidPassword(data.RawPassword) {
+		return apiHandlers.SendErrInvalidInputPass(c)
+	}
+
+	// the invitation can only lead to creating a new account once
+	existingUser, err := database.GetUserByEmail(invitation.Email)
+	if err != nil && err != database.ErrUserNotFound {
+		logging.UnexpectedError("CreateUserFromInvitationV1: failed to get user by email: ", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	} else if existingUser != nil || invitation.UserId != nil {
+		return apiHandlers.SendErrEmailAlreadyExists(c)
+	}
+
+	newUserInfo, err := database.GetUserByUserId(data.UserId)
+	if err != nil && err != database.ErrUserNotFound {
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	if newUserInfo != nil {
+		return apiHandlers.SendErrUsernameExists(c)
+	} else if !appValues.IsUserIdValid(data.UserId) {
+		return apiHandlers.SendErrInvalidUserID(c)
+	}
+
+	// the email address is already confirmed by the join token, so there
+	// is no need for sending the confirmation email
+	newUserInfo, err = database.CreateNewUser(&database.NewUserData{
+		UserId:         data.UserId,
+		FullName:       data.FullName,
+		Email:          invitation.Email,
+		RawPassword:    data.RawPassword,
+		Role:           appValues.UserRoleStudent,
+		SetupCompleted: true,
+	})
+	if err != nil {
+		errStr := strings.ToLower(err.Error())
+		if strings.Contains(errStr, "unique") && strings.Contains(errStr, "email") {
+			return apiHandlers.SendErrEmailAlreadyExists(c)
+		}
+
+		logging.UnexpectedError("CreateUserFromInvitationV1: failed to create new user: ", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	} else if newUserInfo == nil {
+		logging.UnexpectedError("CreateUserFromInvitationV1: failed to create new user: ", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	result := &CreateUserFromInvitationResult{
+		UserId:   newUserInfo.UserId,
+		Email:    newUserInfo.Email,
+		FullName: newUserInfo.FullName,
+		Role:     newUserInfo.Role.ToString(),
+		ExamId:   invitation.ExamId,
+	}
+
+	var waitlistEntry *database.ExamWaitlistEntry
+	result.InvitationStatus, waitlistEntry = acceptInvitationForNewUser(invitation, newUserInfo)
+	if waitlistEntry != nil {
+		result.IsWaitlisted = true
+		result.WaitlistPosition = waitlistEntry.Position
+	}
+
+	return apiHandlers.SendResult(c, result)
+}
@@ -4,6 +4,7 @@ import (
 	"ExamSphere/src/apiHandlers"
 	"ExamSphere/src/core/appConfig"
 	"ExamSphere/src/core/utils/hashing"
+	"ExamSphere/src/core/utils/logging"
 	"ExamSphere/src/database"
 	"encoding/hex"
 
@@ -201,3 +202,35 @@ func getChangePasswordRequest(rqId string) *changePasswordRequestEntry {
 
 	return entry
 }
+
+// acceptInvitationForNewUser makes the newly created user join the exam of
+// the invitation they have signed up with, and returns the resulting status
+// of the invitation, alongside their waitlist entry if the exam is full. If
+// the exam cannot be joined right away (e.g. the wallet of the new user
+// doesn't have enough balance for its price yet, or they don't meet its
+// prerequisites), the invitation is only linked to the user, so they can
+// accept it later.
+func acceptInvitationForNewUser(
+	invitation *database.ExamInvitation,
+	userInfo *database.UserInfo,
+) (string, *database.ExamWaitlistEntry) {
+	examInfo := database.GetExamInfoOrNil(invitation.ExamId)
+	if examInfo != nil && examInfo.IsPublished() &&
+		!examInfo.HasExamFinishedFor(database.GetExamAccommodationOrNil(userInfo.UserId, examInfo.ExamId)) {
+		_, waitlistEntry, err := database.JoinExamByInvitation(invitation, examInfo, userInfo.UserId)
+		if err == nil {
+			return database.InvitationStatusAccepted, waitlistEntry
+		} else if err != database.ErrInsufficientBalance &&
+			err != database.ErrCurrencyMismatch &&
+			err != database.ErrExamPrerequisitesNotMet {
+			logging.UnexpectedError("acceptInvitationForNewUser: failed to join exam by invitation:", err)
+		}
+	}
+
+	err := database.LinkExamInvitationToUser(invitation.InvitationId, userInfo.UserId)
+	if err != nil {
+		logging.UnexpectedError("acceptInvitationForNewUser: failed to link invitation to user:", err)
+	}
+
+	return invitation.GetStatus(), nil
+}
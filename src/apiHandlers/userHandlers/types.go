@@ -39,6 +39,10 @@ type MeResult struct {
 	UserId   string             `json:"user_id"`
 	FullName string             `json:"full_name"`
 	Role     appValues.UserRole `json:"role"`
+
+	// CanReviewExams is true if the user can review (and approve) the
+	// exams of the others.
+	CanReviewExams bool `json:"can_review_exams"`
 } // @name GetMeResult
 
 // CreateUserData is the data required to create a new user.
@@ -54,6 +58,14 @@ type CreateUserData = struct {
 	PrimaryLanguage string             `json:"primary_language"`
 } // @name CreateUserData
 
+type CreateUserFromInvitationData struct {
+	InvitationId int    `json:"invitation_id"`
+	Token        string `json:"token"`
+	UserId       string `json:"user_id"`
+	FullName     string `json:"full_name"`
+	RawPassword  string `json:"password"`
+} // @name CreateUserFromInvitationData
+
 // CreateUserResult is the result of creating a new user.
 type CreateUserResult struct {
 	UserId   string `json:"user_id"`
@@ -62,6 +74,27 @@ type CreateUserResult struct {
 	Role     string `json:"role"`
 } // @name CreateUserResult
 
+// CreateUserFromInvitationResult is the result of creating a new user
+// through the join link of an invitation.
+type CreateUserFromInvitationResult struct {
+	UserId   string `json:"user_id"`
+	Email    string `json:"email"`
+	FullName string `json:"full_name"`
+	Role     string `json:"role"`
+	ExamId   int    `json:"exam_id"`
+
+	// InvitationStatus is accepted if the user has joined the exam (or its
+	// waitlist) right away; the invitation stays pending (linked to the
+	// new user) if the exam cannot be joined yet, e.g. when it has to be
+	// paid for first.
+	InvitationStatus string `json:"invitation_status"`
+
+	// IsWaitlisted is true if the exam is full and the user has been put
+	// on its waitlist instead; WaitlistPosition is their 1-based position.
+	IsWaitlisted     bool `json:"is_waitlisted" default:"false"`
+	WaitlistPosition int  `json:"waitlist_position" default:"0"`
+} // @name CreateUserFromInvitationResult
+
 type userRequestEntry struct {
 	RequestPath string
 	LastTryAt   time.Time
@@ -112,6 +145,13 @@ type BanUserResult struct {
 	BanReason *string `json:"ban_reason"`
 } // @name BanUserResult
 
+type SetExamReviewerData = database.SetExamReviewerData // @name SetExamReviewerData
+
+type SetExamReviewerResult struct {
+	UserId         string `json:"user_id"`
+	IsExamReviewer bool   `json:"is_exam_reviewer"`
+} // @name SetExamReviewerResult
+
 type ChangePasswordData struct {
 	UserId      string `json:"user_id"`
 	NewPassword string `json:"new_password"`
@@ -0,0 +1,353 @@
+package walletHandlers
+
+import (
+	"ExamSphere/src/apiHandlers"
+	"ExamSphere/src/core/utils/logging"
+	"ExamSphere/src/core/utils/paymentUtils"
+	"ExamSphere/src/database"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetWalletV1 godoc
+// @Summary Get the wallet of a user
+// @Description Allows the user to get their own wallet (or the wallet of another user, for admins).
+// @ID getWalletV1
+// @Tags Wallet
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param targetId query string false "Target user id"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=WalletInfo}
+// @Router /api/v1/wallet/info [get]
+func GetWalletV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	}
+
+	// optional: provide another user's id to see their wallet
+	targetUserId := c.Query("targetId")
+	if targetUserId == "" {
+		targetUserId = userInfo.UserId
+	}
+
+	if !userInfo.CanGetWallet(targetUserId) {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	wallet, err := database.GetUserWallet(targetUserId)
+	if err != nil {
+		logging.UnexpectedError("GetWallet: Failed to get user wallet:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	return apiHandlers.SendResult(c, toWalletInfo(wallet))
+}
+
+// GetWalletTransactionsV1 godoc
+// @Summary Get the wallet transactions of a user
+// @Description Allows the user to get the transactions of their own wallet (or the wallet of another user, for admins), most recent ones first.
+// @ID getWalletTransactionsV1
+// @Tags Wallet
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param targetId query string false "Target user id"
+// @Param offset query int false "Offset"
+// @Param limit query int false "Limit"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=GetWalletTransactionsResult}
+// @Router /api/v1/wallet/transactions [get]
+func GetWalletTransactionsV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	}
+
+	targetUserId := c.Query("targetId")
+	if targetUserId == "" {
+		targetUserId = userInfo.UserId
+	}
+
+	if !userInfo.CanGetWallet(targetUserId) {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	offset := c.QueryInt("offset")
+	limit := c.QueryInt("limit", database.DefaultPaginationLimit)
+	if offset < 0 || limit < 0 {
+		return apiHandlers.SendErrInvalidPagination(c)
+	}
+
+	transactions, err := database.GetWalletTransactions(&database.GetWalletTransactionsData{
+		UserId: targetUserId,
+		Offset: offset,
+		Limit:  limit,
+	})
+	if err != nil {
+		logging.UnexpectedError("GetWalletTransactions: Failed to get wallet transactions:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	transactionsInfo := make([]*WalletTransactionInfo, 0, len(transactions))
+	for _, transaction := range transactions {
+		transactionsInfo = append(transactionsInfo, toWalletTransactionInfo(transaction))
+	}
+
+	return apiHandlers.SendResult(c, &GetWalletTransactionsResult{
+		Transactions: transactionsInfo,
+	})
+}
+
+// TopUpWalletV1 godoc
+// @Summary Top up the wallet of a user
+// @Description Allows the admins to top up the wallet of a user through the payment provider.
+// @ID topUpWalletV1
+// @Tags Wallet
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param data body TopUpWalletData true "Data needed to top up a wallet"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=TopUpWalletResult}
+// @Router /api/v1/wallet/topUp [post]
+func TopUpWalletV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	} else if !userInfo.CanManageWallets() {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	data := &TopUpWalletData{}
+	if err := c.BodyParser(data); err != nil {
+		return apiHandlers.SendErrInvalidBodyData(c)
+	}
+
+	if data.UserId == "" {
+		return apiHandlers.SendErrParameterRequired(c, "user_id")
+	} else if data.Amount == "" {
+		return apiHandlers.SendErrParameterRequired(c, "amount")
+	} else if data.Description != nil && len(*data.Description) > database.MaxTransactionDescLength {
+		return apiHandlers.SendErrBodyTooLong(c)
+	}
+
+	amount, ok := parseAmount(data.Amount)
+	if !ok {
+		return apiHandlers.SendErrInvalidAmount(c, data.Amount)
+	}
+
+	targetUser, err := database.GetUserByUserId(data.UserId)
+	if err != nil {
+		if err == database.ErrUserNotFound {
+			return apiHandlers.SendErrInvalidUserID(c)
+		}
+		logging.UnexpectedError("TopUpWallet: Failed to get user info:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	} else if targetUser == nil {
+		return apiHandlers.SendErrInvalidUserID(c)
+	}
+
+	provider, err := paymentUtils.GetPaymentProvider()
+	if err != nil {
+		logging.UnexpectedError("TopUpWallet: Failed to get payment provider:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	chargeData := &paymentUtils.ChargeData{
+		UserId:   data.UserId,
+		Amount:   amount,
+		Currency: paymentUtils.DefaultCurrency,
+	}
+	if data.Description != nil {
+		chargeData.Description = *data.Description
+	}
+
+	payment, err := provider.Charge(chargeData)
+	if err != nil {
+		return apiHandlers.SendErrPaymentFailed(c, err.Error())
+	}
+
+	transaction, err := database.TopUpWallet(&database.NewTopUpData{
+		UserId:            data.UserId,
+		Amount:            amount,
+		Currency:          paymentUtils.DefaultCurrency,
+		Provider:          payment.Provider,
+		ProviderReference: payment.Reference,
+		Description:       data.Description,
+		CreatedBy:         userInfo.UserId,
+	})
+	if err != nil {
+		logging.UnexpectedError("TopUpWallet: Failed to record top-up "+payment.Reference+":", err)
+
+		// the money has been received, but couldn't be recorded; give it back
+		_, refundErr := provider.Refund(&paymentUtils.RefundData{
+			Reference: payment.Reference,
+			Amount:    amount,
+			Currency:  paymentUtils.DefaultCurrency,
+		})
+		if refundErr != nil {
+			logging.UnexpectedError("TopUpWallet: Failed to refund unrecorded top-up "+payment.Reference+":", refundErr)
+		}
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	wallet, err := database.GetUserWallet(data.UserId)
+	if err != nil {
+		logging.UnexpectedError("TopUpWallet: Failed to get user wallet:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	return apiHandlers.SendResult(c, &TopUpWalletResult{
+		Wallet:      toWalletInfo(wallet),
+		Transaction: toWalletTransactionInfo(transaction),
+	})
+}
+
+// RefundTransactionV1 godoc
+// @Summary Refund a wallet transaction
+// @Description Allows the admins to refund (a part of) a transaction. Refunding an exam payment puts the money back in the wallet of the user; refunding a top-up takes it out of the wallet and gives it back through the payment provider.
+// @ID refundTransactionV1
+// @Tags Wallet
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Authorization token"
+// @Param data body RefundTransactionData true "Data needed to refund a transaction"
+// @Success 200 {object} apiHandlers.EndpointResponse{result=RefundTransactionResult}
+// @Router /api/v1/wallet/refund [post]
+func RefundTransactionV1(c *fiber.Ctx) error {
+	claimInfo := apiHandlers.GetJWTClaimsInfo(c)
+	if claimInfo == nil {
+		return apiHandlers.SendErrInvalidJWT(c)
+	}
+
+	userInfo := database.GetUserInfoByAuthHash(
+		claimInfo.UserId, claimInfo.AuthHash,
+	)
+	if userInfo == nil {
+		return apiHandlers.SendErrInvalidAuth(c)
+	} else if !userInfo.CanManageWallets() {
+		return apiHandlers.SendErrPermissionDenied(c)
+	}
+
+	data := &RefundTransactionData{}
+	if err := c.BodyParser(data); err != nil {
+		return apiHandlers.SendErrInvalidBodyData(c)
+	}
+
+	if data.TransactionId == 0 {
+		return apiHandlers.SendErrParameterRequired(c, "transaction_id")
+	} else if data.Description != nil && len(*data.Description) > database.MaxTransactionDescLength {
+		return apiHandlers.SendErrBodyTooLong(c)
+	}
+
+	original, err := database.GetWalletTransaction(data.TransactionId)
+	if err == database.ErrWalletTransactionNotFound {
+		return apiHandlers.SendErrWalletTransactionNotFound(c)
+	} else if err != nil {
+		logging.UnexpectedError("RefundTransaction: Failed to get wallet transaction:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	} else if original.TransactionType == database.TransactionTypeRefund {
+		return apiHandlers.SendErrTransactionNotRefundable(c)
+	}
+
+	refundable := original.Amount - original.RefundedAmount
+	amount := refundable
+	if data.Amount != "" {
+		var ok bool
+		amount, ok = parseAmount(data.Amount)
+		if !ok {
+			return apiHandlers.SendErrInvalidAmount(c, data.Amount)
+		}
+	}
+
+	if amount <= 0 || amount > refundable {
+		return apiHandlers.SendErrRefundExceedsAmount(c)
+	}
+
+	newData := &database.NewRefundData{
+		TransactionId: original.TransactionId,
+		Amount:        amount,
+		Description:   data.Description,
+		CreatedBy:     userInfo.UserId,
+	}
+
+	if original.TransactionType == database.TransactionTypeTopUp {
+		// the money is going to leave the wallet, make sure it's there
+		// before giving it back through the provider.
+		wallet, err := database.GetUserWallet(original.UserId)
+		if err != nil {
+			logging.UnexpectedError("RefundTransaction: Failed to get user wallet:", err)
+			return apiHandlers.SendErrInternalServerError(c)
+		} else if wallet.Balance < amount {
+			return apiHandlers.SendErrInsufficientBalance(c)
+		}
+
+		provider, err := paymentUtils.GetPaymentProvider()
+		if err != nil {
+			logging.UnexpectedError("RefundTransaction: Failed to get payment provider:", err)
+			return apiHandlers.SendErrInternalServerError(c)
+		} else if original.Provider == nil || *original.Provider != provider.GetName() ||
+			original.ProviderReference == nil {
+			// made through another provider, can't be given back automatically
+			return apiHandlers.SendErrTransactionNotRefundable(c)
+		}
+
+		payment, err := provider.Refund(&paymentUtils.RefundData{
+			Reference: *original.ProviderReference,
+			Amount:    amount,
+			Currency:  original.Currency,
+		})
+		if err != nil {
+			return apiHandlers.SendErrPaymentFailed(c, err.Error())
+		}
+
+		newData.ProviderReference = &payment.Reference
+	}
+
+	transaction, err := database.RefundWalletTransaction(newData)
+	if err == database.ErrInsufficientBalance {
+		return apiHandlers.SendErrInsufficientBalance(c)
+	} else if err != nil {
+		if newData.ProviderReference != nil {
+			// the money has already left through the provider, this has to
+			// be sorted out manually.
+			logging.UnexpectedError("RefundTransaction: Failed to record refund "+*newData.ProviderReference+":", err)
+			return apiHandlers.SendErrInternalServerError(c)
+		}
+
+		logging.UnexpectedError("RefundTransaction: Failed to refund wallet transaction:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	wallet, err := database.GetUserWallet(original.UserId)
+	if err != nil {
+		logging.UnexpectedError("RefundTransaction: Failed to get user wallet:", err)
+		return apiHandlers.SendErrInternalServerError(c)
+	}
+
+	return apiHandlers.SendResult(c, &RefundTransactionResult{
+		Wallet:      toWalletInfo(wallet),
+		Transaction: toWalletTransactionInfo(transaction),
+	})
+}
@@ -0,0 +1,45 @@
+package walletHandlers
+
+import (
+	"ExamSphere/src/core/utils/paymentUtils"
+	"ExamSphere/src/database"
+
+	"github.com/ALiwoto/ssg/ssg"
+)
+
+func toWalletInfo(wallet *database.Wallet) *WalletInfo {
+	return &WalletInfo{
+		UserId:    wallet.UserId,
+		Balance:   paymentUtils.FormatPrice(wallet.Balance, wallet.Currency),
+		Currency:  wallet.Currency,
+		UpdatedAt: ssg.Clone(wallet.UpdatedAt),
+	}
+}
+
+func toWalletTransactionInfo(transaction *database.WalletTransaction) *WalletTransactionInfo {
+	return &WalletTransactionInfo{
+		TransactionId:     transaction.TransactionId,
+		TransactionType:   transaction.TransactionType,
+		UserId:            transaction.UserId,
+		ExamId:            ssg.Clone(transaction.ExamId),
+		Amount:            paymentUtils.FormatPrice(transaction.Amount, transaction.Currency),
+		RefundedAmount:    paymentUtils.FormatPrice(transaction.RefundedAmount, transaction.Currency),
+		Provider:          ssg.Clone(transaction.Provider),
+		ProviderReference: ssg.Clone(transaction.ProviderReference),
+		RefundOf:          ssg.Clone(transaction.RefundOf),
+		Description:       ssg.Clone(transaction.Description),
+		CreatedBy:         ssg.Clone(transaction.CreatedBy),
+		CreatedAt:         transaction.CreatedAt,
+	}
+}
+
+// parseAmount parses an amount written the same way the prices are, and
+// makes sure it's positive and in the currency of the wallets.
+func parseAmount(value string) (int64, bool) {
+	amount, currency, err := paymentUtils.ParsePrice(value)
+	if err != nil || amount <= 0 || currency != paymentUtils.DefaultCurrency {
+		return 0, false
+	}
+
+	return amount, true
+}
@@ -0,0 +1,60 @@
+package walletHandlers
+
+import "time"
+
+type WalletInfo struct {
+	UserId string `json:"user_id"`
+
+	// Balance is formatted the same way the prices are, e.g. "149.99T".
+	Balance   string     `json:"balance"`
+	Currency  string     `json:"currency"`
+	UpdatedAt *time.Time `json:"updated_at"`
+} // @name WalletInfo
+
+type WalletTransactionInfo struct {
+	TransactionId int64 `json:"transaction_id"`
+
+	// TransactionType is one of "top_up", "exam_payment" or "refund".
+	TransactionType   string    `json:"transaction_type"`
+	UserId            string    `json:"user_id"`
+	ExamId            *int      `json:"exam_id"`
+	Amount            string    `json:"amount"`
+	RefundedAmount    string    `json:"refunded_amount"`
+	Provider          *string   `json:"provider"`
+	ProviderReference *string   `json:"provider_reference"`
+	RefundOf          *int64    `json:"refund_of"`
+	Description       *string   `json:"description"`
+	CreatedBy         *string   `json:"created_by"`
+	CreatedAt         time.Time `json:"created_at"`
+} // @name WalletTransactionInfo
+
+type GetWalletTransactionsResult struct {
+	Transactions []*WalletTransactionInfo `json:"transactions"`
+} // @name GetWalletTransactionsResult
+
+type TopUpWalletData struct {
+	UserId string `json:"user_id"`
+
+	// Amount is written the same way the prices are, e.g. "100T".
+	Amount      string  `json:"amount"`
+	Description *string `json:"description"`
+} // @name TopUpWalletData
+
+type TopUpWalletResult struct {
+	Wallet      *WalletInfo            `json:"wallet"`
+	Transaction *WalletTransactionInfo `json:"transaction"`
+} // @name TopUpWalletResult
+
+type RefundTransactionData struct {
+	TransactionId int64 `json:"transaction_id"`
+
+	// Amount is the amount to refund (e.g. "50T"); the whole refundable
+	// amount of the transaction is refunded if it's not set.
+	Amount      string  `json:"amount"`
+	Description *string `json:"description"`
+} // @name RefundTransactionData
+
+type RefundTransactionResult struct {
+	Wallet      *WalletInfo            `json:"wallet"`
+	Transaction *WalletTransactionInfo `json:"transaction"`
+} // @name RefundTransactionResult
@@ -177,3 +177,125 @@ func GetConfirmAccountBaseURL() string {
 
 	return TheConfig.ConfirmAccountBaseUrl
 }
+
+func GetExamInviteBaseURL() string {
+	if TheConfig == nil {
+		return ""
+	}
+
+	return TheConfig.ExamInviteBaseUrl
+}
+
+func GetExamInviteExpiration() time.Duration {
+	if TheConfig == nil {
+		return 72 * time.Hour
+	}
+
+	return TheConfig.ExamInviteExpiration * time.Hour
+}
+
+func ShouldRejectForeignExamClients() bool {
+	if TheConfig == nil {
+		return true
+	}
+
+	return TheConfig.RejectForeignExamClients
+}
+
+func GetMaxAccessCodeAttempts() int {
+	if TheConfig == nil {
+		return 5
+	}
+
+	return TheConfig.MaxAccessCodeAttempts
+}
+
+func GetAccessCodeLockDuration() time.Duration {
+	if TheConfig == nil {
+		return 10 * time.Minute
+	}
+
+	return TheConfig.AccessCodeLockDuration * time.Minute
+}
+
+func GetExamSchedulerInterval() time.Duration {
+	if TheConfig == nil {
+		return 10 * time.Minute
+	}
+
+	return TheConfig.ExamSchedulerInterval * time.Minute
+}
+
+func GetPaymentProvider() string {
+	if TheConfig == nil || TheConfig.PaymentProvider == "" {
+		return "fake"
+	}
+
+	return TheConfig.PaymentProvider
+}
+
+func GetStorageBackend() string {
+	if TheConfig == nil || TheConfig.StorageBackend == "" {
+		return "local"
+	}
+
+	return TheConfig.StorageBackend
+}
+
+func GetStorageLocalPath() string {
+	if TheConfig == nil || TheConfig.StorageLocalPath == "" {
+		return "attachments"
+	}
+
+	return TheConfig.StorageLocalPath
+}
+
+func GetStorageS3Endpoint() string {
+	if TheConfig == nil {
+		return ""
+	}
+
+	return TheConfig.StorageS3Endpoint
+}
+
+func GetStorageS3Region() string {
+	if TheConfig == nil {
+		return ""
+	}
+
+	return TheConfig.StorageS3Region
+}
+
+func GetStorageS3Bucket() string {
+	if TheConfig == nil {
+		return ""
+	}
+
+	return TheConfig.StorageS3Bucket
+}
+
+func GetStorageS3AccessKey() string {
+	if TheConfig == nil {
+		return ""
+	}
+
+	return TheConfig.StorageS3AccessKey
+}
+
+func GetStorageS3SecretKey() string {
+	if TheConfig == nil {
+		return ""
+	}
+
+	return TheConfig.StorageS3SecretKey
+}
+
+// GetMaxAttachmentSize returns the maximum size (in bytes) of the
+// uploaded files.
+func GetMaxAttachmentSize() int64 {
+	if TheConfig == nil || TheConfig.MaxAttachmentSize <= 0 {
+		return 20 * 1024 * 1024
+	}
+
+	return int64(TheConfig.MaxAttachmentSize) * 1024 * 1024
+}
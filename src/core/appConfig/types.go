@@ -3,6 +3,7 @@ package appConfig
 import "time"
 
 type Minute = time.Duration
+type Hour = time.Duration
 
 type PlatformConfig struct {
 	OwnerUsername string `key:"owner_username"`
@@ -40,6 +41,11 @@ type PlatformConfig struct {
 	ChangePassBaseUrl     string `key:"change_pass_base_url"`
 	ConfirmAccountBaseUrl string `key:"confirm_account_base_url"`
 
+	// ExamInviteBaseUrl is the page the exam invitation links point to;
+	// ExamInviteExpiration is how long the invitations stay valid.
+	ExamInviteBaseUrl    string `key:"exam_invite_base_url"`
+	ExamInviteExpiration Hour   `key:"exam_invite_expiration" default:"72"`
+
 	MaxOutgoingMessagesCount      int    `key:"max_outgoing_messages_count" default:"15"`
 	MaxDailyNewConversationsCount int    `key:"max_daily_new_conversations_count" default:"45"`
 	NewConversationMinDelay       Minute `key:"new_conversation_min_delay" default:"2"`
@@ -47,4 +53,36 @@ type PlatformConfig struct {
 	MaxRateLimitDuration          Minute `key:"max_rate_limit_duration" default:"3"`
 	RateLimitPunishmentDuration   Minute `key:"rate_limit_punishment_duration" default:"5"`
 	AdminStatsCacheDuration       Minute `key:"admin_stats_cache_duration" default:"3"`
+
+	// If set to true, requests coming from a client other than the one an
+	// exam attempt is bound to will be rejected. Otherwise, they will be
+	// accepted, but flagged so the teacher can review them later.
+	RejectForeignExamClients bool `key:"reject_foreign_exam_clients" default:"true"`
+
+	// MaxAccessCodeAttempts is the number of wrong access codes a user can
+	// enter for an exam before getting locked out for AccessCodeLockDuration.
+	MaxAccessCodeAttempts  int    `key:"max_access_code_attempts" default:"5"`
+	AccessCodeLockDuration Minute `key:"access_code_lock_duration" default:"10"`
+
+	// ExamSchedulerInterval is the interval in which the upcoming occurrences
+	// of the exam series get materialised; 0 disables the scheduler.
+	ExamSchedulerInterval Minute `key:"exam_scheduler_interval" default:"10"`
+
+	// PaymentProvider is the name of the provider used for charging the
+	// wallets of the users; "fake" is only meant for development.
+	PaymentProvider string `key:"payment_provider" default:"fake"`
+
+	// StorageBackend is the name of the backend the attachments are stored
+	// in; it can be "local" (StorageLocalPath) or "s3".
+	StorageBackend     string `key:"storage_backend" default:"local"`
+	StorageLocalPath   string `key:"storage_local_path" default:"attachments"`
+	StorageS3Endpoint  string `key:"storage_s3_endpoint"`
+	StorageS3Region    string `key:"storage_s3_region" default:"us-east-1"`
+	StorageS3Bucket    string `key:"storage_s3_bucket"`
+	StorageS3AccessKey string `key:"storage_s3_access_key"`
+	StorageS3SecretKey string `key:"storage_s3_secret_key"`
+
+	// MaxAttachmentSize is the maximum size (in MB) of the uploaded files;
+	// each type of the files has its own (lower) limit as well.
+	MaxAttachmentSize int `key:"max_attachment_size" default:"20"`
 }
@@ -0,0 +1,48 @@
+package contentUtils
+
+const (
+	// FormatPlain is the format of the content which is shown as-is.
+	FormatPlain = "plain"
+
+	// FormatMarkdown is the format of the content written in (a safe
+	// subset of) Markdown; raw HTML is never allowed.
+	FormatMarkdown = "markdown"
+
+	// FormatMarkdownLatex is the format of the Markdown content which can
+	// contain LaTeX formulas between $...$ (inline) and $$...$$ (display).
+	FormatMarkdownLatex = "markdown_latex"
+)
+
+const (
+	// MathInlineClass and MathDisplayClass are the classes of the elements
+	// wrapping the formulas in the rendered HTML; the clients are expected
+	// to typeset their content (e.g. with KaTeX) with the trust option off.
+	MathInlineClass  = "math math-inline"
+	MathDisplayClass = "math math-display"
+
+	// linkRel is the rel attribute of the rendered links.
+	linkRel = "nofollow noopener noreferrer"
+
+	// maxHeadingLevel is the maximum level of the Markdown headings.
+	maxHeadingLevel = 6
+
+	// maxNestingDepth is the maximum depth of the nested blockquotes and
+	// inline elements; anything nested deeper is rendered as text.
+	maxNestingDepth = 16
+)
+
+const (
+	// MaxContentLength is the maximum length (in bytes) of a piece of
+	// content which is not plain text.
+	MaxContentLength = 32 * 1024
+)
+
+const (
+	// escapableCharacters are the characters which can be escaped with a
+	// backslash in Markdown.
+	escapableCharacters = "!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~"
+
+	// inlineSpecialCharacters are the characters which can start an
+	// inline element in Markdown.
+	inlineSpecialCharacters = "\\`$*_!["
+)
@@ -0,0 +1,327 @@
+package contentUtils
+
+import (
+	"html"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+)
+
+// IsFormatValid returns true if the value is one of the Format* constants.
+func IsFormatValid(format string) bool {
+	switch format {
+	case FormatPlain, FormatMarkdown, FormatMarkdownLatex:
+		return true
+	}
+
+	return false
+}
+
+// SanitizeContent normalises the content (line endings and control
+// characters) and makes sure it's safe to be rendered in the format; the
+// returned value is what should be stored.
+func SanitizeContent(format, content string) (string, error) {
+	if !IsFormatValid(format) {
+		return "", ErrInvalidFormat
+	} else if !utf8.ValidString(content) {
+		return "", ErrInvalidEncoding
+	} else if strings.IndexByte(content, 0) != -1 {
+		return "", ErrContentHasNullBytes
+	}
+
+	content = normalizeContent(content)
+	if format == FormatPlain {
+		return content, nil
+	} else if len(content) > MaxContentLength {
+		return "", ErrContentTooLong
+	}
+
+	r := newRenderer(format)
+	r.renderBlocks(content, 0)
+	if r.err != nil {
+		return "", r.err
+	}
+
+	return content, nil
+}
+
+// RenderHTML renders the content as a safe HTML fragment made of blocks
+// (paragraphs, lists, etc.); it's meant for the longer pieces of content,
+// such as the descriptions of the questions.
+func RenderHTML(format, content string) string {
+	if content == "" {
+		return ""
+	} else if !IsMarkdown(format) {
+		return "<p>" + escapeWithBreaks(content) + "</p>"
+	}
+
+	r := newRenderer(format)
+	r.renderBlocks(normalizeContent(content), 0)
+	return r.out.String()
+}
+
+// RenderInlineHTML renders the content as a safe HTML fragment without any
+// blocks; it's meant for the short pieces of content, such as the titles
+// and the options of the questions.
+func RenderInlineHTML(format, content string) string {
+	if !IsMarkdown(format) {
+		return escapeWithBreaks(content)
+	}
+
+	r := newRenderer(format)
+	r.renderInline(normalizeContent(content), 0)
+	return r.out.String()
+}
+
+// IsMarkdown returns true if the content of the format is written in
+// Markdown.
+func IsMarkdown(format string) bool {
+	return format == FormatMarkdown || format == FormatMarkdownLatex
+}
+
+func newRenderer(format string) *renderer {
+	return &renderer{
+		latex: format == FormatMarkdownLatex,
+	}
+}
+
+// normalizeContent converts the line endings to \n and removes the control
+// characters (other than the new lines and the tabs).
+func normalizeContent(content string) string {
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r == '\r':
+			return '\n'
+		case r == '\n' || r == '\t':
+			return r
+		case r < 0x20 || (r >= 0x7f && r < 0xa0):
+			return -1
+		}
+		return r
+	}, content)
+}
+
+func escapeWithBreaks(content string) string {
+	return strings.ReplaceAll(html.EscapeString(content), "\n", "<br>\n")
+}
+
+// cleanURL removes the whitespaces and the control characters from the
+// destination of a link, and returns false if its scheme is not one of the
+// allowed schemes; relative destinations are always allowed.
+func cleanURL(destination string, allowedSchemes map[string]bool) (string, bool) {
+	cleaned := strings.Map(func(r rune) rune {
+		if r <= 0x20 || r == 0x7f {
+			return -1
+		}
+		return r
+	}, destination)
+	if cleaned == "" {
+		return "", false
+	}
+
+	parsed, err := url.Parse(cleaned)
+	if err != nil {
+		return "", false
+	} else if parsed.Scheme != "" && !allowedSchemes[strings.ToLower(parsed.Scheme)] {
+		return "", false
+	}
+
+	return cleaned, true
+}
+
+// checkLatex returns false if the formula uses any of the disallowed
+// commands.
+func checkLatex(formula string) bool {
+	for i := 0; i < len(formula); i++ {
+		if formula[i] != '\\' {
+			continue
+		}
+
+		end := i + 1
+		for end < len(formula) && isASCIILetter(formula[end]) {
+			end++
+		}
+
+		name := formula[i+1 : end]
+		if disallowedLatexCommands[name] || strings.HasPrefix(name, "html") {
+			return false
+		}
+
+		if end == i+1 {
+			// skip the escaped character (e.g. \\ or \$)
+			end++
+		}
+		i = end - 1
+	}
+
+	return true
+}
+
+func isASCIILetter(ch byte) bool {
+	return (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z')
+}
+
+func isASCIIDigit(ch byte) bool {
+	return ch >= '0' && ch <= '9'
+}
+
+// countRun returns the number of the consecutive ch characters starting
+// at the index.
+func countRun(text string, index int, ch byte) int {
+	count := 0
+	for index+count < len(text) && text[index+count] == ch {
+		count++
+	}
+	return count
+}
+
+// isThematicBreak returns true if the line is a horizontal rule
+// (e.g. --- or ***).
+func isThematicBreak(line string) bool {
+	var marker byte
+	count := 0
+	for i := 0; i < len(line); i++ {
+		switch ch := line[i]; {
+		case ch == ' ' || ch == '\t':
+			continue
+		case ch != '-' && ch != '*' && ch != '_':
+			return false
+		case marker == 0:
+			marker = ch
+		case ch != marker:
+			return false
+		}
+		count++
+	}
+
+	return count >= 3
+}
+
+// getHeadingLevel returns the level of the heading, or 0 if the line is
+// not a heading.
+func getHeadingLevel(line string) int {
+	level := countRun(line, 0, '#')
+	if level == 0 || level > maxHeadingLevel {
+		return 0
+	} else if level < len(line) && line[level] != ' ' && line[level] != '\t' {
+		return 0
+	}
+
+	return level
+}
+
+// parseListItem returns the content of the list item, or false if the
+// line is not a list item.
+func parseListItem(line string) (content string, ordered bool, ok bool) {
+	if len(line) >= 2 && strings.ContainsRune("-*+", rune(line[0])) &&
+		(line[1] == ' ' || line[1] == '\t') {
+		return strings.TrimSpace(line[2:]), false, true
+	}
+
+	digits := 0
+	for digits < len(line) && digits < 9 && isASCIIDigit(line[digits]) {
+		digits++
+	}
+	if digits == 0 || digits+1 >= len(line) ||
+		(line[digits] != '.' && line[digits] != ')') ||
+		(line[digits+1] != ' ' && line[digits+1] != '\t') {
+		return "", false, false
+	}
+
+	return strings.TrimSpace(line[digits+2:]), true, true
+}
+
+// findMathEnd returns the index of the delimiter closing the formula, or
+// -1 if there is none; the formulas cannot be empty or span paragraphs.
+func findMathEnd(text string, from int, delimiter string) int {
+	for i := from; i < len(text); i++ {
+		switch {
+		case text[i] == '\\':
+			i++
+		case strings.HasPrefix(text[i:], delimiter):
+			if i == from {
+				return -1
+			}
+			return i
+		}
+	}
+
+	return -1
+}
+
+// findEmphasisEnd returns the index of the delimiter closing the emphasis
+// opened at the index, or -1 if there is none.
+func findEmphasisEnd(text string, start int, delimiter string) int {
+	from := start + len(delimiter)
+	if from >= len(text) || text[from] == ' ' || text[from] == '\n' {
+		return -1
+	} else if delimiter[0] == '_' && start > 0 && isWordCharacter(text[start-1]) {
+		// underscores inside words (e.g. snake_case) are not emphasis
+		return -1
+	}
+
+	for i := from + 1; i < len(text); {
+		index := strings.Index(text[i:], delimiter)
+		if index == -1 {
+			return -1
+		}
+
+		end := i + index
+		run := countRun(text, end, delimiter[0])
+		if text[end-1] != ' ' && text[end-1] != '\\' &&
+			(len(delimiter) == 2 || run == 1) {
+			return end
+		}
+		i = end + run
+	}
+
+	return -1
+}
+
+func isWordCharacter(ch byte) bool {
+	return isASCIILetter(ch) || isASCIIDigit(ch)
+}
+
+// parseLink parses a link like [label](destination) starting at the
+// index, and returns the index after it (or -1 if it's not a link).
+func parseLink(text string, start int) (label, destination string, end int) {
+	depth := 0
+	closing := -1
+	for i := start; i < len(text) && closing == -1; i++ {
+		switch text[i] {
+		case '\\':
+			i++
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				closing = i
+			}
+		}
+	}
+
+	if closing == -1 || closing+1 >= len(text) || text[closing+1] != '(' {
+		return "", "", -1
+	}
+
+	// the destination can contain balanced parentheses
+	depth = 0
+	for i := closing + 1; i < len(text); i++ {
+		switch text[i] {
+		case '\\':
+			i++
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				destination = strings.TrimSpace(text[closing+2 : i])
+				return text[start+1 : closing], destination, i + 1
+			}
+		}
+	}
+
+	return "", "", -1
+}
@@ -0,0 +1,102 @@
+package contentUtils_test
+
+import (
+	"strings"
+	"testing"
+
+	"ExamSphere/src/core/utils/contentUtils"
+)
+
+func TestSanitizeContent(t *testing.T) {
+	content, err := contentUtils.SanitizeContent(contentUtils.FormatMarkdown, "line 1\r\nline\x00 2")
+	if err != contentUtils.ErrContentHasNullBytes {
+		t.Error("Expected null bytes to be rejected, got", err)
+	}
+
+	content, err = contentUtils.SanitizeContent(contentUtils.FormatPlain, "line 1\r\nline\x07 2\r")
+	if err != nil {
+		t.Fatal("Expected plain content to be valid, got", err)
+	} else if content != "line 1\nline 2\n" {
+		t.Errorf("Unexpected normalized content: %q", content)
+	}
+
+	if _, err = contentUtils.SanitizeContent("html", "<b>hi</b>"); err != contentUtils.ErrInvalidFormat {
+		t.Error("Expected invalid format error, got", err)
+	}
+
+	unsafeContents := map[string]error{
+		"[click](javascript:alert(1))":       contentUtils.ErrUnsafeLink,
+		"[click](JaVa\tScRiPt:alert(1))":     contentUtils.ErrUnsafeLink,
+		"![x](data:image/png;base64,AAAA)":   contentUtils.ErrUnsafeLink,
+		"$\\href{javascript:alert(1)}{x}$":   contentUtils.ErrUnsafeLatexCommand,
+		"$$\n\\htmlStyle{color:red}{x}\n$$":  contentUtils.ErrUnsafeLatexCommand,
+		"$$\nx^2":                            contentUtils.ErrUnclosedMathBlock,
+		"```\ncode":                          contentUtils.ErrUnclosedCodeBlock,
+		strings.Repeat("a", 64*1024):         contentUtils.ErrContentTooLong,
+		"[ok](https://example.com) \xff\xfe": contentUtils.ErrInvalidEncoding,
+	}
+	for value, expected := range unsafeContents {
+		_, err := contentUtils.SanitizeContent(contentUtils.FormatMarkdownLatex, value)
+		if err != expected {
+			t.Errorf("Expected %q to fail with %v, got %v", value, expected, err)
+		}
+	}
+
+	// formulas are just text in markdown without latex
+	_, err = contentUtils.SanitizeContent(contentUtils.FormatMarkdown, "$\\href{x}{y}$")
+	if err != nil {
+		t.Error("Expected formulas to be ignored in markdown, got", err)
+	}
+}
+
+func TestRenderHTML(t *testing.T) {
+	tests := []struct {
+		format   string
+		content  string
+		expected string
+	}{
+		{
+			contentUtils.FormatPlain,
+			"<script>alert(1)</script>\n**x**",
+			"<p>&lt;script&gt;alert(1)&lt;/script&gt;<br>\n**x**</p>",
+		},
+		{
+			contentUtils.FormatMarkdown,
+			"# Title\n\nSome **bold** and *italic* `<code>`\n\n<img src=x onerror=alert(1)>",
+			"<h1>Title</h1>\n<p>Some <strong>bold</strong> and <em>italic</em> <code>&lt;code&gt;</code></p>\n" +
+				"<p>&lt;img src=x onerror=alert(1)&gt;</p>\n",
+		},
+		{
+			contentUtils.FormatMarkdown,
+			"- one\n- two\n\n1. first\n2. second",
+			"<ul>\n<li>one</li>\n<li>two</li>\n</ul>\n<ol>\n<li>first</li>\n<li>second</li>\n</ol>\n",
+		},
+		{
+			contentUtils.FormatMarkdown,
+			`[site](https://example.com/?a=1&b="2") [bad](javascript:alert(1))`,
+			`<p><a href="https://example.com/?a=1&amp;b=&#34;2&#34;" rel="nofollow noopener noreferrer" target="_blank">site</a> bad</p>` + "\n",
+		},
+		{
+			contentUtils.FormatMarkdownLatex,
+			"Solve $x^2 < 4$ where snake_case_name costs \\$5\n\n$$\n\\frac{a}{b}\n$$",
+			`<p>Solve <span class="math math-inline">\(x^2 &lt; 4\)</span> where snake_case_name costs $5</p>` + "\n" +
+				`<div class="math math-display">\[\frac{a}{b}\]</div>` + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		if rendered := contentUtils.RenderHTML(test.format, test.content); rendered != test.expected {
+			t.Errorf("Unexpected rendering of %q:\n%s\nexpected:\n%s", test.content, rendered, test.expected)
+		}
+	}
+
+	inline := contentUtils.RenderInlineHTML(contentUtils.FormatMarkdownLatex, "# not a heading $a<b$")
+	if inline != `# not a heading <span class="math math-inline">\(a&lt;b\)</span>` {
+		t.Error("Unexpected inline rendering:", inline)
+	}
+
+	nested := contentUtils.RenderHTML(contentUtils.FormatMarkdown, strings.Repeat(">", 10000)+" deep")
+	if !strings.Contains(nested, "&gt;") {
+		t.Error("Expected deeply nested quotes to be rendered as text")
+	}
+}
@@ -0,0 +1,309 @@
+package contentUtils
+
+import (
+	"html"
+	"strconv"
+	"strings"
+)
+
+func (r *renderer) setError(err error) {
+	if r.err == nil {
+		r.err = err
+	}
+}
+
+func (r *renderer) writeText(text string) {
+	r.out.WriteString(html.EscapeString(text))
+}
+
+// isBlockStart returns true if the (trimmed) line starts a block other
+// than a paragraph.
+func (r *renderer) isBlockStart(line string) bool {
+	if strings.HasPrefix(line, "```") || strings.HasPrefix(line, ">") ||
+		(r.latex && line == "$$") || getHeadingLevel(line) > 0 || isThematicBreak(line) {
+		return true
+	}
+
+	_, _, isListItem := parseListItem(line)
+	return isListItem
+}
+
+// renderBlocks renders the lines of the content as blocks.
+func (r *renderer) renderBlocks(content string, depth int) {
+	lines := strings.Split(content, "\n")
+	for i := 0; i < len(lines); {
+		line := strings.TrimSpace(lines[i])
+		switch {
+		case line == "":
+			i++
+		case strings.HasPrefix(line, "```"):
+			i = r.renderFencedBlock(lines, i, "```", ErrUnclosedCodeBlock)
+		case r.latex && line == "$$":
+			i = r.renderFencedBlock(lines, i, "$$", ErrUnclosedMathBlock)
+		case getHeadingLevel(line) > 0:
+			level := strconv.Itoa(getHeadingLevel(line))
+			r.out.WriteString("<h" + level + ">")
+			r.renderInline(strings.TrimSpace(strings.TrimRight(strings.TrimLeft(line, "#"), "#")), depth)
+			r.out.WriteString("</h" + level + ">\n")
+			i++
+		case isThematicBreak(line):
+			r.out.WriteString("<hr>\n")
+			i++
+		case strings.HasPrefix(line, ">") && depth < maxNestingDepth:
+			var quoted []string
+			for ; i < len(lines); i++ {
+				current := strings.TrimSpace(lines[i])
+				if !strings.HasPrefix(current, ">") {
+					break
+				}
+				quoted = append(quoted, strings.TrimPrefix(current[1:], " "))
+			}
+
+			r.out.WriteString("<blockquote>\n")
+			r.renderBlocks(strings.Join(quoted, "\n"), depth+1)
+			r.out.WriteString("</blockquote>\n")
+		default:
+			if _, ordered, isListItem := parseListItem(line); isListItem {
+				i = r.renderList(lines, i, ordered, depth)
+				continue
+			}
+
+			paragraph := []string{line}
+			for i++; i < len(lines); i++ {
+				current := strings.TrimSpace(lines[i])
+				if current == "" || r.isBlockStart(current) {
+					break
+				}
+				paragraph = append(paragraph, current)
+			}
+
+			r.out.WriteString("<p>")
+			r.renderInline(strings.Join(paragraph, "\n"), depth)
+			r.out.WriteString("</p>\n")
+		}
+	}
+}
+
+// renderFencedBlock renders a code block or a display formula, and returns
+// the index of the line after it.
+func (r *renderer) renderFencedBlock(lines []string, start int, fence string, unclosedErr error) int {
+	end := start + 1
+	for end < len(lines) && strings.TrimSpace(lines[end]) != fence {
+		end++
+	}
+	if end >= len(lines) {
+		r.setError(unclosedErr)
+	}
+
+	body := strings.Join(lines[start+1:min(end, len(lines))], "\n")
+	if fence == "$$" {
+		r.writeMath(body, true, true)
+	} else {
+		r.out.WriteString("<pre><code>")
+		r.writeText(body)
+		r.out.WriteString("</code></pre>\n")
+	}
+
+	return end + 1
+}
+
+// renderList renders the consecutive items of a list, and returns the
+// index of the line after it; the lines which are not items are appended
+// to the previous item.
+func (r *renderer) renderList(lines []string, start int, ordered bool, depth int) int {
+	var items []string
+	i := start
+	for ; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		if line == "" {
+			break
+		}
+
+		content, itemOrdered, isListItem := parseListItem(line)
+		if isListItem && itemOrdered != ordered {
+			break
+		} else if isListItem {
+			items = append(items, content)
+		} else if r.isBlockStart(line) {
+			break
+		} else {
+			items[len(items)-1] += "\n" + line
+		}
+	}
+
+	tag := "ul"
+	if ordered {
+		tag = "ol"
+	}
+
+	r.out.WriteString("<" + tag + ">\n")
+	for _, item := range items {
+		r.out.WriteString("<li>")
+		r.renderInline(item, depth)
+		r.out.WriteString("</li>\n")
+	}
+	r.out.WriteString("</" + tag + ">\n")
+
+	return i
+}
+
+// renderInline renders the inline elements (emphasis, code spans, links,
+// images and formulas) of the text.
+func (r *renderer) renderInline(text string, depth int) {
+	if depth >= maxNestingDepth {
+		r.writeText(text)
+		return
+	}
+
+	for i := 0; i < len(text); {
+		ch := text[i]
+		switch {
+		case ch == '\\' && i+1 < len(text) && strings.IndexByte(escapableCharacters, text[i+1]) != -1:
+			r.writeText(text[i+1 : i+2])
+			i += 2
+		case ch == '`':
+			run := countRun(text, i, '`')
+			end := r.findCodeSpanEnd(text, i+run, run)
+			if end == -1 {
+				r.writeText(text[i : i+run])
+				i += run
+				continue
+			}
+
+			r.out.WriteString("<code>")
+			r.writeText(text[i+run : end])
+			r.out.WriteString("</code>")
+			i = end + run
+		case ch == '$' && r.latex:
+			delimiter := "$"
+			if strings.HasPrefix(text[i:], "$$") {
+				delimiter = "$$"
+			}
+
+			end := findMathEnd(text, i+len(delimiter), delimiter)
+			if end == -1 {
+				r.writeText(delimiter)
+				i += len(delimiter)
+				continue
+			}
+
+			r.writeMath(text[i+len(delimiter):end], delimiter == "$$", false)
+			i = end + len(delimiter)
+		case ch == '*' || ch == '_':
+			delimiter := text[i : i+min(countRun(text, i, ch), 2)]
+			end := findEmphasisEnd(text, i, delimiter)
+			if end == -1 {
+				r.writeText(delimiter)
+				i += len(delimiter)
+				continue
+			}
+
+			tag := "em"
+			if len(delimiter) == 2 {
+				tag = "strong"
+			}
+
+			r.out.WriteString("<" + tag + ">")
+			r.renderInline(text[i+len(delimiter):end], depth+1)
+			r.out.WriteString("</" + tag + ">")
+			i = end + len(delimiter)
+		case ch == '!' && i+1 < len(text) && text[i+1] == '[':
+			label, destination, end := parseLink(text, i+1)
+			if end == -1 {
+				r.writeText("!")
+				i++
+				continue
+			}
+
+			r.writeImage(label, destination)
+			i = end
+		case ch == '[':
+			label, destination, end := parseLink(text, i)
+			if end == -1 {
+				r.writeText("[")
+				i++
+				continue
+			}
+
+			r.writeLink(label, destination, depth)
+			i = end
+		default:
+			next := strings.IndexAny(text[i+1:], inlineSpecialCharacters)
+			if next == -1 {
+				r.writeText(text[i:])
+				return
+			}
+
+			r.writeText(text[i : i+1+next])
+			i += 1 + next
+		}
+	}
+}
+
+// findCodeSpanEnd returns the index of the backticks closing the code
+// span, or -1 if there are none.
+func (r *renderer) findCodeSpanEnd(text string, from, run int) int {
+	for i := from; i < len(text); {
+		if text[i] != '`' {
+			i++
+			continue
+		}
+
+		current := countRun(text, i, '`')
+		if current == run {
+			return i
+		}
+		i += current
+	}
+
+	return -1
+}
+
+// writeMath writes the formula for the clients to typeset; its content is
+// only escaped, never interpreted.
+func (r *renderer) writeMath(formula string, display, isBlock bool) {
+	if !checkLatex(formula) {
+		r.setError(ErrUnsafeLatexCommand)
+	}
+
+	tag, class, open, close := "span", MathInlineClass, `\(`, `\)`
+	if display {
+		class, open, close = MathDisplayClass, `\[`, `\]`
+	}
+	if isBlock {
+		tag = "div"
+	}
+
+	r.out.WriteString("<" + tag + ` class="` + class + `">` + open)
+	r.writeText(formula)
+	r.out.WriteString(close + "</" + tag + ">")
+	if isBlock {
+		r.out.WriteString("\n")
+	}
+}
+
+func (r *renderer) writeLink(label, destination string, depth int) {
+	cleaned, ok := cleanURL(destination, allowedLinkSchemes)
+	if !ok {
+		r.setError(ErrUnsafeLink)
+		r.renderInline(label, depth+1)
+		return
+	}
+
+	r.out.WriteString(`<a href="` + html.EscapeString(cleaned) +
+		`" rel="` + linkRel + `" target="_blank">`)
+	r.renderInline(label, depth+1)
+	r.out.WriteString("</a>")
+}
+
+func (r *renderer) writeImage(alt, destination string) {
+	cleaned, ok := cleanURL(destination, allowedImageSchemes)
+	if !ok {
+		r.setError(ErrUnsafeLink)
+		r.writeText(alt)
+		return
+	}
+
+	r.out.WriteString(`<img src="` + html.EscapeString(cleaned) +
+		`" alt="` + html.EscapeString(alt) + `">`)
+}
@@ -0,0 +1,17 @@
+package contentUtils
+
+import "strings"
+
+// renderer converts the content to HTML; every piece of text written to
+// the output is escaped, so the only tags in the output are the ones the
+// renderer itself writes.
+type renderer struct {
+	// latex is true if the formulas have to be recognised.
+	latex bool
+
+	// err is the first problem found in the content; the rendering never
+	// stops because of it, the (safe) output is still produced.
+	err error
+
+	out strings.Builder
+}
@@ -0,0 +1,40 @@
+package contentUtils
+
+import "errors"
+
+var (
+	ErrInvalidFormat       = errors.New("invalid content format")
+	ErrInvalidEncoding     = errors.New("content is not valid utf-8")
+	ErrUnsafeLink          = errors.New("content contains a link with a disallowed scheme")
+	ErrUnsafeLatexCommand  = errors.New("content contains a disallowed latex command")
+	ErrUnclosedMathBlock   = errors.New("content contains an unclosed $$ math block")
+	ErrUnclosedCodeBlock   = errors.New("content contains an unclosed ``` code block")
+	ErrContentHasNullBytes = errors.New("content contains null bytes")
+	ErrContentTooLong      = errors.New("content is too long")
+)
+
+// allowedLinkSchemes are the schemes the links of the content can have;
+// links without a scheme are relative and always allowed.
+var allowedLinkSchemes = map[string]bool{
+	"http":   true,
+	"https":  true,
+	"mailto": true,
+}
+
+// allowedImageSchemes are the schemes the images of the content can have.
+var allowedImageSchemes = map[string]bool{
+	"http":  true,
+	"https": true,
+}
+
+// disallowedLatexCommands are the commands which can produce links, load
+// external resources or inject attributes into the typeset formulas.
+var disallowedLatexCommands = map[string]bool{
+	"href":            true,
+	"url":             true,
+	"includegraphics": true,
+	"htmlClass":       true,
+	"htmlId":          true,
+	"htmlStyle":       true,
+	"htmlData":        true,
+}
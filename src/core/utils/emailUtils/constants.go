@@ -2,4 +2,8 @@ package emailUtils
 
 const (
 	DefaultEmailLanguage = "en"
+
+	// DefaultInviteeName is used in the invitation emails sent to the
+	// addresses which don't have an account yet.
+	DefaultInviteeName = "Student"
 )
@@ -4,6 +4,7 @@ import (
 	"ExamSphere/src/core/appConfig"
 	"encoding/base64"
 	"fmt"
+	"html"
 	"strings"
 
 	"github.com/ALiwoto/ssg/ssg"
@@ -91,6 +92,44 @@ func SendConfirmAccountEmail(data *ConfirmAccountEmailData) error {
 	return nil
 }
 
+func SendExamInvitationEmail(data *ExamInvitationEmailData) error {
+	if emailSenderClient == nil {
+		return ErrEmailClientNotLoaded
+	}
+
+	e := email.NewEmail()
+	e.From = emailSenderClient.EmailFrom
+	e.To = []string{data.EmailTo}
+	e.Subject = "Exam Invitation: " + data.ExamTitle
+
+	htmlTemplate, ok := ExamInvitationTemplateMap[data.Lang]
+	if !ok {
+		// default to english
+		htmlTemplate = ExamInvitationTemplateMap[DefaultEmailLanguage]
+	}
+
+	inviteeName := data.InviteeName
+	if inviteeName == "" {
+		inviteeName = DefaultInviteeName
+	}
+
+	e.HTML = []byte(fmt.Sprintf(htmlTemplate,
+		html.EscapeString(inviteeName),
+		html.EscapeString(data.InviterName),
+		html.EscapeString(data.ExamTitle),
+		data.ExamDate,
+		data.JoinLink,
+		data.ExpiresAt,
+	))
+
+	err := e.Send(emailSenderClient.GetHostAddress(), emailSenderClient.GetSmtpAuth())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func DecodeSpecificPassword(encodedPassword string) (string, error) {
 	// Base64 decode the whole string
 	decoded, err := base64.StdEncoding.DecodeString(encodedPassword)
@@ -121,6 +160,37 @@ func DecodeSpecificPassword(encodedPassword string) (string, error) {
 	return passResult, nil
 }
 
+func SendWaitlistPromotionEmail(data *WaitlistPromotionEmailData) error {
+	if emailSenderClient == nil {
+		return ErrEmailClientNotLoaded
+	}
+
+	e := email.NewEmail()
+	e.From = emailSenderClient.EmailFrom
+	e.To = []string{data.EmailTo}
+	e.Subject = "A seat has opened up: " + data.ExamTitle
+
+	htmlTemplate, ok := WaitlistPromotionTemplateMap[data.Lang]
+	if !ok {
+		// default to english
+		htmlTemplate = WaitlistPromotionTemplateMap[DefaultEmailLanguage]
+	}
+
+	e.HTML = []byte(fmt.Sprintf(htmlTemplate,
+		html.EscapeString(data.UserFullName),
+		html.EscapeString(data.ExamTitle),
+		data.ExamDate,
+		html.EscapeString(data.Price),
+	))
+
+	err := e.Send(emailSenderClient.GetHostAddress(), emailSenderClient.GetSmtpAuth())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func fixTemplateFormatting(template string) string {
 	template = strings.ReplaceAll(template, "100%", "100%%")
 
@@ -21,3 +21,25 @@ type ConfirmAccountEmailData struct {
 	EmailTo      string
 	Lang         string
 }
+
+type ExamInvitationEmailData struct {
+	// InviteeName is the full name of the invited user; it's empty if
+	// the invitee doesn't have an account yet.
+	InviteeName string
+	InviterName string
+	ExamTitle   string
+	ExamDate    string
+	ExpiresAt   string
+	JoinLink    string
+	EmailTo     string
+	Lang        string
+}
+
+type WaitlistPromotionEmailData struct {
+	UserFullName string
+	ExamTitle    string
+	ExamDate     string
+	Price        string
+	EmailTo      string
+	Lang         string
+}
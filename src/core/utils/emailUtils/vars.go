@@ -14,6 +14,12 @@ var (
 
 	//go:embed templates/ConfirmAccount.en.html
 	ConfirmAccountEmailTemplate_en string
+
+	//go:embed templates/ExamInvitation.en.html
+	ExamInvitationEmailTemplate_en string
+
+	//go:embed templates/WaitlistPromotion.en.html
+	WaitlistPromotionEmailTemplate_en string
 )
 
 var (
@@ -24,4 +30,12 @@ var (
 	ConfirmAccountTemplateMap = map[string]string{
 		"en": fixTemplateFormatting(ConfirmAccountEmailTemplate_en),
 	}
+
+	ExamInvitationTemplateMap = map[string]string{
+		"en": fixTemplateFormatting(ExamInvitationEmailTemplate_en),
+	}
+
+	WaitlistPromotionTemplateMap = map[string]string{
+		"en": fixTemplateFormatting(WaitlistPromotionEmailTemplate_en),
+	}
 )
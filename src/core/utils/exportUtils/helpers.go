@@ -0,0 +1,239 @@
+package exportUtils
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// IsFormatValid returns true if the format is one of the supported export
+// formats.
+func IsFormatValid(format string) bool {
+	return format == FormatCsv || format == FormatXlsx
+}
+
+// GetContentType returns the content type of the files of the format.
+func GetContentType(format string) string {
+	if format == FormatXlsx {
+		return ContentTypeXlsx
+	}
+	return ContentTypeCsv
+}
+
+// GetFileName returns the name of the exported file with the extension
+// of the format; the characters which are not safe in a file name (or in
+// the Content-Disposition header) are replaced with underscores.
+func GetFileName(name, format string) string {
+	safeName := strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') ||
+			(r >= '0' && r <= '9') || r == '-' || r == '_' {
+			return r
+		}
+		return '_'
+	}, name)
+	if safeName == "" {
+		safeName = "export"
+	}
+
+	return safeName + "." + format
+}
+
+// SelectColumns returns the requested columns (a comma separated list of
+// their keys) in the requested order; all of the columns are returned if
+// nothing is requested. The second value is the first requested key which
+// is not one of the available columns, or empty if all of them are.
+func SelectColumns(available []Column, requested string) ([]Column, string) {
+	requested = strings.TrimSpace(requested)
+	if requested == "" {
+		return available, ""
+	}
+
+	columnsMap := make(map[string]Column, len(available))
+	for _, column := range available {
+		columnsMap[column.Key] = column
+	}
+
+	var selected []Column
+	seen := make(map[string]bool)
+	for _, key := range strings.Split(requested, columnsSeparator) {
+		key = strings.TrimSpace(key)
+		if key == "" || seen[key] {
+			continue
+		}
+
+		column, found := columnsMap[key]
+		if !found {
+			return nil, key
+		}
+
+		seen[key] = true
+		selected = append(selected, column)
+	}
+
+	return selected, ""
+}
+
+// GetHeaderRow returns the row of the titles of the columns.
+func GetHeaderRow(columns []Column) []Cell {
+	cells := make([]Cell, 0, len(columns))
+	for _, column := range columns {
+		cells = append(cells, TextCell(column.Title))
+	}
+	return cells
+}
+
+// GetRow returns the cells of the columns from the values by the keys of
+// the columns; the columns without a value get an empty cell.
+func GetRow(columns []Column, values map[string]Cell) []Cell {
+	cells := make([]Cell, 0, len(columns))
+	for _, column := range columns {
+		cells = append(cells, values[column.Key])
+	}
+	return cells
+}
+
+// TextCell returns a text cell.
+func TextCell(value string) Cell {
+	return Cell{Text: value}
+}
+
+// NumberCell returns a number cell; NaN and infinities become empty cells.
+func NumberCell(value float64) Cell {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return Cell{}
+	}
+	return Cell{Number: value, IsNumber: true}
+}
+
+// OptionalTextCell returns a text cell, or an empty cell if value is nil.
+func OptionalTextCell(value *string) Cell {
+	if value == nil {
+		return Cell{}
+	}
+	return TextCell(*value)
+}
+
+// OptionalNumberCell returns a number cell, or an empty cell if value is nil.
+func OptionalNumberCell(value *float64) Cell {
+	if value == nil {
+		return Cell{}
+	}
+	return NumberCell(*value)
+}
+
+// NewWriter returns a writer of the specified format which writes to w;
+// sheetName is only used by the xlsx files.
+func NewWriter(format string, w io.Writer, sheetName string) (Writer, error) {
+	switch format {
+	case FormatCsv:
+		return &csvWriter{writer: csv.NewWriter(w)}, nil
+	case FormatXlsx:
+		return newXlsxWriter(w, sheetName)
+	}
+
+	return nil, ErrInvalidFormat
+}
+
+func newXlsxWriter(w io.Writer, sheetName string) (*xlsxWriter, error) {
+	archive := zip.NewWriter(w)
+	parts := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRootRels},
+		{"xl/workbook.xml", fmt.Sprintf(xlsxWorkbook, escapeXml(getSheetName(sheetName)))},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+		{"xl/styles.xml", xlsxStyles},
+	}
+	for _, part := range parts {
+		partWriter, err := archive.Create(part.name)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err = io.WriteString(partWriter, part.content); err != nil {
+			return nil, err
+		}
+	}
+
+	// the sheet is the last part, so its rows can be written one by one
+	sheet, err := archive.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = io.WriteString(sheet, xlsxSheetStart); err != nil {
+		return nil, err
+	}
+
+	return &xlsxWriter{archive: archive, sheet: sheet}, nil
+}
+
+// getSheetName removes the characters which are not allowed in the sheet
+// names and cuts the name to the maximum length.
+func getSheetName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		switch r {
+		case '[', ']', ':', '*', '?', '/', '\\':
+			return -1
+		}
+		return r
+	}, strings.TrimSpace(name))
+
+	if runes := []rune(name); len(runes) > MaxSheetNameLength {
+		name = string(runes[:MaxSheetNameLength])
+	}
+	name = strings.Trim(name, "'")
+	if name == "" {
+		return DefaultSheetName
+	}
+
+	return name
+}
+
+// getColumnName returns the name of the column with the specified index
+// (starting from 0) in the spreadsheets: A, B, ..., Z, AA, AB, ...
+func getColumnName(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+	return name
+}
+
+// formatNumber formats a number cell without losing its precision.
+func formatNumber(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// escapeCsvText stops the spreadsheet apps from running the text cells
+// which look like formulas.
+func escapeCsvText(value string) string {
+	if value == "" {
+		return value
+	}
+
+	switch value[0] {
+	case '=', '+', '-', '@', '\t', '\r':
+		return csvFormulaPrefix + value
+	}
+	return value
+}
+
+func escapeXml(value string) string {
+	var builder strings.Builder
+	// EscapeText only fails if the writer does
+	_ = xmlEscapeText(&builder, value)
+	return builder.String()
+}
+
+func xmlEscapeText(w io.Writer, value string) error {
+	return xml.EscapeText(w, []byte(value))
+}
@@ -0,0 +1,121 @@
+package exportUtils_test
+
+import (
+	"ExamSphere/src/core/utils/exportUtils"
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+var testColumns = []exportUtils.Column{
+	{Key: "user_id", Title: "User ID"},
+	{Key: "full_name", Title: "Full name"},
+	{Key: "final_score", Title: "Final score"},
+}
+
+func TestSelectColumns(t *testing.T) {
+	all, unknown := exportUtils.SelectColumns(testColumns, "")
+	if unknown != "" || len(all) != len(testColumns) {
+		t.Errorf("SelectColumns(\"\") = %v, %q, expected all of the columns", all, unknown)
+	}
+
+	selected, unknown := exportUtils.SelectColumns(testColumns, "final_score, user_id,final_score")
+	if unknown != "" || len(selected) != 2 ||
+		selected[0].Key != "final_score" || selected[1].Key != "user_id" {
+		t.Errorf("SelectColumns = %v, %q, expected final_score and user_id", selected, unknown)
+	}
+
+	if _, unknown = exportUtils.SelectColumns(testColumns, "user_id,password"); unknown != "password" {
+		t.Errorf("SelectColumns returned unknown key %q, expected password", unknown)
+	}
+}
+
+func TestCsvWriter(t *testing.T) {
+	var buffer bytes.Buffer
+	writer, err := exportUtils.NewWriter(exportUtils.FormatCsv, &buffer, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_ = writer.WriteRow(exportUtils.GetHeaderRow(testColumns))
+	_ = writer.WriteRow(exportUtils.GetRow(testColumns, map[string]exportUtils.Cell{
+		"user_id":     exportUtils.TextCell("u1"),
+		"full_name":   exportUtils.TextCell("=HYPERLINK(\"x\")"),
+		"final_score": exportUtils.NumberCell(87.5),
+	}))
+	_ = writer.WriteRow(exportUtils.GetRow(testColumns, map[string]exportUtils.Cell{
+		"user_id": exportUtils.TextCell("u2"),
+	}))
+	if err = writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "User ID,Full name,Final score\n" +
+		"u1,\"'=HYPERLINK(\"\"x\"\")\",87.5\n" +
+		"u2,,\n"
+	if buffer.String() != expected {
+		t.Errorf("csv output = %q, expected %q", buffer.String(), expected)
+	}
+}
+
+func TestXlsxWriter(t *testing.T) {
+	var buffer bytes.Buffer
+	writer, err := exportUtils.NewWriter(exportUtils.FormatXlsx, &buffer, "Course [1]: grades")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_ = writer.WriteRow(exportUtils.GetHeaderRow(testColumns))
+	_ = writer.WriteRow([]exportUtils.Cell{
+		exportUtils.TextCell("a < b & c"),
+		exportUtils.OptionalTextCell(nil),
+		exportUtils.NumberCell(12),
+	})
+	if err = writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatalf("xlsx output is not a valid zip archive: %v", err)
+	}
+
+	files := make(map[string]string)
+	for _, file := range archive.File {
+		reader, err := file.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, _ := io.ReadAll(reader)
+		_ = reader.Close()
+		files[file.Name] = string(content)
+	}
+
+	if !strings.Contains(files["xl/workbook.xml"], `name="Course 1 grades"`) {
+		t.Errorf("workbook does not have the sanitized sheet name: %s", files["xl/workbook.xml"])
+	}
+
+	sheet := files["xl/worksheets/sheet1.xml"]
+	for _, expected := range []string{
+		`<c r="C1" t="inlineStr"><is><t xml:space="preserve">Final score</t></is></c>`,
+		`<c r="A2" t="inlineStr"><is><t xml:space="preserve">a &lt; b &amp; c</t></is></c>`,
+		`<c r="C2"><v>12</v></c>`,
+		`</sheetData></worksheet>`,
+	} {
+		if !strings.Contains(sheet, expected) {
+			t.Errorf("sheet does not contain %s", expected)
+		}
+	}
+}
+
+func TestInvalidFormat(t *testing.T) {
+	if exportUtils.IsFormatValid("pdf") {
+		t.Error("IsFormatValid(\"pdf\") = true")
+	}
+
+	if _, err := exportUtils.NewWriter("pdf", io.Discard, ""); err != exportUtils.ErrInvalidFormat {
+		t.Errorf("NewWriter(\"pdf\") error = %v, expected ErrInvalidFormat", err)
+	}
+}
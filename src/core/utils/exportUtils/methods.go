@@ -0,0 +1,94 @@
+package exportUtils
+
+import (
+	"io"
+	"strconv"
+	"strings"
+)
+
+// WriteRow writes a row of cells to the csv file.
+func (w *csvWriter) WriteRow(cells []Cell) error {
+	if w.closed {
+		return ErrWriterClosed
+	}
+
+	record := make([]string, 0, len(cells))
+	for _, cell := range cells {
+		if cell.IsNumber {
+			record = append(record, formatNumber(cell.Number))
+			continue
+		}
+		record = append(record, escapeCsvText(cell.Text))
+	}
+
+	return w.writer.Write(record)
+}
+
+// Flush writes the buffered rows to the underlying writer.
+func (w *csvWriter) Flush() error {
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+// Close flushes the remaining rows of the csv file.
+func (w *csvWriter) Close() error {
+	if w.closed {
+		return nil
+	}
+
+	w.closed = true
+	return w.Flush()
+}
+
+// WriteRow writes a row of cells to the sheet of the xlsx file.
+func (w *xlsxWriter) WriteRow(cells []Cell) error {
+	if w.closed {
+		return ErrWriterClosed
+	}
+
+	w.rowIndex++
+	rowNumber := strconv.Itoa(w.rowIndex)
+
+	var builder strings.Builder
+	builder.WriteString(`<row r="` + rowNumber + `">`)
+	for i, cell := range cells {
+		reference := getColumnName(i) + rowNumber
+		if cell.IsNumber {
+			builder.WriteString(`<c r="` + reference + `"><v>` + formatNumber(cell.Number) + `</v></c>`)
+			continue
+		} else if cell.Text == "" {
+			continue
+		}
+
+		// inline strings are never run as formulas, so nothing needs to
+		// be escaped other than the xml itself
+		builder.WriteString(`<c r="` + reference + `" t="inlineStr"><is><t xml:space="preserve">`)
+		if err := xmlEscapeText(&builder, cell.Text); err != nil {
+			return err
+		}
+		builder.WriteString(`</t></is></c>`)
+	}
+	builder.WriteString(`</row>`)
+
+	_, err := io.WriteString(w.sheet, builder.String())
+	return err
+}
+
+// Flush writes the compressed rows to the underlying writer.
+func (w *xlsxWriter) Flush() error {
+	return w.archive.Flush()
+}
+
+// Close finishes the sheet and the archive of the xlsx file.
+func (w *xlsxWriter) Close() error {
+	if w.closed {
+		return nil
+	}
+
+	w.closed = true
+	if _, err := io.WriteString(w.sheet, xlsxSheetEnd); err != nil {
+		return err
+	}
+
+	return w.archive.Close()
+}
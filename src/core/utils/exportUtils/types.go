@@ -0,0 +1,48 @@
+package exportUtils
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"io"
+)
+
+// Column is a column which can be selected to be exported.
+type Column struct {
+	// Key is how the column is selected (e.g. final_score).
+	Key string
+
+	// Title is the header of the column in the exported file.
+	Title string
+}
+
+// Cell is a cell of an exported row; it's either a text or a number.
+type Cell struct {
+	Text     string
+	Number   float64
+	IsNumber bool
+}
+
+// Writer writes the rows of an export to the underlying writer, as soon
+// as they are written; so the exports of any size can be streamed.
+type Writer interface {
+	// WriteRow writes a row of cells.
+	WriteRow(cells []Cell) error
+
+	// Flush writes the buffered rows (if any) to the underlying writer.
+	Flush() error
+
+	// Close finishes the file; it does not close the underlying writer.
+	Close() error
+}
+
+type csvWriter struct {
+	writer *csv.Writer
+	closed bool
+}
+
+type xlsxWriter struct {
+	archive  *zip.Writer
+	sheet    io.Writer
+	rowIndex int
+	closed   bool
+}
@@ -0,0 +1,8 @@
+package exportUtils
+
+import "errors"
+
+var (
+	ErrInvalidFormat = errors.New("invalid export format")
+	ErrWriterClosed  = errors.New("export writer is closed")
+)
@@ -0,0 +1,81 @@
+package gradebookUtils
+
+import "sort"
+
+// ComputeCategoryGrade averages the scores of the category, after dropping
+// its lowest ones. At least one score is always kept, so dropping never
+// leaves a student who has been scored without a grade.
+func ComputeCategoryGrade(category *CategoryScores) *CategoryGrade {
+	grade := &CategoryGrade{}
+
+	scored := make([]int, 0, len(category.Scores))
+	for i, score := range category.Scores {
+		if score != nil {
+			scored = append(scored, i)
+		}
+	}
+
+	if len(scored) == 0 {
+		return grade
+	}
+
+	dropCount := min(max(category.DropLowest, 0), len(scored)-1)
+	if dropCount > 0 {
+		// stable, so the earlier exams are dropped first on a tie
+		sort.SliceStable(scored, func(a, b int) bool {
+			return *category.Scores[scored[a]] < *category.Scores[scored[b]]
+		})
+		grade.Dropped = append(grade.Dropped, scored[:dropCount]...)
+		sort.Ints(grade.Dropped)
+		scored = scored[dropCount:]
+	}
+
+	var total float64
+	for _, i := range scored {
+		total += *category.Scores[i]
+	}
+
+	average := total / float64(len(scored))
+	grade.Average = &average
+	return grade
+}
+
+// ComputeCourseGrade computes the grade of each category and their weighted
+// average. The categories without a grade are left out and the weights of
+// the rest are normalised, so the grade reflects the exams scored so far.
+func ComputeCourseGrade(categories []*CategoryScores) *CourseGrade {
+	result := &CourseGrade{
+		Categories: make([]*CategoryGrade, 0, len(categories)),
+	}
+
+	var weightedTotal, totalWeight float64
+	for _, category := range categories {
+		grade := ComputeCategoryGrade(category)
+		result.Categories = append(result.Categories, grade)
+
+		if grade.Average == nil || category.Weight <= 0 {
+			continue
+		}
+
+		weightedTotal += *grade.Average * category.Weight
+		totalWeight += category.Weight
+	}
+
+	if totalWeight > 0 {
+		courseGrade := weightedTotal / totalWeight
+		result.Grade = &courseGrade
+	}
+
+	return result
+}
+
+// GetLetterGrade returns the letter grade of a score percentage.
+func GetLetterGrade(percentage float64) string {
+	for _, grade := range letterGrades {
+		if percentage >= grade.MinPercentage {
+			return grade.Letter
+		}
+	}
+
+	return letterGrades[len(letterGrades)-1].Letter
+}
@@ -0,0 +1,90 @@
+package gradebookUtils_test
+
+import (
+	"ExamSphere/src/core/utils/gradebookUtils"
+	"math"
+	"testing"
+)
+
+func scores(values ...float64) []*float64 {
+	result := make([]*float64, 0, len(values))
+	for i := range values {
+		if values[i] < 0 {
+			// negative values stand for the exams not scored yet
+			result = append(result, nil)
+			continue
+		}
+		result = append(result, &values[i])
+	}
+	return result
+}
+
+func TestComputeCategoryGrade(t *testing.T) {
+	grade := gradebookUtils.ComputeCategoryGrade(&gradebookUtils.CategoryScores{
+		DropLowest: 1,
+		Scores:     scores(80, 40, 90, -1),
+	})
+	if grade.Average == nil || math.Abs(*grade.Average-85) > 1e-9 {
+		t.Errorf("ComputeCategoryGrade: got %v, expected 85", grade.Average)
+	}
+	if len(grade.Dropped) != 1 || grade.Dropped[0] != 1 {
+		t.Errorf("ComputeCategoryGrade: dropped %v, expected [1]", grade.Dropped)
+	}
+
+	grade = gradebookUtils.ComputeCategoryGrade(&gradebookUtils.CategoryScores{
+		DropLowest: 3,
+		Scores:     scores(-1, 70, 60),
+	})
+	if grade.Average == nil || math.Abs(*grade.Average-70) > 1e-9 {
+		t.Errorf("ComputeCategoryGrade: got %v, expected the highest score to be kept", grade.Average)
+	}
+
+	grade = gradebookUtils.ComputeCategoryGrade(&gradebookUtils.CategoryScores{
+		DropLowest: 1,
+		Scores:     scores(-1, -1),
+	})
+	if grade.Average != nil || len(grade.Dropped) != 0 {
+		t.Errorf("ComputeCategoryGrade: got %v (dropped %v) without any scores", grade.Average, grade.Dropped)
+	}
+}
+
+func TestComputeCourseGrade(t *testing.T) {
+	result := gradebookUtils.ComputeCourseGrade([]*gradebookUtils.CategoryScores{
+		{Weight: 20, DropLowest: 1, Scores: scores(50, 100, 90)},
+		{Weight: 30, Scores: scores(70)},
+		{Weight: 50, Scores: scores(80)},
+	})
+	// 20% * 95 + 30% * 70 + 50% * 80
+	if result.Grade == nil || math.Abs(*result.Grade-80) > 1e-9 {
+		t.Errorf("ComputeCourseGrade: got %v, expected 80", result.Grade)
+	}
+	if len(result.Categories) != 3 {
+		t.Fatalf("ComputeCourseGrade: got %d categories, expected 3", len(result.Categories))
+	}
+
+	result = gradebookUtils.ComputeCourseGrade([]*gradebookUtils.CategoryScores{
+		{Weight: 20, Scores: scores(60)},
+		{Weight: 30, Scores: scores(90)},
+		{Weight: 50, Scores: scores(-1)},
+	})
+	// the final has not been scored yet, so only the rest count
+	if result.Grade == nil || math.Abs(*result.Grade-78) > 1e-9 {
+		t.Errorf("ComputeCourseGrade: got %v, expected 78", result.Grade)
+	}
+
+	result = gradebookUtils.ComputeCourseGrade([]*gradebookUtils.CategoryScores{
+		{Weight: 0, Scores: scores(60)},
+	})
+	if result.Grade != nil {
+		t.Errorf("ComputeCourseGrade: got %v, expected no grade without weights", *result.Grade)
+	}
+}
+
+func TestGetLetterGrade(t *testing.T) {
+	cases := map[float64]string{100: "A", 90: "A", 89.99: "B", 75: "C", 60: "D", 59.5: "F", -5: "F"}
+	for percentage, expected := range cases {
+		if grade := gradebookUtils.GetLetterGrade(percentage); grade != expected {
+			t.Errorf("GetLetterGrade(%v) = %s, expected %s", percentage, grade, expected)
+		}
+	}
+}
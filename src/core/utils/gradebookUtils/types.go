@@ -0,0 +1,46 @@
+package gradebookUtils
+
+// CategoryScores holds the scores of a student in the exams of a grade
+// category (e.g. quizzes) alongside the rules of the category.
+type CategoryScores struct {
+	// Weight is the share of the category in the course grade; the
+	// weights don't have to add up to 100, they are normalised.
+	Weight float64
+
+	// DropLowest is the number of the lowest scores which are left out
+	// of the average of the category.
+	DropLowest int
+
+	// Scores are the score percentages of the student in the exams of
+	// the category; nil means the exam has not been scored (yet), so it
+	// is left out as well.
+	Scores []*float64
+}
+
+// CategoryGrade is the grade of a student in a grade category.
+type CategoryGrade struct {
+	// Average is the average of the scores which are not dropped; nil if
+	// there are no such scores.
+	Average *float64
+
+	// Dropped holds the indexes (in the scores of the category) of the
+	// dropped scores.
+	Dropped []int
+}
+
+// CourseGrade is the grade of a student in a course.
+type CourseGrade struct {
+	// Grade is the weighted average of the grades of the categories; nil
+	// if none of the categories (with a weight) has a grade yet.
+	Grade *float64
+
+	// Categories holds the grades of the categories, in the same order as
+	// they were given.
+	Categories []*CategoryGrade
+}
+
+// LetterGrade is a step of the scale of the letter grades.
+type LetterGrade struct {
+	Letter        string
+	MinPercentage float64
+}
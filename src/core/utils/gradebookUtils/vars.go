@@ -0,0 +1,13 @@
+package gradebookUtils
+
+var (
+	// letterGrades is the scale of the letter grades, from the highest;
+	// a grade is given for the percentages at or above its minimum.
+	letterGrades = []LetterGrade{
+		{Letter: "A", MinPercentage: 90},
+		{Letter: "B", MinPercentage: 80},
+		{Letter: "C", MinPercentage: 70},
+		{Letter: "D", MinPercentage: 60},
+		{Letter: "F", MinPercentage: 0},
+	}
+)
@@ -3,4 +3,7 @@ package hashing
 const (
 	AuthHashSize     = 8
 	AgentAuthKeySize = 32
+
+	// RotatingCodeDigits is the number of digits in a rotating code.
+	RotatingCodeDigits = 6
 )
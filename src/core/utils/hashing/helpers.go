@@ -1,11 +1,14 @@
 package hashing
 
 import (
+	"crypto/hmac"
 	"crypto/sha256"
 	"crypto/sha512"
 	"encoding/base64"
+	"encoding/binary"
 	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"math/rand"
 	"strconv"
 	"strings"
@@ -125,3 +128,57 @@ func DecodeValueFromBase64(value string, result any) error {
 
 	return json.Unmarshal(b, result)
 }
+
+// GenerateRotatingCode generates a numeric code (HOTP-like) out of the
+// specified secret and counter. The same secret and counter will always
+// result in the same code, so a time-based counter can be used to get
+// a code which rotates over time.
+func GenerateRotatingCode(secret string, counter int64) string {
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, uint64(counter))
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+
+	// dynamic truncation, as described in RFC 4226
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	modulo := uint32(1)
+	for i := 0; i < RotatingCodeDigits; i++ {
+		modulo *= 10
+	}
+
+	return fmt.Sprintf("%0*d", RotatingCodeDigits, value%modulo)
+}
+
+// SignValue returns the (hex encoded) HMAC-SHA256 signature of the value,
+// so it can be handed out (e.g. in a link) and verified later on.
+func SignValue(key []byte, value string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignedValue returns true if the signature is the one generated by
+// SignValue for the same key and value.
+func VerifySignedValue(key []byte, value, signature string) bool {
+	expected, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(value))
+	return hmac.Equal(mac.Sum(nil), expected)
+}
+
+// DeriveKey derives a key for the specified purpose from the key, so a
+// single secret can sign different kinds of values without a signature of
+// one kind ever being valid for another.
+func DeriveKey(key []byte, purpose string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(purpose))
+	return mac.Sum(nil)
+}
@@ -19,3 +19,51 @@ func TestGenerateAccessHash(t *testing.T) {
 		t.Error("Expected 8 characters, got", len(h))
 	}
 }
+
+func TestGenerateRotatingCode(t *testing.T) {
+	c1 := hashing.GenerateRotatingCode("secret", 42)
+	if len(c1) != hashing.RotatingCodeDigits {
+		t.Errorf("Expected %d digits, got %s", hashing.RotatingCodeDigits, c1)
+	}
+
+	if c2 := hashing.GenerateRotatingCode("secret", 42); c1 != c2 {
+		t.Error("Expected the same code for the same counter, got", c1, c2)
+	}
+
+	if c3 := hashing.GenerateRotatingCode("another-secret", 42); c1 == c3 {
+		t.Error("Expected different codes for different secrets, got", c1)
+	}
+}
+
+func TestSignValue(t *testing.T) {
+	key := []byte("signing-key")
+	signature := hashing.SignValue(key, "examInvite:1")
+	if !hashing.VerifySignedValue(key, "examInvite:1", signature) {
+		t.Error("Expected the signature to be valid, got", signature)
+	}
+
+	if hashing.VerifySignedValue(key, "examInvite:2", signature) {
+		t.Error("Expected the signature to be invalid for another value")
+	}
+
+	if hashing.VerifySignedValue([]byte("another-key"), "examInvite:1", signature) {
+		t.Error("Expected the signature to be invalid for another key")
+	}
+}
+
+func TestDeriveKey(t *testing.T) {
+	key := []byte("signing-key")
+	derived := hashing.DeriveKey(key, "exam-invite")
+	if string(derived) == string(key) || len(derived) != 32 {
+		t.Error("Expected a new 32 bytes key, got", derived)
+	}
+
+	if string(hashing.DeriveKey(key, "exam-invite")) != string(derived) {
+		t.Error("Expected the same key to be derived for the same purpose")
+	}
+
+	signature := hashing.SignValue(derived, "examInvite:1")
+	if hashing.VerifySignedValue(key, "examInvite:1", signature) {
+		t.Error("Expected the signature to be invalid for the original key")
+	}
+}
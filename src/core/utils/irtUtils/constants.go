@@ -0,0 +1,26 @@
+package irtUtils
+
+const (
+	// PriorMean and PriorStdDev describe the normal distribution the
+	// abilities of the students are assumed to follow before any answer.
+	PriorMean   = 0.0
+	PriorStdDev = 1.0
+
+	// MinTheta and MaxTheta are the bounds of the ability scale used by
+	// the estimation.
+	MinTheta = -4.0
+	MaxTheta = 4.0
+
+	// quadraturePoints is the number of points used to integrate over the
+	// ability scale while estimating.
+	quadraturePoints = 81
+)
+
+const (
+	// DefaultDiscrimination is the discrimination of the items which have
+	// not been calibrated.
+	DefaultDiscrimination = 1.0
+
+	// MaxGuessing is the (exclusive) upper bound of the guessing parameter.
+	MaxGuessing = 1.0
+)
@@ -0,0 +1,79 @@
+package irtUtils
+
+import "math"
+
+// Probability returns the chance of a student with the specified ability
+// answering the item correctly.
+func Probability(item ItemParams, theta float64) float64 {
+	return item.Guessing + (1-item.Guessing)/
+		(1+math.Exp(-item.Discrimination*(theta-item.Difficulty)))
+}
+
+// Information returns the Fisher information of the item at the specified
+// ability; the higher it is, the more the item tells about students with
+// that ability.
+func Information(item ItemParams, theta float64) float64 {
+	p := Probability(item, theta)
+	if p <= 0 || p >= 1 {
+		return 0
+	}
+
+	q := 1 - p
+	ratio := (p - item.Guessing) / (1 - item.Guessing)
+	return item.Discrimination * item.Discrimination * ratio * ratio * q / p
+}
+
+// EstimateAbility estimates the ability of a student from their responses,
+// using the expected a posteriori (EAP) method; unlike maximum likelihood,
+// it also gives a finite estimate when all the responses are correct (or
+// all of them are wrong). Without any responses, the prior is returned.
+func EstimateAbility(responses []Response) *AbilityEstimate {
+	step := (MaxTheta - MinTheta) / float64(quadraturePoints-1)
+
+	var total, weightedSum, weightedSquares float64
+	for i := 0; i < quadraturePoints; i++ {
+		theta := MinTheta + float64(i)*step
+		z := (theta - PriorMean) / PriorStdDev
+		weight := math.Exp(-z * z / 2)
+
+		for _, response := range responses {
+			p := Probability(response.Item, theta)
+			if response.IsCorrect {
+				weight *= p
+			} else {
+				weight *= 1 - p
+			}
+		}
+
+		total += weight
+		weightedSum += weight * theta
+		weightedSquares += weight * theta * theta
+	}
+
+	if total == 0 {
+		return &AbilityEstimate{Theta: PriorMean, StdError: PriorStdDev}
+	}
+
+	theta := weightedSum / total
+	variance := weightedSquares/total - theta*theta
+	return &AbilityEstimate{
+		Theta:    theta,
+		StdError: math.Sqrt(math.Max(variance, 0)),
+	}
+}
+
+// SelectNextItem returns the index of the item which is the most
+// informative at the specified ability, or -1 if there are no items.
+func SelectNextItem(items []ItemParams, theta float64) int {
+	selected := -1
+	bestInformation := -1.0
+	for i, item := range items {
+		information := Information(item, theta)
+		if information > bestInformation {
+			selected = i
+			bestInformation = information
+		}
+	}
+
+	return selected
+}
@@ -0,0 +1,91 @@
+package irtUtils_test
+
+import (
+	"ExamSphere/src/core/utils/irtUtils"
+	"math"
+	"testing"
+)
+
+func TestProbability(t *testing.T) {
+	item := irtUtils.ItemParams{Discrimination: 1.2, Difficulty: 0.5, Guessing: 0.25}
+
+	if p := irtUtils.Probability(item, item.Difficulty); math.Abs(p-0.625) > 1e-9 {
+		t.Errorf("Probability at the difficulty = %f, expected 0.625", p)
+	}
+
+	if p := irtUtils.Probability(item, irtUtils.MinTheta*4); p < item.Guessing || p > item.Guessing+0.01 {
+		t.Errorf("Probability at a very low ability = %f, expected about the guessing chance", p)
+	}
+
+	if irtUtils.Probability(item, 1) <= irtUtils.Probability(item, 0) {
+		t.Error("Probability should grow with the ability")
+	}
+}
+
+func TestEstimateAbility(t *testing.T) {
+	prior := irtUtils.EstimateAbility(nil)
+	if math.Abs(prior.Theta-irtUtils.PriorMean) > 1e-9 || math.Abs(prior.StdError-irtUtils.PriorStdDev) > 0.01 {
+		t.Errorf("EstimateAbility(nil) = %+v, expected the prior", prior)
+	}
+
+	var correct, wrong []irtUtils.Response
+	for _, difficulty := range []float64{-1, 0, 1} {
+		item := irtUtils.ItemParams{Discrimination: 1.5, Difficulty: difficulty}
+		correct = append(correct, irtUtils.Response{Item: item, IsCorrect: true})
+		wrong = append(wrong, irtUtils.Response{Item: item, IsCorrect: false})
+	}
+
+	high := irtUtils.EstimateAbility(correct)
+	low := irtUtils.EstimateAbility(wrong)
+	if high.Theta <= 0 || low.Theta >= 0 {
+		t.Errorf("EstimateAbility: got %f for all correct and %f for all wrong", high.Theta, low.Theta)
+	}
+
+	if high.StdError >= prior.StdError || low.StdError >= prior.StdError {
+		t.Error("EstimateAbility: the responses should make the estimate more precise")
+	}
+
+	if math.IsNaN(high.Theta) || high.Theta > irtUtils.MaxTheta {
+		t.Errorf("EstimateAbility: estimate %f is out of the scale", high.Theta)
+	}
+}
+
+func TestSelectNextItem(t *testing.T) {
+	items := []irtUtils.ItemParams{
+		{Discrimination: 1, Difficulty: -2},
+		{Discrimination: 1, Difficulty: 0.2},
+		{Discrimination: 1, Difficulty: 2},
+	}
+
+	if selected := irtUtils.SelectNextItem(items, 0); selected != 1 {
+		t.Errorf("SelectNextItem at 0 = %d, expected 1", selected)
+	}
+
+	if selected := irtUtils.SelectNextItem(items, 2.5); selected != 2 {
+		t.Errorf("SelectNextItem at 2.5 = %d, expected 2", selected)
+	}
+
+	if selected := irtUtils.SelectNextItem(nil, 0); selected != -1 {
+		t.Errorf("SelectNextItem without items = %d, expected -1", selected)
+	}
+}
+
+func TestStopRule(t *testing.T) {
+	rule := &irtUtils.StopRule{MaxItems: 10, TargetStdError: 0.3}
+
+	if rule.ShouldStop(0, irtUtils.EstimateAbility(nil)) {
+		t.Error("ShouldStop: the test should not stop before any answer")
+	}
+
+	if !rule.ShouldStop(10, &irtUtils.AbilityEstimate{StdError: 0.8}) {
+		t.Error("ShouldStop: the test should stop on the item count")
+	}
+
+	if !rule.ShouldStop(4, &irtUtils.AbilityEstimate{StdError: 0.25}) {
+		t.Error("ShouldStop: the test should stop on the precision target")
+	}
+
+	if (&irtUtils.StopRule{}).ShouldStop(50, &irtUtils.AbilityEstimate{}) {
+		t.Error("ShouldStop: an empty rule should never stop the test")
+	}
+}
@@ -0,0 +1,19 @@
+package irtUtils
+
+// IsValid returns true if the parameters are within their valid ranges.
+func (p ItemParams) IsValid() bool {
+	return p.Discrimination > 0 &&
+		p.Guessing >= 0 && p.Guessing < MaxGuessing &&
+		p.Difficulty >= MinTheta*2 && p.Difficulty <= MaxTheta*2
+}
+
+// ShouldStop returns true if the test should end after the specified
+// number of answered items with the specified estimate.
+func (r *StopRule) ShouldStop(answeredCount int, estimate *AbilityEstimate) bool {
+	if r.MaxItems > 0 && answeredCount >= r.MaxItems {
+		return true
+	}
+
+	return r.TargetStdError > 0 && answeredCount > 0 &&
+		estimate != nil && estimate.StdError <= r.TargetStdError
+}
@@ -0,0 +1,41 @@
+package irtUtils
+
+// ItemParams holds the parameters of an item (question) in the
+// three-parameter logistic (3PL) model.
+type ItemParams struct {
+	// Discrimination (a) is how sharply the item separates the students
+	// below its difficulty from the ones above it.
+	Discrimination float64
+
+	// Difficulty (b) is the ability at which the item is answered
+	// correctly half the way between the guessing chance and certainty.
+	Difficulty float64
+
+	// Guessing (c) is the chance of answering the item correctly with
+	// no ability at all (e.g. 0.25 for four options).
+	Guessing float64
+}
+
+// Response is the answer of a student to an item.
+type Response struct {
+	Item      ItemParams
+	IsCorrect bool
+}
+
+// AbilityEstimate is the estimated ability (theta) of a student alongside
+// the standard error of the estimate.
+type AbilityEstimate struct {
+	Theta    float64
+	StdError float64
+}
+
+// StopRule decides when an adaptive test ends.
+type StopRule struct {
+	// MaxItems is the maximum number of items given to the student;
+	// 0 means no limit.
+	MaxItems int
+
+	// TargetStdError is the precision at which the test ends; 0 means
+	// the test only ends on MaxItems (or when the items run out).
+	TargetStdError float64
+}
@@ -0,0 +1,17 @@
+package paymentUtils
+
+const (
+	// FakeProviderName is the name of the local payment provider which
+	// doesn't move any real money; only meant for development.
+	FakeProviderName = "fake"
+)
+
+const (
+	// DefaultCurrency is the currency used when a price doesn't
+	// specify one (e.g. "120" is the same as "120T").
+	DefaultCurrency = "T"
+
+	// AmountScale is the number of the minor units in a single unit of the
+	// currency; all amounts are stored in minor units.
+	AmountScale = 100
+)
@@ -0,0 +1,12 @@
+package paymentUtils
+
+import "errors"
+
+var (
+	ErrInvalidPrice              = errors.New("invalid price")
+	ErrInvalidPaymentAmount      = errors.New("invalid payment amount")
+	ErrUnknownPaymentProvider    = errors.New("unknown payment provider")
+	ErrPaymentProviderNotLoaded  = errors.New("payment provider not loaded")
+	ErrPaymentReferenceNotFound  = errors.New("payment reference not found")
+	ErrRefundExceedsChargeAmount = errors.New("refund exceeds charge amount")
+)
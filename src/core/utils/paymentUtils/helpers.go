@@ -0,0 +1,107 @@
+package paymentUtils
+
+import (
+	"ExamSphere/src/core/appConfig"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// RegisterPaymentProvider makes a payment provider available to be used
+// by setting its name in the config file.
+func RegisterPaymentProvider(name string, factory PaymentProviderFactory) {
+	providerFactories[name] = factory
+}
+
+// LoadPaymentProvider loads the payment provider set in the config file.
+func LoadPaymentProvider() error {
+	factory, ok := providerFactories[appConfig.GetPaymentProvider()]
+	if !ok {
+		return ErrUnknownPaymentProvider
+	}
+
+	provider, err := factory()
+	if err != nil {
+		return err
+	}
+
+	currentProvider = provider
+	return nil
+}
+
+// GetPaymentProvider returns the currently loaded payment provider.
+func GetPaymentProvider() (PaymentProvider, error) {
+	if currentProvider == nil {
+		return nil, ErrPaymentProviderNotLoaded
+	}
+
+	return currentProvider, nil
+}
+
+func IsPaymentProviderLoaded() bool {
+	return currentProvider != nil
+}
+
+func NewFakePaymentProvider() (PaymentProvider, error) {
+	return &FakePaymentProvider{
+		mut:       &sync.Mutex{},
+		remaining: make(map[string]int64),
+	}, nil
+}
+
+// ParsePrice parses a price such as "149.99T" and returns its amount (in
+// minor units) and its currency. A price without a currency is considered
+// to be in DefaultCurrency.
+func ParsePrice(value string) (int64, string, error) {
+	value = strings.TrimSpace(value)
+	currencyIndex := strings.IndexFunc(value, unicode.IsLetter)
+	currency := DefaultCurrency
+	if currencyIndex != -1 {
+		currency = value[currencyIndex:]
+		value = strings.TrimSpace(value[:currencyIndex])
+	}
+
+	if value == "" {
+		return 0, "", ErrInvalidPrice
+	}
+
+	wholePart, fractionPart, hasFraction := strings.Cut(value, ".")
+	if wholePart == "" || (hasFraction && (fractionPart == "" || len(fractionPart) > 2)) {
+		return 0, "", ErrInvalidPrice
+	}
+
+	whole, err := strconv.ParseUint(wholePart, 10, 32)
+	if err != nil {
+		return 0, "", ErrInvalidPrice
+	}
+
+	var fraction uint64
+	if hasFraction {
+		fraction, err = strconv.ParseUint(fractionPart, 10, 8)
+		if err != nil {
+			return 0, "", ErrInvalidPrice
+		} else if len(fractionPart) == 1 {
+			fraction *= 10
+		}
+	}
+
+	return int64(whole)*AmountScale + int64(fraction), currency, nil
+}
+
+// FormatPrice formats an amount (in minor units) the same way the prices
+// are written, e.g. 14999 is formatted as "149.99T".
+func FormatPrice(amount int64, currency string) string {
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	result := sign + strconv.FormatInt(amount/AmountScale, 10)
+	if fraction := amount % AmountScale; fraction != 0 {
+		result += "." + strings.TrimSuffix(strconv.FormatInt(AmountScale+fraction, 10)[1:], "0")
+	}
+
+	return result + currency
+}
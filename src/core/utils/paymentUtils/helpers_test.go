@@ -0,0 +1,67 @@
+package paymentUtils_test
+
+import (
+	"ExamSphere/src/core/utils/paymentUtils"
+	"testing"
+)
+
+func TestParsePrice(t *testing.T) {
+	validPrices := map[string]int64{
+		"0T":      0,
+		"120":     12000,
+		"149.99T": 14999,
+		"12.5T":   1250,
+		" 7 T":    700,
+	}
+
+	for value, expected := range validPrices {
+		amount, currency, err := paymentUtils.ParsePrice(value)
+		if err != nil {
+			t.Errorf("ParsePrice(%q): unexpected error: %v", value, err)
+			continue
+		}
+
+		if amount != expected || currency != paymentUtils.DefaultCurrency {
+			t.Errorf("ParsePrice(%q) = %d %s, expected %d %s",
+				value, amount, currency, expected, paymentUtils.DefaultCurrency)
+		}
+
+		formatted := paymentUtils.FormatPrice(amount, currency)
+		if reparsed, _, _ := paymentUtils.ParsePrice(formatted); reparsed != amount {
+			t.Errorf("FormatPrice(%d) = %q doesn't parse back", amount, formatted)
+		}
+	}
+
+	for _, value := range []string{"", "T", "-5T", "1.234T", "1.T", ".5T", "abc"} {
+		if _, _, err := paymentUtils.ParsePrice(value); err == nil {
+			t.Errorf("ParsePrice(%q): expected an error", value)
+		}
+	}
+}
+
+func TestFakePaymentProvider(t *testing.T) {
+	provider, _ := paymentUtils.NewFakePaymentProvider()
+	result, err := provider.Charge(&paymentUtils.ChargeData{
+		UserId: "user1",
+		Amount: 1000,
+	})
+	if err != nil {
+		t.Fatalf("Charge: unexpected error: %v", err)
+	}
+
+	_, err = provider.Refund(&paymentUtils.RefundData{
+		Reference: result.Reference,
+		Amount:    600,
+	})
+	if err != nil {
+		t.Fatalf("Refund: unexpected error: %v", err)
+	}
+
+	_, err = provider.Refund(&paymentUtils.RefundData{
+		Reference: result.Reference,
+		Amount:    600,
+	})
+	if err != paymentUtils.ErrRefundExceedsChargeAmount {
+		t.Errorf("Refund: expected %v, got %v", paymentUtils.ErrRefundExceedsChargeAmount, err)
+	}
+}
@@ -0,0 +1,49 @@
+package paymentUtils
+
+import "strconv"
+
+func (p *FakePaymentProvider) GetName() string {
+	return FakeProviderName
+}
+
+func (p *FakePaymentProvider) Charge(data *ChargeData) (*PaymentResult, error) {
+	if data.Amount <= 0 {
+		return nil, ErrInvalidPaymentAmount
+	}
+
+	p.mut.Lock()
+	defer p.mut.Unlock()
+
+	p.counter++
+	reference := FakeProviderName + "_" + strconv.Itoa(p.counter)
+	p.remaining[reference] = data.Amount
+
+	return &PaymentResult{
+		Provider:  FakeProviderName,
+		Reference: reference,
+	}, nil
+}
+
+func (p *FakePaymentProvider) Refund(data *RefundData) (*PaymentResult, error) {
+	if data.Amount <= 0 {
+		return nil, ErrInvalidPaymentAmount
+	}
+
+	p.mut.Lock()
+	defer p.mut.Unlock()
+
+	remaining, ok := p.remaining[data.Reference]
+	if !ok {
+		return nil, ErrPaymentReferenceNotFound
+	} else if data.Amount > remaining {
+		return nil, ErrRefundExceedsChargeAmount
+	}
+
+	p.remaining[data.Reference] = remaining - data.Amount
+	p.counter++
+
+	return &PaymentResult{
+		Provider:  FakeProviderName,
+		Reference: FakeProviderName + "_refund_" + strconv.Itoa(p.counter),
+	}, nil
+}
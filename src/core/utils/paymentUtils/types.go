@@ -0,0 +1,51 @@
+package paymentUtils
+
+import "sync"
+
+// PaymentProvider is the interface every payment provider (payment gateway)
+// has to implement in order to be used for charging the wallets.
+type PaymentProvider interface {
+	// GetName returns the name of the provider, which is stored alongside
+	// the transactions made through it.
+	GetName() string
+
+	// Charge charges the user the specified amount and returns the
+	// reference of the payment in the provider.
+	Charge(data *ChargeData) (*PaymentResult, error)
+
+	// Refund gives back (a part of) a payment previously made through
+	// the provider.
+	Refund(data *RefundData) (*PaymentResult, error)
+}
+
+// PaymentProviderFactory creates a new instance of a payment provider.
+type PaymentProviderFactory func() (PaymentProvider, error)
+
+type ChargeData struct {
+	UserId      string
+	Amount      int64
+	Currency    string
+	Description string
+}
+
+type RefundData struct {
+	// Reference is the reference of the payment that is being refunded.
+	Reference string
+	Amount    int64
+	Currency  string
+}
+
+type PaymentResult struct {
+	Provider  string
+	Reference string
+}
+
+// FakePaymentProvider is a payment provider that keeps everything in memory
+// and accepts every payment; only meant for development.
+type FakePaymentProvider struct {
+	mut     *sync.Mutex
+	counter int
+
+	// remaining holds the refundable amount of each payment.
+	remaining map[string]int64
+}
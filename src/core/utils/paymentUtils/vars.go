@@ -0,0 +1,9 @@
+package paymentUtils
+
+var (
+	currentProvider PaymentProvider
+
+	providerFactories = map[string]PaymentProviderFactory{
+		FakeProviderName: NewFakePaymentProvider,
+	}
+)
@@ -0,0 +1,59 @@
+package pdfUtils
+
+const (
+	// PageWidth and PageHeight are the size of an A4 page in points.
+	PageWidth  = 595.28
+	PageHeight = 841.89
+
+	// DefaultMargin is the margin around the content of the pages.
+	DefaultMargin = 50
+)
+
+const (
+	FontRegular Font = iota
+	FontBold
+)
+
+const (
+	DefaultFontSize = 10
+	HeadingFontSize = 16
+	SmallFontSize   = 8
+
+	// lineHeightFactor is the height of a line of text relative to the
+	// size of its font.
+	lineHeightFactor = 1.3
+
+	// cellPadding is the padding inside the cells of the tables.
+	cellPadding = 4
+
+	// keyColumnWidth is the width of the keys in the key-value lists.
+	keyColumnWidth = 140
+
+	// defaultGlyphWidth is the width of the glyphs which are missing from
+	// the width tables, in thousandths of the font size.
+	defaultGlyphWidth = 556
+
+	// unknownChar replaces the characters which cannot be written in the
+	// standard fonts, not even as a similar character.
+	unknownChar = '?'
+
+	// noteTextGray is the gray level of the text of the notes.
+	noteTextGray = 0.4
+
+	// ellipsis is put at the end of the texts which are cut to fit.
+	ellipsis = "..."
+)
+
+const (
+	// the maximum of the values of the line charts; the charts are meant
+	// for percentages.
+	chartMaxValue = 100
+
+	// chartGridSteps is the number of the horizontal grid lines of the
+	// line charts (other than the axis).
+	chartGridSteps = 4
+
+	chartAxisWidth   = 28
+	chartLabelHeight = 14
+	chartPointRadius = 2.5
+)
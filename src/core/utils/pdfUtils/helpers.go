@@ -0,0 +1,184 @@
+package pdfUtils
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// NewDocument returns a new document with the specified title (which is
+// kept in the properties of the file) and its first page.
+func NewDocument(title string) *Document {
+	d := &Document{
+		title:    title,
+		font:     FontRegular,
+		fontSize: DefaultFontSize,
+	}
+	d.AddPage()
+
+	return d
+}
+
+// ContentWidth returns the width of the content of the pages, between the
+// margins.
+func ContentWidth() float64 {
+	return PageWidth - 2*DefaultMargin
+}
+
+// GetTextWidth returns the width of the text in points, written in the
+// specified font and size.
+func GetTextWidth(text string, font Font, size float64) float64 {
+	widths := helveticaWidths[:]
+	if font == FontBold {
+		widths = helveticaBoldWidths[:]
+	}
+
+	total := 0
+	for _, r := range text {
+		b, _ := toWinAnsi(r)
+		if b >= 32 && int(b-32) < len(widths) {
+			total += widths[b-32]
+		} else {
+			total += defaultGlyphWidth
+		}
+	}
+
+	return float64(total) * size / 1000
+}
+
+// WrapText breaks the text into the lines which fit in maxWidth; the new
+// lines of the text are kept and the words longer than a line are broken.
+func WrapText(text string, font Font, size, maxWidth float64) []string {
+	var lines []string
+	text = strings.ReplaceAll(strings.ReplaceAll(text, "\r\n", "\n"), "\t", " ")
+	for _, paragraph := range strings.Split(text, "\n") {
+		line := ""
+		for _, word := range strings.Fields(paragraph) {
+			candidate := word
+			if line != "" {
+				candidate = line + " " + word
+			}
+
+			if GetTextWidth(candidate, font, size) <= maxWidth {
+				line = candidate
+				continue
+			}
+
+			if line != "" {
+				lines = append(lines, line)
+			}
+
+			// break the words which do not fit in a line on their own
+			for GetTextWidth(word, font, size) > maxWidth {
+				cut := cutToWidth(word, font, size, maxWidth)
+				lines = append(lines, word[:cut])
+				word = word[cut:]
+			}
+			line = word
+		}
+
+		lines = append(lines, line)
+	}
+
+	return lines
+}
+
+// FitText cuts the text (and puts an ellipsis at its end) if it does not
+// fit in maxWidth.
+func FitText(text string, font Font, size, maxWidth float64) string {
+	if GetTextWidth(text, font, size) <= maxWidth {
+		return text
+	}
+
+	maxWidth -= GetTextWidth(ellipsis, font, size)
+	if maxWidth <= 0 {
+		return ""
+	}
+
+	return strings.TrimSpace(text[:cutToWidth(text, font, size, maxWidth)]) + ellipsis
+}
+
+// cutToWidth returns the length (in bytes) of the longest prefix of the
+// text which fits in maxWidth; it's at least one character, so the callers
+// always make progress.
+func cutToWidth(text string, font Font, size, maxWidth float64) int {
+	_, cut := utf8.DecodeRuneInString(text)
+	for i, r := range text {
+		end := i + utf8.RuneLen(r)
+		if GetTextWidth(text[:end], font, size) > maxWidth {
+			break
+		}
+		cut = max(cut, end)
+	}
+
+	return cut
+}
+
+// encodeText encodes the text in the WinAnsiEncoding of the standard fonts
+// as a literal string of the PDF files. The characters which are not in the
+// encoding are written as a similar one if possible (see toWinAnsi), or as
+// unknownChar; false is returned if any of them is unknown, e.g. the letters
+// of the Persian or CJK texts.
+func encodeText(text string) (string, bool) {
+	var builder strings.Builder
+	allKnown := true
+	builder.WriteByte('(')
+	for _, r := range text {
+		b, known := toWinAnsi(r)
+		allKnown = allKnown && known
+		if b == '(' || b == ')' || b == '\\' {
+			builder.WriteByte('\\')
+		}
+
+		if b >= 0x80 {
+			// written as an octal escape, so the files stay ASCII
+			builder.WriteString("\\" + strconv.FormatInt(int64(b), 8))
+			continue
+		}
+		builder.WriteByte(b)
+	}
+	builder.WriteByte(')')
+
+	return builder.String(), allKnown
+}
+
+// toWinAnsi returns the byte the character is written as in the
+// WinAnsiEncoding. The characters which are not in the encoding are
+// replaced with a similar one if possible (e.g. the Persian digits with the
+// ASCII digits, the accented letters with the letters without the accents);
+// otherwise, unknownChar and false are returned.
+func toWinAnsi(r rune) (byte, bool) {
+	switch {
+	case r >= 32 && r < 127, r >= 0xA0 && r <= 0xFF:
+		return byte(r), true
+	case r >= '۰' && r <= '۹':
+		return byte('0' + r - '۰'), true
+	case r >= '٠' && r <= '٩':
+		return byte('0' + r - '٠'), true
+	}
+
+	if encoded, found := windows1252Chars[r]; found {
+		return encoded, true
+	} else if substitute, found := substituteChars[r]; found {
+		return substitute, true
+	}
+
+	// the decomposed form of e.g. 'ā' starts with 'a'
+	base, _ := utf8.DecodeRuneInString(norm.NFD.String(string(r)))
+	if base != r && base != utf8.RuneError {
+		return toWinAnsi(base)
+	}
+
+	return unknownChar, false
+}
+
+// formatNumber formats the numbers of the content streams.
+func formatNumber(value float64) string {
+	return strconv.FormatFloat(value, 'f', 2, 64)
+}
+
+func getLineHeight(size float64) float64 {
+	return size * lineHeightFactor
+}
@@ -0,0 +1,112 @@
+package pdfUtils_test
+
+import (
+	"ExamSphere/src/core/utils/pdfUtils"
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestWrapText(t *testing.T) {
+	width := pdfUtils.GetTextWidth("hello world", pdfUtils.FontRegular, 10)
+	lines := pdfUtils.WrapText("hello world hello world\nbye", pdfUtils.FontRegular, 10, width)
+	expected := []string{"hello world", "hello world", "bye"}
+	if strings.Join(lines, "|") != strings.Join(expected, "|") {
+		t.Errorf("WrapText = %q, expected %q", lines, expected)
+	}
+
+	long := strings.Repeat("m", 50)
+	lines = pdfUtils.WrapText(long, pdfUtils.FontRegular, 10, 50)
+	if len(lines) < 2 || strings.Join(lines, "") != long {
+		t.Errorf("WrapText did not break the long word: %q", lines)
+	}
+	for _, line := range lines {
+		if pdfUtils.GetTextWidth(line, pdfUtils.FontRegular, 10) > 50 {
+			t.Errorf("line %q is wider than the maximum width", line)
+		}
+	}
+}
+
+func TestFitText(t *testing.T) {
+	if text := pdfUtils.FitText("short", pdfUtils.FontRegular, 10, 100); text != "short" {
+		t.Errorf("FitText cut a text which fits: %q", text)
+	}
+
+	text := pdfUtils.FitText("a rather long label of an exam", pdfUtils.FontRegular, 10, 60)
+	if !strings.HasSuffix(text, "...") || pdfUtils.GetTextWidth(text, pdfUtils.FontRegular, 10) > 60 {
+		t.Errorf("FitText = %q, expected a cut text which fits", text)
+	}
+}
+
+func TestDocumentBytes(t *testing.T) {
+	value := 75.0
+	doc := pdfUtils.NewDocument("Report (final)")
+	doc.Heading("Results of Café", pdfUtils.HeadingFontSize)
+	doc.KeyValues([][2]string{{"Student", "Jane"}, {"Score", "75/100"}})
+	doc.Table([]pdfUtils.TableColumn{
+		{Title: "#", Width: 30},
+		{Title: "Question", Width: pdfUtils.ContentWidth() - 30},
+	}, [][]string{{"1", "What is (2 + 2)?"}})
+	doc.LineChart([]pdfUtils.ChartPoint{{Label: "Exam 1", Value: &value, IsHighlighted: true}, {Label: "Exam 2"}}, 100)
+	for i := 0; i < 100; i++ {
+		doc.Paragraph("a paragraph long enough to need another page at some point")
+	}
+
+	output := doc.Bytes()
+	if doc.HasUnknownChars() {
+		t.Error("HasUnknownChars is true for a Latin-1 document")
+	}
+	if !bytes.HasPrefix(output, []byte("%PDF-1.4")) || !bytes.HasSuffix(output, []byte("%%EOF\n")) {
+		t.Fatal("output is not a PDF file")
+	}
+
+	for _, expected := range []string{`(Report \(final\))`, `(Results of Caf\351)`, `(What is \(2 + 2\)?)`} {
+		if !bytes.Contains(output, []byte(expected)) {
+			t.Errorf("output does not contain %s", expected)
+		}
+	}
+
+	if count := bytes.Count(output, []byte("/Type /Page ")); count < 2 {
+		t.Errorf("output has %d pages, expected the paragraphs to continue on another page", count)
+	}
+
+	// every entry of the cross-reference table has to point at its object
+	startXref := bytes.LastIndex(output, []byte("startxref\n"))
+	xrefOffset, err := strconv.Atoi(strings.Fields(string(output[startXref+len("startxref\n"):]))[0])
+	if err != nil || !bytes.HasPrefix(output[xrefOffset:], []byte("xref\n")) {
+		t.Fatalf("startxref does not point at the cross-reference table")
+	}
+
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(output[xrefOffset:], -1)
+	for i, entry := range entries {
+		offset, _ := strconv.Atoi(string(entry[1]))
+		if !bytes.HasPrefix(output[offset:], []byte(strconv.Itoa(i+1)+" 0 obj")) {
+			t.Errorf("cross-reference entry %d does not point at its object", i+1)
+		}
+	}
+}
+
+func TestDocumentUnknownChars(t *testing.T) {
+	doc := pdfUtils.NewDocument("گزارش")
+	doc.KeyValues([][2]string{{"Score", "۷۵٫۵ ٪"}, {"Course", "Économie – Şanā"}})
+	output := doc.Bytes()
+	if doc.HasUnknownChars() {
+		t.Error("HasUnknownChars is true for the characters which have a substitute")
+	}
+
+	for _, expected := range []string{`(75.5 %)`, `(\311conomie \226 Sana)`} {
+		if !bytes.Contains(output, []byte(expected)) {
+			t.Errorf("output does not contain %s", expected)
+		}
+	}
+
+	doc.Paragraph("علی رضایی")
+	if !doc.HasUnknownChars() {
+		t.Error("HasUnknownChars is false after writing a Persian text")
+	}
+	if output := doc.Bytes(); !bytes.Contains(output, []byte(`(??? ?????)`)) {
+		t.Error("output does not contain the Persian text replaced with question marks")
+	}
+}
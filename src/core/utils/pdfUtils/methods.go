@@ -0,0 +1,341 @@
+package pdfUtils
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+)
+
+// AddPage starts a new page; the next blocks are written at its top.
+func (d *Document) AddPage() {
+	d.page = &bytes.Buffer{}
+	d.pages = append(d.pages, d.page)
+	d.cursorY = DefaultMargin
+}
+
+// SetFont sets the font of the next paragraphs.
+func (d *Document) SetFont(font Font, size float64) {
+	d.font = font
+	d.fontSize = size
+}
+
+// Heading writes a heading in the bold font.
+func (d *Document) Heading(text string, size float64) {
+	font, fontSize := d.font, d.fontSize
+	d.SetFont(FontBold, size)
+	d.Spacer(size / 2)
+	d.Paragraph(text)
+	d.Spacer(size / 4)
+	d.SetFont(font, fontSize)
+}
+
+// Paragraph writes the text in the current font, broken into the lines
+// which fit in the width of the pages.
+func (d *Document) Paragraph(text string) {
+	lineHeight := getLineHeight(d.fontSize)
+	for _, line := range WrapText(text, d.font, d.fontSize, ContentWidth()) {
+		d.ensureSpace(lineHeight)
+		d.drawText(DefaultMargin, d.cursorY, line, d.font, d.fontSize)
+		d.cursorY += lineHeight
+	}
+}
+
+// Note writes the text in a small gray font.
+func (d *Document) Note(text string) {
+	font, fontSize := d.font, d.fontSize
+	d.SetFont(FontRegular, SmallFontSize)
+	d.textGray = noteTextGray
+	d.Paragraph(text)
+	d.textGray = 0
+	d.SetFont(font, fontSize)
+}
+
+// Spacer leaves an empty space of the specified height.
+func (d *Document) Spacer(height float64) {
+	d.cursorY += height
+}
+
+// HorizontalRule draws a line across the width of the page.
+func (d *Document) HorizontalRule() {
+	d.ensureSpace(cellPadding * 2)
+	d.cursorY += cellPadding
+	d.drawLine(DefaultMargin, d.cursorY, PageWidth-DefaultMargin, d.cursorY, 0.7)
+	d.cursorY += cellPadding
+}
+
+// KeyValues writes the pairs of keys (in the bold font) and values as two
+// columns.
+func (d *Document) KeyValues(pairs [][2]string) {
+	lineHeight := getLineHeight(d.fontSize)
+	valueWidth := ContentWidth() - keyColumnWidth
+	for _, pair := range pairs {
+		keyLines := WrapText(pair[0], FontBold, d.fontSize, keyColumnWidth-cellPadding)
+		valueLines := WrapText(pair[1], d.font, d.fontSize, valueWidth)
+
+		d.ensureSpace(float64(max(len(keyLines), len(valueLines))) * lineHeight)
+		for i, line := range keyLines {
+			d.drawText(DefaultMargin, d.cursorY+float64(i)*lineHeight, line, FontBold, d.fontSize)
+		}
+		for i, line := range valueLines {
+			d.drawText(DefaultMargin+keyColumnWidth, d.cursorY+float64(i)*lineHeight, line, d.font, d.fontSize)
+		}
+		d.cursorY += float64(max(len(keyLines), len(valueLines))) * lineHeight
+	}
+}
+
+// Table writes a table with a header row; the texts of the cells are
+// broken into the lines which fit in their columns, and the header is
+// written again on top of every page the table continues on.
+func (d *Document) Table(columns []TableColumn, rows [][]string) {
+	header := make([]string, 0, len(columns))
+	for _, column := range columns {
+		header = append(header, column.Title)
+	}
+
+	d.ensureSpace(d.getRowHeight(columns, header, FontBold) + getLineHeight(d.fontSize))
+	d.drawTableRow(columns, header, FontBold, true)
+	for _, row := range rows {
+		if d.ensureSpace(d.getRowHeight(columns, row, d.font)) {
+			d.drawTableRow(columns, header, FontBold, true)
+		}
+		d.drawTableRow(columns, row, d.font, false)
+	}
+}
+
+// LineChart draws a line chart of the points (whose values are between 0
+// and 100) with the specified height, with their labels under it.
+func (d *Document) LineChart(points []ChartPoint, height float64) {
+	d.ensureSpace(height + chartLabelHeight + cellPadding)
+
+	top := d.cursorY
+	left := float64(DefaultMargin + chartAxisWidth)
+	width := ContentWidth() - chartAxisWidth
+	getY := func(value float64) float64 {
+		value = min(max(value, 0), chartMaxValue)
+		return top + height - height*value/chartMaxValue
+	}
+
+	for i := 0; i <= chartGridSteps; i++ {
+		value := float64(chartMaxValue * i / chartGridSteps)
+		y := getY(value)
+		lineWidth := 0.3
+		if i == 0 {
+			lineWidth = 0.8
+		}
+		d.drawLine(left, y, left+width, y, lineWidth)
+
+		label := strconv.Itoa(int(value)) + "%"
+		labelWidth := GetTextWidth(label, FontRegular, SmallFontSize)
+		d.drawText(left-labelWidth-cellPadding, y-SmallFontSize/2, label, FontRegular, SmallFontSize)
+	}
+
+	if len(points) == 0 {
+		d.cursorY = top + height + chartLabelHeight + cellPadding
+		return
+	}
+
+	step := width / float64(len(points))
+	getX := func(i int) float64 {
+		return left + step*(float64(i)+0.5)
+	}
+
+	d.page.WriteString("0.16 0.38 0.71 RG 1.5 w\n")
+	for i := 1; i < len(points); i++ {
+		if points[i-1].Value == nil || points[i].Value == nil {
+			continue
+		}
+		d.page.WriteString(fmt.Sprintf("%s %s m %s %s l S\n",
+			formatNumber(getX(i-1)), formatNumber(PageHeight-getY(*points[i-1].Value)),
+			formatNumber(getX(i)), formatNumber(PageHeight-getY(*points[i].Value)),
+		))
+	}
+	d.page.WriteString("0 0 0 RG 1 w\n")
+
+	for i, point := range points {
+		if point.Value != nil {
+			radius := chartPointRadius
+			color := "0.16 0.38 0.71 rg\n"
+			if point.IsHighlighted {
+				radius *= 1.6
+				color = "0.90 0.45 0.10 rg\n"
+			}
+			d.page.WriteString(color)
+			d.drawCircle(getX(i), getY(*point.Value), radius)
+			d.page.WriteString("0 g\n")
+		}
+
+		label := FitText(point.Label, FontRegular, SmallFontSize, step-cellPadding)
+		labelWidth := GetTextWidth(label, FontRegular, SmallFontSize)
+		labelFont := FontRegular
+		if point.IsHighlighted {
+			labelFont = FontBold
+		}
+		d.drawText(getX(i)-labelWidth/2, top+height+cellPadding, label, labelFont, SmallFontSize)
+	}
+
+	d.cursorY = top + height + chartLabelHeight + cellPadding
+}
+
+// HasUnknownChars returns true if some of the characters written in the
+// document so far could not be written in its fonts (not even as a similar
+// character), and have been replaced with a question mark.
+func (d *Document) HasUnknownChars() bool {
+	return d.hasUnknownChars
+}
+
+// Bytes returns the content of the PDF file of the document.
+func (d *Document) Bytes() []byte {
+	title, _ := encodeText(d.title)
+
+	var output bytes.Buffer
+	var offsets []int
+	startObject := func() int {
+		offsets = append(offsets, output.Len())
+		output.WriteString(strconv.Itoa(len(offsets)) + " 0 obj\n")
+		return len(offsets)
+	}
+	endObject := func() {
+		output.WriteString("\nendobj\n")
+	}
+
+	// the objects of the pages come after these five
+	const firstPageObject = 6
+	kids := ""
+	for i := range d.pages {
+		kids += strconv.Itoa(firstPageObject+2*i) + " 0 R "
+	}
+
+	output.WriteString("%PDF-1.4\n%\xE2\xE3\xCF\xD3\n")
+	startObject()
+	output.WriteString("<< /Type /Catalog /Pages 2 0 R >>")
+	endObject()
+	startObject()
+	output.WriteString(fmt.Sprintf("<< /Type /Pages /Kids [ %s] /Count %d >>", kids, len(d.pages)))
+	endObject()
+	startObject()
+	output.WriteString("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	endObject()
+	startObject()
+	output.WriteString("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	endObject()
+	startObject()
+	output.WriteString("<< /Title " + title + " /Producer (ExamSphere) >>")
+	endObject()
+
+	for _, page := range d.pages {
+		pageObject := startObject()
+		output.WriteString(fmt.Sprintf(
+			"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] "+
+				"/Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			formatNumber(PageWidth), formatNumber(PageHeight), pageObject+1,
+		))
+		endObject()
+
+		startObject()
+		output.WriteString(fmt.Sprintf("<< /Length %d >>\nstream\n", page.Len()))
+		output.Write(page.Bytes())
+		output.WriteString("\nendstream")
+		endObject()
+	}
+
+	xrefOffset := output.Len()
+	output.WriteString(fmt.Sprintf("xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1))
+	for _, offset := range offsets {
+		output.WriteString(fmt.Sprintf("%010d 00000 n \n", offset))
+	}
+	output.WriteString(fmt.Sprintf(
+		"trailer\n<< /Size %d /Root 1 0 R /Info 5 0 R >>\nstartxref\n%d\n%%%%EOF\n",
+		len(offsets)+1, xrefOffset,
+	))
+
+	return output.Bytes()
+}
+
+// ensureSpace starts a new page if a block of the specified height does
+// not fit in the rest of the current page; it returns true if it did.
+func (d *Document) ensureSpace(height float64) bool {
+	if d.cursorY+height <= PageHeight-DefaultMargin || d.cursorY <= DefaultMargin {
+		return false
+	}
+
+	d.AddPage()
+	return true
+}
+
+func (d *Document) getRowHeight(columns []TableColumn, cells []string, font Font) float64 {
+	maxLines := 1
+	for i, column := range columns {
+		if i < len(cells) {
+			lines := WrapText(cells[i], font, d.fontSize, column.Width-2*cellPadding)
+			maxLines = max(maxLines, len(lines))
+		}
+	}
+
+	return float64(maxLines)*getLineHeight(d.fontSize) + 2*cellPadding
+}
+
+func (d *Document) drawTableRow(columns []TableColumn, cells []string, font Font, isHeader bool) {
+	height := d.getRowHeight(columns, cells, font)
+	if isHeader {
+		d.page.WriteString(fmt.Sprintf("0.92 g %s %s %s %s re f 0 g\n",
+			formatNumber(DefaultMargin), formatNumber(PageHeight-d.cursorY-height),
+			formatNumber(ContentWidth()), formatNumber(height),
+		))
+	}
+
+	x := float64(DefaultMargin)
+	for i, column := range columns {
+		if i < len(cells) {
+			lines := WrapText(cells[i], font, d.fontSize, column.Width-2*cellPadding)
+			for j, line := range lines {
+				top := d.cursorY + cellPadding + float64(j)*getLineHeight(d.fontSize)
+				d.drawText(x+cellPadding, top, line, font, d.fontSize)
+			}
+		}
+		x += column.Width
+	}
+
+	d.cursorY += height
+	d.drawLine(DefaultMargin, d.cursorY, PageWidth-DefaultMargin, d.cursorY, 0.3)
+}
+
+// drawText writes a line of text; top is the distance of the top of the
+// line from the top of the page.
+func (d *Document) drawText(x, top float64, text string, font Font, size float64) {
+	if text == "" {
+		return
+	}
+
+	encoded, allKnown := encodeText(text)
+	d.hasUnknownChars = d.hasUnknownChars || !allKnown
+
+	d.page.WriteString(fmt.Sprintf("BT %s g /F%d %s Tf %s %s Td %s Tj ET 0 g\n",
+		formatNumber(d.textGray), int(font)+1, formatNumber(size),
+		formatNumber(x), formatNumber(PageHeight-top-size),
+		encoded,
+	))
+}
+
+func (d *Document) drawLine(x1, y1, x2, y2, width float64) {
+	d.page.WriteString(fmt.Sprintf("%s w %s %s m %s %s l S\n",
+		formatNumber(width),
+		formatNumber(x1), formatNumber(PageHeight-y1),
+		formatNumber(x2), formatNumber(PageHeight-y2),
+	))
+}
+
+// drawCircle fills a circle, made of four bezier curves.
+func (d *Document) drawCircle(x, y, radius float64) {
+	y = PageHeight - y
+	k := radius * 0.5523
+	f := formatNumber
+	d.page.WriteString(fmt.Sprintf(
+		"%s %s m %s %s %s %s %s %s c %s %s %s %s %s %s c "+
+			"%s %s %s %s %s %s c %s %s %s %s %s %s c f\n",
+		f(x+radius), f(y),
+		f(x+radius), f(y+k), f(x+k), f(y+radius), f(x), f(y+radius),
+		f(x-k), f(y+radius), f(x-radius), f(y+k), f(x-radius), f(y),
+		f(x-radius), f(y-k), f(x-k), f(y-radius), f(x), f(y-radius),
+		f(x+k), f(y-radius), f(x+radius), f(y-k), f(x+radius), f(y),
+	))
+}
@@ -0,0 +1,47 @@
+package pdfUtils
+
+import "bytes"
+
+// Font is one of the standard fonts the documents are written in; the
+// standard fonts need no embedding, but only support the Latin-1 (and
+// Windows-1252) characters.
+type Font int
+
+// Document is a PDF document made of flowing blocks (headings, paragraphs,
+// tables, charts); a new page is started whenever a block does not fit in
+// the current one.
+type Document struct {
+	title    string
+	pages    []*bytes.Buffer
+	page     *bytes.Buffer
+	cursorY  float64
+	font     Font
+	fontSize float64
+
+	// textGray is the gray level of the text (0 is black).
+	textGray float64
+
+	// hasUnknownChars is true if some of the characters of the texts could
+	// not be written in the fonts of the document (see encodeText).
+	hasUnknownChars bool
+}
+
+// TableColumn is a column of a table.
+type TableColumn struct {
+	Title string
+
+	// Width is the width of the column in points; the widths of the columns
+	// of a table should add up to the ContentWidth.
+	Width float64
+}
+
+// ChartPoint is a point of a line chart.
+type ChartPoint struct {
+	Label string
+
+	// Value is nil if the point has no value; the line is broken there.
+	Value *float64
+
+	// IsHighlighted points are drawn bigger and in another color.
+	IsHighlighted bool
+}
@@ -0,0 +1,27 @@
+package recurrenceUtils
+
+const (
+	FrequencyDaily   = "DAILY"
+	FrequencyWeekly  = "WEEKLY"
+	FrequencyMonthly = "MONTHLY"
+)
+
+const (
+	// RulePrefix is the optional prefix of the rules (as in iCalendar).
+	RulePrefix = "RRULE:"
+
+	// UntilLayout is the layout of the UNTIL part of the rules.
+	UntilLayout = "20060102T150405Z"
+
+	// UntilDateLayout is the date-only layout of the UNTIL part of the rules.
+	UntilDateLayout = "20060102"
+)
+
+const (
+	// MaxInterval is the maximum value of the INTERVAL part of the rules.
+	MaxInterval = 366
+
+	// maxIterations is the maximum number of periods checked while
+	// generating occurrences, to protect against rules that never match.
+	maxIterations = 10000
+)
@@ -0,0 +1,129 @@
+package recurrenceUtils
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseRecurrenceRule parses the specified value as a recurrence rule.
+// Supported parts are FREQ, INTERVAL, BYDAY, COUNT and UNTIL.
+func ParseRecurrenceRule(value string) (*RecurrenceRule, error) {
+	value = strings.TrimPrefix(strings.TrimSpace(value), RulePrefix)
+	if value == "" {
+		return nil, ErrEmptyRule
+	}
+
+	rule := &RecurrenceRule{
+		Interval: 1,
+	}
+	for _, part := range strings.Split(value, ";") {
+		if part == "" {
+			continue
+		}
+
+		key, partValue, found := strings.Cut(part, "=")
+		if !found || partValue == "" {
+			return nil, ErrInvalidRulePart
+		}
+
+		var err error
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			rule.Frequency = strings.ToUpper(partValue)
+		case "INTERVAL":
+			rule.Interval, err = strconv.Atoi(partValue)
+			if err != nil || rule.Interval < 1 || rule.Interval > MaxInterval {
+				return nil, ErrInvalidInterval
+			}
+		case "COUNT":
+			rule.Count, err = strconv.Atoi(partValue)
+			if err != nil || rule.Count < 1 {
+				return nil, ErrInvalidCount
+			}
+		case "UNTIL":
+			until, err := parseUntil(partValue)
+			if err != nil {
+				return nil, ErrInvalidUntil
+			}
+			rule.Until = &until
+		case "BYDAY":
+			rule.ByDay, err = parseByDay(partValue)
+			if err != nil {
+				return nil, err
+			}
+		default:
+			return nil, ErrUnsupportedPart
+		}
+	}
+
+	switch rule.Frequency {
+	case FrequencyDaily, FrequencyWeekly, FrequencyMonthly:
+	default:
+		return nil, ErrInvalidFrequency
+	}
+
+	if len(rule.ByDay) > 0 && rule.Frequency != FrequencyWeekly {
+		return nil, ErrByDayNotSupported
+	} else if rule.Count > 0 && rule.Until != nil {
+		return nil, ErrCountAndUntilGiven
+	}
+
+	return rule, nil
+}
+
+func parseUntil(value string) (time.Time, error) {
+	if len(value) == len(UntilDateLayout) {
+		t, err := time.Parse(UntilDateLayout, value)
+		if err != nil {
+			return t, err
+		}
+
+		// the whole day is included
+		return t.Add(24*time.Hour - time.Second), nil
+	}
+
+	return time.Parse(UntilLayout, value)
+}
+
+func parseByDay(value string) ([]time.Weekday, error) {
+	var days []time.Weekday
+	seen := make(map[time.Weekday]bool)
+	for _, name := range strings.Split(value, ",") {
+		day, ok := weekdayNames[strings.ToUpper(strings.TrimSpace(name))]
+		if !ok {
+			return nil, ErrInvalidByDay
+		} else if seen[day] {
+			continue
+		}
+
+		seen[day] = true
+		days = append(days, day)
+	}
+
+	return days, nil
+}
+
+// startOfWeek returns the start (Monday, same time of the day) of the week
+// the specified time belongs to.
+func startOfWeek(t time.Time) time.Time {
+	offset := (int(t.Weekday()) + 6) % 7
+	return t.AddDate(0, 0, -offset)
+}
+
+// sortedWeekdaysOffsets returns the offsets of the specified weekdays from
+// Monday, in order.
+func sortedWeekdaysOffsets(days []time.Weekday) []int {
+	var offsets []int
+	for offset := 0; offset < 7; offset++ {
+		weekday := time.Weekday((offset + 1) % 7)
+		for _, day := range days {
+			if day == weekday {
+				offsets = append(offsets, offset)
+				break
+			}
+		}
+	}
+
+	return offsets
+}
@@ -0,0 +1,80 @@
+package recurrenceUtils_test
+
+import (
+	"testing"
+	"time"
+
+	"ExamSphere/src/core/utils/recurrenceUtils"
+)
+
+func TestParseRecurrenceRule(t *testing.T) {
+	rule, err := recurrenceUtils.ParseRecurrenceRule("RRULE:FREQ=WEEKLY;BYDAY=WE,MO;COUNT=4")
+	if err != nil {
+		t.Fatal("Expected a valid rule, got", err)
+	}
+
+	if s := rule.String(); s != "FREQ=WEEKLY;BYDAY=MO,WE;COUNT=4" {
+		t.Error("Unexpected normalized rule:", s)
+	}
+
+	invalidRules := []string{
+		"",
+		"FREQ=YEARLY",
+		"FREQ=DAILY;INTERVAL=0",
+		"FREQ=DAILY;BYDAY=MO",
+		"FREQ=WEEKLY;BYDAY=XX",
+		"FREQ=WEEKLY;COUNT=2;UNTIL=20240101",
+	}
+	for _, value := range invalidRules {
+		if _, err := recurrenceUtils.ParseRecurrenceRule(value); err == nil {
+			t.Errorf("Expected rule %q to be invalid", value)
+		}
+	}
+}
+
+func TestWeeklyOccurrences(t *testing.T) {
+	rule, err := recurrenceUtils.ParseRecurrenceRule("FREQ=WEEKLY;BYDAY=MO,WE;COUNT=4")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a Wednesday
+	dtStart := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	occurrences := rule.Occurrences(dtStart, dtStart.Add(-time.Second), dtStart.AddDate(1, 0, 0), 0)
+	expected := []time.Time{
+		time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+		time.Date(2024, 5, 6, 10, 0, 0, 0, time.UTC),
+		time.Date(2024, 5, 8, 10, 0, 0, 0, time.UTC),
+		time.Date(2024, 5, 13, 10, 0, 0, 0, time.UTC),
+	}
+	if len(occurrences) != len(expected) {
+		t.Fatalf("Expected %d occurrences, got %v", len(expected), occurrences)
+	}
+
+	for i := range expected {
+		if !occurrences[i].Equal(expected[i]) {
+			t.Errorf("Expected %s, got %s", expected[i], occurrences[i])
+		}
+	}
+
+	// the occurrences before "after" still count towards COUNT
+	later := rule.Occurrences(dtStart, expected[2], dtStart.AddDate(1, 0, 0), 0)
+	if len(later) != 1 || !later[0].Equal(expected[3]) {
+		t.Error("Expected only the last occurrence, got", later)
+	}
+}
+
+func TestMonthlyOccurrences(t *testing.T) {
+	rule, err := recurrenceUtils.ParseRecurrenceRule("FREQ=MONTHLY;UNTIL=20240531")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dtStart := time.Date(2024, 1, 31, 9, 0, 0, 0, time.UTC)
+	occurrences := rule.Occurrences(dtStart, dtStart.Add(-time.Second), dtStart.AddDate(2, 0, 0), 0)
+
+	// February and April don't have a 31st
+	if len(occurrences) != 3 || occurrences[1].Month() != time.March || occurrences[2].Month() != time.May {
+		t.Error("Unexpected monthly occurrences:", occurrences)
+	}
+}
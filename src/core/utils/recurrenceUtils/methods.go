@@ -0,0 +1,112 @@
+package recurrenceUtils
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// String returns the rule in its normalized textual form.
+func (r *RecurrenceRule) String() string {
+	parts := []string{"FREQ=" + r.Frequency}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+
+	if len(r.ByDay) > 0 {
+		var days []string
+		for _, offset := range sortedWeekdaysOffsets(r.ByDay) {
+			day := time.Weekday((offset + 1) % 7)
+			for name, value := range weekdayNames {
+				if value == day {
+					days = append(days, name)
+					break
+				}
+			}
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+
+	if r.Until != nil {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format(UntilLayout))
+	}
+
+	return strings.Join(parts, ";")
+}
+
+// Occurrences returns the occurrences of the rule that starts at dtStart,
+// which happen after the specified time (exclusive) and not later than
+// until (inclusive). At most limit occurrences are returned (0 means no limit).
+func (r *RecurrenceRule) Occurrences(dtStart, after, until time.Time, limit int) []time.Time {
+	var result []time.Time
+	count := 0
+	r.forEach(dtStart, func(occurrence time.Time) bool {
+		count++
+		if r.Count > 0 && count > r.Count {
+			return false
+		} else if occurrence.After(until) ||
+			(r.Until != nil && occurrence.After(*r.Until)) {
+			return false
+		}
+
+		if occurrence.After(after) {
+			result = append(result, occurrence)
+		}
+
+		return limit <= 0 || len(result) < limit
+	})
+
+	return result
+}
+
+// forEach calls the handler for every occurrence of the rule (in order),
+// until the handler returns false.
+func (r *RecurrenceRule) forEach(dtStart time.Time, handler func(time.Time) bool) {
+	interval := max(r.Interval, 1)
+	switch r.Frequency {
+	case FrequencyDaily:
+		for i := 0; i < maxIterations; i++ {
+			if !handler(dtStart.AddDate(0, 0, i*interval)) {
+				return
+			}
+		}
+	case FrequencyWeekly:
+		if len(r.ByDay) == 0 {
+			for i := 0; i < maxIterations; i++ {
+				if !handler(dtStart.AddDate(0, 0, i*interval*7)) {
+					return
+				}
+			}
+			return
+		}
+
+		weekStart := startOfWeek(dtStart)
+		offsets := sortedWeekdaysOffsets(r.ByDay)
+		for i := 0; i < maxIterations; i++ {
+			currentWeek := weekStart.AddDate(0, 0, i*interval*7)
+			for _, offset := range offsets {
+				occurrence := currentWeek.AddDate(0, 0, offset)
+				if occurrence.Before(dtStart) {
+					continue
+				} else if !handler(occurrence) {
+					return
+				}
+			}
+		}
+	case FrequencyMonthly:
+		for i := 0; i < maxIterations; i++ {
+			occurrence := dtStart.AddDate(0, i*interval, 0)
+			if occurrence.Day() != dtStart.Day() {
+				// e.g. the 31st in a month with 30 days; just like
+				// iCalendar, such invalid dates are ignored
+				continue
+			} else if !handler(occurrence) {
+				return
+			}
+		}
+	}
+}
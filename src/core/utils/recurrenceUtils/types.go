@@ -0,0 +1,24 @@
+package recurrenceUtils
+
+import "time"
+
+// RecurrenceRule is a (simplified) iCalendar RRULE, e.g.
+// "FREQ=WEEKLY;INTERVAL=1;BYDAY=MO,WE;COUNT=10".
+type RecurrenceRule struct {
+	// Frequency is one of DAILY, WEEKLY or MONTHLY.
+	Frequency string
+
+	// Interval is the number of periods between two occurrences
+	// (e.g. 2 with a WEEKLY frequency means every other week).
+	Interval int
+
+	// ByDay is the list of the weekdays the occurrences happen on
+	// (WEEKLY rules only); the weekday of the start is used if empty.
+	ByDay []time.Weekday
+
+	// Count is the total number of occurrences; 0 means unlimited.
+	Count int
+
+	// Until is the time after which there are no more occurrences.
+	Until *time.Time
+}
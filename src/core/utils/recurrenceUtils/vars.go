@@ -0,0 +1,29 @@
+package recurrenceUtils
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrEmptyRule          = errors.New("recurrence rule is empty")
+	ErrInvalidRulePart    = errors.New("invalid part in recurrence rule")
+	ErrUnsupportedPart    = errors.New("unsupported part in recurrence rule")
+	ErrInvalidFrequency   = errors.New("invalid frequency in recurrence rule")
+	ErrInvalidInterval    = errors.New("invalid interval in recurrence rule")
+	ErrInvalidCount       = errors.New("invalid count in recurrence rule")
+	ErrInvalidUntil       = errors.New("invalid until in recurrence rule")
+	ErrInvalidByDay       = errors.New("invalid by-day in recurrence rule")
+	ErrByDayNotSupported  = errors.New("by-day is only supported for weekly rules")
+	ErrCountAndUntilGiven = errors.New("recurrence rule cannot have both count and until")
+)
+
+var weekdayNames = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
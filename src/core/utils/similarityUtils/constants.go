@@ -0,0 +1,36 @@
+package similarityUtils
+
+const (
+	// DefaultShingleSize is the default number of the words in a shingle.
+	DefaultShingleSize = 5
+
+	// MinShingleSize and MaxShingleSize are the limits of the number of the
+	// words in a shingle.
+	MinShingleSize = 2
+	MaxShingleSize = 10
+)
+
+const (
+	// DefaultThreshold is the default similarity (Jaccard index of the
+	// shingles) from which a pair of documents is reported.
+	DefaultThreshold = 0.4
+
+	// MinThreshold is the lowest threshold which can be used; anything
+	// lower would report mostly unrelated documents.
+	MinThreshold = 0.05
+)
+
+const (
+	// SignatureSize is the number of the hash functions used in the MinHash
+	// signatures of the documents.
+	SignatureSize = 128
+
+	// candidateMargin is how much lower than the threshold the estimated
+	// similarity of a pair can be for its exact similarity to be computed,
+	// so the errors of the estimates don't hide the similar pairs.
+	candidateMargin = 0.15
+
+	// maxMatchCandidates is the maximum number of the occurrences of a
+	// shingle in the other document checked while finding the passages.
+	maxMatchCandidates = 16
+)
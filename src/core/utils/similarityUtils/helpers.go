@@ -0,0 +1,240 @@
+package similarityUtils
+
+import (
+	"hash/fnv"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// CheckOptions fills the defaults of the options, and returns an error if
+// any of them is invalid.
+func CheckOptions(opts *CompareOptions) error {
+	if opts.ShingleSize == 0 {
+		opts.ShingleSize = DefaultShingleSize
+	} else if opts.ShingleSize < MinShingleSize || opts.ShingleSize > MaxShingleSize {
+		return ErrInvalidShingleSize
+	}
+
+	if opts.Threshold == 0 {
+		opts.Threshold = DefaultThreshold
+	} else if opts.Threshold < MinThreshold || opts.Threshold > 1 {
+		return ErrInvalidThreshold
+	}
+
+	return nil
+}
+
+// CompareDocuments compares each pair of the documents, and returns the
+// pairs whose similarity reaches the threshold (the most similar first).
+// The pairs are picked by the MinHash estimates of their similarity, and
+// the exact similarity is only computed for the candidates.
+func CompareDocuments(documents []*Document, opts *CompareOptions) ([]*SimilarPair, error) {
+	if opts == nil {
+		opts = &CompareOptions{}
+	}
+	if err := CheckOptions(opts); err != nil {
+		return nil, err
+	}
+
+	fingerprints := make([]*fingerprint, len(documents))
+	for i, document := range documents {
+		fingerprints[i] = newFingerprint(document.Text, opts.ShingleSize)
+	}
+
+	var pairs []*SimilarPair
+	for i := 0; i < len(documents); i++ {
+		for j := i + 1; j < len(documents); j++ {
+			if documents[i].Owner != "" && documents[i].Owner == documents[j].Owner {
+				continue
+			}
+
+			first, second := fingerprints[i], fingerprints[j]
+			if len(first.hashes) == 0 || len(second.hashes) == 0 ||
+				estimateSimilarity(first.signature, second.signature) < opts.Threshold-candidateMargin {
+				continue
+			}
+
+			similarity, containment := compareShingles(first, second)
+			if similarity < opts.Threshold {
+				continue
+			}
+
+			pairs = append(pairs, &SimilarPair{
+				First:       i,
+				Second:      j,
+				Similarity:  similarity,
+				Containment: containment,
+				Passages:    findPassages(first, second),
+			})
+		}
+	}
+
+	sort.SliceStable(pairs, func(i, j int) bool {
+		return pairs[i].Similarity > pairs[j].Similarity
+	})
+
+	return pairs, nil
+}
+
+// tokenize splits the text to its (lowercased) words; anything other than
+// the letters and the digits separates the words.
+func tokenize(text string) []token {
+	var tokens []token
+	start := -1
+	for i, r := range text {
+		isWordRune := unicode.IsLetter(r) || unicode.IsDigit(r)
+		if isWordRune && start == -1 {
+			start = i
+		} else if !isWordRune && start != -1 {
+			tokens = append(tokens, token{word: strings.ToLower(text[start:i]), start: start, end: i})
+			start = -1
+		}
+	}
+
+	if start != -1 {
+		tokens = append(tokens, token{word: strings.ToLower(text[start:]), start: start, end: len(text)})
+	}
+
+	return tokens
+}
+
+func newFingerprint(text string, shingleSize int) *fingerprint {
+	f := &fingerprint{
+		tokens:      tokenize(text),
+		shingleSize: shingleSize,
+		positions:   make(map[uint64][]int),
+	}
+
+	// the documents shorter than a shingle are a single shingle, so they
+	// can only be similar to the exact same text
+	f.shingleSize = min(f.shingleSize, len(f.tokens))
+	for i := 0; i+f.shingleSize <= len(f.tokens) && f.shingleSize > 0; i++ {
+		hash := hashShingle(f.tokens[i : i+f.shingleSize])
+		f.hashes = append(f.hashes, hash)
+		f.positions[hash] = append(f.positions[hash], i)
+	}
+
+	f.signature = make([]uint64, len(signatureSeeds))
+	for i, seed := range signatureSeeds {
+		minValue := ^uint64(0)
+		for hash := range f.positions {
+			minValue = min(minValue, mix64(hash^seed))
+		}
+		f.signature[i] = minValue
+	}
+
+	return f
+}
+
+func hashShingle(tokens []token) uint64 {
+	hasher := fnv.New64a()
+	for _, current := range tokens {
+		_, _ = hasher.Write([]byte(current.word))
+		_, _ = hasher.Write([]byte{0})
+	}
+
+	return hasher.Sum64()
+}
+
+// mix64 is the finalizer of splitmix64; xor-ing the hashes with different
+// seeds before mixing them gives the independent hash functions needed by
+// the signatures.
+func mix64(value uint64) uint64 {
+	value ^= value >> 30
+	value *= 0xbf58476d1ce4e5b9
+	value ^= value >> 27
+	value *= 0x94d049bb133111eb
+	value ^= value >> 31
+	return value
+}
+
+func generateSeeds(count int) []uint64 {
+	seeds := make([]uint64, count)
+	state := uint64(0x9e3779b97f4a7c15)
+	for i := range seeds {
+		state += 0x9e3779b97f4a7c15
+		seeds[i] = mix64(state)
+	}
+
+	return seeds
+}
+
+// estimateSimilarity estimates the Jaccard index of the shingles of two
+// documents from their signatures.
+func estimateSimilarity(first, second []uint64) float64 {
+	equal := 0
+	for i := range first {
+		if first[i] == second[i] {
+			equal++
+		}
+	}
+
+	return float64(equal) / float64(len(first))
+}
+
+// compareShingles returns the Jaccard index of the shingles of the
+// documents, and how much of the shingles of the smaller one are found in
+// the other one.
+func compareShingles(first, second *fingerprint) (similarity, containment float64) {
+	common := 0
+	for hash := range first.positions {
+		if _, found := second.positions[hash]; found {
+			common++
+		}
+	}
+
+	union := len(first.positions) + len(second.positions) - common
+	smaller := min(len(first.positions), len(second.positions))
+	return float64(common) / float64(union), float64(common) / float64(smaller)
+}
+
+// findPassages finds the longest runs of the shingles which appear in both
+// of the documents.
+func findPassages(first, second *fingerprint) []*Passage {
+	var passages []*Passage
+	for i := 0; i < len(first.hashes); {
+		bestStart, bestLength := -1, 0
+		candidates := second.positions[first.hashes[i]]
+		for _, j := range candidates[:min(len(candidates), maxMatchCandidates)] {
+			length := 0
+			for i+length < len(first.hashes) && j+length < len(second.hashes) &&
+				first.hashes[i+length] == second.hashes[j+length] {
+				length++
+			}
+
+			if length > bestLength {
+				bestStart, bestLength = j, length
+			}
+		}
+
+		if bestStart == -1 {
+			i++
+			continue
+		}
+
+		firstEnd := i + bestLength + first.shingleSize - 2
+		secondEnd := bestStart + bestLength + second.shingleSize - 2
+		passages = append(passages, &Passage{
+			FirstStart:  first.tokens[i].start,
+			FirstEnd:    first.tokens[firstEnd].end,
+			SecondStart: second.tokens[bestStart].start,
+			SecondEnd:   second.tokens[secondEnd].end,
+		})
+		i = firstEnd + 1
+	}
+
+	return passages
+}
+
+// GetPassageText returns the text of the passage in the document, or an
+// empty string if the offsets don't fit the text (e.g. it has changed).
+func GetPassageText(text string, start, end int) string {
+	if start < 0 || end > len(text) || start >= end ||
+		!utf8.RuneStart(text[start]) || (end < len(text) && !utf8.RuneStart(text[end])) {
+		return ""
+	}
+
+	return text[start:end]
+}
@@ -0,0 +1,97 @@
+package similarityUtils_test
+
+import (
+	"testing"
+
+	"ExamSphere/src/core/utils/similarityUtils"
+)
+
+func TestCompareDocuments(t *testing.T) {
+	source := "The mitochondria is the powerhouse of the cell, it produces energy " +
+		"through cellular respiration and stores it in molecules of ATP."
+	documents := []*similarityUtils.Document{
+		{Owner: "alice", Text: source},
+		{Owner: "bob", Text: "In my opinion, the MITOCHONDRIA is the powerhouse of the cell; it produces energy " +
+			"through cellular respiration and stores it in molecules of ATP!"},
+		{Owner: "carol", Text: "Photosynthesis happens in the chloroplasts, where light is turned into " +
+			"chemical energy which the plant can use later."},
+		{Owner: "alice", Text: source},
+		{Owner: "dave", Text: ""},
+	}
+
+	pairs, err := similarityUtils.CompareDocuments(documents, nil)
+	if err != nil {
+		t.Fatal("Expected the documents to be compared, got", err)
+	}
+
+	// alice/bob twice (both attempts of alice); never alice/alice or carol
+	if len(pairs) != 2 {
+		t.Fatalf("Expected 2 similar pairs, got %d", len(pairs))
+	}
+
+	for _, pair := range pairs {
+		if len(pair.Passages) != 1 {
+			t.Fatalf("Expected a single overlapping passage, got %d", len(pair.Passages))
+		}
+
+		bob, alice := pair.First, pair.Second
+		bobStart, bobEnd := pair.Passages[0].FirstStart, pair.Passages[0].FirstEnd
+		aliceStart, aliceEnd := pair.Passages[0].SecondStart, pair.Passages[0].SecondEnd
+		if documents[alice].Owner == "bob" {
+			bob, alice = alice, bob
+			bobStart, bobEnd, aliceStart, aliceEnd = aliceStart, aliceEnd, bobStart, bobEnd
+		}
+
+		if documents[bob].Owner != "bob" || documents[alice].Owner != "alice" {
+			t.Errorf("Unexpected pair %d/%d", pair.First, pair.Second)
+		} else if pair.Similarity < 0.6 || pair.Containment < 0.8 {
+			t.Errorf("Unexpected scores %f/%f", pair.Similarity, pair.Containment)
+		}
+
+		aliceText := similarityUtils.GetPassageText(documents[alice].Text, aliceStart, aliceEnd)
+		if aliceText != "The mitochondria is the powerhouse of the cell, it produces energy "+
+			"through cellular respiration and stores it in molecules of ATP" {
+			t.Errorf("Unexpected passage in the answer of alice: %q", aliceText)
+		}
+
+		bobText := similarityUtils.GetPassageText(documents[bob].Text, bobStart, bobEnd)
+		if bobText != "the MITOCHONDRIA is the powerhouse of the cell; it produces energy "+
+			"through cellular respiration and stores it in molecules of ATP" {
+			t.Errorf("Unexpected passage in the answer of bob: %q", bobText)
+		}
+	}
+
+	shortDocuments := []*similarityUtils.Document{
+		{Text: "Paris"},
+		{Text: "paris."},
+		{Text: "London"},
+	}
+	pairs, err = similarityUtils.CompareDocuments(shortDocuments, nil)
+	if err != nil {
+		t.Fatal("Expected the documents to be compared, got", err)
+	} else if len(pairs) != 1 || pairs[0].First != 0 || pairs[0].Second != 1 || pairs[0].Similarity != 1 {
+		t.Error("Expected only the identical short answers to be similar")
+	}
+}
+
+func TestCheckOptions(t *testing.T) {
+	opts := &similarityUtils.CompareOptions{}
+	if err := similarityUtils.CheckOptions(opts); err != nil {
+		t.Fatal("Expected the default options to be valid, got", err)
+	} else if opts.ShingleSize != similarityUtils.DefaultShingleSize ||
+		opts.Threshold != similarityUtils.DefaultThreshold {
+		t.Error("Expected the defaults to be filled")
+	}
+
+	invalidOptions := []*similarityUtils.CompareOptions{
+		{ShingleSize: 1},
+		{ShingleSize: 50},
+		{Threshold: 0.01},
+		{Threshold: 1.5},
+	}
+	for _, current := range invalidOptions {
+		if err := similarityUtils.CheckOptions(current); err == nil {
+			t.Errorf("Expected %+v to be invalid", current)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package similarityUtils
+
+// Document is a piece of text to be compared with the others.
+type Document struct {
+	// Owner is who wrote the document; the documents of the same (non-empty)
+	// owner are never compared with each other.
+	Owner string
+
+	Text string
+}
+
+// CompareOptions are the options of comparing the documents.
+type CompareOptions struct {
+	// ShingleSize is the number of the words in a shingle; 0 means
+	// DefaultShingleSize.
+	ShingleSize int
+
+	// Threshold is the similarity from which a pair is reported; 0 means
+	// DefaultThreshold.
+	Threshold float64
+}
+
+// SimilarPair is a pair of documents which are suspiciously similar.
+type SimilarPair struct {
+	// First and Second are the indexes of the documents (First < Second).
+	First  int
+	Second int
+
+	// Similarity is the Jaccard index of the shingles of the documents, and
+	// Containment is the part of the shingles of the shorter document found
+	// in the other one.
+	Similarity  float64
+	Containment float64
+
+	// Passages are the overlapping passages of the documents.
+	Passages []*Passage
+}
+
+// Passage is a passage which appears in both of the documents of a pair;
+// the offsets are byte offsets in the texts of the documents.
+type Passage struct {
+	FirstStart  int `json:"first_start"`
+	FirstEnd    int `json:"first_end"`
+	SecondStart int `json:"second_start"`
+	SecondEnd   int `json:"second_end"`
+}
+
+// token is a (normalised) word of a document.
+type token struct {
+	word  string
+	start int
+	end   int
+}
+
+// fingerprint holds what's needed for comparing a document.
+type fingerprint struct {
+	tokens []token
+
+	// shingleSize is the number of the words in the shingles of the
+	// document; it's lower than the requested size for the documents with
+	// fewer words.
+	shingleSize int
+
+	// hashes are the hashes of the shingles in order, and positions maps
+	// each hash to where it appears.
+	hashes    []uint64
+	positions map[uint64][]int
+
+	signature []uint64
+}
@@ -0,0 +1,11 @@
+package similarityUtils
+
+import "errors"
+
+var (
+	ErrInvalidShingleSize = errors.New("invalid shingle size")
+	ErrInvalidThreshold   = errors.New("invalid similarity threshold")
+)
+
+// signatureSeeds are the seeds of the hash functions of the signatures.
+var signatureSeeds = generateSeeds(SignatureSize)
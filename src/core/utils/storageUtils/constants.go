@@ -0,0 +1,39 @@
+package storageUtils
+
+const (
+	// LocalStorageName is the name of the backend storing the files on the
+	// local filesystem.
+	LocalStorageName = "local"
+
+	// S3StorageName is the name of the backend storing the files in an
+	// S3-compatible object storage (AWS S3, MinIO, etc).
+	S3StorageName = "s3"
+)
+
+const (
+	// MaxImageSize, MaxAudioSize and MaxDocumentSize are the maximum sizes
+	// (in bytes) of each kind of the files; the max_attachment_size in the
+	// config file can lower them further.
+	MaxImageSize    = 5 * 1024 * 1024
+	MaxAudioSize    = 20 * 1024 * 1024
+	MaxDocumentSize = 10 * 1024 * 1024
+
+	// sniffLength is the number of the bytes checked to detect the type of
+	// the files.
+	sniffLength = 512
+)
+
+const (
+	FileKindImage    = "image"
+	FileKindAudio    = "audio"
+	FileKindDocument = "document"
+)
+
+const (
+	s3Algorithm       = "AWS4-HMAC-SHA256"
+	s3Service         = "s3"
+	s3DateLayout      = "20060102"
+	s3TimeLayout      = "20060102T150405Z"
+	s3DefaultRegion   = "us-east-1"
+	s3ScopeTerminator = "aws4_request"
+)
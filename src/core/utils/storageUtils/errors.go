@@ -0,0 +1,13 @@
+package storageUtils
+
+import "errors"
+
+var (
+	ErrUnknownStorageBackend   = errors.New("unknown storage backend")
+	ErrStorageBackendNotLoaded = errors.New("storage backend not loaded")
+	ErrObjectNotFound          = errors.New("object not found")
+	ErrInvalidObjectKey        = errors.New("invalid object key")
+	ErrStorageNotConfigured    = errors.New("storage backend is not configured")
+	ErrUnsupportedFileType     = errors.New("unsupported file type")
+	ErrFileTooLarge            = errors.New("file is too large")
+)
@@ -0,0 +1,187 @@
+package storageUtils
+
+import (
+	"ExamSphere/src/core/appConfig"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// RegisterStorageBackend makes a storage backend available to be used
+// by setting its name in the config file.
+func RegisterStorageBackend(name string, factory StorageBackendFactory) {
+	backendFactories[name] = factory
+}
+
+// LoadStorageBackend loads the storage backend set in the config file.
+func LoadStorageBackend() error {
+	factory, ok := backendFactories[appConfig.GetStorageBackend()]
+	if !ok {
+		return ErrUnknownStorageBackend
+	}
+
+	backend, err := factory()
+	if err != nil {
+		return err
+	}
+
+	currentBackend = backend
+	return nil
+}
+
+// GetStorageBackend returns the currently loaded storage backend.
+func GetStorageBackend() (StorageBackend, error) {
+	if currentBackend == nil {
+		return nil, ErrStorageBackendNotLoaded
+	}
+
+	return currentBackend, nil
+}
+
+func IsStorageBackendLoaded() bool {
+	return currentBackend != nil
+}
+
+// NewLocalStorage creates the local storage backend in the directory set
+// in the config file.
+func NewLocalStorage() (StorageBackend, error) {
+	return NewLocalStorageAt(appConfig.GetStorageLocalPath())
+}
+
+// NewLocalStorageAt creates a local storage backend storing the files in
+// the specified directory.
+func NewLocalStorageAt(root string) (StorageBackend, error) {
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LocalStorage{root: root}, nil
+}
+
+// NewS3Storage creates the S3 storage backend with the settings set in
+// the config file.
+func NewS3Storage() (StorageBackend, error) {
+	return NewS3StorageWithConfig(&S3Config{
+		Endpoint:  appConfig.GetStorageS3Endpoint(),
+		Region:    appConfig.GetStorageS3Region(),
+		Bucket:    appConfig.GetStorageS3Bucket(),
+		AccessKey: appConfig.GetStorageS3AccessKey(),
+		SecretKey: appConfig.GetStorageS3SecretKey(),
+	})
+}
+
+// NewS3StorageWithConfig creates an S3 storage backend with the specified
+// settings.
+func NewS3StorageWithConfig(config *S3Config) (StorageBackend, error) {
+	if config.Endpoint == "" || config.Bucket == "" ||
+		config.AccessKey == "" || config.SecretKey == "" {
+		return nil, ErrStorageNotConfigured
+	}
+
+	endpoint, err := url.Parse(config.Endpoint)
+	if err != nil || endpoint.Host == "" {
+		return nil, ErrStorageNotConfigured
+	}
+
+	storageConfig := *config
+	storageConfig.Endpoint = strings.TrimRight(config.Endpoint, "/")
+	if storageConfig.Region == "" {
+		storageConfig.Region = s3DefaultRegion
+	}
+
+	return &S3Storage{
+		config: &storageConfig,
+		client: &http.Client{Timeout: time.Minute},
+	}, nil
+}
+
+// IsObjectKeyValid returns true if the key can be used for storing an
+// object; the keys are relative slash-separated paths without any dot
+// segments.
+func IsObjectKeyValid(key string) bool {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return false
+	}
+
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return false
+		}
+	}
+
+	return path.Clean(key) == key
+}
+
+// DetectFileType detects the type of the file from its first bytes (falling
+// back to its extension), and returns nil if the type is not allowed to be
+// uploaded. The type declared by the client is never trusted.
+func DetectFileType(fileName string, head []byte) *FileTypeInfo {
+	if len(head) > sniffLength {
+		head = head[:sniffLength]
+	}
+
+	contentType, _, _ := strings.Cut(http.DetectContentType(head), ";")
+	if info := allowedFileTypes[contentType]; info != nil {
+		return info
+	}
+
+	if contentType == "application/octet-stream" {
+		byExtension := extensionContentTypes[strings.ToLower(filepath.Ext(fileName))]
+		return allowedFileTypes[byExtension]
+	}
+
+	return nil
+}
+
+// CheckFile returns the type of the file, or an error if the file is not
+// allowed to be uploaded; maxSize is the global limit (0 means only the
+// limit of the type applies).
+func CheckFile(fileName string, head []byte, size, maxSize int64) (*FileTypeInfo, error) {
+	info := DetectFileType(fileName, head)
+	if info == nil {
+		return nil, ErrUnsupportedFileType
+	}
+
+	if size > info.MaxSize || (maxSize > 0 && size > maxSize) {
+		return nil, ErrFileTooLarge
+	}
+
+	return info, nil
+}
+
+func hashSHA256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// encodeS3Path encodes the path as required by the signature version 4:
+// every byte except the unreserved characters is percent-encoded, and the
+// slashes are kept.
+func encodeS3Path(value string) string {
+	builder := strings.Builder{}
+	for i := 0; i < len(value); i++ {
+		b := value[i]
+		if (b >= 'A' && b <= 'Z') || (b >= 'a' && b <= 'z') || (b >= '0' && b <= '9') ||
+			b == '-' || b == '_' || b == '.' || b == '~' || b == '/' {
+			builder.WriteByte(b)
+			continue
+		}
+
+		builder.WriteString("%" + strings.ToUpper(hex.EncodeToString([]byte{b})))
+	}
+
+	return builder.String()
+}
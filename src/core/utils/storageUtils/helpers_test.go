@@ -0,0 +1,144 @@
+package storageUtils_test
+
+import (
+	"ExamSphere/src/core/utils/storageUtils"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// newFakeS3Server starts a minimal stand-in for an S3-compatible storage,
+// which keeps the objects in memory and checks the signature headers.
+func newFakeS3Server(t *testing.T, accessKey string) *httptest.Server {
+	mut := &sync.Mutex{}
+	objects := make(map[string][]byte)
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		sum := sha256.Sum256(body)
+
+		auth := r.Header.Get("Authorization")
+		if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential="+accessKey+"/") ||
+			!strings.Contains(auth, "SignedHeaders=host;x-amz-content-sha256;x-amz-date") ||
+			r.Header.Get("X-Amz-Date") == "" ||
+			r.Header.Get("X-Amz-Content-Sha256") != hex.EncodeToString(sum[:]) {
+			t.Errorf("fake s3: request %s %s is not signed correctly", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		mut.Lock()
+		defer mut.Unlock()
+		switch r.Method {
+		case http.MethodPut:
+			objects[r.URL.Path] = body
+		case http.MethodGet:
+			data, ok := objects[r.URL.Path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write(data)
+		case http.MethodDelete:
+			delete(objects, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}))
+}
+
+func testBackend(t *testing.T, backend storageUtils.StorageBackend) {
+	key := "exams/12/abc.png"
+	data := []byte("some attachment content")
+
+	if err := backend.Put(key, data, "image/png"); err != nil {
+		t.Fatalf("%s: Put: %v", backend.GetName(), err)
+	}
+
+	reader, err := backend.Get(key)
+	if err != nil {
+		t.Fatalf("%s: Get: %v", backend.GetName(), err)
+	}
+	stored, _ := io.ReadAll(reader)
+	reader.Close()
+	if string(stored) != string(data) {
+		t.Errorf("%s: Get returned %q, expected %q", backend.GetName(), stored, data)
+	}
+
+	if err := backend.Delete(key); err != nil {
+		t.Errorf("%s: Delete: %v", backend.GetName(), err)
+	}
+	if _, err := backend.Get(key); err != storageUtils.ErrObjectNotFound {
+		t.Errorf("%s: Get after Delete: expected ErrObjectNotFound, got %v", backend.GetName(), err)
+	}
+	if err := backend.Delete(key); err != nil {
+		t.Errorf("%s: Delete of a missing object: %v", backend.GetName(), err)
+	}
+
+	if err := backend.Put("../outside.png", data, ""); err != storageUtils.ErrInvalidObjectKey {
+		t.Errorf("%s: Put outside of the storage: expected ErrInvalidObjectKey, got %v", backend.GetName(), err)
+	}
+}
+
+func TestLocalStorage(t *testing.T) {
+	backend, err := storageUtils.NewLocalStorageAt(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testBackend(t, backend)
+}
+
+func TestS3Storage(t *testing.T) {
+	server := newFakeS3Server(t, "test-access-key")
+	defer server.Close()
+
+	backend, err := storageUtils.NewS3StorageWithConfig(&storageUtils.S3Config{
+		Endpoint:  server.URL,
+		Bucket:    "exam-sphere",
+		AccessKey: "test-access-key",
+		SecretKey: "test-secret-key",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testBackend(t, backend)
+
+	_, err = storageUtils.NewS3StorageWithConfig(&storageUtils.S3Config{Endpoint: server.URL})
+	if err != storageUtils.ErrStorageNotConfigured {
+		t.Errorf("NewS3StorageWithConfig without credentials: expected ErrStorageNotConfigured, got %v", err)
+	}
+}
+
+func TestCheckFile(t *testing.T) {
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+	pdf := []byte("%PDF-1.7\n")
+
+	if info, err := storageUtils.CheckFile("figure.png", png, 1024, 0); err != nil || info.Kind != storageUtils.FileKindImage {
+		t.Errorf("CheckFile(png) = %+v, %v", info, err)
+	}
+
+	if info, err := storageUtils.CheckFile("sheet.bin", pdf, 1024, 0); err != nil || info.ContentType != "application/pdf" {
+		t.Errorf("CheckFile(pdf) = %+v, %v", info, err)
+	}
+
+	// the extension must not make an executable look like an image
+	if _, err := storageUtils.CheckFile("evil.png", []byte("#!/bin/sh\nrm -rf /"), 64, 0); err != storageUtils.ErrUnsupportedFileType {
+		t.Errorf("CheckFile(script): expected ErrUnsupportedFileType, got %v", err)
+	}
+
+	if _, err := storageUtils.CheckFile("big.png", png, storageUtils.MaxImageSize+1, 0); err != storageUtils.ErrFileTooLarge {
+		t.Errorf("CheckFile(large png): expected ErrFileTooLarge, got %v", err)
+	}
+
+	if _, err := storageUtils.CheckFile("doc.pdf", pdf, 2048, 1024); err != storageUtils.ErrFileTooLarge {
+		t.Errorf("CheckFile over the global limit: expected ErrFileTooLarge, got %v", err)
+	}
+}
@@ -0,0 +1,225 @@
+package storageUtils
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+//-------------------------------------------------------------
+
+func (s *LocalStorage) GetName() string {
+	return LocalStorageName
+}
+
+func (s *LocalStorage) getPath(key string) (string, error) {
+	if !IsObjectKeyValid(key) {
+		return "", ErrInvalidObjectKey
+	}
+
+	return filepath.Join(s.root, filepath.FromSlash(key)), nil
+}
+
+func (s *LocalStorage) Put(key string, data []byte, _ string) error {
+	filePath, err := s.getPath(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(filePath), 0o750)
+	if err != nil {
+		return err
+	}
+
+	// write to a temporary file first, so a half-written file is never
+	// served under the key
+	tempFile, err := os.CreateTemp(filepath.Dir(filePath), ".upload-*")
+	if err != nil {
+		return err
+	}
+
+	_, err = tempFile.Write(data)
+	if closeErr := tempFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tempFile.Name(), filePath)
+	}
+	if err != nil {
+		_ = os.Remove(tempFile.Name())
+		return err
+	}
+
+	return nil
+}
+
+func (s *LocalStorage) Get(key string) (io.ReadCloser, error) {
+	filePath, err := s.getPath(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(filePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrObjectNotFound
+	}
+
+	return file, err
+}
+
+func (s *LocalStorage) Delete(key string) error {
+	filePath, err := s.getPath(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(filePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+
+	return err
+}
+
+//-------------------------------------------------------------
+
+func (s *S3Storage) GetName() string {
+	return S3StorageName
+}
+
+// newRequest creates a request for the object stored under the key, signed
+// with the signature version 4.
+func (s *S3Storage) newRequest(method, key string, body []byte) (*http.Request, error) {
+	if !IsObjectKeyValid(key) {
+		return nil, ErrInvalidObjectKey
+	}
+
+	objectPath := encodeS3Path("/" + s.config.Bucket + "/" + key)
+	request, err := http.NewRequest(method, s.config.Endpoint+objectPath, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	s.signRequest(request, objectPath, body, time.Now().UTC())
+	return request, nil
+}
+
+// signRequest adds the headers of the signature version 4 to the request.
+func (s *S3Storage) signRequest(request *http.Request, objectPath string, body []byte, now time.Time) {
+	payloadHash := hashSHA256Hex(body)
+	amzDate := now.Format(s3TimeLayout)
+	request.Header.Set("X-Amz-Date", amzDate)
+	request.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalRequest := strings.Join([]string{
+		request.Method,
+		objectPath,
+		"",
+		"host:" + request.URL.Host,
+		"x-amz-content-sha256:" + payloadHash,
+		"x-amz-date:" + amzDate,
+		"",
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := strings.Join([]string{
+		now.Format(s3DateLayout), s.config.Region, s3Service, s3ScopeTerminator,
+	}, "/")
+	stringToSign := strings.Join([]string{
+		s3Algorithm,
+		amzDate,
+		scope,
+		hashSHA256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+s.config.SecretKey), now.Format(s3DateLayout))
+	signingKey = hmacSHA256(signingKey, s.config.Region)
+	signingKey = hmacSHA256(signingKey, s3Service)
+	signingKey = hmacSHA256(signingKey, s3ScopeTerminator)
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	request.Header.Set("Authorization", s3Algorithm+
+		" Credential="+s.config.AccessKey+"/"+scope+
+		", SignedHeaders="+signedHeaders+
+		", Signature="+signature,
+	)
+}
+
+// do sends the request and returns the response if its status is a
+// success; the body of the failed responses is returned in the error.
+func (s *S3Storage) do(request *http.Request) (*http.Response, error) {
+	response, err := s.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode >= 200 && response.StatusCode < 300 {
+		return response, nil
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode == http.StatusNotFound {
+		return nil, ErrObjectNotFound
+	}
+
+	message, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+	return nil, errors.New("s3 storage: " + response.Status + ": " + string(message))
+}
+
+func (s *S3Storage) Put(key string, data []byte, contentType string) error {
+	request, err := s.newRequest(http.MethodPut, key, data)
+	if err != nil {
+		return err
+	}
+
+	if contentType != "" {
+		request.Header.Set("Content-Type", contentType)
+	}
+	request.ContentLength = int64(len(data))
+
+	response, err := s.do(request)
+	if err != nil {
+		return err
+	}
+
+	return response.Body.Close()
+}
+
+func (s *S3Storage) Get(key string) (io.ReadCloser, error) {
+	request, err := s.newRequest(http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := s.do(request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Body, nil
+}
+
+func (s *S3Storage) Delete(key string) error {
+	request, err := s.newRequest(http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+
+	response, err := s.do(request)
+	if err == ErrObjectNotFound {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	return response.Body.Close()
+}
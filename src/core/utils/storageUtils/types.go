@@ -0,0 +1,61 @@
+package storageUtils
+
+import (
+	"io"
+	"net/http"
+)
+
+// StorageBackend is the interface every storage backend has to implement
+// in order to be used for storing the attachments.
+type StorageBackend interface {
+	// GetName returns the name of the backend, which is stored alongside
+	// the files stored in it.
+	GetName() string
+
+	// Put stores the data under the specified key, replacing the existing
+	// object (if any).
+	Put(key string, data []byte, contentType string) error
+
+	// Get opens the object stored under the specified key; the caller
+	// has to close it.
+	Get(key string) (io.ReadCloser, error)
+
+	// Delete removes the object stored under the specified key; deleting
+	// an object which doesn't exist is not an error.
+	Delete(key string) error
+}
+
+// StorageBackendFactory creates a new instance of a storage backend.
+type StorageBackendFactory func() (StorageBackend, error)
+
+// LocalStorage stores the files in a directory of the local filesystem.
+type LocalStorage struct {
+	root string
+}
+
+// S3Config is the configuration needed to connect to an S3-compatible
+// object storage.
+type S3Config struct {
+	// Endpoint is the base url of the storage, such as
+	// "https://s3.eu-central-1.amazonaws.com" or "http://localhost:9000".
+	Endpoint  string
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+}
+
+// S3Storage stores the files in a bucket of an S3-compatible object
+// storage, addressing the objects in the path style (endpoint/bucket/key),
+// which is supported by all of the compatible implementations.
+type S3Storage struct {
+	config *S3Config
+	client *http.Client
+}
+
+// FileTypeInfo describes a type of the files which can be uploaded.
+type FileTypeInfo struct {
+	ContentType string
+	Kind        string
+	MaxSize     int64
+}
@@ -0,0 +1,29 @@
+package storageUtils
+
+var (
+	currentBackend StorageBackend
+
+	backendFactories = map[string]StorageBackendFactory{
+		LocalStorageName: NewLocalStorage,
+		S3StorageName:    NewS3Storage,
+	}
+
+	// allowedFileTypes are the types of the files which can be uploaded,
+	// by their detected content type.
+	allowedFileTypes = map[string]*FileTypeInfo{
+		"image/png":       {ContentType: "image/png", Kind: FileKindImage, MaxSize: MaxImageSize},
+		"image/jpeg":      {ContentType: "image/jpeg", Kind: FileKindImage, MaxSize: MaxImageSize},
+		"image/gif":       {ContentType: "image/gif", Kind: FileKindImage, MaxSize: MaxImageSize},
+		"image/webp":      {ContentType: "image/webp", Kind: FileKindImage, MaxSize: MaxImageSize},
+		"audio/mpeg":      {ContentType: "audio/mpeg", Kind: FileKindAudio, MaxSize: MaxAudioSize},
+		"audio/wave":      {ContentType: "audio/wave", Kind: FileKindAudio, MaxSize: MaxAudioSize},
+		"application/ogg": {ContentType: "audio/ogg", Kind: FileKindAudio, MaxSize: MaxAudioSize},
+		"application/pdf": {ContentType: "application/pdf", Kind: FileKindDocument, MaxSize: MaxDocumentSize},
+	}
+
+	// extensionContentTypes are used for the files whose type cannot be
+	// detected from their content (e.g. mp3 files without an ID3 tag).
+	extensionContentTypes = map[string]string{
+		".mp3": "audio/mpeg",
+	}
+)
@@ -15,5 +15,255 @@ const (
 )
 
 const (
-	MaxExamTitleLength = 63
+	MaxExamTitleLength         = 63
+	MaxAccessCodeLength        = 63
+	MaxAccommodationNoteLength = 255
+	MaxTransactionDescLength   = 255
+)
+
+const (
+	AccessCodeTypeNone     = "none"
+	AccessCodeTypeStatic   = "static"
+	AccessCodeTypeRotating = "rotating"
+)
+
+const (
+	DefaultAccessCodeInterval = 5
+)
+
+const (
+	PrerequisiteTypeExam       = "exam"
+	PrerequisiteTypeTopicLevel = "topic_level"
+)
+
+const (
+	DefaultPrerequisiteMinScore = 50
+)
+
+const (
+	GradingPolicyBest    = "best"
+	GradingPolicyLatest  = "latest"
+	GradingPolicyAverage = "average"
+)
+
+const (
+	DefaultMaxAttempts   = 1
+	DefaultGradingPolicy = GradingPolicyLatest
+)
+
+const (
+	MaxAccommodationTimeMultiplier = 10
+)
+
+const (
+	DeadlinePolicyAutoSubmit  = "auto_submit"
+	DeadlinePolicyGracePeriod = "grace_period"
+)
+
+const (
+	DefaultDeadlinePolicy = DeadlinePolicyAutoSubmit
+)
+
+const (
+	DefaultSeriesGenerateAhead = 7
+	MaxSeriesGenerateAhead     = 365
+
+	// MaxSeriesPreviewCount is the maximum number of the upcoming
+	// occurrences of a series that are calculated for previewing it.
+	MaxSeriesPreviewCount = 10
+)
+
+const (
+	TransactionTypeTopUp       = "top_up"
+	TransactionTypeExamPayment = "exam_payment"
+	TransactionTypeRefund      = "refund"
+)
+
+const (
+	// walletBalanceConstraint is the constraint failing when a wallet
+	// doesn't have enough balance for a transaction.
+	walletBalanceConstraint = "chk_wallet_balance"
+
+	// couponNotUsableErrCode is the error code raised by add_user_in_exam
+	// when the coupon cannot be used (anymore).
+	couponNotUsableErrCode = "EXC01"
+
+	// examFullErrCode is the error code raised by add_user_in_exam when
+	// the exam has reached its capacity.
+	examFullErrCode = "EXC02"
+
+	// similarityCheckRunningErrCode is the error code raised by
+	// start_similarity_check when another check of the question is running.
+	similarityCheckRunningErrCode = "EXC03"
+
+	// currencyMismatchErrCode is the error code raised by
+	// record_wallet_transaction when the currency of a transaction is not
+	// the currency of the wallet of the user.
+	currencyMismatchErrCode = "EXC04"
+)
+
+const (
+	DiscountTypePercentage = "percentage"
+	DiscountTypeFixed      = "fixed"
+)
+
+const (
+	CouponScopeExam   = "exam"
+	CouponScopeCourse = "course"
+	CouponScopeTopic  = "topic"
+)
+
+const (
+	MinCouponCodeLength = 3
+	MaxCouponCodeLength = 32
+)
+
+const (
+	InvitationStatusPending  = "pending"
+	InvitationStatusAccepted = "accepted"
+	InvitationStatusExpired  = "expired"
+)
+
+const (
+	// MaxInviteesCount is the maximum number of users (and emails) that can
+	// be invited to an exam at once.
+	MaxInviteesCount = 100
+
+	// joinTokenKeyPurpose is the purpose the key of the join tokens of the
+	// invitations is derived for.
+	joinTokenKeyPurpose = "exam-invite"
+)
+
+const (
+	// SectionStatusUpcoming means the user has not reached the section yet.
+	SectionStatusUpcoming = "upcoming"
+
+	// SectionStatusOngoing means the section is the current section of
+	// the user.
+	SectionStatusOngoing = "ongoing"
+
+	// SectionStatusOpen means the user has moved past the section, but can
+	// still go back to it (until its time runs out).
+	SectionStatusOpen = "open"
+
+	// SectionStatusLocked means the user has moved past a forward-only
+	// section, so it cannot be answered anymore.
+	SectionStatusLocked = "locked"
+
+	// SectionStatusExpired means the time of the section has run out.
+	SectionStatusExpired = "expired"
+)
+
+const (
+	MaxSectionTitleLength = 255
+)
+
+const (
+	AttachmentTypeQuestion = "question"
+	AttachmentTypeOption   = "option"
+	AttachmentTypeAnswer   = "answer"
+)
+
+const (
+	MaxAttachmentFileNameLength = 255
+
+	// MaxAnswerAttachmentsCount is the maximum number of files a user can
+	// upload as their answer to a question in a single attempt.
+	MaxAnswerAttachmentsCount = 5
+)
+
+const (
+	SimilarityCheckStatusRunning   = "running"
+	SimilarityCheckStatusCompleted = "completed"
+	SimilarityCheckStatusFailed    = "failed"
+)
+
+const (
+	QuestionDifficultyEasy   = "easy"
+	QuestionDifficultyMedium = "medium"
+	QuestionDifficultyHard   = "hard"
+)
+
+const (
+	MaxQuestionTagsCount          = 16
+	MaxQuestionTagLength          = 32
+	MaxLearningObjectivesCount    = 8
+	MaxLearningObjectiveLength    = 512
+	DefaultSearchedQuestionsLimit = 20
+	MaxSearchedQuestionsLimit     = 100
+)
+
+const (
+	// ExamReviewStatusDraft means the exam is still being written; it's
+	// only visible to its authors.
+	ExamReviewStatusDraft = "draft"
+
+	// ExamReviewStatusInReview means the exam waits for a reviewer to
+	// approve it (or to send it back to draft).
+	ExamReviewStatusInReview = "in_review"
+
+	// ExamReviewStatusPublished means the exam has been approved, so it's
+	// visible to (and joinable by) the students.
+	ExamReviewStatusPublished = "published"
+)
+
+const (
+	MaxReviewCommentLength  = 4096
+	DefaultReviewQueueLimit = 20
+	MaxReviewQueueLimit     = 100
+)
+
+const (
+	// CollaboratorRoleEditor can edit the exam and its questions, and
+	// manage its participants (and grade and view it too).
+	CollaboratorRoleEditor = "editor"
+
+	// CollaboratorRoleGrader can grade the answers of the participants of
+	// the exam (and view it too).
+	CollaboratorRoleGrader = "grader"
+
+	// CollaboratorRoleViewer can see the exam and its questions.
+	CollaboratorRoleViewer = "viewer"
+)
+
+const (
+	MaxExamCollaboratorsCount = 32
+)
+
+const (
+	// MaxOngoingPreviewAttempts is the maximum number of the unfinished
+	// preview attempts a user can have in an exam at the same time.
+	MaxOngoingPreviewAttempts = 5
+)
+
+const (
+	// AttemptReviewPolicyNever means the students cannot review their
+	// attempts in the exam.
+	AttemptReviewPolicyNever = "never"
+
+	// AttemptReviewPolicyAfterClose means the students can review their
+	// attempts once the exam has closed for them.
+	AttemptReviewPolicyAfterClose = "after_close"
+
+	// AttemptReviewPolicyAfterRelease means the students can review their
+	// attempts once the exam has closed for them and its results have
+	// been released.
+	AttemptReviewPolicyAfterRelease = "after_release"
+)
+
+const (
+	DefaultQuestionPoints  = 1
+	MaxGraderCommentLength = 4096
+)
+
+const (
+	MaxGradeCategoryNameLength    = 64
+	MaxCourseGradeCategoriesCount = 16
+	MaxGradeCategoryWeight        = 100
+)
+
+const (
+	// ExportBatchSize is the number of the rows which are fetched from the
+	// database at once while an export is being streamed.
+	ExportBatchSize = 500
 )
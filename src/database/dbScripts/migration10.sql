@@ -0,0 +1,511 @@
+-- Availability window of the exams:
+--  opens_at: when the users can start taking the exam (exam_date if not set).
+--  closes_at: when the window of the exam closes; if set, users can start the
+--      exam at any time between opens_at and closes_at, and each of them has
+--      (duration) minutes from the time they started their attempt, while
+--      their attempt can never go past closes_at. If not set, the exam is a
+--      fixed session running from exam_date for (duration) minutes.
+--  late_start_limit: minutes after the exam opens (for the user) in which
+--      they are still allowed to start an attempt (null means no limit).
+--  deadline_policy: what happens when the deadline of the user is reached;
+--      'auto_submit' finishes their attempt right away, 'grace_period' still
+--      accepts their answers for grace_period more minutes.
+ALTER TABLE "exam_info" ADD COLUMN IF NOT EXISTS opens_at TIMESTAMP WITH TIME ZONE DEFAULT NULL;
+ALTER TABLE "exam_info" ADD COLUMN IF NOT EXISTS closes_at TIMESTAMP WITH TIME ZONE DEFAULT NULL;
+ALTER TABLE "exam_info" ADD COLUMN IF NOT EXISTS late_start_limit INTEGER DEFAULT NULL;
+ALTER TABLE "exam_info" ADD COLUMN IF NOT EXISTS deadline_policy VARCHAR(16) DEFAULT 'auto_submit';
+ALTER TABLE "exam_info" ADD COLUMN IF NOT EXISTS grace_period INTEGER DEFAULT 0;
+ALTER TABLE "exam_info" ADD CONSTRAINT chk_exam_window CHECK (
+    closes_at IS NULL OR closes_at > COALESCE(opens_at, exam_date)
+);
+ALTER TABLE "exam_info" ADD CONSTRAINT chk_late_start_limit CHECK (
+    late_start_limit IS NULL OR late_start_limit >= 0
+);
+ALTER TABLE "exam_info" ADD CONSTRAINT chk_deadline_policy CHECK (
+    deadline_policy IN ('auto_submit', 'grace_period')
+);
+ALTER TABLE "exam_info" ADD CONSTRAINT chk_grace_period CHECK (grace_period >= 0);
+
+COMMENT ON COLUMN exam_info.opens_at IS 'When the window of the exam opens (exam_date if null)';
+COMMENT ON COLUMN exam_info.closes_at IS 'When the window of the exam closes (null means a fixed session)';
+COMMENT ON COLUMN exam_info.late_start_limit IS 'Minutes after the opening in which users can still start the exam (null means no limit)';
+COMMENT ON COLUMN exam_info.deadline_policy IS 'What happens at the deadline of the users (auto_submit or grace_period)';
+COMMENT ON COLUMN exam_info.grace_period IS 'Minutes after the deadline in which answers are still accepted (grace_period policy only)';
+
+-- Drop the old versions of the functions/procedures whose signature is changed
+DO
+$$
+DECLARE
+    r RECORD;
+BEGIN
+    FOR r IN SELECT proname, prokind, pg_get_function_identity_arguments(p.oid) AS args
+             FROM pg_proc p
+             JOIN pg_namespace n ON p.pronamespace = n.oid
+             WHERE n.nspname NOT IN ('pg_catalog', 'information_schema')
+             AND pg_function_is_visible(p.oid)
+             AND proname IN ('finish_exam_attempt')
+    LOOP
+        IF r.prokind = 'p' THEN
+            EXECUTE format('DROP PROCEDURE IF EXISTS %I(%s);', r.proname, r.args);
+        ELSE
+            EXECUTE format('DROP FUNCTION IF EXISTS %I(%s);', r.proname, r.args);
+        END IF;
+    END LOOP;
+END
+$$;
+
+-- The exam-wide versions of the functions have to respect the window as well
+CREATE OR REPLACE FUNCTION has_exam_started(p_exam_id INTEGER)
+RETURNS BOOLEAN AS $$
+DECLARE
+    exam_start_time TIMESTAMP WITH TIME ZONE;
+BEGIN
+    SELECT COALESCE(opens_at, exam_date) INTO exam_start_time
+    FROM exam_info
+    WHERE exam_id = p_exam_id;
+
+    IF exam_start_time IS NULL THEN
+        RAISE EXCEPTION 'Exam with ID % not found', p_exam_id;
+    END IF;
+
+    RETURN CURRENT_TIMESTAMP >= exam_start_time;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION has_exam_finished(p_exam_id INTEGER)
+RETURNS BOOLEAN AS $$
+DECLARE
+    exam_end_time TIMESTAMP WITH TIME ZONE;
+BEGIN
+    SELECT COALESCE(closes_at, COALESCE(opens_at, exam_date) + (duration || ' minutes')::INTERVAL)
+    INTO exam_end_time
+    FROM exam_info
+    WHERE exam_id = p_exam_id;
+
+    IF exam_end_time IS NULL THEN
+        RAISE EXCEPTION 'Exam with ID % not found', p_exam_id;
+    END IF;
+
+    RETURN CURRENT_TIMESTAMP > exam_end_time + get_exam_grace_period(p_exam_id);
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION get_exam_starts_in(p_exam_id INTEGER)
+RETURNS INTEGER AS $$
+DECLARE
+    exam_start_time TIMESTAMP WITH TIME ZONE;
+BEGIN
+    SELECT COALESCE(opens_at, exam_date) INTO exam_start_time
+    FROM exam_info
+    WHERE exam_id = p_exam_id;
+
+    IF exam_start_time IS NULL THEN
+        RAISE EXCEPTION 'Exam with ID % not found', p_exam_id;
+    END IF;
+
+    RETURN EXTRACT(EPOCH FROM (exam_start_time - CURRENT_TIMESTAMP)) / 60;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION get_exam_finishes_in(p_exam_id INTEGER)
+RETURNS INTEGER AS $$
+DECLARE
+    exam_end_time TIMESTAMP WITH TIME ZONE;
+BEGIN
+    SELECT COALESCE(closes_at, COALESCE(opens_at, exam_date) + (duration || ' minutes')::INTERVAL)
+    INTO exam_end_time
+    FROM exam_info
+    WHERE exam_id = p_exam_id;
+
+    IF exam_end_time IS NULL THEN
+        RAISE EXCEPTION 'Exam with ID % not found', p_exam_id;
+    END IF;
+
+    RETURN GREATEST(0, EXTRACT(EPOCH FROM (exam_end_time - CURRENT_TIMESTAMP)) / 60)::INTEGER;
+END;
+$$ LANGUAGE plpgsql;
+
+-- get_exam_start_time returns the time the exam opens for the user,
+-- considering the window of the exam and their accommodation (if any).
+-- Example usage:
+--      SELECT get_exam_start_time(1, '1234');
+CREATE OR REPLACE FUNCTION get_exam_start_time(p_exam_id INTEGER, p_user_id UserIdType)
+RETURNS TIMESTAMP WITH TIME ZONE AS $$
+DECLARE
+    exam_start_time TIMESTAMP WITH TIME ZONE;
+BEGIN
+    SELECT COALESCE(a.custom_start, e.opens_at, e.exam_date) INTO exam_start_time
+    FROM exam_info e
+    LEFT JOIN exam_accommodation a ON a.exam_id = e.exam_id AND a.user_id = p_user_id
+    WHERE e.exam_id = p_exam_id;
+
+    IF exam_start_time IS NULL THEN
+        RAISE EXCEPTION 'Exam with ID % not found', p_exam_id;
+    END IF;
+
+    RETURN exam_start_time;
+END;
+$$ LANGUAGE plpgsql;
+
+-- get_exam_close_time returns the time the exam closes for the user, which is
+-- the end of the window (or of the fixed session) of the exam, considering
+-- their accommodation (if any).
+-- Example usage:
+--      SELECT get_exam_close_time(1, '1234');
+CREATE OR REPLACE FUNCTION get_exam_close_time(p_exam_id INTEGER, p_user_id UserIdType)
+RETURNS TIMESTAMP WITH TIME ZONE AS $$
+DECLARE
+    exam_close_time TIMESTAMP WITH TIME ZONE;
+BEGIN
+    SELECT COALESCE(
+        a.custom_end,
+        e.closes_at,
+        COALESCE(a.custom_start, e.opens_at, e.exam_date) +
+            (e.duration * COALESCE(a.time_multiplier, 1) + COALESCE(a.extra_minutes, 0)) * INTERVAL '1 minute'
+    ) INTO exam_close_time
+    FROM exam_info e
+    LEFT JOIN exam_accommodation a ON a.exam_id = e.exam_id AND a.user_id = p_user_id
+    WHERE e.exam_id = p_exam_id;
+
+    IF exam_close_time IS NULL THEN
+        RAISE EXCEPTION 'Exam with ID % not found', p_exam_id;
+    END IF;
+
+    RETURN exam_close_time;
+END;
+$$ LANGUAGE plpgsql;
+
+-- get_exam_attempt_deadline returns the deadline of an attempt of the user
+-- which was started at p_started_at. For exams with a window, the user has
+-- (duration) minutes from the start of their attempt, but never more than
+-- the close time of the exam.
+-- Example usage:
+--      SELECT get_exam_attempt_deadline(1, '1234', CURRENT_TIMESTAMP);
+CREATE OR REPLACE FUNCTION get_exam_attempt_deadline(
+    p_exam_id INTEGER,
+    p_user_id UserIdType,
+    p_started_at TIMESTAMP WITH TIME ZONE
+)
+RETURNS TIMESTAMP WITH TIME ZONE AS $$
+DECLARE
+    v_closes_at TIMESTAMP WITH TIME ZONE;
+    v_time_limit INTERVAL;
+BEGIN
+    SELECT e.closes_at,
+        (e.duration * COALESCE(a.time_multiplier, 1) + COALESCE(a.extra_minutes, 0)) * INTERVAL '1 minute'
+    INTO v_closes_at, v_time_limit
+    FROM exam_info e
+    LEFT JOIN exam_accommodation a ON a.exam_id = e.exam_id AND a.user_id = p_user_id
+    WHERE e.exam_id = p_exam_id;
+
+    IF v_closes_at IS NULL OR p_started_at IS NULL THEN
+        RETURN get_exam_close_time(p_exam_id, p_user_id);
+    END IF;
+
+    RETURN LEAST(p_started_at + v_time_limit, get_exam_close_time(p_exam_id, p_user_id));
+END;
+$$ LANGUAGE plpgsql;
+
+-- get_exam_end_time returns the personal deadline of the user in the exam;
+-- the deadline of their ongoing attempt if they have one, otherwise the
+-- close time of the exam.
+-- Example usage:
+--      SELECT get_exam_end_time(1, '1234');
+CREATE OR REPLACE FUNCTION get_exam_end_time(p_exam_id INTEGER, p_user_id UserIdType)
+RETURNS TIMESTAMP WITH TIME ZONE AS $$
+DECLARE
+    v_started_at TIMESTAMP WITH TIME ZONE;
+BEGIN
+    SELECT a.started_at INTO v_started_at
+    FROM exam_attempt a
+    WHERE a.exam_id = p_exam_id AND a.user_id = p_user_id
+        AND a.finished_at IS NULL
+    ORDER BY a.attempt_number DESC
+    LIMIT 1;
+
+    RETURN get_exam_attempt_deadline(p_exam_id, p_user_id, v_started_at);
+END;
+$$ LANGUAGE plpgsql;
+
+-- get_exam_grace_period returns the grace period of the exam (zero if the
+-- deadline policy of the exam is not 'grace_period').
+-- Example usage:
+--      SELECT get_exam_grace_period(1);
+CREATE OR REPLACE FUNCTION get_exam_grace_period(p_exam_id INTEGER)
+RETURNS INTERVAL AS $$
+DECLARE
+    v_grace_period INTERVAL;
+BEGIN
+    SELECT CASE
+        WHEN e.deadline_policy = 'grace_period' THEN e.grace_period * INTERVAL '1 minute'
+        ELSE INTERVAL '0'
+    END INTO v_grace_period
+    FROM exam_info e
+    WHERE e.exam_id = p_exam_id;
+
+    RETURN COALESCE(v_grace_period, INTERVAL '0');
+END;
+$$ LANGUAGE plpgsql;
+
+-- Returns true if the user cannot submit anything to the exam anymore,
+-- false otherwise.
+-- Example usage:
+--      SELECT has_exam_finished(1, '1234');
+CREATE OR REPLACE FUNCTION has_exam_finished(p_exam_id INTEGER, p_user_id UserIdType)
+RETURNS BOOLEAN AS $$
+BEGIN
+    RETURN CURRENT_TIMESTAMP > get_exam_end_time(p_exam_id, p_user_id) + get_exam_grace_period(p_exam_id);
+END;
+$$ LANGUAGE plpgsql;
+
+-- get_exam_latest_start_time returns the latest time the user is allowed to
+-- start an attempt in the exam.
+-- Example usage:
+--      SELECT get_exam_latest_start_time(1, '1234');
+CREATE OR REPLACE FUNCTION get_exam_latest_start_time(p_exam_id INTEGER, p_user_id UserIdType)
+RETURNS TIMESTAMP WITH TIME ZONE AS $$
+DECLARE
+    v_late_start_limit INTEGER;
+    v_close_time TIMESTAMP WITH TIME ZONE := get_exam_close_time(p_exam_id, p_user_id);
+BEGIN
+    SELECT e.late_start_limit INTO v_late_start_limit
+    FROM exam_info e
+    WHERE e.exam_id = p_exam_id;
+
+    IF v_late_start_limit IS NULL THEN
+        RETURN v_close_time;
+    END IF;
+
+    RETURN LEAST(
+        get_exam_start_time(p_exam_id, p_user_id) + v_late_start_limit * INTERVAL '1 minute',
+        v_close_time
+    );
+END;
+$$ LANGUAGE plpgsql;
+
+-- finish_exam_attempt marks the attempt of the user as finished, at the
+-- specified time (or right now if p_finished_at is NULL).
+-- Example usage:
+--      CALL finish_exam_attempt(
+--          p_exam_id := 1,
+--          p_user_id := '1234',
+--          p_attempt_number := 1,
+--          p_finished_at := NULL
+--      );
+CREATE OR REPLACE PROCEDURE finish_exam_attempt(
+    p_exam_id INTEGER,
+    p_user_id UserIdType,
+    p_attempt_number INTEGER,
+    p_finished_at TIMESTAMP WITH TIME ZONE DEFAULT NULL
+)
+LANGUAGE plpgsql
+AS $$
+BEGIN
+    UPDATE exam_attempt
+    SET finished_at = COALESCE(p_finished_at, CURRENT_TIMESTAMP)
+    WHERE exam_id = p_exam_id
+        AND user_id = p_user_id
+        AND attempt_number = p_attempt_number
+        AND finished_at IS NULL;
+
+    IF NOT FOUND THEN
+        RAISE EXCEPTION 'No ongoing attempt % found for user % in exam %', p_attempt_number, p_user_id, p_exam_id;
+    END IF;
+END;
+$$;
+
+-- finish_expired_exam_attempts finishes the ongoing attempt of the user in
+-- the exam if its deadline (plus the grace period) has been reached, as if
+-- the user has submitted it right at that moment.
+-- Example usage:
+--      CALL finish_expired_exam_attempts(
+--          p_exam_id := 1,
+--          p_user_id := '1234'
+--      );
+CREATE OR REPLACE PROCEDURE finish_expired_exam_attempts(
+    p_exam_id INTEGER,
+    p_user_id UserIdType
+)
+LANGUAGE plpgsql
+AS $$
+BEGIN
+    UPDATE exam_attempt a
+    SET finished_at = get_exam_attempt_deadline(a.exam_id, a.user_id, a.started_at) +
+        get_exam_grace_period(a.exam_id)
+    WHERE a.exam_id = p_exam_id
+        AND a.user_id = p_user_id
+        AND a.finished_at IS NULL
+        AND CURRENT_TIMESTAMP > get_exam_attempt_deadline(a.exam_id, a.user_id, a.started_at) +
+            get_exam_grace_period(a.exam_id);
+END;
+$$;
+
+-- start_exam_attempt starts a new attempt for the user in the exam and
+-- returns its number. The client binding of the previous attempt (if any)
+-- is removed, so the new attempt can get bound to a new client.
+-- Example usage:
+--      SELECT * FROM start_exam_attempt(
+--          p_exam_id := 1,
+--          p_user_id := '1234'
+--      );
+CREATE OR REPLACE FUNCTION start_exam_attempt(
+    p_exam_id INTEGER,
+    p_user_id UserIdType
+) RETURNS TABLE (
+    attempt_number INTEGER,
+    started_at TIMESTAMP WITH TIME ZONE
+) AS $$
+DECLARE
+    v_max_attempts INTEGER;
+    v_attempt_cooldown INTEGER;
+    v_last_attempt INTEGER;
+    v_last_finished_at TIMESTAMP WITH TIME ZONE;
+BEGIN
+    IF NOT has_participated_in_exam(p_exam_id, p_user_id) THEN
+        RAISE EXCEPTION 'User has not participated in exam % yet', p_exam_id;
+    END IF;
+
+    IF CURRENT_TIMESTAMP < get_exam_start_time(p_exam_id, p_user_id) THEN
+        RAISE EXCEPTION 'Exam % has not started yet', p_exam_id;
+    ELSIF CURRENT_TIMESTAMP > get_exam_latest_start_time(p_exam_id, p_user_id) THEN
+        RAISE EXCEPTION 'User % cannot start exam % anymore', p_user_id, p_exam_id;
+    END IF;
+
+    CALL finish_expired_exam_attempts(p_exam_id, p_user_id);
+
+    SELECT e.max_attempts, e.attempt_cooldown
+    INTO v_max_attempts, v_attempt_cooldown
+    FROM exam_info e
+    WHERE e.exam_id = p_exam_id;
+
+    SELECT a.attempt_number, a.finished_at
+    INTO v_last_attempt, v_last_finished_at
+    FROM exam_attempt a
+    WHERE a.exam_id = p_exam_id AND a.user_id = p_user_id
+    ORDER BY a.attempt_number DESC
+    LIMIT 1;
+
+    IF v_last_attempt IS NULL THEN
+        v_last_attempt := 0;
+    ELSIF v_last_finished_at IS NULL THEN
+        RAISE EXCEPTION 'Attempt % of user % in exam % is not finished yet', v_last_attempt, p_user_id, p_exam_id;
+    ELSIF v_max_attempts > 0 AND v_last_attempt >= v_max_attempts THEN
+        RAISE EXCEPTION 'User % has no attempts left in exam %', p_user_id, p_exam_id;
+    ELSIF v_last_finished_at + (v_attempt_cooldown || ' minutes')::INTERVAL > CURRENT_TIMESTAMP THEN
+        RAISE EXCEPTION 'User % has to wait before retaking exam %', p_user_id, p_exam_id;
+    END IF;
+
+    DELETE FROM exam_attempt_binding b
+    WHERE b.exam_id = p_exam_id AND b.user_id = p_user_id;
+
+    RETURN QUERY
+    INSERT INTO exam_attempt AS a (exam_id, user_id, attempt_number)
+    VALUES (p_exam_id, p_user_id, v_last_attempt + 1)
+    RETURNING a.attempt_number, a.started_at;
+END;
+$$ LANGUAGE plpgsql;
+
+-- is_exam_open_for_user returns true if the user still has something to do in
+-- the exam: it has not closed for them yet, and they either have an ongoing
+-- attempt or are still allowed to start a new one.
+-- Example usage:
+--      SELECT is_exam_open_for_user(1, '1234');
+CREATE OR REPLACE FUNCTION is_exam_open_for_user(p_exam_id INTEGER, p_user_id UserIdType)
+RETURNS BOOLEAN AS $$
+DECLARE
+    v_max_attempts INTEGER;
+    v_last_attempt INTEGER;
+    v_last_started_at TIMESTAMP WITH TIME ZONE;
+    v_last_finished_at TIMESTAMP WITH TIME ZONE;
+    v_grace_period INTERVAL := get_exam_grace_period(p_exam_id);
+BEGIN
+    IF CURRENT_TIMESTAMP > get_exam_close_time(p_exam_id, p_user_id) + v_grace_period THEN
+        RETURN FALSE;
+    END IF;
+
+    SELECT a.attempt_number, a.started_at, a.finished_at
+    INTO v_last_attempt, v_last_started_at, v_last_finished_at
+    FROM exam_attempt a
+    WHERE a.exam_id = p_exam_id AND a.user_id = p_user_id
+    ORDER BY a.attempt_number DESC
+    LIMIT 1;
+
+    IF v_last_attempt IS NOT NULL AND v_last_finished_at IS NULL AND
+        CURRENT_TIMESTAMP <= get_exam_attempt_deadline(p_exam_id, p_user_id, v_last_started_at) + v_grace_period THEN
+        -- the user is still taking the exam
+        RETURN TRUE;
+    ELSIF CURRENT_TIMESTAMP > get_exam_latest_start_time(p_exam_id, p_user_id) THEN
+        RETURN FALSE;
+    END IF;
+
+    SELECT e.max_attempts INTO v_max_attempts
+    FROM exam_info e
+    WHERE e.exam_id = p_exam_id;
+
+    RETURN v_last_attempt IS NULL OR v_max_attempts = 0 OR v_last_attempt < v_max_attempts;
+END;
+$$ LANGUAGE plpgsql;
+
+-- set_exam_availability sets the availability window, the late start rule and
+-- the deadline policy of the exam.
+-- Example usage:
+--      CALL set_exam_availability(
+--          p_exam_id := 1,
+--          p_opens_at := '2024-06-01 08:00:00+00',
+--          p_closes_at := '2024-06-03 20:00:00+00',
+--          p_late_start_limit := 60,
+--          p_deadline_policy := 'grace_period',
+--          p_grace_period := 5
+--      );
+CREATE OR REPLACE PROCEDURE set_exam_availability(
+    p_exam_id INTEGER,
+    p_opens_at TIMESTAMP WITH TIME ZONE,
+    p_closes_at TIMESTAMP WITH TIME ZONE,
+    p_late_start_limit INTEGER,
+    p_deadline_policy VARCHAR(16),
+    p_grace_period INTEGER
+)
+LANGUAGE plpgsql
+AS $$
+BEGIN
+    UPDATE exam_info
+    SET opens_at = p_opens_at,
+        closes_at = p_closes_at,
+        late_start_limit = p_late_start_limit,
+        deadline_policy = p_deadline_policy,
+        grace_period = p_grace_period
+    WHERE exam_id = p_exam_id;
+
+    IF NOT FOUND THEN
+        RAISE EXCEPTION 'Exam with ID % not found', p_exam_id;
+    END IF;
+END;
+$$;
+
+-- The ongoing exams of the users are the ones that are still open for them,
+-- and the rest of their exams are considered as their history.
+CREATE OR REPLACE VIEW user_ongoing_exams AS
+SELECT DISTINCT
+    u.user_id,
+    e.exam_id,
+    e.exam_title,
+    e.exam_date,
+    get_exam_start_time(e.exam_id, u.user_id) AS starts_at,
+    get_exam_end_time(e.exam_id, u.user_id) AS ends_at
+FROM "exam_info" e
+JOIN "given_exam" g ON e.exam_id = g.exam_id
+JOIN "user_info" u ON g.user_id = u.user_id
+WHERE is_exam_open_for_user(e.exam_id, u.user_id);
+
+CREATE OR REPLACE VIEW user_exams_history AS
+SELECT DISTINCT
+    u.user_id,
+    e.exam_id,
+    e.exam_title,
+    e.exam_date
+FROM "exam_info" e
+JOIN "given_exam" g ON e.exam_id = g.exam_id
+JOIN "user_info" u ON g.user_id = u.user_id
+WHERE NOT is_exam_open_for_user(e.exam_id, u.user_id);
+
+COMMENT ON VIEW user_ongoing_exams IS 'View to get all exams (exam_id and exam_title and when it starts and ends for the user) that are still open for the users who participated in them';
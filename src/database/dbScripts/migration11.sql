@@ -0,0 +1,328 @@
+-- exam_series defines exams that have to be recreated periodically (e.g.
+-- weekly quizzes). Each occurrence of the series is materialised as a copy of
+-- the template exam (including its questions and prerequisites) ahead of time,
+-- by the scheduler.
+CREATE TABLE IF NOT EXISTS "exam_series" (
+    series_id SERIAL PRIMARY KEY,
+    template_exam_id INTEGER NOT NULL,
+    series_title VARCHAR(63) NOT NULL,
+    recurrence_rule VARCHAR(255) NOT NULL,
+    starts_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    generate_ahead INTEGER DEFAULT 7,
+    is_active BOOLEAN DEFAULT TRUE,
+    last_generated_at TIMESTAMP WITH TIME ZONE DEFAULT NULL,
+    created_by UserIdType,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_template_exam FOREIGN KEY (template_exam_id) REFERENCES "exam_info"(exam_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_created_by FOREIGN KEY (created_by) REFERENCES "user_info"(user_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT chk_generate_ahead CHECK (generate_ahead >= 0 AND generate_ahead <= 365)
+);
+
+COMMENT ON TABLE exam_series IS 'Stores the recurring exams (exam series)';
+COMMENT ON COLUMN exam_series.series_id IS 'Unique identifier for the series';
+COMMENT ON COLUMN exam_series.template_exam_id IS 'ID of the exam every occurrence is copied from';
+COMMENT ON COLUMN exam_series.series_title IS 'Title of the series';
+COMMENT ON COLUMN exam_series.recurrence_rule IS 'RRULE-like recurrence rule (e.g. FREQ=WEEKLY;BYDAY=MO)';
+COMMENT ON COLUMN exam_series.starts_at IS 'Start of the recurrence (time of the first occurrence)';
+COMMENT ON COLUMN exam_series.generate_ahead IS 'Number of days the occurrences are materialised ahead of time';
+COMMENT ON COLUMN exam_series.is_active IS 'Flag indicating if new occurrences should be materialised';
+COMMENT ON COLUMN exam_series.last_generated_at IS 'Time of the last occurrence handled by the scheduler (can be null)';
+COMMENT ON COLUMN exam_series.created_by IS 'ID of the user who created the series';
+COMMENT ON COLUMN exam_series.created_at IS 'Timestamp when the series was created';
+
+-- exam_series_occurrence holds the occurrences of the series that have either
+-- been materialised, or edited/skipped by the teacher.
+CREATE TABLE IF NOT EXISTS "exam_series_occurrence" (
+    series_id INTEGER NOT NULL,
+    occurrence_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    exam_id INTEGER DEFAULT NULL,
+    is_skipped BOOLEAN DEFAULT FALSE,
+    exam_title VARCHAR(63) DEFAULT NULL,
+    exam_date TIMESTAMP WITH TIME ZONE DEFAULT NULL,
+    duration INTEGER DEFAULT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (series_id, occurrence_at),
+
+    CONSTRAINT fk_series FOREIGN KEY (series_id) REFERENCES "exam_series"(series_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_exam FOREIGN KEY (exam_id) REFERENCES "exam_info"(exam_id) ON DELETE SET NULL ON UPDATE CASCADE,
+    CONSTRAINT chk_duration CHECK (duration IS NULL OR duration > 0)
+);
+
+COMMENT ON TABLE exam_series_occurrence IS 'Stores the materialised, edited or skipped occurrences of the exam series';
+COMMENT ON COLUMN exam_series_occurrence.series_id IS 'ID of the series';
+COMMENT ON COLUMN exam_series_occurrence.occurrence_at IS 'Time of the occurrence as calculated by the recurrence rule';
+COMMENT ON COLUMN exam_series_occurrence.exam_id IS 'ID of the exam materialised for this occurrence (can be null)';
+COMMENT ON COLUMN exam_series_occurrence.is_skipped IS 'Flag indicating if this occurrence is skipped';
+COMMENT ON COLUMN exam_series_occurrence.exam_title IS 'Overridden title of the exam (can be null)';
+COMMENT ON COLUMN exam_series_occurrence.exam_date IS 'Overridden date of the exam (can be null)';
+COMMENT ON COLUMN exam_series_occurrence.duration IS 'Overridden duration of the exam (can be null)';
+COMMENT ON COLUMN exam_series_occurrence.updated_at IS 'Timestamp when the occurrence was last changed';
+
+-- create_exam_series creates a new exam series and returns its id.
+-- Example usage:
+--      SELECT create_exam_series(
+--          p_template_exam_id := 1,
+--          p_series_title := 'Weekly quiz',
+--          p_recurrence_rule := 'FREQ=WEEKLY;BYDAY=MO',
+--          p_starts_at := '2024-06-03 10:00:00+00',
+--          p_generate_ahead := 7,
+--          p_created_by := '1234'
+--      );
+CREATE OR REPLACE FUNCTION create_exam_series(
+    p_template_exam_id INTEGER,
+    p_series_title VARCHAR(63),
+    p_recurrence_rule VARCHAR(255),
+    p_starts_at TIMESTAMP WITH TIME ZONE,
+    p_generate_ahead INTEGER,
+    p_created_by UserIdType
+) RETURNS INTEGER AS $$
+DECLARE
+    new_series_id INTEGER;
+BEGIN
+    INSERT INTO exam_series (
+        template_exam_id,
+        series_title,
+        recurrence_rule,
+        starts_at,
+        generate_ahead,
+        created_by
+    )
+    VALUES (
+        p_template_exam_id,
+        p_series_title,
+        p_recurrence_rule,
+        p_starts_at,
+        p_generate_ahead,
+        p_created_by
+    )
+    RETURNING series_id INTO new_series_id;
+
+    RETURN new_series_id;
+END;
+$$ LANGUAGE plpgsql;
+
+-- materialise_exam_series_occurrence creates the exam of an occurrence of the
+-- series (by copying the template exam, its questions and its prerequisites)
+-- and returns its id. If the occurrence has already been materialised, the id
+-- of the existing exam is returned; if it's skipped, NULL is returned.
+-- Example usage:
+--      SELECT materialise_exam_series_occurrence(1, '2024-06-03 10:00:00+00');
+CREATE OR REPLACE FUNCTION materialise_exam_series_occurrence(
+    p_series_id INTEGER,
+    p_occurrence_at TIMESTAMP WITH TIME ZONE
+) RETURNS INTEGER AS $$
+DECLARE
+    v_template_exam_id INTEGER;
+    v_occurrence RECORD;
+    v_exam_date TIMESTAMP WITH TIME ZONE;
+    v_shift INTERVAL;
+    new_exam_id INTEGER;
+BEGIN
+    SELECT s.template_exam_id INTO v_template_exam_id
+    FROM exam_series s
+    WHERE s.series_id = p_series_id;
+
+    IF v_template_exam_id IS NULL THEN
+        RAISE EXCEPTION 'Exam series with ID % not found', p_series_id;
+    END IF;
+
+    SELECT o.exam_id, o.is_skipped, o.exam_title, o.exam_date, o.duration
+    INTO v_occurrence
+    FROM exam_series_occurrence o
+    WHERE o.series_id = p_series_id AND o.occurrence_at = p_occurrence_at
+    FOR UPDATE;
+
+    IF FOUND AND v_occurrence.exam_id IS NOT NULL THEN
+        RETURN v_occurrence.exam_id;
+    ELSIF FOUND AND v_occurrence.is_skipped THEN
+        RETURN NULL;
+    END IF;
+
+    v_exam_date := COALESCE(v_occurrence.exam_date, p_occurrence_at);
+
+    SELECT v_exam_date - e.exam_date INTO v_shift
+    FROM exam_info e
+    WHERE e.exam_id = v_template_exam_id;
+
+    INSERT INTO exam_info (
+        course_id,
+        exam_title,
+        exam_description,
+        price,
+        exam_date,
+        duration,
+        created_by,
+        is_public,
+        access_code_type,
+        access_code,
+        access_code_interval,
+        max_attempts,
+        attempt_cooldown,
+        grading_policy,
+        opens_at,
+        closes_at,
+        late_start_limit,
+        deadline_policy,
+        grace_period
+    )
+    SELECT
+        e.course_id,
+        COALESCE(v_occurrence.exam_title, e.exam_title),
+        e.exam_description,
+        e.price,
+        v_exam_date,
+        COALESCE(v_occurrence.duration, e.duration),
+        e.created_by,
+        e.is_public,
+        e.access_code_type,
+        e.access_code,
+        e.access_code_interval,
+        e.max_attempts,
+        e.attempt_cooldown,
+        e.grading_policy,
+        e.opens_at + v_shift,
+        e.closes_at + v_shift,
+        e.late_start_limit,
+        e.deadline_policy,
+        e.grace_period
+    FROM exam_info e
+    WHERE e.exam_id = v_template_exam_id
+    RETURNING exam_id INTO new_exam_id;
+
+    INSERT INTO exam_question (
+        exam_id,
+        question_title,
+        description,
+        option1,
+        option2,
+        option3,
+        option4
+    )
+    SELECT new_exam_id, q.question_title, q.description, q.option1, q.option2, q.option3, q.option4
+    FROM exam_question q
+    WHERE q.exam_id = v_template_exam_id
+    ORDER BY q.question_id;
+
+    INSERT INTO exam_prerequisite (
+        exam_id,
+        prerequisite_type,
+        required_exam_id,
+        min_score,
+        topic_id,
+        min_level,
+        added_by
+    )
+    SELECT new_exam_id, p.prerequisite_type, p.required_exam_id, p.min_score, p.topic_id, p.min_level, p.added_by
+    FROM exam_prerequisite p
+    WHERE p.exam_id = v_template_exam_id;
+
+    INSERT INTO exam_series_occurrence (series_id, occurrence_at, exam_id)
+    VALUES (p_series_id, p_occurrence_at, new_exam_id)
+    ON CONFLICT (series_id, occurrence_at)
+    DO UPDATE SET
+        exam_id = EXCLUDED.exam_id,
+        updated_at = CURRENT_TIMESTAMP;
+
+    RETURN new_exam_id;
+END;
+$$ LANGUAGE plpgsql;
+
+-- edit_exam_series_occurrence overrides the title, date and duration of an
+-- occurrence of the series (NULL means no override). If the occurrence has
+-- already been materialised, its exam is updated as well.
+-- Example usage:
+--      CALL edit_exam_series_occurrence(
+--          p_series_id := 1,
+--          p_occurrence_at := '2024-06-03 10:00:00+00',
+--          p_exam_title := 'Weekly quiz (holiday edition)',
+--          p_exam_date := '2024-06-04 10:00:00+00',
+--          p_duration := NULL
+--      );
+CREATE OR REPLACE PROCEDURE edit_exam_series_occurrence(
+    p_series_id INTEGER,
+    p_occurrence_at TIMESTAMP WITH TIME ZONE,
+    p_exam_title VARCHAR(63),
+    p_exam_date TIMESTAMP WITH TIME ZONE,
+    p_duration INTEGER
+)
+LANGUAGE plpgsql
+AS $$
+DECLARE
+    v_exam_id INTEGER;
+    v_shift INTERVAL;
+    v_template RECORD;
+BEGIN
+    INSERT INTO exam_series_occurrence (series_id, occurrence_at, exam_title, exam_date, duration)
+    VALUES (p_series_id, p_occurrence_at, p_exam_title, p_exam_date, p_duration)
+    ON CONFLICT (series_id, occurrence_at)
+    DO UPDATE SET
+        exam_title = EXCLUDED.exam_title,
+        exam_date = EXCLUDED.exam_date,
+        duration = EXCLUDED.duration,
+        updated_at = CURRENT_TIMESTAMP
+    RETURNING exam_id INTO v_exam_id;
+
+    IF v_exam_id IS NULL THEN
+        RETURN;
+    END IF;
+
+    SELECT t.exam_title, t.duration INTO v_template
+    FROM exam_series s
+    JOIN exam_info t ON t.exam_id = s.template_exam_id
+    WHERE s.series_id = p_series_id;
+
+    SELECT COALESCE(p_exam_date, p_occurrence_at) - e.exam_date INTO v_shift
+    FROM exam_info e
+    WHERE e.exam_id = v_exam_id;
+
+    -- not overriding something means going back to the template
+    UPDATE exam_info e
+    SET exam_title = COALESCE(p_exam_title, v_template.exam_title),
+        exam_date = e.exam_date + v_shift,
+        opens_at = e.opens_at + v_shift,
+        closes_at = e.closes_at + v_shift,
+        duration = COALESCE(p_duration, v_template.duration)
+    WHERE e.exam_id = v_exam_id;
+END;
+$$;
+
+-- skip_exam_series_occurrence skips (or un-skips) an occurrence of the series.
+-- If the occurrence has already been materialised, its exam is removed; which
+-- is only possible if no one has participated in it yet.
+-- Example usage:
+--      CALL skip_exam_series_occurrence(
+--          p_series_id := 1,
+--          p_occurrence_at := '2024-06-03 10:00:00+00',
+--          p_is_skipped := TRUE
+--      );
+CREATE OR REPLACE PROCEDURE skip_exam_series_occurrence(
+    p_series_id INTEGER,
+    p_occurrence_at TIMESTAMP WITH TIME ZONE,
+    p_is_skipped BOOLEAN
+)
+LANGUAGE plpgsql
+AS $$
+DECLARE
+    v_exam_id INTEGER;
+BEGIN
+    INSERT INTO exam_series_occurrence (series_id, occurrence_at, is_skipped)
+    VALUES (p_series_id, p_occurrence_at, p_is_skipped)
+    ON CONFLICT (series_id, occurrence_at)
+    DO UPDATE SET
+        is_skipped = EXCLUDED.is_skipped,
+        updated_at = CURRENT_TIMESTAMP
+    RETURNING exam_id INTO v_exam_id;
+
+    IF NOT p_is_skipped OR v_exam_id IS NULL THEN
+        RETURN;
+    END IF;
+
+    IF EXISTS (SELECT 1 FROM given_exam g WHERE g.exam_id = v_exam_id) THEN
+        RAISE EXCEPTION 'Exam % of the occurrence already has participants', v_exam_id;
+    END IF;
+
+    -- the occurrence loses its exam_id because of ON DELETE SET NULL
+    DELETE FROM exam_info WHERE exam_id = v_exam_id;
+END;
+$$;
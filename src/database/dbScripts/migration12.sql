@@ -0,0 +1,399 @@
+-- wallet_account holds the accounts of the double-entry ledger. Every user
+-- gets a wallet account on their first transaction; the system accounts
+-- (user_id is null) are the other side of the transactions:
+--   'payment_provider': money coming in (or going out) through the provider.
+--   'exam_revenue': money paid by the users for taking the exams.
+CREATE TABLE IF NOT EXISTS "wallet_account" (
+    account_id SERIAL PRIMARY KEY,
+    account_type VARCHAR(16) NOT NULL,
+    user_id VARCHAR(16) DEFAULT NULL UNIQUE,
+    balance BIGINT NOT NULL DEFAULT 0,
+    currency VARCHAR(8) NOT NULL DEFAULT 'T',
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_user_id FOREIGN KEY (user_id) REFERENCES "user_info"(user_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT chk_account_type CHECK (account_type IN ('user', 'payment_provider', 'exam_revenue')),
+    CONSTRAINT chk_account_user CHECK ((account_type = 'user') = (user_id IS NOT NULL)),
+    CONSTRAINT chk_wallet_balance CHECK (account_type <> 'user' OR balance >= 0)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_wallet_system_account
+    ON "wallet_account" (account_type) WHERE user_id IS NULL;
+
+COMMENT ON TABLE wallet_account IS 'Stores the accounts of the double-entry ledger (user wallets and system accounts)';
+COMMENT ON COLUMN wallet_account.account_id IS 'Unique identifier for the account';
+COMMENT ON COLUMN wallet_account.account_type IS 'Type of the account (user, payment_provider or exam_revenue)';
+COMMENT ON COLUMN wallet_account.user_id IS 'ID of the user owning the wallet (null for system accounts)';
+COMMENT ON COLUMN wallet_account.balance IS 'Current balance of the account, in minor units';
+COMMENT ON COLUMN wallet_account.currency IS 'Currency of the account';
+COMMENT ON COLUMN wallet_account.updated_at IS 'Timestamp of the last change of the balance';
+
+INSERT INTO "wallet_account" (account_type) VALUES ('payment_provider'), ('exam_revenue')
+    ON CONFLICT DO NOTHING;
+
+-- wallet_transaction holds every money movement of the platform; the actual
+-- movement is recorded as (at least) two balanced entries in wallet_entry.
+CREATE TABLE IF NOT EXISTS "wallet_transaction" (
+    transaction_id BIGSERIAL PRIMARY KEY,
+    transaction_type VARCHAR(16) NOT NULL,
+    user_id UserIdType,
+    exam_id INTEGER DEFAULT NULL,
+    amount BIGINT NOT NULL,
+    currency VARCHAR(8) NOT NULL DEFAULT 'T',
+    provider VARCHAR(32) DEFAULT NULL,
+    provider_reference VARCHAR(127) DEFAULT NULL,
+    refund_of BIGINT DEFAULT NULL,
+    description VARCHAR(255) DEFAULT NULL,
+    created_by VARCHAR(16) DEFAULT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_user_id FOREIGN KEY (user_id) REFERENCES "user_info"(user_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_exam_id FOREIGN KEY (exam_id) REFERENCES "exam_info"(exam_id) ON DELETE SET NULL ON UPDATE CASCADE,
+    CONSTRAINT fk_refund_of FOREIGN KEY (refund_of) REFERENCES "wallet_transaction"(transaction_id) ON DELETE RESTRICT,
+    CONSTRAINT fk_created_by FOREIGN KEY (created_by) REFERENCES "user_info"(user_id) ON DELETE SET NULL ON UPDATE CASCADE,
+    CONSTRAINT chk_transaction_type CHECK (transaction_type IN ('top_up', 'exam_payment', 'refund')),
+    CONSTRAINT chk_transaction_amount CHECK (amount > 0),
+    CONSTRAINT chk_refund_of CHECK ((transaction_type = 'refund') = (refund_of IS NOT NULL))
+);
+
+CREATE INDEX IF NOT EXISTS idx_wallet_transaction_user ON "wallet_transaction" (user_id, created_at DESC);
+
+COMMENT ON TABLE wallet_transaction IS 'Stores the wallet transactions (top-ups, exam payments and refunds)';
+COMMENT ON COLUMN wallet_transaction.transaction_id IS 'Unique identifier for the transaction';
+COMMENT ON COLUMN wallet_transaction.transaction_type IS 'Type of the transaction (top_up, exam_payment or refund)';
+COMMENT ON COLUMN wallet_transaction.user_id IS 'ID of the user whose wallet is affected';
+COMMENT ON COLUMN wallet_transaction.exam_id IS 'ID of the exam paid for (only for exam payments and their refunds)';
+COMMENT ON COLUMN wallet_transaction.amount IS 'Amount of the transaction, in minor units';
+COMMENT ON COLUMN wallet_transaction.currency IS 'Currency of the transaction';
+COMMENT ON COLUMN wallet_transaction.provider IS 'Name of the payment provider (only for top-ups and their refunds)';
+COMMENT ON COLUMN wallet_transaction.provider_reference IS 'Reference of the payment in the payment provider';
+COMMENT ON COLUMN wallet_transaction.refund_of IS 'ID of the refunded transaction (only for refunds)';
+COMMENT ON COLUMN wallet_transaction.description IS 'Optional description of the transaction';
+COMMENT ON COLUMN wallet_transaction.created_by IS 'ID of the user who made the transaction';
+COMMENT ON COLUMN wallet_transaction.created_at IS 'Timestamp when the transaction was made';
+
+-- wallet_entry holds the ledger entries; a positive amount is added to the
+-- balance of the account (credit), a negative one is taken from it (debit).
+-- The entries of every transaction always sum up to zero.
+CREATE TABLE IF NOT EXISTS "wallet_entry" (
+    entry_id BIGSERIAL PRIMARY KEY,
+    transaction_id BIGINT NOT NULL,
+    account_id INTEGER NOT NULL,
+    amount BIGINT NOT NULL,
+
+    CONSTRAINT fk_transaction_id FOREIGN KEY (transaction_id) REFERENCES "wallet_transaction"(transaction_id) ON DELETE CASCADE,
+    CONSTRAINT fk_account_id FOREIGN KEY (account_id) REFERENCES "wallet_account"(account_id) ON DELETE RESTRICT,
+    CONSTRAINT chk_entry_amount CHECK (amount <> 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_wallet_entry_transaction ON "wallet_entry" (transaction_id);
+CREATE INDEX IF NOT EXISTS idx_wallet_entry_account ON "wallet_entry" (account_id);
+
+COMMENT ON TABLE wallet_entry IS 'Stores the double-entry ledger entries of the wallet transactions';
+COMMENT ON COLUMN wallet_entry.entry_id IS 'Unique identifier for the entry';
+COMMENT ON COLUMN wallet_entry.transaction_id IS 'ID of the transaction the entry belongs to';
+COMMENT ON COLUMN wallet_entry.account_id IS 'ID of the affected account';
+COMMENT ON COLUMN wallet_entry.amount IS 'Amount added to (positive) or taken from (negative) the account';
+
+-- Makes sure the entries of a transaction are balanced by the end of the
+-- database transaction, and keeps the balance of the accounts in sync.
+CREATE OR REPLACE FUNCTION check_wallet_entries_balanced()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF (SELECT COALESCE(SUM(amount), 0) FROM wallet_entry
+        WHERE transaction_id = NEW.transaction_id) <> 0 THEN
+        RAISE EXCEPTION 'Entries of wallet transaction % are not balanced', NEW.transaction_id;
+    END IF;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_wallet_entries_balanced ON wallet_entry;
+CREATE CONSTRAINT TRIGGER trg_wallet_entries_balanced
+    AFTER INSERT ON wallet_entry
+    DEFERRABLE INITIALLY DEFERRED
+    FOR EACH ROW EXECUTE FUNCTION check_wallet_entries_balanced();
+
+CREATE OR REPLACE FUNCTION apply_wallet_entry()
+RETURNS TRIGGER AS $$
+BEGIN
+    UPDATE wallet_account
+    SET balance = balance + NEW.amount,
+        updated_at = CURRENT_TIMESTAMP
+    WHERE account_id = NEW.account_id;
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_apply_wallet_entry ON wallet_entry;
+CREATE TRIGGER trg_apply_wallet_entry
+    AFTER INSERT ON wallet_entry
+    FOR EACH ROW EXECUTE FUNCTION apply_wallet_entry();
+
+
+-- Returns the id of the wallet account of the user, creating it if needed.
+-- Example usage:
+--     SELECT get_wallet_account_id('user123');
+CREATE OR REPLACE FUNCTION get_wallet_account_id(
+    p_user_id UserIdType
+) RETURNS INTEGER AS $$
+DECLARE
+    v_account_id INTEGER;
+BEGIN
+    INSERT INTO wallet_account (account_type, user_id)
+    VALUES ('user', p_user_id)
+    ON CONFLICT (user_id) DO NOTHING;
+
+    SELECT account_id INTO v_account_id
+    FROM wallet_account
+    WHERE user_id = p_user_id;
+
+    RETURN v_account_id;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Returns the id of a system account (payment_provider or exam_revenue).
+-- Example usage:
+--     SELECT get_system_account_id('exam_revenue');
+CREATE OR REPLACE FUNCTION get_system_account_id(
+    p_account_type VARCHAR(16)
+) RETURNS INTEGER AS $$
+    SELECT account_id FROM wallet_account
+    WHERE account_type = p_account_type AND user_id IS NULL;
+$$ LANGUAGE sql STABLE;
+
+-- Records a wallet transaction moving p_amount from p_from_account to
+-- p_to_account and returns its id. If the user doesn't have enough balance,
+-- the chk_wallet_balance constraint makes the whole thing fail.
+-- Example usage:
+--     SELECT record_wallet_transaction(
+--         p_transaction_type := 'top_up',
+--         p_user_id := 'user123',
+--         p_amount := 10000,
+--         p_from_account := get_system_account_id('payment_provider'),
+--         p_to_account := get_wallet_account_id('user123'),
+--         p_provider := 'fake',
+--         p_provider_reference := 'fake_1',
+--         p_created_by := 'admin'
+--     );
+CREATE OR REPLACE FUNCTION record_wallet_transaction(
+    p_transaction_type VARCHAR(16),
+    p_user_id UserIdType,
+    p_amount BIGINT,
+    p_from_account INTEGER,
+    p_to_account INTEGER,
+    p_exam_id INTEGER DEFAULT NULL,
+    p_provider VARCHAR(32) DEFAULT NULL,
+    p_provider_reference VARCHAR(127) DEFAULT NULL,
+    p_refund_of BIGINT DEFAULT NULL,
+    p_description VARCHAR(255) DEFAULT NULL,
+    p_created_by VARCHAR(16) DEFAULT NULL,
+    p_currency VARCHAR(8) DEFAULT 'T'
+) RETURNS BIGINT AS $$
+DECLARE
+    v_transaction_id BIGINT;
+BEGIN
+    INSERT INTO wallet_transaction (
+        transaction_type,
+        user_id,
+        exam_id,
+        amount,
+        currency,
+        provider,
+        provider_reference,
+        refund_of,
+        description,
+        created_by
+    ) VALUES (
+        p_transaction_type,
+        p_user_id,
+        p_exam_id,
+        p_amount,
+        p_currency,
+        p_provider,
+        p_provider_reference,
+        p_refund_of,
+        p_description,
+        p_created_by
+    ) RETURNING transaction_id INTO v_transaction_id;
+
+    INSERT INTO wallet_entry (transaction_id, account_id, amount)
+    VALUES
+        (v_transaction_id, p_from_account, -p_amount),
+        (v_transaction_id, p_to_account, p_amount);
+
+    RETURN v_transaction_id;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Tops up the wallet of the user with money received through the payment
+-- provider, and returns the id of the transaction.
+-- Example usage:
+--     SELECT top_up_wallet(
+--         p_user_id := 'user123',
+--         p_amount := 10000,
+--         p_provider := 'fake',
+--         p_provider_reference := 'fake_1',
+--         p_description := NULL,
+--         p_created_by := 'admin'
+--     );
+CREATE OR REPLACE FUNCTION top_up_wallet(
+    p_user_id UserIdType,
+    p_amount BIGINT,
+    p_provider VARCHAR(32),
+    p_provider_reference VARCHAR(127),
+    p_description VARCHAR(255) DEFAULT NULL,
+    p_created_by VARCHAR(16) DEFAULT NULL,
+    p_currency VARCHAR(8) DEFAULT 'T'
+) RETURNS BIGINT AS $$
+BEGIN
+    RETURN record_wallet_transaction(
+        p_transaction_type := 'top_up',
+        p_user_id := p_user_id,
+        p_amount := p_amount,
+        p_from_account := get_system_account_id('payment_provider'),
+        p_to_account := get_wallet_account_id(p_user_id),
+        p_provider := p_provider,
+        p_provider_reference := p_provider_reference,
+        p_description := p_description,
+        p_created_by := p_created_by,
+        p_currency := p_currency
+    );
+END;
+$$ LANGUAGE plpgsql;
+
+-- Refunds (a part of) a transaction and returns the id of the refund
+-- transaction. Refunding an exam payment puts the money back in the wallet
+-- of the user; refunding a top-up takes the money out of the wallet and
+-- gives it back through the payment provider.
+-- Example usage:
+--     SELECT refund_wallet_transaction(
+--         p_transaction_id := 12,
+--         p_amount := 5000,
+--         p_provider_reference := NULL,
+--         p_description := 'exam got cancelled',
+--         p_created_by := 'admin'
+--     );
+CREATE OR REPLACE FUNCTION refund_wallet_transaction(
+    p_transaction_id BIGINT,
+    p_amount BIGINT,
+    p_provider_reference VARCHAR(127) DEFAULT NULL,
+    p_description VARCHAR(255) DEFAULT NULL,
+    p_created_by VARCHAR(16) DEFAULT NULL
+) RETURNS BIGINT AS $$
+DECLARE
+    v_original wallet_transaction%ROWTYPE;
+    v_refunded BIGINT;
+    v_user_account INTEGER;
+BEGIN
+    SELECT * INTO v_original FROM wallet_transaction
+    WHERE transaction_id = p_transaction_id
+    FOR UPDATE;
+
+    IF NOT FOUND THEN
+        RAISE EXCEPTION 'Wallet transaction % does not exist', p_transaction_id;
+    ELSIF v_original.transaction_type = 'refund' THEN
+        RAISE EXCEPTION 'Refunds cannot be refunded';
+    END IF;
+
+    SELECT COALESCE(SUM(amount), 0) INTO v_refunded
+    FROM wallet_transaction
+    WHERE refund_of = p_transaction_id;
+
+    IF v_refunded + p_amount > v_original.amount THEN
+        RAISE EXCEPTION 'Refund exceeds the refundable amount of transaction %', p_transaction_id;
+    END IF;
+
+    v_user_account := get_wallet_account_id(v_original.user_id);
+
+    IF v_original.transaction_type = 'exam_payment' THEN
+        RETURN record_wallet_transaction(
+            p_transaction_type := 'refund',
+            p_user_id := v_original.user_id,
+            p_amount := p_amount,
+            p_from_account := get_system_account_id('exam_revenue'),
+            p_to_account := v_user_account,
+            p_exam_id := v_original.exam_id,
+            p_refund_of := p_transaction_id,
+            p_description := p_description,
+            p_created_by := p_created_by,
+            p_currency := v_original.currency
+        );
+    END IF;
+
+    RETURN record_wallet_transaction(
+        p_transaction_type := 'refund',
+        p_user_id := v_original.user_id,
+        p_amount := p_amount,
+        p_from_account := v_user_account,
+        p_to_account := get_system_account_id('payment_provider'),
+        p_provider := v_original.provider,
+        p_provider_reference := p_provider_reference,
+        p_refund_of := p_transaction_id,
+        p_description := p_description,
+        p_created_by := p_created_by,
+        p_currency := v_original.currency
+    );
+END;
+$$ LANGUAGE plpgsql;
+
+
+-- add_user_in_exam now charges the wallet of the user for the exam in the
+-- same transaction the user is added to the exam.
+DO $$
+DECLARE
+    r RECORD;
+BEGIN
+    FOR r IN SELECT oid::regprocedure AS signature FROM pg_proc
+        WHERE proname = 'add_user_in_exam' LOOP
+        EXECUTE 'DROP PROCEDURE ' || r.signature;
+    END LOOP;
+END;
+$$;
+
+-- Example usage:
+--    CALL add_user_in_exam(
+--        p_user_id := 'user123',
+--        p_exam_id := 1001,
+--        p_price := '149.99T',
+--        p_amount := 14999,
+--        p_added_by := 'admin'
+--    );
+CREATE OR REPLACE PROCEDURE add_user_in_exam(
+    p_user_id UserIdType,
+    p_exam_id INTEGER,
+    p_price VARCHAR(16) DEFAULT '0T',
+    p_added_by VARCHAR(16) DEFAULT NULL,
+    p_amount BIGINT DEFAULT 0,
+    p_currency VARCHAR(8) DEFAULT 'T'
+)
+LANGUAGE plpgsql
+AS $$
+BEGIN
+    -- Check if the user already exists in the exam
+    IF EXISTS (
+        SELECT 1 FROM given_exam
+        WHERE user_id = p_user_id AND exam_id = p_exam_id
+    ) THEN
+        RAISE EXCEPTION 'User % is already registered for exam %', p_user_id, p_exam_id;
+    END IF;
+
+    INSERT INTO "given_exam" (user_id, exam_id, price, added_by)
+    VALUES (p_user_id, p_exam_id, p_price, p_added_by);
+
+    IF p_amount > 0 THEN
+        PERFORM record_wallet_transaction(
+            p_transaction_type := 'exam_payment',
+            p_user_id := p_user_id,
+            p_amount := p_amount,
+            p_from_account := get_wallet_account_id(p_user_id),
+            p_to_account := get_system_account_id('exam_revenue'),
+            p_exam_id := p_exam_id,
+            p_created_by := COALESCE(p_added_by, p_user_id),
+            p_currency := p_currency
+        );
+    END IF;
+END;
+$$;
@@ -0,0 +1,219 @@
+-- exam_coupon holds the discount coupons of the exam prices. A coupon is
+-- scoped to either a single exam, all exams of a course or all exams of
+-- a topic.
+CREATE TABLE IF NOT EXISTS "exam_coupon" (
+    coupon_id SERIAL PRIMARY KEY,
+    coupon_code VARCHAR(32) NOT NULL UNIQUE,
+    discount_type VARCHAR(16) NOT NULL,
+    discount_value BIGINT NOT NULL,
+    scope_type VARCHAR(16) NOT NULL,
+    scope_id INTEGER NOT NULL,
+    max_uses INTEGER DEFAULT NULL,
+    max_uses_per_user INTEGER DEFAULT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE DEFAULT NULL,
+    is_active BOOLEAN DEFAULT TRUE,
+    created_by UserIdType,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_created_by FOREIGN KEY (created_by) REFERENCES "user_info"(user_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT chk_coupon_code CHECK (coupon_code ~ '^[A-Z0-9_-]{3,32}$'),
+    CONSTRAINT chk_discount_type CHECK (discount_type IN ('percentage', 'fixed')),
+    CONSTRAINT chk_discount_value CHECK (discount_value > 0 AND
+        (discount_type <> 'percentage' OR discount_value <= 100)),
+    CONSTRAINT chk_scope_type CHECK (scope_type IN ('exam', 'course', 'topic')),
+    CONSTRAINT chk_max_uses CHECK (max_uses IS NULL OR max_uses > 0),
+    CONSTRAINT chk_max_uses_per_user CHECK (max_uses_per_user IS NULL OR max_uses_per_user > 0)
+);
+
+COMMENT ON TABLE exam_coupon IS 'Stores the discount coupons of the exam prices';
+COMMENT ON COLUMN exam_coupon.coupon_id IS 'Unique identifier for the coupon';
+COMMENT ON COLUMN exam_coupon.coupon_code IS 'Code of the coupon entered by the users (upper case)';
+COMMENT ON COLUMN exam_coupon.discount_type IS 'Type of the discount (percentage or fixed)';
+COMMENT ON COLUMN exam_coupon.discount_value IS 'Percentage (1-100) or fixed amount (in minor units) of the discount';
+COMMENT ON COLUMN exam_coupon.scope_type IS 'What the coupon can be used for (exam, course or topic)';
+COMMENT ON COLUMN exam_coupon.scope_id IS 'ID of the exam, course or topic the coupon can be used for';
+COMMENT ON COLUMN exam_coupon.max_uses IS 'Maximum number of times the coupon can be used (null means unlimited)';
+COMMENT ON COLUMN exam_coupon.max_uses_per_user IS 'Maximum number of times a single user can use the coupon (null means unlimited)';
+COMMENT ON COLUMN exam_coupon.expires_at IS 'Time after which the coupon cannot be used anymore (can be null)';
+COMMENT ON COLUMN exam_coupon.is_active IS 'Flag indicating if the coupon can still be used';
+COMMENT ON COLUMN exam_coupon.created_by IS 'ID of the user who created the coupon';
+COMMENT ON COLUMN exam_coupon.created_at IS 'Timestamp when the coupon was created';
+
+CREATE TABLE IF NOT EXISTS "exam_coupon_redemption" (
+    coupon_id INTEGER NOT NULL,
+    user_id UserIdType,
+    exam_id INTEGER NOT NULL,
+    discount_amount BIGINT NOT NULL DEFAULT 0,
+    redeemed_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (coupon_id, user_id, exam_id),
+
+    CONSTRAINT fk_coupon_id FOREIGN KEY (coupon_id) REFERENCES "exam_coupon"(coupon_id) ON DELETE CASCADE,
+    CONSTRAINT fk_user_id FOREIGN KEY (user_id) REFERENCES "user_info"(user_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_exam_id FOREIGN KEY (exam_id) REFERENCES "exam_info"(exam_id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+COMMENT ON TABLE exam_coupon_redemption IS 'Stores the usages of the coupons';
+COMMENT ON COLUMN exam_coupon_redemption.coupon_id IS 'ID of the used coupon';
+COMMENT ON COLUMN exam_coupon_redemption.user_id IS 'ID of the user who used the coupon';
+COMMENT ON COLUMN exam_coupon_redemption.exam_id IS 'ID of the exam the coupon was used for';
+COMMENT ON COLUMN exam_coupon_redemption.discount_amount IS 'Amount discounted from the price of the exam (in minor units)';
+COMMENT ON COLUMN exam_coupon_redemption.redeemed_at IS 'Timestamp when the coupon was used';
+
+-- Example usage:
+--     SELECT create_exam_coupon(
+--         p_coupon_code := 'SPRING20',
+--         p_discount_type := 'percentage',
+--         p_discount_value := 20,
+--         p_scope_type := 'course',
+--         p_scope_id := 3,
+--         p_max_uses := 100,
+--         p_max_uses_per_user := 1,
+--         p_expires_at := '2025-06-01 00:00:00+00',
+--         p_created_by := 'teacher1'
+--     );
+CREATE OR REPLACE FUNCTION create_exam_coupon(
+    p_coupon_code VARCHAR(32),
+    p_discount_type VARCHAR(16),
+    p_discount_value BIGINT,
+    p_scope_type VARCHAR(16),
+    p_scope_id INTEGER,
+    p_max_uses INTEGER DEFAULT NULL,
+    p_max_uses_per_user INTEGER DEFAULT NULL,
+    p_expires_at TIMESTAMP WITH TIME ZONE DEFAULT NULL,
+    p_created_by UserIdType DEFAULT '0'
+) RETURNS INTEGER AS $$
+DECLARE
+    v_coupon_id INTEGER;
+BEGIN
+    INSERT INTO exam_coupon (
+        coupon_code,
+        discount_type,
+        discount_value,
+        scope_type,
+        scope_id,
+        max_uses,
+        max_uses_per_user,
+        expires_at,
+        created_by
+    ) VALUES (
+        UPPER(p_coupon_code),
+        p_discount_type,
+        p_discount_value,
+        p_scope_type,
+        p_scope_id,
+        p_max_uses,
+        p_max_uses_per_user,
+        p_expires_at,
+        p_created_by
+    ) RETURNING coupon_id INTO v_coupon_id;
+
+    RETURN v_coupon_id;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Returns true if the coupon can be used for the exam (based on its scope).
+-- Example usage:
+--     SELECT is_exam_coupon_applicable(1, 1001);
+CREATE OR REPLACE FUNCTION is_exam_coupon_applicable(
+    p_coupon_id INTEGER,
+    p_exam_id INTEGER
+) RETURNS BOOLEAN AS $$
+    SELECT EXISTS (
+        SELECT 1 FROM exam_coupon c
+        JOIN exam_info e ON e.exam_id = p_exam_id
+        JOIN course_info ci ON ci.course_id = e.course_id
+        WHERE c.coupon_id = p_coupon_id AND (
+            (c.scope_type = 'exam' AND c.scope_id = e.exam_id) OR
+            (c.scope_type = 'course' AND c.scope_id = e.course_id) OR
+            (c.scope_type = 'topic' AND c.scope_id = ci.topic_id)
+        )
+    );
+$$ LANGUAGE sql STABLE;
+
+
+-- add_user_in_exam now redeems the coupon used by the user (if any) in the
+-- same transaction the user is added to the exam; the coupon row is locked
+-- so its usage limits cannot be exceeded by concurrent participations.
+DO $$
+DECLARE
+    r RECORD;
+BEGIN
+    FOR r IN SELECT oid::regprocedure AS signature FROM pg_proc
+        WHERE proname = 'add_user_in_exam' LOOP
+        EXECUTE 'DROP PROCEDURE ' || r.signature;
+    END LOOP;
+END;
+$$;
+
+-- Example usage:
+--    CALL add_user_in_exam(
+--        p_user_id := 'user123',
+--        p_exam_id := 1001,
+--        p_price := '119.99T',
+--        p_added_by := 'admin',
+--        p_amount := 11999,
+--        p_coupon_id := 1,
+--        p_discount := 3000
+--    );
+CREATE OR REPLACE PROCEDURE add_user_in_exam(
+    p_user_id UserIdType,
+    p_exam_id INTEGER,
+    p_price VARCHAR(16) DEFAULT '0T',
+    p_added_by VARCHAR(16) DEFAULT NULL,
+    p_amount BIGINT DEFAULT 0,
+    p_currency VARCHAR(8) DEFAULT 'T',
+    p_coupon_id INTEGER DEFAULT NULL,
+    p_discount BIGINT DEFAULT 0
+)
+LANGUAGE plpgsql
+AS $$
+DECLARE
+    v_coupon exam_coupon%ROWTYPE;
+BEGIN
+    -- Check if the user already exists in the exam
+    IF EXISTS (
+        SELECT 1 FROM given_exam
+        WHERE user_id = p_user_id AND exam_id = p_exam_id
+    ) THEN
+        RAISE EXCEPTION 'User % is already registered for exam %', p_user_id, p_exam_id;
+    END IF;
+
+    IF p_coupon_id IS NOT NULL THEN
+        SELECT * INTO v_coupon FROM exam_coupon
+        WHERE coupon_id = p_coupon_id
+        FOR UPDATE;
+
+        IF NOT FOUND OR NOT v_coupon.is_active OR
+            (v_coupon.expires_at IS NOT NULL AND v_coupon.expires_at <= CURRENT_TIMESTAMP) OR
+            NOT is_exam_coupon_applicable(p_coupon_id, p_exam_id) OR
+            (v_coupon.max_uses IS NOT NULL AND v_coupon.max_uses <= (
+                SELECT COUNT(*) FROM exam_coupon_redemption
+                WHERE coupon_id = p_coupon_id)) OR
+            (v_coupon.max_uses_per_user IS NOT NULL AND v_coupon.max_uses_per_user <= (
+                SELECT COUNT(*) FROM exam_coupon_redemption
+                WHERE coupon_id = p_coupon_id AND user_id = p_user_id)) THEN
+            RAISE EXCEPTION 'Coupon % cannot be used for exam %', p_coupon_id, p_exam_id
+                USING ERRCODE = 'EXC01';
+        END IF;
+
+        INSERT INTO exam_coupon_redemption (coupon_id, user_id, exam_id, discount_amount)
+        VALUES (p_coupon_id, p_user_id, p_exam_id, p_discount);
+    END IF;
+
+    INSERT INTO "given_exam" (user_id, exam_id, price, added_by)
+    VALUES (p_user_id, p_exam_id, p_price, p_added_by);
+
+    IF p_amount > 0 THEN
+        PERFORM record_wallet_transaction(
+            p_transaction_type := 'exam_payment',
+            p_user_id := p_user_id,
+            p_amount := p_amount,
+            p_from_account := get_wallet_account_id(p_user_id),
+            p_to_account := get_system_account_id('exam_revenue'),
+            p_exam_id := p_exam_id,
+            p_created_by := COALESCE(p_added_by, p_user_id),
+            p_currency := p_currency
+        );
+    END IF;
+END;
+$$;
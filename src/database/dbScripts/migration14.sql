@@ -0,0 +1,103 @@
+-- exam_invitation holds the invitations sent to the users (or to email
+-- addresses without an account) to take part in an exam. The join links
+-- are signed by the server, so no token is stored here.
+CREATE TABLE IF NOT EXISTS "exam_invitation" (
+    invitation_id SERIAL PRIMARY KEY,
+    exam_id INTEGER NOT NULL,
+    email VARCHAR(127) NOT NULL,
+    user_id VARCHAR(16) DEFAULT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'pending',
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    invited_by UserIdType,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    accepted_at TIMESTAMP WITH TIME ZONE DEFAULT NULL,
+
+    CONSTRAINT fk_exam_id FOREIGN KEY (exam_id) REFERENCES "exam_info"(exam_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_user_id FOREIGN KEY (user_id) REFERENCES "user_info"(user_id) ON DELETE SET NULL ON UPDATE CASCADE,
+    CONSTRAINT fk_invited_by FOREIGN KEY (invited_by) REFERENCES "user_info"(user_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT chk_invitation_status CHECK (status IN ('pending', 'accepted', 'expired'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_exam_invitation_exam ON "exam_invitation" (exam_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_exam_invitation_email ON "exam_invitation" (LOWER(email));
+
+COMMENT ON TABLE exam_invitation IS 'Stores the invitations to the exams';
+COMMENT ON COLUMN exam_invitation.invitation_id IS 'Unique identifier for the invitation';
+COMMENT ON COLUMN exam_invitation.exam_id IS 'ID of the exam the invitee is invited to';
+COMMENT ON COLUMN exam_invitation.email IS 'Email address the invitation is sent to';
+COMMENT ON COLUMN exam_invitation.user_id IS 'ID of the invited user (null if the email has no account yet)';
+COMMENT ON COLUMN exam_invitation.status IS 'Status of the invitation (pending, accepted or expired)';
+COMMENT ON COLUMN exam_invitation.expires_at IS 'Time after which the invitation cannot be accepted anymore';
+COMMENT ON COLUMN exam_invitation.invited_by IS 'ID of the user who sent the invitation';
+COMMENT ON COLUMN exam_invitation.created_at IS 'Timestamp when the invitation was sent';
+COMMENT ON COLUMN exam_invitation.accepted_at IS 'Timestamp when the invitation was accepted (can be null)';
+
+-- Creates a new invitation; the previous pending invitations of the same
+-- email to the same exam are marked as expired, so only the most recent
+-- link can be used.
+-- Example usage:
+--     SELECT create_exam_invitation(
+--         p_exam_id := 1001,
+--         p_email := 'student@example.com',
+--         p_user_id := NULL,
+--         p_expires_at := CURRENT_TIMESTAMP + INTERVAL '3 days',
+--         p_invited_by := 'teacher1'
+--     );
+CREATE OR REPLACE FUNCTION create_exam_invitation(
+    p_exam_id INTEGER,
+    p_email VARCHAR(127),
+    p_user_id VARCHAR(16),
+    p_expires_at TIMESTAMP WITH TIME ZONE,
+    p_invited_by UserIdType
+) RETURNS INTEGER AS $$
+DECLARE
+    v_invitation_id INTEGER;
+BEGIN
+    UPDATE exam_invitation
+    SET status = 'expired'
+    WHERE exam_id = p_exam_id
+        AND LOWER(email) = LOWER(p_email)
+        AND status = 'pending';
+
+    INSERT INTO exam_invitation (exam_id, email, user_id, expires_at, invited_by)
+    VALUES (p_exam_id, p_email, p_user_id, p_expires_at, p_invited_by)
+    RETURNING invitation_id INTO v_invitation_id;
+
+    RETURN v_invitation_id;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Marks the pending invitations which are past their expiry as expired.
+-- Example usage:
+--     CALL expire_exam_invitations();
+CREATE OR REPLACE PROCEDURE expire_exam_invitations()
+LANGUAGE plpgsql
+AS $$
+BEGIN
+    UPDATE exam_invitation
+    SET status = 'expired'
+    WHERE status = 'pending' AND expires_at <= CURRENT_TIMESTAMP;
+END;
+$$;
+
+-- Marks the invitation as accepted by the user.
+-- Example usage:
+--     CALL accept_exam_invitation(12, 'user123');
+CREATE OR REPLACE PROCEDURE accept_exam_invitation(
+    p_invitation_id INTEGER,
+    p_user_id UserIdType
+)
+LANGUAGE plpgsql
+AS $$
+BEGIN
+    UPDATE exam_invitation
+    SET status = 'accepted',
+        user_id = p_user_id,
+        accepted_at = CURRENT_TIMESTAMP
+    WHERE invitation_id = p_invitation_id AND status = 'pending';
+
+    IF NOT FOUND THEN
+        RAISE EXCEPTION 'Invitation % is not pending', p_invitation_id;
+    END IF;
+END;
+$$;
@@ -0,0 +1,240 @@
+-- capacity is the maximum number of participants of the exam (e.g. the seats
+-- of the room it's held in); NULL means unlimited.
+ALTER TABLE "exam_info" ADD COLUMN IF NOT EXISTS capacity INTEGER DEFAULT NULL;
+ALTER TABLE "exam_info" ADD CONSTRAINT chk_exam_capacity CHECK (
+    capacity IS NULL OR capacity > 0
+);
+
+COMMENT ON COLUMN exam_info.capacity IS 'Maximum number of participants of the exam (null means unlimited)';
+
+-- exam_waitlist holds the users waiting for a seat in a full exam, in the
+-- order they have joined the waitlist.
+CREATE TABLE IF NOT EXISTS "exam_waitlist" (
+    waitlist_id SERIAL PRIMARY KEY,
+    exam_id INTEGER NOT NULL,
+    user_id UserIdType,
+    added_by VARCHAR(16) DEFAULT NULL,
+    coupon_id INTEGER DEFAULT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_exam_id FOREIGN KEY (exam_id) REFERENCES "exam_info"(exam_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_user_id FOREIGN KEY (user_id) REFERENCES "user_info"(user_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_added_by FOREIGN KEY (added_by) REFERENCES "user_info"(user_id) ON DELETE SET NULL ON UPDATE CASCADE,
+    CONSTRAINT fk_coupon_id FOREIGN KEY (coupon_id) REFERENCES "exam_coupon"(coupon_id) ON DELETE SET NULL ON UPDATE CASCADE,
+    CONSTRAINT unique_exam_waitlist_user UNIQUE (exam_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_exam_waitlist_order ON "exam_waitlist" (exam_id, waitlist_id);
+
+COMMENT ON TABLE exam_waitlist IS 'Stores the users waiting for a seat in the full exams';
+COMMENT ON COLUMN exam_waitlist.waitlist_id IS 'Unique identifier of the entry; also decides the order of the waitlist';
+COMMENT ON COLUMN exam_waitlist.exam_id IS 'ID of the exam';
+COMMENT ON COLUMN exam_waitlist.user_id IS 'ID of the waiting user';
+COMMENT ON COLUMN exam_waitlist.added_by IS 'ID of the user who added the waiting user (can be null)';
+COMMENT ON COLUMN exam_waitlist.coupon_id IS 'ID of the coupon to apply once the user gets a seat (can be null)';
+COMMENT ON COLUMN exam_waitlist.created_at IS 'Timestamp when the user joined the waitlist';
+
+-- Sets the capacity of the exam; NULL removes the limit.
+-- Lowering the capacity never removes the current participants.
+-- Example usage:
+--     CALL set_exam_capacity(
+--         p_exam_id := 1001,
+--         p_capacity := 40
+--     );
+CREATE OR REPLACE PROCEDURE set_exam_capacity(
+    p_exam_id INTEGER,
+    p_capacity INTEGER
+)
+LANGUAGE plpgsql
+AS $$
+BEGIN
+    UPDATE exam_info
+    SET capacity = p_capacity
+    WHERE exam_id = p_exam_id;
+
+    IF NOT FOUND THEN
+        RAISE EXCEPTION 'Exam with ID % not found', p_exam_id;
+    END IF;
+END;
+$$;
+
+-- add_user_in_exam now locks the exam while adding the user, so its
+-- capacity cannot be exceeded by concurrent requests; if the exam is full
+-- an exception with ERRCODE 'EXC02' is raised. The user is also removed
+-- from the waitlist of the exam (if they were waiting).
+-- Example usage:
+--    CALL add_user_in_exam(
+--        p_user_id := 'user123',
+--        p_exam_id := 1001,
+--        p_price := '119.99T',
+--        p_added_by := 'admin',
+--        p_amount := 11999,
+--        p_coupon_id := 1,
+--        p_discount := 3000
+--    );
+CREATE OR REPLACE PROCEDURE add_user_in_exam(
+    p_user_id UserIdType,
+    p_exam_id INTEGER,
+    p_price VARCHAR(16) DEFAULT '0T',
+    p_added_by VARCHAR(16) DEFAULT NULL,
+    p_amount BIGINT DEFAULT 0,
+    p_currency VARCHAR(8) DEFAULT 'T',
+    p_coupon_id INTEGER DEFAULT NULL,
+    p_discount BIGINT DEFAULT 0
+)
+LANGUAGE plpgsql
+AS $$
+DECLARE
+    v_coupon exam_coupon%ROWTYPE;
+    v_capacity INTEGER;
+BEGIN
+    SELECT capacity INTO v_capacity FROM exam_info
+    WHERE exam_id = p_exam_id
+    FOR UPDATE;
+
+    -- Check if the user already exists in the exam
+    IF EXISTS (
+        SELECT 1 FROM given_exam
+        WHERE user_id = p_user_id AND exam_id = p_exam_id
+    ) THEN
+        RAISE EXCEPTION 'User % is already registered for exam %', p_user_id, p_exam_id;
+    END IF;
+
+    IF v_capacity IS NOT NULL AND v_capacity <= (
+        SELECT COUNT(*) FROM given_exam WHERE exam_id = p_exam_id) THEN
+        RAISE EXCEPTION 'Exam % is full', p_exam_id
+            USING ERRCODE = 'EXC02';
+    END IF;
+
+    IF p_coupon_id IS NOT NULL THEN
+        SELECT * INTO v_coupon FROM exam_coupon
+        WHERE coupon_id = p_coupon_id
+        FOR UPDATE;
+
+        IF NOT FOUND OR NOT v_coupon.is_active OR
+            (v_coupon.expires_at IS NOT NULL AND v_coupon.expires_at <= CURRENT_TIMESTAMP) OR
+            NOT is_exam_coupon_applicable(p_coupon_id, p_exam_id) OR
+            (v_coupon.max_uses IS NOT NULL AND v_coupon.max_uses <= (
+                SELECT COUNT(*) FROM exam_coupon_redemption
+                WHERE coupon_id = p_coupon_id)) OR
+            (v_coupon.max_uses_per_user IS NOT NULL AND v_coupon.max_uses_per_user <= (
+                SELECT COUNT(*) FROM exam_coupon_redemption
+                WHERE coupon_id = p_coupon_id AND user_id = p_user_id)) THEN
+            RAISE EXCEPTION 'Coupon % cannot be used for exam %', p_coupon_id, p_exam_id
+                USING ERRCODE = 'EXC01';
+        END IF;
+
+        INSERT INTO exam_coupon_redemption (coupon_id, user_id, exam_id, discount_amount)
+        VALUES (p_coupon_id, p_user_id, p_exam_id, p_discount);
+    END IF;
+
+    INSERT INTO "given_exam" (user_id, exam_id, price, added_by)
+    VALUES (p_user_id, p_exam_id, p_price, p_added_by);
+
+    DELETE FROM exam_waitlist
+    WHERE exam_id = p_exam_id AND user_id = p_user_id;
+
+    IF p_amount > 0 THEN
+        PERFORM record_wallet_transaction(
+            p_transaction_type := 'exam_payment',
+            p_user_id := p_user_id,
+            p_amount := p_amount,
+            p_from_account := get_wallet_account_id(p_user_id),
+            p_to_account := get_system_account_id('exam_revenue'),
+            p_exam_id := p_exam_id,
+            p_created_by := COALESCE(p_added_by, p_user_id),
+            p_currency := p_currency
+        );
+    END IF;
+END;
+$$;
+
+-- Adds the user to the end of the waitlist of the exam and returns the id
+-- of their entry; if the user is already waiting, their current entry is
+-- returned and their place in the waitlist is kept.
+-- Example usage:
+--     SELECT add_user_to_exam_waitlist(
+--         p_user_id := 'user123',
+--         p_exam_id := 1001,
+--         p_added_by := NULL,
+--         p_coupon_id := NULL
+--     );
+CREATE OR REPLACE FUNCTION add_user_to_exam_waitlist(
+    p_user_id UserIdType,
+    p_exam_id INTEGER,
+    p_added_by VARCHAR(16) DEFAULT NULL,
+    p_coupon_id INTEGER DEFAULT NULL
+) RETURNS INTEGER AS $$
+DECLARE
+    v_waitlist_id INTEGER;
+BEGIN
+    INSERT INTO exam_waitlist (exam_id, user_id, added_by, coupon_id)
+    VALUES (p_exam_id, p_user_id, p_added_by, p_coupon_id)
+    ON CONFLICT (exam_id, user_id) DO NOTHING
+    RETURNING waitlist_id INTO v_waitlist_id;
+
+    IF v_waitlist_id IS NULL THEN
+        SELECT waitlist_id INTO v_waitlist_id FROM exam_waitlist
+        WHERE exam_id = p_exam_id AND user_id = p_user_id;
+    END IF;
+
+    RETURN v_waitlist_id;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Withdraws the user from the exam: what they have paid for the exam is
+-- refunded to their wallet, their coupon redemption is released and their
+-- seat is freed (alongside their attempts).
+-- Example usage:
+--     CALL withdraw_user_from_exam(
+--         p_user_id := 'user123',
+--         p_exam_id := 1001,
+--         p_withdrawn_by := 'user123'
+--     );
+CREATE OR REPLACE PROCEDURE withdraw_user_from_exam(
+    p_user_id UserIdType,
+    p_exam_id INTEGER,
+    p_withdrawn_by VARCHAR(16) DEFAULT NULL
+)
+LANGUAGE plpgsql
+AS $$
+DECLARE
+    v_payment RECORD;
+BEGIN
+    PERFORM 1 FROM exam_info
+    WHERE exam_id = p_exam_id
+    FOR UPDATE;
+
+    IF NOT EXISTS (
+        SELECT 1 FROM given_exam
+        WHERE user_id = p_user_id AND exam_id = p_exam_id
+    ) THEN
+        RAISE EXCEPTION 'User % is not registered for exam %', p_user_id, p_exam_id;
+    END IF;
+
+    FOR v_payment IN
+        SELECT t.transaction_id,
+            t.amount - COALESCE((
+                SELECT SUM(r.amount) FROM wallet_transaction r
+                WHERE r.refund_of = t.transaction_id), 0) AS remaining
+        FROM wallet_transaction t
+        WHERE t.transaction_type = 'exam_payment'
+            AND t.user_id = p_user_id AND t.exam_id = p_exam_id
+    LOOP
+        IF v_payment.remaining > 0 THEN
+            PERFORM refund_wallet_transaction(
+                p_transaction_id := v_payment.transaction_id,
+                p_amount := v_payment.remaining,
+                p_description := 'withdrawn from the exam',
+                p_created_by := p_withdrawn_by
+            );
+        END IF;
+    END LOOP;
+
+    DELETE FROM exam_coupon_redemption
+    WHERE user_id = p_user_id AND exam_id = p_exam_id;
+
+    DELETE FROM given_exam
+    WHERE user_id = p_user_id AND exam_id = p_exam_id;
+END;
+$$;
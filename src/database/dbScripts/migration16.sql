@@ -0,0 +1,369 @@
+-- exam_section holds the parts of an exam (e.g. Listening, Reading and
+-- Writing). The sections are taken one after another in their order, each
+-- with its own (optional) time limit.
+CREATE TABLE IF NOT EXISTS "exam_section" (
+    section_id SERIAL PRIMARY KEY,
+    exam_id INTEGER NOT NULL,
+    section_title VARCHAR(255) NOT NULL,
+    section_order INTEGER NOT NULL DEFAULT 0,
+    duration INTEGER DEFAULT NULL,
+    is_forward_only BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_exam_id FOREIGN KEY (exam_id) REFERENCES "exam_info"(exam_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT chk_section_duration CHECK (duration IS NULL OR duration > 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_exam_section_order ON "exam_section" (exam_id, section_order, section_id);
+
+COMMENT ON TABLE exam_section IS 'Stores the sections of the exams';
+COMMENT ON COLUMN exam_section.section_id IS 'Unique identifier for the section';
+COMMENT ON COLUMN exam_section.exam_id IS 'ID of the exam this section belongs to';
+COMMENT ON COLUMN exam_section.section_title IS 'Title of the section';
+COMMENT ON COLUMN exam_section.section_order IS 'Position of the section in the exam (ascending)';
+COMMENT ON COLUMN exam_section.duration IS 'Time limit of the section in minutes (null means only the exam deadline applies)';
+COMMENT ON COLUMN exam_section.is_forward_only IS 'Whether the section gets locked once the user moves past it';
+COMMENT ON COLUMN exam_section.created_at IS 'Timestamp when the section was created';
+
+ALTER TABLE "exam_question" ADD COLUMN IF NOT EXISTS section_id INTEGER DEFAULT NULL;
+ALTER TABLE "exam_question" ADD COLUMN IF NOT EXISTS question_order INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE "exam_question" ADD CONSTRAINT fk_section_id
+    FOREIGN KEY (section_id) REFERENCES "exam_section"(section_id) ON DELETE SET NULL ON UPDATE CASCADE;
+
+COMMENT ON COLUMN exam_question.section_id IS 'ID of the section this question belongs to (null means no section)';
+COMMENT ON COLUMN exam_question.question_order IS 'Position of the question in its section (ascending)';
+
+-- exam_attempt_section holds the progress of the attempts through the
+-- sections of the exam.
+CREATE TABLE IF NOT EXISTS "exam_attempt_section" (
+    exam_id INTEGER NOT NULL,
+    user_id UserIdType,
+    attempt_number INTEGER NOT NULL,
+    section_id INTEGER NOT NULL,
+    started_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    finished_at TIMESTAMP WITH TIME ZONE DEFAULT NULL,
+    PRIMARY KEY (exam_id, user_id, attempt_number, section_id),
+
+    CONSTRAINT fk_exam_attempt FOREIGN KEY (exam_id, user_id, attempt_number) REFERENCES "exam_attempt"(exam_id, user_id, attempt_number) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_section_id FOREIGN KEY (section_id) REFERENCES "exam_section"(section_id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+COMMENT ON TABLE exam_attempt_section IS 'Stores the progress of the attempts through the sections of the exams';
+COMMENT ON COLUMN exam_attempt_section.exam_id IS 'ID of the exam';
+COMMENT ON COLUMN exam_attempt_section.user_id IS 'ID of the user taking the exam';
+COMMENT ON COLUMN exam_attempt_section.attempt_number IS 'Number of the attempt';
+COMMENT ON COLUMN exam_attempt_section.section_id IS 'ID of the section';
+COMMENT ON COLUMN exam_attempt_section.started_at IS 'Timestamp when the user entered the section';
+COMMENT ON COLUMN exam_attempt_section.finished_at IS 'Timestamp when the user moved past the section (can be null)';
+
+-- Drop the old versions of the functions/procedures whose signature is changed
+DO
+$$
+DECLARE
+    r RECORD;
+BEGIN
+    FOR r IN SELECT proname, prokind, pg_get_function_identity_arguments(p.oid) AS args
+             FROM pg_proc p
+             JOIN pg_namespace n ON p.pronamespace = n.oid
+             WHERE n.nspname NOT IN ('pg_catalog', 'information_schema')
+             AND pg_function_is_visible(p.oid)
+             AND proname IN ('create_exam_question')
+    LOOP
+        IF r.prokind = 'p' THEN
+            EXECUTE format('DROP PROCEDURE IF EXISTS %I(%s);', r.proname, r.args);
+        ELSE
+            EXECUTE format('DROP FUNCTION IF EXISTS %I(%s);', r.proname, r.args);
+        END IF;
+    END LOOP;
+END
+$$;
+
+-- Function to create a single exam question, optionally inside a section.
+-- Returns the question_id of the newly created question.
+-- Example usage:
+--      SELECT create_exam_question(
+--         p_exam_id := 1234,
+--         p_question_title := 'What is the capital of France?',
+--         p_description := 'Choose the correct option from the following.',
+--         p_option1 := 'Paris',
+--         p_option2 := 'London',
+--         p_option3 := 'Berlin',
+--         p_option4 := 'Madrid',
+--         p_section_id := 1,
+--         p_question_order := 3
+--      );
+CREATE OR REPLACE FUNCTION create_exam_question(
+    p_exam_id INTEGER,
+    p_question_title VARCHAR(2048),
+    p_description TEXT DEFAULT NULL,
+    p_option1 TEXT DEFAULT NULL,
+    p_option2 TEXT DEFAULT NULL,
+    p_option3 TEXT DEFAULT NULL,
+    p_option4 TEXT DEFAULT NULL,
+    p_section_id INTEGER DEFAULT NULL,
+    p_question_order INTEGER DEFAULT 0
+) RETURNS INTEGER AS $$
+DECLARE
+    new_question_id INTEGER;
+BEGIN
+    IF p_section_id IS NOT NULL AND NOT EXISTS (
+        SELECT 1 FROM exam_section
+        WHERE section_id = p_section_id AND exam_id = p_exam_id
+    ) THEN
+        RAISE EXCEPTION 'Section % does not belong to exam %', p_section_id, p_exam_id;
+    END IF;
+
+    INSERT INTO exam_question (exam_id, question_title, description, option1, option2, option3, option4, section_id, question_order)
+    VALUES (p_exam_id, p_question_title, p_description, p_option1, p_option2, p_option3, p_option4, p_section_id, p_question_order)
+    RETURNING question_id INTO new_question_id;
+
+    RETURN new_question_id;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Creates a new section in the exam and returns its id.
+-- Example usage:
+--      SELECT create_exam_section(
+--         p_exam_id := 1234,
+--         p_section_title := 'Listening',
+--         p_section_order := 1,
+--         p_duration := 30,
+--         p_is_forward_only := TRUE
+--      );
+CREATE OR REPLACE FUNCTION create_exam_section(
+    p_exam_id INTEGER,
+    p_section_title VARCHAR(255),
+    p_section_order INTEGER DEFAULT 0,
+    p_duration INTEGER DEFAULT NULL,
+    p_is_forward_only BOOLEAN DEFAULT FALSE
+) RETURNS INTEGER AS $$
+DECLARE
+    new_section_id INTEGER;
+BEGIN
+    INSERT INTO exam_section (exam_id, section_title, section_order, duration, is_forward_only)
+    VALUES (p_exam_id, p_section_title, p_section_order, p_duration, p_is_forward_only)
+    RETURNING section_id INTO new_section_id;
+
+    RETURN new_section_id;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Marks the section as entered in the attempt; entering it again keeps
+-- the original start time, so its timer cannot be reset.
+-- Example usage:
+--      CALL start_exam_attempt_section(
+--         p_exam_id := 1234,
+--         p_user_id := 'user123',
+--         p_attempt_number := 1,
+--         p_section_id := 2
+--      );
+CREATE OR REPLACE PROCEDURE start_exam_attempt_section(
+    p_exam_id INTEGER,
+    p_user_id UserIdType,
+    p_attempt_number INTEGER,
+    p_section_id INTEGER
+)
+LANGUAGE plpgsql
+AS $$
+BEGIN
+    INSERT INTO exam_attempt_section (exam_id, user_id, attempt_number, section_id)
+    VALUES (p_exam_id, p_user_id, p_attempt_number, p_section_id)
+    ON CONFLICT (exam_id, user_id, attempt_number, section_id) DO NOTHING;
+END;
+$$;
+
+-- Marks the section as left in the attempt (either by the user moving past
+-- it, or by its time running out).
+-- Example usage:
+--      CALL finish_exam_attempt_section(
+--         p_exam_id := 1234,
+--         p_user_id := 'user123',
+--         p_attempt_number := 1,
+--         p_section_id := 2,
+--         p_finished_at := CURRENT_TIMESTAMP
+--      );
+CREATE OR REPLACE PROCEDURE finish_exam_attempt_section(
+    p_exam_id INTEGER,
+    p_user_id UserIdType,
+    p_attempt_number INTEGER,
+    p_section_id INTEGER,
+    p_finished_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+)
+LANGUAGE plpgsql
+AS $$
+BEGIN
+    UPDATE exam_attempt_section
+    SET finished_at = COALESCE(finished_at, p_finished_at)
+    WHERE exam_id = p_exam_id
+        AND user_id = p_user_id
+        AND attempt_number = p_attempt_number
+        AND section_id = p_section_id;
+
+    IF NOT FOUND THEN
+        RAISE EXCEPTION 'Section % has not been started in attempt % of user % in exam %',
+            p_section_id, p_attempt_number, p_user_id, p_exam_id;
+    END IF;
+END;
+$$;
+
+-- materialise_exam_series_occurrence now copies the capacity and the
+-- sections of the template exam as well (keeping the questions inside their
+-- sections).
+-- Example usage:
+--      SELECT materialise_exam_series_occurrence(1, '2024-06-03 10:00:00+00');
+CREATE OR REPLACE FUNCTION materialise_exam_series_occurrence(
+    p_series_id INTEGER,
+    p_occurrence_at TIMESTAMP WITH TIME ZONE
+) RETURNS INTEGER AS $$
+DECLARE
+    v_template_exam_id INTEGER;
+    v_occurrence RECORD;
+    v_section RECORD;
+    v_exam_date TIMESTAMP WITH TIME ZONE;
+    v_shift INTERVAL;
+    new_exam_id INTEGER;
+    new_section_id INTEGER;
+BEGIN
+    SELECT s.template_exam_id INTO v_template_exam_id
+    FROM exam_series s
+    WHERE s.series_id = p_series_id;
+
+    IF v_template_exam_id IS NULL THEN
+        RAISE EXCEPTION 'Exam series with ID % not found', p_series_id;
+    END IF;
+
+    SELECT o.exam_id, o.is_skipped, o.exam_title, o.exam_date, o.duration
+    INTO v_occurrence
+    FROM exam_series_occurrence o
+    WHERE o.series_id = p_series_id AND o.occurrence_at = p_occurrence_at
+    FOR UPDATE;
+
+    IF FOUND AND v_occurrence.exam_id IS NOT NULL THEN
+        RETURN v_occurrence.exam_id;
+    ELSIF FOUND AND v_occurrence.is_skipped THEN
+        RETURN NULL;
+    END IF;
+
+    v_exam_date := COALESCE(v_occurrence.exam_date, p_occurrence_at);
+
+    SELECT v_exam_date - e.exam_date INTO v_shift
+    FROM exam_info e
+    WHERE e.exam_id = v_template_exam_id;
+
+    INSERT INTO exam_info (
+        course_id,
+        exam_title,
+        exam_description,
+        price,
+        exam_date,
+        duration,
+        created_by,
+        is_public,
+        access_code_type,
+        access_code,
+        access_code_interval,
+        max_attempts,
+        attempt_cooldown,
+        grading_policy,
+        opens_at,
+        closes_at,
+        late_start_limit,
+        deadline_policy,
+        grace_period,
+        capacity
+    )
+    SELECT
+        e.course_id,
+        COALESCE(v_occurrence.exam_title, e.exam_title),
+        e.exam_description,
+        e.price,
+        v_exam_date,
+        COALESCE(v_occurrence.duration, e.duration),
+        e.created_by,
+        e.is_public,
+        e.access_code_type,
+        e.access_code,
+        e.access_code_interval,
+        e.max_attempts,
+        e.attempt_cooldown,
+        e.grading_policy,
+        e.opens_at + v_shift,
+        e.closes_at + v_shift,
+        e.late_start_limit,
+        e.deadline_policy,
+        e.grace_period,
+        e.capacity
+    FROM exam_info e
+    WHERE e.exam_id = v_template_exam_id
+    RETURNING exam_id INTO new_exam_id;
+
+    FOR v_section IN
+        SELECT * FROM exam_section
+        WHERE exam_id = v_template_exam_id
+        ORDER BY section_order, section_id
+    LOOP
+        new_section_id := create_exam_section(
+            p_exam_id := new_exam_id,
+            p_section_title := v_section.section_title,
+            p_section_order := v_section.section_order,
+            p_duration := v_section.duration,
+            p_is_forward_only := v_section.is_forward_only
+        );
+
+        INSERT INTO exam_question (
+            exam_id,
+            question_title,
+            description,
+            option1,
+            option2,
+            option3,
+            option4,
+            section_id,
+            question_order
+        )
+        SELECT new_exam_id, q.question_title, q.description, q.option1, q.option2, q.option3, q.option4,
+            new_section_id, q.question_order
+        FROM exam_question q
+        WHERE q.exam_id = v_template_exam_id AND q.section_id = v_section.section_id
+        ORDER BY q.question_order, q.question_id;
+    END LOOP;
+
+    INSERT INTO exam_question (
+        exam_id,
+        question_title,
+        description,
+        option1,
+        option2,
+        option3,
+        option4,
+        question_order
+    )
+    SELECT new_exam_id, q.question_title, q.description, q.option1, q.option2, q.option3, q.option4,
+        q.question_order
+    FROM exam_question q
+    WHERE q.exam_id = v_template_exam_id AND q.section_id IS NULL
+    ORDER BY q.question_order, q.question_id;
+
+    INSERT INTO exam_prerequisite (
+        exam_id,
+        prerequisite_type,
+        required_exam_id,
+        min_score,
+        topic_id,
+        min_level,
+        added_by
+    )
+    SELECT new_exam_id, p.prerequisite_type, p.required_exam_id, p.min_score, p.topic_id, p.min_level, p.added_by
+    FROM exam_prerequisite p
+    WHERE p.exam_id = v_template_exam_id;
+
+    INSERT INTO exam_series_occurrence (series_id, occurrence_at, exam_id)
+    VALUES (p_series_id, p_occurrence_at, new_exam_id)
+    ON CONFLICT (series_id, occurrence_at)
+    DO UPDATE SET
+        exam_id = EXCLUDED.exam_id,
+        updated_at = CURRENT_TIMESTAMP;
+
+    RETURN new_exam_id;
+END;
+$$ LANGUAGE plpgsql;
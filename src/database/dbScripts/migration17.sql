@@ -0,0 +1,390 @@
+-- Exams can be adaptive (computerized adaptive testing): instead of getting
+-- all of the questions at once, the students are given one question at a
+-- time, picked by their answers so far, until the estimate of their ability
+-- is precise enough (or enough questions have been given).
+ALTER TABLE "exam_info" ADD COLUMN IF NOT EXISTS is_adaptive BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE "exam_info" ADD COLUMN IF NOT EXISTS adaptive_max_items INTEGER DEFAULT NULL;
+ALTER TABLE "exam_info" ADD COLUMN IF NOT EXISTS adaptive_target_se DOUBLE PRECISION DEFAULT NULL;
+ALTER TABLE "exam_info" ADD CONSTRAINT chk_adaptive_max_items CHECK (
+    adaptive_max_items IS NULL OR adaptive_max_items > 0
+);
+ALTER TABLE "exam_info" ADD CONSTRAINT chk_adaptive_target_se CHECK (
+    adaptive_target_se IS NULL OR adaptive_target_se > 0
+);
+
+COMMENT ON COLUMN exam_info.is_adaptive IS 'Whether the questions of the exam are given one at a time, picked by the previous answers';
+COMMENT ON COLUMN exam_info.adaptive_max_items IS 'Maximum number of questions given in an adaptive attempt (null means no limit)';
+COMMENT ON COLUMN exam_info.adaptive_target_se IS 'Standard error of the ability estimate at which an adaptive attempt ends (null means no target)';
+
+-- The questions can carry their answer key and their parameters in the
+-- three-parameter logistic (3PL) model of item response theory; only the
+-- questions with an answer key are given in the adaptive exams.
+ALTER TABLE "exam_question" ADD COLUMN IF NOT EXISTS correct_option TEXT DEFAULT NULL;
+ALTER TABLE "exam_question" ADD COLUMN IF NOT EXISTS irt_discrimination DOUBLE PRECISION NOT NULL DEFAULT 1;
+ALTER TABLE "exam_question" ADD COLUMN IF NOT EXISTS irt_difficulty DOUBLE PRECISION NOT NULL DEFAULT 0;
+ALTER TABLE "exam_question" ADD COLUMN IF NOT EXISTS irt_guessing DOUBLE PRECISION NOT NULL DEFAULT 0;
+ALTER TABLE "exam_question" ADD CONSTRAINT chk_irt_params CHECK (
+    irt_discrimination > 0 AND irt_guessing >= 0 AND irt_guessing < 1
+);
+
+COMMENT ON COLUMN exam_question.correct_option IS 'The correct option of the question (null means no answer key)';
+COMMENT ON COLUMN exam_question.irt_discrimination IS 'Discrimination (a) of the question in the 3PL model';
+COMMENT ON COLUMN exam_question.irt_difficulty IS 'Difficulty (b) of the question in the 3PL model';
+COMMENT ON COLUMN exam_question.irt_guessing IS 'Guessing chance (c) of the question in the 3PL model';
+
+ALTER TABLE "exam_attempt" ADD COLUMN IF NOT EXISTS ability_estimate DOUBLE PRECISION DEFAULT NULL;
+ALTER TABLE "exam_attempt" ADD COLUMN IF NOT EXISTS ability_std_error DOUBLE PRECISION DEFAULT NULL;
+
+COMMENT ON COLUMN exam_attempt.ability_estimate IS 'Estimated ability (theta) of the user in an adaptive attempt (can be null)';
+COMMENT ON COLUMN exam_attempt.ability_std_error IS 'Standard error of the ability estimate (can be null)';
+
+-- exam_attempt_item holds the questions given to the adaptive attempts,
+-- in the order they were given.
+CREATE TABLE IF NOT EXISTS "exam_attempt_item" (
+    exam_id INTEGER NOT NULL,
+    user_id UserIdType,
+    attempt_number INTEGER NOT NULL,
+    item_index INTEGER NOT NULL,
+    question_id INTEGER NOT NULL,
+    served_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    is_correct BOOLEAN DEFAULT NULL,
+    answered_at TIMESTAMP WITH TIME ZONE DEFAULT NULL,
+    PRIMARY KEY (exam_id, user_id, attempt_number, item_index),
+
+    CONSTRAINT fk_exam_attempt FOREIGN KEY (exam_id, user_id, attempt_number) REFERENCES "exam_attempt"(exam_id, user_id, attempt_number) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_question_id FOREIGN KEY (question_id) REFERENCES "exam_question"(question_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT unique_exam_attempt_question UNIQUE (exam_id, user_id, attempt_number, question_id)
+);
+
+COMMENT ON TABLE exam_attempt_item IS 'Stores the questions given to the adaptive attempts';
+COMMENT ON COLUMN exam_attempt_item.exam_id IS 'ID of the exam';
+COMMENT ON COLUMN exam_attempt_item.user_id IS 'ID of the user taking the exam';
+COMMENT ON COLUMN exam_attempt_item.attempt_number IS 'Number of the attempt';
+COMMENT ON COLUMN exam_attempt_item.item_index IS 'Position of the question in the attempt (starting from 1)';
+COMMENT ON COLUMN exam_attempt_item.question_id IS 'ID of the given question';
+COMMENT ON COLUMN exam_attempt_item.served_at IS 'Timestamp when the question was given';
+COMMENT ON COLUMN exam_attempt_item.is_correct IS 'Whether the answer was correct (null means not answered yet)';
+COMMENT ON COLUMN exam_attempt_item.answered_at IS 'Timestamp when the question was answered (can be null)';
+
+-- Sets the adaptive settings of the exam.
+-- Example usage:
+--     CALL set_exam_adaptive_settings(
+--         p_exam_id := 1001,
+--         p_is_adaptive := TRUE,
+--         p_max_items := 30,
+--         p_target_se := 0.3
+--     );
+CREATE OR REPLACE PROCEDURE set_exam_adaptive_settings(
+    p_exam_id INTEGER,
+    p_is_adaptive BOOLEAN,
+    p_max_items INTEGER DEFAULT NULL,
+    p_target_se DOUBLE PRECISION DEFAULT NULL
+)
+LANGUAGE plpgsql
+AS $$
+BEGIN
+    UPDATE exam_info
+    SET is_adaptive = p_is_adaptive,
+        adaptive_max_items = p_max_items,
+        adaptive_target_se = p_target_se
+    WHERE exam_id = p_exam_id;
+
+    IF NOT FOUND THEN
+        RAISE EXCEPTION 'Exam with ID % not found', p_exam_id;
+    END IF;
+END;
+$$;
+
+-- Sets the answer key and the IRT parameters of a question.
+-- Example usage:
+--     CALL set_exam_question_irt(
+--         p_question_id := 1,
+--         p_correct_option := 'Paris',
+--         p_discrimination := 1.2,
+--         p_difficulty := -0.5,
+--         p_guessing := 0.25
+--     );
+CREATE OR REPLACE PROCEDURE set_exam_question_irt(
+    p_question_id INTEGER,
+    p_correct_option TEXT,
+    p_discrimination DOUBLE PRECISION DEFAULT 1,
+    p_difficulty DOUBLE PRECISION DEFAULT 0,
+    p_guessing DOUBLE PRECISION DEFAULT 0
+)
+LANGUAGE plpgsql
+AS $$
+BEGIN
+    UPDATE exam_question
+    SET correct_option = p_correct_option,
+        irt_discrimination = p_discrimination,
+        irt_difficulty = p_difficulty,
+        irt_guessing = p_guessing
+    WHERE question_id = p_question_id;
+
+    IF NOT FOUND THEN
+        RAISE EXCEPTION 'Question with ID % not found', p_question_id;
+    END IF;
+END;
+$$;
+
+-- Gives the question to the adaptive attempt as its next item and returns
+-- the index of the item; if the question has already been given to the
+-- attempt, its current index is returned.
+-- Example usage:
+--     SELECT serve_exam_attempt_item(
+--         p_exam_id := 1001,
+--         p_user_id := 'user123',
+--         p_attempt_number := 1,
+--         p_question_id := 12
+--     );
+CREATE OR REPLACE FUNCTION serve_exam_attempt_item(
+    p_exam_id INTEGER,
+    p_user_id UserIdType,
+    p_attempt_number INTEGER,
+    p_question_id INTEGER
+) RETURNS INTEGER AS $$
+DECLARE
+    v_item_index INTEGER;
+BEGIN
+    PERFORM 1 FROM exam_attempt
+    WHERE exam_id = p_exam_id AND user_id = p_user_id AND attempt_number = p_attempt_number
+    FOR UPDATE;
+
+    SELECT item_index INTO v_item_index FROM exam_attempt_item
+    WHERE exam_id = p_exam_id AND user_id = p_user_id
+        AND attempt_number = p_attempt_number AND question_id = p_question_id;
+
+    IF v_item_index IS NOT NULL THEN
+        RETURN v_item_index;
+    END IF;
+
+    SELECT COALESCE(MAX(item_index), 0) + 1 INTO v_item_index FROM exam_attempt_item
+    WHERE exam_id = p_exam_id AND user_id = p_user_id AND attempt_number = p_attempt_number;
+
+    INSERT INTO exam_attempt_item (exam_id, user_id, attempt_number, item_index, question_id)
+    VALUES (p_exam_id, p_user_id, p_attempt_number, v_item_index, p_question_id);
+
+    RETURN v_item_index;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Records the result of the answer to an item of the adaptive attempt,
+-- alongside the new ability estimate of the attempt. Only the first answer
+-- to each item is recorded.
+-- Example usage:
+--     CALL record_exam_attempt_item_answer(
+--         p_exam_id := 1001,
+--         p_user_id := 'user123',
+--         p_attempt_number := 1,
+--         p_question_id := 12,
+--         p_is_correct := TRUE,
+--         p_ability_estimate := 0.42,
+--         p_ability_std_error := 0.61
+--     );
+CREATE OR REPLACE PROCEDURE record_exam_attempt_item_answer(
+    p_exam_id INTEGER,
+    p_user_id UserIdType,
+    p_attempt_number INTEGER,
+    p_question_id INTEGER,
+    p_is_correct BOOLEAN,
+    p_ability_estimate DOUBLE PRECISION,
+    p_ability_std_error DOUBLE PRECISION
+)
+LANGUAGE plpgsql
+AS $$
+BEGIN
+    UPDATE exam_attempt_item
+    SET is_correct = p_is_correct,
+        answered_at = CURRENT_TIMESTAMP
+    WHERE exam_id = p_exam_id AND user_id = p_user_id
+        AND attempt_number = p_attempt_number AND question_id = p_question_id
+        AND is_correct IS NULL;
+
+    IF NOT FOUND THEN
+        RAISE EXCEPTION 'Question % is not waiting for an answer in attempt % of user % in exam %',
+            p_question_id, p_attempt_number, p_user_id, p_exam_id;
+    END IF;
+
+    UPDATE exam_attempt
+    SET ability_estimate = p_ability_estimate,
+        ability_std_error = p_ability_std_error
+    WHERE exam_id = p_exam_id AND user_id = p_user_id AND attempt_number = p_attempt_number;
+END;
+$$;
+
+-- materialise_exam_series_occurrence now copies the adaptive settings of the
+-- template exam, and the answer keys and IRT parameters of its questions.
+-- Example usage:
+--      SELECT materialise_exam_series_occurrence(1, '2024-06-03 10:00:00+00');
+CREATE OR REPLACE FUNCTION materialise_exam_series_occurrence(
+    p_series_id INTEGER,
+    p_occurrence_at TIMESTAMP WITH TIME ZONE
+) RETURNS INTEGER AS $$
+DECLARE
+    v_template_exam_id INTEGER;
+    v_occurrence RECORD;
+    v_section RECORD;
+    v_exam_date TIMESTAMP WITH TIME ZONE;
+    v_shift INTERVAL;
+    new_exam_id INTEGER;
+    new_section_id INTEGER;
+BEGIN
+    SELECT s.template_exam_id INTO v_template_exam_id
+    FROM exam_series s
+    WHERE s.series_id = p_series_id;
+
+    IF v_template_exam_id IS NULL THEN
+        RAISE EXCEPTION 'Exam series with ID % not found', p_series_id;
+    END IF;
+
+    SELECT o.exam_id, o.is_skipped, o.exam_title, o.exam_date, o.duration
+    INTO v_occurrence
+    FROM exam_series_occurrence o
+    WHERE o.series_id = p_series_id AND o.occurrence_at = p_occurrence_at
+    FOR UPDATE;
+
+    IF FOUND AND v_occurrence.exam_id IS NOT NULL THEN
+        RETURN v_occurrence.exam_id;
+    ELSIF FOUND AND v_occurrence.is_skipped THEN
+        RETURN NULL;
+    END IF;
+
+    v_exam_date := COALESCE(v_occurrence.exam_date, p_occurrence_at);
+
+    SELECT v_exam_date - e.exam_date INTO v_shift
+    FROM exam_info e
+    WHERE e.exam_id = v_template_exam_id;
+
+    INSERT INTO exam_info (
+        course_id,
+        exam_title,
+        exam_description,
+        price,
+        exam_date,
+        duration,
+        created_by,
+        is_public,
+        access_code_type,
+        access_code,
+        access_code_interval,
+        max_attempts,
+        attempt_cooldown,
+        grading_policy,
+        opens_at,
+        closes_at,
+        late_start_limit,
+        deadline_policy,
+        grace_period,
+        capacity,
+        is_adaptive,
+        adaptive_max_items,
+        adaptive_target_se
+    )
+    SELECT
+        e.course_id,
+        COALESCE(v_occurrence.exam_title, e.exam_title),
+        e.exam_description,
+        e.price,
+        v_exam_date,
+        COALESCE(v_occurrence.duration, e.duration),
+        e.created_by,
+        e.is_public,
+        e.access_code_type,
+        e.access_code,
+        e.access_code_interval,
+        e.max_attempts,
+        e.attempt_cooldown,
+        e.grading_policy,
+        e.opens_at + v_shift,
+        e.closes_at + v_shift,
+        e.late_start_limit,
+        e.deadline_policy,
+        e.grace_period,
+        e.capacity,
+        e.is_adaptive,
+        e.adaptive_max_items,
+        e.adaptive_target_se
+    FROM exam_info e
+    WHERE e.exam_id = v_template_exam_id
+    RETURNING exam_id INTO new_exam_id;
+
+    FOR v_section IN
+        SELECT * FROM exam_section
+        WHERE exam_id = v_template_exam_id
+        ORDER BY section_order, section_id
+    LOOP
+        new_section_id := create_exam_section(
+            p_exam_id := new_exam_id,
+            p_section_title := v_section.section_title,
+            p_section_order := v_section.section_order,
+            p_duration := v_section.duration,
+            p_is_forward_only := v_section.is_forward_only
+        );
+
+        INSERT INTO exam_question (
+            exam_id,
+            question_title,
+            description,
+            option1,
+            option2,
+            option3,
+            option4,
+            section_id,
+            question_order,
+            correct_option,
+            irt_discrimination,
+            irt_difficulty,
+            irt_guessing
+        )
+        SELECT new_exam_id, q.question_title, q.description, q.option1, q.option2, q.option3, q.option4,
+            new_section_id, q.question_order, q.correct_option,
+            q.irt_discrimination, q.irt_difficulty, q.irt_guessing
+        FROM exam_question q
+        WHERE q.exam_id = v_template_exam_id AND q.section_id = v_section.section_id
+        ORDER BY q.question_order, q.question_id;
+    END LOOP;
+
+    INSERT INTO exam_question (
+        exam_id,
+        question_title,
+        description,
+        option1,
+        option2,
+        option3,
+        option4,
+        question_order,
+        correct_option,
+        irt_discrimination,
+        irt_difficulty,
+        irt_guessing
+    )
+    SELECT new_exam_id, q.question_title, q.description, q.option1, q.option2, q.option3, q.option4,
+        q.question_order, q.correct_option,
+        q.irt_discrimination, q.irt_difficulty, q.irt_guessing
+    FROM exam_question q
+    WHERE q.exam_id = v_template_exam_id AND q.section_id IS NULL
+    ORDER BY q.question_order, q.question_id;
+
+    INSERT INTO exam_prerequisite (
+        exam_id,
+        prerequisite_type,
+        required_exam_id,
+        min_score,
+        topic_id,
+        min_level,
+        added_by
+    )
+    SELECT new_exam_id, p.prerequisite_type, p.required_exam_id, p.min_score, p.topic_id, p.min_level, p.added_by
+    FROM exam_prerequisite p
+    WHERE p.exam_id = v_template_exam_id;
+
+    INSERT INTO exam_series_occurrence (series_id, occurrence_at, exam_id)
+    VALUES (p_series_id, p_occurrence_at, new_exam_id)
+    ON CONFLICT (series_id, occurrence_at)
+    DO UPDATE SET
+        exam_id = EXCLUDED.exam_id,
+        updated_at = CURRENT_TIMESTAMP;
+
+    RETURN new_exam_id;
+END;
+$$ LANGUAGE plpgsql;
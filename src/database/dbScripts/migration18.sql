@@ -0,0 +1,125 @@
+-- attachment holds the files (images, audio and pdf) attached to the
+-- questions, to the options of the questions, or uploaded by the users as
+-- their answers. The files themselves are kept in the storage backend set in
+-- the config file; only their metadata is stored here.
+CREATE TABLE IF NOT EXISTS "attachment" (
+    attachment_id SERIAL PRIMARY KEY,
+    attachment_type VARCHAR(15) NOT NULL,
+    exam_id INTEGER NOT NULL,
+    question_id INTEGER NOT NULL,
+    option_number SMALLINT DEFAULT NULL,
+    answered_by VARCHAR(16) DEFAULT NULL,
+    attempt_number INTEGER DEFAULT NULL,
+    storage_backend VARCHAR(32) NOT NULL,
+    storage_key VARCHAR(255) NOT NULL UNIQUE,
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(127) NOT NULL,
+    file_size BIGINT NOT NULL,
+    uploaded_by VARCHAR(16) DEFAULT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_exam_id FOREIGN KEY (exam_id) REFERENCES "exam_info"(exam_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_question_id FOREIGN KEY (question_id) REFERENCES "exam_question"(question_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_answered_by FOREIGN KEY (answered_by) REFERENCES "user_info"(user_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_uploaded_by FOREIGN KEY (uploaded_by) REFERENCES "user_info"(user_id) ON DELETE SET NULL ON UPDATE CASCADE,
+    CONSTRAINT chk_attachment_type CHECK (attachment_type IN ('question', 'option', 'answer')),
+    CONSTRAINT chk_attachment_target CHECK (
+        (attachment_type = 'question' AND option_number IS NULL AND answered_by IS NULL) OR
+        (attachment_type = 'option' AND option_number BETWEEN 1 AND 4 AND answered_by IS NULL) OR
+        (attachment_type = 'answer' AND option_number IS NULL AND answered_by IS NOT NULL AND attempt_number IS NOT NULL)
+    ),
+    CONSTRAINT chk_file_size CHECK (file_size >= 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_attachment_question ON "attachment" (question_id, attachment_type);
+
+COMMENT ON TABLE attachment IS 'Stores the metadata of the files attached to the questions and the answers';
+COMMENT ON COLUMN attachment.attachment_id IS 'Unique identifier for the attachment';
+COMMENT ON COLUMN attachment.attachment_type IS 'What the file is attached to (question, option or answer)';
+COMMENT ON COLUMN attachment.exam_id IS 'ID of the exam';
+COMMENT ON COLUMN attachment.question_id IS 'ID of the question';
+COMMENT ON COLUMN attachment.option_number IS 'Number of the option (1-4) for the option attachments';
+COMMENT ON COLUMN attachment.answered_by IS 'ID of the user who uploaded the file as their answer';
+COMMENT ON COLUMN attachment.attempt_number IS 'Number of the attempt the answer was uploaded in';
+COMMENT ON COLUMN attachment.storage_backend IS 'Name of the storage backend the file is kept in';
+COMMENT ON COLUMN attachment.storage_key IS 'Key of the file in the storage backend';
+COMMENT ON COLUMN attachment.file_name IS 'Original name of the file';
+COMMENT ON COLUMN attachment.content_type IS 'Detected content type of the file';
+COMMENT ON COLUMN attachment.file_size IS 'Size of the file in bytes';
+COMMENT ON COLUMN attachment.uploaded_by IS 'ID of the user who uploaded the file (can be null)';
+COMMENT ON COLUMN attachment.created_at IS 'Timestamp when the file was uploaded';
+
+-- Creates a new attachment and returns its ID; the question has to belong
+-- to the exam.
+-- Example usage:
+--     SELECT create_attachment(
+--         p_attachment_type := 'option',
+--         p_exam_id := 1001,
+--         p_question_id := 12,
+--         p_option_number := 2,
+--         p_answered_by := NULL,
+--         p_attempt_number := NULL,
+--         p_storage_backend := 'local',
+--         p_storage_key := 'exams/1001/3f2a9c.png',
+--         p_file_name := 'graph.png',
+--         p_content_type := 'image/png',
+--         p_file_size := 48213,
+--         p_uploaded_by := 'teacher1'
+--     );
+CREATE OR REPLACE FUNCTION create_attachment(
+    p_attachment_type VARCHAR(15),
+    p_exam_id INTEGER,
+    p_question_id INTEGER,
+    p_option_number SMALLINT,
+    p_answered_by VARCHAR(16),
+    p_attempt_number INTEGER,
+    p_storage_backend VARCHAR(32),
+    p_storage_key VARCHAR(255),
+    p_file_name VARCHAR(255),
+    p_content_type VARCHAR(127),
+    p_file_size BIGINT,
+    p_uploaded_by VARCHAR(16) DEFAULT NULL
+) RETURNS INTEGER AS $$
+DECLARE
+    new_attachment_id INTEGER;
+BEGIN
+    IF NOT EXISTS (
+        SELECT 1 FROM exam_question
+        WHERE question_id = p_question_id AND exam_id = p_exam_id
+    ) THEN
+        RAISE EXCEPTION 'Question % does not belong to exam %', p_question_id, p_exam_id;
+    END IF;
+
+    INSERT INTO attachment (
+        attachment_type,
+        exam_id,
+        question_id,
+        option_number,
+        answered_by,
+        attempt_number,
+        storage_backend,
+        storage_key,
+        file_name,
+        content_type,
+        file_size,
+        uploaded_by
+    )
+    VALUES (
+        p_attachment_type,
+        p_exam_id,
+        p_question_id,
+        p_option_number,
+        p_answered_by,
+        p_attempt_number,
+        p_storage_backend,
+        p_storage_key,
+        p_file_name,
+        p_content_type,
+        p_file_size,
+        p_uploaded_by
+    )
+    RETURNING attachment_id INTO new_attachment_id;
+
+    RETURN new_attachment_id;
+END;
+$$ LANGUAGE plpgsql;
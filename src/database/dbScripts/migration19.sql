@@ -0,0 +1,255 @@
+-- The title, the description and the options of the questions can be
+-- written in Markdown (optionally with LaTeX formulas) instead of plain text.
+-- The content is sanitised by the server before being stored; the format
+-- tells the clients (and the server) how it has to be rendered.
+ALTER TABLE "exam_question" ADD COLUMN IF NOT EXISTS content_format VARCHAR(20) NOT NULL DEFAULT 'plain';
+ALTER TABLE "exam_question" ADD CONSTRAINT chk_content_format CHECK (
+    content_format IN ('plain', 'markdown', 'markdown_latex')
+);
+
+COMMENT ON COLUMN exam_question.content_format IS 'Format of the content of the question (plain, markdown or markdown_latex)';
+
+DO
+$$
+DECLARE
+    r RECORD;
+BEGIN
+    FOR r IN SELECT proname, prokind, pg_get_function_identity_arguments(p.oid) AS args
+             FROM pg_proc p
+             JOIN pg_namespace n ON p.pronamespace = n.oid
+             WHERE n.nspname NOT IN ('pg_catalog', 'information_schema')
+             AND pg_function_is_visible(p.oid)
+             AND proname IN ('create_exam_question')
+    LOOP
+        IF r.prokind = 'p' THEN
+            EXECUTE format('DROP PROCEDURE IF EXISTS %I(%s);', r.proname, r.args);
+        ELSE
+            EXECUTE format('DROP FUNCTION IF EXISTS %I(%s);', r.proname, r.args);
+        END IF;
+    END LOOP;
+END
+$$;
+
+-- Function to create a single exam question, optionally inside a section.
+-- Returns the question_id of the newly created question.
+-- Example usage:
+--      SELECT create_exam_question(
+--         p_exam_id := 1234,
+--         p_question_title := 'What is $\sqrt{16}$?',
+--         p_description := 'Choose the **correct** option from the following.',
+--         p_option1 := '$2$',
+--         p_option2 := '$4$',
+--         p_option3 := '$8$',
+--         p_option4 := '$16$',
+--         p_section_id := 1,
+--         p_question_order := 3,
+--         p_content_format := 'markdown_latex'
+--      );
+CREATE OR REPLACE FUNCTION create_exam_question(
+    p_exam_id INTEGER,
+    p_question_title VARCHAR(2048),
+    p_description TEXT DEFAULT NULL,
+    p_option1 TEXT DEFAULT NULL,
+    p_option2 TEXT DEFAULT NULL,
+    p_option3 TEXT DEFAULT NULL,
+    p_option4 TEXT DEFAULT NULL,
+    p_section_id INTEGER DEFAULT NULL,
+    p_question_order INTEGER DEFAULT 0,
+    p_content_format VARCHAR(20) DEFAULT 'plain'
+) RETURNS INTEGER AS $$
+DECLARE
+    new_question_id INTEGER;
+BEGIN
+    IF p_section_id IS NOT NULL AND NOT EXISTS (
+        SELECT 1 FROM exam_section
+        WHERE section_id = p_section_id AND exam_id = p_exam_id
+    ) THEN
+        RAISE EXCEPTION 'Section % does not belong to exam %', p_section_id, p_exam_id;
+    END IF;
+
+    INSERT INTO exam_question (exam_id, question_title, description, option1, option2, option3, option4, section_id, question_order, content_format)
+    VALUES (p_exam_id, p_question_title, p_description, p_option1, p_option2, p_option3, p_option4, p_section_id, p_question_order, p_content_format)
+    RETURNING question_id INTO new_question_id;
+
+    RETURN new_question_id;
+END;
+$$ LANGUAGE plpgsql;
+
+-- materialise_exam_series_occurrence now copies the content format of the
+-- questions of the template exam.
+-- Example usage:
+--      SELECT materialise_exam_series_occurrence(1, '2024-06-03 10:00:00+00');
+CREATE OR REPLACE FUNCTION materialise_exam_series_occurrence(
+    p_series_id INTEGER,
+    p_occurrence_at TIMESTAMP WITH TIME ZONE
+) RETURNS INTEGER AS $$
+DECLARE
+    v_template_exam_id INTEGER;
+    v_occurrence RECORD;
+    v_section RECORD;
+    v_exam_date TIMESTAMP WITH TIME ZONE;
+    v_shift INTERVAL;
+    new_exam_id INTEGER;
+    new_section_id INTEGER;
+BEGIN
+    SELECT s.template_exam_id INTO v_template_exam_id
+    FROM exam_series s
+    WHERE s.series_id = p_series_id;
+
+    IF v_template_exam_id IS NULL THEN
+        RAISE EXCEPTION 'Exam series with ID % not found', p_series_id;
+    END IF;
+
+    SELECT o.exam_id, o.is_skipped, o.exam_title, o.exam_date, o.duration
+    INTO v_occurrence
+    FROM exam_series_occurrence o
+    WHERE o.series_id = p_series_id AND o.occurrence_at = p_occurrence_at
+    FOR UPDATE;
+
+    IF FOUND AND v_occurrence.exam_id IS NOT NULL THEN
+        RETURN v_occurrence.exam_id;
+    ELSIF FOUND AND v_occurrence.is_skipped THEN
+        RETURN NULL;
+    END IF;
+
+    v_exam_date := COALESCE(v_occurrence.exam_date, p_occurrence_at);
+
+    SELECT v_exam_date - e.exam_date INTO v_shift
+    FROM exam_info e
+    WHERE e.exam_id = v_template_exam_id;
+
+    INSERT INTO exam_info (
+        course_id,
+        exam_title,
+        exam_description,
+        price,
+        exam_date,
+        duration,
+        created_by,
+        is_public,
+        access_code_type,
+        access_code,
+        access_code_interval,
+        max_attempts,
+        attempt_cooldown,
+        grading_policy,
+        opens_at,
+        closes_at,
+        late_start_limit,
+        deadline_policy,
+        grace_period,
+        capacity,
+        is_adaptive,
+        adaptive_max_items,
+        adaptive_target_se
+    )
+    SELECT
+        e.course_id,
+        COALESCE(v_occurrence.exam_title, e.exam_title),
+        e.exam_description,
+        e.price,
+        v_exam_date,
+        COALESCE(v_occurrence.duration, e.duration),
+        e.created_by,
+        e.is_public,
+        e.access_code_type,
+        e.access_code,
+        e.access_code_interval,
+        e.max_attempts,
+        e.attempt_cooldown,
+        e.grading_policy,
+        e.opens_at + v_shift,
+        e.closes_at + v_shift,
+        e.late_start_limit,
+        e.deadline_policy,
+        e.grace_period,
+        e.capacity,
+        e.is_adaptive,
+        e.adaptive_max_items,
+        e.adaptive_target_se
+    FROM exam_info e
+    WHERE e.exam_id = v_template_exam_id
+    RETURNING exam_id INTO new_exam_id;
+
+    FOR v_section IN
+        SELECT * FROM exam_section
+        WHERE exam_id = v_template_exam_id
+        ORDER BY section_order, section_id
+    LOOP
+        new_section_id := create_exam_section(
+            p_exam_id := new_exam_id,
+            p_section_title := v_section.section_title,
+            p_section_order := v_section.section_order,
+            p_duration := v_section.duration,
+            p_is_forward_only := v_section.is_forward_only
+        );
+
+        INSERT INTO exam_question (
+            exam_id,
+            question_title,
+            description,
+            option1,
+            option2,
+            option3,
+            option4,
+            section_id,
+            question_order,
+            correct_option,
+            irt_discrimination,
+            irt_difficulty,
+            irt_guessing,
+            content_format
+        )
+        SELECT new_exam_id, q.question_title, q.description, q.option1, q.option2, q.option3, q.option4,
+            new_section_id, q.question_order, q.correct_option,
+            q.irt_discrimination, q.irt_difficulty, q.irt_guessing, q.content_format
+        FROM exam_question q
+        WHERE q.exam_id = v_template_exam_id AND q.section_id = v_section.section_id
+        ORDER BY q.question_order, q.question_id;
+    END LOOP;
+
+    INSERT INTO exam_question (
+        exam_id,
+        question_title,
+        description,
+        option1,
+        option2,
+        option3,
+        option4,
+        question_order,
+        correct_option,
+        irt_discrimination,
+        irt_difficulty,
+        irt_guessing,
+        content_format
+    )
+    SELECT new_exam_id, q.question_title, q.description, q.option1, q.option2, q.option3, q.option4,
+        q.question_order, q.correct_option,
+        q.irt_discrimination, q.irt_difficulty, q.irt_guessing, q.content_format
+    FROM exam_question q
+    WHERE q.exam_id = v_template_exam_id AND q.section_id IS NULL
+    ORDER BY q.question_order, q.question_id;
+
+    INSERT INTO exam_prerequisite (
+        exam_id,
+        prerequisite_type,
+        required_exam_id,
+        min_score,
+        topic_id,
+        min_level,
+        added_by
+    )
+    SELECT new_exam_id, p.prerequisite_type, p.required_exam_id, p.min_score, p.topic_id, p.min_level, p.added_by
+    FROM exam_prerequisite p
+    WHERE p.exam_id = v_template_exam_id;
+
+    INSERT INTO exam_series_occurrence (series_id, occurrence_at, exam_id)
+    VALUES (p_series_id, p_occurrence_at, new_exam_id)
+    ON CONFLICT (series_id, occurrence_at)
+    DO UPDATE SET
+        exam_id = EXCLUDED.exam_id,
+        updated_at = CURRENT_TIMESTAMP;
+
+    RETURN new_exam_id;
+END;
+$$ LANGUAGE plpgsql;
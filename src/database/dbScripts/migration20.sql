@@ -0,0 +1,200 @@
+-- similarity_check holds the similarity (plagiarism) checks the teachers
+-- run on the text answers given to a question; the results are stored so
+-- they can be reviewed without being computed again.
+CREATE TABLE IF NOT EXISTS "similarity_check" (
+    check_id SERIAL PRIMARY KEY,
+    exam_id INTEGER NOT NULL,
+    question_id INTEGER NOT NULL,
+    requested_by VARCHAR(16) DEFAULT NULL,
+    check_status VARCHAR(15) NOT NULL DEFAULT 'running',
+    shingle_size INTEGER NOT NULL,
+    threshold DOUBLE PRECISION NOT NULL,
+    answers_count INTEGER NOT NULL DEFAULT 0,
+    matches_count INTEGER NOT NULL DEFAULT 0,
+    error_message TEXT DEFAULT NULL,
+    started_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    finished_at TIMESTAMP WITH TIME ZONE DEFAULT NULL,
+
+    CONSTRAINT fk_exam_id FOREIGN KEY (exam_id) REFERENCES "exam_info"(exam_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_question_id FOREIGN KEY (question_id) REFERENCES "exam_question"(question_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_requested_by FOREIGN KEY (requested_by) REFERENCES "user_info"(user_id) ON DELETE SET NULL ON UPDATE CASCADE,
+    CONSTRAINT chk_check_status CHECK (check_status IN ('running', 'completed', 'failed')),
+    CONSTRAINT chk_similarity_threshold CHECK (threshold > 0 AND threshold <= 1)
+);
+
+CREATE INDEX IF NOT EXISTS idx_similarity_check_question ON "similarity_check" (question_id, started_at DESC);
+
+COMMENT ON TABLE similarity_check IS 'Stores the similarity checks of the text answers of the questions';
+COMMENT ON COLUMN similarity_check.check_id IS 'Unique identifier for the check';
+COMMENT ON COLUMN similarity_check.exam_id IS 'ID of the exam';
+COMMENT ON COLUMN similarity_check.question_id IS 'ID of the question whose answers are compared';
+COMMENT ON COLUMN similarity_check.requested_by IS 'ID of the user who started the check (can be null)';
+COMMENT ON COLUMN similarity_check.check_status IS 'Status of the check (running, completed or failed)';
+COMMENT ON COLUMN similarity_check.shingle_size IS 'Number of the words in the compared shingles';
+COMMENT ON COLUMN similarity_check.threshold IS 'Similarity from which the pairs of answers are reported';
+COMMENT ON COLUMN similarity_check.answers_count IS 'Number of the compared answers';
+COMMENT ON COLUMN similarity_check.matches_count IS 'Number of the reported pairs of answers';
+COMMENT ON COLUMN similarity_check.error_message IS 'Why the check has failed (can be null)';
+COMMENT ON COLUMN similarity_check.started_at IS 'Timestamp when the check was started';
+COMMENT ON COLUMN similarity_check.finished_at IS 'Timestamp when the check was finished (can be null)';
+
+-- similarity_match holds the pairs of answers reported by the checks.
+CREATE TABLE IF NOT EXISTS "similarity_match" (
+    match_id SERIAL PRIMARY KEY,
+    check_id INTEGER NOT NULL,
+    first_user_id UserIdType,
+    first_attempt INTEGER NOT NULL,
+    second_user_id UserIdType,
+    second_attempt INTEGER NOT NULL,
+    similarity DOUBLE PRECISION NOT NULL,
+    containment DOUBLE PRECISION NOT NULL,
+    passages JSONB NOT NULL DEFAULT '[]',
+
+    CONSTRAINT fk_check_id FOREIGN KEY (check_id) REFERENCES "similarity_check"(check_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_first_user_id FOREIGN KEY (first_user_id) REFERENCES "user_info"(user_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_second_user_id FOREIGN KEY (second_user_id) REFERENCES "user_info"(user_id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_similarity_match_check ON "similarity_match" (check_id, similarity DESC);
+
+COMMENT ON TABLE similarity_match IS 'Stores the pairs of answers reported by the similarity checks';
+COMMENT ON COLUMN similarity_match.match_id IS 'Unique identifier for the match';
+COMMENT ON COLUMN similarity_match.check_id IS 'ID of the check';
+COMMENT ON COLUMN similarity_match.first_user_id IS 'ID of the user who gave the first answer';
+COMMENT ON COLUMN similarity_match.first_attempt IS 'Number of the attempt of the first answer';
+COMMENT ON COLUMN similarity_match.second_user_id IS 'ID of the user who gave the second answer';
+COMMENT ON COLUMN similarity_match.second_attempt IS 'Number of the attempt of the second answer';
+COMMENT ON COLUMN similarity_match.similarity IS 'Jaccard index of the shingles of the answers';
+COMMENT ON COLUMN similarity_match.containment IS 'Part of the shingles of the shorter answer found in the other one';
+COMMENT ON COLUMN similarity_match.passages IS 'Overlapping passages of the answers, as byte offsets and the text of each side';
+
+-- Starts a new similarity check on the answers of the question and returns
+-- its ID. Only one check of a question can be running at a time; the checks
+-- which have been running for more than an hour are considered interrupted
+-- (e.g. by a restart) and are marked as failed. An exception with ERRCODE
+-- 'EXC03' is raised if another check of the question is running.
+-- Example usage:
+--     SELECT start_similarity_check(
+--         p_exam_id := 1001,
+--         p_question_id := 12,
+--         p_requested_by := 'teacher1',
+--         p_shingle_size := 5,
+--         p_threshold := 0.4
+--     );
+CREATE OR REPLACE FUNCTION start_similarity_check(
+    p_exam_id INTEGER,
+    p_question_id INTEGER,
+    p_requested_by VARCHAR(16),
+    p_shingle_size INTEGER,
+    p_threshold DOUBLE PRECISION
+) RETURNS INTEGER AS $$
+DECLARE
+    new_check_id INTEGER;
+BEGIN
+    IF NOT EXISTS (
+        SELECT 1 FROM exam_question
+        WHERE question_id = p_question_id AND exam_id = p_exam_id
+    ) THEN
+        RAISE EXCEPTION 'Question % does not belong to exam %', p_question_id, p_exam_id;
+    END IF;
+
+    -- serialise the checks of the question
+    PERFORM 1 FROM exam_question WHERE question_id = p_question_id FOR UPDATE;
+
+    UPDATE similarity_check
+    SET check_status = 'failed',
+        error_message = 'The check was interrupted',
+        finished_at = CURRENT_TIMESTAMP
+    WHERE question_id = p_question_id AND check_status = 'running'
+        AND started_at < CURRENT_TIMESTAMP - INTERVAL '1 hour';
+
+    IF EXISTS (
+        SELECT 1 FROM similarity_check
+        WHERE question_id = p_question_id AND check_status = 'running'
+    ) THEN
+        RAISE EXCEPTION 'Another similarity check of question % is running', p_question_id
+            USING ERRCODE = 'EXC03';
+    END IF;
+
+    INSERT INTO similarity_check (exam_id, question_id, requested_by, shingle_size, threshold)
+    VALUES (p_exam_id, p_question_id, p_requested_by, p_shingle_size, p_threshold)
+    RETURNING check_id INTO new_check_id;
+
+    RETURN new_check_id;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Stores the results of a running similarity check and marks it as
+-- completed; p_matches is a json array of the reported pairs.
+-- Example usage:
+--     SELECT complete_similarity_check(
+--         p_check_id := 1,
+--         p_answers_count := 30,
+--         p_matches := '[{"first_user_id": "student1", "first_attempt": 1,
+--             "second_user_id": "student2", "second_attempt": 1,
+--             "similarity": 0.82, "containment": 0.9, "passages": []}]'
+--     );
+CREATE OR REPLACE FUNCTION complete_similarity_check(
+    p_check_id INTEGER,
+    p_answers_count INTEGER,
+    p_matches JSONB
+) RETURNS VOID AS $$
+DECLARE
+    v_matches_count INTEGER;
+BEGIN
+    PERFORM 1 FROM similarity_check
+    WHERE check_id = p_check_id AND check_status = 'running'
+    FOR UPDATE;
+
+    IF NOT FOUND THEN
+        RAISE EXCEPTION 'Similarity check % is not running', p_check_id;
+    END IF;
+
+    INSERT INTO similarity_match (
+        check_id,
+        first_user_id,
+        first_attempt,
+        second_user_id,
+        second_attempt,
+        similarity,
+        containment,
+        passages
+    )
+    SELECT p_check_id, m.first_user_id, m.first_attempt, m.second_user_id, m.second_attempt,
+        m.similarity, m.containment, COALESCE(m.passages, '[]')
+    FROM jsonb_to_recordset(COALESCE(p_matches, '[]')) AS m(
+        first_user_id VARCHAR(16),
+        first_attempt INTEGER,
+        second_user_id VARCHAR(16),
+        second_attempt INTEGER,
+        similarity DOUBLE PRECISION,
+        containment DOUBLE PRECISION,
+        passages JSONB
+    );
+
+    GET DIAGNOSTICS v_matches_count = ROW_COUNT;
+
+    UPDATE similarity_check
+    SET check_status = 'completed',
+        answers_count = p_answers_count,
+        matches_count = v_matches_count,
+        finished_at = CURRENT_TIMESTAMP
+    WHERE check_id = p_check_id;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Marks a running similarity check as failed.
+-- Example usage:
+--     SELECT fail_similarity_check(1, 'failed to get the answers');
+CREATE OR REPLACE FUNCTION fail_similarity_check(
+    p_check_id INTEGER,
+    p_error_message TEXT
+) RETURNS VOID AS $$
+BEGIN
+    UPDATE similarity_check
+    SET check_status = 'failed',
+        error_message = p_error_message,
+        finished_at = CURRENT_TIMESTAMP
+    WHERE check_id = p_check_id AND check_status = 'running';
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,255 @@
+-- The questions can be tagged and annotated with a difficulty level, the
+-- learning objectives they assess and the topic they belong to, so they
+-- can be searched across the exams (e.g. "all the algebra questions of
+-- medium difficulty").
+ALTER TABLE "exam_question" ADD COLUMN IF NOT EXISTS tags VARCHAR(32)[] NOT NULL DEFAULT '{}';
+ALTER TABLE "exam_question" ADD COLUMN IF NOT EXISTS difficulty_level VARCHAR(10) DEFAULT NULL;
+ALTER TABLE "exam_question" ADD COLUMN IF NOT EXISTS learning_objectives TEXT[] NOT NULL DEFAULT '{}';
+ALTER TABLE "exam_question" ADD COLUMN IF NOT EXISTS topic_id INTEGER DEFAULT NULL;
+ALTER TABLE "exam_question" ADD CONSTRAINT chk_difficulty_level CHECK (
+    difficulty_level IN ('easy', 'medium', 'hard')
+);
+ALTER TABLE "exam_question" ADD CONSTRAINT fk_question_topic_id FOREIGN KEY (topic_id)
+    REFERENCES "topic_info"(topic_id) ON DELETE SET NULL ON UPDATE CASCADE;
+
+-- The search vector of the title and the description of the question; the
+-- 'simple' configuration is used since the questions can be written in any
+-- language.
+ALTER TABLE "exam_question" ADD COLUMN IF NOT EXISTS search_vector TSVECTOR
+    GENERATED ALWAYS AS (
+        to_tsvector('simple', question_title || ' ' || COALESCE(description, ''))
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_exam_question_tags ON "exam_question" USING GIN (tags);
+CREATE INDEX IF NOT EXISTS idx_exam_question_search_vector ON "exam_question" USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS idx_exam_question_difficulty_level ON "exam_question" (difficulty_level);
+CREATE INDEX IF NOT EXISTS idx_exam_question_topic_id ON "exam_question" (topic_id);
+
+COMMENT ON COLUMN exam_question.tags IS 'Tags of the question (lowercase)';
+COMMENT ON COLUMN exam_question.difficulty_level IS 'Difficulty level of the question (easy, medium or hard)';
+COMMENT ON COLUMN exam_question.learning_objectives IS 'Learning objectives assessed by the question';
+COMMENT ON COLUMN exam_question.topic_id IS 'Topic the question belongs to';
+COMMENT ON COLUMN exam_question.search_vector IS 'Full-text search vector of the title and the description of the question';
+
+-- Sets the tags, the difficulty level, the learning objectives and the
+-- topic of a question.
+-- Example usage:
+--     CALL set_exam_question_metadata(
+--         p_question_id := 1,
+--         p_tags := ARRAY['algebra', 'equations'],
+--         p_difficulty_level := 'medium',
+--         p_learning_objectives := ARRAY['Solve linear equations'],
+--         p_topic_id := 3
+--     );
+CREATE OR REPLACE PROCEDURE set_exam_question_metadata(
+    p_question_id INTEGER,
+    p_tags VARCHAR(32)[] DEFAULT '{}',
+    p_difficulty_level VARCHAR(10) DEFAULT NULL,
+    p_learning_objectives TEXT[] DEFAULT '{}',
+    p_topic_id INTEGER DEFAULT NULL
+)
+LANGUAGE plpgsql
+AS $$
+BEGIN
+    UPDATE exam_question
+    SET tags = COALESCE(p_tags, '{}'),
+        difficulty_level = p_difficulty_level,
+        learning_objectives = COALESCE(p_learning_objectives, '{}'),
+        topic_id = p_topic_id
+    WHERE question_id = p_question_id;
+
+    IF NOT FOUND THEN
+        RAISE EXCEPTION 'Question with ID % not found', p_question_id;
+    END IF;
+END;
+$$;
+
+-- materialise_exam_series_occurrence now copies the tags, the difficulty
+-- level, the learning objectives and the topic of the questions of the
+-- template exam.
+-- Example usage:
+--      SELECT materialise_exam_series_occurrence(1, '2024-06-03 10:00:00+00');
+CREATE OR REPLACE FUNCTION materialise_exam_series_occurrence(
+    p_series_id INTEGER,
+    p_occurrence_at TIMESTAMP WITH TIME ZONE
+) RETURNS INTEGER AS $$
+DECLARE
+    v_template_exam_id INTEGER;
+    v_occurrence RECORD;
+    v_section RECORD;
+    v_exam_date TIMESTAMP WITH TIME ZONE;
+    v_shift INTERVAL;
+    new_exam_id INTEGER;
+    new_section_id INTEGER;
+BEGIN
+    SELECT s.template_exam_id INTO v_template_exam_id
+    FROM exam_series s
+    WHERE s.series_id = p_series_id;
+
+    IF v_template_exam_id IS NULL THEN
+        RAISE EXCEPTION 'Exam series with ID % not found', p_series_id;
+    END IF;
+
+    SELECT o.exam_id, o.is_skipped, o.exam_title, o.exam_date, o.duration
+    INTO v_occurrence
+    FROM exam_series_occurrence o
+    WHERE o.series_id = p_series_id AND o.occurrence_at = p_occurrence_at
+    FOR UPDATE;
+
+    IF FOUND AND v_occurrence.exam_id IS NOT NULL THEN
+        RETURN v_occurrence.exam_id;
+    ELSIF FOUND AND v_occurrence.is_skipped THEN
+        RETURN NULL;
+    END IF;
+
+    v_exam_date := COALESCE(v_occurrence.exam_date, p_occurrence_at);
+
+    SELECT v_exam_date - e.exam_date INTO v_shift
+    FROM exam_info e
+    WHERE e.exam_id = v_template_exam_id;
+
+    INSERT INTO exam_info (
+        course_id,
+        exam_title,
+        exam_description,
+        price,
+        exam_date,
+        duration,
+        created_by,
+        is_public,
+        access_code_type,
+        access_code,
+        access_code_interval,
+        max_attempts,
+        attempt_cooldown,
+        grading_policy,
+        opens_at,
+        closes_at,
+        late_start_limit,
+        deadline_policy,
+        grace_period,
+        capacity,
+        is_adaptive,
+        adaptive_max_items,
+        adaptive_target_se
+    )
+    SELECT
+        e.course_id,
+        COALESCE(v_occurrence.exam_title, e.exam_title),
+        e.exam_description,
+        e.price,
+        v_exam_date,
+        COALESCE(v_occurrence.duration, e.duration),
+        e.created_by,
+        e.is_public,
+        e.access_code_type,
+        e.access_code,
+        e.access_code_interval,
+        e.max_attempts,
+        e.attempt_cooldown,
+        e.grading_policy,
+        e.opens_at + v_shift,
+        e.closes_at + v_shift,
+        e.late_start_limit,
+        e.deadline_policy,
+        e.grace_period,
+        e.capacity,
+        e.is_adaptive,
+        e.adaptive_max_items,
+        e.adaptive_target_se
+    FROM exam_info e
+    WHERE e.exam_id = v_template_exam_id
+    RETURNING exam_id INTO new_exam_id;
+
+    FOR v_section IN
+        SELECT * FROM exam_section
+        WHERE exam_id = v_template_exam_id
+        ORDER BY section_order, section_id
+    LOOP
+        new_section_id := create_exam_section(
+            p_exam_id := new_exam_id,
+            p_section_title := v_section.section_title,
+            p_section_order := v_section.section_order,
+            p_duration := v_section.duration,
+            p_is_forward_only := v_section.is_forward_only
+        );
+
+        INSERT INTO exam_question (
+            exam_id,
+            question_title,
+            description,
+            option1,
+            option2,
+            option3,
+            option4,
+            section_id,
+            question_order,
+            correct_option,
+            irt_discrimination,
+            irt_difficulty,
+            irt_guessing,
+            content_format,
+            tags,
+            difficulty_level,
+            learning_objectives,
+            topic_id
+        )
+        SELECT new_exam_id, q.question_title, q.description, q.option1, q.option2, q.option3, q.option4,
+            new_section_id, q.question_order, q.correct_option,
+            q.irt_discrimination, q.irt_difficulty, q.irt_guessing, q.content_format,
+            q.tags, q.difficulty_level, q.learning_objectives, q.topic_id
+        FROM exam_question q
+        WHERE q.exam_id = v_template_exam_id AND q.section_id = v_section.section_id
+        ORDER BY q.question_order, q.question_id;
+    END LOOP;
+
+    INSERT INTO exam_question (
+        exam_id,
+        question_title,
+        description,
+        option1,
+        option2,
+        option3,
+        option4,
+        question_order,
+        correct_option,
+        irt_discrimination,
+        irt_difficulty,
+        irt_guessing,
+        content_format,
+        tags,
+        difficulty_level,
+        learning_objectives,
+        topic_id
+    )
+    SELECT new_exam_id, q.question_title, q.description, q.option1, q.option2, q.option3, q.option4,
+        q.question_order, q.correct_option,
+        q.irt_discrimination, q.irt_difficulty, q.irt_guessing, q.content_format,
+        q.tags, q.difficulty_level, q.learning_objectives, q.topic_id
+    FROM exam_question q
+    WHERE q.exam_id = v_template_exam_id AND q.section_id IS NULL
+    ORDER BY q.question_order, q.question_id;
+
+    INSERT INTO exam_prerequisite (
+        exam_id,
+        prerequisite_type,
+        required_exam_id,
+        min_score,
+        topic_id,
+        min_level,
+        added_by
+    )
+    SELECT new_exam_id, p.prerequisite_type, p.required_exam_id, p.min_score, p.topic_id, p.min_level, p.added_by
+    FROM exam_prerequisite p
+    WHERE p.exam_id = v_template_exam_id;
+
+    INSERT INTO exam_series_occurrence (series_id, occurrence_at, exam_id)
+    VALUES (p_series_id, p_occurrence_at, new_exam_id)
+    ON CONFLICT (series_id, occurrence_at)
+    DO UPDATE SET
+        exam_id = EXCLUDED.exam_id,
+        updated_at = CURRENT_TIMESTAMP;
+
+    RETURN new_exam_id;
+END;
+$$ LANGUAGE plpgsql;
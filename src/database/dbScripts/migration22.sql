@@ -0,0 +1,346 @@
+-- The exams go through a review before they become visible to the
+-- students: draft -> in_review -> published. A reviewer (an admin, or a
+-- teacher marked as an exam reviewer) approves the exam or sends it back
+-- to draft. The existing exams are considered already published.
+ALTER TABLE "exam_info" ADD COLUMN IF NOT EXISTS review_status VARCHAR(16) NOT NULL DEFAULT 'published';
+ALTER TABLE "exam_info" ALTER COLUMN review_status SET DEFAULT 'draft';
+ALTER TABLE "exam_info" ADD COLUMN IF NOT EXISTS submitted_at TIMESTAMP WITH TIME ZONE DEFAULT NULL;
+ALTER TABLE "exam_info" ADD COLUMN IF NOT EXISTS reviewed_by VARCHAR(16) DEFAULT NULL;
+ALTER TABLE "exam_info" ADD COLUMN IF NOT EXISTS reviewed_at TIMESTAMP WITH TIME ZONE DEFAULT NULL;
+ALTER TABLE "exam_info" ADD CONSTRAINT chk_review_status CHECK (
+    review_status IN ('draft', 'in_review', 'published')
+);
+ALTER TABLE "exam_info" ADD CONSTRAINT fk_reviewed_by FOREIGN KEY (reviewed_by)
+    REFERENCES "user_info"(user_id) ON DELETE SET NULL ON UPDATE CASCADE;
+
+CREATE INDEX IF NOT EXISTS idx_exam_info_review_status ON "exam_info" (review_status, submitted_at);
+
+COMMENT ON COLUMN exam_info.review_status IS 'Review status of the exam (draft, in_review or published)';
+COMMENT ON COLUMN exam_info.submitted_at IS 'Timestamp when the exam was last submitted for review (can be null)';
+COMMENT ON COLUMN exam_info.reviewed_by IS 'ID of the user who last reviewed the exam (can be null)';
+COMMENT ON COLUMN exam_info.reviewed_at IS 'Timestamp when the exam was last reviewed (can be null)';
+
+-- The teachers who are allowed to review (and approve) the exams of the
+-- other teachers.
+ALTER TABLE "user_info" ADD COLUMN IF NOT EXISTS is_exam_reviewer BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMENT ON COLUMN user_info.is_exam_reviewer IS 'True if the user (a teacher) can review the exams of the other teachers';
+
+-- exam_review_comment holds the comments the reviewers (and the authors)
+-- leave on an exam or on one of its questions while it's being reviewed.
+CREATE TABLE IF NOT EXISTS "exam_review_comment" (
+    comment_id SERIAL PRIMARY KEY,
+    exam_id INTEGER NOT NULL,
+    question_id INTEGER DEFAULT NULL,
+    author_id UserIdType,
+    comment_text TEXT NOT NULL,
+    is_resolved BOOLEAN NOT NULL DEFAULT FALSE,
+    resolved_by VARCHAR(16) DEFAULT NULL,
+    resolved_at TIMESTAMP WITH TIME ZONE DEFAULT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_exam_id FOREIGN KEY (exam_id) REFERENCES "exam_info"(exam_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_question_id FOREIGN KEY (question_id) REFERENCES "exam_question"(question_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_author_id FOREIGN KEY (author_id) REFERENCES "user_info"(user_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_resolved_by FOREIGN KEY (resolved_by) REFERENCES "user_info"(user_id) ON DELETE SET NULL ON UPDATE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_exam_review_comment_exam ON "exam_review_comment" (exam_id, created_at);
+
+COMMENT ON TABLE exam_review_comment IS 'Stores the review comments of the exams and their questions';
+COMMENT ON COLUMN exam_review_comment.comment_id IS 'Unique identifier for the comment';
+COMMENT ON COLUMN exam_review_comment.exam_id IS 'ID of the reviewed exam';
+COMMENT ON COLUMN exam_review_comment.question_id IS 'ID of the commented question (null for the comments on the whole exam)';
+COMMENT ON COLUMN exam_review_comment.author_id IS 'ID of the user who wrote the comment';
+COMMENT ON COLUMN exam_review_comment.comment_text IS 'Text of the comment';
+COMMENT ON COLUMN exam_review_comment.is_resolved IS 'True if the comment has been addressed';
+COMMENT ON COLUMN exam_review_comment.resolved_by IS 'ID of the user who resolved the comment (can be null)';
+COMMENT ON COLUMN exam_review_comment.resolved_at IS 'Timestamp when the comment was resolved (can be null)';
+COMMENT ON COLUMN exam_review_comment.created_at IS 'Timestamp when the comment was written';
+
+-- Moves the exam from the expected review status to the new one, and
+-- returns false if the exam is not in the expected status (anymore).
+-- Moving the exam to in_review sets submitted_at; moving it out of
+-- in_review records the reviewer.
+-- Example usage:
+--      SELECT change_exam_review_status(
+--          p_exam_id := 1234,
+--          p_expected_status := 'in_review',
+--          p_new_status := 'published',
+--          p_changed_by := 'admin1'
+--      );
+CREATE OR REPLACE FUNCTION change_exam_review_status(
+    p_exam_id INTEGER,
+    p_expected_status VARCHAR(16),
+    p_new_status VARCHAR(16),
+    p_changed_by UserIdType
+) RETURNS BOOLEAN AS $$
+BEGIN
+    UPDATE exam_info
+    SET review_status = p_new_status,
+        submitted_at = CASE WHEN p_new_status = 'in_review'
+            THEN CURRENT_TIMESTAMP ELSE submitted_at END,
+        reviewed_by = CASE WHEN p_expected_status = 'in_review'
+            THEN p_changed_by ELSE reviewed_by END,
+        reviewed_at = CASE WHEN p_expected_status = 'in_review'
+            THEN CURRENT_TIMESTAMP ELSE reviewed_at END
+    WHERE exam_id = p_exam_id AND review_status = p_expected_status;
+
+    RETURN FOUND;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Returns true if the user can participate in the exam, false otherwise.
+-- Users who have not participated yet can only participate in public exams
+-- which have been published, and only if they meet all of the
+-- prerequisites of the exam.
+-- Example usage:
+--      SELECT can_participate_in_exam(1234, '5678');
+CREATE OR REPLACE FUNCTION can_participate_in_exam(p_exam_id INTEGER, p_user_id UserIdType)
+RETURNS BOOLEAN AS $$
+DECLARE
+    is_exam_public BOOLEAN;
+    v_review_status VARCHAR(16);
+BEGIN
+    -- Just return true if the user already participated inside of this exam
+    IF has_participated_in_exam(p_exam_id, p_user_id) THEN
+        RETURN TRUE;
+    END IF;
+
+    SELECT "is_public", "review_status" INTO is_exam_public, v_review_status
+    FROM "exam_info"
+    WHERE exam_id = p_exam_id;
+
+    IF is_exam_public IS NULL THEN
+        RAISE EXCEPTION 'Exam with ID % not found', p_exam_id;
+    END IF;
+
+    IF NOT is_exam_public OR v_review_status <> 'published' THEN
+        RETURN FALSE;
+    END IF;
+
+    RETURN NOT EXISTS (
+        SELECT 1
+        FROM get_unmet_exam_prerequisites(p_exam_id, p_user_id)
+    );
+END;
+$$ LANGUAGE plpgsql;
+
+-- The most recent exams view only lists the published exams now.
+-- Example usage:
+--   SELECT * FROM most_recent_exams_view LIMIT 10 OFFSET 0;
+CREATE OR REPLACE VIEW most_recent_exams_view AS
+SELECT 
+    ei.exam_id,
+    ei.course_id,
+    ei.exam_title,
+    ei.exam_description,
+    ei.price,
+    ei.created_at,
+    ei.exam_date,
+    ei.duration,
+    ei.created_by,
+    ei.is_public
+FROM 
+    exam_info ei
+WHERE 
+    ei.exam_date >= CURRENT_TIMESTAMP AND ei.is_public = TRUE
+    AND ei.review_status = 'published'
+ORDER BY 
+    ei.exam_date DESC;
+
+-- materialise_exam_series_occurrence now copies the review status of the
+-- template exam, so the occurrences of an approved template are published
+-- (and the ones of a draft template are drafts too).
+-- Example usage:
+--      SELECT materialise_exam_series_occurrence(1, '2024-06-03 10:00:00+00');
+CREATE OR REPLACE FUNCTION materialise_exam_series_occurrence(
+    p_series_id INTEGER,
+    p_occurrence_at TIMESTAMP WITH TIME ZONE
+) RETURNS INTEGER AS $$
+DECLARE
+    v_template_exam_id INTEGER;
+    v_occurrence RECORD;
+    v_section RECORD;
+    v_exam_date TIMESTAMP WITH TIME ZONE;
+    v_shift INTERVAL;
+    new_exam_id INTEGER;
+    new_section_id INTEGER;
+BEGIN
+    SELECT s.template_exam_id INTO v_template_exam_id
+    FROM exam_series s
+    WHERE s.series_id = p_series_id;
+
+    IF v_template_exam_id IS NULL THEN
+        RAISE EXCEPTION 'Exam series with ID % not found', p_series_id;
+    END IF;
+
+    SELECT o.exam_id, o.is_skipped, o.exam_title, o.exam_date, o.duration
+    INTO v_occurrence
+    FROM exam_series_occurrence o
+    WHERE o.series_id = p_series_id AND o.occurrence_at = p_occurrence_at
+    FOR UPDATE;
+
+    IF FOUND AND v_occurrence.exam_id IS NOT NULL THEN
+        RETURN v_occurrence.exam_id;
+    ELSIF FOUND AND v_occurrence.is_skipped THEN
+        RETURN NULL;
+    END IF;
+
+    v_exam_date := COALESCE(v_occurrence.exam_date, p_occurrence_at);
+
+    SELECT v_exam_date - e.exam_date INTO v_shift
+    FROM exam_info e
+    WHERE e.exam_id = v_template_exam_id;
+
+    INSERT INTO exam_info (
+        course_id,
+        exam_title,
+        exam_description,
+        price,
+        exam_date,
+        duration,
+        created_by,
+        is_public,
+        access_code_type,
+        access_code,
+        access_code_interval,
+        max_attempts,
+        attempt_cooldown,
+        grading_policy,
+        opens_at,
+        closes_at,
+        late_start_limit,
+        deadline_policy,
+        grace_period,
+        capacity,
+        is_adaptive,
+        adaptive_max_items,
+        adaptive_target_se,
+        review_status,
+        reviewed_by,
+        reviewed_at
+    )
+    SELECT
+        e.course_id,
+        COALESCE(v_occurrence.exam_title, e.exam_title),
+        e.exam_description,
+        e.price,
+        v_exam_date,
+        COALESCE(v_occurrence.duration, e.duration),
+        e.created_by,
+        e.is_public,
+        e.access_code_type,
+        e.access_code,
+        e.access_code_interval,
+        e.max_attempts,
+        e.attempt_cooldown,
+        e.grading_policy,
+        e.opens_at + v_shift,
+        e.closes_at + v_shift,
+        e.late_start_limit,
+        e.deadline_policy,
+        e.grace_period,
+        e.capacity,
+        e.is_adaptive,
+        e.adaptive_max_items,
+        e.adaptive_target_se,
+        e.review_status,
+        e.reviewed_by,
+        e.reviewed_at
+    FROM exam_info e
+    WHERE e.exam_id = v_template_exam_id
+    RETURNING exam_id INTO new_exam_id;
+
+    FOR v_section IN
+        SELECT * FROM exam_section
+        WHERE exam_id = v_template_exam_id
+        ORDER BY section_order, section_id
+    LOOP
+        new_section_id := create_exam_section(
+            p_exam_id := new_exam_id,
+            p_section_title := v_section.section_title,
+            p_section_order := v_section.section_order,
+            p_duration := v_section.duration,
+            p_is_forward_only := v_section.is_forward_only
+        );
+
+        INSERT INTO exam_question (
+            exam_id,
+            question_title,
+            description,
+            option1,
+            option2,
+            option3,
+            option4,
+            section_id,
+            question_order,
+            correct_option,
+            irt_discrimination,
+            irt_difficulty,
+            irt_guessing,
+            content_format,
+            tags,
+            difficulty_level,
+            learning_objectives,
+            topic_id
+        )
+        SELECT new_exam_id, q.question_title, q.description, q.option1, q.option2, q.option3, q.option4,
+            new_section_id, q.question_order, q.correct_option,
+            q.irt_discrimination, q.irt_difficulty, q.irt_guessing, q.content_format,
+            q.tags, q.difficulty_level, q.learning_objectives, q.topic_id
+        FROM exam_question q
+        WHERE q.exam_id = v_template_exam_id AND q.section_id = v_section.section_id
+        ORDER BY q.question_order, q.question_id;
+    END LOOP;
+
+    INSERT INTO exam_question (
+        exam_id,
+        question_title,
+        description,
+        option1,
+        option2,
+        option3,
+        option4,
+        question_order,
+        correct_option,
+        irt_discrimination,
+        irt_difficulty,
+        irt_guessing,
+        content_format,
+        tags,
+        difficulty_level,
+        learning_objectives,
+        topic_id
+    )
+    SELECT new_exam_id, q.question_title, q.description, q.option1, q.option2, q.option3, q.option4,
+        q.question_order, q.correct_option,
+        q.irt_discrimination, q.irt_difficulty, q.irt_guessing, q.content_format,
+        q.tags, q.difficulty_level, q.learning_objectives, q.topic_id
+    FROM exam_question q
+    WHERE q.exam_id = v_template_exam_id AND q.section_id IS NULL
+    ORDER BY q.question_order, q.question_id;
+
+    INSERT INTO exam_prerequisite (
+        exam_id,
+        prerequisite_type,
+        required_exam_id,
+        min_score,
+        topic_id,
+        min_level,
+        added_by
+    )
+    SELECT new_exam_id, p.prerequisite_type, p.required_exam_id, p.min_score, p.topic_id, p.min_level, p.added_by
+    FROM exam_prerequisite p
+    WHERE p.exam_id = v_template_exam_id;
+
+    INSERT INTO exam_series_occurrence (series_id, occurrence_at, exam_id)
+    VALUES (p_series_id, p_occurrence_at, new_exam_id)
+    ON CONFLICT (series_id, occurrence_at)
+    DO UPDATE SET
+        exam_id = EXCLUDED.exam_id,
+        updated_at = CURRENT_TIMESTAMP;
+
+    RETURN new_exam_id;
+END;
+$$ LANGUAGE plpgsql;
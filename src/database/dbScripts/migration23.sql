@@ -0,0 +1,264 @@
+-- exam_collaborator holds the teachers who co-own an exam with its
+-- creator. The role of a collaborator decides what they can do:
+--   editor: edit the exam and its questions, and manage its participants,
+--   grader: grade the answers of the participants,
+--   viewer: see the exam and its questions.
+-- Each role also has the permissions of the roles below it.
+CREATE TABLE IF NOT EXISTS "exam_collaborator" (
+    exam_id INTEGER NOT NULL,
+    user_id UserIdType,
+    collaborator_role VARCHAR(10) NOT NULL,
+    added_by VARCHAR(16) DEFAULT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (exam_id, user_id),
+    CONSTRAINT fk_exam_id FOREIGN KEY (exam_id) REFERENCES "exam_info"(exam_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_user_id FOREIGN KEY (user_id) REFERENCES "user_info"(user_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_added_by FOREIGN KEY (added_by) REFERENCES "user_info"(user_id) ON DELETE SET NULL ON UPDATE CASCADE,
+    CONSTRAINT chk_collaborator_role CHECK (collaborator_role IN ('editor', 'grader', 'viewer'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_exam_collaborator_user ON "exam_collaborator" (user_id);
+
+COMMENT ON TABLE exam_collaborator IS 'Stores the collaborators (co-authors) of the exams';
+COMMENT ON COLUMN exam_collaborator.exam_id IS 'ID of the exam';
+COMMENT ON COLUMN exam_collaborator.user_id IS 'ID of the collaborator';
+COMMENT ON COLUMN exam_collaborator.collaborator_role IS 'Role of the collaborator (editor, grader or viewer)';
+COMMENT ON COLUMN exam_collaborator.added_by IS 'ID of the user who added the collaborator (can be null)';
+COMMENT ON COLUMN exam_collaborator.created_at IS 'Timestamp when the collaborator was added';
+COMMENT ON COLUMN exam_collaborator.updated_at IS 'Timestamp when the role of the collaborator was last changed';
+
+-- Adds a collaborator to the exam, or changes their role if they're
+-- already a collaborator of it.
+-- Example usage:
+--      CALL set_exam_collaborator(
+--          p_exam_id := 1234,
+--          p_user_id := 'teacher2',
+--          p_collaborator_role := 'grader',
+--          p_added_by := 'teacher1'
+--      );
+CREATE OR REPLACE PROCEDURE set_exam_collaborator(
+    p_exam_id INTEGER,
+    p_user_id UserIdType,
+    p_collaborator_role VARCHAR(10),
+    p_added_by VARCHAR(16) DEFAULT NULL
+)
+LANGUAGE plpgsql
+AS $$
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM exam_info
+        WHERE exam_id = p_exam_id AND created_by = p_user_id
+    ) THEN
+        RAISE EXCEPTION 'User % is the creator of exam %', p_user_id, p_exam_id;
+    END IF;
+
+    INSERT INTO exam_collaborator (exam_id, user_id, collaborator_role, added_by)
+    VALUES (p_exam_id, p_user_id, p_collaborator_role, p_added_by)
+    ON CONFLICT (exam_id, user_id)
+    DO UPDATE SET
+        collaborator_role = EXCLUDED.collaborator_role,
+        updated_at = CURRENT_TIMESTAMP;
+END;
+$$;
+
+-- materialise_exam_series_occurrence now copies the collaborators of the
+-- template exam, so the co-authors of a series can manage its occurrences.
+-- Example usage:
+--      SELECT materialise_exam_series_occurrence(1, '2024-06-03 10:00:00+00');
+CREATE OR REPLACE FUNCTION materialise_exam_series_occurrence(
+    p_series_id INTEGER,
+    p_occurrence_at TIMESTAMP WITH TIME ZONE
+) RETURNS INTEGER AS $$
+DECLARE
+    v_template_exam_id INTEGER;
+    v_occurrence RECORD;
+    v_section RECORD;
+    v_exam_date TIMESTAMP WITH TIME ZONE;
+    v_shift INTERVAL;
+    new_exam_id INTEGER;
+    new_section_id INTEGER;
+BEGIN
+    SELECT s.template_exam_id INTO v_template_exam_id
+    FROM exam_series s
+    WHERE s.series_id = p_series_id;
+
+    IF v_template_exam_id IS NULL THEN
+        RAISE EXCEPTION 'Exam series with ID % not found', p_series_id;
+    END IF;
+
+    SELECT o.exam_id, o.is_skipped, o.exam_title, o.exam_date, o.duration
+    INTO v_occurrence
+    FROM exam_series_occurrence o
+    WHERE o.series_id = p_series_id AND o.occurrence_at = p_occurrence_at
+    FOR UPDATE;
+
+    IF FOUND AND v_occurrence.exam_id IS NOT NULL THEN
+        RETURN v_occurrence.exam_id;
+    ELSIF FOUND AND v_occurrence.is_skipped THEN
+        RETURN NULL;
+    END IF;
+
+    v_exam_date := COALESCE(v_occurrence.exam_date, p_occurrence_at);
+
+    SELECT v_exam_date - e.exam_date INTO v_shift
+    FROM exam_info e
+    WHERE e.exam_id = v_template_exam_id;
+
+    INSERT INTO exam_info (
+        course_id,
+        exam_title,
+        exam_description,
+        price,
+        exam_date,
+        duration,
+        created_by,
+        is_public,
+        access_code_type,
+        access_code,
+        access_code_interval,
+        max_attempts,
+        attempt_cooldown,
+        grading_policy,
+        opens_at,
+        closes_at,
+        late_start_limit,
+        deadline_policy,
+        grace_period,
+        capacity,
+        is_adaptive,
+        adaptive_max_items,
+        adaptive_target_se,
+        review_status,
+        reviewed_by,
+        reviewed_at
+    )
+    SELECT
+        e.course_id,
+        COALESCE(v_occurrence.exam_title, e.exam_title),
+        e.exam_description,
+        e.price,
+        v_exam_date,
+        COALESCE(v_occurrence.duration, e.duration),
+        e.created_by,
+        e.is_public,
+        e.access_code_type,
+        e.access_code,
+        e.access_code_interval,
+        e.max_attempts,
+        e.attempt_cooldown,
+        e.grading_policy,
+        e.opens_at + v_shift,
+        e.closes_at + v_shift,
+        e.late_start_limit,
+        e.deadline_policy,
+        e.grace_period,
+        e.capacity,
+        e.is_adaptive,
+        e.adaptive_max_items,
+        e.adaptive_target_se,
+        e.review_status,
+        e.reviewed_by,
+        e.reviewed_at
+    FROM exam_info e
+    WHERE e.exam_id = v_template_exam_id
+    RETURNING exam_id INTO new_exam_id;
+
+    FOR v_section IN
+        SELECT * FROM exam_section
+        WHERE exam_id = v_template_exam_id
+        ORDER BY section_order, section_id
+    LOOP
+        new_section_id := create_exam_section(
+            p_exam_id := new_exam_id,
+            p_section_title := v_section.section_title,
+            p_section_order := v_section.section_order,
+            p_duration := v_section.duration,
+            p_is_forward_only := v_section.is_forward_only
+        );
+
+        INSERT INTO exam_question (
+            exam_id,
+            question_title,
+            description,
+            option1,
+            option2,
+            option3,
+            option4,
+            section_id,
+            question_order,
+            correct_option,
+            irt_discrimination,
+            irt_difficulty,
+            irt_guessing,
+            content_format,
+            tags,
+            difficulty_level,
+            learning_objectives,
+            topic_id
+        )
+        SELECT new_exam_id, q.question_title, q.description, q.option1, q.option2, q.option3, q.option4,
+            new_section_id, q.question_order, q.correct_option,
+            q.irt_discrimination, q.irt_difficulty, q.irt_guessing, q.content_format,
+            q.tags, q.difficulty_level, q.learning_objectives, q.topic_id
+        FROM exam_question q
+        WHERE q.exam_id = v_template_exam_id AND q.section_id = v_section.section_id
+        ORDER BY q.question_order, q.question_id;
+    END LOOP;
+
+    INSERT INTO exam_question (
+        exam_id,
+        question_title,
+        description,
+        option1,
+        option2,
+        option3,
+        option4,
+        question_order,
+        correct_option,
+        irt_discrimination,
+        irt_difficulty,
+        irt_guessing,
+        content_format,
+        tags,
+        difficulty_level,
+        learning_objectives,
+        topic_id
+    )
+    SELECT new_exam_id, q.question_title, q.description, q.option1, q.option2, q.option3, q.option4,
+        q.question_order, q.correct_option,
+        q.irt_discrimination, q.irt_difficulty, q.irt_guessing, q.content_format,
+        q.tags, q.difficulty_level, q.learning_objectives, q.topic_id
+    FROM exam_question q
+    WHERE q.exam_id = v_template_exam_id AND q.section_id IS NULL
+    ORDER BY q.question_order, q.question_id;
+
+    INSERT INTO exam_prerequisite (
+        exam_id,
+        prerequisite_type,
+        required_exam_id,
+        min_score,
+        topic_id,
+        min_level,
+        added_by
+    )
+    SELECT new_exam_id, p.prerequisite_type, p.required_exam_id, p.min_score, p.topic_id, p.min_level, p.added_by
+    FROM exam_prerequisite p
+    WHERE p.exam_id = v_template_exam_id;
+
+    INSERT INTO exam_collaborator (exam_id, user_id, collaborator_role, added_by)
+    SELECT new_exam_id, c.user_id, c.collaborator_role, c.added_by
+    FROM exam_collaborator c
+    WHERE c.exam_id = v_template_exam_id;
+
+    INSERT INTO exam_series_occurrence (series_id, occurrence_at, exam_id)
+    VALUES (p_series_id, p_occurrence_at, new_exam_id)
+    ON CONFLICT (series_id, occurrence_at)
+    DO UPDATE SET
+        exam_id = EXCLUDED.exam_id,
+        updated_at = CURRENT_TIMESTAMP;
+
+    RETURN new_exam_id;
+END;
+$$ LANGUAGE plpgsql;
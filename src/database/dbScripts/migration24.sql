@@ -0,0 +1,89 @@
+-- exam_preview_attempt holds the preview (test-drive) attempts of the
+-- teachers in their own exams. Previews are kept in a sandbox: they never
+-- create given_exam (or given_answer) rows, so they affect neither the
+-- participants of the exam nor its statistics.
+CREATE TABLE IF NOT EXISTS "exam_preview_attempt" (
+    preview_id SERIAL PRIMARY KEY,
+    exam_id INTEGER NOT NULL,
+    user_id UserIdType,
+    started_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    finished_at TIMESTAMP WITH TIME ZONE DEFAULT NULL,
+
+    CONSTRAINT fk_exam_id FOREIGN KEY (exam_id) REFERENCES "exam_info"(exam_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_user_id FOREIGN KEY (user_id) REFERENCES "user_info"(user_id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_exam_preview_attempt_exam_user ON "exam_preview_attempt" (exam_id, user_id);
+
+COMMENT ON TABLE exam_preview_attempt IS 'Stores the sandboxed preview attempts of the teachers in their exams';
+COMMENT ON COLUMN exam_preview_attempt.preview_id IS 'Unique identifier for the preview attempt';
+COMMENT ON COLUMN exam_preview_attempt.exam_id IS 'ID of the previewed exam';
+COMMENT ON COLUMN exam_preview_attempt.user_id IS 'ID of the user taking the preview';
+COMMENT ON COLUMN exam_preview_attempt.started_at IS 'Timestamp when the preview was started';
+COMMENT ON COLUMN exam_preview_attempt.finished_at IS 'Timestamp when the preview was finished (null if still ongoing)';
+
+-- exam_preview_answer holds the answers given in the preview attempts;
+-- they get removed alongside their preview when it's discarded.
+CREATE TABLE IF NOT EXISTS "exam_preview_answer" (
+    preview_id INTEGER NOT NULL,
+    question_id INTEGER NOT NULL,
+    chosen_option TEXT DEFAULT NULL,
+    answer_text TEXT DEFAULT NULL,
+    seconds_taken INTEGER DEFAULT 0,
+    answered_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (preview_id, question_id),
+    CONSTRAINT fk_preview_id FOREIGN KEY (preview_id) REFERENCES "exam_preview_attempt"(preview_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_question_id FOREIGN KEY (question_id) REFERENCES "exam_question"(question_id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+COMMENT ON TABLE exam_preview_answer IS 'Stores the answers given in the preview attempts';
+COMMENT ON COLUMN exam_preview_answer.preview_id IS 'ID of the preview attempt';
+COMMENT ON COLUMN exam_preview_answer.question_id IS 'ID of the answered question';
+COMMENT ON COLUMN exam_preview_answer.chosen_option IS 'The title of the option chosen in the preview';
+COMMENT ON COLUMN exam_preview_answer.answer_text IS 'The text answer given in the preview';
+COMMENT ON COLUMN exam_preview_answer.seconds_taken IS 'Seconds taken to answer the question';
+COMMENT ON COLUMN exam_preview_answer.answered_at IS 'Timestamp when the question was (last) answered';
+
+-- Answers a question in a preview attempt, replacing the previous answer
+-- of the question (if any).
+-- Example usage:
+--      CALL give_preview_answer(
+--          p_preview_id := 1,
+--          p_question_id := 10,
+--          p_chosen_option := 'Option 2',
+--          p_answer_text := NULL,
+--          p_seconds_taken := 42
+--      );
+CREATE OR REPLACE PROCEDURE give_preview_answer(
+    p_preview_id INTEGER,
+    p_question_id INTEGER,
+    p_chosen_option TEXT DEFAULT NULL,
+    p_answer_text TEXT DEFAULT NULL,
+    p_seconds_taken INTEGER DEFAULT 0
+)
+LANGUAGE plpgsql
+AS $$
+BEGIN
+    INSERT INTO exam_preview_answer (
+        preview_id,
+        question_id,
+        chosen_option,
+        answer_text,
+        seconds_taken
+    )
+    VALUES (
+        p_preview_id,
+        p_question_id,
+        p_chosen_option,
+        p_answer_text,
+        p_seconds_taken
+    )
+    ON CONFLICT (preview_id, question_id)
+    DO UPDATE SET
+        chosen_option = EXCLUDED.chosen_option,
+        answer_text = EXCLUDED.answer_text,
+        seconds_taken = EXCLUDED.seconds_taken,
+        answered_at = CURRENT_TIMESTAMP;
+END;
+$$;
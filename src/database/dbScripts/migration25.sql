@@ -0,0 +1,377 @@
+-- Practice exams are not graded: their attempts are unlimited (and have no
+-- cooldown), and the answers of the students are checked right away, so
+-- they can see whether they were right alongside the explanation of the
+-- question. The answers still earn the students experience in the topic
+-- of the exam, like the answers of any other exam do.
+ALTER TABLE "exam_info" ADD COLUMN IF NOT EXISTS is_practice BOOLEAN DEFAULT FALSE;
+
+COMMENT ON COLUMN exam_info.is_practice IS 'Whether the exam is a practice exam (unlimited attempts, instant feedback and no grades)';
+
+ALTER TABLE "exam_question" ADD COLUMN IF NOT EXISTS explanation TEXT DEFAULT NULL;
+
+COMMENT ON COLUMN exam_question.explanation IS 'Explanation of the answer of the question, written by the teacher (can be null)';
+
+DO
+$$
+DECLARE
+    r RECORD;
+BEGIN
+    FOR r IN SELECT proname, prokind, pg_get_function_identity_arguments(p.oid) AS args
+             FROM pg_proc p
+             JOIN pg_namespace n ON p.pronamespace = n.oid
+             WHERE n.nspname NOT IN ('pg_catalog', 'information_schema')
+             AND pg_function_is_visible(p.oid)
+             AND proname IN ('create_exam_question')
+    LOOP
+        IF r.prokind = 'p' THEN
+            EXECUTE format('DROP PROCEDURE IF EXISTS %I(%s);', r.proname, r.args);
+        ELSE
+            EXECUTE format('DROP FUNCTION IF EXISTS %I(%s);', r.proname, r.args);
+        END IF;
+    END LOOP;
+END
+$$;
+
+-- Function to create a single exam question, optionally inside a section.
+-- Returns the question_id of the newly created question.
+-- Example usage:
+--      SELECT create_exam_question(
+--         p_exam_id := 1234,
+--         p_question_title := 'What is $\sqrt{16}$?',
+--         p_description := 'Choose the **correct** option from the following.',
+--         p_option1 := '$2$',
+--         p_option2 := '$4$',
+--         p_option3 := '$8$',
+--         p_option4 := '$16$',
+--         p_section_id := 1,
+--         p_question_order := 3,
+--         p_content_format := 'markdown_latex',
+--         p_explanation := 'Because $4 \times 4 = 16$.'
+--      );
+CREATE OR REPLACE FUNCTION create_exam_question(
+    p_exam_id INTEGER,
+    p_question_title VARCHAR(2048),
+    p_description TEXT DEFAULT NULL,
+    p_option1 TEXT DEFAULT NULL,
+    p_option2 TEXT DEFAULT NULL,
+    p_option3 TEXT DEFAULT NULL,
+    p_option4 TEXT DEFAULT NULL,
+    p_section_id INTEGER DEFAULT NULL,
+    p_question_order INTEGER DEFAULT 0,
+    p_content_format VARCHAR(20) DEFAULT 'plain',
+    p_explanation TEXT DEFAULT NULL
+) RETURNS INTEGER AS $$
+DECLARE
+    new_question_id INTEGER;
+BEGIN
+    IF p_section_id IS NOT NULL AND NOT EXISTS (
+        SELECT 1 FROM exam_section
+        WHERE section_id = p_section_id AND exam_id = p_exam_id
+    ) THEN
+        RAISE EXCEPTION 'Section % does not belong to exam %', p_section_id, p_exam_id;
+    END IF;
+
+    INSERT INTO exam_question (exam_id, question_title, description, option1, option2, option3, option4, section_id, question_order, content_format, explanation)
+    VALUES (p_exam_id, p_question_title, p_description, p_option1, p_option2, p_option3, p_option4, p_section_id, p_question_order, p_content_format, p_explanation)
+    RETURNING question_id INTO new_question_id;
+
+    RETURN new_question_id;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Sets whether the exam is a practice exam.
+-- Example usage:
+--      CALL set_exam_practice_mode(
+--          p_exam_id := 1234,
+--          p_is_practice := TRUE
+--      );
+CREATE OR REPLACE PROCEDURE set_exam_practice_mode(
+    p_exam_id INTEGER,
+    p_is_practice BOOLEAN
+)
+LANGUAGE plpgsql
+AS $$
+BEGIN
+    UPDATE exam_info
+    SET is_practice = p_is_practice
+    WHERE exam_id = p_exam_id;
+
+    IF NOT FOUND THEN
+        RAISE EXCEPTION 'Exam with ID % not found', p_exam_id;
+    END IF;
+END;
+$$;
+
+-- start_exam_attempt now ignores the retake policy of the practice exams,
+-- as their attempts are unlimited.
+-- Example usage:
+--      SELECT * FROM start_exam_attempt(
+--          p_exam_id := 1,
+--          p_user_id := '1234'
+--      );
+CREATE OR REPLACE FUNCTION start_exam_attempt(
+    p_exam_id INTEGER,
+    p_user_id UserIdType
+) RETURNS TABLE (
+    attempt_number INTEGER,
+    started_at TIMESTAMP WITH TIME ZONE
+) AS $$
+DECLARE
+    v_max_attempts INTEGER;
+    v_attempt_cooldown INTEGER;
+    v_is_practice BOOLEAN;
+    v_last_attempt INTEGER;
+    v_last_finished_at TIMESTAMP WITH TIME ZONE;
+BEGIN
+    IF NOT has_participated_in_exam(p_exam_id, p_user_id) THEN
+        RAISE EXCEPTION 'User has not participated in exam % yet', p_exam_id;
+    END IF;
+
+    IF CURRENT_TIMESTAMP < get_exam_start_time(p_exam_id, p_user_id) THEN
+        RAISE EXCEPTION 'Exam % has not started yet', p_exam_id;
+    ELSIF CURRENT_TIMESTAMP > get_exam_latest_start_time(p_exam_id, p_user_id) THEN
+        RAISE EXCEPTION 'User % cannot start exam % anymore', p_user_id, p_exam_id;
+    END IF;
+
+    CALL finish_expired_exam_attempts(p_exam_id, p_user_id);
+
+    SELECT e.max_attempts, e.attempt_cooldown, e.is_practice
+    INTO v_max_attempts, v_attempt_cooldown, v_is_practice
+    FROM exam_info e
+    WHERE e.exam_id = p_exam_id;
+
+    IF v_is_practice THEN
+        v_max_attempts := 0;
+        v_attempt_cooldown := 0;
+    END IF;
+
+    SELECT a.attempt_number, a.finished_at
+    INTO v_last_attempt, v_last_finished_at
+    FROM exam_attempt a
+    WHERE a.exam_id = p_exam_id AND a.user_id = p_user_id
+    ORDER BY a.attempt_number DESC
+    LIMIT 1;
+
+    IF v_last_attempt IS NULL THEN
+        v_last_attempt := 0;
+    ELSIF v_last_finished_at IS NULL THEN
+        RAISE EXCEPTION 'Attempt % of user % in exam % is not finished yet', v_last_attempt, p_user_id, p_exam_id;
+    ELSIF v_max_attempts > 0 AND v_last_attempt >= v_max_attempts THEN
+        RAISE EXCEPTION 'User % has no attempts left in exam %', p_user_id, p_exam_id;
+    ELSIF v_last_finished_at + (v_attempt_cooldown || ' minutes')::INTERVAL > CURRENT_TIMESTAMP THEN
+        RAISE EXCEPTION 'User % has to wait before retaking exam %', p_user_id, p_exam_id;
+    END IF;
+
+    DELETE FROM exam_attempt_binding b
+    WHERE b.exam_id = p_exam_id AND b.user_id = p_user_id;
+
+    RETURN QUERY
+    INSERT INTO exam_attempt AS a (exam_id, user_id, attempt_number)
+    VALUES (p_exam_id, p_user_id, v_last_attempt + 1)
+    RETURNING a.attempt_number, a.started_at;
+END;
+$$ LANGUAGE plpgsql;
+
+-- materialise_exam_series_occurrence now copies the practice mode of the
+-- template exam and the explanations of its questions.
+-- Example usage:
+--      SELECT materialise_exam_series_occurrence(1, '2024-06-03 10:00:00+00');
+CREATE OR REPLACE FUNCTION materialise_exam_series_occurrence(
+    p_series_id INTEGER,
+    p_occurrence_at TIMESTAMP WITH TIME ZONE
+) RETURNS INTEGER AS $$
+DECLARE
+    v_template_exam_id INTEGER;
+    v_occurrence RECORD;
+    v_section RECORD;
+    v_exam_date TIMESTAMP WITH TIME ZONE;
+    v_shift INTERVAL;
+    new_exam_id INTEGER;
+    new_section_id INTEGER;
+BEGIN
+    SELECT s.template_exam_id INTO v_template_exam_id
+    FROM exam_series s
+    WHERE s.series_id = p_series_id;
+
+    IF v_template_exam_id IS NULL THEN
+        RAISE EXCEPTION 'Exam series with ID % not found', p_series_id;
+    END IF;
+
+    SELECT o.exam_id, o.is_skipped, o.exam_title, o.exam_date, o.duration
+    INTO v_occurrence
+    FROM exam_series_occurrence o
+    WHERE o.series_id = p_series_id AND o.occurrence_at = p_occurrence_at
+    FOR UPDATE;
+
+    IF FOUND AND v_occurrence.exam_id IS NOT NULL THEN
+        RETURN v_occurrence.exam_id;
+    ELSIF FOUND AND v_occurrence.is_skipped THEN
+        RETURN NULL;
+    END IF;
+
+    v_exam_date := COALESCE(v_occurrence.exam_date, p_occurrence_at);
+
+    SELECT v_exam_date - e.exam_date INTO v_shift
+    FROM exam_info e
+    WHERE e.exam_id = v_template_exam_id;
+
+    INSERT INTO exam_info (
+        course_id,
+        exam_title,
+        exam_description,
+        price,
+        exam_date,
+        duration,
+        created_by,
+        is_public,
+        access_code_type,
+        access_code,
+        access_code_interval,
+        max_attempts,
+        attempt_cooldown,
+        grading_policy,
+        opens_at,
+        closes_at,
+        late_start_limit,
+        deadline_policy,
+        grace_period,
+        capacity,
+        is_adaptive,
+        adaptive_max_items,
+        adaptive_target_se,
+        review_status,
+        reviewed_by,
+        reviewed_at,
+        is_practice
+    )
+    SELECT
+        e.course_id,
+        COALESCE(v_occurrence.exam_title, e.exam_title),
+        e.exam_description,
+        e.price,
+        v_exam_date,
+        COALESCE(v_occurrence.duration, e.duration),
+        e.created_by,
+        e.is_public,
+        e.access_code_type,
+        e.access_code,
+        e.access_code_interval,
+        e.max_attempts,
+        e.attempt_cooldown,
+        e.grading_policy,
+        e.opens_at + v_shift,
+        e.closes_at + v_shift,
+        e.late_start_limit,
+        e.deadline_policy,
+        e.grace_period,
+        e.capacity,
+        e.is_adaptive,
+        e.adaptive_max_items,
+        e.adaptive_target_se,
+        e.review_status,
+        e.reviewed_by,
+        e.reviewed_at,
+        e.is_practice
+    FROM exam_info e
+    WHERE e.exam_id = v_template_exam_id
+    RETURNING exam_id INTO new_exam_id;
+
+    FOR v_section IN
+        SELECT * FROM exam_section
+        WHERE exam_id = v_template_exam_id
+        ORDER BY section_order, section_id
+    LOOP
+        new_section_id := create_exam_section(
+            p_exam_id := new_exam_id,
+            p_section_title := v_section.section_title,
+            p_section_order := v_section.section_order,
+            p_duration := v_section.duration,
+            p_is_forward_only := v_section.is_forward_only
+        );
+
+        INSERT INTO exam_question (
+            exam_id,
+            question_title,
+            description,
+            option1,
+            option2,
+            option3,
+            option4,
+            section_id,
+            question_order,
+            correct_option,
+            irt_discrimination,
+            irt_difficulty,
+            irt_guessing,
+            content_format,
+            tags,
+            difficulty_level,
+            learning_objectives,
+            topic_id,
+            explanation
+        )
+        SELECT new_exam_id, q.question_title, q.description, q.option1, q.option2, q.option3, q.option4,
+            new_section_id, q.question_order, q.correct_option,
+            q.irt_discrimination, q.irt_difficulty, q.irt_guessing, q.content_format,
+            q.tags, q.difficulty_level, q.learning_objectives, q.topic_id, q.explanation
+        FROM exam_question q
+        WHERE q.exam_id = v_template_exam_id AND q.section_id = v_section.section_id
+        ORDER BY q.question_order, q.question_id;
+    END LOOP;
+
+    INSERT INTO exam_question (
+        exam_id,
+        question_title,
+        description,
+        option1,
+        option2,
+        option3,
+        option4,
+        question_order,
+        correct_option,
+        irt_discrimination,
+        irt_difficulty,
+        irt_guessing,
+        content_format,
+        tags,
+        difficulty_level,
+        learning_objectives,
+        topic_id,
+        explanation
+    )
+    SELECT new_exam_id, q.question_title, q.description, q.option1, q.option2, q.option3, q.option4,
+        q.question_order, q.correct_option,
+        q.irt_discrimination, q.irt_difficulty, q.irt_guessing, q.content_format,
+        q.tags, q.difficulty_level, q.learning_objectives, q.topic_id, q.explanation
+    FROM exam_question q
+    WHERE q.exam_id = v_template_exam_id AND q.section_id IS NULL
+    ORDER BY q.question_order, q.question_id;
+
+    INSERT INTO exam_prerequisite (
+        exam_id,
+        prerequisite_type,
+        required_exam_id,
+        min_score,
+        topic_id,
+        min_level,
+        added_by
+    )
+    SELECT new_exam_id, p.prerequisite_type, p.required_exam_id, p.min_score, p.topic_id, p.min_level, p.added_by
+    FROM exam_prerequisite p
+    WHERE p.exam_id = v_template_exam_id;
+
+    INSERT INTO exam_collaborator (exam_id, user_id, collaborator_role, added_by)
+    SELECT new_exam_id, c.user_id, c.collaborator_role, c.added_by
+    FROM exam_collaborator c
+    WHERE c.exam_id = v_template_exam_id;
+
+    INSERT INTO exam_series_occurrence (series_id, occurrence_at, exam_id)
+    VALUES (p_series_id, p_occurrence_at, new_exam_id)
+    ON CONFLICT (series_id, occurrence_at)
+    DO UPDATE SET
+        exam_id = EXCLUDED.exam_id,
+        updated_at = CURRENT_TIMESTAMP;
+
+    RETURN new_exam_id;
+END;
+$$ LANGUAGE plpgsql;
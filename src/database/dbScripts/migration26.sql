@@ -0,0 +1,409 @@
+-- attempt_review_policy decides when the students can review their
+-- attempts (their answers alongside the correct answers, the points they
+-- have earned and the comments of the graders):
+--   never: the attempts cannot be reviewed,
+--   after_close: once the exam has closed for the student,
+--   after_release: once the exam has closed for the student and its
+--      results have been released by a teacher.
+ALTER TABLE "exam_info" ADD COLUMN IF NOT EXISTS attempt_review_policy VARCHAR(16) DEFAULT 'never';
+ALTER TABLE "exam_info" ADD COLUMN IF NOT EXISTS results_released_at TIMESTAMP WITH TIME ZONE DEFAULT NULL;
+ALTER TABLE "exam_info" ADD COLUMN IF NOT EXISTS results_released_by VARCHAR(16) DEFAULT NULL;
+ALTER TABLE "exam_info" ADD CONSTRAINT chk_attempt_review_policy CHECK (
+    attempt_review_policy IN ('never', 'after_close', 'after_release')
+);
+ALTER TABLE "exam_info" ADD CONSTRAINT fk_results_released_by FOREIGN KEY (results_released_by)
+    REFERENCES "user_info"(user_id) ON DELETE SET NULL ON UPDATE CASCADE;
+
+COMMENT ON COLUMN exam_info.attempt_review_policy IS 'When the students can review their attempts (never, after_close or after_release)';
+COMMENT ON COLUMN exam_info.results_released_at IS 'Timestamp when the results of the exam were released (null if not released)';
+COMMENT ON COLUMN exam_info.results_released_by IS 'ID of the user who released the results of the exam (can be null)';
+
+-- points is the maximum number of points a question is worth; the answers
+-- of the questions with an answer key earn all of it (or nothing) unless
+-- a grader gives them a different number of points.
+ALTER TABLE "exam_question" ADD COLUMN IF NOT EXISTS points DOUBLE PRECISION NOT NULL DEFAULT 1;
+ALTER TABLE "exam_question" ADD CONSTRAINT chk_points CHECK (points >= 0);
+
+COMMENT ON COLUMN exam_question.points IS 'Maximum number of points the question is worth';
+
+ALTER TABLE "given_answer" ADD COLUMN IF NOT EXISTS points_earned DOUBLE PRECISION DEFAULT NULL;
+ALTER TABLE "given_answer" ADD COLUMN IF NOT EXISTS grader_comment TEXT DEFAULT NULL;
+ALTER TABLE "given_answer" ADD COLUMN IF NOT EXISTS graded_by VARCHAR(16) DEFAULT NULL;
+ALTER TABLE "given_answer" ADD COLUMN IF NOT EXISTS graded_at TIMESTAMP WITH TIME ZONE DEFAULT NULL;
+ALTER TABLE "given_answer" ADD CONSTRAINT chk_points_earned CHECK (points_earned IS NULL OR points_earned >= 0);
+ALTER TABLE "given_answer" ADD CONSTRAINT fk_graded_by FOREIGN KEY (graded_by)
+    REFERENCES "user_info"(user_id) ON DELETE SET NULL ON UPDATE CASCADE;
+
+COMMENT ON COLUMN given_answer.points_earned IS 'Points given to the answer by a grader (null if not graded by hand)';
+COMMENT ON COLUMN given_answer.grader_comment IS 'Comment of the grader on the answer (can be null)';
+COMMENT ON COLUMN given_answer.graded_by IS 'ID of the user who graded the answer (can be null)';
+COMMENT ON COLUMN given_answer.graded_at IS 'Timestamp when the answer was (last) graded';
+
+DO
+$$
+DECLARE
+    r RECORD;
+BEGIN
+    FOR r IN SELECT proname, prokind, pg_get_function_identity_arguments(p.oid) AS args
+             FROM pg_proc p
+             JOIN pg_namespace n ON p.pronamespace = n.oid
+             WHERE n.nspname NOT IN ('pg_catalog', 'information_schema')
+             AND pg_function_is_visible(p.oid)
+             AND proname IN ('create_exam_question')
+    LOOP
+        IF r.prokind = 'p' THEN
+            EXECUTE format('DROP PROCEDURE IF EXISTS %I(%s);', r.proname, r.args);
+        ELSE
+            EXECUTE format('DROP FUNCTION IF EXISTS %I(%s);', r.proname, r.args);
+        END IF;
+    END LOOP;
+END
+$$;
+
+-- Function to create a single exam question, optionally inside a section.
+-- Returns the question_id of the newly created question.
+-- Example usage:
+--      SELECT create_exam_question(
+--         p_exam_id := 1234,
+--         p_question_title := 'What is $\sqrt{16}$?',
+--         p_description := 'Choose the **correct** option from the following.',
+--         p_option1 := '$2$',
+--         p_option2 := '$4$',
+--         p_option3 := '$8$',
+--         p_option4 := '$16$',
+--         p_section_id := 1,
+--         p_question_order := 3,
+--         p_content_format := 'markdown_latex',
+--         p_explanation := 'Because $4 \times 4 = 16$.',
+--         p_points := 2
+--      );
+CREATE OR REPLACE FUNCTION create_exam_question(
+    p_exam_id INTEGER,
+    p_question_title VARCHAR(2048),
+    p_description TEXT DEFAULT NULL,
+    p_option1 TEXT DEFAULT NULL,
+    p_option2 TEXT DEFAULT NULL,
+    p_option3 TEXT DEFAULT NULL,
+    p_option4 TEXT DEFAULT NULL,
+    p_section_id INTEGER DEFAULT NULL,
+    p_question_order INTEGER DEFAULT 0,
+    p_content_format VARCHAR(20) DEFAULT 'plain',
+    p_explanation TEXT DEFAULT NULL,
+    p_points DOUBLE PRECISION DEFAULT 1
+) RETURNS INTEGER AS $$
+DECLARE
+    new_question_id INTEGER;
+BEGIN
+    IF p_section_id IS NOT NULL AND NOT EXISTS (
+        SELECT 1 FROM exam_section
+        WHERE section_id = p_section_id AND exam_id = p_exam_id
+    ) THEN
+        RAISE EXCEPTION 'Section % does not belong to exam %', p_section_id, p_exam_id;
+    END IF;
+
+    INSERT INTO exam_question (exam_id, question_title, description, option1, option2, option3, option4, section_id, question_order, content_format, explanation, points)
+    VALUES (p_exam_id, p_question_title, p_description, p_option1, p_option2, p_option3, p_option4, p_section_id, p_question_order, p_content_format, p_explanation, p_points)
+    RETURNING question_id INTO new_question_id;
+
+    RETURN new_question_id;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Sets when the students can review their attempts in the exam.
+-- Example usage:
+--      CALL set_exam_attempt_review_policy(
+--          p_exam_id := 1234,
+--          p_attempt_review_policy := 'after_release'
+--      );
+CREATE OR REPLACE PROCEDURE set_exam_attempt_review_policy(
+    p_exam_id INTEGER,
+    p_attempt_review_policy VARCHAR(16)
+)
+LANGUAGE plpgsql
+AS $$
+BEGIN
+    UPDATE exam_info
+    SET attempt_review_policy = p_attempt_review_policy
+    WHERE exam_id = p_exam_id;
+
+    IF NOT FOUND THEN
+        RAISE EXCEPTION 'Exam with ID % not found', p_exam_id;
+    END IF;
+END;
+$$;
+
+-- Releases the results of the exam (or withdraws them, if p_is_released
+-- is false).
+-- Example usage:
+--      CALL set_exam_results_released(
+--          p_exam_id := 1234,
+--          p_is_released := TRUE,
+--          p_released_by := 'teacher1'
+--      );
+CREATE OR REPLACE PROCEDURE set_exam_results_released(
+    p_exam_id INTEGER,
+    p_is_released BOOLEAN,
+    p_released_by VARCHAR(16) DEFAULT NULL
+)
+LANGUAGE plpgsql
+AS $$
+BEGIN
+    UPDATE exam_info
+    SET results_released_at = CASE WHEN p_is_released THEN CURRENT_TIMESTAMP ELSE NULL END,
+        results_released_by = CASE WHEN p_is_released THEN p_released_by ELSE NULL END
+    WHERE exam_id = p_exam_id;
+
+    IF NOT FOUND THEN
+        RAISE EXCEPTION 'Exam with ID % not found', p_exam_id;
+    END IF;
+END;
+$$;
+
+-- Grades an answer given in an attempt: sets the points it has earned (null
+-- to fall back to the answer key) and the comment of the grader on it.
+-- Example usage:
+--      CALL grade_given_answer(
+--          p_exam_id := 1234,
+--          p_question_id := 10,
+--          p_answered_by := 'student1',
+--          p_attempt_number := 1,
+--          p_points_earned := 1.5,
+--          p_grader_comment := 'Missing the second half of the proof.',
+--          p_graded_by := 'teacher1'
+--      );
+CREATE OR REPLACE PROCEDURE grade_given_answer(
+    p_exam_id INTEGER,
+    p_question_id INTEGER,
+    p_answered_by UserIdType,
+    p_attempt_number INTEGER,
+    p_points_earned DOUBLE PRECISION,
+    p_grader_comment TEXT,
+    p_graded_by VARCHAR(16)
+)
+LANGUAGE plpgsql
+AS $$
+BEGIN
+    UPDATE given_answer
+    SET points_earned = p_points_earned,
+        grader_comment = p_grader_comment,
+        graded_by = p_graded_by,
+        graded_at = CURRENT_TIMESTAMP
+    WHERE exam_id = p_exam_id
+        AND question_id = p_question_id
+        AND answered_by = p_answered_by
+        AND attempt_number = p_attempt_number;
+
+    IF NOT FOUND THEN
+        RAISE EXCEPTION 'No answer of user % to question % found in attempt % of exam %',
+            p_answered_by, p_question_id, p_attempt_number, p_exam_id;
+    END IF;
+END;
+$$;
+
+-- materialise_exam_series_occurrence now copies the attempt review policy of
+-- the template exam and the points of its questions.
+-- Example usage:
+--      SELECT materialise_exam_series_occurrence(1, '2024-06-03 10:00:00+00');
+CREATE OR REPLACE FUNCTION materialise_exam_series_occurrence(
+    p_series_id INTEGER,
+    p_occurrence_at TIMESTAMP WITH TIME ZONE
+) RETURNS INTEGER AS $$
+DECLARE
+    v_template_exam_id INTEGER;
+    v_occurrence RECORD;
+    v_section RECORD;
+    v_exam_date TIMESTAMP WITH TIME ZONE;
+    v_shift INTERVAL;
+    new_exam_id INTEGER;
+    new_section_id INTEGER;
+BEGIN
+    SELECT s.template_exam_id INTO v_template_exam_id
+    FROM exam_series s
+    WHERE s.series_id = p_series_id;
+
+    IF v_template_exam_id IS NULL THEN
+        RAISE EXCEPTION 'Exam series with ID % not found', p_series_id;
+    END IF;
+
+    SELECT o.exam_id, o.is_skipped, o.exam_title, o.exam_date, o.duration
+    INTO v_occurrence
+    FROM exam_series_occurrence o
+    WHERE o.series_id = p_series_id AND o.occurrence_at = p_occurrence_at
+    FOR UPDATE;
+
+    IF FOUND AND v_occurrence.exam_id IS NOT NULL THEN
+        RETURN v_occurrence.exam_id;
+    ELSIF FOUND AND v_occurrence.is_skipped THEN
+        RETURN NULL;
+    END IF;
+
+    v_exam_date := COALESCE(v_occurrence.exam_date, p_occurrence_at);
+
+    SELECT v_exam_date - e.exam_date INTO v_shift
+    FROM exam_info e
+    WHERE e.exam_id = v_template_exam_id;
+
+    INSERT INTO exam_info (
+        course_id,
+        exam_title,
+        exam_description,
+        price,
+        exam_date,
+        duration,
+        created_by,
+        is_public,
+        access_code_type,
+        access_code,
+        access_code_interval,
+        max_attempts,
+        attempt_cooldown,
+        grading_policy,
+        opens_at,
+        closes_at,
+        late_start_limit,
+        deadline_policy,
+        grace_period,
+        capacity,
+        is_adaptive,
+        adaptive_max_items,
+        adaptive_target_se,
+        review_status,
+        reviewed_by,
+        reviewed_at,
+        is_practice,
+        attempt_review_policy
+    )
+    SELECT
+        e.course_id,
+        COALESCE(v_occurrence.exam_title, e.exam_title),
+        e.exam_description,
+        e.price,
+        v_exam_date,
+        COALESCE(v_occurrence.duration, e.duration),
+        e.created_by,
+        e.is_public,
+        e.access_code_type,
+        e.access_code,
+        e.access_code_interval,
+        e.max_attempts,
+        e.attempt_cooldown,
+        e.grading_policy,
+        e.opens_at + v_shift,
+        e.closes_at + v_shift,
+        e.late_start_limit,
+        e.deadline_policy,
+        e.grace_period,
+        e.capacity,
+        e.is_adaptive,
+        e.adaptive_max_items,
+        e.adaptive_target_se,
+        e.review_status,
+        e.reviewed_by,
+        e.reviewed_at,
+        e.is_practice,
+        e.attempt_review_policy
+    FROM exam_info e
+    WHERE e.exam_id = v_template_exam_id
+    RETURNING exam_id INTO new_exam_id;
+
+    FOR v_section IN
+        SELECT * FROM exam_section
+        WHERE exam_id = v_template_exam_id
+        ORDER BY section_order, section_id
+    LOOP
+        new_section_id := create_exam_section(
+            p_exam_id := new_exam_id,
+            p_section_title := v_section.section_title,
+            p_section_order := v_section.section_order,
+            p_duration := v_section.duration,
+            p_is_forward_only := v_section.is_forward_only
+        );
+
+        INSERT INTO exam_question (
+            exam_id,
+            question_title,
+            description,
+            option1,
+            option2,
+            option3,
+            option4,
+            section_id,
+            question_order,
+            correct_option,
+            irt_discrimination,
+            irt_difficulty,
+            irt_guessing,
+            content_format,
+            tags,
+            difficulty_level,
+            learning_objectives,
+            topic_id,
+            explanation,
+            points
+        )
+        SELECT new_exam_id, q.question_title, q.description, q.option1, q.option2, q.option3, q.option4,
+            new_section_id, q.question_order, q.correct_option,
+            q.irt_discrimination, q.irt_difficulty, q.irt_guessing, q.content_format,
+            q.tags, q.difficulty_level, q.learning_objectives, q.topic_id, q.explanation, q.points
+        FROM exam_question q
+        WHERE q.exam_id = v_template_exam_id AND q.section_id = v_section.section_id
+        ORDER BY q.question_order, q.question_id;
+    END LOOP;
+
+    INSERT INTO exam_question (
+        exam_id,
+        question_title,
+        description,
+        option1,
+        option2,
+        option3,
+        option4,
+        question_order,
+        correct_option,
+        irt_discrimination,
+        irt_difficulty,
+        irt_guessing,
+        content_format,
+        tags,
+        difficulty_level,
+        learning_objectives,
+        topic_id,
+        explanation,
+        points
+    )
+    SELECT new_exam_id, q.question_title, q.description, q.option1, q.option2, q.option3, q.option4,
+        q.question_order, q.correct_option,
+        q.irt_discrimination, q.irt_difficulty, q.irt_guessing, q.content_format,
+        q.tags, q.difficulty_level, q.learning_objectives, q.topic_id, q.explanation, q.points
+    FROM exam_question q
+    WHERE q.exam_id = v_template_exam_id AND q.section_id IS NULL
+    ORDER BY q.question_order, q.question_id;
+
+    INSERT INTO exam_prerequisite (
+        exam_id,
+        prerequisite_type,
+        required_exam_id,
+        min_score,
+        topic_id,
+        min_level,
+        added_by
+    )
+    SELECT new_exam_id, p.prerequisite_type, p.required_exam_id, p.min_score, p.topic_id, p.min_level, p.added_by
+    FROM exam_prerequisite p
+    WHERE p.exam_id = v_template_exam_id;
+
+    INSERT INTO exam_collaborator (exam_id, user_id, collaborator_role, added_by)
+    SELECT new_exam_id, c.user_id, c.collaborator_role, c.added_by
+    FROM exam_collaborator c
+    WHERE c.exam_id = v_template_exam_id;
+
+    INSERT INTO exam_series_occurrence (series_id, occurrence_at, exam_id)
+    VALUES (p_series_id, p_occurrence_at, new_exam_id)
+    ON CONFLICT (series_id, occurrence_at)
+    DO UPDATE SET
+        exam_id = EXCLUDED.exam_id,
+        updated_at = CURRENT_TIMESTAMP;
+
+    RETURN new_exam_id;
+END;
+$$ LANGUAGE plpgsql;
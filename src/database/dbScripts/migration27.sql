@@ -0,0 +1,328 @@
+-- course_grade_category holds the grade categories of the courses (e.g.
+-- quizzes 20%, midterm 30%, final 50%). The course grade of a student is
+-- the weighted average of the averages of the categories, in which the
+-- drop_lowest lowest scores of each category are left out.
+CREATE TABLE IF NOT EXISTS "course_grade_category" (
+    category_id SERIAL PRIMARY KEY,
+    course_id INTEGER NOT NULL,
+    category_name VARCHAR(64) NOT NULL,
+    weight NUMERIC(5, 2) NOT NULL DEFAULT 0,
+    drop_lowest INTEGER NOT NULL DEFAULT 0,
+    added_by VARCHAR(16) DEFAULT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_course_id FOREIGN KEY (course_id) REFERENCES "course_info"(course_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_added_by FOREIGN KEY (added_by) REFERENCES "user_info"(user_id) ON DELETE SET NULL ON UPDATE CASCADE,
+    CONSTRAINT chk_weight CHECK (weight >= 0 AND weight <= 100),
+    CONSTRAINT chk_drop_lowest CHECK (drop_lowest >= 0),
+    CONSTRAINT uq_course_category_name UNIQUE (course_id, category_name)
+);
+
+COMMENT ON TABLE course_grade_category IS 'Stores the weighted grade categories of the courses';
+COMMENT ON COLUMN course_grade_category.category_id IS 'Unique identifier for the grade category';
+COMMENT ON COLUMN course_grade_category.course_id IS 'ID of the course the category belongs to';
+COMMENT ON COLUMN course_grade_category.category_name IS 'Name of the category (e.g. quizzes)';
+COMMENT ON COLUMN course_grade_category.weight IS 'Share of the category in the course grade (percentage)';
+COMMENT ON COLUMN course_grade_category.drop_lowest IS 'Number of the lowest scores of the category which are not counted';
+COMMENT ON COLUMN course_grade_category.added_by IS 'ID of the user who added the category';
+COMMENT ON COLUMN course_grade_category.created_at IS 'Timestamp when the category was added';
+
+-- grade_category_id is the grade category the exam is counted in; the exams
+-- without a category are not counted in the course grade.
+ALTER TABLE "exam_info" ADD COLUMN IF NOT EXISTS grade_category_id INTEGER DEFAULT NULL;
+ALTER TABLE "exam_info" ADD CONSTRAINT fk_grade_category_id FOREIGN KEY (grade_category_id)
+    REFERENCES "course_grade_category"(category_id) ON DELETE SET NULL ON UPDATE CASCADE;
+
+CREATE INDEX IF NOT EXISTS idx_exam_info_course_id ON "exam_info" (course_id);
+
+COMMENT ON COLUMN exam_info.grade_category_id IS 'ID of the grade category the exam is counted in (can be null)';
+
+-- Creates a new grade category in a course and returns its id.
+-- Example usage:
+--      SELECT create_course_grade_category(
+--          p_course_id := 1,
+--          p_category_name := 'Quizzes',
+--          p_weight := 20,
+--          p_drop_lowest := 1,
+--          p_added_by := 'teacher1'
+--      );
+CREATE OR REPLACE FUNCTION create_course_grade_category(
+    p_course_id INTEGER,
+    p_category_name VARCHAR(64),
+    p_weight NUMERIC(5, 2),
+    p_drop_lowest INTEGER,
+    p_added_by VARCHAR(16)
+) RETURNS INTEGER AS $$
+DECLARE
+    new_category_id INTEGER;
+BEGIN
+    INSERT INTO course_grade_category (course_id, category_name, weight, drop_lowest, added_by)
+    VALUES (p_course_id, p_category_name, p_weight, p_drop_lowest, p_added_by)
+    RETURNING category_id INTO new_category_id;
+
+    RETURN new_category_id;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Sets the grade category of an exam (or clears it, if p_category_id is
+-- null). The category has to belong to the course of the exam.
+-- Example usage:
+--      CALL set_exam_grade_category(
+--          p_exam_id := 1234,
+--          p_category_id := 2
+--      );
+CREATE OR REPLACE PROCEDURE set_exam_grade_category(
+    p_exam_id INTEGER,
+    p_category_id INTEGER
+)
+LANGUAGE plpgsql
+AS $$
+BEGIN
+    IF p_category_id IS NOT NULL AND NOT EXISTS (
+        SELECT 1
+        FROM course_grade_category c
+        JOIN exam_info e ON e.course_id = c.course_id
+        WHERE c.category_id = p_category_id AND e.exam_id = p_exam_id
+    ) THEN
+        RAISE EXCEPTION 'Grade category % does not belong to the course of exam %',
+            p_category_id, p_exam_id;
+    END IF;
+
+    UPDATE exam_info
+    SET grade_category_id = p_category_id
+    WHERE exam_id = p_exam_id;
+
+    IF NOT FOUND THEN
+        RAISE EXCEPTION 'Exam with ID % not found', p_exam_id;
+    END IF;
+END;
+$$;
+
+-- View to get the scores (and their percentages) of the participants of
+-- the graded (i.e. non-practice) exams of the courses.
+-- An example of using this view would be:
+--      SELECT user_id, exam_id, final_score, score_percentage
+--          FROM course_gradebook_scores
+--          WHERE course_id = 1;
+CREATE OR REPLACE VIEW course_gradebook_scores AS
+SELECT
+    e.course_id,
+    g.user_id,
+    g.exam_id,
+    g.final_score,
+    get_score_percentage(g.final_score) AS score_percentage
+FROM "given_exam" g
+JOIN "exam_info" e ON g.exam_id = e.exam_id
+WHERE e.is_practice IS NOT TRUE;
+
+COMMENT ON VIEW course_gradebook_scores IS 'View to get the scores of the participants of the graded exams of the courses';
+
+-- materialise_exam_series_occurrence now copies the grade category of the
+-- template exam too.
+-- Example usage:
+--      SELECT materialise_exam_series_occurrence(1, '2024-06-03 10:00:00+00');
+CREATE OR REPLACE FUNCTION materialise_exam_series_occurrence(
+    p_series_id INTEGER,
+    p_occurrence_at TIMESTAMP WITH TIME ZONE
+) RETURNS INTEGER AS $$
+DECLARE
+    v_template_exam_id INTEGER;
+    v_occurrence RECORD;
+    v_section RECORD;
+    v_exam_date TIMESTAMP WITH TIME ZONE;
+    v_shift INTERVAL;
+    new_exam_id INTEGER;
+    new_section_id INTEGER;
+BEGIN
+    SELECT s.template_exam_id INTO v_template_exam_id
+    FROM exam_series s
+    WHERE s.series_id = p_series_id;
+
+    IF v_template_exam_id IS NULL THEN
+        RAISE EXCEPTION 'Exam series with ID % not found', p_series_id;
+    END IF;
+
+    SELECT o.exam_id, o.is_skipped, o.exam_title, o.exam_date, o.duration
+    INTO v_occurrence
+    FROM exam_series_occurrence o
+    WHERE o.series_id = p_series_id AND o.occurrence_at = p_occurrence_at
+    FOR UPDATE;
+
+    IF FOUND AND v_occurrence.exam_id IS NOT NULL THEN
+        RETURN v_occurrence.exam_id;
+    ELSIF FOUND AND v_occurrence.is_skipped THEN
+        RETURN NULL;
+    END IF;
+
+    v_exam_date := COALESCE(v_occurrence.exam_date, p_occurrence_at);
+
+    SELECT v_exam_date - e.exam_date INTO v_shift
+    FROM exam_info e
+    WHERE e.exam_id = v_template_exam_id;
+
+    INSERT INTO exam_info (
+        course_id,
+        exam_title,
+        exam_description,
+        price,
+        exam_date,
+        duration,
+        created_by,
+        is_public,
+        access_code_type,
+        access_code,
+        access_code_interval,
+        max_attempts,
+        attempt_cooldown,
+        grading_policy,
+        opens_at,
+        closes_at,
+        late_start_limit,
+        deadline_policy,
+        grace_period,
+        capacity,
+        is_adaptive,
+        adaptive_max_items,
+        adaptive_target_se,
+        review_status,
+        reviewed_by,
+        reviewed_at,
+        is_practice,
+        attempt_review_policy,
+        grade_category_id
+    )
+    SELECT
+        e.course_id,
+        COALESCE(v_occurrence.exam_title, e.exam_title),
+        e.exam_description,
+        e.price,
+        v_exam_date,
+        COALESCE(v_occurrence.duration, e.duration),
+        e.created_by,
+        e.is_public,
+        e.access_code_type,
+        e.access_code,
+        e.access_code_interval,
+        e.max_attempts,
+        e.attempt_cooldown,
+        e.grading_policy,
+        e.opens_at + v_shift,
+        e.closes_at + v_shift,
+        e.late_start_limit,
+        e.deadline_policy,
+        e.grace_period,
+        e.capacity,
+        e.is_adaptive,
+        e.adaptive_max_items,
+        e.adaptive_target_se,
+        e.review_status,
+        e.reviewed_by,
+        e.reviewed_at,
+        e.is_practice,
+        e.attempt_review_policy,
+        e.grade_category_id
+    FROM exam_info e
+    WHERE e.exam_id = v_template_exam_id
+    RETURNING exam_id INTO new_exam_id;
+
+    FOR v_section IN
+        SELECT * FROM exam_section
+        WHERE exam_id = v_template_exam_id
+        ORDER BY section_order, section_id
+    LOOP
+        new_section_id := create_exam_section(
+            p_exam_id := new_exam_id,
+            p_section_title := v_section.section_title,
+            p_section_order := v_section.section_order,
+            p_duration := v_section.duration,
+            p_is_forward_only := v_section.is_forward_only
+        );
+
+        INSERT INTO exam_question (
+            exam_id,
+            question_title,
+            description,
+            option1,
+            option2,
+            option3,
+            option4,
+            section_id,
+            question_order,
+            correct_option,
+            irt_discrimination,
+            irt_difficulty,
+            irt_guessing,
+            content_format,
+            tags,
+            difficulty_level,
+            learning_objectives,
+            topic_id,
+            explanation,
+            points
+        )
+        SELECT new_exam_id, q.question_title, q.description, q.option1, q.option2, q.option3, q.option4,
+            new_section_id, q.question_order, q.correct_option,
+            q.irt_discrimination, q.irt_difficulty, q.irt_guessing, q.content_format,
+            q.tags, q.difficulty_level, q.learning_objectives, q.topic_id, q.explanation, q.points
+        FROM exam_question q
+        WHERE q.exam_id = v_template_exam_id AND q.section_id = v_section.section_id
+        ORDER BY q.question_order, q.question_id;
+    END LOOP;
+
+    INSERT INTO exam_question (
+        exam_id,
+        question_title,
+        description,
+        option1,
+        option2,
+        option3,
+        option4,
+        question_order,
+        correct_option,
+        irt_discrimination,
+        irt_difficulty,
+        irt_guessing,
+        content_format,
+        tags,
+        difficulty_level,
+        learning_objectives,
+        topic_id,
+        explanation,
+        points
+    )
+    SELECT new_exam_id, q.question_title, q.description, q.option1, q.option2, q.option3, q.option4,
+        q.question_order, q.correct_option,
+        q.irt_discrimination, q.irt_difficulty, q.irt_guessing, q.content_format,
+        q.tags, q.difficulty_level, q.learning_objectives, q.topic_id, q.explanation, q.points
+    FROM exam_question q
+    WHERE q.exam_id = v_template_exam_id AND q.section_id IS NULL
+    ORDER BY q.question_order, q.question_id;
+
+    INSERT INTO exam_prerequisite (
+        exam_id,
+        prerequisite_type,
+        required_exam_id,
+        min_score,
+        topic_id,
+        min_level,
+        added_by
+    )
+    SELECT new_exam_id, p.prerequisite_type, p.required_exam_id, p.min_score, p.topic_id, p.min_level, p.added_by
+    FROM exam_prerequisite p
+    WHERE p.exam_id = v_template_exam_id;
+
+    INSERT INTO exam_collaborator (exam_id, user_id, collaborator_role, added_by)
+    SELECT new_exam_id, c.user_id, c.collaborator_role, c.added_by
+    FROM exam_collaborator c
+    WHERE c.exam_id = v_template_exam_id;
+
+    INSERT INTO exam_series_occurrence (series_id, occurrence_at, exam_id)
+    VALUES (p_series_id, p_occurrence_at, new_exam_id)
+    ON CONFLICT (series_id, occurrence_at)
+    DO UPDATE SET
+        exam_id = EXCLUDED.exam_id,
+        updated_at = CURRENT_TIMESTAMP;
+
+    RETURN new_exam_id;
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,76 @@
+-- Records a wallet transaction moving p_amount from p_from_account to
+-- p_to_account and returns its id. If the user doesn't have enough balance,
+-- the chk_wallet_balance constraint makes the whole thing fail; if the
+-- currency of the transaction is not the currency of the wallets of the
+-- users it moves money from or to, an exception with ERRCODE 'EXC04' is
+-- raised, so different currencies never get mixed in one balance.
+-- Example usage:
+--     SELECT record_wallet_transaction(
+--         p_transaction_type := 'top_up',
+--         p_user_id := 'user123',
+--         p_amount := 10000,
+--         p_from_account := get_system_account_id('payment_provider'),
+--         p_to_account := get_wallet_account_id('user123'),
+--         p_provider := 'fake',
+--         p_provider_reference := 'fake_1',
+--         p_created_by := 'admin'
+--     );
+CREATE OR REPLACE FUNCTION record_wallet_transaction(
+    p_transaction_type VARCHAR(16),
+    p_user_id UserIdType,
+    p_amount BIGINT,
+    p_from_account INTEGER,
+    p_to_account INTEGER,
+    p_exam_id INTEGER DEFAULT NULL,
+    p_provider VARCHAR(32) DEFAULT NULL,
+    p_provider_reference VARCHAR(127) DEFAULT NULL,
+    p_refund_of BIGINT DEFAULT NULL,
+    p_description VARCHAR(255) DEFAULT NULL,
+    p_created_by VARCHAR(16) DEFAULT NULL,
+    p_currency VARCHAR(8) DEFAULT 'T'
+) RETURNS BIGINT AS $$
+DECLARE
+    v_transaction_id BIGINT;
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM wallet_account
+        WHERE account_id IN (p_from_account, p_to_account)
+            AND account_type = 'user'
+            AND currency <> p_currency
+    ) THEN
+        RAISE EXCEPTION 'Transaction currency % does not match the wallet currency', p_currency
+            USING ERRCODE = 'EXC04';
+    END IF;
+
+    INSERT INTO wallet_transaction (
+        transaction_type,
+        user_id,
+        exam_id,
+        amount,
+        currency,
+        provider,
+        provider_reference,
+        refund_of,
+        description,
+        created_by
+    ) VALUES (
+        p_transaction_type,
+        p_user_id,
+        p_exam_id,
+        p_amount,
+        p_currency,
+        p_provider,
+        p_provider_reference,
+        p_refund_of,
+        p_description,
+        p_created_by
+    ) RETURNING transaction_id INTO v_transaction_id;
+
+    INSERT INTO wallet_entry (transaction_id, account_id, amount)
+    VALUES
+        (v_transaction_id, p_from_account, -p_amount),
+        (v_transaction_id, p_to_account, p_amount);
+
+    RETURN v_transaction_id;
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,48 @@
+-- Returns true if the user can participate in the exam, false otherwise.
+-- Users who have not participated yet can only participate in exams which
+-- have been published, and only if they meet all of the prerequisites of
+-- the exam. The exam has to be public as well, unless the user is being
+-- added to it by someone else (p_added_by); whether they are allowed to
+-- add others to the exam is checked by the application.
+-- Example usage:
+--      SELECT can_participate_in_exam(1234, '5678');
+--      SELECT can_participate_in_exam(1234, '5678', 'teacher1');
+DROP FUNCTION IF EXISTS can_participate_in_exam(INTEGER, UserIdType);
+CREATE OR REPLACE FUNCTION can_participate_in_exam(
+    p_exam_id INTEGER,
+    p_user_id UserIdType,
+    p_added_by UserIdType DEFAULT NULL
+)
+RETURNS BOOLEAN AS $$
+DECLARE
+    is_exam_public BOOLEAN;
+    v_review_status VARCHAR(16);
+BEGIN
+    -- Just return true if the user already participated inside of this exam
+    IF has_participated_in_exam(p_exam_id, p_user_id) THEN
+        RETURN TRUE;
+    END IF;
+
+    SELECT "is_public", "review_status" INTO is_exam_public, v_review_status
+    FROM "exam_info"
+    WHERE exam_id = p_exam_id;
+
+    IF is_exam_public IS NULL THEN
+        RAISE EXCEPTION 'Exam with ID % not found', p_exam_id;
+    END IF;
+
+    IF v_review_status <> 'published' THEN
+        RETURN FALSE;
+    END IF;
+
+    IF NOT is_exam_public AND
+        (p_added_by IS NULL OR p_added_by = p_user_id) THEN
+        RETURN FALSE;
+    END IF;
+
+    RETURN NOT EXISTS (
+        SELECT 1
+        FROM get_unmet_exam_prerequisites(p_exam_id, p_user_id)
+    );
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,76 @@
+-- The attempts are bound to the device id of the client only; the ip
+-- address of a client can change in the middle of an exam (e.g. on mobile
+-- networks), so the changes are only recorded for the audits.
+ALTER TABLE "exam_attempt_binding"
+    ADD COLUMN IF NOT EXISTS last_ip_address VARCHAR(63) DEFAULT NULL,
+    ADD COLUMN IF NOT EXISTS ip_change_count INTEGER DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS last_ip_change_at TIMESTAMP WITH TIME ZONE DEFAULT NULL;
+
+COMMENT ON COLUMN exam_attempt_binding.ip_address IS 'IP address of the client when the attempt was bound to it';
+COMMENT ON COLUMN exam_attempt_binding.last_ip_address IS 'Last IP address of the bound client, if it has changed';
+COMMENT ON COLUMN exam_attempt_binding.ip_change_count IS 'Number of times the IP address of the bound client has changed';
+COMMENT ON COLUMN exam_attempt_binding.last_ip_change_at IS 'Timestamp of the last change of the IP address of the bound client';
+
+-- bind_exam_attempt binds the attempt of the user to the specified client
+-- if (and only if) it is not bound to any client yet. It returns the ip address
+-- (the one it was bound with and the last one) and the device id of the
+-- client the attempt is bound to.
+-- Example usage:
+--      SELECT * FROM bind_exam_attempt(
+--          p_exam_id := 1,
+--          p_user_id := '1234',
+--          p_ip_address := '127.0.0.1',
+--          p_device_id := 'a1b2c3d4'
+--      );
+DROP FUNCTION IF EXISTS bind_exam_attempt(INTEGER, UserIdType, VARCHAR(63), VARCHAR(127));
+CREATE OR REPLACE FUNCTION bind_exam_attempt(
+    p_exam_id INTEGER,
+    p_user_id UserIdType,
+    p_ip_address VARCHAR(63),
+    p_device_id VARCHAR(127)
+) RETURNS TABLE (
+    bound_ip_address VARCHAR(63),
+    bound_device_id VARCHAR(127),
+    bound_at TIMESTAMP WITH TIME ZONE,
+    mismatch_count INTEGER,
+    last_ip_address VARCHAR(63),
+    ip_change_count INTEGER
+) AS $$
+BEGIN
+    INSERT INTO exam_attempt_binding (exam_id, user_id, ip_address, device_id)
+    VALUES (p_exam_id, p_user_id, p_ip_address, p_device_id)
+    ON CONFLICT (exam_id, user_id) DO NOTHING;
+
+    RETURN QUERY
+    SELECT b.ip_address, b.device_id, b.bound_at, b.mismatch_count,
+        b.last_ip_address, b.ip_change_count
+    FROM exam_attempt_binding b
+    WHERE b.exam_id = p_exam_id AND b.user_id = p_user_id;
+END;
+$$ LANGUAGE plpgsql;
+
+-- record_exam_attempt_ip_change records a new ip address of the client the
+-- attempt is bound to; nothing is recorded if the ip address is the same
+-- as its last one.
+-- Example usage:
+--      CALL record_exam_attempt_ip_change(
+--          p_exam_id := 1,
+--          p_user_id := '1234',
+--          p_ip_address := '127.0.0.2'
+--      );
+CREATE OR REPLACE PROCEDURE record_exam_attempt_ip_change(
+    p_exam_id INTEGER,
+    p_user_id UserIdType,
+    p_ip_address VARCHAR(63)
+)
+LANGUAGE plpgsql
+AS $$
+BEGIN
+    UPDATE exam_attempt_binding
+    SET last_ip_address = p_ip_address,
+        ip_change_count = ip_change_count + 1,
+        last_ip_change_at = CURRENT_TIMESTAMP
+    WHERE exam_id = p_exam_id AND user_id = p_user_id
+        AND COALESCE(last_ip_address, ip_address) <> p_ip_address;
+END;
+$$;
@@ -0,0 +1,92 @@
+
+-- exam_attempt_binding holds the client (ip address + device id) that
+-- a user's exam attempt has been bound to. The first client that touches
+-- the exam (fetching questions or answering them) after it has started
+-- will be bound to the attempt; any other client will be rejected or
+-- flagged by the backend.
+CREATE TABLE IF NOT EXISTS "exam_attempt_binding" (
+    exam_id INTEGER NOT NULL,
+    user_id UserIdType,
+    ip_address VARCHAR(63) NOT NULL,
+    device_id VARCHAR(127) NOT NULL,
+    bound_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    mismatch_count INTEGER DEFAULT 0,
+    last_mismatch_ip VARCHAR(63) DEFAULT NULL,
+    last_mismatch_device VARCHAR(127) DEFAULT NULL,
+    last_mismatch_at TIMESTAMP WITH TIME ZONE DEFAULT NULL,
+    PRIMARY KEY (exam_id, user_id),
+
+    CONSTRAINT fk_exam FOREIGN KEY (exam_id) REFERENCES "exam_info"(exam_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_user FOREIGN KEY (user_id) REFERENCES "user_info"(user_id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+COMMENT ON TABLE exam_attempt_binding IS 'Stores the client each exam attempt is bound to';
+COMMENT ON COLUMN exam_attempt_binding.exam_id IS 'ID of the exam';
+COMMENT ON COLUMN exam_attempt_binding.user_id IS 'ID of the user taking the exam';
+COMMENT ON COLUMN exam_attempt_binding.ip_address IS 'IP address of the bound client';
+COMMENT ON COLUMN exam_attempt_binding.device_id IS 'Client-generated device id of the bound client';
+COMMENT ON COLUMN exam_attempt_binding.bound_at IS 'Timestamp when the attempt was bound to the client';
+COMMENT ON COLUMN exam_attempt_binding.mismatch_count IS 'Number of requests received from other clients';
+COMMENT ON COLUMN exam_attempt_binding.last_mismatch_ip IS 'IP address of the last foreign client';
+COMMENT ON COLUMN exam_attempt_binding.last_mismatch_device IS 'Device id of the last foreign client';
+COMMENT ON COLUMN exam_attempt_binding.last_mismatch_at IS 'Timestamp of the last request from a foreign client';
+
+-- bind_exam_attempt binds the attempt of the user to the specified client
+-- if (and only if) it is not bound to any client yet. It returns the ip address
+-- and the device id of the client the attempt is bound to.
+-- Example usage:
+--      SELECT * FROM bind_exam_attempt(
+--          p_exam_id := 1,
+--          p_user_id := '1234',
+--          p_ip_address := '127.0.0.1',
+--          p_device_id := 'a1b2c3d4'
+--      );
+CREATE OR REPLACE FUNCTION bind_exam_attempt(
+    p_exam_id INTEGER,
+    p_user_id UserIdType,
+    p_ip_address VARCHAR(63),
+    p_device_id VARCHAR(127)
+) RETURNS TABLE (
+    bound_ip_address VARCHAR(63),
+    bound_device_id VARCHAR(127),
+    bound_at TIMESTAMP WITH TIME ZONE,
+    mismatch_count INTEGER
+) AS $$
+BEGIN
+    INSERT INTO exam_attempt_binding (exam_id, user_id, ip_address, device_id)
+    VALUES (p_exam_id, p_user_id, p_ip_address, p_device_id)
+    ON CONFLICT (exam_id, user_id) DO NOTHING;
+
+    RETURN QUERY
+    SELECT b.ip_address, b.device_id, b.bound_at, b.mismatch_count
+    FROM exam_attempt_binding b
+    WHERE b.exam_id = p_exam_id AND b.user_id = p_user_id;
+END;
+$$ LANGUAGE plpgsql;
+
+-- flag_exam_attempt_mismatch records a request coming from a client other
+-- than the one the attempt is bound to.
+-- Example usage:
+--      CALL flag_exam_attempt_mismatch(
+--          p_exam_id := 1,
+--          p_user_id := '1234',
+--          p_ip_address := '127.0.0.2',
+--          p_device_id := 'e5f6g7h8'
+--      );
+CREATE OR REPLACE PROCEDURE flag_exam_attempt_mismatch(
+    p_exam_id INTEGER,
+    p_user_id UserIdType,
+    p_ip_address VARCHAR(63),
+    p_device_id VARCHAR(127)
+)
+LANGUAGE plpgsql
+AS $$
+BEGIN
+    UPDATE exam_attempt_binding
+    SET mismatch_count = mismatch_count + 1,
+        last_mismatch_ip = p_ip_address,
+        last_mismatch_device = p_device_id,
+        last_mismatch_at = CURRENT_TIMESTAMP
+    WHERE exam_id = p_exam_id AND user_id = p_user_id;
+END;
+$$;
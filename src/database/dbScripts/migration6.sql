@@ -0,0 +1,51 @@
+
+-- Exams can optionally be protected by an access code, so students can only
+-- join (or start) them after the invigilator announces the code.
+-- The code can either be static, or rotating; in case of a rotating code,
+-- access_code holds the secret which is used to generate the code for
+-- each interval.
+ALTER TABLE "exam_info" ADD COLUMN IF NOT EXISTS access_code_type VARCHAR(15) NOT NULL DEFAULT 'none';
+ALTER TABLE "exam_info" ADD COLUMN IF NOT EXISTS access_code VARCHAR(63) DEFAULT NULL;
+ALTER TABLE "exam_info" ADD COLUMN IF NOT EXISTS access_code_interval INTEGER NOT NULL DEFAULT 5;
+
+ALTER TABLE "exam_info" ADD CONSTRAINT check_access_code_type
+    CHECK (access_code_type IN ('none', 'static', 'rotating'));
+ALTER TABLE "exam_info" ADD CONSTRAINT check_access_code_interval
+    CHECK (access_code_interval > 0);
+
+COMMENT ON COLUMN "exam_info".access_code_type IS 'Type of the access code of the exam (none, static or rotating)';
+COMMENT ON COLUMN "exam_info".access_code IS 'The static access code, or the secret used to generate the rotating codes';
+COMMENT ON COLUMN "exam_info".access_code_interval IS 'Interval (in minutes) in which a rotating access code changes';
+
+-- Sets the access code settings of an exam.
+-- Example usage:
+--    CALL set_exam_access_code(
+--        p_exam_id := 1001,
+--        p_access_code_type := 'rotating',
+--        p_access_code := 'some-random-secret',
+--        p_access_code_interval := 5
+--    );
+CREATE OR REPLACE PROCEDURE set_exam_access_code(
+    p_exam_id INTEGER,
+    p_access_code_type VARCHAR(15),
+    p_access_code VARCHAR(63) DEFAULT NULL,
+    p_access_code_interval INTEGER DEFAULT 5
+)
+LANGUAGE plpgsql
+AS $$
+BEGIN
+    IF p_access_code_type <> 'none' AND p_access_code IS NULL THEN
+        RAISE EXCEPTION 'Access code of type % cannot be empty', p_access_code_type;
+    END IF;
+
+    UPDATE exam_info
+    SET access_code_type = p_access_code_type,
+        access_code = p_access_code,
+        access_code_interval = p_access_code_interval
+    WHERE exam_id = p_exam_id;
+
+    IF NOT FOUND THEN
+        RAISE EXCEPTION 'Exam with ID % not found', p_exam_id;
+    END IF;
+END;
+$$;
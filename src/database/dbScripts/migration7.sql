@@ -0,0 +1,226 @@
+
+-- exam_prerequisite holds the rules a user has to meet before being able to
+-- participate in an exam. A rule can either require the user to have passed
+-- another exam (with a minimum score percentage), or to have reached a
+-- minimum level in a topic (using user_topic_stat.current_level).
+CREATE TABLE IF NOT EXISTS "exam_prerequisite" (
+    prerequisite_id SERIAL PRIMARY KEY,
+    exam_id INTEGER NOT NULL,
+    prerequisite_type VARCHAR(16) NOT NULL,
+    required_exam_id INTEGER DEFAULT NULL,
+    min_score NUMERIC(5, 2) DEFAULT NULL,
+    topic_id INTEGER DEFAULT NULL,
+    min_level INTEGER DEFAULT NULL,
+    added_by UserIdType,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_exam FOREIGN KEY (exam_id) REFERENCES "exam_info"(exam_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_required_exam FOREIGN KEY (required_exam_id) REFERENCES "exam_info"(exam_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_topic FOREIGN KEY (topic_id) REFERENCES "topic_info"(topic_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_added_by FOREIGN KEY (added_by) REFERENCES "user_info"(user_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT chk_prerequisite_type CHECK (
+        (prerequisite_type = 'exam' AND required_exam_id IS NOT NULL AND required_exam_id <> exam_id) OR
+        (prerequisite_type = 'topic_level' AND topic_id IS NOT NULL AND min_level IS NOT NULL)
+    )
+);
+
+COMMENT ON TABLE exam_prerequisite IS 'Stores the rules a user has to meet before participating in an exam';
+COMMENT ON COLUMN exam_prerequisite.prerequisite_id IS 'Unique identifier for the prerequisite';
+COMMENT ON COLUMN exam_prerequisite.exam_id IS 'ID of the exam this prerequisite belongs to';
+COMMENT ON COLUMN exam_prerequisite.prerequisite_type IS 'Type of the prerequisite (exam or topic_level)';
+COMMENT ON COLUMN exam_prerequisite.required_exam_id IS 'ID of the exam that has to be passed (exam type only)';
+COMMENT ON COLUMN exam_prerequisite.min_score IS 'Minimum score percentage needed in the required exam (exam type only)';
+COMMENT ON COLUMN exam_prerequisite.topic_id IS 'ID of the topic (topic_level type only)';
+COMMENT ON COLUMN exam_prerequisite.min_level IS 'Minimum level needed in the topic (topic_level type only)';
+COMMENT ON COLUMN exam_prerequisite.added_by IS 'ID of the user who added this prerequisite';
+COMMENT ON COLUMN exam_prerequisite.created_at IS 'Timestamp when the prerequisite was added';
+
+-- get_score_percentage converts a final score (which is decided by teachers
+-- in a free format) to a percentage. Supported formats are "85/100", "85%"
+-- and "85" (which is considered to be a percentage already).
+-- It returns NULL if the score cannot be parsed.
+-- Example usage:
+--      SELECT get_score_percentage('17/20');
+CREATE OR REPLACE FUNCTION get_score_percentage(p_score VARCHAR(63))
+RETURNS NUMERIC AS $$
+DECLARE
+    score_parts TEXT[];
+BEGIN
+    IF p_score IS NULL THEN
+        RETURN NULL;
+    END IF;
+
+    score_parts := regexp_match(
+        p_score,
+        '^\s*([0-9]+(?:\.[0-9]+)?)\s*/\s*([0-9]+(?:\.[0-9]+)?)\s*$'
+    );
+    IF score_parts IS NOT NULL THEN
+        IF score_parts[2]::NUMERIC = 0 THEN
+            RETURN NULL;
+        END IF;
+
+        RETURN score_parts[1]::NUMERIC * 100 / score_parts[2]::NUMERIC;
+    END IF;
+
+    score_parts := regexp_match(p_score, '^\s*([0-9]+(?:\.[0-9]+)?)\s*%?\s*$');
+    IF score_parts IS NOT NULL THEN
+        RETURN score_parts[1]::NUMERIC;
+    END IF;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql IMMUTABLE;
+
+-- get_unmet_exam_prerequisites returns the prerequisites of the exam that
+-- the user has not met yet, alongside the current value of the user for each
+-- of them (best score percentage in the required exam, or current level
+-- in the topic).
+-- Example usage:
+--      SELECT * FROM get_unmet_exam_prerequisites(1234, '5678');
+CREATE OR REPLACE FUNCTION get_unmet_exam_prerequisites(p_exam_id INTEGER, p_user_id UserIdType)
+RETURNS TABLE (
+    prerequisite_id INTEGER,
+    prerequisite_type VARCHAR(16),
+    required_exam_id INTEGER,
+    min_score NUMERIC(5, 2),
+    topic_id INTEGER,
+    min_level INTEGER,
+    current_value NUMERIC
+) AS $$
+BEGIN
+    RETURN QUERY
+    SELECT p.prerequisite_id,
+        p.prerequisite_type,
+        p.required_exam_id,
+        p.min_score,
+        p.topic_id,
+        p.min_level,
+        v.current_value
+    FROM exam_prerequisite p
+    CROSS JOIN LATERAL (
+        SELECT CASE p.prerequisite_type
+            WHEN 'exam' THEN (
+                SELECT MAX(get_score_percentage(g.final_score))
+                FROM given_exam g
+                WHERE g.exam_id = p.required_exam_id AND g.user_id = p_user_id
+            )
+            ELSE (
+                SELECT s.current_level::NUMERIC
+                FROM user_topic_stat s
+                WHERE s.topic_id = p.topic_id AND s.user_id = p_user_id
+            )
+        END AS current_value
+    ) v
+    WHERE p.exam_id = p_exam_id
+        AND (
+            v.current_value IS NULL OR
+            (p.prerequisite_type = 'exam' AND v.current_value < COALESCE(p.min_score, 0)) OR
+            (p.prerequisite_type = 'topic_level' AND v.current_value < p.min_level)
+        )
+    ORDER BY p.prerequisite_id;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Returns true if the user can participate in the exam, false otherwise.
+-- Users who have not participated yet can only participate in public exams
+-- and only if they meet all of the prerequisites of the exam.
+-- Example usage:
+--      SELECT can_participate_in_exam(1234, '5678');
+CREATE OR REPLACE FUNCTION can_participate_in_exam(p_exam_id INTEGER, p_user_id UserIdType)
+RETURNS BOOLEAN AS $$
+DECLARE
+    is_exam_public BOOLEAN;
+BEGIN
+    -- Just return true if the user already participated inside of this exam
+    IF has_participated_in_exam(p_exam_id, p_user_id) THEN
+        RETURN TRUE;
+    END IF;
+
+    SELECT "is_public" INTO is_exam_public
+    FROM "exam_info"
+    WHERE exam_id = p_exam_id;
+
+    IF is_exam_public IS NULL THEN
+        RAISE EXCEPTION 'Exam with ID % not found', p_exam_id;
+    END IF;
+
+    IF NOT is_exam_public THEN
+        RETURN FALSE;
+    END IF;
+
+    RETURN NOT EXISTS (
+        SELECT 1
+        FROM get_unmet_exam_prerequisites(p_exam_id, p_user_id)
+    );
+END;
+$$ LANGUAGE plpgsql;
+
+-- add_exam_prerequisite adds a new prerequisite to the exam and returns
+-- its id.
+-- Example usage:
+--      SELECT add_exam_prerequisite(
+--          p_exam_id := 1234,
+--          p_prerequisite_type := 'exam',
+--          p_required_exam_id := 1233,
+--          p_min_score := 50,
+--          p_topic_id := NULL,
+--          p_min_level := NULL,
+--          p_added_by := '5678'
+--      );
+CREATE OR REPLACE FUNCTION add_exam_prerequisite(
+    p_exam_id INTEGER,
+    p_prerequisite_type VARCHAR(16),
+    p_required_exam_id INTEGER,
+    p_min_score NUMERIC(5, 2),
+    p_topic_id INTEGER,
+    p_min_level INTEGER,
+    p_added_by UserIdType
+) RETURNS INTEGER AS $$
+DECLARE
+    new_prerequisite_id INTEGER;
+BEGIN
+    INSERT INTO exam_prerequisite (
+        exam_id,
+        prerequisite_type,
+        required_exam_id,
+        min_score,
+        topic_id,
+        min_level,
+        added_by
+    )
+    VALUES (
+        p_exam_id,
+        p_prerequisite_type,
+        p_required_exam_id,
+        p_min_score,
+        p_topic_id,
+        p_min_level,
+        p_added_by
+    )
+    RETURNING prerequisite_id INTO new_prerequisite_id;
+
+    RETURN new_prerequisite_id;
+END;
+$$ LANGUAGE plpgsql;
+
+-- remove_exam_prerequisite removes a prerequisite from the exam.
+-- Example usage:
+--      CALL remove_exam_prerequisite(
+--          p_exam_id := 1234,
+--          p_prerequisite_id := 1
+--      );
+CREATE OR REPLACE PROCEDURE remove_exam_prerequisite(
+    p_exam_id INTEGER,
+    p_prerequisite_id INTEGER
+)
+LANGUAGE plpgsql
+AS $$
+BEGIN
+    DELETE FROM exam_prerequisite
+    WHERE exam_id = p_exam_id AND prerequisite_id = p_prerequisite_id;
+
+    IF NOT FOUND THEN
+        RAISE EXCEPTION 'Prerequisite % not found in exam %', p_prerequisite_id, p_exam_id;
+    END IF;
+END;
+$$;
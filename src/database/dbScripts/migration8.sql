@@ -0,0 +1,395 @@
+
+-- Retake policy of the exams:
+--  max_attempts: how many times a user can take the exam (0 means unlimited).
+--  attempt_cooldown: minutes a user has to wait after finishing an attempt
+--      before starting the next one.
+--  grading_policy: how the final score of the user is decided when they have
+--      more than one scored attempt; it can be one of 'best', 'latest' or 'average'.
+ALTER TABLE "exam_info" ADD COLUMN IF NOT EXISTS max_attempts INTEGER DEFAULT 1;
+ALTER TABLE "exam_info" ADD COLUMN IF NOT EXISTS attempt_cooldown INTEGER DEFAULT 0;
+ALTER TABLE "exam_info" ADD COLUMN IF NOT EXISTS grading_policy VARCHAR(16) DEFAULT 'latest';
+ALTER TABLE "exam_info" ADD CONSTRAINT chk_max_attempts CHECK (max_attempts >= 0);
+ALTER TABLE "exam_info" ADD CONSTRAINT chk_attempt_cooldown CHECK (attempt_cooldown >= 0);
+ALTER TABLE "exam_info" ADD CONSTRAINT chk_grading_policy CHECK (
+    grading_policy IN ('best', 'latest', 'average')
+);
+
+COMMENT ON COLUMN exam_info.max_attempts IS 'Maximum number of attempts a user can have in the exam (0 means unlimited)';
+COMMENT ON COLUMN exam_info.attempt_cooldown IS 'Minutes a user has to wait between two attempts';
+COMMENT ON COLUMN exam_info.grading_policy IS 'How the final score is decided from the attempts (best, latest or average)';
+
+-- exam_attempt holds every attempt of a user in an exam. The answers and the
+-- score of each attempt are kept separately; given_exam.final_score is then
+-- decided based on the grading policy of the exam.
+CREATE TABLE IF NOT EXISTS "exam_attempt" (
+    exam_id INTEGER NOT NULL,
+    user_id UserIdType,
+    attempt_number INTEGER NOT NULL,
+    started_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    finished_at TIMESTAMP WITH TIME ZONE DEFAULT NULL,
+    final_score VARCHAR(63) DEFAULT NULL,
+    scored_by VARCHAR(16) DEFAULT NULL,
+    PRIMARY KEY (exam_id, user_id, attempt_number),
+
+    CONSTRAINT fk_given_exam FOREIGN KEY (user_id, exam_id) REFERENCES "given_exam"(user_id, exam_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_scored_by FOREIGN KEY (scored_by) REFERENCES "user_info"(user_id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+COMMENT ON TABLE exam_attempt IS 'Stores every attempt of the users in the exams';
+COMMENT ON COLUMN exam_attempt.exam_id IS 'ID of the exam';
+COMMENT ON COLUMN exam_attempt.user_id IS 'ID of the user taking the exam';
+COMMENT ON COLUMN exam_attempt.attempt_number IS 'Number of the attempt (starting from 1)';
+COMMENT ON COLUMN exam_attempt.started_at IS 'Timestamp when the attempt was started';
+COMMENT ON COLUMN exam_attempt.finished_at IS 'Timestamp when the user finished the attempt (can be null)';
+COMMENT ON COLUMN exam_attempt.final_score IS 'Score of the user in this attempt; has to be decided by teacher';
+COMMENT ON COLUMN exam_attempt.scored_by IS 'ID of the user (teacher) who scored this attempt (can be null)';
+
+-- Existing entries are considered to be the first attempt of the users
+INSERT INTO exam_attempt (exam_id, user_id, attempt_number, started_at, final_score, scored_by)
+SELECT g.exam_id, g.user_id, 1, COALESCE(b.bound_at, g.created_at), g.final_score, g.scored_by
+FROM given_exam g
+LEFT JOIN exam_attempt_binding b ON b.exam_id = g.exam_id AND b.user_id = g.user_id
+WHERE g.final_score IS NOT NULL
+    OR b.exam_id IS NOT NULL
+    OR EXISTS (
+        SELECT 1 FROM given_answer a
+        WHERE a.exam_id = g.exam_id AND a.answered_by = g.user_id
+    )
+ON CONFLICT DO NOTHING;
+
+-- Each attempt keeps its own answers
+ALTER TABLE "given_answer" ADD COLUMN IF NOT EXISTS attempt_number INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE "given_answer" DROP CONSTRAINT IF EXISTS given_answer_pkey;
+ALTER TABLE "given_answer" ADD CONSTRAINT given_answer_pkey PRIMARY KEY (exam_id, question_id, answered_by, attempt_number);
+
+COMMENT ON COLUMN given_answer.attempt_number IS 'Number of the attempt this answer belongs to';
+
+-- Drop the old versions of the functions/procedures whose signature is changed
+DO
+$$
+DECLARE
+    r RECORD;
+BEGIN
+    FOR r IN SELECT proname, prokind, pg_get_function_identity_arguments(p.oid) AS args
+             FROM pg_proc p
+             JOIN pg_namespace n ON p.pronamespace = n.oid
+             WHERE n.nspname NOT IN ('pg_catalog', 'information_schema')
+             AND pg_function_is_visible(p.oid)
+             AND proname IN ('give_answer_to_exam_question', 'set_score_for_user_in_exam')
+    LOOP
+        IF r.prokind = 'p' THEN
+            EXECUTE format('DROP PROCEDURE IF EXISTS %I(%s);', r.proname, r.args);
+        ELSE
+            EXECUTE format('DROP FUNCTION IF EXISTS %I(%s);', r.proname, r.args);
+        END IF;
+    END LOOP;
+END
+$$;
+
+-- start_exam_attempt starts a new attempt for the user in the exam and
+-- returns its number. The client binding of the previous attempt (if any)
+-- is removed, so the new attempt can get bound to a new client.
+-- Example usage:
+--      SELECT * FROM start_exam_attempt(
+--          p_exam_id := 1,
+--          p_user_id := '1234'
+--      );
+CREATE OR REPLACE FUNCTION start_exam_attempt(
+    p_exam_id INTEGER,
+    p_user_id UserIdType
+) RETURNS TABLE (
+    attempt_number INTEGER,
+    started_at TIMESTAMP WITH TIME ZONE
+) AS $$
+DECLARE
+    v_max_attempts INTEGER;
+    v_attempt_cooldown INTEGER;
+    v_last_attempt INTEGER;
+    v_last_finished_at TIMESTAMP WITH TIME ZONE;
+BEGIN
+    IF NOT has_participated_in_exam(p_exam_id, p_user_id) THEN
+        RAISE EXCEPTION 'User has not participated in exam % yet', p_exam_id;
+    END IF;
+
+    SELECT e.max_attempts, e.attempt_cooldown
+    INTO v_max_attempts, v_attempt_cooldown
+    FROM exam_info e
+    WHERE e.exam_id = p_exam_id;
+
+    SELECT a.attempt_number, a.finished_at
+    INTO v_last_attempt, v_last_finished_at
+    FROM exam_attempt a
+    WHERE a.exam_id = p_exam_id AND a.user_id = p_user_id
+    ORDER BY a.attempt_number DESC
+    LIMIT 1;
+
+    IF v_last_attempt IS NULL THEN
+        v_last_attempt := 0;
+    ELSIF v_last_finished_at IS NULL THEN
+        RAISE EXCEPTION 'Attempt % of user % in exam % is not finished yet', v_last_attempt, p_user_id, p_exam_id;
+    ELSIF v_max_attempts > 0 AND v_last_attempt >= v_max_attempts THEN
+        RAISE EXCEPTION 'User % has no attempts left in exam %', p_user_id, p_exam_id;
+    ELSIF v_last_finished_at + (v_attempt_cooldown || ' minutes')::INTERVAL > CURRENT_TIMESTAMP THEN
+        RAISE EXCEPTION 'User % has to wait before retaking exam %', p_user_id, p_exam_id;
+    END IF;
+
+    DELETE FROM exam_attempt_binding b
+    WHERE b.exam_id = p_exam_id AND b.user_id = p_user_id;
+
+    RETURN QUERY
+    INSERT INTO exam_attempt AS a (exam_id, user_id, attempt_number)
+    VALUES (p_exam_id, p_user_id, v_last_attempt + 1)
+    RETURNING a.attempt_number, a.started_at;
+END;
+$$ LANGUAGE plpgsql;
+
+-- finish_exam_attempt marks the attempt of the user as finished.
+-- Example usage:
+--      CALL finish_exam_attempt(
+--          p_exam_id := 1,
+--          p_user_id := '1234',
+--          p_attempt_number := 1
+--      );
+CREATE OR REPLACE PROCEDURE finish_exam_attempt(
+    p_exam_id INTEGER,
+    p_user_id UserIdType,
+    p_attempt_number INTEGER
+)
+LANGUAGE plpgsql
+AS $$
+BEGIN
+    UPDATE exam_attempt
+    SET finished_at = CURRENT_TIMESTAMP
+    WHERE exam_id = p_exam_id
+        AND user_id = p_user_id
+        AND attempt_number = p_attempt_number
+        AND finished_at IS NULL;
+
+    IF NOT FOUND THEN
+        RAISE EXCEPTION 'No ongoing attempt % found for user % in exam %', p_attempt_number, p_user_id, p_exam_id;
+    END IF;
+END;
+$$;
+
+-- get_exam_final_score decides the final score of the user in the exam
+-- based on the scored attempts and the grading policy of the exam.
+-- The 'best' and 'average' policies need the scores to be parsable by
+-- get_score_percentage; if none of them are, the latest score is used.
+-- Example usage:
+--      SELECT get_exam_final_score(1, '1234');
+CREATE OR REPLACE FUNCTION get_exam_final_score(p_exam_id INTEGER, p_user_id UserIdType)
+RETURNS VARCHAR(63) AS $$
+DECLARE
+    v_grading_policy VARCHAR(16);
+    v_final_score VARCHAR(63);
+    v_average NUMERIC;
+BEGIN
+    SELECT grading_policy INTO v_grading_policy
+    FROM exam_info
+    WHERE exam_id = p_exam_id;
+
+    IF v_grading_policy = 'best' THEN
+        SELECT a.final_score INTO v_final_score
+        FROM exam_attempt a
+        WHERE a.exam_id = p_exam_id AND a.user_id = p_user_id
+            AND get_score_percentage(a.final_score) IS NOT NULL
+        ORDER BY get_score_percentage(a.final_score) DESC, a.attempt_number DESC
+        LIMIT 1;
+    ELSIF v_grading_policy = 'average' THEN
+        SELECT AVG(get_score_percentage(a.final_score)) INTO v_average
+        FROM exam_attempt a
+        WHERE a.exam_id = p_exam_id AND a.user_id = p_user_id;
+
+        IF v_average IS NOT NULL THEN
+            v_final_score := ROUND(v_average, 2)::TEXT || '%';
+        END IF;
+    END IF;
+
+    IF v_final_score IS NULL THEN
+        SELECT a.final_score INTO v_final_score
+        FROM exam_attempt a
+        WHERE a.exam_id = p_exam_id AND a.user_id = p_user_id
+            AND a.final_score IS NOT NULL
+        ORDER BY a.attempt_number DESC
+        LIMIT 1;
+    END IF;
+
+    RETURN v_final_score;
+END;
+$$ LANGUAGE plpgsql;
+
+-- set_exam_retake_policy sets the retake policy of the exam, and updates the
+-- final score of the participants based on the new grading policy.
+-- Example usage:
+--      CALL set_exam_retake_policy(
+--          p_exam_id := 1,
+--          p_max_attempts := 3,
+--          p_attempt_cooldown := 60,
+--          p_grading_policy := 'best'
+--      );
+CREATE OR REPLACE PROCEDURE set_exam_retake_policy(
+    p_exam_id INTEGER,
+    p_max_attempts INTEGER,
+    p_attempt_cooldown INTEGER,
+    p_grading_policy VARCHAR(16)
+)
+LANGUAGE plpgsql
+AS $$
+BEGIN
+    UPDATE exam_info
+    SET max_attempts = p_max_attempts,
+        attempt_cooldown = p_attempt_cooldown,
+        grading_policy = p_grading_policy
+    WHERE exam_id = p_exam_id;
+
+    IF NOT FOUND THEN
+        RAISE EXCEPTION 'Exam with ID % not found', p_exam_id;
+    END IF;
+
+    UPDATE given_exam g
+    SET final_score = get_exam_final_score(g.exam_id, g.user_id)
+    WHERE g.exam_id = p_exam_id AND g.final_score IS NOT NULL;
+END;
+$$;
+
+-- Sets the score of an attempt of the user in the exam (the latest attempt if
+-- p_attempt_number is NULL), and updates final_score and scored_by of the
+-- given_exam based on the grading policy of the exam.
+-- If the user has no attempts at all (e.g. they have been added to the exam
+-- but never took it), an empty finished attempt is created for them.
+-- Example usage:
+--    CALL set_score_for_user_in_exam(
+--        p_exam_id := 1001,
+--        p_user_id := 'user123',
+--        p_final_score := '85/100',
+--        p_scored_by := 'teacher1',
+--        p_attempt_number := 2
+--    );
+CREATE OR REPLACE PROCEDURE set_score_for_user_in_exam(
+    p_exam_id INTEGER,
+    p_user_id UserIdType,
+    p_final_score VARCHAR(63),
+    p_scored_by VARCHAR(16),
+    p_attempt_number INTEGER DEFAULT NULL
+)
+LANGUAGE plpgsql
+AS $$
+DECLARE
+    v_attempt_number INTEGER := p_attempt_number;
+BEGIN
+    IF NOT EXISTS (
+        SELECT 1 FROM given_exam
+        WHERE exam_id = p_exam_id AND user_id = p_user_id
+    ) THEN
+        RAISE EXCEPTION 'No exam entry found for user % in exam %', p_user_id, p_exam_id;
+    END IF;
+
+    IF v_attempt_number IS NULL THEN
+        SELECT MAX(attempt_number) INTO v_attempt_number
+        FROM exam_attempt
+        WHERE exam_id = p_exam_id AND user_id = p_user_id;
+
+        IF v_attempt_number IS NULL THEN
+            v_attempt_number := 1;
+            INSERT INTO exam_attempt (exam_id, user_id, attempt_number, finished_at)
+            VALUES (p_exam_id, p_user_id, v_attempt_number, CURRENT_TIMESTAMP);
+        END IF;
+    END IF;
+
+    UPDATE exam_attempt
+    SET final_score = p_final_score,
+        scored_by = p_scored_by
+    WHERE exam_id = p_exam_id
+        AND user_id = p_user_id
+        AND attempt_number = v_attempt_number;
+
+    IF NOT FOUND THEN
+        RAISE EXCEPTION 'Attempt % not found for user % in exam %', v_attempt_number, p_user_id, p_exam_id;
+    END IF;
+
+    UPDATE given_exam
+    SET final_score = get_exam_final_score(p_exam_id, p_user_id),
+        scored_by = p_scored_by
+    WHERE exam_id = p_exam_id AND user_id = p_user_id;
+END;
+$$;
+
+-- give_answer_to_exam_question function is used to insert or update
+-- an answer given by a user to an exam question, in the specified attempt
+-- (or the ongoing attempt of the user if p_attempt_number is NULL).
+-- Example usage:
+--      SELECT give_answer_to_exam_question(
+--          p_exam_id := 1,
+--          p_question_id := 1,
+--          p_answered_by := '1234',
+--          p_chosen_option := 'A',
+--          p_seconds_taken := 30,
+--          p_answer_text := NULL,
+--          p_attempt_number := 1
+--      );
+CREATE OR REPLACE FUNCTION give_answer_to_exam_question(
+    p_exam_id INTEGER,
+    p_question_id INTEGER,
+    p_answered_by UserIdType,
+    p_chosen_option TEXT DEFAULT NULL,
+    p_seconds_taken INTEGER DEFAULT 0,
+    p_answer_text TEXT DEFAULT NULL,
+    p_attempt_number INTEGER DEFAULT NULL
+) RETURNS VOID AS $$
+DECLARE
+    v_attempt_number INTEGER := p_attempt_number;
+BEGIN
+    -- Check if the user has participated in the exam
+    IF NOT has_participated_in_exam(p_exam_id, p_answered_by) THEN
+        RAISE EXCEPTION 'User has not participated in exam % yet', p_exam_id;
+    END IF;
+
+    -- Check if the exam has finished
+    IF has_exam_finished(p_exam_id) THEN
+        RAISE EXCEPTION 'Exam % has already finished', p_exam_id;
+    END IF;
+
+    IF v_attempt_number IS NULL THEN
+        SELECT attempt_number INTO v_attempt_number
+        FROM exam_attempt
+        WHERE exam_id = p_exam_id AND user_id = p_answered_by
+        ORDER BY attempt_number DESC
+        LIMIT 1;
+    END IF;
+
+    -- Answers can only be given to an ongoing attempt
+    IF NOT EXISTS (
+        SELECT 1 FROM exam_attempt
+        WHERE exam_id = p_exam_id
+            AND user_id = p_answered_by
+            AND attempt_number = v_attempt_number
+            AND finished_at IS NULL
+    ) THEN
+        RAISE EXCEPTION 'User % has no ongoing attempt in exam %', p_answered_by, p_exam_id;
+    END IF;
+
+    INSERT INTO given_answer (
+        exam_id,
+        question_id,
+        answered_by,
+        chosen_option,
+        seconds_taken,
+        answer_text,
+        attempt_number
+    )
+    VALUES (
+        p_exam_id,
+        p_question_id,
+        p_answered_by,
+        p_chosen_option,
+        p_seconds_taken,
+        p_answer_text,
+        v_attempt_number
+    )
+    ON CONFLICT (exam_id, question_id, answered_by, attempt_number)
+    DO UPDATE SET -- Just update the answer if it already exists
+        chosen_option = EXCLUDED.chosen_option,
+        answer_text = EXCLUDED.answer_text,
+        answered_at = CURRENT_TIMESTAMP;
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,271 @@
+
+-- exam_accommodation holds per-user overrides of the exam timing, for the
+-- students with documented accommodations. The effective window of the user
+-- is calculated like this:
+--  start: custom_start (or exam_date if not set)
+--  end:   custom_end (or start + duration * time_multiplier + extra_minutes
+--         if not set)
+CREATE TABLE IF NOT EXISTS "exam_accommodation" (
+    exam_id INTEGER NOT NULL,
+    user_id UserIdType,
+    extra_minutes INTEGER DEFAULT 0,
+    time_multiplier NUMERIC(4, 2) DEFAULT 1,
+    custom_start TIMESTAMP WITH TIME ZONE DEFAULT NULL,
+    custom_end TIMESTAMP WITH TIME ZONE DEFAULT NULL,
+    note VARCHAR(255) DEFAULT NULL,
+    granted_by VARCHAR(16) DEFAULT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (exam_id, user_id),
+
+    CONSTRAINT fk_exam FOREIGN KEY (exam_id) REFERENCES "exam_info"(exam_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_user FOREIGN KEY (user_id) REFERENCES "user_info"(user_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_granted_by FOREIGN KEY (granted_by) REFERENCES "user_info"(user_id) ON DELETE SET NULL ON UPDATE CASCADE,
+    CONSTRAINT chk_extra_minutes CHECK (extra_minutes >= 0),
+    CONSTRAINT chk_time_multiplier CHECK (time_multiplier >= 1),
+    CONSTRAINT chk_custom_window CHECK (
+        custom_start IS NULL OR custom_end IS NULL OR custom_end > custom_start
+    )
+);
+
+COMMENT ON TABLE exam_accommodation IS 'Stores per-user overrides of the exam timing (accommodations)';
+COMMENT ON COLUMN exam_accommodation.exam_id IS 'ID of the exam';
+COMMENT ON COLUMN exam_accommodation.user_id IS 'ID of the user the accommodation is granted to';
+COMMENT ON COLUMN exam_accommodation.extra_minutes IS 'Extra minutes added to the duration of the exam';
+COMMENT ON COLUMN exam_accommodation.time_multiplier IS 'Multiplier applied to the duration of the exam (e.g. 1.5)';
+COMMENT ON COLUMN exam_accommodation.custom_start IS 'Custom start time of the exam for the user (can be null)';
+COMMENT ON COLUMN exam_accommodation.custom_end IS 'Custom close time of the exam for the user (can be null)';
+COMMENT ON COLUMN exam_accommodation.note IS 'Optional note about the accommodation';
+COMMENT ON COLUMN exam_accommodation.granted_by IS 'ID of the user (teacher or admin) who granted the accommodation';
+COMMENT ON COLUMN exam_accommodation.created_at IS 'Timestamp when the accommodation was granted';
+
+-- get_exam_start_time returns the time the exam starts for the user,
+-- considering their accommodation (if any).
+-- Example usage:
+--      SELECT get_exam_start_time(1, '1234');
+CREATE OR REPLACE FUNCTION get_exam_start_time(p_exam_id INTEGER, p_user_id UserIdType)
+RETURNS TIMESTAMP WITH TIME ZONE AS $$
+DECLARE
+    exam_start_time TIMESTAMP WITH TIME ZONE;
+BEGIN
+    SELECT COALESCE(a.custom_start, e.exam_date) INTO exam_start_time
+    FROM exam_info e
+    LEFT JOIN exam_accommodation a ON a.exam_id = e.exam_id AND a.user_id = p_user_id
+    WHERE e.exam_id = p_exam_id;
+
+    IF exam_start_time IS NULL THEN
+        RAISE EXCEPTION 'Exam with ID % not found', p_exam_id;
+    END IF;
+
+    RETURN exam_start_time;
+END;
+$$ LANGUAGE plpgsql;
+
+-- get_exam_end_time returns the time the exam finishes for the user,
+-- considering their accommodation (if any).
+-- Example usage:
+--      SELECT get_exam_end_time(1, '1234');
+CREATE OR REPLACE FUNCTION get_exam_end_time(p_exam_id INTEGER, p_user_id UserIdType)
+RETURNS TIMESTAMP WITH TIME ZONE AS $$
+DECLARE
+    exam_end_time TIMESTAMP WITH TIME ZONE;
+BEGIN
+    SELECT COALESCE(
+        a.custom_end,
+        COALESCE(a.custom_start, e.exam_date) +
+            (e.duration * COALESCE(a.time_multiplier, 1) + COALESCE(a.extra_minutes, 0)) * INTERVAL '1 minute'
+    ) INTO exam_end_time
+    FROM exam_info e
+    LEFT JOIN exam_accommodation a ON a.exam_id = e.exam_id AND a.user_id = p_user_id
+    WHERE e.exam_id = p_exam_id;
+
+    IF exam_end_time IS NULL THEN
+        RAISE EXCEPTION 'Exam with ID % not found', p_exam_id;
+    END IF;
+
+    RETURN exam_end_time;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Returns true if the exam has started for the user, false otherwise.
+-- Example usage:
+--      SELECT has_exam_started(1, '1234');
+CREATE OR REPLACE FUNCTION has_exam_started(p_exam_id INTEGER, p_user_id UserIdType)
+RETURNS BOOLEAN AS $$
+BEGIN
+    RETURN CURRENT_TIMESTAMP >= get_exam_start_time(p_exam_id, p_user_id);
+END;
+$$ LANGUAGE plpgsql;
+
+-- Returns true if the exam has finished for the user, false otherwise.
+-- Example usage:
+--      SELECT has_exam_finished(1, '1234');
+CREATE OR REPLACE FUNCTION has_exam_finished(p_exam_id INTEGER, p_user_id UserIdType)
+RETURNS BOOLEAN AS $$
+BEGIN
+    RETURN CURRENT_TIMESTAMP > get_exam_end_time(p_exam_id, p_user_id);
+END;
+$$ LANGUAGE plpgsql;
+
+-- Returns the number of minutes until the exam finishes for the user.
+-- If the exam has already finished for them, it returns 0.
+-- Example usage:
+--      SELECT get_exam_finishes_in(1, '1234');
+CREATE OR REPLACE FUNCTION get_exam_finishes_in(p_exam_id INTEGER, p_user_id UserIdType)
+RETURNS INTEGER AS $$
+BEGIN
+    RETURN GREATEST(
+        0,
+        EXTRACT(EPOCH FROM (get_exam_end_time(p_exam_id, p_user_id) - CURRENT_TIMESTAMP)) / 60
+    )::INTEGER;
+END;
+$$ LANGUAGE plpgsql;
+
+-- set_exam_accommodation grants (or updates) the accommodation of the user
+-- in the exam.
+-- Example usage:
+--      CALL set_exam_accommodation(
+--          p_exam_id := 1,
+--          p_user_id := '1234',
+--          p_extra_minutes := 15,
+--          p_time_multiplier := 1.5,
+--          p_custom_start := NULL,
+--          p_custom_end := NULL,
+--          p_note := 'Documented accommodation',
+--          p_granted_by := '5678'
+--      );
+CREATE OR REPLACE PROCEDURE set_exam_accommodation(
+    p_exam_id INTEGER,
+    p_user_id UserIdType,
+    p_extra_minutes INTEGER,
+    p_time_multiplier NUMERIC(4, 2),
+    p_custom_start TIMESTAMP WITH TIME ZONE,
+    p_custom_end TIMESTAMP WITH TIME ZONE,
+    p_note VARCHAR(255),
+    p_granted_by VARCHAR(16)
+)
+LANGUAGE plpgsql
+AS $$
+BEGIN
+    INSERT INTO exam_accommodation (
+        exam_id,
+        user_id,
+        extra_minutes,
+        time_multiplier,
+        custom_start,
+        custom_end,
+        note,
+        granted_by
+    )
+    VALUES (
+        p_exam_id,
+        p_user_id,
+        p_extra_minutes,
+        p_time_multiplier,
+        p_custom_start,
+        p_custom_end,
+        p_note,
+        p_granted_by
+    )
+    ON CONFLICT (exam_id, user_id)
+    DO UPDATE SET
+        extra_minutes = EXCLUDED.extra_minutes,
+        time_multiplier = EXCLUDED.time_multiplier,
+        custom_start = EXCLUDED.custom_start,
+        custom_end = EXCLUDED.custom_end,
+        note = EXCLUDED.note,
+        granted_by = EXCLUDED.granted_by,
+        created_at = CURRENT_TIMESTAMP;
+END;
+$$;
+
+-- The deadline of the answers has to respect the accommodation of the user
+CREATE OR REPLACE FUNCTION give_answer_to_exam_question(
+    p_exam_id INTEGER,
+    p_question_id INTEGER,
+    p_answered_by UserIdType,
+    p_chosen_option TEXT DEFAULT NULL,
+    p_seconds_taken INTEGER DEFAULT 0,
+    p_answer_text TEXT DEFAULT NULL,
+    p_attempt_number INTEGER DEFAULT NULL
+) RETURNS VOID AS $$
+DECLARE
+    v_attempt_number INTEGER := p_attempt_number;
+BEGIN
+    -- Check if the user has participated in the exam
+    IF NOT has_participated_in_exam(p_exam_id, p_answered_by) THEN
+        RAISE EXCEPTION 'User has not participated in exam % yet', p_exam_id;
+    END IF;
+
+    -- Check if the exam has finished (for this user)
+    IF has_exam_finished(p_exam_id, p_answered_by) THEN
+        RAISE EXCEPTION 'Exam % has already finished', p_exam_id;
+    END IF;
+
+    IF v_attempt_number IS NULL THEN
+        SELECT attempt_number INTO v_attempt_number
+        FROM exam_attempt
+        WHERE exam_id = p_exam_id AND user_id = p_answered_by
+        ORDER BY attempt_number DESC
+        LIMIT 1;
+    END IF;
+
+    -- Answers can only be given to an ongoing attempt
+    IF NOT EXISTS (
+        SELECT 1 FROM exam_attempt
+        WHERE exam_id = p_exam_id
+            AND user_id = p_answered_by
+            AND attempt_number = v_attempt_number
+            AND finished_at IS NULL
+    ) THEN
+        RAISE EXCEPTION 'User % has no ongoing attempt in exam %', p_answered_by, p_exam_id;
+    END IF;
+
+    INSERT INTO given_answer (
+        exam_id,
+        question_id,
+        answered_by,
+        chosen_option,
+        seconds_taken,
+        answer_text,
+        attempt_number
+    )
+    VALUES (
+        p_exam_id,
+        p_question_id,
+        p_answered_by,
+        p_chosen_option,
+        p_seconds_taken,
+        p_answer_text,
+        v_attempt_number
+    )
+    ON CONFLICT (exam_id, question_id, answered_by, attempt_number)
+    DO UPDATE SET -- Just update the answer if it already exists
+        chosen_option = EXCLUDED.chosen_option,
+        answer_text = EXCLUDED.answer_text,
+        answered_at = CURRENT_TIMESTAMP;
+END;
+$$ LANGUAGE plpgsql;
+
+-- The ongoing exams and the history of the users have to respect their
+-- accommodations as well.
+CREATE OR REPLACE VIEW user_ongoing_exams AS
+SELECT DISTINCT
+    u.user_id,
+    e.exam_id,
+    e.exam_title,
+    e.exam_date
+FROM "exam_info" e
+JOIN "given_exam" g ON e.exam_id = g.exam_id
+JOIN "user_info" u ON g.user_id = u.user_id
+WHERE CURRENT_TIMESTAMP < get_exam_end_time(e.exam_id, u.user_id);
+
+CREATE OR REPLACE VIEW user_exams_history AS
+SELECT DISTINCT
+    u.user_id,
+    e.exam_id,
+    e.exam_title,
+    e.exam_date
+FROM "exam_info" e
+JOIN "given_exam" g ON e.exam_id = g.exam_id
+JOIN "user_info" u ON g.user_id = u.user_id
+WHERE CURRENT_TIMESTAMP > get_exam_end_time(e.exam_id, u.user_id);
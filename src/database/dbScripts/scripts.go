@@ -16,4 +16,82 @@ var (
 
 	//go:embed migration4.sql
 	Migration4Str string
+
+	//go:embed migration5.sql
+	Migration5Str string
+
+	//go:embed migration6.sql
+	Migration6Str string
+
+	//go:embed migration7.sql
+	Migration7Str string
+
+	//go:embed migration8.sql
+	Migration8Str string
+
+	//go:embed migration9.sql
+	Migration9Str string
+
+	//go:embed migration10.sql
+	Migration10Str string
+
+	//go:embed migration11.sql
+	Migration11Str string
+
+	//go:embed migration12.sql
+	Migration12Str string
+
+	//go:embed migration13.sql
+	Migration13Str string
+
+	//go:embed migration14.sql
+	Migration14Str string
+
+	//go:embed migration15.sql
+	Migration15Str string
+
+	//go:embed migration16.sql
+	Migration16Str string
+
+	//go:embed migration17.sql
+	Migration17Str string
+
+	//go:embed migration18.sql
+	Migration18Str string
+
+	//go:embed migration19.sql
+	Migration19Str string
+
+	//go:embed migration20.sql
+	Migration20Str string
+
+	//go:embed migration21.sql
+	Migration21Str string
+
+	//go:embed migration22.sql
+	Migration22Str string
+
+	//go:embed migration23.sql
+	Migration23Str string
+
+	//go:embed migration24.sql
+	Migration24Str string
+
+	//go:embed migration25.sql
+	Migration25Str string
+
+	//go:embed migration26.sql
+	Migration26Str string
+
+	//go:embed migration27.sql
+	Migration27Str string
+
+	//go:embed migration28.sql
+	Migration28Str string
+
+	//go:embed migration29.sql
+	Migration29Str string
+
+	//go:embed migration30.sql
+	Migration30Str string
 )
@@ -3,17 +3,42 @@ package database
 import "errors"
 
 var (
-	ErrUserAlreadyExists     = errors.New("user already exists")
-	ErrInternalDatabaseError = errors.New("internal database error")
-	ErrUserNotFound          = errors.New("user not found")
-	ErrInvalidPassword       = errors.New("invalid password")
-	ErrOperationNotAllowed   = errors.New("operation not allowed")
-	ErrCourseNotFound        = errors.New("course not found")
-	ErrTopicNotFound         = errors.New("topic not found")
-	ErrUserTopicStatNotFound = errors.New("user topic stat not found")
-	ErrExamNotFound          = errors.New("exam not found")
-	ErrExamQuestionNotFound  = errors.New("exam question not found")
-	ErrGivenExamNotFound     = errors.New("given exam not found")
-	ErrGivenAnswerNotFound   = errors.New("given answer not found")
-	ErrInvalidAnswer         = errors.New("invalid answer")
+	ErrUserAlreadyExists          = errors.New("user already exists")
+	ErrInternalDatabaseError      = errors.New("internal database error")
+	ErrUserNotFound               = errors.New("user not found")
+	ErrInvalidPassword            = errors.New("invalid password")
+	ErrOperationNotAllowed        = errors.New("operation not allowed")
+	ErrCourseNotFound             = errors.New("course not found")
+	ErrTopicNotFound              = errors.New("topic not found")
+	ErrUserTopicStatNotFound      = errors.New("user topic stat not found")
+	ErrExamNotFound               = errors.New("exam not found")
+	ErrExamQuestionNotFound       = errors.New("exam question not found")
+	ErrGivenExamNotFound          = errors.New("given exam not found")
+	ErrGivenAnswerNotFound        = errors.New("given answer not found")
+	ErrInvalidAnswer              = errors.New("invalid answer")
+	ErrExamAttemptBindingNotFound = errors.New("exam attempt binding not found")
+	ErrExamPrerequisiteNotFound   = errors.New("exam prerequisite not found")
+	ErrExamPrerequisitesNotMet    = errors.New("exam prerequisites not met")
+	ErrExamAttemptNotFound        = errors.New("exam attempt not found")
+	ErrExamAccommodationNotFound  = errors.New("exam accommodation not found")
+	ErrExamSeriesNotFound         = errors.New("exam series not found")
+	ErrInsufficientBalance        = errors.New("insufficient balance")
+	ErrCurrencyMismatch           = errors.New("currency does not match the wallet currency")
+	ErrWalletTransactionNotFound  = errors.New("wallet transaction not found")
+	ErrRefundExceedsAmount        = errors.New("refund exceeds the refundable amount")
+	ErrExamCouponNotFound         = errors.New("exam coupon not found")
+	ErrExamCouponNotUsable        = errors.New("exam coupon cannot be used")
+	ErrExamInvitationNotFound     = errors.New("exam invitation not found")
+	ErrExamFull                   = errors.New("exam is full")
+	ErrWaitlistEntryNotFound      = errors.New("waitlist entry not found")
+	ErrExamSectionNotFound        = errors.New("exam section not found")
+	ErrNoAdaptiveQuestions        = errors.New("exam has no questions with an answer key")
+	ErrAttachmentNotFound         = errors.New("attachment not found")
+	ErrSimilarityCheckNotFound    = errors.New("similarity check not found")
+	ErrSimilarityCheckRunning     = errors.New("another similarity check of the question is running")
+	ErrExamReviewStatusChanged    = errors.New("exam is not in the expected review status")
+	ErrReviewCommentNotFound      = errors.New("review comment not found")
+	ErrExamCollaboratorNotFound   = errors.New("exam collaborator not found")
+	ErrPreviewAttemptNotFound     = errors.New("preview attempt not found")
+	ErrGradeCategoryNotFound      = errors.New("grade category not found")
 )
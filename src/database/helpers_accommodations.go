@@ -0,0 +1,184 @@
+package database
+
+import (
+	"ExamSphere/src/core/utils/logging"
+	"context"
+	"time"
+
+	"github.com/ALiwoto/ssg/ssg"
+	"github.com/jackc/pgx/v5"
+)
+
+// GetExamAccommodation gets the accommodation of the user in the exam.
+func GetExamAccommodation(userId string, examId int) (*ExamAccommodation, error) {
+	uniqueId := userId + KeySepChar + ssg.ToBase10(examId)
+	info := examAccommodationsMap.Get(uniqueId)
+	if info != nil && info != valueExamAccommodationNotFound &&
+		info.ExamId == examId && info.UserId == userId {
+		return info, nil
+	} else if info == valueExamAccommodationNotFound {
+		return nil, ErrExamAccommodationNotFound
+	}
+
+	info = &ExamAccommodation{}
+	err := DefaultContainer.db.QueryRow(context.Background(),
+		`SELECT exam_id,
+			user_id,
+			extra_minutes,
+			time_multiplier,
+			custom_start,
+			custom_end,
+			note,
+			granted_by,
+			created_at
+		FROM exam_accommodation WHERE user_id = $1 AND exam_id = $2`,
+		userId,
+		examId,
+	).Scan(
+		&info.ExamId,
+		&info.UserId,
+		&info.ExtraMinutes,
+		&info.TimeMultiplier,
+		&info.CustomStart,
+		&info.CustomEnd,
+		&info.Note,
+		&info.GrantedBy,
+		&info.CreatedAt,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			examAccommodationsMap.Add(uniqueId, valueExamAccommodationNotFound)
+			return nil, ErrExamAccommodationNotFound
+		}
+
+		return nil, err
+	}
+
+	examAccommodationsMap.Add(uniqueId, info)
+	return info, nil
+}
+
+// GetExamAccommodationOrNil gets the accommodation of the user in the exam
+// or nil if the user has no accommodations.
+// It will also log the error if the error is something unexpected.
+func GetExamAccommodationOrNil(userId string, examId int) *ExamAccommodation {
+	info, err := GetExamAccommodation(userId, examId)
+	if err != nil && err != ErrExamAccommodationNotFound {
+		logging.UnexpectedError("GetExamAccommodationOrNil: failed to get accommodation:", err)
+		return nil
+	}
+
+	return info
+}
+
+// GetExamAccommodations gets all of the accommodations granted in the exam.
+func GetExamAccommodations(examId int) ([]*ExamAccommodation, error) {
+	rows, err := DefaultContainer.db.Query(context.Background(),
+		`SELECT exam_id,
+			user_id,
+			extra_minutes,
+			time_multiplier,
+			custom_start,
+			custom_end,
+			note,
+			granted_by,
+			created_at
+		FROM exam_accommodation WHERE exam_id = $1
+		ORDER BY created_at`,
+		examId,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var accommodations []*ExamAccommodation
+	for rows.Next() {
+		info := &ExamAccommodation{}
+		err = rows.Scan(
+			&info.ExamId,
+			&info.UserId,
+			&info.ExtraMinutes,
+			&info.TimeMultiplier,
+			&info.CustomStart,
+			&info.CustomEnd,
+			&info.Note,
+			&info.GrantedBy,
+			&info.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		accommodations = append(accommodations, info)
+	}
+
+	return accommodations, nil
+}
+
+// SetExamAccommodation grants (or updates) the accommodation of the user
+// in the exam.
+// It uses the sp set_exam_accommodation.
+func SetExamAccommodation(data *SetExamAccommodationData) (*ExamAccommodation, error) {
+	if data.TimeMultiplier < 1 {
+		data.TimeMultiplier = 1
+	}
+
+	info := &ExamAccommodation{
+		ExamId:         data.ExamId,
+		UserId:         data.UserId,
+		ExtraMinutes:   data.ExtraMinutes,
+		TimeMultiplier: data.TimeMultiplier,
+		CustomStart:    ssg.Clone(data.CustomStart),
+		CustomEnd:      ssg.Clone(data.CustomEnd),
+		Note:           ssg.Clone(data.Note),
+		GrantedBy:      ssg.Clone(&data.GrantedBy),
+		CreatedAt:      time.Now(),
+	}
+
+	_, err := DefaultContainer.db.Exec(context.Background(),
+		`CALL set_exam_accommodation(
+			p_exam_id := $1,
+			p_user_id := $2,
+			p_extra_minutes := $3,
+			p_time_multiplier := $4,
+			p_custom_start := $5,
+			p_custom_end := $6,
+			p_note := $7,
+			p_granted_by := $8
+		)`,
+		info.ExamId,
+		info.UserId,
+		info.ExtraMinutes,
+		info.TimeMultiplier,
+		info.CustomStart,
+		info.CustomEnd,
+		info.Note,
+		info.GrantedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	examAccommodationsMap.Add(info.GetUniqueId(), info)
+	return info, nil
+}
+
+// RemoveExamAccommodation removes the accommodation of the user in the exam.
+func RemoveExamAccommodation(userId string, examId int) error {
+	result, err := DefaultContainer.db.Exec(context.Background(),
+		`DELETE FROM exam_accommodation WHERE user_id = $1 AND exam_id = $2`,
+		userId,
+		examId,
+	)
+	if err != nil {
+		return err
+	}
+
+	examAccommodationsMap.Delete(userId + KeySepChar + ssg.ToBase10(examId))
+	if result.RowsAffected() == 0 {
+		return ErrExamAccommodationNotFound
+	}
+
+	return nil
+}
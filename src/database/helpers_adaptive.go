@@ -0,0 +1,352 @@
+package database
+
+import (
+	"ExamSphere/src/core/utils/irtUtils"
+	"context"
+
+	"github.com/ALiwoto/ssg/ssg"
+)
+
+// SetExamAdaptiveSettings sets whether the exam is adaptive, and when its
+// adaptive attempts end.
+// It uses the sp set_exam_adaptive_settings.
+func SetExamAdaptiveSettings(data *SetExamAdaptiveSettingsData) (*ExamInfo, error) {
+	info, err := GetExamInfo(data.ExamId)
+	if err != nil {
+		return nil, err
+	} else if info == nil {
+		return nil, ErrExamNotFound
+	}
+
+	_, err = DefaultContainer.db.Exec(context.Background(),
+		`CALL set_exam_adaptive_settings(
+			p_exam_id := $1,
+			p_is_adaptive := $2,
+			p_max_items := $3,
+			p_target_se := $4
+		)`,
+		data.ExamId,
+		data.IsAdaptive,
+		data.MaxItems,
+		data.TargetStdError,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	info.IsAdaptive = data.IsAdaptive
+	info.AdaptiveMaxItems = ssg.Clone(data.MaxItems)
+	info.AdaptiveTargetStdError = ssg.Clone(data.TargetStdError)
+	return info, nil
+}
+
+// SetExamQuestionIrt sets the answer key and the IRT parameters of a question.
+// It uses the sp set_exam_question_irt.
+func SetExamQuestionIrt(data *SetExamQuestionIrtData) (*ExamQuestion, error) {
+	info, err := GetExamQuestion(data.ExamId, data.QuestionId)
+	if err != nil {
+		return nil, err
+	} else if info == nil {
+		return nil, ErrExamQuestionNotFound
+	}
+
+	_, err = DefaultContainer.db.Exec(context.Background(),
+		`CALL set_exam_question_irt(
+			p_question_id := $1,
+			p_correct_option := $2,
+			p_discrimination := $3,
+			p_difficulty := $4,
+			p_guessing := $5
+		)`,
+		data.QuestionId,
+		data.CorrectOption,
+		data.Discrimination,
+		data.Difficulty,
+		data.Guessing,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	info.CorrectOption = ssg.Clone(data.CorrectOption)
+	info.IrtDiscrimination = data.Discrimination
+	info.IrtDifficulty = data.Difficulty
+	info.IrtGuessing = data.Guessing
+	return info, nil
+}
+
+// GetAdaptiveExamQuestions gets the questions of the exam which can be given
+// in its adaptive attempts (the ones with an answer key).
+func GetAdaptiveExamQuestions(examId int) ([]*ExamQuestion, error) {
+	rows, err := DefaultContainer.db.Query(context.Background(),
+		`SELECT question_id
+		FROM exam_question
+		WHERE exam_id = $1 AND correct_option IS NOT NULL
+		ORDER BY question_id`,
+		examId,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var questionIds []int
+	for rows.Next() {
+		var questionId int
+		err = rows.Scan(&questionId)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+
+		questionIds = append(questionIds, questionId)
+	}
+	rows.Close()
+
+	questions := make([]*ExamQuestion, 0, len(questionIds))
+	for _, questionId := range questionIds {
+		question, err := GetExamQuestion(examId, questionId)
+		if err != nil {
+			return nil, err
+		}
+
+		questions = append(questions, question)
+	}
+
+	return questions, nil
+}
+
+// GetExamAttemptItems gets the questions given to the adaptive attempt, in
+// the order they were given.
+func GetExamAttemptItems(attempt *ExamAttempt) ([]*ExamAttemptItem, error) {
+	rows, err := DefaultContainer.db.Query(context.Background(),
+		`SELECT exam_id,
+			user_id,
+			attempt_number,
+			item_index,
+			question_id,
+			served_at,
+			is_correct,
+			answered_at
+		FROM exam_attempt_item
+		WHERE exam_id = $1 AND user_id = $2 AND attempt_number = $3
+		ORDER BY item_index`,
+		attempt.ExamId,
+		attempt.UserId,
+		attempt.AttemptNumber,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []*ExamAttemptItem
+	for rows.Next() {
+		info := &ExamAttemptItem{}
+		err = rows.Scan(
+			&info.ExamId,
+			&info.UserId,
+			&info.AttemptNumber,
+			&info.ItemIndex,
+			&info.QuestionId,
+			&info.ServedAt,
+			&info.IsCorrect,
+			&info.AnsweredAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, info)
+	}
+
+	return items, nil
+}
+
+// GetCurrentExamAttemptItem gets the question of the adaptive attempt which
+// is waiting for an answer, or nil if there is none.
+func GetCurrentExamAttemptItem(attempt *ExamAttempt) (*ExamAttemptItem, error) {
+	items, err := GetExamAttemptItems(attempt)
+	if err != nil || len(items) == 0 {
+		return nil, err
+	}
+
+	lastItem := items[len(items)-1]
+	if lastItem.IsAnswered() {
+		return nil, nil
+	}
+
+	return lastItem, nil
+}
+
+// ServeExamAttemptItem gives the question to the adaptive attempt.
+// It uses the plpgsql function serve_exam_attempt_item.
+func ServeExamAttemptItem(attempt *ExamAttempt, questionId int) (*ExamAttemptItem, error) {
+	info := &ExamAttemptItem{
+		ExamId:        attempt.ExamId,
+		UserId:        attempt.UserId,
+		AttemptNumber: attempt.AttemptNumber,
+		QuestionId:    questionId,
+	}
+
+	err := DefaultContainer.db.QueryRow(context.Background(),
+		`SELECT serve_exam_attempt_item(
+			p_exam_id := $1,
+			p_user_id := $2,
+			p_attempt_number := $3,
+			p_question_id := $4
+		)`,
+		info.ExamId,
+		info.UserId,
+		info.AttemptNumber,
+		info.QuestionId,
+	).Scan(&info.ItemIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := GetExamAttemptItems(attempt)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range items {
+		if item.ItemIndex == info.ItemIndex {
+			return item, nil
+		}
+	}
+
+	return info, nil
+}
+
+// RecordExamAttemptItemAnswer records whether the answer of the user to the
+// current question of the adaptive attempt was correct, and updates the
+// ability estimate of the attempt.
+// It uses the sp record_exam_attempt_item_answer.
+func RecordExamAttemptItemAnswer(
+	attempt *ExamAttempt,
+	question *ExamQuestion,
+	isCorrect bool,
+) (*irtUtils.AbilityEstimate, error) {
+	items, err := GetExamAttemptItems(attempt)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := []irtUtils.Response{{
+		Item:      question.GetIrtParams(),
+		IsCorrect: isCorrect,
+	}}
+	for _, item := range items {
+		if !item.IsAnswered() || item.QuestionId == question.QuestionId {
+			continue
+		}
+
+		answeredQuestion, err := GetExamQuestion(item.ExamId, item.QuestionId)
+		if err != nil {
+			return nil, err
+		}
+
+		responses = append(responses, irtUtils.Response{
+			Item:      answeredQuestion.GetIrtParams(),
+			IsCorrect: *item.IsCorrect,
+		})
+	}
+
+	estimate := irtUtils.EstimateAbility(responses)
+	_, err = DefaultContainer.db.Exec(context.Background(),
+		`CALL record_exam_attempt_item_answer(
+			p_exam_id := $1,
+			p_user_id := $2,
+			p_attempt_number := $3,
+			p_question_id := $4,
+			p_is_correct := $5,
+			p_ability_estimate := $6,
+			p_ability_std_error := $7
+		)`,
+		attempt.ExamId,
+		attempt.UserId,
+		attempt.AttemptNumber,
+		question.QuestionId,
+		isCorrect,
+		estimate.Theta,
+		estimate.StdError,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	attempt.AbilityEstimate = &estimate.Theta
+	attempt.AbilityStdError = &estimate.StdError
+	return estimate, nil
+}
+
+// GetNextAdaptiveStep gets the question the user has to answer next in the
+// adaptive attempt; the question waiting for an answer is returned again,
+// otherwise the most informative question at the current ability estimate
+// is given. Once the stop rule of the exam is met (or no questions are
+// left), the attempt is finished instead.
+func GetNextAdaptiveStep(examInfo *ExamInfo, attempt *ExamAttempt) (*AdaptiveStep, error) {
+	items, err := GetExamAttemptItems(attempt)
+	if err != nil {
+		return nil, err
+	}
+
+	step := &AdaptiveStep{}
+	servedQuestions := make(map[int]bool, len(items))
+	for _, item := range items {
+		servedQuestions[item.QuestionId] = true
+		if item.IsAnswered() {
+			step.AnsweredCount++
+		} else {
+			step.Item = item
+		}
+	}
+
+	if step.Item != nil {
+		step.Question, err = GetExamQuestion(examInfo.ExamId, step.Item.QuestionId)
+		if err != nil {
+			return nil, err
+		}
+		return step, nil
+	}
+
+	estimate := attempt.GetAbilityEstimate()
+	if !attempt.IsFinished() && !examInfo.GetAdaptiveStopRule().ShouldStop(step.AnsweredCount, estimate) {
+		questions, err := GetAdaptiveExamQuestions(examInfo.ExamId)
+		if err != nil {
+			return nil, err
+		} else if len(questions) == 0 {
+			return nil, ErrNoAdaptiveQuestions
+		}
+
+		var candidates []*ExamQuestion
+		var candidatesParams []irtUtils.ItemParams
+		for _, question := range questions {
+			if servedQuestions[question.QuestionId] {
+				continue
+			}
+			candidates = append(candidates, question)
+			candidatesParams = append(candidatesParams, question.GetIrtParams())
+		}
+
+		selected := irtUtils.SelectNextItem(candidatesParams, estimate.Theta)
+		if selected != -1 {
+			step.Question = candidates[selected]
+			step.Item, err = ServeExamAttemptItem(attempt, step.Question.QuestionId)
+			if err != nil {
+				return nil, err
+			}
+			return step, nil
+		}
+	}
+
+	if !attempt.IsFinished() {
+		err = FinishExamAttempt(attempt)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	step.IsFinished = true
+	return step, nil
+}
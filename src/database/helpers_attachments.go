@@ -0,0 +1,202 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// CreateAttachment stores the metadata of an uploaded file.
+// It uses the plpgsql function create_attachment.
+func CreateAttachment(data *NewAttachmentData) (*Attachment, error) {
+	info := &Attachment{
+		AttachmentType: data.AttachmentType,
+		ExamId:         data.ExamId,
+		QuestionId:     data.QuestionId,
+		OptionNumber:   data.OptionNumber,
+		AnsweredBy:     data.AnsweredBy,
+		AttemptNumber:  data.AttemptNumber,
+		StorageBackend: data.StorageBackend,
+		StorageKey:     data.StorageKey,
+		FileName:       data.FileName,
+		ContentType:    data.ContentType,
+		FileSize:       data.FileSize,
+		UploadedBy:     &data.UploadedBy,
+		CreatedAt:      time.Now(),
+	}
+
+	err := DefaultContainer.db.QueryRow(context.Background(),
+		`SELECT create_attachment(
+			p_attachment_type := $1,
+			p_exam_id := $2,
+			p_question_id := $3,
+			p_option_number := $4,
+			p_answered_by := $5,
+			p_attempt_number := $6,
+			p_storage_backend := $7,
+			p_storage_key := $8,
+			p_file_name := $9,
+			p_content_type := $10,
+			p_file_size := $11,
+			p_uploaded_by := $12
+		)`,
+		info.AttachmentType,
+		info.ExamId,
+		info.QuestionId,
+		info.OptionNumber,
+		info.AnsweredBy,
+		info.AttemptNumber,
+		info.StorageBackend,
+		info.StorageKey,
+		info.FileName,
+		info.ContentType,
+		info.FileSize,
+		info.UploadedBy,
+	).Scan(&info.AttachmentId)
+	if err != nil {
+		return nil, err
+	}
+
+	return info, nil
+}
+
+// GetAttachment gets the attachment with the specified id.
+func GetAttachment(attachmentId int) (*Attachment, error) {
+	info := &Attachment{}
+	err := DefaultContainer.db.QueryRow(context.Background(),
+		`SELECT attachment_id,
+			attachment_type,
+			exam_id,
+			question_id,
+			option_number,
+			answered_by,
+			attempt_number,
+			storage_backend,
+			storage_key,
+			file_name,
+			content_type,
+			file_size,
+			uploaded_by,
+			created_at
+		FROM attachment WHERE attachment_id = $1`,
+		attachmentId,
+	).Scan(
+		&info.AttachmentId,
+		&info.AttachmentType,
+		&info.ExamId,
+		&info.QuestionId,
+		&info.OptionNumber,
+		&info.AnsweredBy,
+		&info.AttemptNumber,
+		&info.StorageBackend,
+		&info.StorageKey,
+		&info.FileName,
+		&info.ContentType,
+		&info.FileSize,
+		&info.UploadedBy,
+		&info.CreatedAt,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, ErrAttachmentNotFound
+		}
+
+		return nil, err
+	}
+
+	return info, nil
+}
+
+// GetQuestionsAttachments gets the attachments of the specified questions
+// (and of their options), alongside the answer attachments selected by
+// the options.
+func GetQuestionsAttachments(opts *GetQuestionsAttachmentsOptions) ([]*Attachment, error) {
+	rows, err := DefaultContainer.db.Query(context.Background(),
+		`SELECT attachment_id,
+			attachment_type,
+			exam_id,
+			question_id,
+			option_number,
+			answered_by,
+			attempt_number,
+			storage_backend,
+			storage_key,
+			file_name,
+			content_type,
+			file_size,
+			uploaded_by,
+			created_at
+		FROM attachment
+		WHERE question_id = ANY($1) AND (attachment_type <> 'answer' OR
+			(answered_by = $2 AND attempt_number = $3))
+		ORDER BY question_id, attachment_type, option_number NULLS FIRST, attachment_id`,
+		opts.QuestionIds,
+		opts.AnsweredBy,
+		opts.AttemptNumber,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var attachments []*Attachment
+	for rows.Next() {
+		info := &Attachment{}
+		err = rows.Scan(
+			&info.AttachmentId,
+			&info.AttachmentType,
+			&info.ExamId,
+			&info.QuestionId,
+			&info.OptionNumber,
+			&info.AnsweredBy,
+			&info.AttemptNumber,
+			&info.StorageBackend,
+			&info.StorageKey,
+			&info.FileName,
+			&info.ContentType,
+			&info.FileSize,
+			&info.UploadedBy,
+			&info.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		attachments = append(attachments, info)
+	}
+
+	return attachments, nil
+}
+
+// GetAnswerAttachmentsCount returns the number of files the user has
+// uploaded as their answer to the question in the attempt.
+func GetAnswerAttachmentsCount(questionId int, userId string, attemptNumber int) (int, error) {
+	var count int
+	err := DefaultContainer.db.QueryRow(context.Background(),
+		`SELECT COUNT(*) FROM attachment
+		WHERE question_id = $1 AND attachment_type = 'answer'
+			AND answered_by = $2 AND attempt_number = $3`,
+		questionId,
+		userId,
+		attemptNumber,
+	).Scan(&count)
+
+	return count, err
+}
+
+// DeleteAttachment deletes the metadata of an attachment; the file itself
+// has to be removed from the storage backend by the caller.
+func DeleteAttachment(attachmentId int) error {
+	result, err := DefaultContainer.db.Exec(context.Background(),
+		`DELETE FROM attachment WHERE attachment_id = $1`,
+		attachmentId,
+	)
+	if err != nil {
+		return err
+	} else if result.RowsAffected() == 0 {
+		return ErrAttachmentNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,192 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/ALiwoto/ssg/ssg"
+)
+
+func getGivenAnswerKey(examId, questionId int, userId string, attemptNumber int) string {
+	return ssg.ToBase10(examId) + KeySepChar +
+		ssg.ToBase10(questionId) + KeySepChar +
+		userId + KeySepChar +
+		ssg.ToBase10(attemptNumber)
+}
+
+// IsAttemptReviewPolicyValid returns true if the policy is one of the
+// AttemptReviewPolicy* constants.
+func IsAttemptReviewPolicyValid(policy string) bool {
+	switch policy {
+	case AttemptReviewPolicyNever,
+		AttemptReviewPolicyAfterClose,
+		AttemptReviewPolicyAfterRelease:
+		return true
+	}
+	return false
+}
+
+// SetExamAttemptReviewPolicy sets when the students can review their
+// attempts in an exam.
+// It uses the sp set_exam_attempt_review_policy.
+func SetExamAttemptReviewPolicy(data *SetExamAttemptReviewPolicyData) (*ExamInfo, error) {
+	info, err := GetExamInfo(data.ExamId)
+	if err != nil {
+		return nil, err
+	} else if info == nil {
+		return nil, ErrExamNotFound
+	}
+
+	_, err = DefaultContainer.db.Exec(context.Background(),
+		`CALL set_exam_attempt_review_policy(
+			p_exam_id := $1,
+			p_attempt_review_policy := $2
+		)`,
+		data.ExamId,
+		data.AttemptReviewPolicy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	info.AttemptReviewPolicy = data.AttemptReviewPolicy
+	return info, nil
+}
+
+// SetExamResultsReleased releases (or withdraws) the results of an exam.
+// It uses the sp set_exam_results_released.
+func SetExamResultsReleased(data *SetExamResultsReleasedData) (*ExamInfo, error) {
+	info, err := GetExamInfo(data.ExamId)
+	if err != nil {
+		return nil, err
+	} else if info == nil {
+		return nil, ErrExamNotFound
+	}
+
+	_, err = DefaultContainer.db.Exec(context.Background(),
+		`CALL set_exam_results_released(
+			p_exam_id := $1,
+			p_is_released := $2,
+			p_released_by := $3
+		)`,
+		data.ExamId,
+		data.IsReleased,
+		data.ReleasedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if data.IsReleased {
+		now := time.Now()
+		info.ResultsReleasedBy = ssg.Clone(&data.ReleasedBy)
+		info.ResultsReleasedAt = &now
+	} else {
+		info.ResultsReleasedBy = nil
+		info.ResultsReleasedAt = nil
+	}
+
+	return info, nil
+}
+
+// GetAttemptGivenAnswers gets all of the answers given by a user in an
+// attempt of an exam.
+func GetAttemptGivenAnswers(userId string, examId, attemptNumber int) ([]*GivenAnswerInfo, error) {
+	rows, err := DefaultContainer.db.Query(context.Background(),
+		`SELECT exam_id,
+			question_id,
+			answered_by,
+			attempt_number,
+			chosen_option,
+			seconds_taken,
+			answer_text,
+			answered_at,
+			points_earned,
+			grader_comment,
+			graded_by,
+			graded_at
+		FROM given_answer
+		WHERE answered_by = $1 AND exam_id = $2 AND attempt_number = $3
+		ORDER BY question_id`,
+		userId,
+		examId,
+		attemptNumber,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var answers []*GivenAnswerInfo
+	for rows.Next() {
+		info := &GivenAnswerInfo{}
+		err = rows.Scan(
+			&info.ExamId,
+			&info.QuestionId,
+			&info.AnsweredBy,
+			&info.AttemptNumber,
+			&info.ChosenOption,
+			&info.SecondsTaken,
+			&info.AnswerText,
+			&info.AnsweredAt,
+			&info.PointsEarned,
+			&info.GraderComment,
+			&info.GradedBy,
+			&info.GradedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		givenAnswersMap.Add(getGivenAnswerKey(
+			info.ExamId, info.QuestionId, info.AnsweredBy, info.AttemptNumber,
+		), info)
+		answers = append(answers, info)
+	}
+
+	return answers, nil
+}
+
+// GradeGivenAnswer sets the points earned by an answer given in an attempt
+// and the comment of the grader on it.
+// It uses the sp grade_given_answer.
+func GradeGivenAnswer(data *GradeGivenAnswerData) (*GivenAnswerInfo, error) {
+	info, err := GetGivenAnswer(&GetGivenAnswerData{
+		ExamId:        data.ExamId,
+		QuestionId:    data.QuestionId,
+		UserId:        data.AnsweredBy,
+		AttemptNumber: data.AttemptNumber,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = DefaultContainer.db.Exec(context.Background(),
+		`CALL grade_given_answer(
+			p_exam_id := $1,
+			p_question_id := $2,
+			p_answered_by := $3,
+			p_attempt_number := $4,
+			p_points_earned := $5,
+			p_grader_comment := $6,
+			p_graded_by := $7
+		)`,
+		data.ExamId,
+		data.QuestionId,
+		data.AnsweredBy,
+		data.AttemptNumber,
+		data.PointsEarned,
+		data.GraderComment,
+		data.GradedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	info.PointsEarned = ssg.Clone(data.PointsEarned)
+	info.GraderComment = ssg.Clone(data.GraderComment)
+	info.GradedBy = ssg.Clone(&data.GradedBy)
+	info.GradedAt = &now
+	return info, nil
+}
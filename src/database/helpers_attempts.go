@@ -0,0 +1,368 @@
+package database
+
+import (
+	"ExamSphere/src/core/utils/logging"
+	"context"
+	"time"
+
+	"github.com/ALiwoto/ssg/ssg"
+	"github.com/jackc/pgx/v5"
+)
+
+// BindExamAttempt binds the exam attempt of the user to the specified
+// client, if and only if it's not already bound to another client.
+// The returned value is the binding that is stored in the database, so
+// the caller has to compare it with the client to see if they match.
+// It uses the plpgsql function bind_exam_attempt.
+func BindExamAttempt(data *ExamAttemptClientData) (*ExamAttemptBinding, error) {
+	uniqueId := data.GetUniqueId()
+	info := examAttemptBindingsMap.Get(uniqueId)
+	if info != nil && info.ExamId == data.ExamId && info.UserId == data.UserId {
+		return info, nil
+	}
+
+	info = &ExamAttemptBinding{
+		ExamId: data.ExamId,
+		UserId: data.UserId,
+	}
+	err := DefaultContainer.db.QueryRow(context.Background(),
+		`SELECT bound_ip_address,
+			bound_device_id,
+			bound_at,
+			mismatch_count,
+			last_ip_address,
+			ip_change_count
+		FROM bind_exam_attempt(
+			p_exam_id := $1,
+			p_user_id := $2,
+			p_ip_address := $3,
+			p_device_id := $4
+		)`,
+		data.ExamId,
+		data.UserId,
+		data.IPAddress,
+		data.DeviceId,
+	).Scan(
+		&info.IPAddress,
+		&info.DeviceId,
+		&info.BoundAt,
+		&info.MismatchCount,
+		&info.LastIPAddress,
+		&info.IPChangeCount,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	examAttemptBindingsMap.Add(uniqueId, info)
+	return info, nil
+}
+
+// FlagExamAttemptMismatch records a request coming from a client other than
+// the one the exam attempt is bound to.
+// It uses the sp flag_exam_attempt_mismatch.
+func FlagExamAttemptMismatch(data *ExamAttemptClientData) error {
+	_, err := DefaultContainer.db.Exec(context.Background(),
+		`CALL flag_exam_attempt_mismatch(
+			p_exam_id := $1,
+			p_user_id := $2,
+			p_ip_address := $3,
+			p_device_id := $4
+		)`,
+		data.ExamId,
+		data.UserId,
+		data.IPAddress,
+		data.DeviceId,
+	)
+	if err != nil {
+		return err
+	}
+
+	info := examAttemptBindingsMap.Get(data.GetUniqueId())
+	if info != nil && info.ExamId == data.ExamId && info.UserId == data.UserId {
+		info.MismatchCount++
+		info.LastMismatchIP = ssg.Clone(&data.IPAddress)
+		info.LastMismatchDevice = ssg.Clone(&data.DeviceId)
+		now := time.Now()
+		info.LastMismatchAt = &now
+	}
+
+	return nil
+}
+
+// RecordExamAttemptIPChange records a new ip address of the client the
+// attempt is bound to, for the audits; the client is not rejected.
+func RecordExamAttemptIPChange(data *ExamAttemptClientData) error {
+	_, err := DefaultContainer.db.Exec(context.Background(),
+		`CALL record_exam_attempt_ip_change(
+			p_exam_id := $1,
+			p_user_id := $2,
+			p_ip_address := $3
+		)`,
+		data.ExamId,
+		data.UserId,
+		data.IPAddress,
+	)
+	if err != nil {
+		return err
+	}
+
+	info := examAttemptBindingsMap.Get(data.GetUniqueId())
+	if info != nil && info.ExamId == data.ExamId && info.UserId == data.UserId {
+		info.IPChangeCount++
+		info.LastIPAddress = ssg.Clone(&data.IPAddress)
+		now := time.Now()
+		info.LastIPChangeAt = &now
+	}
+
+	return nil
+}
+
+// GetExamAttemptBinding gets the client binding of the exam attempt of
+// the specified user.
+func GetExamAttemptBinding(userId string, examId int) (*ExamAttemptBinding, error) {
+	info := examAttemptBindingsMap.Get(userId + KeySepChar + ssg.ToBase10(examId))
+	if info != nil && info.ExamId == examId && info.UserId == userId {
+		return info, nil
+	}
+
+	info = &ExamAttemptBinding{}
+	err := DefaultContainer.db.QueryRow(context.Background(),
+		`SELECT exam_id,
+			user_id,
+			ip_address,
+			device_id,
+			bound_at,
+			mismatch_count,
+			last_mismatch_ip,
+			last_mismatch_device,
+			last_mismatch_at,
+			last_ip_address,
+			ip_change_count,
+			last_ip_change_at
+		FROM exam_attempt_binding WHERE user_id = $1 AND exam_id = $2`,
+		userId,
+		examId,
+	).Scan(
+		&info.ExamId,
+		&info.UserId,
+		&info.IPAddress,
+		&info.DeviceId,
+		&info.BoundAt,
+		&info.MismatchCount,
+		&info.LastMismatchIP,
+		&info.LastMismatchDevice,
+		&info.LastMismatchAt,
+		&info.LastIPAddress,
+		&info.IPChangeCount,
+		&info.LastIPChangeAt,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, ErrExamAttemptBindingNotFound
+		}
+
+		return nil, err
+	}
+
+	examAttemptBindingsMap.Add(info.GetUniqueId(), info)
+	return info, nil
+}
+
+// GetExamAttemptBindingOrNil gets the client binding of the exam attempt or
+// nil if not found.
+func GetExamAttemptBindingOrNil(userId string, examId int) *ExamAttemptBinding {
+	info, err := GetExamAttemptBinding(userId, examId)
+	if err != nil && err != ErrExamAttemptBindingNotFound {
+		logging.UnexpectedError("GetExamAttemptBindingOrNil: failed to get binding:", err)
+		return nil
+	}
+
+	return info
+}
+
+// ResetExamAttemptBinding removes the client binding of the exam attempt of
+// the specified user, so the next client they use will get bound to the attempt.
+func ResetExamAttemptBinding(userId string, examId int) error {
+	result, err := DefaultContainer.db.Exec(context.Background(),
+		`DELETE FROM exam_attempt_binding WHERE user_id = $1 AND exam_id = $2`,
+		userId,
+		examId,
+	)
+	if err != nil {
+		return err
+	}
+
+	examAttemptBindingsMap.Delete(userId + KeySepChar + ssg.ToBase10(examId))
+	if result.RowsAffected() == 0 {
+		return ErrExamAttemptBindingNotFound
+	}
+
+	return nil
+}
+
+// StartExamAttempt starts a new attempt for the user in the exam. The client
+// binding of the previous attempt is removed, so the new attempt can get bound
+// to a new client.
+// It uses the plpgsql function start_exam_attempt.
+func StartExamAttempt(userId string, examId int) (*ExamAttempt, error) {
+	info := &ExamAttempt{
+		ExamId: examId,
+		UserId: userId,
+	}
+	err := DefaultContainer.db.QueryRow(context.Background(),
+		`SELECT attempt_number,
+			started_at
+		FROM start_exam_attempt(
+			p_exam_id := $1,
+			p_user_id := $2
+		)`,
+		examId,
+		userId,
+	).Scan(
+		&info.AttemptNumber,
+		&info.StartedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	examAttemptBindingsMap.Delete(info.GetUniqueId())
+	latestExamAttemptsMap.Add(info.GetUniqueId(), info)
+	return info, nil
+}
+
+// FinishExamAttempt marks the specified attempt as finished.
+// It uses the sp finish_exam_attempt.
+func FinishExamAttempt(info *ExamAttempt) error {
+	return FinishExamAttemptAt(info, time.Now())
+}
+
+// FinishExamAttemptAt marks the specified attempt as finished at the
+// specified time (e.g. the deadline of an attempt that is auto-submitted).
+// It uses the sp finish_exam_attempt.
+func FinishExamAttemptAt(info *ExamAttempt, finishedAt time.Time) error {
+	_, err := DefaultContainer.db.Exec(context.Background(),
+		`CALL finish_exam_attempt(
+			p_exam_id := $1,
+			p_user_id := $2,
+			p_attempt_number := $3,
+			p_finished_at := $4
+		)`,
+		info.ExamId,
+		info.UserId,
+		info.AttemptNumber,
+		finishedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	info.FinishedAt = &finishedAt
+	return nil
+}
+
+// GetLatestExamAttempt gets the latest attempt of the user in the exam.
+func GetLatestExamAttempt(userId string, examId int) (*ExamAttempt, error) {
+	uniqueId := userId + KeySepChar + ssg.ToBase10(examId)
+	info := latestExamAttemptsMap.Get(uniqueId)
+	if info != nil && info.ExamId == examId && info.UserId == userId {
+		return info, nil
+	}
+
+	info = &ExamAttempt{}
+	err := DefaultContainer.db.QueryRow(context.Background(),
+		`SELECT exam_id,
+			user_id,
+			attempt_number,
+			started_at,
+			finished_at,
+			final_score,
+			scored_by,
+			ability_estimate,
+			ability_std_error
+		FROM exam_attempt WHERE user_id = $1 AND exam_id = $2
+		ORDER BY attempt_number DESC
+		LIMIT 1`,
+		userId,
+		examId,
+	).Scan(
+		&info.ExamId,
+		&info.UserId,
+		&info.AttemptNumber,
+		&info.StartedAt,
+		&info.FinishedAt,
+		&info.FinalScore,
+		&info.ScoredBy,
+		&info.AbilityEstimate,
+		&info.AbilityStdError,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, ErrExamAttemptNotFound
+		}
+
+		return nil, err
+	}
+
+	latestExamAttemptsMap.Add(uniqueId, info)
+	return info, nil
+}
+
+// GetLatestExamAttemptOrNil gets the latest attempt of the user in the exam
+// or nil if not found.
+func GetLatestExamAttemptOrNil(userId string, examId int) *ExamAttempt {
+	info, err := GetLatestExamAttempt(userId, examId)
+	if err != nil && err != ErrExamAttemptNotFound {
+		logging.UnexpectedError("GetLatestExamAttemptOrNil: failed to get attempt:", err)
+		return nil
+	}
+
+	return info
+}
+
+// GetExamAttempts gets all of the attempts of the user in the exam.
+func GetExamAttempts(userId string, examId int) ([]*ExamAttempt, error) {
+	rows, err := DefaultContainer.db.Query(context.Background(),
+		`SELECT exam_id,
+			user_id,
+			attempt_number,
+			started_at,
+			finished_at,
+			final_score,
+			scored_by,
+			ability_estimate,
+			ability_std_error
+		FROM exam_attempt WHERE user_id = $1 AND exam_id = $2
+		ORDER BY attempt_number`,
+		userId,
+		examId,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var attempts []*ExamAttempt
+	for rows.Next() {
+		info := &ExamAttempt{}
+		err = rows.Scan(
+			&info.ExamId,
+			&info.UserId,
+			&info.AttemptNumber,
+			&info.StartedAt,
+			&info.FinishedAt,
+			&info.FinalScore,
+			&info.ScoredBy,
+			&info.AbilityEstimate,
+			&info.AbilityStdError,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		attempts = append(attempts, info)
+	}
+
+	return attempts, nil
+}
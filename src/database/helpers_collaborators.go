@@ -0,0 +1,173 @@
+package database
+
+import (
+	"ExamSphere/src/core/utils/logging"
+	"context"
+
+	"github.com/ALiwoto/ssg/ssg"
+	"github.com/jackc/pgx/v5"
+)
+
+func getExamCollaboratorKey(userId string, examId int) string {
+	return userId + KeySepChar + ssg.ToBase10(examId)
+}
+
+// IsCollaboratorRoleValid returns true if the role is one of the
+// CollaboratorRole* constants.
+func IsCollaboratorRoleValid(role string) bool {
+	switch role {
+	case CollaboratorRoleEditor, CollaboratorRoleGrader, CollaboratorRoleViewer:
+		return true
+	}
+	return false
+}
+
+// GetExamCollaborator gets the collaborator of an exam from the database.
+func GetExamCollaborator(userId string, examId int) (*ExamCollaborator, error) {
+	uniqueId := getExamCollaboratorKey(userId, examId)
+	info := examCollaboratorsMap.Get(uniqueId)
+	if info != nil && info != valueExamCollaboratorNotFound &&
+		info.ExamId == examId && info.UserId == userId {
+		return info, nil
+	} else if info == valueExamCollaboratorNotFound {
+		return nil, ErrExamCollaboratorNotFound
+	}
+
+	info = &ExamCollaborator{}
+	err := DefaultContainer.db.QueryRow(context.Background(),
+		`SELECT exam_id,
+			user_id,
+			collaborator_role,
+			added_by,
+			created_at,
+			updated_at
+		FROM exam_collaborator WHERE user_id = $1 AND exam_id = $2`,
+		userId,
+		examId,
+	).Scan(
+		&info.ExamId,
+		&info.UserId,
+		&info.CollaboratorRole,
+		&info.AddedBy,
+		&info.CreatedAt,
+		&info.UpdatedAt,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			examCollaboratorsMap.Add(uniqueId, valueExamCollaboratorNotFound)
+			return nil, ErrExamCollaboratorNotFound
+		}
+
+		return nil, err
+	}
+
+	examCollaboratorsMap.Add(uniqueId, info)
+	return info, nil
+}
+
+// GetExamCollaboratorOrNil gets the collaborator of an exam, returning nil
+// if the user is not a collaborator of the exam (or on errors).
+func GetExamCollaboratorOrNil(userId string, examId int) *ExamCollaborator {
+	info, err := GetExamCollaborator(userId, examId)
+	if err != nil && err != ErrExamCollaboratorNotFound {
+		logging.UnexpectedError("GetExamCollaboratorOrNil: failed to get collaborator:", err)
+	}
+
+	return info
+}
+
+// GetExamCollaborators gets the collaborators of an exam, the ones added
+// the earliest first.
+func GetExamCollaborators(examId int) ([]*ExamCollaborator, error) {
+	rows, err := DefaultContainer.db.Query(context.Background(),
+		`SELECT exam_id,
+			user_id,
+			collaborator_role,
+			added_by,
+			created_at,
+			updated_at
+		FROM exam_collaborator
+		WHERE exam_id = $1
+		ORDER BY created_at ASC, user_id ASC`,
+		examId,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var collaborators []*ExamCollaborator
+	for rows.Next() {
+		info := &ExamCollaborator{}
+		err = rows.Scan(
+			&info.ExamId,
+			&info.UserId,
+			&info.CollaboratorRole,
+			&info.AddedBy,
+			&info.CreatedAt,
+			&info.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		collaborators = append(collaborators, info)
+	}
+
+	return collaborators, nil
+}
+
+// GetExamCollaboratorsCount returns the number of the collaborators of
+// an exam.
+func GetExamCollaboratorsCount(examId int) (int, error) {
+	var count int
+	err := DefaultContainer.db.QueryRow(context.Background(),
+		`SELECT COUNT(*) FROM exam_collaborator WHERE exam_id = $1`,
+		examId,
+	).Scan(&count)
+	return count, err
+}
+
+// SetExamCollaborator adds a collaborator to an exam, or changes their
+// role if they're already a collaborator of it.
+// It uses the sp set_exam_collaborator.
+func SetExamCollaborator(data *SetExamCollaboratorData) (*ExamCollaborator, error) {
+	_, err := DefaultContainer.db.Exec(context.Background(),
+		`CALL set_exam_collaborator(
+			p_exam_id := $1,
+			p_user_id := $2,
+			p_collaborator_role := $3,
+			p_added_by := $4
+		)`,
+		data.ExamId,
+		data.UserId,
+		data.CollaboratorRole,
+		data.AddedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// the user might have been cached as "not a collaborator"
+	examCollaboratorsMap.Delete(getExamCollaboratorKey(data.UserId, data.ExamId))
+	return GetExamCollaborator(data.UserId, data.ExamId)
+}
+
+// RemoveExamCollaborator removes a collaborator from an exam.
+func RemoveExamCollaborator(userId string, examId int) error {
+	result, err := DefaultContainer.db.Exec(context.Background(),
+		`DELETE FROM exam_collaborator WHERE user_id = $1 AND exam_id = $2`,
+		userId,
+		examId,
+	)
+	if err != nil {
+		return err
+	}
+
+	examCollaboratorsMap.Add(getExamCollaboratorKey(userId, examId), valueExamCollaboratorNotFound)
+	if result.RowsAffected() == 0 {
+		return ErrExamCollaboratorNotFound
+	}
+
+	return nil
+}
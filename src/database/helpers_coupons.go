@@ -0,0 +1,232 @@
+package database
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// CreateExamCoupon creates a new coupon.
+// It uses the plpgsql function create_exam_coupon.
+func CreateExamCoupon(data *NewExamCouponData) (*ExamCoupon, error) {
+	var couponId int
+	err := DefaultContainer.db.QueryRow(context.Background(),
+		`SELECT create_exam_coupon(
+			p_coupon_code := $1,
+			p_discount_type := $2,
+			p_discount_value := $3,
+			p_scope_type := $4,
+			p_scope_id := $5,
+			p_max_uses := $6,
+			p_max_uses_per_user := $7,
+			p_expires_at := $8,
+			p_created_by := $9
+		)`,
+		data.CouponCode,
+		data.DiscountType,
+		data.DiscountValue,
+		data.ScopeType,
+		data.ScopeId,
+		data.MaxUses,
+		data.MaxUsesPerUser,
+		data.ExpiresAt,
+		data.CreatedBy,
+	).Scan(&couponId)
+	if err != nil {
+		return nil, err
+	}
+
+	return GetExamCoupon(couponId)
+}
+
+// GetExamCoupon returns the coupon with the specified id.
+func GetExamCoupon(couponId int) (*ExamCoupon, error) {
+	return getExamCouponBy("c.coupon_id = $1", couponId)
+}
+
+// GetExamCouponByCode returns the coupon with the specified code (the code
+// is case-insensitive).
+func GetExamCouponByCode(couponCode string) (*ExamCoupon, error) {
+	return getExamCouponBy("c.coupon_code = $1", strings.ToUpper(couponCode))
+}
+
+func getExamCouponBy(condition string, value any) (*ExamCoupon, error) {
+	info := &ExamCoupon{}
+	err := DefaultContainer.db.QueryRow(context.Background(),
+		`SELECT c.coupon_id,
+			c.coupon_code,
+			c.discount_type,
+			c.discount_value,
+			c.scope_type,
+			c.scope_id,
+			c.max_uses,
+			c.max_uses_per_user,
+			c.expires_at,
+			c.is_active,
+			c.created_by,
+			c.created_at,
+			(SELECT COUNT(*) FROM exam_coupon_redemption r
+				WHERE r.coupon_id = c.coupon_id)
+		FROM exam_coupon c WHERE `+condition,
+		value,
+	).Scan(
+		&info.CouponId,
+		&info.CouponCode,
+		&info.DiscountType,
+		&info.DiscountValue,
+		&info.ScopeType,
+		&info.ScopeId,
+		&info.MaxUses,
+		&info.MaxUsesPerUser,
+		&info.ExpiresAt,
+		&info.IsActive,
+		&info.CreatedBy,
+		&info.CreatedAt,
+		&info.UsesCount,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, ErrExamCouponNotFound
+		}
+		return nil, err
+	}
+
+	return info, nil
+}
+
+// GetExamCoupons returns the coupons, most recent ones first.
+func GetExamCoupons(data *GetExamCouponsData) ([]*ExamCoupon, error) {
+	if data.Limit <= 0 {
+		data.Limit = DefaultPaginationLimit
+	}
+
+	rows, err := DefaultContainer.db.Query(context.Background(),
+		`SELECT c.coupon_id,
+			c.coupon_code,
+			c.discount_type,
+			c.discount_value,
+			c.scope_type,
+			c.scope_id,
+			c.max_uses,
+			c.max_uses_per_user,
+			c.expires_at,
+			c.is_active,
+			c.created_by,
+			c.created_at,
+			(SELECT COUNT(*) FROM exam_coupon_redemption r
+				WHERE r.coupon_id = c.coupon_id)
+		FROM exam_coupon c
+		WHERE $1 = '' OR c.created_by = $1
+		ORDER BY c.created_at DESC
+		LIMIT $2 OFFSET $3`,
+		data.CreatedBy,
+		data.Limit,
+		data.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var coupons []*ExamCoupon
+	for rows.Next() {
+		info := &ExamCoupon{}
+		err = rows.Scan(
+			&info.CouponId,
+			&info.CouponCode,
+			&info.DiscountType,
+			&info.DiscountValue,
+			&info.ScopeType,
+			&info.ScopeId,
+			&info.MaxUses,
+			&info.MaxUsesPerUser,
+			&info.ExpiresAt,
+			&info.IsActive,
+			&info.CreatedBy,
+			&info.CreatedAt,
+			&info.UsesCount,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		coupons = append(coupons, info)
+	}
+
+	return coupons, nil
+}
+
+// DeactivateExamCoupon makes the coupon unusable from now on.
+func DeactivateExamCoupon(couponId int) error {
+	_, err := DefaultContainer.db.Exec(context.Background(),
+		`UPDATE exam_coupon SET is_active = FALSE WHERE coupon_id = $1`,
+		couponId,
+	)
+	return err
+}
+
+// IsExamCouponApplicable returns true if the coupon can be used for the
+// exam, based on its scope.
+func IsExamCouponApplicable(couponId, examId int) (bool, error) {
+	var isApplicable bool
+	err := DefaultContainer.db.QueryRow(context.Background(),
+		`SELECT is_exam_coupon_applicable($1, $2)`,
+		couponId,
+		examId,
+	).Scan(&isApplicable)
+	if err != nil {
+		return false, err
+	}
+
+	return isApplicable, nil
+}
+
+// GetExamCouponUserUses returns the number of times the user has used
+// the coupon.
+func GetExamCouponUserUses(couponId int, userId string) (int, error) {
+	var count int
+	err := DefaultContainer.db.QueryRow(context.Background(),
+		`SELECT COUNT(*) FROM exam_coupon_redemption
+		WHERE coupon_id = $1 AND user_id = $2`,
+		couponId,
+		userId,
+	).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// GetUsableExamCoupon returns the coupon with the specified code if the user
+// can use it for the exam right now; ErrExamCouponNotUsable is returned if
+// the coupon is inactive, expired, used up or not applicable to the exam.
+func GetUsableExamCoupon(couponCode, userId string, examId int) (*ExamCoupon, error) {
+	coupon, err := GetExamCouponByCode(couponCode)
+	if err != nil {
+		return nil, err
+	}
+
+	if !coupon.IsActive || coupon.IsExpired() || coupon.HasReachedMaxUses() {
+		return nil, ErrExamCouponNotUsable
+	}
+
+	isApplicable, err := IsExamCouponApplicable(coupon.CouponId, examId)
+	if err != nil {
+		return nil, err
+	} else if !isApplicable {
+		return nil, ErrExamCouponNotUsable
+	}
+
+	if coupon.MaxUsesPerUser != nil {
+		userUses, err := GetExamCouponUserUses(coupon.CouponId, userId)
+		if err != nil {
+			return nil, err
+		} else if userUses >= *coupon.MaxUsesPerUser {
+			return nil, ErrExamCouponNotUsable
+		}
+	}
+
+	return coupon, nil
+}
@@ -1,7 +1,10 @@
 package database
 
 import (
+	"ExamSphere/src/core/utils/contentUtils"
+	"ExamSphere/src/core/utils/irtUtils"
 	"ExamSphere/src/core/utils/logging"
+	"ExamSphere/src/core/utils/paymentUtils"
 	"context"
 	"strings"
 	"time"
@@ -29,6 +32,13 @@ func CreateNewExam(data *NewExamData) (*ExamInfo, error) {
 		Duration:        data.Duration,
 		ExamDate:        data.ExamDate,
 		CreatedAt:       time.Now(),
+
+		AccessCodeType:     AccessCodeTypeNone,
+		AccessCodeInterval: DefaultAccessCodeInterval,
+		MaxAttempts:        DefaultMaxAttempts,
+		GradingPolicy:      DefaultGradingPolicy,
+		DeadlinePolicy:     DefaultDeadlinePolicy,
+		ReviewStatus:       ExamReviewStatusDraft,
 	}
 
 	err := DefaultContainer.db.QueryRow(context.Background(),
@@ -79,7 +89,31 @@ func GetExamInfo(examId int) (*ExamInfo, error) {
 			exam_date, 
 			duration, 
 			created_by, 
-			is_public
+			is_public,
+			access_code_type,
+			access_code,
+			access_code_interval,
+			max_attempts,
+			attempt_cooldown,
+			grading_policy,
+			opens_at,
+			closes_at,
+			late_start_limit,
+			deadline_policy,
+			grace_period,
+			capacity,
+			is_adaptive,
+			adaptive_max_items,
+			adaptive_target_se,
+			review_status,
+			submitted_at,
+			reviewed_by,
+			reviewed_at,
+			is_practice,
+			attempt_review_policy,
+			results_released_by,
+			results_released_at,
+			grade_category_id
 		FROM exam_info WHERE exam_id = $1`,
 		examId,
 	).Scan(
@@ -93,6 +127,30 @@ func GetExamInfo(examId int) (*ExamInfo, error) {
 		&info.Duration,
 		&info.CreatedBy,
 		&info.IsPublic,
+		&info.AccessCodeType,
+		&info.AccessCode,
+		&info.AccessCodeInterval,
+		&info.MaxAttempts,
+		&info.AttemptCooldown,
+		&info.GradingPolicy,
+		&info.OpensAt,
+		&info.ClosesAt,
+		&info.LateStartLimit,
+		&info.DeadlinePolicy,
+		&info.GracePeriod,
+		&info.Capacity,
+		&info.IsAdaptive,
+		&info.AdaptiveMaxItems,
+		&info.AdaptiveTargetStdError,
+		&info.ReviewStatus,
+		&info.SubmittedAt,
+		&info.ReviewedBy,
+		&info.ReviewedAt,
+		&info.IsPractice,
+		&info.AttemptReviewPolicy,
+		&info.ResultsReleasedBy,
+		&info.ResultsReleasedAt,
+		&info.GradeCategoryId,
 	)
 	if err != nil {
 		if err == pgx.ErrNoRows {
@@ -126,11 +184,17 @@ func SearchExam(data *SearchExamsData) (*SearchExamResult, error) {
 			is_public
 		FROM exam_info
 		WHERE exam_title ILIKE '%' || $1 || '%'`+publicWhere+`
+			AND ($4 OR review_status = 'published' OR created_by = $5 OR EXISTS (
+				SELECT 1 FROM exam_collaborator c
+				WHERE c.exam_id = exam_info.exam_id AND c.user_id = $5
+			))
 		ORDER BY exam_date DESC
 		LIMIT $2 OFFSET $3`,
 		"%"+data.SearchQuery+"%",
 		data.Limit,
 		data.Offset,
+		data.IncludeUnpublished,
+		data.UserId,
 	)
 	if err != nil {
 		return nil, err
@@ -207,6 +271,159 @@ func EditExamInfo(data *EditExamInfoData) (*ExamInfo, error) {
 	return info, nil
 }
 
+// SetExamAccessCode sets the access code settings of an exam.
+// It uses the sp set_exam_access_code.
+func SetExamAccessCode(data *SetExamAccessCodeData) (*ExamInfo, error) {
+	info, err := GetExamInfo(data.ExamId)
+	if err != nil {
+		return nil, err
+	} else if info == nil {
+		return nil, ErrExamNotFound
+	}
+
+	if data.AccessCodeInterval <= 0 {
+		data.AccessCodeInterval = DefaultAccessCodeInterval
+	}
+
+	_, err = DefaultContainer.db.Exec(context.Background(),
+		`CALL set_exam_access_code(
+			p_exam_id := $1,
+			p_access_code_type := $2,
+			p_access_code := $3,
+			p_access_code_interval := $4
+		)`,
+		data.ExamId,
+		data.AccessCodeType,
+		data.AccessCode,
+		data.AccessCodeInterval,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	info.AccessCodeType = data.AccessCodeType
+	info.AccessCode = ssg.Clone(data.AccessCode)
+	info.AccessCodeInterval = data.AccessCodeInterval
+
+	return info, nil
+}
+
+// SetExamRetakePolicy sets the retake policy of an exam.
+// It uses the sp set_exam_retake_policy.
+func SetExamRetakePolicy(data *SetExamRetakePolicyData) (*ExamInfo, error) {
+	info, err := GetExamInfo(data.ExamId)
+	if err != nil {
+		return nil, err
+	} else if info == nil {
+		return nil, ErrExamNotFound
+	}
+
+	if data.GradingPolicy == "" {
+		data.GradingPolicy = DefaultGradingPolicy
+	}
+
+	_, err = DefaultContainer.db.Exec(context.Background(),
+		`CALL set_exam_retake_policy(
+			p_exam_id := $1,
+			p_max_attempts := $2,
+			p_attempt_cooldown := $3,
+			p_grading_policy := $4
+		)`,
+		data.ExamId,
+		data.MaxAttempts,
+		data.AttemptCooldown,
+		data.GradingPolicy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	info.MaxAttempts = data.MaxAttempts
+	info.AttemptCooldown = data.AttemptCooldown
+	info.GradingPolicy = data.GradingPolicy
+
+	// final scores of the participants might have changed
+	examKeySuffix := KeySepChar + ssg.ToBase10(data.ExamId)
+	givenExamsMap.ForEach(func(key string, value *GivenExam) ssg.ForEachOperation {
+		if strings.HasSuffix(key, examKeySuffix) {
+			return ssg.ForEachOperationRemove
+		}
+		return ssg.ForEachOperationContinue
+	})
+
+	return info, nil
+}
+
+// SetExamPracticeMode sets whether an exam is a practice exam.
+// It uses the sp set_exam_practice_mode.
+func SetExamPracticeMode(data *SetExamPracticeModeData) (*ExamInfo, error) {
+	info, err := GetExamInfo(data.ExamId)
+	if err != nil {
+		return nil, err
+	} else if info == nil {
+		return nil, ErrExamNotFound
+	}
+
+	_, err = DefaultContainer.db.Exec(context.Background(),
+		`CALL set_exam_practice_mode(
+			p_exam_id := $1,
+			p_is_practice := $2
+		)`,
+		data.ExamId,
+		data.IsPractice,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	info.IsPractice = data.IsPractice
+	return info, nil
+}
+
+// SetExamAvailability sets the availability window, the late start rule and
+// the deadline policy of an exam.
+// It uses the sp set_exam_availability.
+func SetExamAvailability(data *SetExamAvailabilityData) (*ExamInfo, error) {
+	info, err := GetExamInfo(data.ExamId)
+	if err != nil {
+		return nil, err
+	} else if info == nil {
+		return nil, ErrExamNotFound
+	}
+
+	if data.DeadlinePolicy == "" {
+		data.DeadlinePolicy = DefaultDeadlinePolicy
+	}
+
+	_, err = DefaultContainer.db.Exec(context.Background(),
+		`CALL set_exam_availability(
+			p_exam_id := $1,
+			p_opens_at := $2,
+			p_closes_at := $3,
+			p_late_start_limit := $4,
+			p_deadline_policy := $5,
+			p_grace_period := $6
+		)`,
+		data.ExamId,
+		data.OpensAt,
+		data.ClosesAt,
+		data.LateStartLimit,
+		data.DeadlinePolicy,
+		data.GracePeriod,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	info.OpensAt = ssg.Clone(data.OpensAt)
+	info.ClosesAt = ssg.Clone(data.ClosesAt)
+	info.LateStartLimit = ssg.Clone(data.LateStartLimit)
+	info.DeadlinePolicy = data.DeadlinePolicy
+	info.GracePeriod = data.GracePeriod
+
+	return info, nil
+}
+
 // GetExamInfoOrNil gets the exam info or nil if not found.
 func GetExamInfoOrNil(examId int) *ExamInfo {
 	info, err := GetExamInfo(examId)
@@ -246,6 +463,23 @@ func HasExamFinished(examId int) (bool, error) {
 	return hasFinished, nil
 }
 
+// HasExamFinishedForUser returns true if the exam has finished for the user,
+// considering their accommodation (if any).
+// It uses the plpgsql function has_exam_finished.
+func HasExamFinishedForUser(userId string, examId int) (bool, error) {
+	var hasFinished bool
+	err := DefaultContainer.db.QueryRow(context.Background(),
+		`SELECT has_exam_finished($1, $2)`,
+		examId,
+		userId,
+	).Scan(&hasFinished)
+	if err != nil {
+		return false, err
+	}
+
+	return hasFinished, nil
+}
+
 // GetExamStartsIn returns the time in minutes until the exam starts.
 func GetExamStartsIn(examId int) (int, error) {
 	var startsIn int
@@ -274,6 +508,23 @@ func GetExamFinishesIn(examId int) (int, error) {
 	return finishesIn, nil
 }
 
+// GetExamFinishesInForUser returns the time in minutes until the exam
+// finishes for the user, considering their accommodation (if any).
+// It uses the plpgsql function get_exam_finishes_in.
+func GetExamFinishesInForUser(userId string, examId int) (int, error) {
+	var finishesIn int
+	err := DefaultContainer.db.QueryRow(context.Background(),
+		`SELECT get_exam_finishes_in($1, $2)`,
+		examId,
+		userId,
+	).Scan(&finishesIn)
+	if err != nil {
+		return 0, err
+	}
+
+	return finishesIn, nil
+}
+
 // GetExamQuestionsCount returns the count of questions in the exam.
 func GetExamQuestionsCount(examId int) int {
 	var count int
@@ -307,6 +558,22 @@ func CreateNewExamQuestion(data *NewExamQuestionData) (*ExamQuestion, error) {
 		Option2:       data.Option2,
 		Option3:       data.Option3,
 		Option4:       data.Option4,
+		SectionId:     data.SectionId,
+		QuestionOrder: data.QuestionOrder,
+		ContentFormat: data.ContentFormat,
+		Explanation:   data.Explanation,
+		Points:        DefaultQuestionPoints,
+		CreatedAt:     time.Now(),
+
+		IrtDiscrimination:  irtUtils.DefaultDiscrimination,
+		Tags:               []string{},
+		LearningObjectives: []string{},
+	}
+	if info.ContentFormat == "" {
+		info.ContentFormat = contentUtils.FormatPlain
+	}
+	if data.Points != nil {
+		info.Points = *data.Points
 	}
 
 	err = DefaultContainer.db.QueryRow(context.Background(),
@@ -317,7 +584,12 @@ func CreateNewExamQuestion(data *NewExamQuestionData) (*ExamQuestion, error) {
 			p_option1 := $4,
 			p_option2 := $5,
 			p_option3 := $6,
-			p_option4 := $7
+			p_option4 := $7,
+			p_section_id := $8,
+			p_question_order := $9,
+			p_content_format := $10,
+			p_explanation := $11,
+			p_points := $12
 		)`,
 		info.ExamId,
 		info.QuestionTitle,
@@ -326,6 +598,11 @@ func CreateNewExamQuestion(data *NewExamQuestionData) (*ExamQuestion, error) {
 		info.Option2,
 		info.Option3,
 		info.Option4,
+		info.SectionId,
+		info.QuestionOrder,
+		info.ContentFormat,
+		info.Explanation,
+		info.Points,
 	).Scan(&info.QuestionId)
 	if err != nil {
 		return nil, err
@@ -356,6 +633,15 @@ func EditExamQuestion(data *EditExamQuestionData) (*ExamQuestion, error) {
 	info.Option2 = data.Option2
 	info.Option3 = data.Option3
 	info.Option4 = data.Option4
+	info.SectionId = data.SectionId
+	info.QuestionOrder = data.QuestionOrder
+	info.Explanation = data.Explanation
+	if data.ContentFormat != "" {
+		info.ContentFormat = data.ContentFormat
+	}
+	if data.Points != nil {
+		info.Points = *data.Points
+	}
 
 	_, err = DefaultContainer.db.Exec(context.Background(),
 		`UPDATE exam_question SET
@@ -364,14 +650,24 @@ func EditExamQuestion(data *EditExamQuestionData) (*ExamQuestion, error) {
 			option1 = $3,
 			option2 = $4,
 			option3 = $5,
-			option4 = $6
-		WHERE question_id = $7`,
+			option4 = $6,
+			section_id = $7,
+			question_order = $8,
+			content_format = $9,
+			explanation = $10,
+			points = $11
+		WHERE question_id = $12`,
 		info.QuestionTitle,
 		info.Description,
 		info.Option1,
 		info.Option2,
 		info.Option3,
 		info.Option4,
+		info.SectionId,
+		info.QuestionOrder,
+		info.ContentFormat,
+		info.Explanation,
+		info.Points,
 		info.QuestionId,
 	)
 	if err != nil {
@@ -407,7 +703,20 @@ func GetExamQuestion(examId, questionId int) (*ExamQuestion, error) {
 			option2, 
 			option3, 
 			option4, 
-			created_at
+			section_id, 
+			question_order, 
+			content_format, 
+			created_at, 
+			correct_option, 
+			irt_discrimination, 
+			irt_difficulty, 
+			irt_guessing, 
+			tags, 
+			difficulty_level, 
+			learning_objectives, 
+			topic_id, 
+			explanation,
+			points
 		FROM exam_question WHERE question_id = $1`,
 		questionId,
 	).Scan(
@@ -419,7 +728,20 @@ func GetExamQuestion(examId, questionId int) (*ExamQuestion, error) {
 		&info.Option2,
 		&info.Option3,
 		&info.Option4,
+		&info.SectionId,
+		&info.QuestionOrder,
+		&info.ContentFormat,
 		&info.CreatedAt,
+		&info.CorrectOption,
+		&info.IrtDiscrimination,
+		&info.IrtDifficulty,
+		&info.IrtGuessing,
+		&info.Tags,
+		&info.DifficultyLevel,
+		&info.LearningObjectives,
+		&info.TopicId,
+		&info.Explanation,
+		&info.Points,
 	)
 	if err != nil {
 		if err == pgx.ErrNoRows {
@@ -450,21 +772,40 @@ func GetExamQuestions(data *GetExamQuestionsData) ([]*ExamQuestion, error) {
 	// }
 
 	rows, err := DefaultContainer.db.Query(context.Background(),
-		`SELECT question_id, 
-			exam_id, 
-			question_title, 
-			description, 
-			option1, 
-			option2, 
-			option3, 
-			option4, 
-			created_at
-		FROM exam_question WHERE exam_id = $1
-		ORDER BY question_id
+		`SELECT q.question_id, 
+			q.exam_id, 
+			q.question_title, 
+			q.description, 
+			q.option1, 
+			q.option2, 
+			q.option3, 
+			q.option4, 
+			q.section_id, 
+			q.question_order, 
+			q.content_format, 
+			q.created_at, 
+			q.correct_option, 
+			q.irt_discrimination, 
+			q.irt_difficulty, 
+			q.irt_guessing, 
+			q.tags, 
+			q.difficulty_level, 
+			q.learning_objectives, 
+			q.topic_id, 
+			q.explanation,
+			q.points
+		FROM exam_question q
+		LEFT JOIN exam_section s ON s.section_id = q.section_id
+		WHERE q.exam_id = $1 AND (NOT $4 OR
+			q.section_id = ANY($5) OR ($6 AND q.section_id IS NULL))
+		ORDER BY s.section_order NULLS FIRST, s.section_id, q.question_order, q.question_id
 		LIMIT $2 OFFSET $3`,
 		data.ExamId,
 		data.Limit,
 		data.Offset,
+		data.FilterSections,
+		data.SectionIds,
+		data.IncludeUnsectioned,
 	)
 	if err != nil {
 		return nil, err
@@ -483,7 +824,20 @@ func GetExamQuestions(data *GetExamQuestionsData) ([]*ExamQuestion, error) {
 			&info.Option2,
 			&info.Option3,
 			&info.Option4,
+			&info.SectionId,
+			&info.QuestionOrder,
+			&info.ContentFormat,
 			&info.CreatedAt,
+			&info.CorrectOption,
+			&info.IrtDiscrimination,
+			&info.IrtDifficulty,
+			&info.IrtGuessing,
+			&info.Tags,
+			&info.DifficultyLevel,
+			&info.LearningObjectives,
+			&info.TopicId,
+			&info.Explanation,
+			&info.Points,
 		)
 		if err != nil {
 			return nil, err
@@ -540,6 +894,37 @@ func CanParticipateInExamOrFalse(userId string, examId int) bool {
 	return canParticipate
 }
 
+// CanAddUserToExam returns true if the user can be added to the exam by
+// another user (addedBy); unlike CanParticipateInExam, the exam doesn't have
+// to be public. The permission of addedBy to add others to the exam is not
+// checked here.
+func CanAddUserToExam(userId string, examId int, addedBy string) (bool, error) {
+	var canParticipate bool
+	err := DefaultContainer.db.QueryRow(context.Background(),
+		`SELECT can_participate_in_exam($1, $2, $3)`,
+		examId,
+		userId,
+		addedBy,
+	).Scan(&canParticipate)
+	if err != nil {
+		return false, err
+	}
+
+	return canParticipate, nil
+}
+
+// CanAddUserToExamOrFalse returns true if the user can be added to the exam
+// by another user. It will also returns false if there is an error.
+func CanAddUserToExamOrFalse(userId string, examId int, addedBy string) bool {
+	canParticipate, err := CanAddUserToExam(userId, examId, addedBy)
+	if err != nil {
+		logging.UnexpectedError("CanAddUserToExamOrFalse: failed to check participation:", err)
+		return false
+	}
+
+	return canParticipate
+}
+
 // GetGivenExam gets the information of a given exam.
 func GetGivenExam(userId string, examId int) (*GivenExam, error) {
 	uniqueId := userId + KeySepChar + ssg.ToBase10(examId)
@@ -599,6 +984,11 @@ func AddUserInExam(data *NewGivenExamData) (*GivenExam, error) {
 		return info, nil
 	}
 
+	amount, currency, err := paymentUtils.ParsePrice(data.Price)
+	if err != nil {
+		return nil, err
+	}
+
 	info = &GivenExam{
 		UserId:    data.UserId,
 		ExamId:    data.ExamId,
@@ -607,27 +997,47 @@ func AddUserInExam(data *NewGivenExamData) (*GivenExam, error) {
 		CreatedAt: time.Now(),
 	}
 
+	var couponId *int
+	if data.Coupon != nil {
+		couponId = &data.Coupon.CouponId
+	}
+
+	// the price of the exam is taken from the wallet of the user (and the
+	// coupon is redeemed) in the same transaction; the wallet can only be
+	// debited in its own currency (ErrCurrencyMismatch otherwise).
 	// 	-- Example usage:
 	// --    CALL add_user_in_exam(
 	// --        p_user_id := 'user123',
 	// --        p_exam_id := 1001,
-	// --        p_price := '0T',
-	// --        p_added_by := 'admin'
+	// --        p_price := '119.99T',
+	// --        p_added_by := 'admin',
+	// --        p_amount := 11999,
+	// --        p_currency := 'T',
+	// --        p_coupon_id := 1,
+	// --        p_discount := 3000
 	// --    );
-	_, err := DefaultContainer.db.Exec(context.Background(),
+	_, err = DefaultContainer.db.Exec(context.Background(),
 		`CALL add_user_in_exam(
 			p_user_id := $1,
 			p_exam_id := $2,
 			p_price := $3,
-			p_added_by := $4
+			p_added_by := $4,
+			p_amount := $5,
+			p_currency := $6,
+			p_coupon_id := $7,
+			p_discount := $8
 		)`,
 		info.UserId,
 		info.ExamId,
 		info.Price,
 		info.AddedBy,
+		amount,
+		currency,
+		couponId,
+		data.Discount,
 	)
 	if err != nil {
-		return nil, err
+		return nil, toWalletError(err)
 	}
 
 	givenExamsMap.Add(uniqueId, info)
@@ -644,26 +1054,36 @@ func SetScoreForUserInExam(data *NewScoreData) (*GivenExam, error) {
 		return nil, ErrGivenExamNotFound
 	}
 
-	info.FinalScore = ssg.Clone(&data.FinalScore)
-	info.ScoredBy = ssg.Clone(&data.ScoredBy)
+	var attemptNumber *int
+	if data.AttemptNumber != 0 {
+		attemptNumber = &data.AttemptNumber
+	}
 
 	_, err = DefaultContainer.db.Exec(context.Background(),
 		`CALL set_score_for_user_in_exam(
 			p_exam_id := $1,
 			p_user_id := $2,
 			p_final_score := $3,
-			p_scored_by := $4
+			p_scored_by := $4,
+			p_attempt_number := $5
 		)`,
 		info.ExamId,
 		info.UserId,
-		info.FinalScore,
-		info.ScoredBy,
+		data.FinalScore,
+		data.ScoredBy,
+		attemptNumber,
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	return info, nil
+	// the final score is decided by the grading policy of the exam,
+	// so it has to be fetched again
+	uniqueId := data.UserId + KeySepChar + ssg.ToBase10(data.ExamId)
+	givenExamsMap.Delete(uniqueId)
+	latestExamAttemptsMap.Delete(uniqueId)
+
+	return GetGivenExam(data.UserId, data.ExamId)
 }
 
 // GetMostRecentExams returns the most recent exams.
@@ -717,14 +1137,13 @@ func GetMostRecentExams(data *GetMostRecentExamsData) ([]*MostRecentExamInfo, er
 
 // GetGivenAnswer gets the given answer of a user for a question in an exam.
 func GetGivenAnswer(data *GetGivenAnswerData) (*GivenAnswerInfo, error) {
-	uniqueId := ssg.ToBase10(data.ExamId) + KeySepChar +
-		ssg.ToBase10(data.QuestionId) + KeySepChar +
-		data.UserId
+	uniqueId := getGivenAnswerKey(data.ExamId, data.QuestionId, data.UserId, data.AttemptNumber)
 	info := givenAnswersMap.Get(uniqueId)
 	if info != nil && info != valueGivenAnswerNotFound &&
 		info.ExamId == data.ExamId &&
 		info.QuestionId == data.QuestionId &&
-		info.AnsweredBy == data.UserId {
+		info.AnsweredBy == data.UserId &&
+		info.AttemptNumber == data.AttemptNumber {
 		return info, nil
 	} else if info == valueGivenAnswerNotFound {
 		return nil, ErrGivenAnswerNotFound
@@ -735,22 +1154,34 @@ func GetGivenAnswer(data *GetGivenAnswerData) (*GivenAnswerInfo, error) {
 		`SELECT exam_id, 
 			question_id, 
 			answered_by, 
+			attempt_number,
 			chosen_option,
 			seconds_taken,
 			answer_text,
-			answered_at
-		FROM given_answer WHERE exam_id = $1 AND question_id = $2 AND answered_by = $3`,
+			answered_at,
+			points_earned,
+			grader_comment,
+			graded_by,
+			graded_at
+		FROM given_answer WHERE exam_id = $1 AND question_id = $2 AND answered_by = $3
+			AND attempt_number = $4`,
 		data.ExamId,
 		data.QuestionId,
 		data.UserId,
+		data.AttemptNumber,
 	).Scan(
 		&info.ExamId,
 		&info.QuestionId,
 		&info.AnsweredBy,
+		&info.AttemptNumber,
 		&info.ChosenOption,
 		&info.SecondsTaken,
 		&info.AnswerText,
 		&info.AnsweredAt,
+		&info.PointsEarned,
+		&info.GraderComment,
+		&info.GradedBy,
+		&info.GradedAt,
 	)
 	if err != nil {
 		if err == pgx.ErrNoRows {
@@ -784,15 +1215,14 @@ func AnswerQuestion(data *AnswerQuestionData) (*GivenAnswerInfo, error) {
 		return nil, ErrInvalidAnswer
 	}
 
-	uniqueId := ssg.ToBase10(data.ExamId) + KeySepChar +
-		ssg.ToBase10(data.QuestionId) + KeySepChar +
-		data.AnsweredBy
+	uniqueId := getGivenAnswerKey(data.ExamId, data.QuestionId, data.AnsweredBy, data.AttemptNumber)
 	info := givenAnswersMap.Get(uniqueId)
 	if info == nil || info == valueGivenAnswerNotFound || info.ExamId != data.ExamId {
 		info = &GivenAnswerInfo{
-			ExamId:     data.ExamId,
-			QuestionId: data.QuestionId,
-			AnsweredBy: data.AnsweredBy,
+			ExamId:        data.ExamId,
+			QuestionId:    data.QuestionId,
+			AnsweredBy:    data.AnsweredBy,
+			AttemptNumber: data.AttemptNumber,
 		}
 	}
 
@@ -808,7 +1238,8 @@ func AnswerQuestion(data *AnswerQuestionData) (*GivenAnswerInfo, error) {
 			p_answered_by := $3,
 			p_chosen_option := $4,
 			p_seconds_taken := $5,
-			p_answer_text := $6
+			p_answer_text := $6,
+			p_attempt_number := $7
 		)`,
 		info.ExamId,
 		info.QuestionId,
@@ -816,6 +1247,7 @@ func AnswerQuestion(data *AnswerQuestionData) (*GivenAnswerInfo, error) {
 		info.ChosenOption,
 		info.SecondsTaken,
 		info.AnswerText,
+		info.AttemptNumber,
 	)
 	if err != nil {
 		logging.UnexpectedError("AnswerQuestion: failed to answer question:", err)
@@ -829,8 +1261,9 @@ func AnswerQuestion(data *AnswerQuestionData) (*GivenAnswerInfo, error) {
 // GetUserOngoingExams gets the ongoing exams of a user.
 func GetUserOngoingExams(userId string) ([]*UserOngoingExamInfo, error) {
 	rows, err := DefaultContainer.db.Query(context.Background(),
-		`SELECT exam_id, exam_title, exam_date
-		FROM user_ongoing_exams WHERE user_id = $1`,
+		`SELECT exam_id, exam_title, starts_at, ends_at
+		FROM user_ongoing_exams WHERE user_id = $1
+		ORDER BY ends_at`,
 		userId,
 	)
 	if err != nil {
@@ -849,6 +1282,7 @@ func GetUserOngoingExams(userId string) ([]*UserOngoingExamInfo, error) {
 			&info.ExamId,
 			&info.ExamTitle,
 			&info.StartTime,
+			&info.EndTime,
 		)
 		if err != nil {
 			return nil, err
@@ -915,15 +1349,20 @@ func GetUserExamsHistoryOrNil(opts *GetUserExamsHistoryOptions) []*UserPastExamI
 // GetExamParticipants gets all the participants of an exam.
 func GetExamParticipants(opts *GetExamParticipantsOptions) ([]*GivenExam, error) {
 	rows, err := DefaultContainer.db.Query(context.Background(),
-		`SELECT user_id, 
-			exam_id, 
-			price, 
-			added_by, 
-			scored_by, 
-			created_at, 
-			final_score
-		FROM given_exam WHERE exam_id = $1
-		ORDER BY created_at DESC
+		`SELECT g.user_id, 
+			g.exam_id, 
+			g.price, 
+			g.added_by, 
+			g.scored_by, 
+			g.created_at, 
+			g.final_score,
+			COALESCE(b.mismatch_count, 0),
+			COALESCE(b.ip_change_count, 0)
+		FROM given_exam g
+		LEFT JOIN exam_attempt_binding b
+			ON b.user_id = g.user_id AND b.exam_id = g.exam_id
+		WHERE g.exam_id = $1
+		ORDER BY g.created_at DESC
 		LIMIT $2 OFFSET $3`,
 		opts.ExamId,
 		opts.Limit,
@@ -945,6 +1384,8 @@ func GetExamParticipants(opts *GetExamParticipantsOptions) ([]*GivenExam, error)
 			&info.ScoredBy,
 			&info.CreatedAt,
 			&info.FinalScore,
+			&info.ClientMismatches,
+			&info.ClientIPChanges,
 		)
 		if err != nil {
 			return nil, err
@@ -966,3 +1407,25 @@ func GetExamParticipantsOrNil(opts *GetExamParticipantsOptions) []*GivenExam {
 
 	return exams
 }
+
+// IsDeadlinePolicyValid returns true if the specified deadline policy is
+// supported.
+func IsDeadlinePolicyValid(policy string) bool {
+	switch policy {
+	case DeadlinePolicyAutoSubmit, DeadlinePolicyGracePeriod:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsGradingPolicyValid returns true if the specified grading policy is
+// supported.
+func IsGradingPolicyValid(policy string) bool {
+	switch policy {
+	case GradingPolicyBest, GradingPolicyLatest, GradingPolicyAverage:
+		return true
+	default:
+		return false
+	}
+}
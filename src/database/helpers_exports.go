@@ -0,0 +1,138 @@
+package database
+
+import "context"
+
+// GetExamParticipantsExportBatch gets a batch of the participants of an
+// exam alongside their results, ordered by their user id; only the
+// participants after afterUserId are returned, so the whole list can be
+// walked batch by batch without holding a connection in between.
+func GetExamParticipantsExportBatch(examId int, afterUserId string, limit int) ([]*ExamParticipantExportRow, error) {
+	rows, err := DefaultContainer.db.Query(context.Background(),
+		`SELECT g.user_id,
+			u.full_name,
+			u.email,
+			g.created_at,
+			(SELECT COUNT(*) FROM exam_attempt a
+				WHERE a.exam_id = g.exam_id AND a.user_id = g.user_id),
+			(SELECT MAX(a.finished_at) FROM exam_attempt a
+				WHERE a.exam_id = g.exam_id AND a.user_id = g.user_id),
+			g.final_score,
+			get_score_percentage(g.final_score),
+			g.scored_by
+		FROM given_exam g
+		JOIN user_info u ON g.user_id = u.user_id
+		WHERE g.exam_id = $1 AND g.user_id > $2
+		ORDER BY g.user_id
+		LIMIT $3`,
+		examId,
+		afterUserId,
+		limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var participants []*ExamParticipantExportRow
+	for rows.Next() {
+		info := &ExamParticipantExportRow{}
+		err = rows.Scan(
+			&info.UserId,
+			&info.FullName,
+			&info.Email,
+			&info.JoinedAt,
+			&info.AttemptsCount,
+			&info.LastFinishedAt,
+			&info.FinalScore,
+			&info.ScorePercentage,
+			&info.ScoredBy,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		participants = append(participants, info)
+	}
+
+	return participants, nil
+}
+
+// GetCourseParticipantsExportBatch gets a batch of the participants of a
+// course, ordered by their user id; only the participants after
+// afterUserId are returned. If examIds is not nil, only the participants
+// of those exams are returned.
+func GetCourseParticipantsExportBatch(courseId int, examIds []int, afterUserId string, limit int) ([]*CourseParticipantInfo, error) {
+	rows, err := DefaultContainer.db.Query(context.Background(),
+		`SELECT DISTINCT u.user_id, u.full_name
+		FROM given_exam g
+		JOIN exam_info e ON g.exam_id = e.exam_id
+		JOIN user_info u ON g.user_id = u.user_id
+		WHERE e.course_id = $1
+			AND ($2::INTEGER[] IS NULL OR g.exam_id = ANY($2))
+			AND u.user_id > $3
+		ORDER BY u.user_id
+		LIMIT $4`,
+		courseId,
+		examIds,
+		afterUserId,
+		limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var participants []*CourseParticipantInfo
+	for rows.Next() {
+		participant := &CourseParticipantInfo{}
+		err = rows.Scan(
+			&participant.UserId,
+			&participant.FullName,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		participants = append(participants, participant)
+	}
+
+	return participants, nil
+}
+
+// GetCourseUsersGradebookScores gets the scores of the specified users in
+// the graded exams of a course, using the course_gradebook_scores view.
+func GetCourseUsersGradebookScores(courseId int, userIds []string) ([]*CourseGradebookScore, error) {
+	rows, err := DefaultContainer.db.Query(context.Background(),
+		`SELECT user_id,
+			exam_id,
+			final_score,
+			score_percentage
+		FROM course_gradebook_scores
+		WHERE course_id = $1 AND user_id = ANY($2)
+		ORDER BY user_id, exam_id`,
+		courseId,
+		userIds,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var scores []*CourseGradebookScore
+	for rows.Next() {
+		info := &CourseGradebookScore{}
+		err = rows.Scan(
+			&info.UserId,
+			&info.ExamId,
+			&info.FinalScore,
+			&info.ScorePercentage,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		scores = append(scores, info)
+	}
+
+	return scores, nil
+}
@@ -0,0 +1,289 @@
+package database
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/ALiwoto/ssg/ssg"
+	"github.com/jackc/pgx/v5"
+)
+
+// CreateCourseGradeCategory adds a new grade category to a course, using
+// the plpgsql function create_course_grade_category.
+func CreateCourseGradeCategory(data *NewCourseGradeCategoryData) (*CourseGradeCategory, error) {
+	info := &CourseGradeCategory{
+		CourseId:     data.CourseId,
+		CategoryName: strings.TrimSpace(data.CategoryName),
+		Weight:       data.Weight,
+		DropLowest:   data.DropLowest,
+		AddedBy:      ssg.Clone(&data.AddedBy),
+		CreatedAt:    time.Now(),
+	}
+
+	err := DefaultContainer.db.QueryRow(context.Background(),
+		`SELECT create_course_grade_category(
+			p_course_id := $1,
+			p_category_name := $2,
+			p_weight := $3,
+			p_drop_lowest := $4,
+			p_added_by := $5
+		)`,
+		info.CourseId,
+		info.CategoryName,
+		info.Weight,
+		info.DropLowest,
+		info.AddedBy,
+	).Scan(&info.CategoryId)
+	if err != nil {
+		return nil, err
+	}
+
+	return info, nil
+}
+
+// EditCourseGradeCategory edits a grade category of a course.
+func EditCourseGradeCategory(data *EditCourseGradeCategoryData) (*CourseGradeCategory, error) {
+	info, err := GetCourseGradeCategory(data.CategoryId)
+	if err != nil {
+		return nil, err
+	}
+
+	info.CategoryName = strings.TrimSpace(data.CategoryName)
+	info.Weight = data.Weight
+	info.DropLowest = data.DropLowest
+
+	_, err = DefaultContainer.db.Exec(context.Background(),
+		`UPDATE course_grade_category
+			SET category_name = $1,
+				weight = $2,
+				drop_lowest = $3
+			WHERE category_id = $4`,
+		info.CategoryName,
+		info.Weight,
+		info.DropLowest,
+		info.CategoryId,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return info, nil
+}
+
+// DeleteCourseGradeCategory deletes a grade category of a course; its
+// exams are not counted in the course grade anymore.
+func DeleteCourseGradeCategory(categoryId int) error {
+	_, err := DefaultContainer.db.Exec(context.Background(),
+		`DELETE FROM course_grade_category WHERE category_id = $1`,
+		categoryId,
+	)
+	if err != nil {
+		return err
+	}
+
+	// the database has set the category of its exams to null
+	examsInfoMap.ForEach(func(key int, value *ExamInfo) ssg.ForEachOperation {
+		if value.GradeCategoryId != nil && *value.GradeCategoryId == categoryId {
+			value.GradeCategoryId = nil
+		}
+		return ssg.ForEachOperationContinue
+	})
+
+	return nil
+}
+
+// GetCourseGradeCategory gets a grade category of a course from the database.
+func GetCourseGradeCategory(categoryId int) (*CourseGradeCategory, error) {
+	info := &CourseGradeCategory{}
+	err := DefaultContainer.db.QueryRow(context.Background(),
+		`SELECT category_id,
+			course_id,
+			category_name,
+			weight,
+			drop_lowest,
+			added_by,
+			created_at
+		FROM course_grade_category WHERE category_id = $1`,
+		categoryId,
+	).Scan(
+		&info.CategoryId,
+		&info.CourseId,
+		&info.CategoryName,
+		&info.Weight,
+		&info.DropLowest,
+		&info.AddedBy,
+		&info.CreatedAt,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, ErrGradeCategoryNotFound
+		}
+
+		return nil, err
+	}
+
+	return info, nil
+}
+
+// GetCourseGradeCategories gets all of the grade categories of a course.
+func GetCourseGradeCategories(courseId int) ([]*CourseGradeCategory, error) {
+	rows, err := DefaultContainer.db.Query(context.Background(),
+		`SELECT category_id,
+			course_id,
+			category_name,
+			weight,
+			drop_lowest,
+			added_by,
+			created_at
+		FROM course_grade_category
+		WHERE course_id = $1
+		ORDER BY category_id`,
+		courseId,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var categories []*CourseGradeCategory
+	for rows.Next() {
+		info := &CourseGradeCategory{}
+		err = rows.Scan(
+			&info.CategoryId,
+			&info.CourseId,
+			&info.CategoryName,
+			&info.Weight,
+			&info.DropLowest,
+			&info.AddedBy,
+			&info.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		categories = append(categories, info)
+	}
+
+	return categories, nil
+}
+
+// SetExamGradeCategory sets the grade category an exam is counted in.
+// It uses the sp set_exam_grade_category.
+func SetExamGradeCategory(data *SetExamGradeCategoryData) (*ExamInfo, error) {
+	info, err := GetExamInfo(data.ExamId)
+	if err != nil {
+		return nil, err
+	} else if info == nil {
+		return nil, ErrExamNotFound
+	}
+
+	_, err = DefaultContainer.db.Exec(context.Background(),
+		`CALL set_exam_grade_category(
+			p_exam_id := $1,
+			p_category_id := $2
+		)`,
+		data.ExamId,
+		data.CategoryId,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	info.GradeCategoryId = ssg.Clone(data.CategoryId)
+	return info, nil
+}
+
+// GetCourseGradedExams gets the graded (i.e. non-practice) exams of a
+// course, the earliest ones first.
+func GetCourseGradedExams(courseId int) ([]*ExamInfo, error) {
+	rows, err := DefaultContainer.db.Query(context.Background(),
+		`SELECT exam_id
+		FROM exam_info
+		WHERE course_id = $1 AND is_practice IS NOT TRUE
+		ORDER BY exam_date ASC, exam_id ASC`,
+		courseId,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var examIds []int
+	for rows.Next() {
+		var examId int
+		if err = rows.Scan(&examId); err != nil {
+			rows.Close()
+			return nil, err
+		}
+
+		examIds = append(examIds, examId)
+	}
+	rows.Close()
+
+	exams := make([]*ExamInfo, 0, len(examIds))
+	for _, examId := range examIds {
+		info, err := GetExamInfo(examId)
+		if err != nil {
+			return nil, err
+		}
+
+		exams = append(exams, info)
+	}
+
+	return exams, nil
+}
+
+// GetCourseGradebookScores gets the scores of the participants in the
+// graded exams of a course, using the course_gradebook_scores view.
+// If userId is not empty, only the scores of that user are returned.
+func GetCourseGradebookScores(courseId int, userId string) ([]*CourseGradebookScore, error) {
+	rows, err := DefaultContainer.db.Query(context.Background(),
+		`SELECT user_id,
+			exam_id,
+			final_score,
+			score_percentage
+		FROM course_gradebook_scores
+		WHERE course_id = $1 AND ($2 = '' OR user_id = $2)
+		ORDER BY user_id, exam_id`,
+		courseId,
+		userId,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var scores []*CourseGradebookScore
+	for rows.Next() {
+		info := &CourseGradebookScore{}
+		err = rows.Scan(
+			&info.UserId,
+			&info.ExamId,
+			&info.FinalScore,
+			&info.ScorePercentage,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		scores = append(scores, info)
+	}
+
+	return scores, nil
+}
+
+// GetScorePercentage converts a final score to a percentage, using the
+// plpgsql function get_score_percentage; nil is returned if the score
+// cannot be converted.
+func GetScorePercentage(finalScore string) (*float64, error) {
+	var percentage *float64
+	err := DefaultContainer.db.QueryRow(context.Background(),
+		`SELECT get_score_percentage($1)`,
+		finalScore,
+	).Scan(&percentage)
+	if err != nil {
+		return nil, err
+	}
+
+	return percentage, nil
+}
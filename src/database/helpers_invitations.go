@@ -0,0 +1,204 @@
+package database
+
+import (
+	"ExamSphere/src/core/appConfig"
+	"ExamSphere/src/core/utils/hashing"
+	"context"
+
+	"github.com/ALiwoto/ssg/ssg"
+	"github.com/jackc/pgx/v5"
+)
+
+// CreateExamInvitation creates a new invitation to the exam; the previous
+// pending invitations of the same email to the exam get expired.
+// It uses the plpgsql function create_exam_invitation.
+func CreateExamInvitation(data *NewExamInvitationData) (*ExamInvitation, error) {
+	var invitationId int
+	err := DefaultContainer.db.QueryRow(context.Background(),
+		`SELECT create_exam_invitation(
+			p_exam_id := $1,
+			p_email := $2,
+			p_user_id := $3,
+			p_expires_at := $4,
+			p_invited_by := $5
+		)`,
+		data.ExamId,
+		data.Email,
+		data.UserId,
+		data.ExpiresAt,
+		data.InvitedBy,
+	).Scan(&invitationId)
+	if err != nil {
+		return nil, err
+	}
+
+	return GetExamInvitation(invitationId)
+}
+
+// GetExamInvitation returns the invitation with the specified id.
+func GetExamInvitation(invitationId int) (*ExamInvitation, error) {
+	info := &ExamInvitation{}
+	err := DefaultContainer.db.QueryRow(context.Background(),
+		`SELECT invitation_id,
+			exam_id,
+			email,
+			user_id,
+			status,
+			expires_at,
+			invited_by,
+			created_at,
+			accepted_at
+		FROM exam_invitation WHERE invitation_id = $1`,
+		invitationId,
+	).Scan(
+		&info.InvitationId,
+		&info.ExamId,
+		&info.Email,
+		&info.UserId,
+		&info.Status,
+		&info.ExpiresAt,
+		&info.InvitedBy,
+		&info.CreatedAt,
+		&info.AcceptedAt,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, ErrExamInvitationNotFound
+		}
+		return nil, err
+	}
+
+	return info, nil
+}
+
+// GetExamInvitations returns the invitations of the exam, most recent
+// ones first; the invitations past their expiry are marked as expired.
+func GetExamInvitations(examId int) ([]*ExamInvitation, error) {
+	_, err := DefaultContainer.db.Exec(context.Background(),
+		`CALL expire_exam_invitations()`,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := DefaultContainer.db.Query(context.Background(),
+		`SELECT invitation_id,
+			exam_id,
+			email,
+			user_id,
+			status,
+			expires_at,
+			invited_by,
+			created_at,
+			accepted_at
+		FROM exam_invitation WHERE exam_id = $1
+		ORDER BY created_at DESC, invitation_id DESC`,
+		examId,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var invitations []*ExamInvitation
+	for rows.Next() {
+		info := &ExamInvitation{}
+		err = rows.Scan(
+			&info.InvitationId,
+			&info.ExamId,
+			&info.Email,
+			&info.UserId,
+			&info.Status,
+			&info.ExpiresAt,
+			&info.InvitedBy,
+			&info.CreatedAt,
+			&info.AcceptedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		invitations = append(invitations, info)
+	}
+
+	return invitations, nil
+}
+
+// AcceptExamInvitation marks the invitation as accepted by the user.
+// It uses the sp accept_exam_invitation.
+func AcceptExamInvitation(invitationId int, userId string) error {
+	_, err := DefaultContainer.db.Exec(context.Background(),
+		`CALL accept_exam_invitation($1, $2)`,
+		invitationId,
+		userId,
+	)
+	return err
+}
+
+// LinkExamInvitationToUser links the invitation to the account of the user
+// it has been sent to, without accepting it.
+func LinkExamInvitationToUser(invitationId int, userId string) error {
+	_, err := DefaultContainer.db.Exec(context.Background(),
+		`UPDATE exam_invitation SET user_id = $2
+		WHERE invitation_id = $1 AND user_id IS NULL`,
+		invitationId,
+		userId,
+	)
+	return err
+}
+
+// JoinExamByInvitation adds the invitee to the exam of the invitation (or
+// to its waitlist, if the exam is full) and marks the invitation as
+// accepted by them. If the invitee has already joined the exam some other
+// way, the invitation is only accepted. Like anyone added to the exam, the
+// invitee has to meet its prerequisites (ErrExamPrerequisitesNotMet
+// otherwise). The returned waitlist entry is nil unless the invitee has
+// been put on the waitlist.
+func JoinExamByInvitation(
+	invitation *ExamInvitation,
+	examInfo *ExamInfo,
+	userId string,
+) (*GivenExam, *ExamWaitlistEntry, error) {
+	var waitlistEntry *ExamWaitlistEntry
+	givenExam, err := GetGivenExam(userId, examInfo.ExamId)
+	if err == ErrGivenExamNotFound {
+		unmetPrerequisites, err := GetUnmetExamPrerequisites(userId, examInfo.ExamId)
+		if err != nil {
+			return nil, nil, err
+		} else if len(unmetPrerequisites) > 0 {
+			return nil, nil, ErrExamPrerequisitesNotMet
+		}
+
+		givenExam, err = AddUserInExam(&NewGivenExamData{
+			UserId:  userId,
+			ExamId:  examInfo.ExamId,
+			Price:   examInfo.Price,
+			AddedBy: ssg.Clone(&invitation.InvitedBy),
+		})
+		if err == ErrExamFull {
+			waitlistEntry, err = AddUserToExamWaitlist(&NewExamWaitlistEntryData{
+				ExamId:  examInfo.ExamId,
+				UserId:  userId,
+				AddedBy: ssg.Clone(&invitation.InvitedBy),
+			})
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+	} else if err != nil {
+		return nil, nil, err
+	}
+
+	err = AcceptExamInvitation(invitation.InvitationId, userId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return givenExam, waitlistEntry, nil
+}
+
+// getJoinTokenKey returns the key the join tokens of the invitations are
+// signed with.
+func getJoinTokenKey() []byte {
+	return hashing.DeriveKey(appConfig.AccessTokenSigningKey, joinTokenKeyPurpose)
+}
@@ -0,0 +1,205 @@
+package database
+
+import (
+	"ExamSphere/src/core/utils/logging"
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// AddExamPrerequisite adds a new prerequisite to an exam.
+// It uses the plpgsql function add_exam_prerequisite.
+func AddExamPrerequisite(data *NewExamPrerequisiteData) (*ExamPrerequisite, error) {
+	info := &ExamPrerequisite{
+		ExamId:           data.ExamId,
+		PrerequisiteType: data.PrerequisiteType,
+		RequiredExamId:   data.RequiredExamId,
+		MinScore:         data.MinScore,
+		TopicId:          data.TopicId,
+		MinLevel:         data.MinLevel,
+		AddedBy:          data.AddedBy,
+		CreatedAt:        time.Now(),
+	}
+
+	err := DefaultContainer.db.QueryRow(context.Background(),
+		`SELECT add_exam_prerequisite(
+			p_exam_id := $1,
+			p_prerequisite_type := $2,
+			p_required_exam_id := $3,
+			p_min_score := $4,
+			p_topic_id := $5,
+			p_min_level := $6,
+			p_added_by := $7
+		)`,
+		info.ExamId,
+		info.PrerequisiteType,
+		info.RequiredExamId,
+		info.MinScore,
+		info.TopicId,
+		info.MinLevel,
+		info.AddedBy,
+	).Scan(&info.PrerequisiteId)
+	if err != nil {
+		return nil, err
+	}
+
+	return info, nil
+}
+
+// GetExamPrerequisites returns all of the prerequisites of an exam.
+func GetExamPrerequisites(examId int) ([]*ExamPrerequisite, error) {
+	rows, err := DefaultContainer.db.Query(context.Background(),
+		`SELECT prerequisite_id,
+			exam_id,
+			prerequisite_type,
+			required_exam_id,
+			min_score,
+			topic_id,
+			min_level,
+			added_by,
+			created_at
+		FROM exam_prerequisite WHERE exam_id = $1
+		ORDER BY prerequisite_id`,
+		examId,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var prerequisites []*ExamPrerequisite
+	for rows.Next() {
+		info := &ExamPrerequisite{}
+		err = rows.Scan(
+			&info.PrerequisiteId,
+			&info.ExamId,
+			&info.PrerequisiteType,
+			&info.RequiredExamId,
+			&info.MinScore,
+			&info.TopicId,
+			&info.MinLevel,
+			&info.AddedBy,
+			&info.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		prerequisites = append(prerequisites, info)
+	}
+
+	return prerequisites, nil
+}
+
+// GetExamPrerequisite returns the specified prerequisite of an exam.
+func GetExamPrerequisite(examId, prerequisiteId int) (*ExamPrerequisite, error) {
+	info := &ExamPrerequisite{}
+	err := DefaultContainer.db.QueryRow(context.Background(),
+		`SELECT prerequisite_id,
+			exam_id,
+			prerequisite_type,
+			required_exam_id,
+			min_score,
+			topic_id,
+			min_level,
+			added_by,
+			created_at
+		FROM exam_prerequisite WHERE exam_id = $1 AND prerequisite_id = $2`,
+		examId,
+		prerequisiteId,
+	).Scan(
+		&info.PrerequisiteId,
+		&info.ExamId,
+		&info.PrerequisiteType,
+		&info.RequiredExamId,
+		&info.MinScore,
+		&info.TopicId,
+		&info.MinLevel,
+		&info.AddedBy,
+		&info.CreatedAt,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, ErrExamPrerequisiteNotFound
+		}
+		return nil, err
+	}
+
+	return info, nil
+}
+
+// RemoveExamPrerequisite removes the specified prerequisite from an exam.
+// It uses the sp remove_exam_prerequisite.
+func RemoveExamPrerequisite(examId, prerequisiteId int) error {
+	_, err := GetExamPrerequisite(examId, prerequisiteId)
+	if err != nil {
+		return err
+	}
+
+	_, err = DefaultContainer.db.Exec(context.Background(),
+		`CALL remove_exam_prerequisite(
+			p_exam_id := $1,
+			p_prerequisite_id := $2
+		)`,
+		examId,
+		prerequisiteId,
+	)
+	return err
+}
+
+// GetUnmetExamPrerequisites returns the prerequisites of an exam that the
+// user has not met yet.
+// It uses the plpgsql function get_unmet_exam_prerequisites.
+func GetUnmetExamPrerequisites(userId string, examId int) ([]*UnmetExamPrerequisite, error) {
+	rows, err := DefaultContainer.db.Query(context.Background(),
+		`SELECT prerequisite_id,
+			prerequisite_type,
+			required_exam_id,
+			min_score,
+			topic_id,
+			min_level,
+			current_value
+		FROM get_unmet_exam_prerequisites($1, $2)`,
+		examId,
+		userId,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var prerequisites []*UnmetExamPrerequisite
+	for rows.Next() {
+		info := &UnmetExamPrerequisite{}
+		err = rows.Scan(
+			&info.PrerequisiteId,
+			&info.PrerequisiteType,
+			&info.RequiredExamId,
+			&info.MinScore,
+			&info.TopicId,
+			&info.MinLevel,
+			&info.CurrentValue,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		prerequisites = append(prerequisites, info)
+	}
+
+	return prerequisites, nil
+}
+
+// GetUnmetExamPrerequisitesOrNil returns the prerequisites of an exam that
+// the user has not met yet.
+// It will return nil if there is an error.
+func GetUnmetExamPrerequisitesOrNil(userId string, examId int) []*UnmetExamPrerequisite {
+	prerequisites, err := GetUnmetExamPrerequisites(userId, examId)
+	if err != nil {
+		logging.UnexpectedError("GetUnmetExamPrerequisitesOrNil: failed to get unmet prerequisites:", err)
+		return nil
+	}
+
+	return prerequisites
+}
@@ -0,0 +1,224 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/ALiwoto/ssg/ssg"
+	"github.com/jackc/pgx/v5"
+)
+
+// StartExamPreviewAttempt starts a new preview attempt of the user in the
+// exam.
+func StartExamPreviewAttempt(examId int, userId string) (*ExamPreviewAttempt, error) {
+	info := &ExamPreviewAttempt{
+		ExamId: examId,
+		UserId: userId,
+	}
+
+	err := DefaultContainer.db.QueryRow(context.Background(),
+		`INSERT INTO exam_preview_attempt (exam_id, user_id)
+		VALUES ($1, $2)
+		RETURNING preview_id, started_at`,
+		info.ExamId,
+		info.UserId,
+	).Scan(
+		&info.PreviewId,
+		&info.StartedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return info, nil
+}
+
+// GetExamPreviewAttempt gets a preview attempt from the database.
+func GetExamPreviewAttempt(previewId int) (*ExamPreviewAttempt, error) {
+	info := &ExamPreviewAttempt{}
+	err := DefaultContainer.db.QueryRow(context.Background(),
+		`SELECT preview_id,
+			exam_id,
+			user_id,
+			started_at,
+			finished_at
+		FROM exam_preview_attempt WHERE preview_id = $1`,
+		previewId,
+	).Scan(
+		&info.PreviewId,
+		&info.ExamId,
+		&info.UserId,
+		&info.StartedAt,
+		&info.FinishedAt,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, ErrPreviewAttemptNotFound
+		}
+
+		return nil, err
+	}
+
+	return info, nil
+}
+
+// GetExamPreviewAttempts gets the preview attempts of the user in the exam,
+// the latest ones first.
+func GetExamPreviewAttempts(examId int, userId string) ([]*ExamPreviewAttempt, error) {
+	rows, err := DefaultContainer.db.Query(context.Background(),
+		`SELECT preview_id,
+			exam_id,
+			user_id,
+			started_at,
+			finished_at
+		FROM exam_preview_attempt
+		WHERE exam_id = $1 AND user_id = $2
+		ORDER BY started_at DESC, preview_id DESC`,
+		examId,
+		userId,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var previews []*ExamPreviewAttempt
+	for rows.Next() {
+		info := &ExamPreviewAttempt{}
+		err = rows.Scan(
+			&info.PreviewId,
+			&info.ExamId,
+			&info.UserId,
+			&info.StartedAt,
+			&info.FinishedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		previews = append(previews, info)
+	}
+
+	return previews, nil
+}
+
+// GetOngoingPreviewAttemptsCount returns the number of the unfinished
+// preview attempts of the user in the exam.
+func GetOngoingPreviewAttemptsCount(examId int, userId string) (int, error) {
+	var count int
+	err := DefaultContainer.db.QueryRow(context.Background(),
+		`SELECT COUNT(*) FROM exam_preview_attempt
+		WHERE exam_id = $1 AND user_id = $2 AND finished_at IS NULL`,
+		examId,
+		userId,
+	).Scan(&count)
+	return count, err
+}
+
+// AnswerPreviewQuestion answers a question in a preview attempt, replacing
+// its previous answer (if any).
+// It uses the sp give_preview_answer.
+func AnswerPreviewQuestion(data *PreviewAnswerData) (*ExamPreviewAnswer, error) {
+	_, err := DefaultContainer.db.Exec(context.Background(),
+		`CALL give_preview_answer(
+			p_preview_id := $1,
+			p_question_id := $2,
+			p_chosen_option := $3,
+			p_answer_text := $4,
+			p_seconds_taken := $5
+		)`,
+		data.PreviewId,
+		data.QuestionId,
+		data.ChosenOption,
+		data.AnswerText,
+		data.SecondsTaken,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ExamPreviewAnswer{
+		PreviewId:    data.PreviewId,
+		QuestionId:   data.QuestionId,
+		ChosenOption: ssg.Clone(data.ChosenOption),
+		AnswerText:   ssg.Clone(data.AnswerText),
+		SecondsTaken: data.SecondsTaken,
+		AnsweredAt:   time.Now(),
+	}, nil
+}
+
+// GetExamPreviewAnswers gets the answers given in a preview attempt.
+func GetExamPreviewAnswers(previewId int) ([]*ExamPreviewAnswer, error) {
+	rows, err := DefaultContainer.db.Query(context.Background(),
+		`SELECT preview_id,
+			question_id,
+			chosen_option,
+			answer_text,
+			seconds_taken,
+			answered_at
+		FROM exam_preview_answer
+		WHERE preview_id = $1`,
+		previewId,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var answers []*ExamPreviewAnswer
+	for rows.Next() {
+		info := &ExamPreviewAnswer{}
+		err = rows.Scan(
+			&info.PreviewId,
+			&info.QuestionId,
+			&info.ChosenOption,
+			&info.AnswerText,
+			&info.SecondsTaken,
+			&info.AnsweredAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		answers = append(answers, info)
+	}
+
+	return answers, nil
+}
+
+// FinishExamPreviewAttempt marks the preview attempt as finished; it does
+// nothing if the preview has already been finished.
+func FinishExamPreviewAttempt(preview *ExamPreviewAttempt) error {
+	if preview.IsFinished() {
+		return nil
+	}
+
+	err := DefaultContainer.db.QueryRow(context.Background(),
+		`UPDATE exam_preview_attempt
+		SET finished_at = COALESCE(finished_at, CURRENT_TIMESTAMP)
+		WHERE preview_id = $1
+		RETURNING finished_at`,
+		preview.PreviewId,
+	).Scan(&preview.FinishedAt)
+	if err == pgx.ErrNoRows {
+		return ErrPreviewAttemptNotFound
+	}
+
+	return err
+}
+
+// DiscardExamPreviewAttempt removes a preview attempt, alongside all of
+// the answers given in it.
+func DiscardExamPreviewAttempt(previewId int) error {
+	result, err := DefaultContainer.db.Exec(context.Background(),
+		`DELETE FROM exam_preview_attempt WHERE preview_id = $1`,
+		previewId,
+	)
+	if err != nil {
+		return err
+	} else if result.RowsAffected() == 0 {
+		return ErrPreviewAttemptNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,137 @@
+package database
+
+import (
+	"context"
+
+	"github.com/ALiwoto/ssg/ssg"
+)
+
+// SetExamQuestionMetadata sets the tags, the difficulty level, the
+// learning objectives and the topic of an exam question.
+// It uses the sp set_exam_question_metadata.
+func SetExamQuestionMetadata(data *SetExamQuestionMetadataData) (*ExamQuestion, error) {
+	info, err := GetExamQuestion(data.ExamId, data.QuestionId)
+	if err != nil {
+		return nil, err
+	} else if info == nil {
+		return nil, ErrExamQuestionNotFound
+	}
+
+	if data.Tags == nil {
+		data.Tags = []string{}
+	}
+	if data.LearningObjectives == nil {
+		data.LearningObjectives = []string{}
+	}
+
+	_, err = DefaultContainer.db.Exec(context.Background(),
+		`CALL set_exam_question_metadata(
+			p_question_id := $1,
+			p_tags := $2,
+			p_difficulty_level := $3,
+			p_learning_objectives := $4,
+			p_topic_id := $5
+		)`,
+		data.QuestionId,
+		data.Tags,
+		data.DifficultyLevel,
+		data.LearningObjectives,
+		data.TopicId,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	info.Tags = data.Tags
+	info.DifficultyLevel = ssg.Clone(data.DifficultyLevel)
+	info.LearningObjectives = data.LearningObjectives
+	info.TopicId = ssg.Clone(data.TopicId)
+	return info, nil
+}
+
+// SearchExamQuestions searches for questions across the exams, the ones
+// matching the search query the best first.
+func SearchExamQuestions(data *SearchExamQuestionsData) ([]*SearchedExamQuestion, error) {
+	if data.Tags == nil {
+		data.Tags = []string{}
+	}
+
+	rows, err := DefaultContainer.db.Query(context.Background(),
+		`SELECT q.question_id, 
+			q.exam_id, 
+			e.exam_title, 
+			q.question_title, 
+			q.description, 
+			q.content_format, 
+			q.tags, 
+			q.difficulty_level, 
+			q.learning_objectives, 
+			q.topic_id, 
+			q.created_at
+		FROM exam_question q
+		JOIN exam_info e ON e.exam_id = q.exam_id
+		WHERE ($1 = '' OR q.search_vector @@ plainto_tsquery('simple', $1))
+			AND q.tags @> $2::VARCHAR(32)[]
+			AND ($3::VARCHAR IS NULL OR q.difficulty_level = $3)
+			AND ($4::INTEGER IS NULL OR q.topic_id = $4)
+			AND ($5 = '' OR EXISTS (
+				SELECT 1 FROM unnest(q.learning_objectives) AS objective
+				WHERE objective ILIKE '%' || $5 || '%'
+			))
+			AND ($6::VARCHAR IS NULL OR e.created_by = $6 OR EXISTS (
+				SELECT 1 FROM exam_collaborator c
+				WHERE c.exam_id = e.exam_id AND c.user_id = $6
+			))
+		ORDER BY CASE WHEN $1 = '' THEN 0
+				ELSE ts_rank(q.search_vector, plainto_tsquery('simple', $1)) END DESC,
+			q.created_at DESC, q.question_id DESC
+		LIMIT $7 OFFSET $8`,
+		data.SearchQuery,
+		data.Tags,
+		data.DifficultyLevel,
+		data.TopicId,
+		data.LearningObjective,
+		data.PeekableBy,
+		data.Limit,
+		data.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var questions []*SearchedExamQuestion
+	for rows.Next() {
+		info := &SearchedExamQuestion{}
+		err = rows.Scan(
+			&info.QuestionId,
+			&info.ExamId,
+			&info.ExamTitle,
+			&info.QuestionTitle,
+			&info.Description,
+			&info.ContentFormat,
+			&info.Tags,
+			&info.DifficultyLevel,
+			&info.LearningObjectives,
+			&info.TopicId,
+			&info.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		questions = append(questions, info)
+	}
+
+	return questions, nil
+}
+
+// IsQuestionDifficultyValid returns true if the difficulty level is one of
+// the QuestionDifficulty* constants.
+func IsQuestionDifficultyValid(level string) bool {
+	switch level {
+	case QuestionDifficultyEasy, QuestionDifficultyMedium, QuestionDifficultyHard:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,233 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/ALiwoto/ssg/ssg"
+	"github.com/jackc/pgx/v5"
+)
+
+// ChangeExamReviewStatus moves the exam from the expected review status to
+// the new one, returning ErrExamReviewStatusChanged if the exam is not in
+// the expected status (anymore).
+// It uses the plpgsql function change_exam_review_status.
+func ChangeExamReviewStatus(data *ChangeExamReviewStatusData) (*ExamInfo, error) {
+	info, err := GetExamInfo(data.ExamId)
+	if err != nil {
+		return nil, err
+	} else if info == nil {
+		return nil, ErrExamNotFound
+	}
+
+	var changed bool
+	err = DefaultContainer.db.QueryRow(context.Background(),
+		`SELECT change_exam_review_status(
+			p_exam_id := $1,
+			p_expected_status := $2,
+			p_new_status := $3,
+			p_changed_by := $4
+		)`,
+		data.ExamId,
+		data.ExpectedStatus,
+		data.NewStatus,
+		data.ChangedBy,
+	).Scan(&changed)
+	if err != nil {
+		return nil, err
+	} else if !changed {
+		// the cached status is outdated
+		examsInfoMap.Delete(data.ExamId)
+		return nil, ErrExamReviewStatusChanged
+	}
+
+	now := time.Now()
+	info.ReviewStatus = data.NewStatus
+	if data.NewStatus == ExamReviewStatusInReview {
+		info.SubmittedAt = &now
+	}
+	if data.ExpectedStatus == ExamReviewStatusInReview {
+		info.ReviewedBy = ssg.Clone(&data.ChangedBy)
+		info.ReviewedAt = &now
+	}
+
+	return info, nil
+}
+
+// GetExamsInReview gets the exams which wait for a reviewer, the ones
+// submitted the earliest first.
+func GetExamsInReview(offset, limit int) ([]*ExamInfo, error) {
+	rows, err := DefaultContainer.db.Query(context.Background(),
+		`SELECT exam_id
+		FROM exam_info
+		WHERE review_status = $1
+		ORDER BY submitted_at ASC NULLS FIRST, exam_id ASC
+		LIMIT $2 OFFSET $3`,
+		ExamReviewStatusInReview,
+		limit,
+		offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var examIds []int
+	for rows.Next() {
+		var examId int
+		if err = rows.Scan(&examId); err != nil {
+			rows.Close()
+			return nil, err
+		}
+
+		examIds = append(examIds, examId)
+	}
+	rows.Close()
+
+	exams := make([]*ExamInfo, 0, len(examIds))
+	for _, examId := range examIds {
+		info, err := GetExamInfo(examId)
+		if err != nil {
+			return nil, err
+		}
+
+		exams = append(exams, info)
+	}
+
+	return exams, nil
+}
+
+// AddExamReviewComment adds a review comment to an exam (or to one of its
+// questions).
+func AddExamReviewComment(data *NewExamReviewCommentData) (*ExamReviewComment, error) {
+	info := &ExamReviewComment{
+		ExamId:      data.ExamId,
+		QuestionId:  ssg.Clone(data.QuestionId),
+		AuthorId:    data.AuthorId,
+		CommentText: data.CommentText,
+	}
+
+	err := DefaultContainer.db.QueryRow(context.Background(),
+		`INSERT INTO exam_review_comment (exam_id, question_id, author_id, comment_text)
+		VALUES ($1, $2, $3, $4)
+		RETURNING comment_id, created_at`,
+		info.ExamId,
+		info.QuestionId,
+		info.AuthorId,
+		info.CommentText,
+	).Scan(
+		&info.CommentId,
+		&info.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return info, nil
+}
+
+// GetExamReviewComment gets a review comment from the database.
+func GetExamReviewComment(commentId int) (*ExamReviewComment, error) {
+	info := &ExamReviewComment{}
+	err := DefaultContainer.db.QueryRow(context.Background(),
+		`SELECT comment_id,
+			exam_id,
+			question_id,
+			author_id,
+			comment_text,
+			is_resolved,
+			resolved_by,
+			resolved_at,
+			created_at
+		FROM exam_review_comment WHERE comment_id = $1`,
+		commentId,
+	).Scan(
+		&info.CommentId,
+		&info.ExamId,
+		&info.QuestionId,
+		&info.AuthorId,
+		&info.CommentText,
+		&info.IsResolved,
+		&info.ResolvedBy,
+		&info.ResolvedAt,
+		&info.CreatedAt,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, ErrReviewCommentNotFound
+		}
+
+		return nil, err
+	}
+
+	return info, nil
+}
+
+// GetExamReviewComments gets the review comments of an exam, the oldest
+// ones first.
+func GetExamReviewComments(examId int) ([]*ExamReviewComment, error) {
+	rows, err := DefaultContainer.db.Query(context.Background(),
+		`SELECT comment_id,
+			exam_id,
+			question_id,
+			author_id,
+			comment_text,
+			is_resolved,
+			resolved_by,
+			resolved_at,
+			created_at
+		FROM exam_review_comment
+		WHERE exam_id = $1
+		ORDER BY created_at ASC, comment_id ASC`,
+		examId,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var comments []*ExamReviewComment
+	for rows.Next() {
+		info := &ExamReviewComment{}
+		err = rows.Scan(
+			&info.CommentId,
+			&info.ExamId,
+			&info.QuestionId,
+			&info.AuthorId,
+			&info.CommentText,
+			&info.IsResolved,
+			&info.ResolvedBy,
+			&info.ResolvedAt,
+			&info.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		comments = append(comments, info)
+	}
+
+	return comments, nil
+}
+
+// ResolveExamReviewComment marks a review comment as resolved.
+func ResolveExamReviewComment(comment *ExamReviewComment, resolvedBy string) error {
+	now := time.Now()
+	_, err := DefaultContainer.db.Exec(context.Background(),
+		`UPDATE exam_review_comment
+		SET is_resolved = TRUE,
+			resolved_by = $1,
+			resolved_at = $2
+		WHERE comment_id = $3`,
+		resolvedBy,
+		now,
+		comment.CommentId,
+	)
+	if err != nil {
+		return err
+	}
+
+	comment.IsResolved = true
+	comment.ResolvedBy = ssg.Clone(&resolvedBy)
+	comment.ResolvedAt = &now
+	return nil
+}
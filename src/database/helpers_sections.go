@@ -0,0 +1,339 @@
+package database
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/ALiwoto/ssg/ssg"
+	"github.com/jackc/pgx/v5"
+)
+
+// CreateExamSection creates a new section in the exam.
+// It uses the plpgsql function create_exam_section.
+func CreateExamSection(data *NewExamSectionData) (*ExamSection, error) {
+	info := &ExamSection{
+		ExamId:        data.ExamId,
+		SectionTitle:  strings.TrimSpace(data.SectionTitle),
+		SectionOrder:  data.SectionOrder,
+		Duration:      data.Duration,
+		IsForwardOnly: data.IsForwardOnly,
+		CreatedAt:     time.Now(),
+	}
+
+	err := DefaultContainer.db.QueryRow(context.Background(),
+		`SELECT create_exam_section(
+			p_exam_id := $1,
+			p_section_title := $2,
+			p_section_order := $3,
+			p_duration := $4,
+			p_is_forward_only := $5
+		)`,
+		info.ExamId,
+		info.SectionTitle,
+		info.SectionOrder,
+		info.Duration,
+		info.IsForwardOnly,
+	).Scan(&info.SectionId)
+	if err != nil {
+		return nil, err
+	}
+
+	return info, nil
+}
+
+// GetExamSection gets the section with the specified id.
+func GetExamSection(sectionId int) (*ExamSection, error) {
+	info := &ExamSection{}
+	err := DefaultContainer.db.QueryRow(context.Background(),
+		`SELECT section_id,
+			exam_id,
+			section_title,
+			section_order,
+			duration,
+			is_forward_only,
+			created_at
+		FROM exam_section WHERE section_id = $1`,
+		sectionId,
+	).Scan(
+		&info.SectionId,
+		&info.ExamId,
+		&info.SectionTitle,
+		&info.SectionOrder,
+		&info.Duration,
+		&info.IsForwardOnly,
+		&info.CreatedAt,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, ErrExamSectionNotFound
+		}
+
+		return nil, err
+	}
+
+	return info, nil
+}
+
+// GetExamSections gets the sections of the exam in order.
+func GetExamSections(examId int) ([]*ExamSection, error) {
+	rows, err := DefaultContainer.db.Query(context.Background(),
+		`SELECT section_id,
+			exam_id,
+			section_title,
+			section_order,
+			duration,
+			is_forward_only,
+			created_at
+		FROM exam_section WHERE exam_id = $1
+		ORDER BY section_order, section_id`,
+		examId,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sections []*ExamSection
+	for rows.Next() {
+		info := &ExamSection{}
+		err = rows.Scan(
+			&info.SectionId,
+			&info.ExamId,
+			&info.SectionTitle,
+			&info.SectionOrder,
+			&info.Duration,
+			&info.IsForwardOnly,
+			&info.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		sections = append(sections, info)
+	}
+
+	return sections, nil
+}
+
+// EditExamSection edits the title, the order, the time limit and the
+// navigation of a section.
+func EditExamSection(data *EditExamSectionData) (*ExamSection, error) {
+	result, err := DefaultContainer.db.Exec(context.Background(),
+		`UPDATE exam_section
+		SET section_title = $2,
+			section_order = $3,
+			duration = $4,
+			is_forward_only = $5
+		WHERE section_id = $1`,
+		data.SectionId,
+		strings.TrimSpace(data.SectionTitle),
+		data.SectionOrder,
+		data.Duration,
+		data.IsForwardOnly,
+	)
+	if err != nil {
+		return nil, err
+	} else if result.RowsAffected() == 0 {
+		return nil, ErrExamSectionNotFound
+	}
+
+	return GetExamSection(data.SectionId)
+}
+
+// DeleteExamSection deletes a section; its questions are kept in the exam
+// without a section.
+func DeleteExamSection(sectionId int) error {
+	result, err := DefaultContainer.db.Exec(context.Background(),
+		`DELETE FROM exam_section WHERE section_id = $1`,
+		sectionId,
+	)
+	if err != nil {
+		return err
+	} else if result.RowsAffected() == 0 {
+		return ErrExamSectionNotFound
+	}
+
+	// the cached questions might have been in this section
+	examQuestionsMap.ForEach(func(_ int, value *ExamQuestion) ssg.ForEachOperation {
+		if value.SectionId != nil && *value.SectionId == sectionId {
+			return ssg.ForEachOperationRemove
+		}
+		return ssg.ForEachOperationContinue
+	})
+
+	return nil
+}
+
+// GetExamAttemptSections gets the progress of the attempt in the sections
+// of the exam, mapped by the section ids.
+func GetExamAttemptSections(userId string, examId, attemptNumber int) (map[int]*ExamAttemptSection, error) {
+	rows, err := DefaultContainer.db.Query(context.Background(),
+		`SELECT exam_id,
+			user_id,
+			attempt_number,
+			section_id,
+			started_at,
+			finished_at
+		FROM exam_attempt_section
+		WHERE exam_id = $1 AND user_id = $2 AND attempt_number = $3`,
+		examId,
+		userId,
+		attemptNumber,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	progresses := make(map[int]*ExamAttemptSection)
+	for rows.Next() {
+		info := &ExamAttemptSection{}
+		err = rows.Scan(
+			&info.ExamId,
+			&info.UserId,
+			&info.AttemptNumber,
+			&info.SectionId,
+			&info.StartedAt,
+			&info.FinishedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		progresses[info.SectionId] = info
+	}
+
+	return progresses, nil
+}
+
+// StartExamAttemptSection marks the section as entered in the attempt.
+// It uses the sp start_exam_attempt_section.
+func StartExamAttemptSection(attempt *ExamAttempt, sectionId int) (*ExamAttemptSection, error) {
+	_, err := DefaultContainer.db.Exec(context.Background(),
+		`CALL start_exam_attempt_section(
+			p_exam_id := $1,
+			p_user_id := $2,
+			p_attempt_number := $3,
+			p_section_id := $4
+		)`,
+		attempt.ExamId,
+		attempt.UserId,
+		attempt.AttemptNumber,
+		sectionId,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	progresses, err := GetExamAttemptSections(attempt.UserId, attempt.ExamId, attempt.AttemptNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	return progresses[sectionId], nil
+}
+
+// FinishExamAttemptSection marks the section as left in the attempt.
+// It uses the sp finish_exam_attempt_section.
+func FinishExamAttemptSection(progress *ExamAttemptSection, finishedAt time.Time) error {
+	_, err := DefaultContainer.db.Exec(context.Background(),
+		`CALL finish_exam_attempt_section(
+			p_exam_id := $1,
+			p_user_id := $2,
+			p_attempt_number := $3,
+			p_section_id := $4,
+			p_finished_at := $5
+		)`,
+		progress.ExamId,
+		progress.UserId,
+		progress.AttemptNumber,
+		progress.SectionId,
+		finishedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	if progress.FinishedAt == nil {
+		progress.FinishedAt = &finishedAt
+	}
+	return nil
+}
+
+// GetExamSectionsProgress gets the sections of the exam alongside the state
+// of the attempt (which can be nil) in each of them. The sections are taken
+// in order: the ones whose time has run out get closed, and the next
+// section is entered (if the attempt is still ongoing).
+func GetExamSectionsProgress(
+	examInfo *ExamInfo,
+	accommodation *ExamAccommodation,
+	attempt *ExamAttempt,
+) ([]*ExamSectionProgress, error) {
+	sections, err := GetExamSections(examInfo.ExamId)
+	if err != nil || len(sections) == 0 {
+		return nil, err
+	}
+
+	progresses := make(map[int]*ExamAttemptSection)
+	if attempt != nil {
+		progresses, err = GetExamAttemptSections(attempt.UserId, attempt.ExamId, attempt.AttemptNumber)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	hasCurrent := false
+	allProgress := make([]*ExamSectionProgress, 0, len(sections))
+	for _, section := range sections {
+		current := &ExamSectionProgress{
+			Section:  section,
+			Progress: progresses[section.SectionId],
+			Status:   SectionStatusUpcoming,
+		}
+		allProgress = append(allProgress, current)
+
+		if current.Progress == nil {
+			if hasCurrent || attempt == nil || attempt.IsFinished() {
+				continue
+			}
+
+			// the user has reached this section just now
+			current.Progress, err = StartExamAttemptSection(attempt, section.SectionId)
+			if err != nil {
+				return nil, err
+			} else if current.Progress == nil {
+				return nil, ErrExamSectionNotFound
+			}
+		}
+
+		current.Deadline = section.GetDeadlineFor(accommodation, current.Progress.StartedAt)
+		if current.Deadline != nil {
+			examDeadline := examInfo.GetDeadlineFor(accommodation, attempt)
+			if current.Deadline.After(examDeadline) {
+				current.Deadline = &examDeadline
+			}
+		}
+
+		switch {
+		case current.Deadline != nil && now.After(*current.Deadline):
+			current.Status = SectionStatusExpired
+			if current.Progress.FinishedAt == nil {
+				err = FinishExamAttemptSection(current.Progress, *current.Deadline)
+				if err != nil {
+					return nil, err
+				}
+			}
+		case current.Progress.FinishedAt != nil && section.IsForwardOnly:
+			current.Status = SectionStatusLocked
+		case current.Progress.FinishedAt != nil:
+			current.Status = SectionStatusOpen
+		default:
+			current.Status = SectionStatusOngoing
+			hasCurrent = true
+		}
+	}
+
+	return allProgress, nil
+}
@@ -0,0 +1,372 @@
+package database
+
+import (
+	"ExamSphere/src/core/utils/logging"
+	"context"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// CreateExamSeries creates a new exam series.
+// It uses the plpgsql function create_exam_series.
+func CreateExamSeries(data *NewExamSeriesData) (*ExamSeries, error) {
+	if data.GenerateAhead == 0 {
+		data.GenerateAhead = DefaultSeriesGenerateAhead
+	}
+
+	info := &ExamSeries{
+		TemplateExamId: data.TemplateExamId,
+		SeriesTitle:    strings.TrimSpace(data.SeriesTitle),
+		RecurrenceRule: data.RecurrenceRule,
+		StartsAt:       data.StartsAt,
+		GenerateAhead:  data.GenerateAhead,
+		IsActive:       true,
+		CreatedBy:      data.CreatedBy,
+		CreatedAt:      time.Now(),
+	}
+
+	err := DefaultContainer.db.QueryRow(context.Background(),
+		`SELECT create_exam_series(
+			p_template_exam_id := $1,
+			p_series_title := $2,
+			p_recurrence_rule := $3,
+			p_starts_at := $4,
+			p_generate_ahead := $5,
+			p_created_by := $6
+		)`,
+		info.TemplateExamId,
+		info.SeriesTitle,
+		info.RecurrenceRule,
+		info.StartsAt,
+		info.GenerateAhead,
+		info.CreatedBy,
+	).Scan(&info.SeriesId)
+	if err != nil {
+		return nil, err
+	}
+
+	return info, nil
+}
+
+// GetExamSeries gets the exam series with the specified id.
+func GetExamSeries(seriesId int) (*ExamSeries, error) {
+	info := &ExamSeries{}
+	err := DefaultContainer.db.QueryRow(context.Background(),
+		`SELECT series_id,
+			template_exam_id,
+			series_title,
+			recurrence_rule,
+			starts_at,
+			generate_ahead,
+			is_active,
+			last_generated_at,
+			created_by,
+			created_at
+		FROM exam_series WHERE series_id = $1`,
+		seriesId,
+	).Scan(
+		&info.SeriesId,
+		&info.TemplateExamId,
+		&info.SeriesTitle,
+		&info.RecurrenceRule,
+		&info.StartsAt,
+		&info.GenerateAhead,
+		&info.IsActive,
+		&info.LastGeneratedAt,
+		&info.CreatedBy,
+		&info.CreatedAt,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, ErrExamSeriesNotFound
+		}
+
+		return nil, err
+	}
+
+	return info, nil
+}
+
+// GetActiveExamSeries gets all of the exam series that are active.
+func GetActiveExamSeries() ([]*ExamSeries, error) {
+	rows, err := DefaultContainer.db.Query(context.Background(),
+		`SELECT series_id,
+			template_exam_id,
+			series_title,
+			recurrence_rule,
+			starts_at,
+			generate_ahead,
+			is_active,
+			last_generated_at,
+			created_by,
+			created_at
+		FROM exam_series WHERE is_active = TRUE
+		ORDER BY series_id`,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var allSeries []*ExamSeries
+	for rows.Next() {
+		info := &ExamSeries{}
+		err = rows.Scan(
+			&info.SeriesId,
+			&info.TemplateExamId,
+			&info.SeriesTitle,
+			&info.RecurrenceRule,
+			&info.StartsAt,
+			&info.GenerateAhead,
+			&info.IsActive,
+			&info.LastGeneratedAt,
+			&info.CreatedBy,
+			&info.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		allSeries = append(allSeries, info)
+	}
+
+	return allSeries, nil
+}
+
+// EditExamSeries edits the title, the recurrence rule and the state of
+// an exam series.
+func EditExamSeries(data *EditExamSeriesData) (*ExamSeries, error) {
+	data.SeriesTitle = strings.TrimSpace(data.SeriesTitle)
+	result, err := DefaultContainer.db.Exec(context.Background(),
+		`UPDATE exam_series
+		SET series_title = $2,
+			recurrence_rule = $3,
+			generate_ahead = $4,
+			is_active = $5
+		WHERE series_id = $1`,
+		data.SeriesId,
+		data.SeriesTitle,
+		data.RecurrenceRule,
+		data.GenerateAhead,
+		data.IsActive,
+	)
+	if err != nil {
+		return nil, err
+	} else if result.RowsAffected() == 0 {
+		return nil, ErrExamSeriesNotFound
+	}
+
+	return GetExamSeries(data.SeriesId)
+}
+
+// GetExamSeriesOccurrences gets the occurrences of the series that have
+// been materialised, edited or skipped.
+func GetExamSeriesOccurrences(seriesId int) ([]*ExamSeriesOccurrence, error) {
+	rows, err := DefaultContainer.db.Query(context.Background(),
+		`SELECT series_id,
+			occurrence_at,
+			exam_id,
+			is_skipped,
+			exam_title,
+			exam_date,
+			duration,
+			updated_at
+		FROM exam_series_occurrence WHERE series_id = $1
+		ORDER BY occurrence_at`,
+		seriesId,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var occurrences []*ExamSeriesOccurrence
+	for rows.Next() {
+		info := &ExamSeriesOccurrence{}
+		err = rows.Scan(
+			&info.SeriesId,
+			&info.OccurrenceAt,
+			&info.ExamId,
+			&info.IsSkipped,
+			&info.ExamTitle,
+			&info.ExamDate,
+			&info.Duration,
+			&info.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		occurrences = append(occurrences, info)
+	}
+
+	return occurrences, nil
+}
+
+// GetExamSeriesOccurrence gets an occurrence of the series; it returns nil
+// (without any errors) if the occurrence has not been materialised, edited
+// or skipped yet.
+func GetExamSeriesOccurrence(seriesId int, occurrenceAt time.Time) (*ExamSeriesOccurrence, error) {
+	info := &ExamSeriesOccurrence{}
+	err := DefaultContainer.db.QueryRow(context.Background(),
+		`SELECT series_id,
+			occurrence_at,
+			exam_id,
+			is_skipped,
+			exam_title,
+			exam_date,
+			duration,
+			updated_at
+		FROM exam_series_occurrence WHERE series_id = $1 AND occurrence_at = $2`,
+		seriesId,
+		occurrenceAt,
+	).Scan(
+		&info.SeriesId,
+		&info.OccurrenceAt,
+		&info.ExamId,
+		&info.IsSkipped,
+		&info.ExamTitle,
+		&info.ExamDate,
+		&info.Duration,
+		&info.UpdatedAt,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return info, nil
+}
+
+// MaterialiseExamSeriesOccurrence creates the exam of an occurrence of the
+// series (if it's not created yet) and returns its id; 0 is returned if the
+// occurrence is skipped.
+// It uses the plpgsql function materialise_exam_series_occurrence.
+func MaterialiseExamSeriesOccurrence(seriesId int, occurrenceAt time.Time) (int, error) {
+	var examId *int
+	err := DefaultContainer.db.QueryRow(context.Background(),
+		`SELECT materialise_exam_series_occurrence($1, $2)`,
+		seriesId,
+		occurrenceAt,
+	).Scan(&examId)
+	if err != nil || examId == nil {
+		return 0, err
+	}
+
+	return *examId, nil
+}
+
+// EditExamSeriesOccurrence overrides an occurrence of the series; the exam
+// of the occurrence is updated as well if it's already materialised.
+// It uses the sp edit_exam_series_occurrence.
+func EditExamSeriesOccurrence(data *EditExamSeriesOccurrenceData) (*ExamSeriesOccurrence, error) {
+	_, err := DefaultContainer.db.Exec(context.Background(),
+		`CALL edit_exam_series_occurrence(
+			p_series_id := $1,
+			p_occurrence_at := $2,
+			p_exam_title := $3,
+			p_exam_date := $4,
+			p_duration := $5
+		)`,
+		data.SeriesId,
+		data.OccurrenceAt,
+		data.ExamTitle,
+		data.ExamDate,
+		data.Duration,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	occurrence, err := GetExamSeriesOccurrence(data.SeriesId, data.OccurrenceAt)
+	if err != nil {
+		return nil, err
+	} else if occurrence != nil && occurrence.ExamId != nil {
+		examsInfoMap.Delete(*occurrence.ExamId)
+	}
+
+	return occurrence, nil
+}
+
+// SkipExamSeriesOccurrence skips (or un-skips) an occurrence of the series.
+// If the occurrence is already materialised, its exam gets removed.
+// It uses the sp skip_exam_series_occurrence.
+func SkipExamSeriesOccurrence(seriesId int, occurrenceAt time.Time, skip bool) (*ExamSeriesOccurrence, error) {
+	previous, err := GetExamSeriesOccurrence(seriesId, occurrenceAt)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = DefaultContainer.db.Exec(context.Background(),
+		`CALL skip_exam_series_occurrence(
+			p_series_id := $1,
+			p_occurrence_at := $2,
+			p_is_skipped := $3
+		)`,
+		seriesId,
+		occurrenceAt,
+		skip,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if skip && previous != nil && previous.ExamId != nil {
+		examsInfoMap.Delete(*previous.ExamId)
+	}
+
+	return GetExamSeriesOccurrence(seriesId, occurrenceAt)
+}
+
+// HasExamParticipants returns true if at least one user has participated
+// in the exam.
+func HasExamParticipants(examId int) bool {
+	var exists bool
+	err := DefaultContainer.db.QueryRow(context.Background(),
+		`SELECT EXISTS (SELECT 1 FROM given_exam WHERE exam_id = $1)`,
+		examId,
+	).Scan(&exists)
+	if err != nil {
+		logging.UnexpectedError("HasExamParticipants: failed to check participants:", err)
+		return false
+	}
+
+	return exists
+}
+
+// GenerateExamSeriesOccurrences materialises the due occurrences of the
+// series, and returns the ids of the exams that have been created.
+func GenerateExamSeriesOccurrences(series *ExamSeries, now time.Time) ([]int, error) {
+	occurrences, err := series.GetDueOccurrences(now)
+	if err != nil || len(occurrences) == 0 {
+		return nil, err
+	}
+
+	var examIds []int
+	for _, occurrenceAt := range occurrences {
+		examId, err := MaterialiseExamSeriesOccurrence(series.SeriesId, occurrenceAt)
+		if err != nil {
+			return examIds, err
+		} else if examId != 0 {
+			examIds = append(examIds, examId)
+		}
+
+		_, err = DefaultContainer.db.Exec(context.Background(),
+			`UPDATE exam_series SET last_generated_at = $2 WHERE series_id = $1`,
+			series.SeriesId,
+			occurrenceAt,
+		)
+		if err != nil {
+			return examIds, err
+		}
+
+		series.LastGeneratedAt = &occurrenceAt
+	}
+
+	return examIds, nil
+}
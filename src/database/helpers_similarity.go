@@ -0,0 +1,279 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// StartSimilarityCheck starts a new similarity check of the answers of the
+// question; the comparison itself is done by the caller.
+// It uses the plpgsql function start_similarity_check.
+func StartSimilarityCheck(data *NewSimilarityCheckData) (*SimilarityCheck, error) {
+	info := &SimilarityCheck{
+		ExamId:      data.ExamId,
+		QuestionId:  data.QuestionId,
+		RequestedBy: &data.RequestedBy,
+		CheckStatus: SimilarityCheckStatusRunning,
+		ShingleSize: data.ShingleSize,
+		Threshold:   data.Threshold,
+		StartedAt:   time.Now(),
+	}
+
+	err := DefaultContainer.db.QueryRow(context.Background(),
+		`SELECT start_similarity_check(
+			p_exam_id := $1,
+			p_question_id := $2,
+			p_requested_by := $3,
+			p_shingle_size := $4,
+			p_threshold := $5
+		)`,
+		info.ExamId,
+		info.QuestionId,
+		info.RequestedBy,
+		info.ShingleSize,
+		info.Threshold,
+	).Scan(&info.CheckId)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == similarityCheckRunningErrCode {
+			return nil, ErrSimilarityCheckRunning
+		}
+		return nil, err
+	}
+
+	return info, nil
+}
+
+// CompleteSimilarityCheck stores the reported pairs of a running check and
+// marks it as completed.
+// It uses the plpgsql function complete_similarity_check.
+func CompleteSimilarityCheck(checkId, answersCount int, matches []*SimilarityMatch) error {
+	if matches == nil {
+		matches = []*SimilarityMatch{}
+	}
+
+	matchesValue, err := json.Marshal(matches)
+	if err != nil {
+		return err
+	}
+
+	_, err = DefaultContainer.db.Exec(context.Background(),
+		`SELECT complete_similarity_check(
+			p_check_id := $1,
+			p_answers_count := $2,
+			p_matches := $3
+		)`,
+		checkId,
+		answersCount,
+		string(matchesValue),
+	)
+	return err
+}
+
+// FailSimilarityCheck marks a running check as failed.
+// It uses the plpgsql function fail_similarity_check.
+func FailSimilarityCheck(checkId int, errorMessage string) error {
+	_, err := DefaultContainer.db.Exec(context.Background(),
+		`SELECT fail_similarity_check($1, $2)`,
+		checkId,
+		errorMessage,
+	)
+	return err
+}
+
+// GetSimilarityCheck gets the similarity check with the specified id.
+func GetSimilarityCheck(checkId int) (*SimilarityCheck, error) {
+	info := &SimilarityCheck{}
+	err := DefaultContainer.db.QueryRow(context.Background(),
+		`SELECT check_id,
+			exam_id,
+			question_id,
+			requested_by,
+			check_status,
+			shingle_size,
+			threshold,
+			answers_count,
+			matches_count,
+			error_message,
+			started_at,
+			finished_at
+		FROM similarity_check WHERE check_id = $1`,
+		checkId,
+	).Scan(
+		&info.CheckId,
+		&info.ExamId,
+		&info.QuestionId,
+		&info.RequestedBy,
+		&info.CheckStatus,
+		&info.ShingleSize,
+		&info.Threshold,
+		&info.AnswersCount,
+		&info.MatchesCount,
+		&info.ErrorMessage,
+		&info.StartedAt,
+		&info.FinishedAt,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, ErrSimilarityCheckNotFound
+		}
+
+		return nil, err
+	}
+
+	return info, nil
+}
+
+// GetSimilarityChecks gets the similarity checks of the exam (the latest
+// first); questionId limits them to the checks of a question if it's not 0.
+func GetSimilarityChecks(examId, questionId int) ([]*SimilarityCheck, error) {
+	rows, err := DefaultContainer.db.Query(context.Background(),
+		`SELECT check_id,
+			exam_id,
+			question_id,
+			requested_by,
+			check_status,
+			shingle_size,
+			threshold,
+			answers_count,
+			matches_count,
+			error_message,
+			started_at,
+			finished_at
+		FROM similarity_check
+		WHERE exam_id = $1 AND ($2 = 0 OR question_id = $2)
+		ORDER BY started_at DESC, check_id DESC`,
+		examId,
+		questionId,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var checks []*SimilarityCheck
+	for rows.Next() {
+		info := &SimilarityCheck{}
+		err = rows.Scan(
+			&info.CheckId,
+			&info.ExamId,
+			&info.QuestionId,
+			&info.RequestedBy,
+			&info.CheckStatus,
+			&info.ShingleSize,
+			&info.Threshold,
+			&info.AnswersCount,
+			&info.MatchesCount,
+			&info.ErrorMessage,
+			&info.StartedAt,
+			&info.FinishedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		checks = append(checks, info)
+	}
+
+	return checks, nil
+}
+
+// GetSimilarityMatches gets the pairs of answers reported by the check
+// (the most similar first).
+func GetSimilarityMatches(checkId int) ([]*SimilarityMatch, error) {
+	rows, err := DefaultContainer.db.Query(context.Background(),
+		`SELECT match_id,
+			check_id,
+			first_user_id,
+			first_attempt,
+			second_user_id,
+			second_attempt,
+			similarity,
+			containment,
+			passages
+		FROM similarity_match
+		WHERE check_id = $1
+		ORDER BY similarity DESC, match_id`,
+		checkId,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var matches []*SimilarityMatch
+	for rows.Next() {
+		info := &SimilarityMatch{}
+		err = rows.Scan(
+			&info.MatchId,
+			&info.CheckId,
+			&info.FirstUserId,
+			&info.FirstAttempt,
+			&info.SecondUserId,
+			&info.SecondAttempt,
+			&info.Similarity,
+			&info.Containment,
+			&info.Passages,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		matches = append(matches, info)
+	}
+
+	return matches, nil
+}
+
+// GetQuestionTextAnswers gets the (non-empty) text answers given to the
+// question, from the latest attempt of each user who has answered it.
+func GetQuestionTextAnswers(examId, questionId int) ([]*GivenAnswerInfo, error) {
+	rows, err := DefaultContainer.db.Query(context.Background(),
+		`SELECT DISTINCT ON (answered_by)
+			exam_id,
+			question_id,
+			answered_by,
+			attempt_number,
+			chosen_option,
+			seconds_taken,
+			answer_text,
+			answered_at
+		FROM given_answer
+		WHERE exam_id = $1 AND question_id = $2
+			AND answer_text IS NOT NULL AND btrim(answer_text) <> ''
+		ORDER BY answered_by, attempt_number DESC`,
+		examId,
+		questionId,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var answers []*GivenAnswerInfo
+	for rows.Next() {
+		info := &GivenAnswerInfo{}
+		err = rows.Scan(
+			&info.ExamId,
+			&info.QuestionId,
+			&info.AnsweredBy,
+			&info.AttemptNumber,
+			&info.ChosenOption,
+			&info.SecondsTaken,
+			&info.AnswerText,
+			&info.AnsweredAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		answers = append(answers, info)
+	}
+
+	return answers, nil
+}
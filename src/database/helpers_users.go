@@ -120,6 +120,24 @@ func GetUserByUserId(userId string) (*UserInfo, error) {
 	return info, nil
 }
 
+// GetUserByEmail returns the user owning the email address (emails are
+// case-insensitive); ErrUserNotFound is returned if there is no such user.
+func GetUserByEmail(email string) (*UserInfo, error) {
+	var userId string
+	err := DefaultContainer.db.QueryRow(context.Background(),
+		`SELECT user_id FROM user_info WHERE LOWER(email) = LOWER($1)`,
+		strings.TrimSpace(email),
+	).Scan(&userId)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	return GetUserByUserId(userId)
+}
+
 // GetUserFullNameOrEmpty returns the full name of a user or an empty string if the user is not found.
 func GetUserFullNameOrEmpty(userId string) string {
 	info, err := GetUserByUserId(userId)
@@ -157,7 +175,8 @@ func getUserFromDB(userId string) (*UserInfo, error) {
 			ban_reason, 
 			created_at,
 			user_address,
-			phone_number
+			phone_number,
+			is_exam_reviewer
 		FROM user_info WHERE user_id = $1`,
 		userId,
 	).Scan(
@@ -172,6 +191,7 @@ func getUserFromDB(userId string) (*UserInfo, error) {
 		&info.CreatedAt,
 		&info.UserAddress,
 		&info.PhoneNumber,
+		&info.IsExamReviewer,
 	)
 	if err != nil {
 		if err == pgx.ErrNoRows {
@@ -361,6 +381,34 @@ func BanUser(data *BanUserData) (*UserInfo, error) {
 	return info, nil
 }
 
+// SetExamReviewer sets whether the user can review the exams of the
+// other teachers.
+func SetExamReviewer(data *SetExamReviewerData) (*UserInfo, error) {
+	data.UserId = appValues.NormalizeUserId(data.UserId)
+	if data.UserId == "" {
+		return nil, ErrUserNotFound
+	}
+
+	info, err := GetUserByUserId(data.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = DefaultContainer.db.Exec(context.Background(),
+		`UPDATE user_info
+		SET is_exam_reviewer = $1
+		WHERE user_id = $2`,
+		data.IsExamReviewer,
+		info.UserId,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	info.IsExamReviewer = data.IsExamReviewer
+	return info, nil
+}
+
 // UpdateUserPassword updates the user's password.
 func UpdateUserPassword(data *UpdateUserPasswordData) error {
 	data.UserId = appValues.NormalizeUserId(data.UserId)
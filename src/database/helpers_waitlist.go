@@ -0,0 +1,263 @@
+package database
+
+import (
+	"ExamSphere/src/core/utils/logging"
+	"context"
+
+	"github.com/ALiwoto/ssg/ssg"
+	"github.com/jackc/pgx/v5"
+)
+
+// SetExamCapacity sets the capacity of an exam; nil removes the limit.
+// It uses the sp set_exam_capacity.
+func SetExamCapacity(examId int, capacity *int) (*ExamInfo, error) {
+	info, err := GetExamInfo(examId)
+	if err != nil {
+		return nil, err
+	} else if info == nil {
+		return nil, ErrExamNotFound
+	}
+
+	_, err = DefaultContainer.db.Exec(context.Background(),
+		`CALL set_exam_capacity(
+			p_exam_id := $1,
+			p_capacity := $2
+		)`,
+		examId,
+		capacity,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	info.Capacity = ssg.Clone(capacity)
+	return info, nil
+}
+
+// GetExamParticipantsCount returns the number of the users participating
+// in the exam.
+func GetExamParticipantsCount(examId int) int {
+	var count int
+	err := DefaultContainer.db.QueryRow(context.Background(),
+		`SELECT COUNT(*) FROM given_exam WHERE exam_id = $1`,
+		examId,
+	).Scan(&count)
+	if err != nil && err != pgx.ErrNoRows {
+		logging.UnexpectedError("GetExamParticipantsCount: failed to query database:", err)
+		return 0
+	}
+
+	return count
+}
+
+// AddUserToExamWaitlist adds the user to the end of the waitlist of the
+// exam; if the user is already waiting, their place is kept.
+// It uses the plpgsql function add_user_to_exam_waitlist.
+func AddUserToExamWaitlist(data *NewExamWaitlistEntryData) (*ExamWaitlistEntry, error) {
+	var couponId *int
+	if data.Coupon != nil {
+		couponId = &data.Coupon.CouponId
+	}
+
+	_, err := DefaultContainer.db.Exec(context.Background(),
+		`SELECT add_user_to_exam_waitlist(
+			p_user_id := $1,
+			p_exam_id := $2,
+			p_added_by := $3,
+			p_coupon_id := $4
+		)`,
+		data.UserId,
+		data.ExamId,
+		data.AddedBy,
+		couponId,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return GetExamWaitlistEntry(data.UserId, data.ExamId)
+}
+
+// GetExamWaitlistEntry gets the entry of the user in the waitlist of the
+// exam alongside their position.
+func GetExamWaitlistEntry(userId string, examId int) (*ExamWaitlistEntry, error) {
+	info := &ExamWaitlistEntry{}
+	err := DefaultContainer.db.QueryRow(context.Background(),
+		`SELECT w.waitlist_id,
+			w.exam_id,
+			w.user_id,
+			w.added_by,
+			w.coupon_id,
+			w.created_at,
+			(SELECT COUNT(*) FROM exam_waitlist o
+				WHERE o.exam_id = w.exam_id AND o.waitlist_id <= w.waitlist_id)
+		FROM exam_waitlist w WHERE w.user_id = $1 AND w.exam_id = $2`,
+		userId,
+		examId,
+	).Scan(
+		&info.WaitlistId,
+		&info.ExamId,
+		&info.UserId,
+		&info.AddedBy,
+		&info.CouponId,
+		&info.CreatedAt,
+		&info.Position,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, ErrWaitlistEntryNotFound
+		}
+
+		return nil, err
+	}
+
+	return info, nil
+}
+
+// GetExamWaitlist gets the waitlist of the exam in order.
+func GetExamWaitlist(examId int) ([]*ExamWaitlistEntry, error) {
+	rows, err := DefaultContainer.db.Query(context.Background(),
+		`SELECT waitlist_id,
+			exam_id,
+			user_id,
+			added_by,
+			coupon_id,
+			created_at
+		FROM exam_waitlist WHERE exam_id = $1
+		ORDER BY waitlist_id`,
+		examId,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []*ExamWaitlistEntry
+	for rows.Next() {
+		info := &ExamWaitlistEntry{}
+		err = rows.Scan(
+			&info.WaitlistId,
+			&info.ExamId,
+			&info.UserId,
+			&info.AddedBy,
+			&info.CouponId,
+			&info.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		info.Position = len(entries) + 1
+		entries = append(entries, info)
+	}
+
+	return entries, nil
+}
+
+// RemoveUserFromExamWaitlist removes the user from the waitlist of the exam.
+func RemoveUserFromExamWaitlist(userId string, examId int) error {
+	result, err := DefaultContainer.db.Exec(context.Background(),
+		`DELETE FROM exam_waitlist WHERE user_id = $1 AND exam_id = $2`,
+		userId,
+		examId,
+	)
+	if err != nil {
+		return err
+	} else if result.RowsAffected() == 0 {
+		return ErrWaitlistEntryNotFound
+	}
+
+	return nil
+}
+
+// WithdrawUserFromExam withdraws the user from the exam and refunds what
+// they have paid for it to their wallet.
+// It uses the sp withdraw_user_from_exam.
+func WithdrawUserFromExam(userId string, examId int, withdrawnBy string) error {
+	_, err := DefaultContainer.db.Exec(context.Background(),
+		`CALL withdraw_user_from_exam(
+			p_user_id := $1,
+			p_exam_id := $2,
+			p_withdrawn_by := $3
+		)`,
+		userId,
+		examId,
+		withdrawnBy,
+	)
+	if err != nil {
+		return err
+	}
+
+	givenExamsMap.Delete(userId + KeySepChar + ssg.ToBase10(examId))
+	return nil
+}
+
+// PromoteExamWaitlist gives the free seats of the exam to the users on its
+// waitlist (in order), and returns the users who got a seat.
+// Users who cannot pay for the exam right now, or whose coupon cannot be
+// used anymore, are skipped, but keep their place in the waitlist; they are
+// never charged more than the price they have joined the waitlist with.
+func PromoteExamWaitlist(examId int) ([]*GivenExam, error) {
+	examInfo, err := GetExamInfo(examId)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := GetExamWaitlist(examId)
+	if err != nil || len(entries) == 0 {
+		return nil, err
+	}
+
+	var promoted []*GivenExam
+	for _, entry := range entries {
+		givenExam, err := promoteWaitlistEntry(examInfo, entry)
+		if err == ErrExamFull {
+			break
+		} else if err == ErrInsufficientBalance ||
+			err == ErrCurrencyMismatch ||
+			err == ErrExamCouponNotUsable {
+			continue
+		} else if err != nil {
+			return promoted, err
+		}
+
+		promoted = append(promoted, givenExam)
+	}
+
+	return promoted, nil
+}
+
+func promoteWaitlistEntry(examInfo *ExamInfo, entry *ExamWaitlistEntry) (*GivenExam, error) {
+	var coupon *ExamCoupon
+	if entry.CouponId != nil {
+		// the user has only agreed to the discounted price; if the coupon
+		// cannot be used anymore, ErrExamCouponNotUsable is returned
+		// rather than charging them the full price
+		var err error
+		coupon, err = GetExamCoupon(*entry.CouponId)
+		if err == ErrExamCouponNotFound {
+			return nil, ErrExamCouponNotUsable
+		} else if err != nil {
+			return nil, err
+		}
+
+		coupon, err = GetUsableExamCoupon(coupon.CouponCode, entry.UserId, examInfo.ExamId)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	price, discount, err := examInfo.GetPriceWithCoupon(coupon)
+	if err != nil {
+		return nil, err
+	}
+
+	return AddUserInExam(&NewGivenExamData{
+		UserId:   entry.UserId,
+		ExamId:   examInfo.ExamId,
+		Price:    price,
+		AddedBy:  entry.AddedBy,
+		Coupon:   coupon,
+		Discount: discount,
+	})
+}
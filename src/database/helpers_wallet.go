@@ -0,0 +1,244 @@
+package database
+
+import (
+	"ExamSphere/src/core/utils/paymentUtils"
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// GetUserWallet returns the wallet of the user; users who have never made
+// a transaction get an empty wallet.
+func GetUserWallet(userId string) (*Wallet, error) {
+	info := &Wallet{
+		UserId:   userId,
+		Currency: paymentUtils.DefaultCurrency,
+	}
+
+	err := DefaultContainer.db.QueryRow(context.Background(),
+		`SELECT balance, currency, updated_at
+		FROM wallet_account WHERE user_id = $1`,
+		userId,
+	).Scan(
+		&info.Balance,
+		&info.Currency,
+		&info.UpdatedAt,
+	)
+	if err != nil && err != pgx.ErrNoRows {
+		return nil, err
+	}
+
+	return info, nil
+}
+
+// GetWalletTransaction returns a wallet transaction alongside the amount
+// that has been refunded from it so far.
+func GetWalletTransaction(transactionId int64) (*WalletTransaction, error) {
+	info := &WalletTransaction{}
+	err := DefaultContainer.db.QueryRow(context.Background(),
+		`SELECT t.transaction_id,
+			t.transaction_type,
+			t.user_id,
+			t.exam_id,
+			t.amount,
+			t.currency,
+			t.provider,
+			t.provider_reference,
+			t.refund_of,
+			t.description,
+			t.created_by,
+			t.created_at,
+			(SELECT COALESCE(SUM(r.amount), 0) FROM wallet_transaction r
+				WHERE r.refund_of = t.transaction_id)
+		FROM wallet_transaction t WHERE t.transaction_id = $1`,
+		transactionId,
+	).Scan(
+		&info.TransactionId,
+		&info.TransactionType,
+		&info.UserId,
+		&info.ExamId,
+		&info.Amount,
+		&info.Currency,
+		&info.Provider,
+		&info.ProviderReference,
+		&info.RefundOf,
+		&info.Description,
+		&info.CreatedBy,
+		&info.CreatedAt,
+		&info.RefundedAmount,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, ErrWalletTransactionNotFound
+		}
+		return nil, err
+	}
+
+	return info, nil
+}
+
+// GetWalletTransactions returns the transactions of the wallet of a user,
+// most recent ones first.
+func GetWalletTransactions(data *GetWalletTransactionsData) ([]*WalletTransaction, error) {
+	if data.Limit <= 0 {
+		data.Limit = DefaultPaginationLimit
+	}
+
+	rows, err := DefaultContainer.db.Query(context.Background(),
+		`SELECT t.transaction_id,
+			t.transaction_type,
+			t.user_id,
+			t.exam_id,
+			t.amount,
+			t.currency,
+			t.provider,
+			t.provider_reference,
+			t.refund_of,
+			t.description,
+			t.created_by,
+			t.created_at,
+			(SELECT COALESCE(SUM(r.amount), 0) FROM wallet_transaction r
+				WHERE r.refund_of = t.transaction_id)
+		FROM wallet_transaction t WHERE t.user_id = $1
+		ORDER BY t.created_at DESC, t.transaction_id DESC
+		LIMIT $2 OFFSET $3`,
+		data.UserId,
+		data.Limit,
+		data.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var transactions []*WalletTransaction
+	for rows.Next() {
+		info := &WalletTransaction{}
+		err = rows.Scan(
+			&info.TransactionId,
+			&info.TransactionType,
+			&info.UserId,
+			&info.ExamId,
+			&info.Amount,
+			&info.Currency,
+			&info.Provider,
+			&info.ProviderReference,
+			&info.RefundOf,
+			&info.Description,
+			&info.CreatedBy,
+			&info.CreatedAt,
+			&info.RefundedAmount,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		transactions = append(transactions, info)
+	}
+
+	return transactions, nil
+}
+
+// TopUpWallet records money received through the payment provider in the
+// wallet of the user.
+// It uses the plpgsql function top_up_wallet.
+func TopUpWallet(data *NewTopUpData) (*WalletTransaction, error) {
+	if data.Currency == "" {
+		data.Currency = paymentUtils.DefaultCurrency
+	}
+
+	var transactionId int64
+	err := DefaultContainer.db.QueryRow(context.Background(),
+		`SELECT top_up_wallet(
+			p_user_id := $1,
+			p_amount := $2,
+			p_provider := $3,
+			p_provider_reference := $4,
+			p_description := $5,
+			p_created_by := $6,
+			p_currency := $7
+		)`,
+		data.UserId,
+		data.Amount,
+		data.Provider,
+		data.ProviderReference,
+		data.Description,
+		data.CreatedBy,
+		data.Currency,
+	).Scan(&transactionId)
+	if err != nil {
+		return nil, err
+	}
+
+	return GetWalletTransaction(transactionId)
+}
+
+// RefundWalletTransaction refunds (a part of) a top-up or an exam payment.
+// It uses the plpgsql function refund_wallet_transaction.
+func RefundWalletTransaction(data *NewRefundData) (*WalletTransaction, error) {
+	var transactionId int64
+	err := DefaultContainer.db.QueryRow(context.Background(),
+		`SELECT refund_wallet_transaction(
+			p_transaction_id := $1,
+			p_amount := $2,
+			p_provider_reference := $3,
+			p_description := $4,
+			p_created_by := $5
+		)`,
+		data.TransactionId,
+		data.Amount,
+		data.ProviderReference,
+		data.Description,
+		data.CreatedBy,
+	).Scan(&transactionId)
+	if err != nil {
+		return nil, toWalletError(err)
+	}
+
+	return GetWalletTransaction(transactionId)
+}
+
+// GetExamPaymentTransaction returns the transaction in which the user has
+// paid for the exam, or nil if they haven't paid for it.
+func GetExamPaymentTransaction(userId string, examId int) (*WalletTransaction, error) {
+	var transactionId int64
+	err := DefaultContainer.db.QueryRow(context.Background(),
+		`SELECT transaction_id FROM wallet_transaction
+		WHERE user_id = $1 AND exam_id = $2 AND transaction_type = $3
+		ORDER BY created_at DESC LIMIT 1`,
+		userId,
+		examId,
+		TransactionTypeExamPayment,
+	).Scan(&transactionId)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return GetWalletTransaction(transactionId)
+}
+
+// toWalletError converts the errors raised by the ledger (and the coupons)
+// to the known database errors (if possible).
+func toWalletError(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+
+	if pgErr.ConstraintName == walletBalanceConstraint {
+		return ErrInsufficientBalance
+	} else if pgErr.Code == couponNotUsableErrCode {
+		return ErrExamCouponNotUsable
+	} else if pgErr.Code == currencyMismatchErrCode {
+		return ErrCurrencyMismatch
+	} else if pgErr.Code == examFullErrCode {
+		return ErrExamFull
+	}
+
+	return err
+}
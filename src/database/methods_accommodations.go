@@ -0,0 +1,19 @@
+package database
+
+import "github.com/ALiwoto/ssg/ssg"
+
+// GetUniqueId returns the unique id of the accommodation, which is the
+// same as the unique id of the given exam.
+func (a *ExamAccommodation) GetUniqueId() string {
+	return a.UserId + KeySepChar + ssg.ToBase10(a.ExamId)
+}
+
+// GetTimeMultiplier returns the time multiplier of the accommodation.
+// It's safe to call this method on a nil accommodation.
+func (a *ExamAccommodation) GetTimeMultiplier() float64 {
+	if a == nil || a.TimeMultiplier < 1 {
+		return 1
+	}
+
+	return a.TimeMultiplier
+}
@@ -0,0 +1,55 @@
+package database
+
+import "ExamSphere/src/core/utils/irtUtils"
+
+// GetAdaptiveStopRule returns the rule that decides when the adaptive
+// attempts of the exam end.
+func (e *ExamInfo) GetAdaptiveStopRule() *irtUtils.StopRule {
+	rule := &irtUtils.StopRule{}
+	if e.AdaptiveMaxItems != nil {
+		rule.MaxItems = *e.AdaptiveMaxItems
+	}
+	if e.AdaptiveTargetStdError != nil {
+		rule.TargetStdError = *e.AdaptiveTargetStdError
+	}
+
+	return rule
+}
+
+// HasAnswerKey returns true if the correct option of the question is known.
+func (e *ExamQuestion) HasAnswerKey() bool {
+	return e.CorrectOption != nil
+}
+
+// IsCorrectOption returns true if the specified option (which can be nil)
+// is the correct option of the question.
+func (e *ExamQuestion) IsCorrectOption(option *string) bool {
+	return option != nil && e.CorrectOption != nil && *option == *e.CorrectOption
+}
+
+// GetIrtParams returns the parameters of the question in the 3PL model.
+func (e *ExamQuestion) GetIrtParams() irtUtils.ItemParams {
+	return irtUtils.ItemParams{
+		Discrimination: e.IrtDiscrimination,
+		Difficulty:     e.IrtDifficulty,
+		Guessing:       e.IrtGuessing,
+	}
+}
+
+// IsAnswered returns true if the question has been answered.
+func (i *ExamAttemptItem) IsAnswered() bool {
+	return i.IsCorrect != nil
+}
+
+// GetAbilityEstimate returns the ability estimate of the attempt, or the
+// prior if nothing has been answered in it yet.
+func (a *ExamAttempt) GetAbilityEstimate() *irtUtils.AbilityEstimate {
+	if a.AbilityEstimate == nil || a.AbilityStdError == nil {
+		return irtUtils.EstimateAbility(nil)
+	}
+
+	return &irtUtils.AbilityEstimate{
+		Theta:    *a.AbilityEstimate,
+		StdError: *a.AbilityStdError,
+	}
+}
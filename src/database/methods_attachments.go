@@ -0,0 +1,17 @@
+package database
+
+// IsAnswer returns true if the file was uploaded by a user as their answer.
+func (a *Attachment) IsAnswer() bool {
+	return a.AttachmentType == AttachmentTypeAnswer
+}
+
+// IsAttachmentTypeValid returns true if the specified value is one of the
+// AttachmentType* constants.
+func IsAttachmentTypeValid(value string) bool {
+	switch value {
+	case AttachmentTypeQuestion, AttachmentTypeOption, AttachmentTypeAnswer:
+		return true
+	}
+
+	return false
+}
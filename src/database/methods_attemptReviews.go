@@ -0,0 +1,45 @@
+package database
+
+// AreResultsReleased returns true if the results of the exam have been
+// released.
+func (e *ExamInfo) AreResultsReleased() bool {
+	return e.ResultsReleasedAt != nil
+}
+
+// CanReviewAttemptFor returns true if a student with the specified
+// accommodation (which can be nil) is allowed to review their attempts
+// in the exam, according to its attempt review policy.
+func (e *ExamInfo) CanReviewAttemptFor(accommodation *ExamAccommodation) bool {
+	switch e.AttemptReviewPolicy {
+	case AttemptReviewPolicyAfterClose:
+		return e.HasExamFinishedFor(accommodation)
+	case AttemptReviewPolicyAfterRelease:
+		return e.HasExamFinishedFor(accommodation) && e.AreResultsReleased()
+	}
+
+	return false
+}
+
+// IsGraded returns true if a grader has given points to the answer.
+func (a *GivenAnswerInfo) IsGraded() bool {
+	return a.PointsEarned != nil
+}
+
+// GetPointsEarned returns the number of points the answer has earned in
+// the specified question: the points given by a grader if any, otherwise
+// all or none of the points of the question by its answer key.
+// It returns nil if the answer is still waiting to be graded.
+func (a *GivenAnswerInfo) GetPointsEarned(question *ExamQuestion) *float64 {
+	if a.PointsEarned != nil {
+		points := *a.PointsEarned
+		return &points
+	} else if !question.HasAnswerKey() {
+		return nil
+	}
+
+	var points float64
+	if question.IsCorrectOption(a.ChosenOption) {
+		points = question.Points
+	}
+	return &points
+}
@@ -0,0 +1,45 @@
+package database
+
+import "github.com/ALiwoto/ssg/ssg"
+
+// GetUniqueId returns the unique id of the binding, which is the
+// same as the unique id of the given exam.
+func (b *ExamAttemptBinding) GetUniqueId() string {
+	return b.UserId + KeySepChar + ssg.ToBase10(b.ExamId)
+}
+
+// IsSameClient returns true if the specified client is the same as the
+// client that the attempt is bound to. The clients are told apart by their
+// device id only, since their ip address can change in the middle of an
+// exam (see HasIPChanged).
+func (b *ExamAttemptBinding) IsSameClient(deviceId string) bool {
+	return b.DeviceId == deviceId
+}
+
+// HasIPChanged returns true if the specified ip address is not the last
+// known ip address of the bound client.
+func (b *ExamAttemptBinding) HasIPChanged(ipAddress string) bool {
+	if b.LastIPAddress != nil {
+		return *b.LastIPAddress != ipAddress
+	}
+
+	return b.IPAddress != ipAddress
+}
+
+//-------------------------------------------------------------
+
+// GetUniqueId returns the unique id of the attempt's given exam.
+func (a *ExamAttempt) GetUniqueId() string {
+	return a.UserId + KeySepChar + ssg.ToBase10(a.ExamId)
+}
+
+// IsFinished returns true if the user has finished this attempt.
+func (a *ExamAttempt) IsFinished() bool {
+	return a.FinishedAt != nil
+}
+
+//-------------------------------------------------------------
+
+func (d *ExamAttemptClientData) GetUniqueId() string {
+	return d.UserId + KeySepChar + ssg.ToBase10(d.ExamId)
+}
@@ -0,0 +1,27 @@
+package database
+
+// GetUniqueId returns the key of the collaborator in the cache.
+func (c *ExamCollaborator) GetUniqueId() string {
+	return getExamCollaboratorKey(c.UserId, c.ExamId)
+}
+
+// CanEdit returns true if the collaborator can edit the exam and its
+// questions, and manage its participants.
+func (c *ExamCollaborator) CanEdit() bool {
+	return c != nil && c.CollaboratorRole == CollaboratorRoleEditor
+}
+
+// CanGrade returns true if the collaborator can grade the answers of the
+// participants of the exam.
+func (c *ExamCollaborator) CanGrade() bool {
+	return c != nil && (c.CollaboratorRole == CollaboratorRoleEditor ||
+		c.CollaboratorRole == CollaboratorRoleGrader)
+}
+
+// CanView returns true if the collaborator can see the exam and its
+// questions.
+func (c *ExamCollaborator) CanView() bool {
+	return c != nil && (c.CollaboratorRole == CollaboratorRoleEditor ||
+		c.CollaboratorRole == CollaboratorRoleGrader ||
+		c.CollaboratorRole == CollaboratorRoleViewer)
+}
@@ -0,0 +1,26 @@
+package database
+
+import "time"
+
+// IsExpired returns true if the coupon cannot be used anymore because of
+// its expiry.
+func (c *ExamCoupon) IsExpired() bool {
+	return c.ExpiresAt != nil && !c.ExpiresAt.After(time.Now())
+}
+
+// HasReachedMaxUses returns true if the coupon has been used as many times
+// as it's allowed to.
+func (c *ExamCoupon) HasReachedMaxUses() bool {
+	return c.MaxUses != nil && c.UsesCount >= *c.MaxUses
+}
+
+// GetDiscount returns the amount discounted from the specified price amount
+// (both in minor units); the discount never exceeds the price.
+func (c *ExamCoupon) GetDiscount(amount int64) int64 {
+	discount := c.DiscountValue
+	if c.DiscountType == DiscountTypePercentage {
+		discount = amount * c.DiscountValue / 100
+	}
+
+	return min(discount, amount)
+}
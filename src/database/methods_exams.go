@@ -1,29 +1,254 @@
 package database
 
 import (
+	"ExamSphere/src/core/utils/hashing"
+	"ExamSphere/src/core/utils/paymentUtils"
+	"crypto/subtle"
 	"time"
 
 	"github.com/ALiwoto/ssg/ssg"
 )
 
 func (e *ExamInfo) HasExamStarted() bool {
-	return time.Now().After(e.ExamDate)
+	return e.HasExamStartedFor(nil)
 }
 
 func (e *ExamInfo) HasExamFinished() bool {
-	return time.Now().After(e.ExamDate.Add(time.Minute * time.Duration(e.Duration)))
+	return e.HasExamFinishedFor(nil)
 }
 
 func (e *ExamInfo) ExamStartsIn() int {
-	until := time.Until(e.ExamDate)
+	return e.ExamStartsInFor(nil)
+}
+
+func (e *ExamInfo) ExamFinishesIn() int {
+	return e.ExamFinishesInFor(nil)
+}
+
+// IsWindowed returns true if the exam has an availability window, in which
+// the users can start it at any time they want.
+func (e *ExamInfo) IsWindowed() bool {
+	return e.ClosesAt != nil
+}
+
+// GetOpensAt returns the time the exam opens (for everyone).
+func (e *ExamInfo) GetOpensAt() time.Time {
+	if e.OpensAt != nil {
+		return *e.OpensAt
+	}
+
+	return e.ExamDate
+}
+
+// GetClosesAt returns the time the exam closes (for everyone).
+func (e *ExamInfo) GetClosesAt() time.Time {
+	if e.ClosesAt != nil {
+		return *e.ClosesAt
+	}
+
+	return e.GetOpensAt().Add(time.Minute * time.Duration(e.Duration))
+}
+
+// GetGracePeriod returns the time in which the answers are still accepted
+// after the deadline of the users.
+func (e *ExamInfo) GetGracePeriod() time.Duration {
+	if e.DeadlinePolicy != DeadlinePolicyGracePeriod {
+		return 0
+	}
+
+	return time.Minute * time.Duration(e.GracePeriod)
+}
+
+// GetTimeLimitFor returns how long a user with the specified accommodation
+// (which can be nil) has for an attempt.
+func (e *ExamInfo) GetTimeLimitFor(accommodation *ExamAccommodation) time.Duration {
+	duration := time.Duration(
+		float64(time.Minute*time.Duration(e.Duration)) * accommodation.GetTimeMultiplier(),
+	)
+	if accommodation != nil {
+		duration += time.Minute * time.Duration(accommodation.ExtraMinutes)
+	}
+
+	return duration
+}
+
+// GetStartTimeFor returns the time the exam opens for a user with the
+// specified accommodation (which can be nil).
+func (e *ExamInfo) GetStartTimeFor(accommodation *ExamAccommodation) time.Time {
+	if accommodation != nil && accommodation.CustomStart != nil {
+		return *accommodation.CustomStart
+	}
+
+	return e.GetOpensAt()
+}
+
+// GetEndTimeFor returns the time the exam closes for a user with the
+// specified accommodation (which can be nil).
+func (e *ExamInfo) GetEndTimeFor(accommodation *ExamAccommodation) time.Time {
+	if accommodation != nil && accommodation.CustomEnd != nil {
+		return *accommodation.CustomEnd
+	} else if e.IsWindowed() {
+		return *e.ClosesAt
+	}
+
+	return e.GetStartTimeFor(accommodation).Add(e.GetTimeLimitFor(accommodation))
+}
+
+// GetLatestStartTimeFor returns the latest time a user with the specified
+// accommodation (which can be nil) is allowed to start an attempt.
+func (e *ExamInfo) GetLatestStartTimeFor(accommodation *ExamAccommodation) time.Time {
+	endTime := e.GetEndTimeFor(accommodation)
+	if e.LateStartLimit == nil {
+		return endTime
+	}
+
+	latestStart := e.GetStartTimeFor(accommodation).Add(
+		time.Minute * time.Duration(*e.LateStartLimit),
+	)
+	if latestStart.After(endTime) {
+		return endTime
+	}
+
+	return latestStart
+}
+
+// GetDeadlineFor returns the personal deadline of a user with the specified
+// accommodation (which can be nil) and latest attempt (which can be nil).
+// For exams with a window, the deadline of an ongoing attempt is calculated
+// from the time it was started, but never goes past the close time.
+func (e *ExamInfo) GetDeadlineFor(accommodation *ExamAccommodation, attempt *ExamAttempt) time.Time {
+	endTime := e.GetEndTimeFor(accommodation)
+	if !e.IsWindowed() || attempt == nil || attempt.IsFinished() {
+		return endTime
+	}
+
+	deadline := attempt.StartedAt.Add(e.GetTimeLimitFor(accommodation))
+	if deadline.After(endTime) {
+		return endTime
+	}
+
+	return deadline
+}
+
+// HasExamStartedFor returns true if the exam has started for a user with
+// the specified accommodation (which can be nil).
+func (e *ExamInfo) HasExamStartedFor(accommodation *ExamAccommodation) bool {
+	return time.Now().After(e.GetStartTimeFor(accommodation))
+}
+
+// HasExamFinishedFor returns true if the exam has finished for a user with
+// the specified accommodation (which can be nil), including the grace period.
+func (e *ExamInfo) HasExamFinishedFor(accommodation *ExamAccommodation) bool {
+	return time.Now().After(e.GetEndTimeFor(accommodation).Add(e.GetGracePeriod()))
+}
+
+// CanStartAttemptFor returns true if a user with the specified accommodation
+// (which can be nil) is still allowed to start an attempt in the exam.
+func (e *ExamInfo) CanStartAttemptFor(accommodation *ExamAccommodation) bool {
+	return e.HasExamStartedFor(accommodation) &&
+		!time.Now().After(e.GetLatestStartTimeFor(accommodation))
+}
+
+// HasDeadlinePassedFor returns true if the deadline of the specified attempt
+// (plus the grace period) has passed for a user with the specified
+// accommodation (which can be nil).
+func (e *ExamInfo) HasDeadlinePassedFor(accommodation *ExamAccommodation, attempt *ExamAttempt) bool {
+	return time.Now().After(e.GetDeadlineFor(accommodation, attempt).Add(e.GetGracePeriod()))
+}
+
+// ExamStartsInFor returns the time (in minutes) until the exam starts for a
+// user with the specified accommodation (which can be nil).
+func (e *ExamInfo) ExamStartsInFor(accommodation *ExamAccommodation) int {
+	until := time.Until(e.GetStartTimeFor(accommodation))
 	if until < 0 {
 		return 0
 	}
 	return int(until.Minutes())
 }
 
-func (e *ExamInfo) ExamFinishesIn() int {
-	return int(time.Until(e.ExamDate.Add(time.Minute * time.Duration(e.Duration))).Minutes())
+// ExamFinishesInFor returns the time (in minutes) until the exam finishes for
+// a user with the specified accommodation (which can be nil).
+func (e *ExamInfo) ExamFinishesInFor(accommodation *ExamAccommodation) int {
+	return int(time.Until(e.GetEndTimeFor(accommodation)).Minutes())
+}
+
+// DeadlineInFor returns the time (in minutes) until the personal deadline of
+// a user with the specified accommodation and latest attempt (both can be nil).
+func (e *ExamInfo) DeadlineInFor(accommodation *ExamAccommodation, attempt *ExamAttempt) int {
+	return int(time.Until(e.GetDeadlineFor(accommodation, attempt)).Minutes())
+}
+
+// RequiresAccessCode returns true if the exam can only be joined (or started)
+// by providing an access code.
+func (e *ExamInfo) RequiresAccessCode() bool {
+	return e.AccessCodeType != AccessCodeTypeNone && e.AccessCodeType != "" &&
+		e.AccessCode != nil
+}
+
+// getAccessCodeCounter returns the counter used to generate the rotating
+// access code at the specified time.
+func (e *ExamInfo) getAccessCodeCounter(t time.Time) int64 {
+	interval := int64(e.AccessCodeInterval)
+	if interval <= 0 {
+		interval = DefaultAccessCodeInterval
+	}
+
+	return t.Unix() / (interval * 60)
+}
+
+// GetCurrentAccessCode returns the access code that has to be announced
+// right now. It returns an empty string if the exam has no access code.
+func (e *ExamInfo) GetCurrentAccessCode() string {
+	if !e.RequiresAccessCode() {
+		return ""
+	}
+
+	if e.AccessCodeType == AccessCodeTypeRotating {
+		return hashing.GenerateRotatingCode(*e.AccessCode, e.getAccessCodeCounter(time.Now()))
+	}
+
+	return *e.AccessCode
+}
+
+// AccessCodeExpiresIn returns the time in seconds until the current rotating
+// access code changes. It returns 0 for the other types of access codes.
+func (e *ExamInfo) AccessCodeExpiresIn() int {
+	if e.AccessCodeType != AccessCodeTypeRotating {
+		return 0
+	}
+
+	nextCounter := e.getAccessCodeCounter(time.Now()) + 1
+	interval := int64(e.AccessCodeInterval)
+	if interval <= 0 {
+		interval = DefaultAccessCodeInterval
+	}
+
+	return int(time.Until(time.Unix(nextCounter*interval*60, 0)).Seconds())
+}
+
+// IsAccessCodeValid returns true if the specified code is a valid access
+// code for the exam. For rotating codes, the previous code is accepted as
+// well, so students won't get rejected right after the code rotates.
+func (e *ExamInfo) IsAccessCodeValid(code string) bool {
+	if !e.RequiresAccessCode() {
+		return true
+	} else if code == "" {
+		return false
+	}
+
+	if e.AccessCodeType != AccessCodeTypeRotating {
+		return subtle.ConstantTimeCompare([]byte(*e.AccessCode), []byte(code)) == 1
+	}
+
+	counter := e.getAccessCodeCounter(time.Now())
+	for _, current := range []int64{counter, counter - 1} {
+		expected := hashing.GenerateRotatingCode(*e.AccessCode, current)
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return true
+		}
+	}
+
+	return false
 }
 
 //-------------------------------------------------------------
@@ -40,8 +265,87 @@ func (e *ExamQuestion) HasOption(option string) bool {
 		(e.Option4 != nil && *e.Option4 == option)
 }
 
+// HasOptionNumber returns true if the question has the option with the
+// specified number (1-4).
+func (e *ExamQuestion) HasOptionNumber(number int) bool {
+	switch number {
+	case 1:
+		return e.Option1 != nil
+	case 2:
+		return e.Option2 != nil
+	case 3:
+		return e.Option3 != nil
+	case 4:
+		return e.Option4 != nil
+	}
+
+	return false
+}
+
 //-------------------------------------------------------------
 
 func (g *GivenExam) GetUniqueId() string {
 	return g.UserId + KeySepChar + ssg.ToBase10(g.ExamId)
 }
+
+// GetAttemptsLeft returns the number of attempts the user still has in the
+// exam, considering their latest attempt (which can be nil).
+// It returns -1 if the exam allows unlimited attempts (as practice exams
+// always do).
+func (e *ExamInfo) GetAttemptsLeft(latestAttempt *ExamAttempt) int {
+	if e.MaxAttempts <= 0 || e.IsPractice {
+		return -1
+	}
+
+	if latestAttempt == nil {
+		return e.MaxAttempts
+	}
+
+	return max(e.MaxAttempts-latestAttempt.AttemptNumber, 0)
+}
+
+// RetakeAvailableIn returns the time (in seconds) the user has to wait before
+// being able to start a new attempt after their latest (finished) attempt.
+func (e *ExamInfo) RetakeAvailableIn(latestAttempt *ExamAttempt) int {
+	if latestAttempt == nil || latestAttempt.FinishedAt == nil || e.IsPractice {
+		return 0
+	}
+
+	availableAt := latestAttempt.FinishedAt.Add(
+		time.Duration(e.AttemptCooldown) * time.Minute,
+	)
+	return max(int(time.Until(availableAt).Seconds()), 0)
+}
+
+// GetPriceWithCoupon returns the price the user has to pay for the exam when
+// using the coupon (which can be nil), alongside the discounted amount (in
+// minor units).
+func (e *ExamInfo) GetPriceWithCoupon(coupon *ExamCoupon) (string, int64, error) {
+	if coupon == nil {
+		return e.Price, 0, nil
+	}
+
+	amount, currency, err := paymentUtils.ParsePrice(e.Price)
+	if err != nil {
+		return "", 0, err
+	}
+
+	discount := coupon.GetDiscount(amount)
+	return paymentUtils.FormatPrice(amount-discount, currency), discount, nil
+}
+
+// IsPublished returns true if the exam has been approved by a reviewer,
+// so it's visible to (and joinable by) the students.
+func (e *ExamInfo) IsPublished() bool {
+	return e.ReviewStatus == ExamReviewStatusPublished
+}
+
+// IsInReview returns true if the exam waits for a reviewer.
+func (e *ExamInfo) IsInReview() bool {
+	return e.ReviewStatus == ExamReviewStatusInReview
+}
+
+// IsDraft returns true if the exam is still being written.
+func (e *ExamInfo) IsDraft() bool {
+	return e.ReviewStatus == ExamReviewStatusDraft
+}
@@ -0,0 +1,54 @@
+package database
+
+import (
+	"ExamSphere/src/core/utils/hashing"
+	"strings"
+	"time"
+
+	"github.com/ALiwoto/ssg/ssg"
+)
+
+// GetStatus returns the status of the invitation, considering its expiry
+// even if it hasn't been marked as expired in the database yet.
+func (i *ExamInvitation) GetStatus() string {
+	if i.Status == InvitationStatusPending && !i.ExpiresAt.After(time.Now()) {
+		return InvitationStatusExpired
+	}
+
+	return i.Status
+}
+
+// IsPending returns true if the invitation can still be accepted.
+func (i *ExamInvitation) IsPending() bool {
+	return i.GetStatus() == InvitationStatusPending
+}
+
+// IsFor returns true if the invitation has been sent to the user (either
+// to their user id or to their email address).
+func (i *ExamInvitation) IsFor(userInfo *UserInfo) bool {
+	if userInfo == nil {
+		return false
+	}
+
+	return (i.UserId != nil && *i.UserId == userInfo.UserId) ||
+		strings.EqualFold(i.Email, userInfo.Email)
+}
+
+// GetJoinToken returns the token of the join link of the invitation, which
+// is signed by the server so it cannot be forged. The key is derived from
+// the access token signing key, so the join tokens and the access tokens
+// are never signed with the same key.
+func (i *ExamInvitation) GetJoinToken() string {
+	return hashing.SignValue(getJoinTokenKey(), i.getSignedValue())
+}
+
+// VerifyJoinToken returns true if the token is the one of the join link
+// of the invitation.
+func (i *ExamInvitation) VerifyJoinToken(token string) bool {
+	return hashing.VerifySignedValue(getJoinTokenKey(), i.getSignedValue(), token)
+}
+
+func (i *ExamInvitation) getSignedValue() string {
+	return "examInvite:" + ssg.ToBase10(i.InvitationId) + ":" +
+		strings.ToLower(i.Email) + ":" + ssg.ToBase10(i.ExpiresAt.Unix())
+}
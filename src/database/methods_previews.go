@@ -0,0 +1,30 @@
+package database
+
+import "time"
+
+// IsFinished returns true if the user has finished the preview.
+func (p *ExamPreviewAttempt) IsFinished() bool {
+	return p.FinishedAt != nil
+}
+
+// GetDeadline returns the time the preview runs out of time, which is
+// calculated the same way as for a student without accommodations who
+// has started their attempt at the same time.
+func (p *ExamPreviewAttempt) GetDeadline(examInfo *ExamInfo) time.Time {
+	return p.StartedAt.Add(examInfo.GetTimeLimitFor(nil))
+}
+
+// HasDeadlinePassed returns true if the deadline of the preview (plus the
+// grace period of the exam) has passed.
+func (p *ExamPreviewAttempt) HasDeadlinePassed(examInfo *ExamInfo) bool {
+	return time.Now().After(p.GetDeadline(examInfo).Add(examInfo.GetGracePeriod()))
+}
+
+// GetElapsedTime returns how long the preview has taken (so far).
+func (p *ExamPreviewAttempt) GetElapsedTime() time.Duration {
+	if p.FinishedAt != nil {
+		return p.FinishedAt.Sub(p.StartedAt)
+	}
+
+	return time.Since(p.StartedAt)
+}
@@ -0,0 +1,34 @@
+package database
+
+import "time"
+
+// GetDurationFor returns the time limit of the section for a user with the
+// specified accommodation (which can be nil); 0 means no time limit.
+func (s *ExamSection) GetDurationFor(accommodation *ExamAccommodation) time.Duration {
+	if s.Duration == nil {
+		return 0
+	}
+
+	return time.Duration(
+		float64(time.Minute*time.Duration(*s.Duration)) * accommodation.GetTimeMultiplier(),
+	)
+}
+
+// GetDeadlineFor returns the time the section closes for a user with the
+// specified accommodation (which can be nil) who has entered it at the
+// specified time; nil is returned if the section has no time limit.
+func (s *ExamSection) GetDeadlineFor(accommodation *ExamAccommodation, startedAt time.Time) *time.Time {
+	duration := s.GetDurationFor(accommodation)
+	if duration == 0 {
+		return nil
+	}
+
+	deadline := startedAt.Add(duration)
+	return &deadline
+}
+
+// IsAnswerable returns true if the questions of the section can be answered
+// (and seen) by the user right now.
+func (p *ExamSectionProgress) IsAnswerable() bool {
+	return p.Status == SectionStatusOngoing || p.Status == SectionStatusOpen
+}
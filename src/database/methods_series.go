@@ -0,0 +1,51 @@
+package database
+
+import (
+	"ExamSphere/src/core/utils/recurrenceUtils"
+	"time"
+)
+
+// GetRule returns the parsed recurrence rule of the series.
+func (s *ExamSeries) GetRule() (*recurrenceUtils.RecurrenceRule, error) {
+	return recurrenceUtils.ParseRecurrenceRule(s.RecurrenceRule)
+}
+
+// GetDueOccurrences returns the occurrences of the series that have to be
+// materialised by now; the ones after the last generated occurrence (or
+// the current time, whichever is later) up to GenerateAhead days from now.
+func (s *ExamSeries) GetDueOccurrences(now time.Time) ([]time.Time, error) {
+	rule, err := s.GetRule()
+	if err != nil {
+		return nil, err
+	}
+
+	after := now
+	if s.LastGeneratedAt != nil && s.LastGeneratedAt.After(after) {
+		after = *s.LastGeneratedAt
+	}
+
+	return rule.Occurrences(s.StartsAt, after, now.AddDate(0, 0, s.GenerateAhead), 0), nil
+}
+
+// GetUpcomingOccurrences returns (at most) the next limit occurrences of the
+// series after the specified time.
+func (s *ExamSeries) GetUpcomingOccurrences(after time.Time, limit int) ([]time.Time, error) {
+	rule, err := s.GetRule()
+	if err != nil {
+		return nil, err
+	}
+
+	return rule.Occurrences(s.StartsAt, after, after.AddDate(MaxSeriesGenerateAhead, 0, 0), limit), nil
+}
+
+// IsOccurrence returns true if the specified time is an occurrence of
+// the series.
+func (s *ExamSeries) IsOccurrence(t time.Time) bool {
+	rule, err := s.GetRule()
+	if err != nil {
+		return false
+	}
+
+	occurrences := rule.Occurrences(s.StartsAt, t.Add(-time.Second), t, 1)
+	return len(occurrences) == 1 && occurrences[0].Equal(t)
+}
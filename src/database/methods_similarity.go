@@ -0,0 +1,6 @@
+package database
+
+// IsFinished returns true if the check is not running anymore.
+func (c *SimilarityCheck) IsFinished() bool {
+	return c.CheckStatus != SimilarityCheckStatusRunning
+}
@@ -192,6 +192,30 @@ func (i *UserInfo) CanEditCourse() bool {
 		i.Role == appValues.UserRoleOwner
 }
 
+// CanManageCourseGradebook returns true if and only if the current user
+// has the permission to manage the grade categories of the course.
+func (i *UserInfo) CanManageCourseGradebook(courseInfo *CourseInfo) bool {
+	return i.IsAdminOrOwner() ||
+		(i != nil && i.UserId == courseInfo.AddedBy)
+}
+
+// CanViewCourseGradebook returns true if and only if the current user has
+// the permission to see the grades of all of the participants of the
+// course: on top of the managers of its gradebook, the creators and the
+// grading collaborators of any of its (graded) exams can see them.
+func (i *UserInfo) CanViewCourseGradebook(courseInfo *CourseInfo, exams []*ExamInfo) bool {
+	if i.CanManageCourseGradebook(courseInfo) {
+		return true
+	}
+
+	for _, examInfo := range exams {
+		if i.isExamCreatorOrGrader(examInfo) {
+			return true
+		}
+	}
+	return false
+}
+
 // CanCreateNewExam returns true if and only if the current user has
 // the permission to create a new exam.
 // Owners, admins, and teachers can create new exams.
@@ -205,7 +229,7 @@ func (i *UserInfo) CanCreateNewExam() bool {
 // the permission to add others to the specified exam.
 // Owners, admins, can add others to exams.
 // Teachers can add others to exams if and only if they are the creator
-// of the exam.
+// of the exam, or one of its editors.
 func (i *UserInfo) CanAddOthersToExam(examInfo *ExamInfo) bool {
 	if i == nil || i.Role == appValues.UserRoleUnknown {
 		// looks like an uninitialized user to me, just in case
@@ -217,7 +241,8 @@ func (i *UserInfo) CanAddOthersToExam(examInfo *ExamInfo) bool {
 	}
 
 	return i.Role == appValues.UserRoleOwner ||
-		i.Role == appValues.UserRoleAdmin
+		i.Role == appValues.UserRoleAdmin ||
+		i.getExamCollaborator(examInfo).CanEdit()
 }
 
 // CanGetExamInfo returns true if and only if the current user has
@@ -241,7 +266,8 @@ func (i *UserInfo) CanEditExamQuestion(examInfo *ExamInfo) bool {
 	}
 
 	return i.Role == appValues.UserRoleOwner ||
-		i.Role == appValues.UserRoleAdmin
+		i.Role == appValues.UserRoleAdmin ||
+		i.getExamCollaborator(examInfo).CanEdit()
 }
 
 // CanCreateExamQuestion returns true if and only if the current user has
@@ -257,7 +283,8 @@ func (i *UserInfo) CanCreateExamQuestion(examInfo *ExamInfo) bool {
 	}
 
 	return i.Role == appValues.UserRoleOwner ||
-		i.Role == appValues.UserRoleAdmin
+		i.Role == appValues.UserRoleAdmin ||
+		i.getExamCollaborator(examInfo).CanEdit()
 }
 
 // CanGetExamParticipants returns true if and only if the current user has
@@ -285,16 +312,29 @@ func (i *UserInfo) CanGetExamQuestions() bool {
 // the permission to peek at the questions of an exam.
 // *peeking* here means getting exam questions without participating in
 // the itself exam.
-func (i *UserInfo) CanPeekExamQuestions(createdBy string) bool {
+func (i *UserInfo) CanPeekExamQuestions(examInfo *ExamInfo) bool {
 	if i == nil || i.Role == appValues.UserRoleUnknown {
 		// looks like an uninitialized user to me, just in case
 		return false
 	}
 
-	if i.UserId == createdBy {
+	if i.UserId == examInfo.CreatedBy {
 		return true
 	}
 
+	return i.CanPeekAllExamQuestions() ||
+		i.getExamCollaborator(examInfo).CanView()
+}
+
+// CanPeekAllExamQuestions returns true if and only if the current user has
+// the permission to peek the questions of all exams, regardless of who
+// has created them.
+func (i *UserInfo) CanPeekAllExamQuestions() bool {
+	if i == nil || i.Role == appValues.UserRoleUnknown {
+		// looks like an uninitialized user to me, just in case
+		return false
+	}
+
 	return i.Role == appValues.UserRoleOwner ||
 		i.Role == appValues.UserRoleAdmin
 }
@@ -327,9 +367,22 @@ func (i *UserInfo) CanForceScoreExam() bool {
 
 // CanSetScoreForExam returns true if and only if the current user has
 // the permission to set score for an exam.
+// Besides the creator of the exam and the admins/owners, only the teachers
+// who are collaborating on the exam as graders (or editors) can score it.
 func (i *UserInfo) CanSetScoreForExam(examInfo *ExamInfo) bool {
-	return i.UserId == examInfo.CreatedBy ||
-		i.CanForceScoreExam()
+	if i.UserId == examInfo.CreatedBy || i.IsAdminOrOwner() {
+		return true
+	}
+
+	return i.CanForceScoreExam() &&
+		i.getExamCollaborator(examInfo).CanGrade()
+}
+
+// CanExportExamResults returns true if and only if the current user has
+// the permission to export the participants of an exam and their scores:
+// its creator, its grading collaborators and the admins/owners.
+func (i *UserInfo) CanExportExamResults(examInfo *ExamInfo) bool {
+	return i.IsAdminOrOwner() || i.isExamCreatorOrGrader(examInfo)
 }
 
 // CanTryToEditExam returns true if and only if the current user has
@@ -352,10 +405,190 @@ func (i *UserInfo) CanEditExam(targetExam *ExamInfo) bool {
 		return true
 	}
 
+	return i.Role == appValues.UserRoleOwner ||
+		i.Role == appValues.UserRoleAdmin ||
+		i.getExamCollaborator(targetExam).CanEdit()
+}
+
+// CanResetAttemptBinding returns true if and only if the current user has
+// the permission to reset the client binding of the attempts of the
+// specified exam (so a student can legitimately switch their device).
+func (i *UserInfo) CanResetAttemptBinding(examInfo *ExamInfo) bool {
+	if i == nil || i.Role == appValues.UserRoleUnknown {
+		// looks like an uninitialized user to me, just in case
+		return false
+	}
+
+	if i.UserId == examInfo.CreatedBy {
+		return true
+	}
+
+	return i.Role == appValues.UserRoleOwner ||
+		i.Role == appValues.UserRoleAdmin ||
+		i.getExamCollaborator(examInfo).CanGrade()
+}
+
+// CanSetExamAccommodation returns true if and only if the current user has
+// the permission to grant accommodations (e.g. extra time) to the
+// participants of the specified exam.
+func (i *UserInfo) CanSetExamAccommodation(examInfo *ExamInfo) bool {
+	if i == nil || i.Role == appValues.UserRoleUnknown {
+		// looks like an uninitialized user to me, just in case
+		return false
+	}
+
+	if i.UserId == examInfo.CreatedBy {
+		return true
+	}
+
+	return i.Role == appValues.UserRoleOwner ||
+		i.Role == appValues.UserRoleAdmin ||
+		i.getExamCollaborator(examInfo).CanEdit()
+}
+
+// CanManageWallets returns true if and only if the current user has
+// the permission to top up the wallets and refund the transactions.
+func (i *UserInfo) CanManageWallets() bool {
+	if i == nil || i.Role == appValues.UserRoleUnknown {
+		// looks like an uninitialized user to me, just in case
+		return false
+	}
+
 	return i.Role == appValues.UserRoleOwner ||
 		i.Role == appValues.UserRoleAdmin
 }
 
+// CanGetWallet returns true if and only if the current user has
+// the permission to get the wallet (and the transactions) of the
+// specified user.
+func (i *UserInfo) CanGetWallet(targetUserId string) bool {
+	if i == nil || i.Role == appValues.UserRoleUnknown {
+		// looks like an uninitialized user to me, just in case
+		return false
+	}
+
+	return i.UserId == targetUserId || i.CanManageWallets()
+}
+
+// CanTryToCreateExamCoupon returns true if and only if the current user
+// might be able to create coupons (for some scopes).
+// Owners, admins, and teachers can create coupons.
+func (i *UserInfo) CanTryToCreateExamCoupon() bool {
+	return i != nil && (i.Role == appValues.UserRoleOwner ||
+		i.Role == appValues.UserRoleAdmin ||
+		i.Role == appValues.UserRoleTeacher)
+}
+
+// CanCreateExamCoupon returns true if and only if the current user has
+// the permission to create a coupon for the specified scope; scopeOwner
+// is the creator of the exam (or course) the coupon is scoped to.
+// Teachers can only create coupons for their own exams and courses.
+func (i *UserInfo) CanCreateExamCoupon(scopeType, scopeOwner string) bool {
+	if !i.CanTryToCreateExamCoupon() {
+		return false
+	}
+
+	if i.Role == appValues.UserRoleOwner || i.Role == appValues.UserRoleAdmin {
+		return true
+	}
+
+	return scopeType != CouponScopeTopic && i.UserId == scopeOwner
+}
+
+// CanEditExamCoupon returns true if and only if the current user has
+// the permission to see the details of (and deactivate) the coupon.
+func (i *UserInfo) CanEditExamCoupon(coupon *ExamCoupon) bool {
+	if i == nil || i.Role == appValues.UserRoleUnknown {
+		// looks like an uninitialized user to me, just in case
+		return false
+	}
+
+	if i.UserId == coupon.CreatedBy {
+		return true
+	}
+
+	return i.Role == appValues.UserRoleOwner ||
+		i.Role == appValues.UserRoleAdmin
+}
+
+// CanSetExamReviewer returns true if and only if the current user has
+// the permission to choose which teachers can review the exams.
+func (i *UserInfo) CanSetExamReviewer() bool {
+	return i.IsAdminOrOwner()
+}
+
+// CanReviewExams returns true if and only if the current user has
+// the permission to review (and approve) the exams.
+// Owners and admins can review exams; teachers can if and only if they
+// have been marked as exam reviewers.
+func (i *UserInfo) CanReviewExams() bool {
+	if i == nil || i.Role == appValues.UserRoleUnknown {
+		// looks like an uninitialized user to me, just in case
+		return false
+	}
+
+	if i.Role == appValues.UserRoleTeacher {
+		return i.IsExamReviewer
+	}
+
+	return i.Role == appValues.UserRoleOwner ||
+		i.Role == appValues.UserRoleAdmin
+}
+
+// CanReviewExam returns true if and only if the current user has
+// the permission to review (and approve) the specified exam.
+// Reviewer teachers cannot approve their own exams (nor the ones they're
+// editing).
+func (i *UserInfo) CanReviewExam(examInfo *ExamInfo) bool {
+	if !i.CanReviewExams() {
+		return false
+	} else if i.IsAdminOrOwner() {
+		return true
+	}
+
+	return i.UserId != examInfo.CreatedBy &&
+		!i.getExamCollaborator(examInfo).CanEdit()
+}
+
+// CanSeeUnpublishedExam returns true if and only if the current user has
+// the permission to see the specified exam before it's published.
+func (i *UserInfo) CanSeeUnpublishedExam(examInfo *ExamInfo) bool {
+	return i.CanEditExam(examInfo) || i.CanReviewExams() ||
+		i.getExamCollaborator(examInfo).CanView()
+}
+
+// CanManageExamCollaborators returns true if and only if the current user
+// has the permission to add (and remove) the collaborators of the exam.
+// Only the creator of the exam, owners and admins can; the editors cannot.
+func (i *UserInfo) CanManageExamCollaborators(examInfo *ExamInfo) bool {
+	if i == nil || i.Role == appValues.UserRoleUnknown {
+		// looks like an uninitialized user to me, just in case
+		return false
+	}
+
+	return i.UserId == examInfo.CreatedBy || i.IsAdminOrOwner()
+}
+
+// CanPreviewExam returns true if and only if the current user has the
+// permission to take sandboxed preview attempts in the specified exam:
+// the ones who can peek its questions, and its reviewers.
+func (i *UserInfo) CanPreviewExam(examInfo *ExamInfo) bool {
+	return i.CanPeekExamQuestions(examInfo) || i.CanReviewExam(examInfo)
+}
+
+// getExamCollaborator returns the current user as a collaborator of the
+// exam, or nil if they're not one.
+func (i *UserInfo) getExamCollaborator(examInfo *ExamInfo) *ExamCollaborator {
+	return GetExamCollaboratorOrNil(i.UserId, examInfo.ExamId)
+}
+
+// isExamCreatorOrGrader returns true if the current user has created the
+// exam, or is collaborating on it as a grader (or an editor).
+func (i *UserInfo) isExamCreatorOrGrader(examInfo *ExamInfo) bool {
+	return i != nil && (i.UserId == examInfo.CreatedBy ||
+		i.getExamCollaborator(examInfo).CanGrade())
+}
+
 //---------------------------------------------------------
 
 func (d *UpdateUserData) IsEmpty() bool {
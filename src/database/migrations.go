@@ -48,3 +48,263 @@ func migrateV4(tx pgx.Tx, container *DatabaseContainer) error {
 
 	return nil
 }
+
+func migrateV5(tx pgx.Tx, container *DatabaseContainer) error {
+	_, err := tx.Exec(context.Background(),
+		dbScripts.Migration5Str)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func migrateV6(tx pgx.Tx, container *DatabaseContainer) error {
+	_, err := tx.Exec(context.Background(),
+		dbScripts.Migration6Str)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func migrateV7(tx pgx.Tx, container *DatabaseContainer) error {
+	_, err := tx.Exec(context.Background(),
+		dbScripts.Migration7Str)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func migrateV8(tx pgx.Tx, container *DatabaseContainer) error {
+	_, err := tx.Exec(context.Background(),
+		dbScripts.Migration8Str)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func migrateV9(tx pgx.Tx, container *DatabaseContainer) error {
+	_, err := tx.Exec(context.Background(),
+		dbScripts.Migration9Str)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func migrateV10(tx pgx.Tx, container *DatabaseContainer) error {
+	_, err := tx.Exec(context.Background(),
+		dbScripts.Migration10Str)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func migrateV11(tx pgx.Tx, container *DatabaseContainer) error {
+	_, err := tx.Exec(context.Background(),
+		dbScripts.Migration11Str)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func migrateV12(tx pgx.Tx, container *DatabaseContainer) error {
+	_, err := tx.Exec(context.Background(),
+		dbScripts.Migration12Str)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func migrateV13(tx pgx.Tx, container *DatabaseContainer) error {
+	_, err := tx.Exec(context.Background(),
+		dbScripts.Migration13Str)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func migrateV14(tx pgx.Tx, container *DatabaseContainer) error {
+	_, err := tx.Exec(context.Background(),
+		dbScripts.Migration14Str)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func migrateV15(tx pgx.Tx, container *DatabaseContainer) error {
+	_, err := tx.Exec(context.Background(),
+		dbScripts.Migration15Str)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func migrateV16(tx pgx.Tx, container *DatabaseContainer) error {
+	_, err := tx.Exec(context.Background(),
+		dbScripts.Migration16Str)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func migrateV17(tx pgx.Tx, container *DatabaseContainer) error {
+	_, err := tx.Exec(context.Background(),
+		dbScripts.Migration17Str)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func migrateV18(tx pgx.Tx, container *DatabaseContainer) error {
+	_, err := tx.Exec(context.Background(),
+		dbScripts.Migration18Str)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func migrateV19(tx pgx.Tx, container *DatabaseContainer) error {
+	_, err := tx.Exec(context.Background(),
+		dbScripts.Migration19Str)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func migrateV20(tx pgx.Tx, container *DatabaseContainer) error {
+	_, err := tx.Exec(context.Background(),
+		dbScripts.Migration20Str)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func migrateV21(tx pgx.Tx, container *DatabaseContainer) error {
+	_, err := tx.Exec(context.Background(),
+		dbScripts.Migration21Str)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func migrateV22(tx pgx.Tx, container *DatabaseContainer) error {
+	_, err := tx.Exec(context.Background(),
+		dbScripts.Migration22Str)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func migrateV23(tx pgx.Tx, container *DatabaseContainer) error {
+	_, err := tx.Exec(context.Background(),
+		dbScripts.Migration23Str)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func migrateV24(tx pgx.Tx, container *DatabaseContainer) error {
+	_, err := tx.Exec(context.Background(),
+		dbScripts.Migration24Str)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func migrateV25(tx pgx.Tx, container *DatabaseContainer) error {
+	_, err := tx.Exec(context.Background(),
+		dbScripts.Migration25Str)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func migrateV26(tx pgx.Tx, container *DatabaseContainer) error {
+	_, err := tx.Exec(context.Background(),
+		dbScripts.Migration26Str)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func migrateV27(tx pgx.Tx, container *DatabaseContainer) error {
+	_, err := tx.Exec(context.Background(),
+		dbScripts.Migration27Str)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func migrateV28(tx pgx.Tx, container *DatabaseContainer) error {
+	_, err := tx.Exec(context.Background(),
+		dbScripts.Migration28Str)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func migrateV29(tx pgx.Tx, container *DatabaseContainer) error {
+	_, err := tx.Exec(context.Background(),
+		dbScripts.Migration29Str)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func migrateV30(tx pgx.Tx, container *DatabaseContainer) error {
+	_, err := tx.Exec(context.Background(),
+		dbScripts.Migration30Str)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,31 @@
+package database
+
+import "time"
+
+// ExamAccommodation is a struct that represents the per-user overrides of
+// the timing of an exam (e.g. extra time for students with documented
+// accommodations).
+type ExamAccommodation struct {
+	ExamId         int        `json:"exam_id"`
+	UserId         string     `json:"user_id"`
+	ExtraMinutes   int        `json:"extra_minutes"`
+	TimeMultiplier float64    `json:"time_multiplier"`
+	CustomStart    *time.Time `json:"custom_start"`
+	CustomEnd      *time.Time `json:"custom_end"`
+	Note           *string    `json:"note"`
+	GrantedBy      *string    `json:"granted_by"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// SetExamAccommodationData is a struct that represents the data needed to
+// grant (or update) the accommodation of a user in an exam.
+type SetExamAccommodationData struct {
+	ExamId         int        `json:"exam_id"`
+	UserId         string     `json:"user_id"`
+	ExtraMinutes   int        `json:"extra_minutes"`
+	TimeMultiplier float64    `json:"time_multiplier"`
+	CustomStart    *time.Time `json:"custom_start"`
+	CustomEnd      *time.Time `json:"custom_end"`
+	Note           *string    `json:"note"`
+	GrantedBy      string     `json:"granted_by"`
+}
@@ -0,0 +1,50 @@
+package database
+
+import "time"
+
+type SetExamAdaptiveSettingsData struct {
+	ExamId         int      `json:"exam_id"`
+	IsAdaptive     bool     `json:"is_adaptive"`
+	MaxItems       *int     `json:"max_items"`
+	TargetStdError *float64 `json:"target_se"`
+}
+
+type SetExamQuestionIrtData struct {
+	ExamId         int     `json:"exam_id"`
+	QuestionId     int     `json:"question_id"`
+	CorrectOption  *string `json:"correct_option"`
+	Discrimination float64 `json:"discrimination"`
+	Difficulty     float64 `json:"difficulty"`
+	Guessing       float64 `json:"guessing"`
+}
+
+// ExamAttemptItem is a struct that represents a question given to an
+// adaptive attempt.
+type ExamAttemptItem struct {
+	ExamId        int       `json:"exam_id"`
+	UserId        string    `json:"user_id"`
+	AttemptNumber int       `json:"attempt_number"`
+	ItemIndex     int       `json:"item_index"`
+	QuestionId    int       `json:"question_id"`
+	ServedAt      time.Time `json:"served_at"`
+
+	// IsCorrect is nil while the question has not been answered.
+	IsCorrect  *bool      `json:"is_correct"`
+	AnsweredAt *time.Time `json:"answered_at"`
+}
+
+// AdaptiveStep is a struct that represents the state of an adaptive
+// attempt after asking for its next question.
+type AdaptiveStep struct {
+	// Item is the question the user has to answer now; nil if the
+	// attempt has ended.
+	Item     *ExamAttemptItem
+	Question *ExamQuestion
+
+	// AnsweredCount is the number of questions answered in the attempt.
+	AnsweredCount int
+
+	// IsFinished is true if the attempt has ended, either because the
+	// estimate is precise enough, or no more questions can be given.
+	IsFinished bool
+}
@@ -0,0 +1,57 @@
+package database
+
+import "time"
+
+// Attachment is a struct that represents a file attached to a question, to
+// an option of a question, or uploaded by a user as their answer.
+type Attachment struct {
+	AttachmentId int `json:"attachment_id"`
+
+	// AttachmentType is one of the AttachmentType* constants.
+	AttachmentType string `json:"attachment_type"`
+	ExamId         int    `json:"exam_id"`
+	QuestionId     int    `json:"question_id"`
+
+	// OptionNumber is the number (1-4) of the option the file is attached
+	// to; only set for the option attachments.
+	OptionNumber *int `json:"option_number"`
+
+	// AnsweredBy and AttemptNumber are only set for the answer attachments.
+	AnsweredBy    *string `json:"answered_by"`
+	AttemptNumber *int    `json:"attempt_number"`
+
+	StorageBackend string    `json:"-"`
+	StorageKey     string    `json:"-"`
+	FileName       string    `json:"file_name"`
+	ContentType    string    `json:"content_type"`
+	FileSize       int64     `json:"file_size"`
+	UploadedBy     *string   `json:"uploaded_by"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type NewAttachmentData struct {
+	AttachmentType string  `json:"attachment_type"`
+	ExamId         int     `json:"exam_id"`
+	QuestionId     int     `json:"question_id"`
+	OptionNumber   *int    `json:"option_number"`
+	AnsweredBy     *string `json:"answered_by"`
+	AttemptNumber  *int    `json:"attempt_number"`
+	StorageBackend string  `json:"storage_backend"`
+	StorageKey     string  `json:"storage_key"`
+	FileName       string  `json:"file_name"`
+	ContentType    string  `json:"content_type"`
+	FileSize       int64   `json:"file_size"`
+	UploadedBy     string  `json:"uploaded_by"`
+}
+
+// GetQuestionsAttachmentsOptions is a struct that represents the options
+// for getting the attachments of the questions.
+type GetQuestionsAttachmentsOptions struct {
+	QuestionIds []int `json:"question_ids"`
+
+	// AnsweredBy and AttemptNumber select the answer attachments to be
+	// returned alongside the attachments of the questions; no answer
+	// attachments are returned if AnsweredBy is empty.
+	AnsweredBy    string `json:"answered_by"`
+	AttemptNumber int    `json:"attempt_number"`
+}
@@ -0,0 +1,31 @@
+package database
+
+// SetExamAttemptReviewPolicyData is a struct that represents the data
+// needed to set when the students can review their attempts in an exam.
+type SetExamAttemptReviewPolicyData struct {
+	ExamId              int    `json:"exam_id"`
+	AttemptReviewPolicy string `json:"attempt_review_policy"`
+}
+
+// SetExamResultsReleasedData is a struct that represents the data needed
+// to release (or withdraw) the results of an exam.
+type SetExamResultsReleasedData struct {
+	ExamId     int    `json:"exam_id"`
+	IsReleased bool   `json:"is_released"`
+	ReleasedBy string `json:"released_by"`
+}
+
+// GradeGivenAnswerData is a struct that represents the data needed to
+// grade an answer given in an attempt.
+type GradeGivenAnswerData struct {
+	ExamId        int    `json:"exam_id"`
+	QuestionId    int    `json:"question_id"`
+	AnsweredBy    string `json:"answered_by"`
+	AttemptNumber int    `json:"attempt_number"`
+
+	// PointsEarned is the number of points given to the answer; nil means
+	// the points are calculated by the answer key of the question.
+	PointsEarned  *float64 `json:"points_earned"`
+	GraderComment *string  `json:"grader_comment"`
+	GradedBy      string   `json:"graded_by"`
+}
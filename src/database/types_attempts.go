@@ -0,0 +1,50 @@
+package database
+
+import "time"
+
+// ExamAttemptBinding is a struct that represents the client that
+// an exam attempt of a user is bound to.
+type ExamAttemptBinding struct {
+	ExamId             int        `json:"exam_id"`
+	UserId             string     `json:"user_id"`
+	IPAddress          string     `json:"ip_address"`
+	DeviceId           string     `json:"device_id"`
+	BoundAt            time.Time  `json:"bound_at"`
+	MismatchCount      int        `json:"mismatch_count"`
+	LastMismatchIP     *string    `json:"last_mismatch_ip"`
+	LastMismatchDevice *string    `json:"last_mismatch_device"`
+	LastMismatchAt     *time.Time `json:"last_mismatch_at"`
+
+	// LastIPAddress is the last ip address of the bound client, if it has
+	// changed since the attempt was bound to it (IPAddress); the changes are
+	// only recorded, the attempt stays bound to the device id.
+	LastIPAddress  *string    `json:"last_ip_address"`
+	IPChangeCount  int        `json:"ip_change_count"`
+	LastIPChangeAt *time.Time `json:"last_ip_change_at"`
+}
+
+// ExamAttempt is a struct that represents a single attempt of a user
+// in an exam. Each attempt keeps its own answers and score.
+type ExamAttempt struct {
+	ExamId        int        `json:"exam_id"`
+	UserId        string     `json:"user_id"`
+	AttemptNumber int        `json:"attempt_number"`
+	StartedAt     time.Time  `json:"started_at"`
+	FinishedAt    *time.Time `json:"finished_at"`
+	FinalScore    *string    `json:"final_score"`
+	ScoredBy      *string    `json:"scored_by"`
+
+	// AbilityEstimate and AbilityStdError are the estimated ability of the
+	// user (and its standard error) in an adaptive attempt.
+	AbilityEstimate *float64 `json:"ability_estimate"`
+	AbilityStdError *float64 `json:"ability_std_error"`
+}
+
+// ExamAttemptClientData is a struct that represents the data of the
+// client that is sending a request for an exam attempt.
+type ExamAttemptClientData struct {
+	ExamId    int    `json:"exam_id"`
+	UserId    string `json:"user_id"`
+	IPAddress string `json:"ip_address"`
+	DeviceId  string `json:"device_id"`
+}
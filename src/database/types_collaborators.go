@@ -0,0 +1,25 @@
+package database
+
+import "time"
+
+// ExamCollaborator is a struct that represents a teacher who co-owns an
+// exam with its creator.
+type ExamCollaborator struct {
+	ExamId int    `json:"exam_id"`
+	UserId string `json:"user_id"`
+
+	// CollaboratorRole is one of the CollaboratorRole* constants.
+	CollaboratorRole string    `json:"collaborator_role"`
+	AddedBy          *string   `json:"added_by"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// SetExamCollaboratorData is a struct that represents the data needed to
+// add a collaborator to an exam (or to change their role).
+type SetExamCollaboratorData struct {
+	ExamId           int     `json:"exam_id"`
+	UserId           string  `json:"user_id"`
+	CollaboratorRole string  `json:"collaborator_role"`
+	AddedBy          *string `json:"added_by"`
+}
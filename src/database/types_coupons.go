@@ -0,0 +1,48 @@
+package database
+
+import "time"
+
+// ExamCoupon is a struct that represents a discount coupon of the
+// exam prices.
+type ExamCoupon struct {
+	CouponId   int    `json:"coupon_id"`
+	CouponCode string `json:"coupon_code"`
+
+	// DiscountType is either "percentage" or "fixed".
+	DiscountType string `json:"discount_type"`
+
+	// DiscountValue is the percentage (1-100) or the fixed amount (in
+	// minor units) of the discount.
+	DiscountValue  int64      `json:"discount_value"`
+	ScopeType      string     `json:"scope_type"`
+	ScopeId        int        `json:"scope_id"`
+	MaxUses        *int       `json:"max_uses"`
+	MaxUsesPerUser *int       `json:"max_uses_per_user"`
+	ExpiresAt      *time.Time `json:"expires_at"`
+	IsActive       bool       `json:"is_active"`
+	CreatedBy      string     `json:"created_by"`
+	CreatedAt      time.Time  `json:"created_at"`
+
+	// UsesCount is the number of times the coupon has been used.
+	UsesCount int `json:"uses_count"`
+}
+
+type NewExamCouponData struct {
+	CouponCode     string     `json:"coupon_code"`
+	DiscountType   string     `json:"discount_type"`
+	DiscountValue  int64      `json:"discount_value"`
+	ScopeType      string     `json:"scope_type"`
+	ScopeId        int        `json:"scope_id"`
+	MaxUses        *int       `json:"max_uses"`
+	MaxUsesPerUser *int       `json:"max_uses_per_user"`
+	ExpiresAt      *time.Time `json:"expires_at"`
+	CreatedBy      string     `json:"created_by"`
+}
+
+type GetExamCouponsData struct {
+	// CreatedBy filters the coupons by their creator; all coupons are
+	// returned if it's empty.
+	CreatedBy string `json:"created_by"`
+	Offset    int    `json:"offset"`
+	Limit     int    `json:"limit"`
+}
@@ -16,6 +16,95 @@ type ExamInfo struct {
 	Duration        int       `json:"duration"`
 	CreatedBy       string    `json:"created_by"`
 	IsPublic        bool      `json:"is_public"`
+
+	// AccessCodeType is the type of the access code of the exam.
+	// It can be one of "none", "static" or "rotating".
+	AccessCodeType string `json:"access_code_type"`
+
+	// AccessCode is the static access code of the exam, or the secret used to
+	// generate the rotating access codes.
+	AccessCode *string `json:"-"`
+
+	// AccessCodeInterval is the interval (in minutes) in which a rotating
+	// access code changes.
+	AccessCodeInterval int `json:"access_code_interval"`
+
+	// MaxAttempts is the maximum number of attempts a user can have in
+	// the exam; 0 means unlimited.
+	MaxAttempts int `json:"max_attempts"`
+
+	// AttemptCooldown is the time (in minutes) a user has to wait after
+	// finishing an attempt before starting the next one.
+	AttemptCooldown int `json:"attempt_cooldown"`
+
+	// GradingPolicy decides how the final score is calculated from the
+	// attempts. It can be one of "best", "latest" or "average".
+	GradingPolicy string `json:"grading_policy"`
+
+	// OpensAt is when the window of the exam opens; ExamDate is used
+	// if it's not set.
+	OpensAt *time.Time `json:"opens_at"`
+
+	// ClosesAt is when the window of the exam closes. If it's not set,
+	// the exam is a fixed session running from ExamDate for Duration minutes.
+	ClosesAt *time.Time `json:"closes_at"`
+
+	// LateStartLimit is the time (in minutes) after the opening in which the
+	// users are still allowed to start the exam; nil means no limit.
+	LateStartLimit *int `json:"late_start_limit"`
+
+	// DeadlinePolicy decides what happens when the deadline of a user is
+	// reached. It can be one of "auto_submit" or "grace_period".
+	DeadlinePolicy string `json:"deadline_policy"`
+
+	// GracePeriod is the time (in minutes) after the deadline in which the
+	// answers are still accepted (grace_period policy only).
+	GracePeriod int `json:"grace_period"`
+
+	// Capacity is the maximum number of participants of the exam;
+	// nil means unlimited.
+	Capacity *int `json:"capacity"`
+
+	// IsAdaptive is true if the questions of the exam are given one at a
+	// time, picked by the previous answers of the user.
+	IsAdaptive bool `json:"is_adaptive"`
+
+	// AdaptiveMaxItems is the maximum number of questions given in an
+	// adaptive attempt; nil means no limit.
+	AdaptiveMaxItems *int `json:"adaptive_max_items"`
+
+	// AdaptiveTargetStdError is the standard error of the ability estimate
+	// at which an adaptive attempt ends; nil means no target.
+	AdaptiveTargetStdError *float64 `json:"adaptive_target_se"`
+
+	// ReviewStatus is the review status of the exam; it can be one of
+	// "draft", "in_review" or "published". Only the published exams are
+	// visible to (and joinable by) the students.
+	ReviewStatus string `json:"review_status"`
+
+	// SubmittedAt is when the exam was last submitted for review.
+	SubmittedAt *time.Time `json:"submitted_at"`
+
+	// ReviewedBy and ReviewedAt are who and when has last reviewed the exam.
+	ReviewedBy *string    `json:"reviewed_by"`
+	ReviewedAt *time.Time `json:"reviewed_at"`
+
+	// IsPractice is true if the exam is a practice exam: its attempts are
+	// unlimited, its answers are checked right away and it's not graded.
+	IsPractice bool `json:"is_practice"`
+
+	// AttemptReviewPolicy decides when the students can review their
+	// attempts; it can be one of the AttemptReviewPolicy* constants.
+	AttemptReviewPolicy string `json:"attempt_review_policy"`
+
+	// ResultsReleasedBy and ResultsReleasedAt are who and when has released
+	// the results of the exam; nil if they are not released (yet).
+	ResultsReleasedBy *string    `json:"results_released_by"`
+	ResultsReleasedAt *time.Time `json:"results_released_at"`
+
+	// GradeCategoryId is the grade category of the course the exam is
+	// counted in; nil means it's not counted in the course grade.
+	GradeCategoryId *int `json:"grade_category_id"`
 }
 
 // SearchExamsData is a struct that represents the data needed to search for exams.
@@ -24,6 +113,12 @@ type SearchExamsData struct {
 	Offset      int    `json:"offset"`
 	Limit       int    `json:"limit"`
 	PublicOnly  bool   `json:"public_only"`
+
+	// IncludeUnpublished is true if the exams which haven't been published
+	// yet should be found too; otherwise only the ones created by UserId
+	// (or which they're collaborating on) are.
+	IncludeUnpublished bool   `json:"include_unpublished"`
+	UserId             string `json:"user_id"`
 }
 
 type SearchExamResult struct {
@@ -47,6 +142,13 @@ type GetExamQuestionsData struct {
 	ExamId int `json:"exam_id"`
 	Offset int `json:"offset"`
 	Limit  int `json:"limit"`
+
+	// FilterSections is true if only the questions of SectionIds (and the
+	// questions without a section if IncludeUnsectioned is true) should
+	// be returned.
+	FilterSections     bool  `json:"filter_sections"`
+	SectionIds         []int `json:"section_ids"`
+	IncludeUnsectioned bool  `json:"include_unsectioned"`
 }
 
 // NewExamData is a struct that represents the data needed to create a new exam.
@@ -82,7 +184,36 @@ type ExamQuestion struct {
 	Option2       *string   `json:"option2"`
 	Option3       *string   `json:"option3"`
 	Option4       *string   `json:"option4"`
+	SectionId     *int      `json:"section_id"`
+	QuestionOrder int       `json:"question_order"`
+	ContentFormat string    `json:"content_format"`
 	CreatedAt     time.Time `json:"created_at"`
+
+	// CorrectOption is the answer key of the question; nil means the
+	// question has no answer key (and cannot be given in adaptive exams).
+	CorrectOption *string `json:"-"`
+
+	// IrtDiscrimination, IrtDifficulty and IrtGuessing are the parameters
+	// of the question in the three-parameter logistic model.
+	IrtDiscrimination float64 `json:"irt_discrimination"`
+	IrtDifficulty     float64 `json:"irt_difficulty"`
+	IrtGuessing       float64 `json:"irt_guessing"`
+
+	// Tags, DifficultyLevel (one of the QuestionDifficulty* constants),
+	// LearningObjectives and TopicId are the metadata of the question used
+	// to search for it.
+	Tags               []string `json:"tags"`
+	DifficultyLevel    *string  `json:"difficulty_level"`
+	LearningObjectives []string `json:"learning_objectives"`
+	TopicId            *int     `json:"topic_id"`
+
+	// Explanation is the explanation of the answer of the question written
+	// by the teacher, shown to the students in the practice exams right
+	// after they have answered it.
+	Explanation *string `json:"explanation"`
+
+	// Points is the maximum number of points the question is worth.
+	Points float64 `json:"points"`
 }
 
 // NewExamQuestionData is a struct that represents the data needed to create a new exam question.
@@ -94,6 +225,14 @@ type NewExamQuestionData struct {
 	Option2       *string `json:"option2"`
 	Option3       *string `json:"option3"`
 	Option4       *string `json:"option4"`
+	SectionId     *int    `json:"section_id"`
+	QuestionOrder int     `json:"question_order"`
+	ContentFormat string  `json:"content_format"`
+	Explanation   *string `json:"explanation"`
+
+	// Points is the maximum number of points of the question; nil means
+	// the default (or, when editing, unchanged) points.
+	Points *float64 `json:"points"`
 }
 
 // EditExamQuestionData is a struct that represents the data needed to edit an exam question.
@@ -106,6 +245,50 @@ type EditExamQuestionData struct {
 	Option2       *string `json:"option2"`
 	Option3       *string `json:"option3"`
 	Option4       *string `json:"option4"`
+	SectionId     *int    `json:"section_id"`
+	QuestionOrder int     `json:"question_order"`
+	ContentFormat string  `json:"content_format"`
+	Explanation   *string `json:"explanation"`
+
+	// Points is the maximum number of points of the question; nil means
+	// the default (or, when editing, unchanged) points.
+	Points *float64 `json:"points"`
+}
+
+// SetExamAccessCodeData is a struct that represents the data needed to
+// set the access code settings of an exam.
+type SetExamAccessCodeData struct {
+	ExamId             int     `json:"exam_id"`
+	AccessCodeType     string  `json:"access_code_type"`
+	AccessCode         *string `json:"access_code"`
+	AccessCodeInterval int     `json:"access_code_interval"`
+}
+
+// SetExamRetakePolicyData is a struct that represents the data needed to
+// set the retake policy of an exam.
+type SetExamRetakePolicyData struct {
+	ExamId          int    `json:"exam_id"`
+	MaxAttempts     int    `json:"max_attempts"`
+	AttemptCooldown int    `json:"attempt_cooldown"`
+	GradingPolicy   string `json:"grading_policy"`
+}
+
+// SetExamPracticeModeData is a struct that represents the data needed to
+// turn an exam into a practice exam (or back into a graded one).
+type SetExamPracticeModeData struct {
+	ExamId     int  `json:"exam_id"`
+	IsPractice bool `json:"is_practice"`
+}
+
+// SetExamAvailabilityData is a struct that represents the data needed to
+// set the availability window and the deadline policy of an exam.
+type SetExamAvailabilityData struct {
+	ExamId         int        `json:"exam_id"`
+	OpensAt        *time.Time `json:"opens_at"`
+	ClosesAt       *time.Time `json:"closes_at"`
+	LateStartLimit *int       `json:"late_start_limit"`
+	DeadlinePolicy string     `json:"deadline_policy"`
+	GracePeriod    int        `json:"grace_period"`
 }
 
 // NewScoreData is a struct that represents the data needed to create
@@ -115,6 +298,10 @@ type NewScoreData struct {
 	UserId     string `json:"user_id"`
 	FinalScore string `json:"final_score"`
 	ScoredBy   string `json:"scored_by"`
+
+	// AttemptNumber is the attempt that is being scored; 0 means
+	// the latest attempt.
+	AttemptNumber int `json:"attempt_number"`
 }
 
 // GivenExam is a struct that represents the information of an exam
@@ -131,6 +318,13 @@ type GivenExam struct {
 	ScoredBy   *string   `json:"scored_by"`
 	CreatedAt  time.Time `json:"created_at"`
 	FinalScore *string   `json:"final_score"`
+
+	// ClientMismatches is the number of times the participant tried to
+	// continue their attempt from another client, and ClientIPChanges the
+	// number of times the ip address of their client has changed; they're
+	// only loaded by GetExamParticipants.
+	ClientMismatches int `json:"client_mismatches"`
+	ClientIPChanges  int `json:"client_ip_changes"`
 }
 
 // NewGivenExamData is a struct that represents the data needed to
@@ -140,6 +334,11 @@ type NewGivenExamData struct {
 	ExamId  int     `json:"exam_id"`
 	Price   string  `json:"price"`
 	AddedBy *string `json:"added_by"`
+
+	// Coupon is the coupon used by the user (if any); Price has to be
+	// the discounted price.
+	Coupon   *ExamCoupon `json:"coupon"`
+	Discount int64       `json:"discount"`
 }
 
 type GetMostRecentExamsData struct {
@@ -161,28 +360,40 @@ type MostRecentExamInfo struct {
 }
 
 type GetGivenAnswerData struct {
-	ExamId     int    `json:"exam_id"`
-	QuestionId int    `json:"question_id"`
-	UserId     string `json:"user_id"`
+	ExamId        int    `json:"exam_id"`
+	QuestionId    int    `json:"question_id"`
+	UserId        string `json:"user_id"`
+	AttemptNumber int    `json:"attempt_number"`
 }
 
 type GivenAnswerInfo struct {
-	ExamId       int       `json:"exam_id"`
-	QuestionId   int       `json:"question_id"`
-	AnsweredBy   string    `json:"answered_by"`
-	ChosenOption *string   `json:"chosen_option"`
-	SecondsTaken int       `json:"seconds_taken"`
-	AnswerText   *string   `json:"answer_text"`
-	AnsweredAt   time.Time `json:"answered_at"`
+	ExamId        int       `json:"exam_id"`
+	QuestionId    int       `json:"question_id"`
+	AnsweredBy    string    `json:"answered_by"`
+	AttemptNumber int       `json:"attempt_number"`
+	ChosenOption  *string   `json:"chosen_option"`
+	SecondsTaken  int       `json:"seconds_taken"`
+	AnswerText    *string   `json:"answer_text"`
+	AnsweredAt    time.Time `json:"answered_at"`
+
+	// PointsEarned is the number of points a grader has given to the
+	// answer; nil if it hasn't been graded by hand.
+	PointsEarned *float64 `json:"points_earned"`
+
+	// GraderComment is the comment of the grader on the answer.
+	GraderComment *string    `json:"grader_comment"`
+	GradedBy      *string    `json:"graded_by"`
+	GradedAt      *time.Time `json:"graded_at"`
 }
 
 type AnswerQuestionData struct {
-	ExamId       int     `json:"exam_id"`
-	QuestionId   int     `json:"question_id"`
-	AnsweredBy   string  `json:"answered_by"`
-	ChosenOption *string `json:"chosen_option"`
-	SecondsTaken int     `json:"seconds_taken"`
-	AnswerText   *string `json:"answer_text"`
+	ExamId        int     `json:"exam_id"`
+	QuestionId    int     `json:"question_id"`
+	AnsweredBy    string  `json:"answered_by"`
+	AttemptNumber int     `json:"attempt_number"`
+	ChosenOption  *string `json:"chosen_option"`
+	SecondsTaken  int     `json:"seconds_taken"`
+	AnswerText    *string `json:"answer_text"`
 }
 
 type GetUserExamsHistoryOptions struct {
@@ -193,8 +404,11 @@ type GetUserExamsHistoryOptions struct {
 
 type UserOngoingExamInfo struct {
 	ExamId    int       `json:"exam_id"`
-	ExamTitle int       `json:"course_id"`
+	ExamTitle string    `json:"exam_title"`
 	StartTime time.Time `json:"start_time"`
+
+	// EndTime is the personal deadline of the user in the exam.
+	EndTime time.Time `json:"end_time"`
 }
 
 type UserPastExamInfo struct {
@@ -0,0 +1,24 @@
+package database
+
+import "time"
+
+// ExamParticipantExportRow is a struct that represents a participant of an
+// exam alongside their results, as they are exported.
+type ExamParticipantExportRow struct {
+	UserId   string    `json:"user_id"`
+	FullName string    `json:"full_name"`
+	Email    string    `json:"email"`
+	JoinedAt time.Time `json:"joined_at"`
+
+	// AttemptsCount is the number of the attempts the participant has
+	// started in the exam.
+	AttemptsCount int `json:"attempts_count"`
+
+	// LastFinishedAt is when the latest finished attempt of the participant
+	// was finished, or nil if they have not finished any attempts yet.
+	LastFinishedAt *time.Time `json:"last_finished_at"`
+
+	FinalScore      *string  `json:"final_score"`
+	ScorePercentage *float64 `json:"score_percentage"`
+	ScoredBy        *string  `json:"scored_by"`
+}
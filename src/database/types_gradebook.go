@@ -0,0 +1,56 @@
+package database
+
+import "time"
+
+// CourseGradeCategory is a struct that represents a weighted grade category
+// of a course (e.g. quizzes 20%).
+type CourseGradeCategory struct {
+	CategoryId   int     `json:"category_id"`
+	CourseId     int     `json:"course_id"`
+	CategoryName string  `json:"category_name"`
+	Weight       float64 `json:"weight"`
+
+	// DropLowest is the number of the lowest scores of the category which
+	// are not counted in the course grade.
+	DropLowest int       `json:"drop_lowest"`
+	AddedBy    *string   `json:"added_by"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// NewCourseGradeCategoryData is a struct that represents the data needed
+// to add a new grade category to a course.
+type NewCourseGradeCategoryData struct {
+	CourseId     int     `json:"course_id"`
+	CategoryName string  `json:"category_name"`
+	Weight       float64 `json:"weight"`
+	DropLowest   int     `json:"drop_lowest"`
+	AddedBy      string  `json:"added_by"`
+}
+
+// EditCourseGradeCategoryData is a struct that represents the data needed
+// to edit a grade category of a course.
+type EditCourseGradeCategoryData struct {
+	CategoryId   int     `json:"category_id"`
+	CategoryName string  `json:"category_name"`
+	Weight       float64 `json:"weight"`
+	DropLowest   int     `json:"drop_lowest"`
+}
+
+// SetExamGradeCategoryData is a struct that represents the data needed to
+// set the grade category an exam is counted in.
+type SetExamGradeCategoryData struct {
+	ExamId     int  `json:"exam_id"`
+	CategoryId *int `json:"category_id"`
+}
+
+// CourseGradebookScore is a struct that represents the score of a
+// participant in a graded exam of a course.
+type CourseGradebookScore struct {
+	UserId     string  `json:"user_id"`
+	ExamId     int     `json:"exam_id"`
+	FinalScore *string `json:"final_score"`
+
+	// ScorePercentage is nil if the exam has not been scored yet (or its
+	// score cannot be converted to a percentage).
+	ScorePercentage *float64 `json:"score_percentage"`
+}
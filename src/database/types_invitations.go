@@ -0,0 +1,28 @@
+package database
+
+import "time"
+
+// ExamInvitation is a struct that represents an invitation of a user (or an
+// email address without an account) to take part in an exam.
+type ExamInvitation struct {
+	InvitationId int    `json:"invitation_id"`
+	ExamId       int    `json:"exam_id"`
+	Email        string `json:"email"`
+
+	// UserId is the id of the invited user; it's nil if the invitation was
+	// sent to an email address without an account.
+	UserId     *string    `json:"user_id"`
+	Status     string     `json:"status"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	InvitedBy  string     `json:"invited_by"`
+	CreatedAt  time.Time  `json:"created_at"`
+	AcceptedAt *time.Time `json:"accepted_at"`
+}
+
+type NewExamInvitationData struct {
+	ExamId    int       `json:"exam_id"`
+	Email     string    `json:"email"`
+	UserId    *string   `json:"user_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+	InvitedBy string    `json:"invited_by"`
+}
@@ -0,0 +1,42 @@
+package database
+
+import "time"
+
+// ExamPrerequisite is a struct that represents a rule that a user has to
+// meet before being able to participate in an exam.
+type ExamPrerequisite struct {
+	PrerequisiteId   int       `json:"prerequisite_id"`
+	ExamId           int       `json:"exam_id"`
+	PrerequisiteType string    `json:"prerequisite_type"`
+	RequiredExamId   *int      `json:"required_exam_id"`
+	MinScore         *float64  `json:"min_score"`
+	TopicId          *int      `json:"topic_id"`
+	MinLevel         *int      `json:"min_level"`
+	AddedBy          string    `json:"added_by"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+// UnmetExamPrerequisite is a struct that represents a prerequisite of an
+// exam that a user has not met yet, alongside the current value of the user
+// (best score percentage in the required exam, or current level in the topic).
+type UnmetExamPrerequisite struct {
+	PrerequisiteId   int      `json:"prerequisite_id"`
+	PrerequisiteType string   `json:"prerequisite_type"`
+	RequiredExamId   *int     `json:"required_exam_id"`
+	MinScore         *float64 `json:"min_score"`
+	TopicId          *int     `json:"topic_id"`
+	MinLevel         *int     `json:"min_level"`
+	CurrentValue     *float64 `json:"current_value"`
+}
+
+// NewExamPrerequisiteData is a struct that represents the data needed to add
+// a new prerequisite to an exam.
+type NewExamPrerequisiteData struct {
+	ExamId           int      `json:"exam_id"`
+	PrerequisiteType string   `json:"prerequisite_type"`
+	RequiredExamId   *int     `json:"required_exam_id"`
+	MinScore         *float64 `json:"min_score"`
+	TopicId          *int     `json:"topic_id"`
+	MinLevel         *int     `json:"min_level"`
+	AddedBy          string   `json:"added_by"`
+}
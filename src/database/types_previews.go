@@ -0,0 +1,35 @@
+package database
+
+import "time"
+
+// ExamPreviewAttempt is a struct that represents a preview (test-drive)
+// attempt of a teacher in an exam. Previews are sandboxed: they have
+// nothing to do with the given exams (and attempts) of the exam.
+type ExamPreviewAttempt struct {
+	PreviewId  int        `json:"preview_id"`
+	ExamId     int        `json:"exam_id"`
+	UserId     string     `json:"user_id"`
+	StartedAt  time.Time  `json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at"`
+}
+
+// ExamPreviewAnswer is a struct that represents an answer given to a
+// question in a preview attempt.
+type ExamPreviewAnswer struct {
+	PreviewId    int       `json:"preview_id"`
+	QuestionId   int       `json:"question_id"`
+	ChosenOption *string   `json:"chosen_option"`
+	AnswerText   *string   `json:"answer_text"`
+	SecondsTaken int       `json:"seconds_taken"`
+	AnsweredAt   time.Time `json:"answered_at"`
+}
+
+// PreviewAnswerData is a struct that represents the data needed to answer
+// a question in a preview attempt.
+type PreviewAnswerData struct {
+	PreviewId    int     `json:"preview_id"`
+	QuestionId   int     `json:"question_id"`
+	ChosenOption *string `json:"chosen_option"`
+	AnswerText   *string `json:"answer_text"`
+	SecondsTaken int     `json:"seconds_taken"`
+}
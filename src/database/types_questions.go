@@ -0,0 +1,52 @@
+package database
+
+import "time"
+
+// SetExamQuestionMetadataData is a struct that represents the data needed
+// to set the metadata of an exam question.
+type SetExamQuestionMetadataData struct {
+	ExamId             int      `json:"exam_id"`
+	QuestionId         int      `json:"question_id"`
+	Tags               []string `json:"tags"`
+	DifficultyLevel    *string  `json:"difficulty_level"`
+	LearningObjectives []string `json:"learning_objectives"`
+	TopicId            *int     `json:"topic_id"`
+}
+
+// SearchExamQuestionsData is a struct that represents the data needed to
+// search for questions across the exams.
+type SearchExamQuestionsData struct {
+	// SearchQuery is matched against the title and the description of the
+	// questions (full-text); empty means any question.
+	SearchQuery string `json:"search_query"`
+
+	// Tags are the tags the questions must all have.
+	Tags              []string `json:"tags"`
+	DifficultyLevel   *string  `json:"difficulty_level"`
+	TopicId           *int     `json:"topic_id"`
+	LearningObjective string   `json:"learning_objective"`
+
+	// PeekableBy restricts the search to the questions of the exams
+	// created by the user (or which they're collaborating on); nil means
+	// the questions of all exams.
+	PeekableBy *string `json:"peekable_by"`
+
+	Offset int `json:"offset"`
+	Limit  int `json:"limit"`
+}
+
+// SearchedExamQuestion is a struct that represents a question found by
+// SearchExamQuestions.
+type SearchedExamQuestion struct {
+	QuestionId         int       `json:"question_id"`
+	ExamId             int       `json:"exam_id"`
+	ExamTitle          string    `json:"exam_title"`
+	QuestionTitle      string    `json:"question_title"`
+	Description        *string   `json:"description"`
+	ContentFormat      string    `json:"content_format"`
+	Tags               []string  `json:"tags"`
+	DifficultyLevel    *string   `json:"difficulty_level"`
+	LearningObjectives []string  `json:"learning_objectives"`
+	TopicId            *int      `json:"topic_id"`
+	CreatedAt          time.Time `json:"created_at"`
+}
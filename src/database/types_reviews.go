@@ -0,0 +1,38 @@
+package database
+
+import "time"
+
+// ExamReviewComment is a struct that represents a comment left on an exam
+// (or on one of its questions) while it's being reviewed.
+type ExamReviewComment struct {
+	CommentId int `json:"comment_id"`
+	ExamId    int `json:"exam_id"`
+
+	// QuestionId is the commented question; nil means the comment is on
+	// the whole exam.
+	QuestionId  *int       `json:"question_id"`
+	AuthorId    string     `json:"author_id"`
+	CommentText string     `json:"comment_text"`
+	IsResolved  bool       `json:"is_resolved"`
+	ResolvedBy  *string    `json:"resolved_by"`
+	ResolvedAt  *time.Time `json:"resolved_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// NewExamReviewCommentData is a struct that represents the data needed to
+// add a review comment to an exam.
+type NewExamReviewCommentData struct {
+	ExamId      int    `json:"exam_id"`
+	QuestionId  *int   `json:"question_id"`
+	AuthorId    string `json:"author_id"`
+	CommentText string `json:"comment_text"`
+}
+
+// ChangeExamReviewStatusData is a struct that represents the data needed to
+// move an exam from a review status to another one.
+type ChangeExamReviewStatusData struct {
+	ExamId         int    `json:"exam_id"`
+	ExpectedStatus string `json:"expected_status"`
+	NewStatus      string `json:"new_status"`
+	ChangedBy      string `json:"changed_by"`
+}
@@ -0,0 +1,67 @@
+package database
+
+import "time"
+
+// ExamSection is a struct that represents a section (part) of an exam,
+// such as the Listening part of a language exam.
+type ExamSection struct {
+	SectionId    int    `json:"section_id"`
+	ExamId       int    `json:"exam_id"`
+	SectionTitle string `json:"section_title"`
+	SectionOrder int    `json:"section_order"`
+
+	// Duration is the time limit (in minutes) of the section; nil means
+	// only the deadline of the exam applies.
+	Duration *int `json:"duration"`
+
+	// IsForwardOnly is true if the section gets locked once the user
+	// moves past it, so they cannot go back to it.
+	IsForwardOnly bool      `json:"is_forward_only"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type NewExamSectionData struct {
+	ExamId        int    `json:"exam_id"`
+	SectionTitle  string `json:"section_title"`
+	SectionOrder  int    `json:"section_order"`
+	Duration      *int   `json:"duration"`
+	IsForwardOnly bool   `json:"is_forward_only"`
+}
+
+type EditExamSectionData struct {
+	SectionId     int    `json:"section_id"`
+	SectionTitle  string `json:"section_title"`
+	SectionOrder  int    `json:"section_order"`
+	Duration      *int   `json:"duration"`
+	IsForwardOnly bool   `json:"is_forward_only"`
+}
+
+// ExamAttemptSection is a struct that represents the progress of an
+// attempt in a section of the exam.
+type ExamAttemptSection struct {
+	ExamId        int       `json:"exam_id"`
+	UserId        string    `json:"user_id"`
+	AttemptNumber int       `json:"attempt_number"`
+	SectionId     int       `json:"section_id"`
+	StartedAt     time.Time `json:"started_at"`
+
+	// FinishedAt is when the user moved past the section (or when its
+	// time ran out); nil means the user is still in the section.
+	FinishedAt *time.Time `json:"finished_at"`
+}
+
+// ExamSectionProgress is a struct that represents a section of the exam
+// alongside the state of an attempt in it.
+type ExamSectionProgress struct {
+	Section *ExamSection
+
+	// Progress is nil if the user has not entered the section yet.
+	Progress *ExamAttemptSection
+
+	// Status is one of the SectionStatus* constants.
+	Status string
+
+	// Deadline is the time the section closes for the user; it's nil if
+	// the section has not been entered yet or has no time limit.
+	Deadline *time.Time
+}
@@ -0,0 +1,58 @@
+package database
+
+import "time"
+
+// ExamSeries is a struct that represents an exam that is recreated
+// periodically (based on a recurrence rule) from a template exam.
+type ExamSeries struct {
+	SeriesId        int        `json:"series_id"`
+	TemplateExamId  int        `json:"template_exam_id"`
+	SeriesTitle     string     `json:"series_title"`
+	RecurrenceRule  string     `json:"recurrence_rule"`
+	StartsAt        time.Time  `json:"starts_at"`
+	GenerateAhead   int        `json:"generate_ahead"`
+	IsActive        bool       `json:"is_active"`
+	LastGeneratedAt *time.Time `json:"last_generated_at"`
+	CreatedBy       string     `json:"created_by"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+// ExamSeriesOccurrence is a struct that represents an occurrence of a series
+// that has been materialised, edited or skipped.
+type ExamSeriesOccurrence struct {
+	SeriesId     int        `json:"series_id"`
+	OccurrenceAt time.Time  `json:"occurrence_at"`
+	ExamId       *int       `json:"exam_id"`
+	IsSkipped    bool       `json:"is_skipped"`
+	ExamTitle    *string    `json:"exam_title"`
+	ExamDate     *time.Time `json:"exam_date"`
+	Duration     *int       `json:"duration"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+type NewExamSeriesData struct {
+	TemplateExamId int       `json:"template_exam_id"`
+	SeriesTitle    string    `json:"series_title"`
+	RecurrenceRule string    `json:"recurrence_rule"`
+	StartsAt       time.Time `json:"starts_at"`
+	GenerateAhead  int       `json:"generate_ahead"`
+	CreatedBy      string    `json:"created_by"`
+}
+
+type EditExamSeriesData struct {
+	SeriesId       int    `json:"series_id"`
+	SeriesTitle    string `json:"series_title"`
+	RecurrenceRule string `json:"recurrence_rule"`
+	GenerateAhead  int    `json:"generate_ahead"`
+	IsActive       bool   `json:"is_active"`
+}
+
+// EditExamSeriesOccurrenceData is a struct that represents the data needed
+// to override an occurrence of a series; nil fields mean no override.
+type EditExamSeriesOccurrenceData struct {
+	SeriesId     int        `json:"series_id"`
+	OccurrenceAt time.Time  `json:"occurrence_at"`
+	ExamTitle    *string    `json:"exam_title"`
+	ExamDate     *time.Time `json:"exam_date"`
+	Duration     *int       `json:"duration"`
+}
@@ -0,0 +1,57 @@
+package database
+
+import "time"
+
+// SimilarityCheck is a struct that represents a similarity (plagiarism)
+// check of the text answers given to a question.
+type SimilarityCheck struct {
+	CheckId     int     `json:"check_id"`
+	ExamId      int     `json:"exam_id"`
+	QuestionId  int     `json:"question_id"`
+	RequestedBy *string `json:"requested_by"`
+
+	// CheckStatus is one of the SimilarityCheckStatus* constants.
+	CheckStatus string  `json:"check_status"`
+	ShingleSize int     `json:"shingle_size"`
+	Threshold   float64 `json:"threshold"`
+
+	AnswersCount int        `json:"answers_count"`
+	MatchesCount int        `json:"matches_count"`
+	ErrorMessage *string    `json:"error_message"`
+	StartedAt    time.Time  `json:"started_at"`
+	FinishedAt   *time.Time `json:"finished_at"`
+}
+
+// SimilarityMatch is a struct that represents a pair of answers reported
+// by a similarity check.
+type SimilarityMatch struct {
+	MatchId       int                  `json:"match_id"`
+	CheckId       int                  `json:"check_id"`
+	FirstUserId   string               `json:"first_user_id"`
+	FirstAttempt  int                  `json:"first_attempt"`
+	SecondUserId  string               `json:"second_user_id"`
+	SecondAttempt int                  `json:"second_attempt"`
+	Similarity    float64              `json:"similarity"`
+	Containment   float64              `json:"containment"`
+	Passages      []*SimilarityPassage `json:"passages"`
+}
+
+// SimilarityPassage is a passage found in both of the answers of a match;
+// the offsets are byte offsets in the texts of the answers, and the texts
+// are kept so the passages can be shown even if the answers change.
+type SimilarityPassage struct {
+	FirstStart  int    `json:"first_start"`
+	FirstEnd    int    `json:"first_end"`
+	SecondStart int    `json:"second_start"`
+	SecondEnd   int    `json:"second_end"`
+	FirstText   string `json:"first_text"`
+	SecondText  string `json:"second_text"`
+}
+
+type NewSimilarityCheckData struct {
+	ExamId      int     `json:"exam_id"`
+	QuestionId  int     `json:"question_id"`
+	RequestedBy string  `json:"requested_by"`
+	ShingleSize int     `json:"shingle_size"`
+	Threshold   float64 `json:"threshold"`
+}
@@ -45,6 +45,10 @@ type UserInfo struct {
 	// SetupCompleted is true if the user has completed the account
 	// setup process.
 	SetupCompleted bool
+
+	// IsExamReviewer is true if the user (a teacher) can review the exams
+	// of the other teachers.
+	IsExamReviewer bool `json:"is_exam_reviewer"`
 }
 
 // NewUserData is used to create a new user.
@@ -80,6 +84,11 @@ type BanUserData struct {
 	BanReason *string `json:"ban_reason"`
 }
 
+type SetExamReviewerData struct {
+	UserId         string `json:"user_id"`
+	IsExamReviewer bool   `json:"is_exam_reviewer"`
+}
+
 type UpdateUserPasswordData struct {
 	UserId      string `json:"user_id"`
 	RawPassword string `json:"password"`
@@ -0,0 +1,27 @@
+package database
+
+import "time"
+
+// ExamWaitlistEntry is a struct that represents a user waiting for a seat
+// in a full exam.
+type ExamWaitlistEntry struct {
+	WaitlistId int     `json:"waitlist_id"`
+	ExamId     int     `json:"exam_id"`
+	UserId     string  `json:"user_id"`
+	AddedBy    *string `json:"added_by"`
+
+	// CouponId is the coupon the user wanted to use; it's applied once
+	// they get a seat (if it can still be used by then).
+	CouponId  *int      `json:"coupon_id"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// Position is the 1-based position of the user in the waitlist.
+	Position int `json:"position"`
+}
+
+type NewExamWaitlistEntryData struct {
+	ExamId  int         `json:"exam_id"`
+	UserId  string      `json:"user_id"`
+	AddedBy *string     `json:"added_by"`
+	Coupon  *ExamCoupon `json:"coupon"`
+}
@@ -0,0 +1,59 @@
+package database
+
+import "time"
+
+// Wallet is a struct that represents the wallet of a user; all amounts
+// are in minor units (see paymentUtils.AmountScale).
+type Wallet struct {
+	UserId    string     `json:"user_id"`
+	Balance   int64      `json:"balance"`
+	Currency  string     `json:"currency"`
+	UpdatedAt *time.Time `json:"updated_at"`
+}
+
+// WalletTransaction is a struct that represents a money movement of a
+// wallet (top-up, exam payment or refund).
+type WalletTransaction struct {
+	TransactionId     int64     `json:"transaction_id"`
+	TransactionType   string    `json:"transaction_type"`
+	UserId            string    `json:"user_id"`
+	ExamId            *int      `json:"exam_id"`
+	Amount            int64     `json:"amount"`
+	Currency          string    `json:"currency"`
+	Provider          *string   `json:"provider"`
+	ProviderReference *string   `json:"provider_reference"`
+	RefundOf          *int64    `json:"refund_of"`
+	Description       *string   `json:"description"`
+	CreatedBy         *string   `json:"created_by"`
+	CreatedAt         time.Time `json:"created_at"`
+
+	// RefundedAmount is the sum of the refunds of this transaction.
+	RefundedAmount int64 `json:"refunded_amount"`
+}
+
+type NewTopUpData struct {
+	UserId            string  `json:"user_id"`
+	Amount            int64   `json:"amount"`
+	Currency          string  `json:"currency"`
+	Provider          string  `json:"provider"`
+	ProviderReference string  `json:"provider_reference"`
+	Description       *string `json:"description"`
+	CreatedBy         string  `json:"created_by"`
+}
+
+type NewRefundData struct {
+	TransactionId int64 `json:"transaction_id"`
+	Amount        int64 `json:"amount"`
+
+	// ProviderReference is the reference of the refund in the payment
+	// provider (only for refunding top-ups).
+	ProviderReference *string `json:"provider_reference"`
+	Description       *string `json:"description"`
+	CreatedBy         string  `json:"created_by"`
+}
+
+type GetWalletTransactionsData struct {
+	UserId string `json:"user_id"`
+	Offset int    `json:"offset"`
+	Limit  int    `json:"limit"`
+}
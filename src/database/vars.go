@@ -16,4 +16,30 @@ var Migrations = [...]MigrationFunc{
 	migrateV2,
 	migrateV3,
 	migrateV4,
+	migrateV5,
+	migrateV6,
+	migrateV7,
+	migrateV8,
+	migrateV9,
+	migrateV10,
+	migrateV11,
+	migrateV12,
+	migrateV13,
+	migrateV14,
+	migrateV15,
+	migrateV16,
+	migrateV17,
+	migrateV18,
+	migrateV19,
+	migrateV20,
+	migrateV21,
+	migrateV22,
+	migrateV23,
+	migrateV24,
+	migrateV25,
+	migrateV26,
+	migrateV27,
+	migrateV28,
+	migrateV29,
+	migrateV30,
 }
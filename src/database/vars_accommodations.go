@@ -0,0 +1,22 @@
+package database
+
+import (
+	"time"
+
+	"github.com/ALiwoto/ssg/ssg"
+)
+
+var (
+	examAccommodationsMap = func() *ssg.SafeEMap[string, ExamAccommodation] {
+		m := ssg.NewSafeEMap[string, ExamAccommodation]()
+		m.SetExpiration(time.Hour * 3)
+		m.SetInterval(time.Hour * 12)
+		m.EnableChecking()
+
+		return m
+	}()
+)
+
+var (
+	valueExamAccommodationNotFound = &ExamAccommodation{}
+)
@@ -0,0 +1,29 @@
+package database
+
+import (
+	"time"
+
+	"github.com/ALiwoto/ssg/ssg"
+)
+
+var (
+	examAttemptBindingsMap = func() *ssg.SafeEMap[string, ExamAttemptBinding] {
+		m := ssg.NewSafeEMap[string, ExamAttemptBinding]()
+		m.SetExpiration(time.Hour * 3)
+		m.SetInterval(time.Hour * 12)
+		m.EnableChecking()
+
+		return m
+	}()
+
+	// latestExamAttemptsMap holds the latest attempt of each user in
+	// each exam.
+	latestExamAttemptsMap = func() *ssg.SafeEMap[string, ExamAttempt] {
+		m := ssg.NewSafeEMap[string, ExamAttempt]()
+		m.SetExpiration(time.Hour * 3)
+		m.SetInterval(time.Hour * 12)
+		m.EnableChecking()
+
+		return m
+	}()
+)
@@ -0,0 +1,22 @@
+package database
+
+import (
+	"time"
+
+	"github.com/ALiwoto/ssg/ssg"
+)
+
+var (
+	examCollaboratorsMap = func() *ssg.SafeEMap[string, ExamCollaborator] {
+		m := ssg.NewSafeEMap[string, ExamCollaborator]()
+		m.SetExpiration(time.Hour * 3)
+		m.SetInterval(time.Hour * 12)
+		m.EnableChecking()
+
+		return m
+	}()
+)
+
+var (
+	valueExamCollaboratorNotFound = &ExamCollaborator{}
+)
@@ -7,3 +7,13 @@ var (
 const (
 	BaseV1Route = "/api/v1"
 )
+
+const (
+	// DefaultBodyLimit is the maximum size of the body of the requests,
+	// except for the ones sent to the upload routes.
+	DefaultBodyLimit = 2 * 1024 * 1024
+
+	// multipartOverhead is the room given to the multipart headers and
+	// fields sent alongside the uploaded files.
+	multipartOverhead = 64 * 1024
+)
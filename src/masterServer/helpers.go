@@ -3,21 +3,28 @@ package masterServer
 import (
 	"ExamSphere/src/apiHandlers"
 	"ExamSphere/src/apiHandlers/captchaHandlers"
+	"ExamSphere/src/apiHandlers/couponHandlers"
 	"ExamSphere/src/apiHandlers/courseHandlers"
 	"ExamSphere/src/apiHandlers/examHandlers"
 	"ExamSphere/src/apiHandlers/sudoHandlers"
 	"ExamSphere/src/apiHandlers/swaggerHandlers"
 	"ExamSphere/src/apiHandlers/topicHandlers"
 	"ExamSphere/src/apiHandlers/userHandlers"
+	"ExamSphere/src/apiHandlers/walletHandlers"
 	"ExamSphere/src/core/appConfig"
 	"ExamSphere/src/core/appValues"
 	"ExamSphere/src/core/utils/emailUtils"
 	"ExamSphere/src/core/utils/logging"
+	"ExamSphere/src/core/utils/paymentUtils"
+	"ExamSphere/src/core/utils/storageUtils"
 	"ExamSphere/src/database"
+	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
 	"github.com/gofiber/swagger"
+	"github.com/valyala/fasthttp"
 )
 
 func RunServer() error {
@@ -31,9 +38,11 @@ func RunServer() error {
 		ProxyHeader:   appConfig.GetIPProxyHeader(),
 		CaseSensitive: CaseSensitive,
 
-		// set body limit to 2MB
-		BodyLimit: 2 * 1024 * 1024,
+		// the upload routes get a larger body limit of their own, as soon
+		// as the headers of their requests are received
+		BodyLimit: DefaultBodyLimit,
 	})
+	appValues.ServerEngine.Server().HeaderReceived = getRequestConfig
 
 	if appConfig.IsDebug() {
 		LoadSwaggerHandler(appValues.ServerEngine)
@@ -48,6 +57,9 @@ func RunServer() error {
 	LoadUIFiles(appValues.ServerEngine)
 
 	LoadEmailClient()
+	LoadPaymentProvider()
+	LoadStorageBackend()
+	LoadExamScheduler()
 
 	if appConfig.TheConfig.CertFile != "" {
 		return appValues.ServerEngine.ListenTLS(
@@ -75,9 +87,11 @@ func LoadHandlersV1(app *fiber.App) {
 	v1.Post("/user/search", authProtection, userHandlers.SearchUserV1)
 	v1.Post("/user/edit", authProtection, userHandlers.EditUserV1)
 	v1.Post("/user/ban", authProtection, userHandlers.BanUserV1)
+	v1.Post("/user/setExamReviewer", authProtection, userHandlers.SetExamReviewerV1)
 	v1.Post("/user/changePassword", authProtection, userHandlers.ChangePasswordV1)
 	v1.Post("/user/confirmChangePassword", userHandlers.ConfirmChangePasswordV1)
 	v1.Post("/user/confirmAccount", userHandlers.ConfirmAccountV1)
+	v1.Post("/user/createFromInvitation", userHandlers.CreateUserFromInvitationV1)
 
 	// captcha handlers
 	v1.Get("/captcha/generate", captchaHandlers.GenerateCaptchaV1)
@@ -98,6 +112,13 @@ func LoadHandlersV1(app *fiber.App) {
 	v1.Post("/course/CreatedCourses", authProtection, courseHandlers.GetCreatedCoursesV1)
 	v1.Post("/course/userCourses", authProtection, courseHandlers.GetUserCoursesV1)
 	v1.Post("/course/courseParticipants", authProtection, courseHandlers.GetCourseParticipantsV1)
+	v1.Post("/course/createGradeCategory", authProtection, courseHandlers.CreateGradeCategoryV1)
+	v1.Post("/course/editGradeCategory", authProtection, courseHandlers.EditGradeCategoryV1)
+	v1.Post("/course/deleteGradeCategory", authProtection, courseHandlers.DeleteGradeCategoryV1)
+	v1.Get("/course/gradeCategories", authProtection, courseHandlers.GetGradeCategoriesV1)
+	v1.Get("/course/gradebook", authProtection, courseHandlers.GetCourseGradebookV1)
+	v1.Get("/course/myGrades", authProtection, courseHandlers.GetMyCourseGradesV1)
+	v1.Get("/course/exportGradebook", authProtection, courseHandlers.ExportCourseGradebookV1)
 
 	// exam handlers
 	v1.Post("/exam/create", authProtection, examHandlers.CreateExamV1)
@@ -114,6 +135,85 @@ func LoadHandlersV1(app *fiber.App) {
 	v1.Post("/exam/givenExam", authProtection, examHandlers.GetGivenExamV1)
 	v1.Get("/exam/userOngoingExams", authProtection, examHandlers.GetUserOngoingExamsV1)
 	v1.Post("/exam/userExamsHistory", authProtection, examHandlers.GetUserExamsHistoryV1)
+	v1.Post("/exam/resetAttemptBinding", authProtection, examHandlers.ResetAttemptBindingV1)
+	v1.Post("/exam/startAttempt", authProtection, examHandlers.StartExamAttemptV1)
+	v1.Post("/exam/setAccessCode", authProtection, examHandlers.SetExamAccessCodeV1)
+	v1.Get("/exam/accessCode", authProtection, examHandlers.GetExamAccessCodeV1)
+	v1.Post("/exam/addPrerequisite", authProtection, examHandlers.AddExamPrerequisiteV1)
+	v1.Post("/exam/removePrerequisite", authProtection, examHandlers.RemoveExamPrerequisiteV1)
+	v1.Get("/exam/prerequisites", authProtection, examHandlers.GetExamPrerequisitesV1)
+	v1.Post("/exam/finishAttempt", authProtection, examHandlers.FinishExamAttemptV1)
+	v1.Get("/exam/attempts", authProtection, examHandlers.GetExamAttemptsV1)
+	v1.Post("/exam/setRetakePolicy", authProtection, examHandlers.SetExamRetakePolicyV1)
+	v1.Post("/exam/setAccommodation", authProtection, examHandlers.SetExamAccommodationV1)
+	v1.Post("/exam/removeAccommodation", authProtection, examHandlers.RemoveExamAccommodationV1)
+	v1.Get("/exam/accommodations", authProtection, examHandlers.GetExamAccommodationsV1)
+	v1.Post("/exam/setAvailability", authProtection, examHandlers.SetExamAvailabilityV1)
+	v1.Post("/exam/createSeries", authProtection, examHandlers.CreateExamSeriesV1)
+	v1.Get("/exam/series", authProtection, examHandlers.GetExamSeriesV1)
+	v1.Post("/exam/editSeries", authProtection, examHandlers.EditExamSeriesV1)
+	v1.Post("/exam/editSeriesOccurrence", authProtection, examHandlers.EditExamSeriesOccurrenceV1)
+	v1.Post("/exam/skipSeriesOccurrence", authProtection, examHandlers.SkipExamSeriesOccurrenceV1)
+	v1.Post("/exam/invite", authProtection, examHandlers.InviteToExamV1)
+	v1.Get("/exam/invitations", authProtection, examHandlers.GetExamInvitationsV1)
+	v1.Get("/exam/invitationInfo", examHandlers.GetExamInvitationInfoV1)
+	v1.Post("/exam/acceptInvitation", authProtection, examHandlers.AcceptExamInvitationV1)
+	v1.Post("/exam/setCapacity", authProtection, examHandlers.SetExamCapacityV1)
+	v1.Post("/exam/withdraw", authProtection, examHandlers.WithdrawFromExamV1)
+	v1.Get("/exam/waitlist", authProtection, examHandlers.GetExamWaitlistV1)
+	v1.Post("/exam/createSection", authProtection, examHandlers.CreateExamSectionV1)
+	v1.Post("/exam/editSection", authProtection, examHandlers.EditExamSectionV1)
+	v1.Post("/exam/deleteSection", authProtection, examHandlers.DeleteExamSectionV1)
+	v1.Get("/exam/sections", authProtection, examHandlers.GetExamSectionsV1)
+	v1.Post("/exam/nextSection", authProtection, examHandlers.NextExamSectionV1)
+	v1.Post("/exam/setAdaptive", authProtection, examHandlers.SetExamAdaptiveV1)
+	v1.Post("/exam/setQuestionIrt", authProtection, examHandlers.SetExamQuestionIrtV1)
+	v1.Post("/exam/nextQuestion", authProtection, examHandlers.NextExamQuestionV1)
+	v1.Post("/exam/uploadAttachment", authProtection, examHandlers.UploadExamAttachmentV1)
+	v1.Get("/exam/attachment", authProtection, examHandlers.GetExamAttachmentV1)
+	v1.Get("/exam/attachments", authProtection, examHandlers.GetExamAttachmentsV1)
+	v1.Post("/exam/deleteAttachment", authProtection, examHandlers.DeleteExamAttachmentV1)
+	v1.Post("/exam/checkSimilarity", authProtection, examHandlers.StartSimilarityCheckV1)
+	v1.Get("/exam/similarityChecks", authProtection, examHandlers.GetSimilarityChecksV1)
+	v1.Get("/exam/similarityCheck", authProtection, examHandlers.GetSimilarityCheckV1)
+	v1.Post("/exam/setQuestionMetadata", authProtection, examHandlers.SetExamQuestionMetadataV1)
+	v1.Post("/exam/searchQuestions", authProtection, examHandlers.SearchExamQuestionsV1)
+	v1.Post("/exam/submitForReview", authProtection, examHandlers.SubmitExamForReviewV1)
+	v1.Post("/exam/review", authProtection, examHandlers.ReviewExamV1)
+	v1.Get("/exam/reviewQueue", authProtection, examHandlers.GetExamReviewQueueV1)
+	v1.Post("/exam/addReviewComment", authProtection, examHandlers.AddExamReviewCommentV1)
+	v1.Post("/exam/resolveReviewComment", authProtection, examHandlers.ResolveExamReviewCommentV1)
+	v1.Get("/exam/reviewComments", authProtection, examHandlers.GetExamReviewCommentsV1)
+	v1.Post("/exam/setCollaborator", authProtection, examHandlers.SetExamCollaboratorV1)
+	v1.Post("/exam/removeCollaborator", authProtection, examHandlers.RemoveExamCollaboratorV1)
+	v1.Get("/exam/collaborators", authProtection, examHandlers.GetExamCollaboratorsV1)
+	v1.Post("/exam/startPreview", authProtection, examHandlers.StartExamPreviewV1)
+	v1.Get("/exam/preview", authProtection, examHandlers.GetExamPreviewV1)
+	v1.Post("/exam/previewAnswer", authProtection, examHandlers.AnswerPreviewQuestionV1)
+	v1.Post("/exam/finishPreview", authProtection, examHandlers.FinishExamPreviewV1)
+	v1.Post("/exam/discardPreview", authProtection, examHandlers.DiscardExamPreviewV1)
+	v1.Get("/exam/previews", authProtection, examHandlers.GetExamPreviewsV1)
+	v1.Post("/exam/setPracticeMode", authProtection, examHandlers.SetExamPracticeModeV1)
+	v1.Post("/exam/setAttemptReviewPolicy", authProtection, examHandlers.SetAttemptReviewPolicyV1)
+	v1.Post("/exam/releaseResults", authProtection, examHandlers.ReleaseExamResultsV1)
+	v1.Post("/exam/gradeAnswer", authProtection, examHandlers.GradeAnswerV1)
+	v1.Get("/exam/attemptReview", authProtection, examHandlers.GetAttemptReviewV1)
+	v1.Post("/exam/setGradeCategory", authProtection, examHandlers.SetExamGradeCategoryV1)
+	v1.Get("/exam/exportParticipants", authProtection, examHandlers.ExportExamParticipantsV1)
+	v1.Get("/exam/resultReport", authProtection, examHandlers.GetResultReportV1)
+
+	// wallet handlers
+	v1.Get("/wallet/info", authProtection, walletHandlers.GetWalletV1)
+	v1.Get("/wallet/transactions", authProtection, walletHandlers.GetWalletTransactionsV1)
+	v1.Post("/wallet/topUp", authProtection, walletHandlers.TopUpWalletV1)
+	v1.Post("/wallet/refund", authProtection, walletHandlers.RefundTransactionV1)
+
+	// coupon handlers
+	v1.Post("/coupon/create", authProtection, couponHandlers.CreateCouponV1)
+	v1.Get("/coupon/info", authProtection, couponHandlers.GetCouponInfoV1)
+	v1.Get("/coupon/list", authProtection, couponHandlers.GetCouponsV1)
+	v1.Post("/coupon/deactivate", authProtection, couponHandlers.DeactivateCouponV1)
+	v1.Post("/coupon/check", authProtection, couponHandlers.CheckCouponV1)
 
 	// sudo handlers
 	v1.Post("/sudo/exit", sudoHandlers.ExitV1)
@@ -169,3 +269,90 @@ func LoadEmailClient() {
 		logging.Warn("Please check the email configuration in the config file.")
 	}
 }
+
+func LoadPaymentProvider() {
+	err := paymentUtils.LoadPaymentProvider()
+	if err != nil {
+		logging.Warn("LoadPaymentProvider: failed to load payment provider: ", err)
+		logging.Warn("Without a payment provider, wallets cannot be topped up.")
+		logging.Warn("Please check the payment_provider in the config file.")
+	}
+}
+
+func LoadStorageBackend() {
+	err := storageUtils.LoadStorageBackend()
+	if err != nil {
+		logging.Warn("LoadStorageBackend: failed to load storage backend: ", err)
+		logging.Warn("Without a storage backend, files cannot be attached to the questions and the answers.")
+		logging.Warn("Please check the storage configuration in the config file.")
+	}
+}
+
+// getRequestConfig returns the config of a request, before its body is
+// read: the requests sent to the upload routes can be as large as the
+// attachments (the rest of them keep the DefaultBodyLimit of the server).
+func getRequestConfig(header *fasthttp.RequestHeader) fasthttp.RequestConfig {
+	path, _, _ := strings.Cut(string(header.RequestURI()), "?")
+	if !isUploadRoute(path) {
+		return fasthttp.RequestConfig{}
+	}
+
+	return fasthttp.RequestConfig{
+		MaxRequestBodySize: getUploadBodyLimit(),
+	}
+}
+
+// getUploadBodyLimit returns the body limit of the upload routes, which has
+// to be large enough for the attachments.
+func getUploadBodyLimit() int {
+	return max(DefaultBodyLimit, int(appConfig.GetMaxAttachmentSize())+multipartOverhead)
+}
+
+// isUploadRoute returns true if the requests sent to the path are allowed
+// to be larger than the default body limit.
+func isUploadRoute(path string) bool {
+	return strings.EqualFold(path, BaseV1Route+"/exam/uploadAttachment")
+}
+
+// LoadExamScheduler starts the scheduler that materialises the upcoming
+// occurrences of the exam series in the background.
+func LoadExamScheduler() {
+	interval := appConfig.GetExamSchedulerInterval()
+	if interval <= 0 {
+		logging.Warn("LoadExamScheduler: exam scheduler is disabled")
+		return
+	}
+
+	go func() {
+		for {
+			runExamScheduler()
+			time.Sleep(interval)
+		}
+	}()
+}
+
+// runExamScheduler materialises the due occurrences of all active exam series.
+func runExamScheduler() {
+	defer func() {
+		if r := recover(); r != nil {
+			logging.UnexpectedPanic("runExamScheduler:", r)
+		}
+	}()
+
+	allSeries, err := database.GetActiveExamSeries()
+	if err != nil {
+		logging.UnexpectedError("runExamScheduler: failed to get exam series:", err)
+		return
+	}
+
+	now := time.Now()
+	for _, series := range allSeries {
+		examIds, err := database.GenerateExamSeriesOccurrences(series, now)
+		if err != nil {
+			logging.UnexpectedError("runExamScheduler: failed to generate occurrences of series", series.SeriesId, ":", err)
+			continue
+		} else if len(examIds) > 0 {
+			logging.Infof("runExamScheduler: materialised %d exam(s) of series %d", len(examIds), series.SeriesId)
+		}
+	}
+}